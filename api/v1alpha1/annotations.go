@@ -10,12 +10,21 @@ const (
 	// Annotations like "kausality.io/trace-ticket" become Labels["ticket"] in the trace.
 	TraceMetadataPrefix = "kausality.io/trace-"
 
+	// TraceParentAnnotation stores the trace as a W3C traceparent, if enabled.
+	// Value: "00-<trace-id>-<span-id>-01", see Trace.ToTraceParent.
+	TraceParentAnnotation = "kausality.io/traceparent"
+
 	// ControllersAnnotation stores hashes of users who update parent status.
-	// Value: comma-separated 5-char base36 hashes (max 5).
+	// Value: comma-separated user hashes (max 5), 5-char base36 by default.
 	ControllersAnnotation = "kausality.io/controllers"
 
+	// ControllerServiceAccountsAnnotation stores the service accounts of users who update
+	// parent status, so that a controller is recognized whatever field manager it uses.
+	// Value: comma-separated "<namespace>/<name>" (max 5).
+	ControllerServiceAccountsAnnotation = "kausality.io/controllers-sa"
+
 	// UpdatersAnnotation stores hashes of users who update child spec.
-	// Value: comma-separated 5-char base36 hashes (max 5).
+	// Value: comma-separated user hashes (max 5), 5-char base36 by default.
 	UpdatersAnnotation = "kausality.io/updaters"
 
 	// PhaseAnnotation stores the lifecycle phase of a parent resource.
@@ -26,6 +35,10 @@ const (
 	// Value: JSON array of Approval objects.
 	ApprovalsAnnotation = "kausality.io/approvals"
 
+	// ApprovalHistoryAnnotation records consumed mode=once approvals, if enabled.
+	// Value: JSON array of ApprovalHistoryEntry objects, oldest first. Bounded to the most recent entries.
+	ApprovalHistoryAnnotation = "kausality.io/approval-history"
+
 	// RejectionsAnnotation stores rejected child mutations.
 	// Value: JSON array of Rejection objects.
 	RejectionsAnnotation = "kausality.io/rejections"
@@ -37,8 +50,92 @@ const (
 	// SnoozeAnnotation indicates drift callbacks are temporarily suppressed.
 	// Value: JSON Snooze object, or legacy RFC3339 timestamp.
 	SnoozeAnnotation = "kausality.io/snooze"
+
+	// BreakGlassAnnotation carries a signed emergency token that bypasses freeze and enforcement.
+	// Value: "<base64url payload>.<base64url ed25519 signature>". Removed once the token is used.
+	BreakGlassAnnotation = "kausality.io/break-glass"
+
+	// BreakGlassAuditAnnotation records the last break-glass use on an object.
+	// Value: JSON audit record with token issuer, reason and request user.
+	BreakGlassAuditAnnotation = "kausality.io/break-glass-audit"
+
+	// DriftFirstSeenAnnotation records when drifts on a parent's children were first detected.
+	// Value: JSON object mapping drift ID to RFC3339 timestamp. Bounded to the most recent entries.
+	DriftFirstSeenAnnotation = "kausality.io/drift-first-seen"
+
+	// ParentAnnotation links a child without a controller ownerReference to its parent.
+	// Value: "<apiVersion>/<kind>/<namespace>/<name>", namespace empty for cluster-scoped parents.
+	ParentAnnotation = "kausality.io/parent"
+
+	// SyntheticDriftAnnotation injects a synthetic drift to test enforcement and callbacks.
+	// Only honored on server-side dry-run requests, so nothing is persisted. Value: "true".
+	SyntheticDriftAnnotation = "kausality.io/synthetic-drift"
 )
 
+// DefaultAnnotationPrefix is the prefix of the annotation keys above.
+const DefaultAnnotationPrefix = "kausality.io/"
+
+// AnnotationKeys holds the kausality annotation keys under one prefix. The constants
+// above are the keys under DefaultAnnotationPrefix; another prefix lets several
+// kausality instances, e.g. with staging and production policies, run side by side
+// on the same objects.
+type AnnotationKeys struct {
+	// Prefix is the prefix of all keys, ending in "/".
+	Prefix string
+
+	Trace                     string
+	TraceMetadataPrefix       string
+	TraceParent               string
+	Controllers               string
+	ControllerServiceAccounts string
+	Updaters                  string
+	Phase                     string
+	Approvals                 string
+	ApprovalHistory           string
+	Rejections                string
+	Freeze                    string
+	Snooze                    string
+	BreakGlass                string
+	BreakGlassAudit           string
+	DriftFirstSeen            string
+	Parent                    string
+	SyntheticDrift            string
+	// Mode is the key of the mode annotation on objects and namespaces.
+	Mode string
+}
+
+// NewAnnotationKeys returns the annotation keys under prefix, e.g. "staging.kausality.io/".
+// An empty prefix means DefaultAnnotationPrefix.
+func NewAnnotationKeys(prefix string) AnnotationKeys {
+	if prefix == "" {
+		prefix = DefaultAnnotationPrefix
+	}
+	return AnnotationKeys{
+		Prefix:                    prefix,
+		Trace:                     prefix + "trace",
+		TraceMetadataPrefix:       prefix + "trace-",
+		TraceParent:               prefix + "traceparent",
+		Controllers:               prefix + "controllers",
+		ControllerServiceAccounts: prefix + "controllers-sa",
+		Updaters:                  prefix + "updaters",
+		Phase:                     prefix + "phase",
+		Approvals:                 prefix + "approvals",
+		ApprovalHistory:           prefix + "approval-history",
+		Rejections:                prefix + "rejections",
+		Freeze:                    prefix + "freeze",
+		Snooze:                    prefix + "snooze",
+		BreakGlass:                prefix + "break-glass",
+		BreakGlassAudit:           prefix + "break-glass-audit",
+		DriftFirstSeen:            prefix + "drift-first-seen",
+		Parent:                    prefix + "parent",
+		SyntheticDrift:            prefix + "synthetic-drift",
+		Mode:                      prefix + "mode",
+	}
+}
+
+// DefaultAnnotationKeys are the annotation keys under DefaultAnnotationPrefix.
+var DefaultAnnotationKeys = NewAnnotationKeys(DefaultAnnotationPrefix)
+
 // Phase values for the PhaseAnnotation.
 const (
 	PhaseValueInitializing = "initializing"
@@ -58,4 +155,20 @@ const (
 
 	// ApprovalModeAlways is permanent and never auto-pruned.
 	ApprovalModeAlways = "always"
+
+	// ApprovalModeRange is valid while approval.generationMin <= parent.generation <=
+	// approval.generationMax, e.g. during a rollout that bumps the generation several times.
+	ApprovalModeRange = "range"
+)
+
+// Rejection severities for the Rejection.Severity field.
+const (
+	// RejectionSeverityInfo is informational.
+	RejectionSeverityInfo = "info"
+
+	// RejectionSeverityWarn needs attention.
+	RejectionSeverityWarn = "warn"
+
+	// RejectionSeverityCritical needs immediate attention.
+	RejectionSeverityCritical = "critical"
 )
@@ -3,9 +3,11 @@ package v1alpha1
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 // Approval represents an approval for a child resource mutation.
@@ -15,14 +17,56 @@ type Approval struct {
 	APIVersion string `json:"apiVersion"`
 	// Kind of the approved child resource.
 	Kind string `json:"kind"`
-	// Name of the approved child resource.
-	Name string `json:"name"`
+	// Name of the approved child resource. Required unless Selector is set.
+	Name string `json:"name,omitempty"`
+	// Selector approves children whose labels match, e.g. dynamically named
+	// ReplicaSets. If Name is set too, both must match.
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
 	// Generation is the parent generation this approval is valid for.
-	// Required for ModeOnce and ModeGeneration, ignored for ModeAlways.
+	// Required for ModeOnce and ModeGeneration, ignored for ModeAlways and ModeRange.
 	Generation int64 `json:"generation,omitempty"`
+	// GenerationMin and GenerationMax bound the parent generations a ModeRange
+	// approval is valid for, inclusively. GenerationMax is required for ModeRange.
+	GenerationMin int64 `json:"generationMin,omitempty"`
+	GenerationMax int64 `json:"generationMax,omitempty"`
 	// Mode determines approval validity and pruning behavior.
-	// One of: once, generation, always. Defaults to "once".
+	// One of: once, generation, range, always. Defaults to "once". Without a Name,
+	// selector approvals are never consumed, so "once" behaves like "always".
 	Mode string `json:"mode,omitempty"`
+	// Note explains why the approval was granted, e.g. "approved per CHG-1234".
+	// Optional, for audit trails.
+	Note string `json:"note,omitempty"`
+	// Fields scopes the approval to changes of these spec fields, as JSON Pointers
+	// (e.g. "/spec/replicas"). A pointer covers the fields below it. If empty, the
+	// approval covers any change of the child.
+	Fields []string `json:"fields,omitempty"`
+	// ExpiresAt bounds the approval in time, in any mode. Expiry is evaluated at
+	// admission time against the webhook's clock. Optional.
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+	// Recursive extends the approval to the whole subtree below the matched child:
+	// drift of any descendant reachable through the ownership chain is approved, with
+	// generations compared against the object holding the approval. Recursive approvals
+	// are never consumed, so "once" behaves as "generation".
+	Recursive bool `json:"recursive,omitempty"`
+}
+
+// ApprovalHistoryEntry records a consumed mode=once approval.
+// Stored in parent's kausality.io/approval-history annotation.
+type ApprovalHistoryEntry struct {
+	// APIVersion of the child the approval was consumed by.
+	APIVersion string `json:"apiVersion"`
+	// Kind of the child the approval was consumed by.
+	Kind string `json:"kind"`
+	// Name of the child the approval was consumed by.
+	Name string `json:"name"`
+	// Generation is the parent generation the approval was consumed at.
+	Generation int64 `json:"generation"`
+	// User whose mutation consumed the approval.
+	User string `json:"user,omitempty"`
+	// Note of the consumed approval.
+	Note string `json:"note,omitempty"`
+	// At is when the approval was consumed.
+	At metav1.Time `json:"at"`
 }
 
 // Rejection represents a rejection for a child resource mutation.
@@ -32,20 +76,29 @@ type Rejection struct {
 	APIVersion string `json:"apiVersion"`
 	// Kind of the rejected child resource.
 	Kind string `json:"kind"`
-	// Name of the rejected child resource.
-	Name string `json:"name"`
+	// Name of the rejected child resource. Required unless Selector is set.
+	Name string `json:"name,omitempty"`
+	// Selector rejects children whose labels match. If Name is set too, both
+	// must match.
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
 	// Generation is the parent generation this rejection applies to.
 	// If set, only rejects when parent.generation == rejection.generation.
 	Generation int64 `json:"generation,omitempty"`
 	// Reason explains why the mutation is rejected.
 	Reason string `json:"reason"`
+	// Severity is info, warn or critical. Optional.
+	Severity string `json:"severity,omitempty"`
+	// Remediation tells the writer how to proceed, e.g. which approval to ask for. Optional.
+	Remediation string `json:"remediation,omitempty"`
 }
 
 // ChildRef identifies a child resource being mutated.
 type ChildRef struct {
-	APIVersion string
-	Kind       string
-	Name       string
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name,omitempty"`
+	// Labels of the child, matched against selectors.
+	Labels map[string]string `json:"labels,omitempty"`
 }
 
 // Freeze represents a freeze lockdown on a parent resource.
@@ -58,6 +111,8 @@ type Freeze struct {
 	Message string `json:"message,omitempty"`
 	// At is when the freeze was applied.
 	At metav1.Time `json:"at,omitempty"`
+	// Until is when the freeze expires. A freeze without it lasts until removed.
+	Until *metav1.Time `json:"until,omitempty"`
 }
 
 // Snooze represents a snooze period on a parent resource.
@@ -70,14 +125,40 @@ type Snooze struct {
 	User string `json:"user,omitempty"`
 	// Message explaining why the snooze was applied.
 	Message string `json:"message,omitempty"`
+	// Child scopes the snooze to one child. Matched like approvals, with wildcards;
+	// labels match like a selector's matchLabels. Optional.
+	Child *ChildRef `json:"child,omitempty"`
+	// Children scopes the snooze to several children, like Child. If neither is set,
+	// the snooze covers all children of the parent.
+	Children []ChildRef `json:"children,omitempty"`
 }
 
-// matchChild checks if apiVersion/kind/name match the child.
-// Supports wildcards: "*" matches any value.
-func matchChild(apiVersion, kind, name string, child ChildRef) bool {
-	return matchField(apiVersion, child.APIVersion) &&
-		matchField(kind, child.Kind) &&
-		matchField(name, child.Name)
+// matchChild checks if apiVersion/kind/name and selector match the child.
+// Supports wildcards: "*" matches any value, and apiVersion supports group globs.
+// An empty name with a selector matches any name. Invalid selectors match nothing.
+func matchChild(apiVersion, kind, name string, selector *metav1.LabelSelector, child ChildRef) bool {
+	if !matchAPIVersion(apiVersion, child.APIVersion) ||
+		!matchField(kind, child.Kind) ||
+		!matchField(namePattern(name, selector), child.Name) {
+		return false
+	}
+	if selector == nil {
+		return true
+	}
+	sel, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return false
+	}
+	return sel.Matches(labels.Set(child.Labels))
+}
+
+// namePattern returns the name pattern of an entry: an empty name with a selector
+// matches any name.
+func namePattern(name string, selector *metav1.LabelSelector) string {
+	if name == "" && selector != nil {
+		return "*"
+	}
+	return name
 }
 
 // matchField checks if a pattern matches a value.
@@ -89,33 +170,163 @@ func matchField(pattern, value string) bool {
 	return pattern == value
 }
 
+// matchAPIVersion checks if an apiVersion pattern matches a value. Besides "*", the
+// group may be a glob with a leading or trailing "*", e.g. "*.aws.crossplane.io/v1beta1",
+// and the version may be "*". Group globs never match the core group.
+func matchAPIVersion(pattern, value string) bool {
+	if pattern == "*" || pattern == value {
+		return true
+	}
+	patternGroup, patternVersion := splitAPIVersion(pattern)
+	group, version := splitAPIVersion(value)
+	return matchGroup(patternGroup, group) && matchField(patternVersion, version)
+}
+
+// matchGroup checks if a group pattern matches a group.
+func matchGroup(pattern, group string) bool {
+	switch {
+	case pattern == "*" || pattern == group:
+		return true
+	case group == "":
+		return false
+	case strings.HasPrefix(pattern, "*"):
+		return strings.HasSuffix(group, pattern[1:])
+	case strings.HasSuffix(pattern, "*"):
+		return strings.HasPrefix(group, pattern[:len(pattern)-1])
+	default:
+		return false
+	}
+}
+
+// splitAPIVersion splits an apiVersion into group and version. The core group is "".
+func splitAPIVersion(apiVersion string) (group, version string) {
+	if i := strings.LastIndex(apiVersion, "/"); i >= 0 {
+		return apiVersion[:i], apiVersion[i+1:]
+	}
+	return "", apiVersion
+}
+
+// specificity ranks how narrowly apiVersion/kind/name and selector select children.
+// An exact name outweighs a selector, which outweighs an exact kind, which outweighs
+// an exact apiVersion; a group glob ranks between an exact apiVersion and "*".
+func specificity(apiVersion, kind, name string, selector *metav1.LabelSelector) int {
+	score := 0
+	if namePattern(name, selector) != "*" {
+		score += 12
+	}
+	if selector != nil {
+		score += 6
+	}
+	if kind != "*" {
+		score += 3
+	}
+	switch {
+	case !strings.Contains(apiVersion, "*"):
+		score += 2
+	case apiVersion != "*":
+		score++
+	}
+	return score
+}
+
+// hasWildcard checks if any of apiVersion/kind/name is a wildcard or glob, or the
+// name is left to a selector.
+func hasWildcard(apiVersion, kind, name string, selector *metav1.LabelSelector) bool {
+	return strings.Contains(apiVersion, "*") || kind == "*" || namePattern(name, selector) == "*"
+}
+
 // Matches checks if this approval matches the given child.
-// Supports wildcards: "*" matches any value for apiVersion, kind, or name.
+// Supports wildcards: "*" matches any value for apiVersion, kind, or name, and the
+// group of apiVersion may be a glob like "*.aws.crossplane.io". A selector must
+// match the child's labels.
 func (a *Approval) Matches(child ChildRef) bool {
-	return matchChild(a.APIVersion, a.Kind, a.Name, child)
+	return matchChild(a.APIVersion, a.Kind, a.Name, a.Selector, child)
 }
 
-// IsValid checks if this approval is valid for the given parent generation.
-func (a *Approval) IsValid(parentGeneration int64) bool {
+// Specificity ranks how narrowly this approval selects children. When several
+// approvals match a child, the most specific one is used.
+func (a *Approval) Specificity() int {
+	return specificity(a.APIVersion, a.Kind, a.Name, a.Selector)
+}
+
+// HasWildcard checks if this approval uses a wildcard or glob, i.e. may match more
+// than one child.
+func (a *Approval) HasWildcard() bool {
+	return hasWildcard(a.APIVersion, a.Kind, a.Name, a.Selector)
+}
+
+// EffectiveMode returns the mode the approval behaves in. The mode defaults to
+// "once"; "once" approvals selecting children without a Name behave as "always",
+// as there is no single child whose mutation would consume them, and recursive
+// "once" approvals behave as "generation", as they cover many descendants.
+func (a *Approval) EffectiveMode() string {
 	mode := a.Mode
 	if mode == "" {
-		mode = ApprovalModeOnce // Default
+		mode = ApprovalModeOnce
+	}
+	if mode == ApprovalModeOnce && a.Selector != nil && namePattern(a.Name, a.Selector) == "*" {
+		return ApprovalModeAlways
+	}
+	if mode == ApprovalModeOnce && a.Recursive {
+		return ApprovalModeGeneration
+	}
+	return mode
+}
+
+// IsExpired checks if the approval has an expiry that has passed.
+func (a *Approval) IsExpired() bool {
+	return a.ExpiresAt != nil && !time.Now().UTC().Before(a.ExpiresAt.UTC())
+}
+
+// Covers checks if this approval covers a change of the given fields, as JSON Pointers.
+// Unscoped approvals cover any change. Scoped approvals cover only a known, non-empty
+// set of changed fields each equal to or below one of Fields; nil means unknown.
+func (a *Approval) Covers(changedFields []string) bool {
+	if len(a.Fields) == 0 {
+		return true
+	}
+	if len(changedFields) == 0 {
+		return false
 	}
+	for _, changed := range changedFields {
+		covered := false
+		for _, f := range a.Fields {
+			if changed == f || strings.HasPrefix(changed, strings.TrimSuffix(f, "/")+"/") {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
+	return true
+}
 
-	switch mode {
+// IsValid checks if this approval is valid for the given parent generation.
+func (a *Approval) IsValid(parentGeneration int64) bool {
+	switch a.EffectiveMode() {
 	case ApprovalModeAlways:
 		return true
 	case ApprovalModeOnce, ApprovalModeGeneration:
 		return a.Generation == parentGeneration
+	case ApprovalModeRange:
+		return a.GenerationMin <= parentGeneration && parentGeneration <= a.GenerationMax
 	default:
 		return false
 	}
 }
 
-// Matches checks if this rejection matches the given child.
-// Supports wildcards: "*" matches any value for apiVersion, kind, or name.
+// Matches checks if this rejection matches the given child, with the same wildcards
+// and globs as Approval.Matches.
 func (r *Rejection) Matches(child ChildRef) bool {
-	return matchChild(r.APIVersion, r.Kind, r.Name, child)
+	return matchChild(r.APIVersion, r.Kind, r.Name, r.Selector, child)
+}
+
+// Specificity ranks how narrowly this rejection selects children, like
+// Approval.Specificity.
+func (r *Rejection) Specificity() int {
+	return specificity(r.APIVersion, r.Kind, r.Name, r.Selector)
 }
 
 // IsActive checks if this rejection is active for the given parent generation.
@@ -137,6 +348,11 @@ func ParseApprovals(annotationValue string) ([]Approval, error) {
 	if err := json.Unmarshal([]byte(annotationValue), &approvals); err != nil {
 		return nil, fmt.Errorf("invalid approvals annotation: %w", err)
 	}
+	for i, a := range approvals {
+		if a.Mode == ApprovalModeRange && a.GenerationMin > a.GenerationMax {
+			return nil, fmt.Errorf("invalid approvals annotation: approval %d: generationMin %d exceeds generationMax %d", i, a.GenerationMin, a.GenerationMax)
+		}
+	}
 	return approvals, nil
 }
 
@@ -165,6 +381,33 @@ func MarshalApprovals(approvals []Approval) (string, error) {
 	return string(data), nil
 }
 
+// ParseApprovalHistory parses the approval history annotation value.
+func ParseApprovalHistory(annotationValue string) ([]ApprovalHistoryEntry, error) {
+	if annotationValue == "" {
+		return nil, nil
+	}
+
+	var history []ApprovalHistoryEntry
+	if err := json.Unmarshal([]byte(annotationValue), &history); err != nil {
+		return nil, fmt.Errorf("invalid approval history annotation: %w", err)
+	}
+	return history, nil
+}
+
+// AppendApprovalHistory appends an entry to the approval history and marshals it
+// to JSON for annotation, dropping the oldest entries beyond maxEntries.
+func AppendApprovalHistory(history []ApprovalHistoryEntry, entry ApprovalHistoryEntry, maxEntries int) (string, error) {
+	history = append(history, entry)
+	if len(history) > maxEntries {
+		history = history[len(history)-maxEntries:]
+	}
+	data, err := json.Marshal(history)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
 // ParseFreeze parses the freeze annotation value.
 // Returns nil if the annotation is empty or not set.
 func ParseFreeze(annotationValue string) (*Freeze, error) {
@@ -196,6 +439,14 @@ func MarshalFreeze(freeze *Freeze) (string, error) {
 	return string(data), nil
 }
 
+// IsActive checks if the freeze is still active (not expired).
+func (f *Freeze) IsActive() bool {
+	if f == nil {
+		return false
+	}
+	return f.Until == nil || time.Now().Before(f.Until.Time)
+}
+
 // String returns a human-readable description of the freeze.
 func (f *Freeze) String() string {
 	if f == nil {
@@ -208,6 +459,9 @@ func (f *Freeze) String() string {
 	if !f.At.IsZero() {
 		msg += fmt.Sprintf(" (since %s)", f.At.Format(time.RFC3339))
 	}
+	if f.Until != nil {
+		msg += fmt.Sprintf(" (until %s)", f.Until.Format(time.RFC3339))
+	}
 	return msg
 }
 
@@ -250,12 +504,52 @@ func (s *Snooze) IsActive() bool {
 	return time.Now().Before(s.Expiry.Time)
 }
 
+// Covers checks if the snooze applies to the child. A snooze without Child and
+// Children covers all children.
+func (s *Snooze) Covers(child ChildRef) bool {
+	if s == nil {
+		return false
+	}
+	if s.Child == nil && len(s.Children) == 0 {
+		return true
+	}
+	if s.Child != nil && s.Child.matches(child) {
+		return true
+	}
+	for i := range s.Children {
+		if s.Children[i].matches(child) {
+			return true
+		}
+	}
+	return false
+}
+
+// matches checks if the snooze entry r matches the child. The entry's labels
+// select children like matchLabels.
+func (r *ChildRef) matches(child ChildRef) bool {
+	var selector *metav1.LabelSelector
+	if len(r.Labels) > 0 {
+		selector = &metav1.LabelSelector{MatchLabels: r.Labels}
+	}
+	return matchChild(r.APIVersion, r.Kind, r.Name, selector, child)
+}
+
 // String returns a human-readable description of the snooze.
 func (s *Snooze) String() string {
 	if s == nil {
 		return ""
 	}
 	msg := fmt.Sprintf("snoozed until %s", s.Expiry.Format(time.RFC3339))
+	var children []string
+	if s.Child != nil {
+		children = append(children, s.Child.Kind+"/"+s.Child.Name)
+	}
+	for _, c := range s.Children {
+		children = append(children, c.Kind+"/"+c.Name)
+	}
+	if len(children) > 0 {
+		msg += " for " + strings.Join(children, ", ")
+	}
 	if s.User != "" {
 		msg += " by " + s.User
 	}
@@ -0,0 +1,69 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BaselineKind identifies a resource kind by API group and kind.
+type BaselineKind struct {
+	// APIGroup of the kind. Empty for the core group.
+	// +optional
+	APIGroup string `json:"apiGroup,omitempty"`
+
+	// Kind of the resource.
+	Kind string `json:"kind"`
+}
+
+// ExpectedChange describes a change to children that controllers of the parent kind
+// make as part of their normal operation, even while the parent is stable.
+type ExpectedChange struct {
+	// Child is the kind of the changed children.
+	Child BaselineKind `json:"child"`
+
+	// FieldManagers that are expected to make the change.
+	// If empty, any field manager is expected.
+	// +optional
+	FieldManagers []string `json:"fieldManagers,omitempty"`
+
+	// Fields are the spec fields expected to change, e.g. "spec.replicas".
+	// A field covers its subfields. A change touching any other field is drift.
+	// +kubebuilder:validation:MinItems=1
+	Fields []string `json:"fields"`
+}
+
+// BaselineSpec describes the expected controller behavior for a parent kind.
+type BaselineSpec struct {
+	// Parent is the kind of parents the baseline applies to.
+	Parent BaselineKind `json:"parent"`
+
+	// Expected lists the changes to children that are not drift.
+	Expected []ExpectedChange `json:"expected"`
+}
+
+// Baseline declares the normal controller→child changes for a parent kind.
+// A controller change to a child while the parent is stable is drift only if it
+// deviates from the baselines of the parent's kind.
+//
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Parent",type=string,JSONPath=`.spec.parent.kind`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+type Baseline struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec BaselineSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// BaselineList contains a list of Baseline resources.
+type BaselineList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Baseline `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Baseline{}, &BaselineList{})
+}
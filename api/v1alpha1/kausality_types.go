@@ -4,7 +4,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-// +kubebuilder:validation:Enum=log;enforce
+// +kubebuilder:validation:Enum=log;enforce;warn;dryrun
 type Mode string
 
 const (
@@ -13,6 +13,13 @@ const (
 
 	// ModeEnforce blocks requests that would cause drift.
 	ModeEnforce Mode = "enforce"
+
+	// ModeWarn evaluates drift as in enforce mode, but admits requests enforce mode
+	// would block, with a warning.
+	ModeWarn Mode = "warn"
+
+	// ModeDryRun behaves like ModeLog, with warnings tagged "[dry-run]".
+	ModeDryRun Mode = "dryrun"
 )
 
 // ResourceRule defines which resources to track within specific API groups.
@@ -106,6 +113,73 @@ type KausalitySpec struct {
 	// +optional
 	// +kubebuilder:validation:MaxItems=50
 	Overrides []ModeOverride `json:"overrides,omitempty"`
+
+	// AutoApprovals approve drift of children of all parents matched by this policy,
+	// as if each parent carried them in its kausality.io/approvals annotation. They are
+	// consulted when no approval or rejection on the parent matches. Only the modes
+	// always, generation and range are supported, as nothing consumes policy approvals.
+	// +optional
+	// +kubebuilder:validation:MaxItems=50
+	AutoApprovals []ApprovalSpec `json:"autoApprovals,omitempty"`
+}
+
+// ApprovalSpec declares an approval in a policy. The fields mean the same as in an
+// approval in the kausality.io/approvals annotation.
+//
+// +kubebuilder:validation:XValidation:rule="has(self.name) || has(self.selector)",message="name or selector is required"
+// +kubebuilder:validation:XValidation:rule="self.mode != 'generation' || has(self.generation)",message="generation is required for mode generation"
+// +kubebuilder:validation:XValidation:rule="self.mode != 'range' || has(self.generationMax)",message="generationMax is required for mode range"
+// +kubebuilder:validation:XValidation:rule="!has(self.generationMin) || !has(self.generationMax) || self.generationMin <= self.generationMax",message="generationMin must not exceed generationMax"
+type ApprovalSpec struct {
+	// APIVersion of the approved children, e.g. "apps/v1". "*" matches any, and the
+	// group may be a glob like "*.aws.crossplane.io/v1beta1".
+	APIVersion string `json:"apiVersion"`
+	// Kind of the approved children. "*" matches any.
+	Kind string `json:"kind"`
+	// Name of the approved children. "*" matches any. Required unless Selector is set.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Selector approves children whose labels match. If Name is set too, both must match.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	// Generation is the parent generation a generation approval is valid for.
+	// +optional
+	Generation int64 `json:"generation,omitempty"`
+	// GenerationMin is the first parent generation a range approval is valid for.
+	// +optional
+	GenerationMin int64 `json:"generationMin,omitempty"`
+	// GenerationMax is the last parent generation a range approval is valid for.
+	// +optional
+	GenerationMax int64 `json:"generationMax,omitempty"`
+	// Mode is always, generation or range.
+	// +kubebuilder:validation:Enum=always;generation;range
+	Mode string `json:"mode"`
+	// Note explains why the approval was granted.
+	// +optional
+	Note string `json:"note,omitempty"`
+	// Fields scopes the approval to changes of these spec fields, as JSON Pointers.
+	// +optional
+	Fields []string `json:"fields,omitempty"`
+	// ExpiresAt bounds the approval in time.
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+}
+
+// Approval returns the approval declared by the spec. Policy approvals are never recursive.
+func (s ApprovalSpec) Approval() Approval {
+	return Approval{
+		APIVersion:    s.APIVersion,
+		Kind:          s.Kind,
+		Name:          s.Name,
+		Selector:      s.Selector,
+		Generation:    s.Generation,
+		GenerationMin: s.GenerationMin,
+		GenerationMax: s.GenerationMax,
+		Mode:          s.Mode,
+		Note:          s.Note,
+		Fields:        s.Fields,
+		ExpiresAt:     s.ExpiresAt,
+	}
 }
 
 // KausalityStatus defines the observed state of a Kausality policy.
@@ -0,0 +1,91 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ProposalDecision is an operator's review decision on a DriftApprovalProposal.
+// +kubebuilder:validation:Enum=Pending;Accepted;Rejected
+type ProposalDecision string
+
+const (
+	// ProposalDecisionPending means the proposal has not been reviewed yet.
+	ProposalDecisionPending ProposalDecision = "Pending"
+
+	// ProposalDecisionAccepted means an operator accepted the proposal.
+	// Kausality does not apply the approval itself; the operator adds it to the parent.
+	ProposalDecisionAccepted ProposalDecision = "Accepted"
+
+	// ProposalDecisionRejected means an operator rejected the proposal.
+	ProposalDecisionRejected ProposalDecision = "Rejected"
+)
+
+// ProposalParentReference identifies the parent of a proposed approval.
+// The parent is in the same namespace as the proposal.
+type ProposalParentReference struct {
+	// APIVersion of the parent.
+	APIVersion string `json:"apiVersion"`
+
+	// Kind of the parent.
+	Kind string `json:"kind"`
+
+	// Name of the parent.
+	Name string `json:"name"`
+}
+
+// DriftApprovalProposalSpec describes a proposed standing approval.
+type DriftApprovalProposalSpec struct {
+	// Parent is the object whose kausality.io/approvals annotation the approval belongs in.
+	Parent ProposalParentReference `json:"parent"`
+
+	// Approval is the proposed entry for the parent's kausality.io/approvals annotation.
+	Approval Approval `json:"approval"`
+
+	// Controller is the user that repeatedly made the same correction to the child.
+	Controller string `json:"controller"`
+
+	// Fields are the spec fields changed by the correction.
+	// +optional
+	Fields []string `json:"fields,omitempty"`
+
+	// Occurrences is the number of identical corrections observed before proposing.
+	Occurrences int32 `json:"occurrences"`
+
+	// FirstSeen is when the first of these corrections was observed.
+	FirstSeen metav1.Time `json:"firstSeen"`
+
+	// Decision is set by an operator reviewing the proposal.
+	// +optional
+	Decision ProposalDecision `json:"decision,omitempty"`
+}
+
+// DriftApprovalProposal proposes a standing approval for a drift that a controller
+// keeps correcting the same way. Proposals are written by the webhook for operator
+// review and are never applied automatically.
+//
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced
+// +kubebuilder:printcolumn:name="Parent",type=string,JSONPath=`.spec.parent.name`
+// +kubebuilder:printcolumn:name="Child",type=string,JSONPath=`.spec.approval.name`
+// +kubebuilder:printcolumn:name="Occurrences",type=integer,JSONPath=`.spec.occurrences`
+// +kubebuilder:printcolumn:name="Decision",type=string,JSONPath=`.spec.decision`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+type DriftApprovalProposal struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec DriftApprovalProposalSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DriftApprovalProposalList contains a list of DriftApprovalProposal resources.
+type DriftApprovalProposalList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DriftApprovalProposal `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DriftApprovalProposal{}, &DriftApprovalProposalList{})
+}
@@ -1,7 +1,12 @@
 package v1alpha1
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -25,11 +30,32 @@ type Hop struct {
 	// RequestUID is the unique identifier of the admission request that caused this mutation.
 	RequestUID string `json:"requestUID,omitempty"`
 	// Timestamp of the mutation.
-	Timestamp metav1.Time `json:"timestamp"`
+	Timestamp metav1.Time `json:"timestamp,omitzero"`
 	// Labels contains custom metadata from kausality.io/trace-* annotations.
 	// For example, "kausality.io/trace-ticket=JIRA-123" becomes Labels["ticket"]="JIRA-123".
 	// Each hop captures labels from its own object; labels are not inherited from parent.
 	Labels map[string]string `json:"labels,omitempty"`
+	// Omitted is set on the marker hop standing in for the hops dropped from the middle
+	// of a trace that grew too long, and counts them. Besides it, only Kind and Name are
+	// set on the marker.
+	Omitted int `json:"omitted,omitempty"`
+}
+
+// OmittedHopKind is the kind of the marker hop standing in for omitted hops.
+const OmittedHopKind = "..."
+
+// NewOmittedHop creates the marker hop standing in for n omitted hops.
+func NewOmittedHop(n int) Hop {
+	return Hop{
+		Kind:    OmittedHopKind,
+		Name:    fmt.Sprintf("(%d hops omitted)", n),
+		Omitted: n,
+	}
+}
+
+// IsOmitted returns true for the marker hop standing in for omitted hops.
+func (h Hop) IsOmitted() bool {
+	return h.Omitted > 0
 }
 
 // ParseTrace parses a trace from its JSON representation.
@@ -82,6 +108,53 @@ func (t Trace) Append(hop Hop) Trace {
 	return result
 }
 
+// ToTraceParent returns the trace as a W3C traceparent, "00-<trace-id>-<span-id>-01",
+// or "" if empty. The trace-id is derived from the origin hop and is shared by all
+// objects of the causal chain; if the origin's request UID is a UUID, it is the UUID
+// itself, so that API server audit events can be joined by request UID. The span-id
+// is derived from the current, i.e. last, hop.
+func (t Trace) ToTraceParent() string {
+	if len(t) == 0 {
+		return ""
+	}
+	traceID := uuidHex(t[0].RequestUID)
+	if traceID == "" {
+		traceID = hopHash(t[0], 16)
+	}
+	return "00-" + traceID + "-" + hopHash(t[len(t)-1], 8) + "-01"
+}
+
+// uuidHex returns the 32 lowercase hex digits of a non-zero UUID, or "" if uid is none.
+func uuidHex(uid string) string {
+	s := strings.ToLower(strings.ReplaceAll(uid, "-", ""))
+	if len(s) != 32 || strings.Trim(s, "0") == "" {
+		return ""
+	}
+	if _, err := hex.DecodeString(s); err != nil {
+		return ""
+	}
+	return s
+}
+
+// hopHash returns n bytes identifying the hop as hex. W3C forbids all-zero IDs,
+// which a truncated hash is in theory, so the last byte is forced to non-zero then.
+func hopHash(hop Hop, n int) string {
+	id := hop.RequestUID
+	if id == "" {
+		id = strings.Join([]string{hop.APIVersion, hop.Kind, hop.Name, strconv.FormatInt(hop.Generation, 10), hop.User}, "/")
+	}
+	sum := sha256.Sum256([]byte(id))
+	b := sum[:n]
+	zero := true
+	for _, c := range b {
+		zero = zero && c == 0
+	}
+	if zero {
+		b[n-1] = 1
+	}
+	return hex.EncodeToString(b)
+}
+
 // NewHop creates a new Hop with the current timestamp.
 func NewHop(apiVersion, kind, name string, generation int64, user, requestUID string) Hop {
 	return Hop{
@@ -108,15 +181,21 @@ func NewHopWithLabels(apiVersion, kind, name string, generation int64, user, req
 // For example, "kausality.io/trace-ticket=JIRA-123" returns map["ticket"]="JIRA-123".
 // Annotations with empty suffix (exactly "kausality.io/trace-") are skipped.
 func ExtractTraceLabels(annotations map[string]string) map[string]string {
+	return ExtractTraceLabelsWithPrefix(annotations, TraceMetadataPrefix)
+}
+
+// ExtractTraceLabelsWithPrefix is ExtractTraceLabels for another trace metadata prefix,
+// see AnnotationKeys.
+func ExtractTraceLabelsWithPrefix(annotations map[string]string, prefix string) map[string]string {
 	if annotations == nil {
 		return nil
 	}
 
 	var labels map[string]string
 	for key, value := range annotations {
-		if len(key) > len(TraceMetadataPrefix) && key[:len(TraceMetadataPrefix)] == TraceMetadataPrefix {
+		if len(key) > len(prefix) && key[:len(prefix)] == prefix {
 			// Extract the key after the prefix
-			labelKey := key[len(TraceMetadataPrefix):]
+			labelKey := key[len(prefix):]
 			if labelKey == "" {
 				continue // Skip empty label keys
 			}
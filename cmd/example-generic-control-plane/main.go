@@ -40,10 +40,12 @@ func main() {
 	var dataDir string
 	var bindAddress string
 	var bindPort int
+	var decisionHistory int
 
 	flag.StringVar(&dataDir, "data-dir", "/tmp/example-control-plane", "Data directory for etcd and server state")
 	flag.StringVar(&bindAddress, "bind-address", "127.0.0.1", "Address to bind the API server")
 	flag.IntVar(&bindPort, "bind-port", 8443, "Port to bind the API server")
+	flag.IntVar(&decisionHistory, "decision-history", 100, "Number of recent admission decisions served at /debug/kausality/decisions (0 disables the endpoint)")
 
 	opts := zap.Options{Development: true}
 	opts.BindFlags(flag.CommandLine)
@@ -55,6 +57,7 @@ func main() {
 		"dataDir", dataDir,
 		"bindAddress", bindAddress,
 		"bindPort", bindPort,
+		"decisionHistory", decisionHistory,
 	)
 
 	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
@@ -65,13 +68,13 @@ func main() {
 	log.Info("policy resolver created", "mode", kausalityv1alpha1.ModeEnforce)
 
 	// Start server with embedded etcd
-	if err := run(ctx, log, dataDir, bindAddress, bindPort, policyResolver); err != nil {
+	if err := run(ctx, log, dataDir, bindAddress, bindPort, decisionHistory, policyResolver); err != nil {
 		log.Error(err, "server failed")
 		os.Exit(1)
 	}
 }
 
-func run(ctx context.Context, log logr.Logger, dataDir, bindAddress string, bindPort int, policyResolver policy.Resolver) error {
+func run(ctx context.Context, log logr.Logger, dataDir, bindAddress string, bindPort, decisionHistory int, policyResolver policy.Resolver) error {
 	log.Info("starting embedded etcd server")
 
 	// Create embedded etcd options with root directory
@@ -129,12 +132,13 @@ func run(ctx context.Context, log logr.Logger, dataDir, bindAddress string, bind
 
 	// Create and start the API server
 	server, err := apiserver.New(apiserver.Config{
-		EtcdServers:    etcdServers,
-		BindAddress:    bindAddress,
-		BindPort:       bindPort,
-		Log:            log,
-		PolicyResolver: policyResolver,
-		Client:         nil, // No client needed for simple example
+		EtcdServers:     etcdServers,
+		BindAddress:     bindAddress,
+		BindPort:        bindPort,
+		Log:             log,
+		PolicyResolver:  policyResolver,
+		Client:          nil, // No client needed for simple example
+		DecisionHistory: decisionHistory,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create API server: %w", err)
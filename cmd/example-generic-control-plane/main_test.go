@@ -3,8 +3,12 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/go-logr/logr"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -13,6 +17,9 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	crAdmission "sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -20,6 +27,7 @@ import (
 	kausalityv1alpha1 "github.com/kausality-io/kausality/api/v1alpha1"
 	localAdmission "github.com/kausality-io/kausality/cmd/example-generic-control-plane/pkg/admission"
 	examplev1alpha1 "github.com/kausality-io/kausality/cmd/example-generic-control-plane/pkg/apis/example/v1alpha1"
+	"github.com/kausality-io/kausality/pkg/controller"
 	"github.com/kausality-io/kausality/pkg/policy"
 )
 
@@ -168,3 +176,82 @@ func TestKausalityAdmission(t *testing.T) {
 		assert.True(t, resp.Allowed, "Widget UPDATE should be allowed when no drift")
 	})
 }
+
+// TestKausalityAdmission_Decisions drives requests through the k8s.io/apiserver admission
+// interface and checks that decisions are recorded and served.
+func TestKausalityAdmission_Decisions(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, examplev1alpha1.AddToScheme(scheme))
+
+	// A stable WidgetSet: its controller changing a Widget is drift
+	parent := &examplev1alpha1.WidgetSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "parent",
+			Namespace:   "default",
+			UID:         "parent-uid",
+			Generation:  1,
+			Annotations: map[string]string{controller.PhaseAnnotation: controller.PhaseValueInitialized},
+		},
+		Status: examplev1alpha1.WidgetSetStatus{ObservedGeneration: 1},
+	}
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}, parent).
+		Build()
+
+	plugin := localAdmission.NewKausalityAdmission(fakeClient, logr.Discard(), policy.NewStaticResolver(kausalityv1alpha1.ModeEnforce))
+	recorder := localAdmission.NewDecisionRecorder(10)
+	plugin.SetDecisionRecorder(recorder)
+
+	widget := &examplev1alpha1.Widget{
+		TypeMeta: metav1.TypeMeta{APIVersion: examplev1alpha1.GroupVersion.String(), Kind: "Widget"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "child",
+			Namespace: "default",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: examplev1alpha1.GroupVersion.String(),
+				Kind:       "WidgetSet",
+				Name:       "parent",
+				UID:        "parent-uid",
+				Controller: ptr.To(true),
+			}},
+		},
+		Spec: examplev1alpha1.WidgetSpec{Color: "blue"},
+	}
+	attrs := func(obj, old runtime.Object, op admission.Operation) admission.Attributes {
+		return admission.NewAttributesRecord(obj, old,
+			examplev1alpha1.GroupVersion.WithKind("Widget"), "default", "child",
+			examplev1alpha1.GroupVersion.WithResource("widgets"), "", op, nil, false,
+			&user.DefaultInfo{Name: "widgetset-controller"})
+	}
+
+	// CREATE of a Widget without a controller owner is allowed
+	standalone := widget.DeepCopy()
+	standalone.OwnerReferences = nil
+	require.NoError(t, plugin.Admit(t.Context(), attrs(standalone, nil, admission.Create), nil))
+
+	// UPDATE by the same controller while the parent is stable is denied as drift
+	old := widget.DeepCopy()
+	old.Annotations = map[string]string{controller.UpdatersAnnotation: controller.HashUsername("widgetset-controller")}
+	updated := old.DeepCopy()
+	updated.Spec.Color = "red"
+	err := plugin.Admit(t.Context(), attrs(updated, old, admission.Update), nil)
+	require.Error(t, err)
+
+	decisions := recorder.Decisions()
+	require.Len(t, decisions, 2)
+	assert.Equal(t, "CREATE", decisions[0].Operation)
+	assert.True(t, decisions[0].Allowed)
+	assert.Equal(t, "UPDATE", decisions[1].Operation)
+	assert.Equal(t, "widgets.example.kausality.io", decisions[1].Resource)
+	assert.Equal(t, "widgetset-controller", decisions[1].User)
+	assert.False(t, decisions[1].Allowed)
+	assert.Contains(t, decisions[1].Message, "drift")
+
+	rec := httptest.NewRecorder()
+	recorder.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, localAdmission.DecisionsPath, nil))
+	var served []localAdmission.Decision
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &served))
+	assert.Len(t, served, 2)
+}
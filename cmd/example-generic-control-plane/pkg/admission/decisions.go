@@ -0,0 +1,69 @@
+package admission
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DecisionsPath is the path the decision recorder is served at.
+const DecisionsPath = "/debug/kausality/decisions"
+
+// Decision is a structured admission decision of the kausality plugin.
+type Decision struct {
+	Time      time.Time `json:"time"`
+	Operation string    `json:"operation"`
+	Resource  string    `json:"resource"`
+	Namespace string    `json:"namespace,omitempty"`
+	Name      string    `json:"name"`
+	User      string    `json:"user"`
+	Allowed   bool      `json:"allowed"`
+	Message   string    `json:"message,omitempty"`
+	Warnings  []string  `json:"warnings,omitempty"`
+}
+
+// DecisionRecorder keeps the most recent admission decisions in memory.
+// It implements http.Handler, serving them as a JSON array, oldest first.
+type DecisionRecorder struct {
+	mu        sync.Mutex
+	capacity  int
+	decisions []Decision
+}
+
+// NewDecisionRecorder creates a recorder keeping the given number of decisions.
+func NewDecisionRecorder(capacity int) *DecisionRecorder {
+	if capacity <= 0 {
+		capacity = 1
+	}
+	return &DecisionRecorder{capacity: capacity}
+}
+
+// Record adds a decision, evicting the oldest one when full.
+func (r *DecisionRecorder) Record(d Decision) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.decisions) == r.capacity {
+		r.decisions = r.decisions[1:]
+	}
+	r.decisions = append(r.decisions, d)
+}
+
+// Decisions returns a copy of the recorded decisions, oldest first.
+func (r *DecisionRecorder) Decisions() []Decision {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Decision(nil), r.decisions...)
+}
+
+// ServeHTTP writes the recorded decisions as JSON.
+func (r *DecisionRecorder) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	decisions := r.Decisions()
+	if decisions == nil {
+		decisions = []Decision{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(decisions); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/go-logr/logr"
 	jsonpatch "gomodules.xyz/jsonpatch/v2"
@@ -37,9 +38,10 @@ func Register(plugins *admission.Plugins, c client.Client, log logr.Logger, reso
 // KausalityAdmission implements k8s.io/apiserver admission.MutationInterface.
 // It wraps the kausality admission handler to provide drift detection and tracing.
 type KausalityAdmission struct {
-	handler *kausalityAdmission.Handler
-	scheme  *runtime.Scheme
-	log     logr.Logger
+	handler   *kausalityAdmission.Handler
+	scheme    *runtime.Scheme
+	log       logr.Logger
+	decisions *DecisionRecorder
 }
 
 // NewKausalityAdmission creates a new kausality admission plugin.
@@ -60,6 +62,11 @@ func (k *KausalityAdmission) SetScheme(scheme *runtime.Scheme) {
 	k.scheme = scheme
 }
 
+// SetDecisionRecorder records every admission decision in r, in addition to logging it.
+func (k *KausalityAdmission) SetDecisionRecorder(r *DecisionRecorder) {
+	k.decisions = r
+}
+
 // Handles returns true if this plugin handles the given operation.
 func (k *KausalityAdmission) Handles(operation admission.Operation) bool {
 	switch operation {
@@ -86,6 +93,7 @@ func (k *KausalityAdmission) Admit(ctx context.Context, a admission.Attributes,
 
 	// Call kausality handler
 	resp := k.handler.Handle(ctx, req)
+	k.recordDecision(a, resp)
 
 	// Handle denial
 	if !resp.Allowed {
@@ -101,12 +109,41 @@ func (k *KausalityAdmission) Admit(ctx context.Context, a admission.Attributes,
 		}
 	}
 
-	// Log warnings
-	for _, w := range resp.Warnings {
-		k.log.Info("admission warning", "warning", w)
+	return nil
+}
+
+// recordDecision logs the handler's decision and records it if a recorder is set.
+func (k *KausalityAdmission) recordDecision(a admission.Attributes, resp crAdmission.Response) {
+	d := Decision{
+		Time:      time.Now(),
+		Operation: string(a.GetOperation()),
+		Resource:  a.GetResource().GroupResource().String(),
+		Namespace: a.GetNamespace(),
+		Name:      a.GetName(),
+		User:      a.GetUserInfo().GetName(),
+		Allowed:   resp.Allowed,
+		Warnings:  resp.Warnings,
+	}
+	if resp.Result != nil {
+		d.Message = resp.Result.Message
+	}
+	if a.GetSubresource() != "" {
+		d.Resource += "/" + a.GetSubresource()
 	}
 
-	return nil
+	k.log.Info("admission decision",
+		"operation", d.Operation,
+		"resource", d.Resource,
+		"namespace", d.Namespace,
+		"name", d.Name,
+		"user", d.User,
+		"allowed", d.Allowed,
+		"message", d.Message,
+		"warnings", d.Warnings,
+	)
+	if k.decisions != nil {
+		k.decisions.Record(d)
+	}
 }
 
 // toAdmissionRequest converts k8s.io/apiserver attributes to controller-runtime request.
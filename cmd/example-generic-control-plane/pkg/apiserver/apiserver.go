@@ -55,6 +55,9 @@ type Config struct {
 	PolicyResolver policy.Resolver
 	// Client is the controller-runtime client for kausality (can be nil for simple use).
 	Client client.Client
+	// DecisionHistory is the number of recent admission decisions served at
+	// kausalityAdmission.DecisionsPath. Zero disables the endpoint.
+	DecisionHistory int
 }
 
 // Server is the API server.
@@ -120,6 +123,11 @@ func New(cfg Config) (*Server, error) {
 	// Create kausality admission plugin
 	kausalityPlugin := kausalityAdmission.NewKausalityAdmission(cfg.Client, cfg.Log, cfg.PolicyResolver)
 	kausalityPlugin.SetScheme(Scheme)
+	var decisions *kausalityAdmission.DecisionRecorder
+	if cfg.DecisionHistory > 0 {
+		decisions = kausalityAdmission.NewDecisionRecorder(cfg.DecisionHistory)
+		kausalityPlugin.SetDecisionRecorder(decisions)
+	}
 
 	// Set up admission chain
 	genericConfig.AdmissionControl = admission.NewChainHandler(kausalityPlugin)
@@ -138,6 +146,11 @@ func New(cfg Config) (*Server, error) {
 		return nil, fmt.Errorf("failed to install API group: %w", err)
 	}
 
+	// Expose recent admission decisions
+	if decisions != nil {
+		genericServer.Handler.NonGoRestfulMux.Handle(kausalityAdmission.DecisionsPath, decisions)
+	}
+
 	return &Server{
 		GenericAPIServer: genericServer,
 		log:              cfg.Log,
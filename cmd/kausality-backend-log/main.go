@@ -9,24 +9,35 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	"sigs.k8s.io/yaml"
 
+	"github.com/kausality-io/kausality/pkg/backend"
 	kausalityv1alpha1 "github.com/kausality-io/kausality/pkg/callback/v1alpha1"
 )
 
 func main() {
-	var addr string
+	var (
+		addr            string
+		summaryInterval time.Duration
+		summaryTop      int
+	)
 
 	flag.StringVar(&addr, "addr", ":8080", "Address to listen on")
+	flag.DurationVar(&summaryInterval, "summary-interval", 0, "Interval for logging a summary of the top drifting parents (0 disables)")
+	flag.IntVar(&summaryTop, "summary-top", 10, "Number of parents in the periodic summary")
 	flag.Parse()
 
+	// stats aggregates received reports for the periodic top-drifters summary
+	stats := backend.NewStats()
+
 	mux := http.NewServeMux()
 
 	// Webhook endpoint - logs DriftReports as YAML
-	mux.HandleFunc("POST /webhook", handleWebhook)
+	mux.HandleFunc("POST /webhook", handleWebhook(stats))
 
 	// Health endpoint
 	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
@@ -52,6 +63,10 @@ func main() {
 		}
 	}()
 
+	if summaryInterval > 0 {
+		go logSummaries(ctx, stats, summaryInterval, summaryTop)
+	}
+
 	<-ctx.Done()
 	fmt.Fprintln(os.Stderr, "shutting down")
 
@@ -60,30 +75,58 @@ func main() {
 	_ = server.Shutdown(shutdownCtx)
 }
 
-func handleWebhook(w http.ResponseWriter, r *http.Request) {
-	body, err := io.ReadAll(r.Body)
-	if err != nil {
-		http.Error(w, "failed to read body", http.StatusBadRequest)
-		return
-	}
+// handleWebhook returns a handler that logs DriftReports and records them in stats.
+func handleWebhook(stats *backend.Stats) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "failed to read body", http.StatusBadRequest)
+			return
+		}
 
-	var report kausalityv1alpha1.DriftReport
-	if err := json.Unmarshal(body, &report); err != nil {
-		http.Error(w, "invalid DriftReport", http.StatusBadRequest)
-		return
-	}
+		var report kausalityv1alpha1.DriftReport
+		if err := json.Unmarshal(body, &report); err != nil {
+			http.Error(w, "invalid DriftReport", http.StatusBadRequest)
+			return
+		}
 
-	// Print as YAML using sigs.k8s.io/yaml which handles RawExtension correctly
-	yamlBytes, err := yaml.Marshal(&report)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "# failed to marshal: %v\n", err)
-	} else {
-		fmt.Println("---")
-		fmt.Print(string(yamlBytes))
+		stats.Record(&report)
+
+		// Print as YAML using sigs.k8s.io/yaml which handles RawExtension correctly
+		yamlBytes, err := yaml.Marshal(&report)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "# failed to marshal: %v\n", err)
+		} else {
+			fmt.Println("---")
+			fmt.Print(string(yamlBytes))
+		}
+
+		// Acknowledge
+		response := kausalityv1alpha1.DriftReportResponse{Acknowledged: true}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
 	}
+}
 
-	// Acknowledge
-	response := kausalityv1alpha1.DriftReportResponse{Acknowledged: true}
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(response)
+// logSummaries periodically prints the top drifting parents to stderr.
+func logSummaries(ctx context.Context, stats *backend.Stats, interval time.Duration, top int) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			drifters := stats.Top(top)
+			fmt.Fprintf(os.Stderr, "# top drifting parents (%d)\n", len(drifters))
+			for _, d := range drifters {
+				var fields []string
+				for _, f := range d.TopFields {
+					fields = append(fields, fmt.Sprintf("%s=%d", f.Field, f.Count))
+				}
+				fmt.Fprintf(os.Stderr, "#   %s %s/%s: %d events %v fields=[%s]\n",
+					d.Parent.Kind, d.Parent.Namespace, d.Parent.Name, d.Count, d.ByPhase, strings.Join(fields, ","))
+			}
+		}
+	}
 }
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/kausality-io/kausality/pkg/backend"
+)
+
+func main() {
+	var (
+		addr         string
+		webhookURL   string
+		linkTemplate string
+		debounce     time.Duration
+	)
+
+	flag.StringVar(&addr, "addr", ":8080", "Address to listen on")
+	flag.StringVar(&webhookURL, "slack-webhook-url", "", "Slack incoming webhook URL to post DriftReports to")
+	flag.StringVar(&linkTemplate, "link-template", backend.DefaultSlackLinkTemplate, "Link added to each message; {id} is replaced by the report ID")
+	flag.DurationVar(&debounce, "debounce", backend.DefaultSlackDebounce, "Window in which reports with the same ID and phase are posted only once (negative disables)")
+	flag.Parse()
+
+	notifier, err := backend.NewSlackNotifier(backend.SlackConfig{
+		WebhookURL:   webhookURL,
+		LinkTemplate: linkTemplate,
+		Debounce:     debounce,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           notifier.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	// Handle shutdown
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		fmt.Fprintf(os.Stderr, "kausality-backend-slack listening on %s\n", addr)
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			fmt.Fprintf(os.Stderr, "server error: %v\n", err)
+			os.Exit(1)
+		}
+	}()
+
+	<-ctx.Done()
+	fmt.Fprintln(os.Stderr, "shutting down")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_ = server.Shutdown(shutdownCtx)
+}
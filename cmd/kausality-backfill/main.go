@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kausality-io/kausality/cmd/kausality-backfill/pkg/backfill"
+	"github.com/kausality-io/kausality/pkg/admission"
+	"github.com/kausality-io/kausality/pkg/config"
+)
+
+func main() {
+	var (
+		kubeconfig   string
+		kinds        string
+		namespace    string
+		dryRun       bool
+		fieldManager string
+		configFile   string
+	)
+
+	flag.StringVar(&kubeconfig, "kubeconfig", "", "Path to kubeconfig file (default: $KUBECONFIG or ~/.kube/config)")
+	flag.StringVar(&kinds, "kinds", "", "Comma-separated kinds to backfill as <apiVersion>/<kind>, e.g. apps/v1/Deployment,apps/v1/ReplicaSet,v1/Pod (required)")
+	flag.StringVar(&namespace, "namespace", "", "Namespace to backfill (default: all namespaces)")
+	flag.BoolVar(&dryRun, "dry-run", false, "Print the traces without writing them")
+	flag.StringVar(&fieldManager, "field-manager", admission.DefaultFieldManager, "Field manager of the writes; must match the webhook's --field-manager")
+	flag.StringVar(&configFile, "config", "", "Path to the webhook configuration file, for the annotation prefix and parent references (default: built-in defaults)")
+	flag.Parse()
+
+	gvks, err := parseKinds(kinds)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	driftConfig := config.Default()
+	if configFile != "" {
+		if driftConfig, err = config.Load(configFile); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to load config: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	backfiller := backfill.New(newClient(kubeconfig), backfill.Options{
+		Kinds:        gvks,
+		Namespace:    namespace,
+		DryRun:       dryRun,
+		FieldManager: fieldManager,
+		Config:       driftConfig,
+	})
+	results, err := backfiller.Run(context.Background())
+	printResults(os.Stdout, results, dryRun)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// parseKinds parses comma-separated <apiVersion>/<kind> entries.
+func parseKinds(s string) ([]schema.GroupVersionKind, error) {
+	if s == "" {
+		return nil, fmt.Errorf("--kinds is required")
+	}
+	var gvks []schema.GroupVersionKind
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		i := strings.LastIndex(entry, "/")
+		if i <= 0 || i == len(entry)-1 {
+			return nil, fmt.Errorf("invalid kind %q: must be <apiVersion>/<kind>", entry)
+		}
+		gv, err := schema.ParseGroupVersion(entry[:i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid kind %q: %w", entry, err)
+		}
+		gvks = append(gvks, gv.WithKind(entry[i+1:]))
+	}
+	return gvks, nil
+}
+
+// printResults prints one line per object and a summary.
+func printResults(out io.Writer, results []backfill.Result, dryRun bool) {
+	verb := "traced"
+	if dryRun {
+		verb = "would trace"
+	}
+	backfilled := 0
+	for _, r := range results {
+		obj := r.Object
+		name := obj.GetName()
+		if ns := obj.GetNamespace(); ns != "" {
+			name = ns + "/" + name
+		}
+		if r.Skipped {
+			fmt.Fprintf(out, "skipped %s %s %s: already traced\n", obj.GetAPIVersion(), obj.GetKind(), name)
+			continue
+		}
+		backfilled++
+		fmt.Fprintf(out, "%s %s %s %s: %d hops\n", verb, obj.GetAPIVersion(), obj.GetKind(), name, len(r.Trace))
+	}
+	fmt.Fprintf(out, "%d %s, %d skipped\n", backfilled, verb, len(results)-backfilled)
+}
+
+func newClient(kubeconfig string) client.Client {
+	if kubeconfig == "" {
+		kubeconfig = os.Getenv("KUBECONFIG")
+		if kubeconfig == "" {
+			home, _ := os.UserHomeDir()
+			kubeconfig = home + "/.kube/config"
+		}
+	}
+
+	restConfig, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error building kubeconfig: %v\n", err)
+		os.Exit(1)
+	}
+	restConfig.WarningHandler = rest.NewWarningWriter(os.Stderr, rest.WarningWriterOptions{})
+
+	c, err := client.New(restConfig, client.Options{})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+		os.Exit(1)
+	}
+	return c
+}
@@ -0,0 +1,197 @@
+// Package backfill writes initial trace annotations on objects created before kausality
+// was installed, so that existing ownership hierarchies get coherent traces instead of
+// their children starting new origins.
+package backfill
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kausalityv1alpha1 "github.com/kausality-io/kausality/api/v1alpha1"
+	"github.com/kausality-io/kausality/pkg/admission"
+	"github.com/kausality-io/kausality/pkg/config"
+	"github.com/kausality-io/kausality/pkg/drift"
+	"github.com/kausality-io/kausality/pkg/trace"
+)
+
+// Options configures a Backfiller.
+type Options struct {
+	// Kinds are the kinds whose objects get traces, e.g. apps/v1 Deployment and
+	// ReplicaSet. Parents of other kinds are read but not written.
+	Kinds []schema.GroupVersionKind
+	// Namespace restricts the backfill to one namespace. Empty means all namespaces.
+	Namespace string
+	// DryRun computes the traces without writing them.
+	DryRun bool
+	// FieldManager is the field manager of the writes. It must be the webhook's
+	// --field-manager, because the webhook reverts kausality annotations changed by
+	// anyone else without a spec change. Default is admission.DefaultFieldManager.
+	FieldManager string
+	// Config provides the annotation keys and parent references. Defaults to
+	// config.Default().
+	Config *config.Config
+}
+
+// Result is the outcome of the backfill of one object.
+type Result struct {
+	// Object is the object as listed.
+	Object *unstructured.Unstructured
+	// Trace is the object's trace, backfilled or existing.
+	Trace trace.Trace
+	// Skipped is true if the object already had a trace before the backfill.
+	Skipped bool
+}
+
+// Backfiller writes traces top-down: parents first, children extending their
+// parent's trace.
+type Backfiller struct {
+	client   client.Client
+	opts     Options
+	keys     kausalityv1alpha1.AnnotationKeys
+	resolver *drift.ParentResolver
+
+	kinds  map[schema.GroupVersionKind]bool
+	traces map[types.UID]trace.Trace
+	// backfilled are the objects this backfill wrote a trace on.
+	backfilled map[types.UID]bool
+	// visiting guards against ownership cycles.
+	visiting map[types.UID]bool
+}
+
+// New creates a Backfiller.
+func New(c client.Client, opts Options) *Backfiller {
+	if opts.FieldManager == "" {
+		opts.FieldManager = admission.DefaultFieldManager
+	}
+	cfg := opts.Config
+	if cfg == nil {
+		cfg = config.Default()
+	}
+	keys := cfg.AnnotationKeys()
+	resolver := drift.NewParentResolver(c)
+	resolver.SetAnnotationKeys(keys)
+	resolver.SetParentReferences(cfg.DriftDetection.ParentReferences)
+
+	kinds := make(map[schema.GroupVersionKind]bool, len(opts.Kinds))
+	for _, gvk := range opts.Kinds {
+		kinds[gvk] = true
+	}
+	return &Backfiller{
+		client:     c,
+		opts:       opts,
+		keys:       keys,
+		resolver:   resolver,
+		kinds:      kinds,
+		traces:     make(map[types.UID]trace.Trace),
+		backfilled: make(map[types.UID]bool),
+		visiting:   make(map[types.UID]bool),
+	}
+}
+
+// Run backfills the objects of all kinds. Objects that already have a trace are
+// skipped, so running it again writes nothing. The results are in the order the
+// objects were listed.
+func (b *Backfiller) Run(ctx context.Context) ([]Result, error) {
+	var results []Result
+	for _, gvk := range b.opts.Kinds {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		var listOpts []client.ListOption
+		if b.opts.Namespace != "" {
+			listOpts = append(listOpts, client.InNamespace(b.opts.Namespace))
+		}
+		if err := b.client.List(ctx, list, listOpts...); err != nil {
+			return results, fmt.Errorf("failed to list %s: %w", gvk.Kind, err)
+		}
+		for i := range list.Items {
+			obj := &list.Items[i]
+			t, err := b.traceFor(ctx, obj)
+			if err != nil {
+				return results, err
+			}
+			results = append(results, Result{Object: obj, Trace: t, Skipped: !b.backfilled[obj.GetUID()]})
+		}
+	}
+	return results, nil
+}
+
+// traceFor returns the trace of obj, backfilling it and its parents first if obj has
+// none and its kind is backfilled.
+func (b *Backfiller) traceFor(ctx context.Context, obj client.Object) (trace.Trace, error) {
+	uid := obj.GetUID()
+	if t, ok := b.traces[uid]; ok {
+		return t, nil
+	}
+	if b.hasTrace(obj) {
+		t, err := trace.Parse(obj.GetAnnotations()[b.keys.Trace])
+		if err != nil {
+			return nil, fmt.Errorf("invalid trace on %s: %w", describe(obj), err)
+		}
+		b.traces[uid] = t
+		return t, nil
+	}
+
+	// Extend the parent's trace, or start an origin without a parent
+	var parentTrace trace.Trace
+	if !b.visiting[uid] {
+		b.visiting[uid] = true
+		defer delete(b.visiting, uid)
+
+		parentState, err := b.resolver.ResolveParent(ctx, obj)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve parent of %s: %w", describe(obj), err)
+		}
+		if parentState != nil && parentState.Object != nil {
+			if parentTrace, err = b.traceFor(ctx, parentState.Object); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// The user of backfilled hops is unknown, like for parents without a trace
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	t := parentTrace.Append(trace.NewHop(gvk.GroupVersion().String(), gvk.Kind, obj.GetName(), obj.GetGeneration(), "", ""))
+	if b.kinds[gvk] {
+		if err := b.write(ctx, obj, t); err != nil {
+			return nil, err
+		}
+		b.backfilled[uid] = true
+	}
+	b.traces[uid] = t
+	return t, nil
+}
+
+// write sets the trace annotation of obj, unless in dry-run mode.
+func (b *Backfiller) write(ctx context.Context, obj client.Object, t trace.Trace) error {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[b.keys.Trace] = t.String()
+	if b.opts.DryRun {
+		obj.SetAnnotations(annotations)
+		return nil
+	}
+
+	patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+	obj.SetAnnotations(annotations)
+	if err := b.client.Patch(ctx, obj, patch, client.FieldOwner(b.opts.FieldManager)); err != nil {
+		return fmt.Errorf("failed to write trace of %s: %w", describe(obj), err)
+	}
+	return nil
+}
+
+// describe returns "<kind> <namespace>/<name>" of obj for messages.
+func describe(obj client.Object) string {
+	return fmt.Sprintf("%s %s/%s", obj.GetObjectKind().GroupVersionKind().Kind, obj.GetNamespace(), obj.GetName())
+}
+
+// hasTrace returns true if obj has a non-empty trace annotation.
+func (b *Backfiller) hasTrace(obj client.Object) bool {
+	return obj.GetAnnotations()[b.keys.Trace] != ""
+}
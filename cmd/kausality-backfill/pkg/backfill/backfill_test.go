@@ -0,0 +1,143 @@
+package backfill
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	kausalityv1alpha1 "github.com/kausality-io/kausality/api/v1alpha1"
+	"github.com/kausality-io/kausality/pkg/trace"
+)
+
+var (
+	deploymentGVK = schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+	replicaSetGVK = schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "ReplicaSet"}
+	podGVK        = schema.GroupVersionKind{Version: "v1", Kind: "Pod"}
+)
+
+// hierarchy returns a Deployment owning a ReplicaSet owning a Pod, none traced.
+func hierarchy() []client.Object {
+	owner := func(kind, name string) []metav1.OwnerReference {
+		return []metav1.OwnerReference{{
+			APIVersion: "apps/v1", Kind: kind, Name: name, UID: types.UID(name + "-uid"), Controller: ptr.To(true),
+		}}
+	}
+	return []client.Object{
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+			Name: "web", Namespace: "default", UID: "web-uid", Generation: 3,
+		}},
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+			Name: "web-abc", Namespace: "default", UID: "web-abc-uid", Generation: 1, OwnerReferences: owner("Deployment", "web"),
+		}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name: "web-abc-x", Namespace: "default", UID: "web-abc-x-uid", OwnerReferences: owner("ReplicaSet", "web-abc"),
+		}},
+	}
+}
+
+// storedTrace returns the trace annotation of an object in the client.
+func storedTrace(t *testing.T, c client.Client, obj client.Object) trace.Trace {
+	t.Helper()
+	require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(obj), obj))
+	tr, err := trace.Parse(obj.GetAnnotations()[kausalityv1alpha1.TraceAnnotation])
+	require.NoError(t, err)
+	return tr
+}
+
+func TestRun(t *testing.T) {
+	// Children are listed before their parents; parents are still traced first
+	c := fake.NewClientBuilder().WithObjects(hierarchy()...).Build()
+	results, err := New(c, Options{Kinds: []schema.GroupVersionKind{podGVK, replicaSetGVK, deploymentGVK}}).Run(t.Context())
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	for _, r := range results {
+		assert.False(t, r.Skipped, r.Object.GetName())
+	}
+
+	deployment := storedTrace(t, c, &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}})
+	require.Len(t, deployment, 1)
+	assert.Equal(t, "Deployment", deployment[0].Kind)
+	assert.Equal(t, int64(3), deployment[0].Generation)
+
+	replicaSet := storedTrace(t, c, &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "web-abc", Namespace: "default"}})
+	require.Len(t, replicaSet, 2)
+	assert.Equal(t, deployment[0].Name, replicaSet[0].Name)
+	assert.Equal(t, "web-abc", replicaSet[1].Name)
+
+	pod := storedTrace(t, c, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-abc-x", Namespace: "default"}})
+	require.Len(t, pod, 3)
+	assert.Equal(t, []string{"apps/v1", "apps/v1", "v1"}, []string{pod[0].APIVersion, pod[1].APIVersion, pod[2].APIVersion})
+	assert.Equal(t, []string{"web", "web-abc", "web-abc-x"}, []string{pod[0].Name, pod[1].Name, pod[2].Name})
+}
+
+func TestRun_Idempotent(t *testing.T) {
+	var patches int
+	c := fake.NewClientBuilder().WithObjects(hierarchy()...).WithInterceptorFuncs(interceptor.Funcs{
+		Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			patches++
+			return c.Patch(ctx, obj, patch, opts...)
+		},
+	}).Build()
+	kinds := []schema.GroupVersionKind{deploymentGVK, replicaSetGVK, podGVK}
+
+	_, err := New(c, Options{Kinds: kinds}).Run(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 3, patches)
+	before := storedTrace(t, c, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-abc-x", Namespace: "default"}})
+
+	results, err := New(c, Options{Kinds: kinds}).Run(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 3, patches, "second run must not write")
+	for _, r := range results {
+		assert.True(t, r.Skipped, r.Object.GetName())
+	}
+	assert.Equal(t, before.String(), storedTrace(t, c, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-abc-x", Namespace: "default"}}).String())
+}
+
+func TestRun_ExtendsExistingParentTrace(t *testing.T) {
+	objs := hierarchy()
+	existing := trace.Trace{trace.NewHop("example.com/v1", "App", "shop", 7, "alice", "req-1")}
+	objs[0].SetAnnotations(map[string]string{kausalityv1alpha1.TraceAnnotation: existing.String()})
+	c := fake.NewClientBuilder().WithObjects(objs...).Build()
+
+	// Only ReplicaSets are backfilled; the traced Deployment is read, not written
+	results, err := New(c, Options{Kinds: []schema.GroupVersionKind{replicaSetGVK}}).Run(t.Context())
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+
+	replicaSet := storedTrace(t, c, &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "web-abc", Namespace: "default"}})
+	require.Len(t, replicaSet, 2)
+	assert.Equal(t, "shop", replicaSet[0].Name)
+	assert.Equal(t, "alice", replicaSet[0].User)
+
+	pod := &corev1.Pod{}
+	require.NoError(t, c.Get(t.Context(), client.ObjectKey{Namespace: "default", Name: "web-abc-x"}, pod))
+	assert.Empty(t, pod.Annotations[kausalityv1alpha1.TraceAnnotation], "pods are not backfilled")
+}
+
+func TestRun_DryRun(t *testing.T) {
+	c := fake.NewClientBuilder().WithObjects(hierarchy()...).Build()
+	results, err := New(c, Options{Kinds: []schema.GroupVersionKind{deploymentGVK, replicaSetGVK, podGVK}, DryRun: true}).Run(t.Context())
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	assert.Len(t, results[2].Trace, 3)
+	for _, r := range results {
+		assert.False(t, r.Skipped)
+	}
+
+	pod := &corev1.Pod{}
+	require.NoError(t, c.Get(t.Context(), client.ObjectKey{Namespace: "default", Name: "web-abc-x"}, pod))
+	assert.Empty(t, pod.Annotations[kausalityv1alpha1.TraceAnnotation])
+}
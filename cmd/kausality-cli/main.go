@@ -9,19 +9,27 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kausality-io/kausality/cmd/kausality-cli/pkg/cli"
+	"github.com/kausality-io/kausality/pkg/config"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "inject-drift" {
+		injectDrift(os.Args[2:])
+		return
+	}
+
 	var (
 		kubeconfig string
 		namespace  string
 		group      string
 		version    string
 		kind       string
+		prefix     string
 	)
 
 	flag.StringVar(&kubeconfig, "kubeconfig", "", "Path to kubeconfig file (default: $KUBECONFIG or ~/.kube/config)")
@@ -29,6 +37,7 @@ func main() {
 	flag.StringVar(&group, "group", "", "API group of resources to monitor")
 	flag.StringVar(&version, "version", "v1", "API version of resources to monitor")
 	flag.StringVar(&kind, "kind", "", "Kind of resources to monitor (required)")
+	flag.StringVar(&prefix, "annotation-prefix", "", "Annotation prefix of the webhook's annotationPrefix setting (default: kausality.io/)")
 	flag.Parse()
 
 	if kind == "" {
@@ -37,30 +46,11 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Build kubeconfig
-	if kubeconfig == "" {
-		kubeconfig = os.Getenv("KUBECONFIG")
-		if kubeconfig == "" {
-			home, _ := os.UserHomeDir()
-			kubeconfig = home + "/.kube/config"
-		}
-	}
-
-	config, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error building kubeconfig: %v\n", err)
-		os.Exit(1)
-	}
-
-	// Create client
-	k8sClient, err := client.New(config, client.Options{})
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
-		os.Exit(1)
-	}
+	k8sClient := newClient(kubeconfig)
 
 	// Create CLI client
 	cliClient := cli.NewClient(k8sClient, namespace)
+	setAnnotationKeys(cliClient, prefix)
 
 	// Build GVK
 	gvk := schema.GroupVersionKind{
@@ -87,3 +77,73 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// injectDrift triggers a synthetic drift on a child via a server-side dry-run request.
+func injectDrift(args []string) {
+	fs := flag.NewFlagSet("inject-drift", flag.ExitOnError)
+	kubeconfig := fs.String("kubeconfig", "", "Path to kubeconfig file (default: $KUBECONFIG or ~/.kube/config)")
+	child := fs.String("child", "", "Child to inject drift into: <Kind>[.<group>]/<namespace>/<name> (required)")
+	version := fs.String("version", "v1", "API version of the child")
+	prefix := fs.String("annotation-prefix", "", "Annotation prefix of the webhook's annotationPrefix setting (default: kausality.io/)")
+	_ = fs.Parse(args)
+
+	if *child == "" {
+		fmt.Fprintln(os.Stderr, "Error: --child is required")
+		fs.Usage()
+		os.Exit(1)
+	}
+	ref, err := cli.ParseChildRef(*child, *version)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	cliClient := cli.NewClient(newClient(*kubeconfig), ref.Namespace)
+	setAnnotationKeys(cliClient, *prefix)
+	if err := cliClient.InjectDrift(context.Background(), ref); err != nil {
+		// In enforce mode the injected drift is denied - that is the expected outcome
+		fmt.Printf("Synthetic drift denied: %v\n", err)
+		return
+	}
+	fmt.Println("Synthetic drift allowed (see warnings above). Check your drift callbacks for a report with synthetic: true.")
+}
+
+// setAnnotationKeys makes the client use the annotation keys under prefix, validated
+// like the webhook's annotationPrefix. An empty prefix keeps the kausality.io/ keys.
+func setAnnotationKeys(c *cli.Client, prefix string) {
+	if prefix == "" {
+		return
+	}
+	cfg := config.Default()
+	cfg.AnnotationPrefix = prefix
+	if err := cfg.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	c.SetAnnotationKeys(cfg.AnnotationKeys())
+}
+
+// newClient builds a Kubernetes client that prints admission warnings to stderr.
+func newClient(kubeconfig string) client.Client {
+	if kubeconfig == "" {
+		kubeconfig = os.Getenv("KUBECONFIG")
+		if kubeconfig == "" {
+			home, _ := os.UserHomeDir()
+			kubeconfig = home + "/.kube/config"
+		}
+	}
+
+	config, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error building kubeconfig: %v\n", err)
+		os.Exit(1)
+	}
+	config.WarningHandler = rest.NewWarningWriter(os.Stderr, rest.WarningWriterOptions{})
+
+	k8sClient, err := client.New(config, client.Options{})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+		os.Exit(1)
+	}
+	return k8sClient
+}
@@ -8,6 +8,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	kausalityv1alpha1 "github.com/kausality-io/kausality/api/v1alpha1"
 	"github.com/kausality-io/kausality/pkg/approval"
 )
 
@@ -15,6 +16,7 @@ import (
 type Client struct {
 	k8s       client.Client
 	applier   *approval.ActionApplier
+	keys      kausalityv1alpha1.AnnotationKeys
 	namespace string
 }
 
@@ -23,10 +25,18 @@ func NewClient(k8s client.Client, namespace string) *Client {
 	return &Client{
 		k8s:       k8s,
 		applier:   approval.NewActionApplier(k8s),
+		keys:      kausalityv1alpha1.DefaultAnnotationKeys,
 		namespace: namespace,
 	}
 }
 
+// SetAnnotationKeys reads and writes the annotations of keys rather than the
+// kausality.io/ ones, for webhooks configured with another annotationPrefix.
+func (c *Client) SetAnnotationKeys(keys kausalityv1alpha1.AnnotationKeys) {
+	c.keys = keys
+	c.applier.SetAnnotationKeys(keys)
+}
+
 // ListDrifts returns all objects with drift annotations in the namespace
 func (c *Client) ListDrifts(ctx context.Context, gvk schema.GroupVersionKind) ([]DriftItem, error) {
 	list := &unstructured.UnstructuredList{}
@@ -49,7 +59,7 @@ func (c *Client) ListDrifts(ctx context.Context, gvk schema.GroupVersionKind) ([
 		}
 
 		// Check for pending approvals (indicates drift)
-		approvalsStr := annotations[approval.ApprovalsAnnotation]
+		approvalsStr := annotations[c.keys.Approvals]
 		if approvalsStr == "" {
 			continue
 		}
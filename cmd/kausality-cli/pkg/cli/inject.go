@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ChildRef identifies the child object to inject a synthetic drift into.
+type ChildRef struct {
+	GVK       schema.GroupVersionKind
+	Namespace string
+	Name      string
+}
+
+// ParseChildRef parses "<Kind>[.<group>]/<namespace>/<name>" with the given version.
+// Cluster-scoped objects use "<Kind>[.<group>]/<name>".
+func ParseChildRef(ref, version string) (ChildRef, error) {
+	parts := strings.Split(ref, "/")
+	if len(parts) < 2 || len(parts) > 3 {
+		return ChildRef{}, fmt.Errorf("invalid child reference %q: expected <Kind>[.<group>]/<namespace>/<name>", ref)
+	}
+	kind, group, _ := strings.Cut(parts[0], ".")
+	result := ChildRef{GVK: schema.GroupVersionKind{Group: group, Version: version, Kind: kind}}
+	if len(parts) == 3 {
+		result.Namespace, result.Name = parts[1], parts[2]
+	} else {
+		result.Name = parts[1]
+	}
+	if result.GVK.Kind == "" || result.Name == "" {
+		return ChildRef{}, fmt.Errorf("invalid child reference %q: kind and name must not be empty", ref)
+	}
+	return result, nil
+}
+
+// InjectDrift sends a server-side dry-run patch that sets the synthetic drift annotation
+// on the child. Kausality treats the request as drift and runs the enforcement decision
+// and callbacks; nothing is persisted. A denial in enforce mode is returned as error.
+func (c *Client) InjectDrift(ctx context.Context, child ChildRef) error {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(child.GVK)
+	obj.SetNamespace(child.Namespace)
+	obj.SetName(child.Name)
+
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:"true"}}}`, c.keys.SyntheticDrift)
+	return c.k8s.Patch(ctx, obj, client.RawPatch(types.MergePatchType, []byte(patch)), client.DryRunAll)
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/go-logr/logr"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kausality-io/kausality/pkg/admission"
+	"github.com/kausality-io/kausality/pkg/config"
+)
+
+func main() {
+	var (
+		parentFile   string
+		childFile    string
+		oldChildFile string
+		operation    string
+		user         string
+		fieldManager string
+		configFile   string
+	)
+
+	flag.StringVar(&parentFile, "parent", "", "Path to the parent object YAML (required)")
+	flag.StringVar(&childFile, "child", "", "Path to the child object YAML as written by the mutation (required)")
+	flag.StringVar(&oldChildFile, "old-child", "", "Path to the child object YAML before the mutation (required for UPDATE)")
+	flag.StringVar(&operation, "operation", "", "Operation of the mutation: CREATE, UPDATE or DELETE (default: UPDATE with --old-child, CREATE otherwise)")
+	flag.StringVar(&user, "user", "", "Username of the request")
+	flag.StringVar(&fieldManager, "field-manager", "", "Field manager of the request")
+	flag.StringVar(&configFile, "config", "", "Path to the webhook configuration file (default: built-in defaults)")
+	flag.Parse()
+
+	if err := run(os.Stdout, parentFile, childFile, oldChildFile, operation, user, fieldManager, configFile); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run explains the mutation of a child against a fake client seeded with the parent and
+// the child as stored before the mutation.
+func run(out io.Writer, parentFile, childFile, oldChildFile, operation, user, fieldManager, configFile string) error {
+	if parentFile == "" || childFile == "" {
+		return fmt.Errorf("--parent and --child are required")
+	}
+
+	op := admissionv1.Operation(strings.ToUpper(operation))
+	if op == "" {
+		op = admissionv1.Create
+		if oldChildFile != "" {
+			op = admissionv1.Update
+		}
+	}
+	if op == admissionv1.Update && oldChildFile == "" {
+		return fmt.Errorf("--old-child is required for UPDATE")
+	}
+
+	driftConfig := config.Default()
+	if configFile != "" {
+		var err error
+		if driftConfig, err = config.Load(configFile); err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+	}
+
+	parent, err := readObject(parentFile)
+	if err != nil {
+		return err
+	}
+	child, err := readObject(childFile)
+	if err != nil {
+		return err
+	}
+	var oldChild *unstructured.Unstructured
+	if oldChildFile != "" {
+		if oldChild, err = readObject(oldChildFile); err != nil {
+			return err
+		}
+	}
+
+	// The child exists before UPDATE and DELETE only
+	objs := []client.Object{parent}
+	newChild := child
+	switch op {
+	case admissionv1.Create:
+		oldChild = nil
+	case admissionv1.Update:
+		objs = append(objs, oldChild)
+	case admissionv1.Delete:
+		oldChild, newChild = child, nil
+		objs = append(objs, child)
+	default:
+		return fmt.Errorf("unsupported operation %q: must be CREATE, UPDATE or DELETE", operation)
+	}
+
+	handler := admission.NewHandler(admission.Config{
+		Client:      fake.NewClientBuilder().WithObjects(objs...).Build(),
+		Log:         logr.Discard(),
+		DriftConfig: driftConfig,
+	})
+	req, err := admission.NewExplainRequest(op, oldChild, newChild, user, fieldManager)
+	if err != nil {
+		return err
+	}
+	explanation, err := handler.Explain(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	printExplanation(out, explanation)
+	return nil
+}
+
+// readObject reads a single object from a YAML or JSON file.
+func readObject(path string) (*unstructured.Unstructured, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	obj := &unstructured.Unstructured{}
+	if err := yaml.Unmarshal(data, &obj.Object); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if obj.GetAPIVersion() == "" || obj.GetKind() == "" {
+		return nil, fmt.Errorf("%s: apiVersion and kind are required", path)
+	}
+	return obj, nil
+}
+
+// printExplanation prints the explanation as aligned "field: value" lines.
+func printExplanation(out io.Writer, e *admission.Explanation) {
+	parent := "<none>"
+	if e.Parent != nil {
+		parent = fmt.Sprintf("%s %s %s/%s", e.Parent.APIVersion, e.Parent.Kind, e.Parent.Namespace, e.Parent.Name)
+	}
+
+	approval := "none"
+	switch {
+	case e.Approval.Rejected:
+		approval = "rejected"
+	case e.Approval.Approved:
+		approval = "approved"
+	}
+	if e.Approval.MatchedApproval != nil {
+		a := e.Approval.MatchedApproval
+		approval += fmt.Sprintf(" by %s %s %s (mode %s)", a.APIVersion, a.Kind, a.Name, a.EffectiveMode())
+	}
+	if e.Approval.MatchedRejection != nil {
+		r := e.Approval.MatchedRejection
+		approval += fmt.Sprintf(" by %s %s %s", r.APIVersion, r.Kind, r.Name)
+	}
+	if e.Approval.Reason != "" {
+		approval += ": " + e.Approval.Reason
+	}
+
+	freeze := "no"
+	if e.Freeze != nil {
+		freeze = "yes"
+	}
+	if e.BreakGlass {
+		freeze += " (break-glass token)"
+	}
+
+	decision := "ALLOW"
+	if !e.Allowed {
+		decision = "DENY"
+	}
+	if e.Message != "" {
+		decision += ": " + e.Message
+	}
+
+	fmt.Fprintf(out, "Parent:   %s\n", parent)
+	fmt.Fprintf(out, "Phase:    %s\n", valueOrNone(string(e.Phase)))
+	fmt.Fprintf(out, "Drift:    %t (%s)\n", e.DriftDetected, e.Reason)
+	fmt.Fprintf(out, "Frozen:   %s\n", freeze)
+	fmt.Fprintf(out, "Approval: %s\n", approval)
+	fmt.Fprintf(out, "Mode:     %s\n", valueOrNone(e.Mode))
+	fmt.Fprintf(out, "Decision: %s\n", decision)
+}
+
+func valueOrNone(s string) string {
+	if s == "" {
+		return "<none>"
+	}
+	return s
+}
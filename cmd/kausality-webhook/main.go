@@ -6,23 +6,32 @@ import (
 	"flag"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/go-logr/logr"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
 	kausalityv1alpha1 "github.com/kausality-io/kausality/api/v1alpha1"
 	"github.com/kausality-io/kausality/cmd/kausality-webhook/pkg/webhook"
+	"github.com/kausality-io/kausality/pkg/admission"
+	"github.com/kausality-io/kausality/pkg/approval"
+	"github.com/kausality-io/kausality/pkg/baseline"
+	"github.com/kausality-io/kausality/pkg/breakglass"
 	"github.com/kausality-io/kausality/pkg/callback"
 	"github.com/kausality-io/kausality/pkg/config"
+	"github.com/kausality-io/kausality/pkg/health"
+	"github.com/kausality-io/kausality/pkg/lineage"
 	"github.com/kausality-io/kausality/pkg/policy"
 )
 
@@ -40,17 +49,38 @@ func main() {
 		host                   string
 		port                   int
 		certDir                string
+		socketPath             string
+		socketTLS              bool
 		healthProbeBindAddress string
 		configFile             string
+		configMap              string
 		metricsAddr            string
+		parallelReads          bool
+		createCacheTTL         time.Duration
+		fieldManager           string
+		pauseCallbacks         bool
+		writeTraceCondition    bool
+		writeTraceParent       bool
+		debugEndpoints         bool
 	)
 
 	flag.StringVar(&host, "host", "", "The address to bind to (default: all interfaces)")
 	flag.IntVar(&port, "port", 9443, "The port to listen on for webhook requests")
 	flag.StringVar(&certDir, "cert-dir", "/etc/webhook/certs", "The directory containing tls.crt and tls.key")
+	flag.StringVar(&socketPath, "socket-path", "", "Serve webhook requests as plain HTTP on this unix socket instead of TLS on host:port (for sidecar deployments)")
+	flag.StringVar(&socketPath, "listen-socket", "", "Alias of --socket-path")
+	flag.BoolVar(&socketTLS, "socket-tls", false, "Serve TLS on the unix socket, with the certificate in --cert-dir")
 	flag.StringVar(&healthProbeBindAddress, "health-probe-bind-address", ":8081", "The address for health probes")
 	flag.StringVar(&configFile, "config", "", "Path to config file (optional, for drift callbacks)")
+	flag.StringVar(&configMap, "config-map", "", "Read the config from the config.yaml entry of this ConfigMap, as <namespace>/<name>, and reload it on change instead of --config")
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8082", "The address for metrics endpoint")
+	flag.BoolVar(&parallelReads, "parallel-reads", false, "Issue parent and namespace reads concurrently with drift detection to reduce admission latency")
+	flag.DurationVar(&createCacheTTL, "create-cache-ttl", 0, "Reuse the parent read for CREATEs of sibling children within this duration (0 disables)")
+	flag.StringVar(&fieldManager, "field-manager", admission.DefaultFieldManager, "Field manager of kausality's own writes; writes by it are never treated as controller actions")
+	flag.BoolVar(&pauseCallbacks, "pause-callbacks", false, "Start with drift callbacks paused; SIGUSR1 pauses and SIGUSR2 resumes them at runtime")
+	flag.BoolVar(&writeTraceCondition, "write-trace-condition", false, "Also set a CausalTrace condition summarizing the trace on objects that have status.conditions")
+	flag.BoolVar(&writeTraceParent, "write-traceparent", false, "Also stamp the trace as a W3C traceparent in the kausality.io/traceparent annotation")
+	flag.BoolVar(&debugEndpoints, "debug-endpoints", false, "Serve GET /debug/policies on the webhook server, a read-only dump of the loaded policies (unauthenticated)")
 
 	opts := zap.Options{
 		Development: true,
@@ -65,8 +95,11 @@ func main() {
 		"host", host,
 		"port", port,
 		"certDir", certDir,
+		"socketPath", socketPath,
+		"socketTLS", socketTLS,
 		"healthProbeBindAddress", healthProbeBindAddress,
 		"configFile", configFile,
+		"configMap", configMap,
 	)
 
 	// Create controller manager for watch-based policy updates
@@ -82,9 +115,50 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Verify RBAC up front: missing permissions would otherwise silently weaken enforcement
+	preflightCtx, preflightCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	missing, err := webhook.MissingPermissions(preflightCtx, mgr.GetClient(), webhook.RequiredPermissions)
+	preflightCancel()
+	if err != nil {
+		log.Error(err, "unable to verify RBAC permissions")
+	}
+	for _, p := range missing {
+		log.Error(nil, "missing RBAC permission", "permission", p.String(), "impact", p.Purpose)
+	}
+
+	// Setup signal handling context
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
 	// Load config (optional, for drift callbacks)
 	var driftConfig *config.Config
-	if configFile != "" {
+	var driftConfigSource func() *config.Config
+	if configMap != "" {
+		if configFile != "" {
+			log.Error(nil, "--config and --config-map are mutually exclusive")
+			os.Exit(1)
+		}
+		namespace, name, ok := strings.Cut(configMap, "/")
+		if !ok || namespace == "" || name == "" {
+			log.Error(nil, "invalid --config-map, must be <namespace>/<name>", "configMap", configMap)
+			os.Exit(1)
+		}
+		watchClient, err := client.NewWithWatch(ctrl.GetConfigOrDie(), client.Options{Scheme: scheme})
+		if err != nil {
+			log.Error(err, "unable to create client")
+			os.Exit(1)
+		}
+		watcher, err := config.WatchConfigMap(logr.NewContext(ctx, log), watchClient, namespace, name)
+		if err != nil {
+			log.Error(err, "unable to load config ConfigMap", "configMap", configMap)
+			os.Exit(1)
+		}
+		driftConfig, driftConfigSource = watcher.Get(), watcher.Get
+		log.Info("loaded config from ConfigMap, reloading on change",
+			"configMap", configMap,
+			"backends", len(driftConfig.Backends),
+		)
+	} else if configFile != "" {
 		driftConfig, err = config.Load(configFile)
 		if err != nil {
 			log.Error(err, "unable to load config file", "path", configFile)
@@ -100,20 +174,46 @@ func main() {
 
 	// Create multi-sender if backends are configured
 	var callbackSender callback.ReportSender
+	var multiSender *callback.MultiSender
 	if len(driftConfig.Backends) > 0 {
 		senderConfigs := make([]callback.SenderConfig, len(driftConfig.Backends))
 		for i, backend := range driftConfig.Backends {
 			senderConfigs[i] = callback.SenderConfig{
-				URL:           backend.URL,
-				CAFile:        backend.CAFile,
-				Timeout:       backend.Timeout,
-				RetryCount:    backend.RetryCount,
-				RetryInterval: backend.RetryInterval,
-				Log:           log,
+				URL:              backend.URL,
+				CAFile:           backend.CAFile,
+				Timeout:          backend.Timeout,
+				RetryCount:       backend.RetryCount,
+				RetryInterval:    backend.RetryInterval,
+				MaxRetryInterval: backend.MaxRetryInterval,
+				MaxInFlight:      backend.MaxInFlight,
+				DedupWindow:      backend.DedupWindow,
+				DedupMaxSize:     backend.DedupMaxSize,
+				Phases:           backend.Phases,
+				Mode:             backend.Mode,
+				QueueSize:        backend.QueueSize,
+				Log:              log,
+			}
+			if k := backend.Kafka; k != nil {
+				kafkaConfig := &callback.KafkaSenderConfig{
+					Brokers:            k.Brokers,
+					Topic:              k.Topic,
+					MaxBufferedRecords: k.MaxBufferedRecords,
+					Log:                log,
+				}
+				if k.TLS != nil {
+					kafkaConfig.TLS = true
+					kafkaConfig.CAFile = k.TLS.CAFile
+				}
+				if k.SASL != nil {
+					kafkaConfig.SASLMechanism = k.SASL.Mechanism
+					kafkaConfig.Username = k.SASL.Username
+					kafkaConfig.PasswordFile = k.SASL.PasswordFile
+				}
+				senderConfigs[i].Kafka = kafkaConfig
 			}
 		}
 
-		multiSender, err := callback.NewMultiSender(senderConfigs, log)
+		multiSender, err = callback.NewMultiSender(senderConfigs, log)
 		if err != nil {
 			log.Error(err, "unable to create drift callback senders")
 			os.Exit(1)
@@ -121,7 +221,54 @@ func main() {
 		if multiSender != nil {
 			callbackSender = multiSender
 			log.Info("drift callbacks enabled", "backends", multiSender.Len())
+			multiSender.SetPaused(pauseCallbacks)
+		}
+	}
+
+	// Create break-glass verifier if configured
+	var breakGlassVerifier *breakglass.Verifier
+	if driftConfig.BreakGlass != nil {
+		publicKey, err := breakglass.LoadPublicKey(driftConfig.BreakGlass.PublicKeyFile)
+		if err != nil {
+			log.Error(err, "unable to load break-glass public key")
+			os.Exit(1)
+		}
+		breakGlassVerifier = breakglass.NewVerifier(publicKey, driftConfig.BreakGlass.MaxTokenAge)
+		log.Info("break-glass tokens enabled", "publicKeyFile", driftConfig.BreakGlass.PublicKeyFile)
+	}
+
+	// Create OpenLineage exporter if configured
+	var lineageExporter *lineage.Exporter
+	if ol := driftConfig.OpenLineage; ol != nil {
+		var apiKey string
+		if ol.APIKeyFile != "" {
+			key, err := os.ReadFile(ol.APIKeyFile)
+			if err != nil {
+				log.Error(err, "unable to read OpenLineage API key file")
+				os.Exit(1)
+			}
+			apiKey = strings.TrimSpace(string(key))
+		}
+		lineageExporter, err = lineage.NewExporter(lineage.ExporterConfig{
+			URL:              ol.URL,
+			APIKey:           apiKey,
+			Timeout:          ol.Timeout,
+			JobNamespace:     ol.JobNamespace,
+			DatasetNamespace: ol.DatasetNamespace,
+			Log:              log,
+		})
+		if err != nil {
+			log.Error(err, "unable to create OpenLineage exporter")
+			os.Exit(1)
 		}
+		log.Info("OpenLineage export enabled", "url", ol.URL)
+	}
+
+	// Create cluster health signal if configured (uncached reads, polled by the handler)
+	var healthSignal health.Signal
+	if hs := driftConfig.DriftDetection.HealthSignal; hs != nil && hs.ConfigMap != nil {
+		healthSignal = health.NewConfigMapSignal(mgr.GetAPIReader(), hs.ConfigMap.Namespace, hs.ConfigMap.Name, hs.ConfigMap.Key)
+		log.Info("cluster health signal enabled", "configMap", hs.ConfigMap.Namespace+"/"+hs.ConfigMap.Name)
 	}
 
 	// Create policy store (uses manager's client which has caching)
@@ -134,9 +281,29 @@ func main() {
 	}
 	log.Info("policy watcher configured (watch-driven, instant updates)")
 
-	// Setup signal handling context
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	// Create baseline store, kept up to date the same way
+	baselineStore := baseline.NewStore(mgr.GetClient(), log)
+	if err := baseline.SetupWatcher(mgr, baselineStore, log); err != nil {
+		log.Error(err, "unable to set up baseline watcher")
+		os.Exit(1)
+	}
+
+	// Periodically prune stale approvals from parents that are not mutated again
+	if as := driftConfig.ApprovalSweep; as != nil && as.Interval > 0 {
+		kinds := make([]schema.GroupVersionKind, 0, len(as.Parents))
+		for _, p := range as.Parents {
+			kinds = append(kinds, schema.FromAPIVersionAndKind(p.APIVersion, p.Kind))
+		}
+		var orphans *approval.OrphanTracker
+		if ttl := driftConfig.DriftDetection.OrphanApprovalTTL; ttl > 0 {
+			orphans = approval.NewOrphanTracker(ttl, driftConfig.DriftDetection.OrphanApprovalAction == config.OrphanApprovalRemove)
+		}
+		if err := approval.SetupSweeper(mgr, kinds, as.Interval, fieldManager, driftConfig.AnnotationKeys(), orphans, log); err != nil {
+			log.Error(err, "unable to set up approval sweeper")
+			os.Exit(1)
+		}
+		log.Info("approval sweep enabled", "interval", as.Interval, "parents", len(kinds), "orphanApprovalTTL", driftConfig.DriftDetection.OrphanApprovalTTL)
+	}
 
 	// Start manager in background (runs the policy watcher)
 	go func() {
@@ -151,9 +318,9 @@ func main() {
 	syncCtx, syncCancel := context.WithTimeout(ctx, 30*time.Second)
 	defer syncCancel()
 	if !mgr.GetCache().WaitForCacheSync(syncCtx) {
-		log.Error(nil, "cache sync timed out, continuing without watch-driven updates")
+		log.Error(nil, "cache sync timed out, not ready until the policy store has synced")
 	} else {
-		log.Info("cache synced, policy store ready")
+		log.Info("cache synced")
 	}
 
 	// Create and start webhook server
@@ -163,20 +330,54 @@ func main() {
 		Host:                   host,
 		Port:                   port,
 		CertDir:                certDir,
+		SocketPath:             socketPath,
+		SocketTLS:              socketTLS,
 		HealthProbeBindAddress: healthProbeBindAddress,
+		Ready:                  policyStore.IsReady,
 		DriftConfig:            driftConfig,
+		DriftConfigSource:      driftConfigSource,
 		CallbackSender:         callbackSender,
 		PolicyResolver:         policyStore,
+		BreakGlassVerifier:     breakGlassVerifier,
+		ParallelReads:          parallelReads,
+		CreateCacheTTL:         createCacheTTL,
+		FieldManager:           fieldManager,
+		LineageExporter:        lineageExporter,
+		HealthSignal:           healthSignal,
+		Baselines:              baselineStore,
+		EventRecorder:          mgr.GetEventRecorder("kausality"),
+		WriteTraceCondition:    writeTraceCondition,
+		WriteTraceParent:       writeTraceParent,
+		DebugEndpoints:         debugEndpoints,
 	})
 
 	server.Register()
 
 	go handleSignals(ctx, cancel, log)
 
+	// Pause and resume callbacks at runtime, by signal and by ConfigMap flag
+	if multiSender != nil {
+		go handlePauseSignals(ctx, multiSender)
+		if cp := driftConfig.CallbackPause; cp != nil {
+			pause := callback.NewConfigMapPause(mgr.GetAPIReader(), cp.ConfigMap.Namespace, cp.ConfigMap.Name, cp.ConfigMap.Key)
+			go multiSender.WatchPause(ctx, pause, cp.CheckInterval)
+			log.Info("callback pause flag enabled", "configMap", cp.ConfigMap.Namespace+"/"+cp.ConfigMap.Name)
+		}
+	}
+
 	if err := server.Start(ctx); err != nil {
 		log.Error(err, "webhook server failed")
 		os.Exit(1)
 	}
+
+	// Flush reports still buffered by Kafka backends and queued for observe backends
+	if multiSender != nil {
+		flushCtx, flushCancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer flushCancel()
+		if err := multiSender.Close(flushCtx); err != nil {
+			log.Error(err, "unable to flush drift callbacks")
+		}
+	}
 }
 
 func handleSignals(ctx context.Context, cancel context.CancelFunc, log logr.Logger) {
@@ -190,3 +391,19 @@ func handleSignals(ctx context.Context, cancel context.CancelFunc, log logr.Logg
 	case <-ctx.Done():
 	}
 }
+
+// handlePauseSignals pauses callbacks on SIGUSR1 and resumes them on SIGUSR2.
+func handlePauseSignals(ctx context.Context, sender *callback.MultiSender) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGUSR1, syscall.SIGUSR2)
+	defer signal.Stop(sigCh)
+
+	for {
+		select {
+		case sig := <-sigCh:
+			sender.SetPaused(sig == syscall.SIGUSR1)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kausalityv1alpha1 "github.com/kausality-io/kausality/api/v1alpha1"
+	"github.com/kausality-io/kausality/pkg/admission"
+	"github.com/kausality-io/kausality/pkg/policy"
+)
+
+// PolicySnapshotter returns the policies a resolver currently holds, e.g. *policy.Store.
+type PolicySnapshotter interface {
+	Snapshot() []kausalityv1alpha1.Kausality
+}
+
+// DebugPoliciesResponse is the result of a GET /debug/policies request.
+type DebugPoliciesResponse struct {
+	// Policies are the policies the resolver holds. Empty if it holds none, or does not
+	// implement PolicySnapshotter.
+	Policies []kausalityv1alpha1.Kausality `json:"policies"`
+	// Resolved is the resolution for the resource given by the query, if any.
+	Resolved *DebugPoliciesResolution `json:"resolved,omitempty"`
+}
+
+// DebugPoliciesResolution is the mode the resolver picks for a resource.
+type DebugPoliciesResolution struct {
+	Group     string `json:"group"`
+	Kind      string `json:"kind"`
+	Resource  string `json:"resource"`
+	Namespace string `json:"namespace,omitempty"`
+	// Mode is the mode for objects without a kausality.io/mode annotation.
+	Mode kausalityv1alpha1.Mode `json:"mode"`
+	// Tracked is true if any policy matches the resource.
+	Tracked bool `json:"tracked"`
+}
+
+// DebugPoliciesHandler serves GET /debug/policies: a read-only dump of the resolver's
+// policies. With ?kind= (and optionally group= and namespace=), it also returns the
+// mode the resolver picks for such a resource, taking the namespace's labels and
+// annotations into account if c is set.
+func DebugPoliciesHandler(resolver policy.Resolver, c client.Client) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		resp := DebugPoliciesResponse{Policies: []kausalityv1alpha1.Kausality{}}
+		if snapshotter, ok := resolver.(PolicySnapshotter); ok {
+			resp.Policies = snapshotter.Snapshot()
+		}
+
+		query := r.URL.Query()
+		if kind := query.Get("kind"); kind != "" {
+			resolved := &DebugPoliciesResolution{
+				Group:     query.Get("group"),
+				Kind:      kind,
+				Resource:  admission.KindToResource(kind),
+				Namespace: query.Get("namespace"),
+			}
+			resourceCtx := policy.ResourceContext{
+				GVR:       schema.GroupVersionResource{Group: resolved.Group, Resource: resolved.Resource},
+				Kind:      kind,
+				Namespace: resolved.Namespace,
+			}
+			var nsAnnotations map[string]string
+			if resolved.Namespace != "" && c != nil {
+				ns := &unstructured.Unstructured{}
+				ns.SetAPIVersion("v1")
+				ns.SetKind("Namespace")
+				// A namespace that does not exist yet resolves as one without labels
+				if err := c.Get(r.Context(), client.ObjectKey{Name: resolved.Namespace}, ns); err != nil && !apierrors.IsNotFound(err) {
+					http.Error(w, fmt.Sprintf("failed to get namespace: %v", err), http.StatusInternalServerError)
+					return
+				}
+				resourceCtx.NamespaceLabels = ns.GetLabels()
+				nsAnnotations = ns.GetAnnotations()
+			}
+			resolved.Mode = resolver.ResolveMode(resourceCtx, nil, nsAnnotations)
+			resolved.Tracked = resolver.IsTracked(resourceCtx)
+			resp.Resolved = resolved
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+}
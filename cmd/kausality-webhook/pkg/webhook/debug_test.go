@@ -0,0 +1,90 @@
+package webhook
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kausalityv1alpha1 "github.com/kausality-io/kausality/api/v1alpha1"
+	"github.com/kausality-io/kausality/pkg/policy"
+)
+
+func TestDebugPoliciesHandler(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(kausalityv1alpha1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&kausalityv1alpha1.Kausality{
+			ObjectMeta: metav1.ObjectMeta{Name: "apps"},
+			Spec: kausalityv1alpha1.KausalitySpec{
+				Resources: []kausalityv1alpha1.ResourceRule{{APIGroups: []string{"apps"}, Resources: []string{"deployments"}}},
+				Mode:      kausalityv1alpha1.ModeEnforce,
+			},
+		},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "dev", Annotations: map[string]string{policy.ModeAnnotation: "warn"}}},
+	).Build()
+	store := policy.NewStore(c, logr.Discard())
+	require.NoError(t, store.Refresh(t.Context()))
+
+	get := func(t *testing.T, resolver policy.Resolver, target string) DebugPoliciesResponse {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		DebugPoliciesHandler(resolver, c).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var resp DebugPoliciesResponse
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+		return resp
+	}
+
+	t.Run("policies", func(t *testing.T) {
+		resp := get(t, store, "/debug/policies")
+		require.Len(t, resp.Policies, 1)
+		assert.Equal(t, "apps", resp.Policies[0].Name)
+		assert.Nil(t, resp.Resolved)
+	})
+
+	t.Run("resolved by policy", func(t *testing.T) {
+		resp := get(t, store, "/debug/policies?group=apps&kind=Deployment&namespace=default")
+		require.NotNil(t, resp.Resolved)
+		assert.Equal(t, "deployments", resp.Resolved.Resource)
+		assert.Equal(t, kausalityv1alpha1.ModeEnforce, resp.Resolved.Mode)
+		assert.True(t, resp.Resolved.Tracked)
+	})
+
+	t.Run("resolved by namespace annotation", func(t *testing.T) {
+		resp := get(t, store, "/debug/policies?group=apps&kind=Deployment&namespace=dev")
+		require.NotNil(t, resp.Resolved)
+		assert.Equal(t, kausalityv1alpha1.ModeWarn, resp.Resolved.Mode)
+	})
+
+	t.Run("untracked resource", func(t *testing.T) {
+		resp := get(t, store, "/debug/policies?kind=ConfigMap")
+		require.NotNil(t, resp.Resolved)
+		assert.Equal(t, kausalityv1alpha1.ModeLog, resp.Resolved.Mode)
+		assert.False(t, resp.Resolved.Tracked)
+	})
+
+	t.Run("resolver without snapshot", func(t *testing.T) {
+		resp := get(t, &policy.StaticResolver{Mode: kausalityv1alpha1.ModeEnforce}, "/debug/policies?kind=ConfigMap")
+		assert.Empty(t, resp.Policies)
+		assert.Equal(t, kausalityv1alpha1.ModeEnforce, resp.Resolved.Mode)
+	})
+
+	t.Run("read-only", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		DebugPoliciesHandler(store, c).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/debug/policies", nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+}
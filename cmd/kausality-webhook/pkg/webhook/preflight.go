@@ -0,0 +1,60 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Permission is an RBAC permission the webhook needs.
+type Permission struct {
+	// Group is the API group, "" for the core group.
+	Group string
+	// Resource is the plural resource name.
+	Resource string
+	// Verb is the verb checked.
+	Verb string
+	// Purpose describes what breaks without the permission.
+	Purpose string
+}
+
+// String returns the permission as "verb group/resource".
+func (p Permission) String() string {
+	if p.Group == "" {
+		return p.Verb + " " + p.Resource
+	}
+	return p.Verb + " " + p.Group + "/" + p.Resource
+}
+
+// RequiredPermissions are the permissions the webhook needs at admission time.
+var RequiredPermissions = []Permission{
+	{Resource: "namespaces", Verb: "get", Purpose: "namespace selectors and namespace annotations do not apply"},
+	{Group: "kausality.io", Resource: "kausalities", Verb: "list", Purpose: "Kausality policies are not applied"},
+	{Group: "kausality.io", Resource: "baselines", Verb: "list", Purpose: "baselines are not applied"},
+}
+
+// MissingPermissions returns the permissions the webhook's own identity lacks, checked
+// with SelfSubjectAccessReviews, cluster-wide.
+func MissingPermissions(ctx context.Context, c client.Client, perms []Permission) ([]Permission, error) {
+	var missing []Permission
+	for _, p := range perms {
+		review := &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Group:    p.Group,
+					Resource: p.Resource,
+					Verb:     p.Verb,
+				},
+			},
+		}
+		if err := c.Create(ctx, review); err != nil {
+			return nil, fmt.Errorf("failed to check permission to %s: %w", p, err)
+		}
+		if !review.Status.Allowed {
+			missing = append(missing, p)
+		}
+	}
+	return missing, nil
+}
@@ -0,0 +1,46 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestMissingPermissions(t *testing.T) {
+	// The webhook may do everything except reading namespaces
+	c := fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			review, ok := obj.(*authorizationv1.SelfSubjectAccessReview)
+			if !ok {
+				return c.Create(ctx, obj, opts...)
+			}
+			review.Status.Allowed = review.Spec.ResourceAttributes.Resource != "namespaces"
+			return nil
+		},
+	}).Build()
+
+	missing, err := MissingPermissions(t.Context(), c, RequiredPermissions)
+	require.NoError(t, err)
+	require.Len(t, missing, 1)
+	assert.Equal(t, "get namespaces", missing[0].String())
+	assert.Equal(t, "list kausality.io/kausalities", RequiredPermissions[1].String())
+}
+
+func TestMissingPermissions_ReviewFails(t *testing.T) {
+	c := fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			return errors.New("connection refused")
+		},
+	}).Build()
+
+	_, err := MissingPermissions(t.Context(), c, RequiredPermissions)
+	assert.ErrorContains(t, err, "failed to check permission to get namespaces")
+}
@@ -5,18 +5,28 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"net"
 	"net/http"
+	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/go-logr/logr"
 
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
+	kausalityv1alpha1 "github.com/kausality-io/kausality/api/v1alpha1"
 	"github.com/kausality-io/kausality/pkg/admission"
+	"github.com/kausality-io/kausality/pkg/baseline"
+	"github.com/kausality-io/kausality/pkg/breakglass"
 	"github.com/kausality-io/kausality/pkg/callback"
 	"github.com/kausality-io/kausality/pkg/config"
+	"github.com/kausality-io/kausality/pkg/health"
+	"github.com/kausality-io/kausality/pkg/lineage"
 	"github.com/kausality-io/kausality/pkg/policy"
 )
 
@@ -36,11 +46,24 @@ type Config struct {
 	CertName string
 	// KeyName is the name of the TLS key file. Defaults to "tls.key".
 	KeyName string
+	// SocketPath, if set, serves webhook requests as plain HTTP on this unix domain socket
+	// instead of TLS on Host:Port. Meant for sidecars co-located with the API server.
+	// The socket file is removed on shutdown.
+	SocketPath string
+	// SocketTLS serves TLS on SocketPath, with the certificate in CertDir.
+	SocketTLS bool
 	// HealthProbeBindAddress is the address for health probes. Defaults to ":8081".
 	HealthProbeBindAddress string
+	// Ready reports whether the server is ready for admission traffic, e.g. whether the
+	// policy store has synced. /readyz answers 503 until it does.
+	// If nil, the server is always ready.
+	Ready func() bool
 	// DriftConfig provides per-resource drift detection configuration.
 	// If nil, defaults to log mode for all resources.
 	DriftConfig *config.Config
+	// DriftConfigSource returns the current drift configuration, e.g. config.Watcher.Get.
+	// Takes precedence over DriftConfig, see admission.Config.DriftConfigSource.
+	DriftConfigSource func() *config.Config
 	// CallbackSender sends drift reports to webhook endpoints.
 	// If nil, drift callbacks are disabled.
 	CallbackSender callback.ReportSender
@@ -48,6 +71,37 @@ type Config struct {
 	// Can be a *policy.Store (CRD-based) or *policy.StaticResolver (in-memory).
 	// If nil, falls back to DriftConfig.
 	PolicyResolver policy.Resolver
+	// BreakGlassVerifier verifies emergency break-glass tokens.
+	// If nil, break-glass tokens are ignored.
+	BreakGlassVerifier *breakglass.Verifier
+	// ParallelReads issues parent and namespace reads concurrently with drift detection.
+	ParallelReads bool
+	// CreateCacheTTL caches parents read for CREATEs to serve bursts of sibling creates.
+	// Zero disables the cache.
+	CreateCacheTTL time.Duration
+	// FieldManager is the field manager of kausality's own writes.
+	// Defaults to admission.DefaultFieldManager.
+	FieldManager string
+	// LineageExporter exports traced mutations as OpenLineage run events.
+	// If nil, lineage export is disabled.
+	LineageExporter *lineage.Exporter
+	// HealthSignal downgrades enforce to log while the cluster is unhealthy.
+	// If nil, enforcement is never downgraded.
+	HealthSignal health.Signal
+	// Baselines declare the changes controllers make to children in normal operation.
+	// If nil, every controller change on a stable parent is drift.
+	Baselines baseline.Matcher
+	// EventRecorder records Events on parents, e.g. when drift is denied.
+	// If nil, no Events are recorded.
+	EventRecorder events.EventRecorder
+	// WriteTraceCondition also sets a CausalTrace condition on objects that have a
+	// status.conditions array.
+	WriteTraceCondition bool
+	// WriteTraceParent also stamps a W3C traceparent annotation derived from the trace.
+	WriteTraceParent bool
+	// DebugEndpoints serves GET /debug/policies, a read-only dump of the policies of
+	// PolicyResolver. Like the webhook paths, it is not authenticated.
+	DebugEndpoints bool
 }
 
 // Server is a standalone webhook server for drift detection.
@@ -95,33 +149,48 @@ func NewServer(cfg Config) *Server {
 // Register registers the admission handler with the webhook server.
 func (s *Server) Register() {
 	handler := admission.NewHandler(admission.Config{
-		Client:         s.config.Client,
-		Log:            s.log,
-		DriftConfig:    s.config.DriftConfig,
-		CallbackSender: s.config.CallbackSender,
-		PolicyResolver: s.config.PolicyResolver,
+		Client:              s.config.Client,
+		Log:                 s.log,
+		DriftConfig:         s.config.DriftConfig,
+		DriftConfigSource:   s.config.DriftConfigSource,
+		CallbackSender:      s.config.CallbackSender,
+		PolicyResolver:      s.config.PolicyResolver,
+		BreakGlassVerifier:  s.config.BreakGlassVerifier,
+		ParallelReads:       s.config.ParallelReads,
+		CreateCacheTTL:      s.config.CreateCacheTTL,
+		FieldManager:        s.config.FieldManager,
+		LineageExporter:     s.config.LineageExporter,
+		HealthSignal:        s.config.HealthSignal,
+		Baselines:           s.config.Baselines,
+		EventRecorder:       s.config.EventRecorder,
+		WriteTraceCondition: s.config.WriteTraceCondition,
+		WriteTraceParent:    s.config.WriteTraceParent,
 	})
 
 	s.webhookServer.Register("/mutate", &webhook.Admission{Handler: handler})
 	s.log.Info("registered kausality webhook", "path", "/mutate")
+
+	s.webhookServer.Register("/validate-approvals", ValidateApprovalsHandler())
+
+	keys := kausalityv1alpha1.DefaultAnnotationKeys
+	if s.config.DriftConfig != nil {
+		keys = s.config.DriftConfig.AnnotationKeys()
+	}
+	s.webhookServer.Register("/validate-annotations", &webhook.Admission{Handler: admission.AnnotationValidator(keys)})
+	s.log.Info("registered annotation validation webhook", "path", "/validate-annotations")
+
+	if s.config.DebugEndpoints && s.config.PolicyResolver != nil {
+		s.webhookServer.Register("/debug/policies", DebugPoliciesHandler(s.config.PolicyResolver, s.config.Client))
+		s.log.Info("registered debug endpoint", "path", "/debug/policies")
+	}
 }
 
 // Start starts the webhook server and health server.
 func (s *Server) Start(ctx context.Context) error {
 	// Start health server
-	healthMux := http.NewServeMux()
-	healthMux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte("ok"))
-	})
-	healthMux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte("ok"))
-	})
-
 	s.healthServer = &http.Server{
 		Addr:    s.config.HealthProbeBindAddress,
-		Handler: healthMux,
+		Handler: s.healthHandler(),
 	}
 
 	// Start health server in background
@@ -132,11 +201,90 @@ func (s *Server) Start(ctx context.Context) error {
 		}
 	}()
 
+	if s.config.SocketPath != "" {
+		return s.serveSocket(ctx)
+	}
+
 	// Start webhook server
 	s.log.Info("starting webhook server", "host", s.config.Host, "port", s.config.Port)
 	return s.webhookServer.Start(ctx)
 }
 
+// healthHandler serves /healthz and /readyz. Readiness is gated on Config.Ready, so that
+// no admission traffic is routed to the server before its policies are loaded.
+func (s *Server) healthHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok"))
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		if s.config.Ready != nil && !s.config.Ready() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte("policy store not synced"))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok"))
+	})
+	return mux
+}
+
+// serveSocket serves the registered webhooks on the unix socket until ctx is done,
+// and removes the socket file when done.
+func (s *Server) serveSocket(ctx context.Context) error {
+	// A socket left behind by a previous run blocks the listener
+	if err := os.Remove(s.config.SocketPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove stale socket: %w", err)
+	}
+	listener, err := net.Listen("unix", s.config.SocketPath)
+	if err != nil {
+		return fmt.Errorf("failed to listen on socket: %w", err)
+	}
+	defer func() {
+		if err := os.Remove(s.config.SocketPath); err != nil && !os.IsNotExist(err) {
+			s.log.Error(err, "failed to remove socket")
+		}
+	}()
+
+	if s.config.SocketTLS {
+		// Like the TCP server, pick up rotated certificates
+		watcher, err := certwatcher.New(filepath.Join(s.config.CertDir, s.config.CertName), filepath.Join(s.config.CertDir, s.config.KeyName))
+		if err != nil {
+			_ = listener.Close()
+			return fmt.Errorf("failed to load socket TLS certificate: %w", err)
+		}
+		go func() {
+			if err := watcher.Start(ctx); err != nil {
+				s.log.Error(err, "certificate watcher failed")
+			}
+		}()
+		listener = tls.NewListener(listener, &tls.Config{
+			GetCertificate: watcher.GetCertificate,
+			MinVersion:     tls.VersionTLS12,
+		})
+	}
+
+	server := &http.Server{
+		Handler:           s.webhookServer.WebhookMux(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			s.log.Error(err, "webhook socket server shutdown failed")
+		}
+	}()
+
+	s.log.Info("starting webhook server", "socket", s.config.SocketPath, "tls", s.config.SocketTLS)
+	if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
 // Shutdown gracefully shuts down the servers.
 func (s *Server) Shutdown(ctx context.Context) error {
 	if s.healthServer != nil {
@@ -0,0 +1,183 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestServer_SocketPath(t *testing.T) {
+	socketPath := filepath.Join(shortTempDir(t), "webhook.sock")
+
+	// A stale socket from a previous run is replaced
+	require.NoError(t, os.WriteFile(socketPath, nil, 0o600))
+
+	stop := startServer(t, Config{SocketPath: socketPath})
+	postReview(t, "http", &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", socketPath)
+		},
+	})
+
+	stop()
+	assert.NoFileExists(t, socketPath, "socket must be removed on shutdown")
+}
+
+func TestServer_SocketTLS(t *testing.T) {
+	dir := shortTempDir(t)
+	socketPath := filepath.Join(dir, "webhook.sock")
+	certPool := writeSelfSignedCert(t, dir)
+
+	stop := startServer(t, Config{SocketPath: socketPath, SocketTLS: true, CertDir: dir})
+	postReview(t, "https", &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", socketPath)
+		},
+		TLSClientConfig: &tls.Config{RootCAs: certPool, ServerName: "kausality-webhook", MinVersion: tls.VersionTLS12},
+	})
+
+	stop()
+	assert.NoFileExists(t, socketPath, "socket must be removed on shutdown")
+}
+
+// shortTempDir returns a temporary directory. Unix socket paths are limited to ~100
+// bytes, t.TempDir() can be longer.
+func shortTempDir(t *testing.T) string {
+	t.Helper()
+	dir, err := os.MkdirTemp("", "kausality")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	return dir
+}
+
+// startServer starts a server with a fake client and returns a function stopping it.
+func startServer(t *testing.T, cfg Config) func() {
+	t.Helper()
+	cfg.Client = fake.NewClientBuilder().Build()
+	cfg.Log = logr.Discard()
+	cfg.HealthProbeBindAddress = "127.0.0.1:0"
+	server := NewServer(cfg)
+	server.Register()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- server.Start(ctx) }()
+
+	var stopped bool
+	stop := func() {
+		if stopped {
+			return
+		}
+		stopped = true
+		cancel()
+		require.NoError(t, <-done)
+		require.NoError(t, server.Shutdown(context.Background()))
+	}
+	t.Cleanup(stop)
+	return stop
+}
+
+// postReview posts an AdmissionReview to /mutate through transport and checks that it
+// is answered.
+func postReview(t *testing.T, scheme string, transport *http.Transport) {
+	t.Helper()
+	httpClient := &http.Client{Transport: transport}
+
+	review, err := json.Marshal(admissionv1.AdmissionReview{
+		TypeMeta: metav1.TypeMeta{APIVersion: "admission.k8s.io/v1", Kind: "AdmissionReview"},
+		Request: &admissionv1.AdmissionRequest{
+			UID:       "socket-test",
+			Operation: admissionv1.Connect,
+			Kind:      metav1.GroupVersionKind{Version: "v1", Kind: "Pod"},
+		},
+	})
+	require.NoError(t, err)
+
+	var resp *http.Response
+	require.Eventually(t, func() bool {
+		resp, err = httpClient.Post(scheme+"://unix/mutate", "application/json", bytes.NewReader(review))
+		return err == nil
+	}, 5*time.Second, 20*time.Millisecond, "webhook socket is not served")
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var got admissionv1.AdmissionReview
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+	require.NotNil(t, got.Response)
+	assert.Equal(t, "socket-test", string(got.Response.UID))
+	assert.True(t, got.Response.Allowed)
+}
+
+// writeSelfSignedCert writes tls.crt and tls.key for "kausality-webhook" to dir and
+// returns a pool trusting it.
+func writeSelfSignedCert(t *testing.T, dir string) *x509.CertPool {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "kausality-webhook"},
+		DNSNames:     []string{"kausality-webhook"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "tls.crt"), certPEM, 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "tls.key"), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+
+	pool := x509.NewCertPool()
+	require.True(t, pool.AppendCertsFromPEM(certPEM))
+	return pool
+}
+
+func TestServer_Readyz(t *testing.T) {
+	ready := false
+	server := NewServer(Config{
+		Client: fake.NewClientBuilder().Build(),
+		Log:    logr.Discard(),
+		Ready:  func() bool { return ready },
+	})
+	handler := server.healthHandler()
+
+	get := func(path string) int {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusServiceUnavailable, get("/readyz"), "not ready before the policy store synced")
+	assert.Equal(t, http.StatusOK, get("/healthz"), "alive regardless of readiness")
+
+	ready = true
+	assert.Equal(t, http.StatusOK, get("/readyz"))
+}
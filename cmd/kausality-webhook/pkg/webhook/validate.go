@@ -0,0 +1,112 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/kausality-io/kausality/pkg/approval"
+)
+
+// maxValidateRequestBytes limits the size of /validate-approvals request bodies.
+const maxValidateRequestBytes = 1 << 20
+
+// ValidateApprovalsRequest is the body of a POST /validate-approvals request.
+type ValidateApprovalsRequest struct {
+	// Approvals is the kausality.io/approvals annotation value to validate.
+	Approvals string `json:"approvals"`
+	// Child is the child the approvals are meant for. Optional.
+	Child *ValidateApprovalsChild `json:"child,omitempty"`
+	// ParentGeneration is the parent generation to check approvals against.
+	// Approvals in mode once or generation only approve a drift at their generation.
+	ParentGeneration int64 `json:"parentGeneration,omitempty"`
+}
+
+// ValidateApprovalsChild identifies the intended child.
+type ValidateApprovalsChild struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	// Labels of the child, matched against selector approvals. Optional.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// ValidateApprovalsResponse is the result of a POST /validate-approvals request.
+type ValidateApprovalsResponse struct {
+	// Valid is true if the annotation parses and every entry is valid.
+	Valid bool `json:"valid"`
+	// Error is set if the annotation is not a JSON array of approvals.
+	Error string `json:"error,omitempty"`
+	// Entries holds the per-entry results.
+	Entries []ValidateApprovalsEntry `json:"entries,omitempty"`
+	// Approved is true if the approvals would approve a drift of the child at the parent generation.
+	Approved bool `json:"approved"`
+	// Reason explains the approval decision for the child.
+	Reason string `json:"reason,omitempty"`
+}
+
+// ValidateApprovalsEntry is the result for one approval entry.
+type ValidateApprovalsEntry struct {
+	approval.EntryValidation
+
+	// Matches is true if the entry matches the child.
+	Matches bool `json:"matches"`
+	// ValidForGeneration is true if the entry is valid at the parent generation.
+	ValidForGeneration bool `json:"validForGeneration"`
+	// Expired is true if the entry's expiresAt has passed.
+	Expired bool `json:"expired,omitempty"`
+}
+
+// ValidateApprovalsHandler serves POST /validate-approvals: a read-only, stateless
+// pre-flight check of an approvals annotation value against an intended child.
+func ValidateApprovalsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var req ValidateApprovalsRequest
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxValidateRequestBytes)).Decode(&req); err != nil {
+			http.Error(w, fmt.Sprintf("invalid request: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(validateApprovals(req))
+	})
+}
+
+func validateApprovals(req ValidateApprovalsRequest) ValidateApprovalsResponse {
+	entries, err := approval.ValidateApprovals(req.Approvals)
+	if err != nil {
+		return ValidateApprovalsResponse{Error: err.Error()}
+	}
+
+	resp := ValidateApprovalsResponse{Valid: true}
+	var child approval.ChildRef
+	if req.Child != nil {
+		child = approval.ChildRef{APIVersion: req.Child.APIVersion, Kind: req.Child.Kind, Name: req.Child.Name, Labels: req.Child.Labels}
+	}
+	for _, e := range entries {
+		entry := ValidateApprovalsEntry{EntryValidation: e}
+		if !e.Valid() {
+			resp.Valid = false
+		}
+		if e.Approval != nil && req.Child != nil {
+			entry.Matches = e.Approval.Matches(child)
+			entry.ValidForGeneration = e.Approval.IsValid(req.ParentGeneration)
+			entry.Expired = e.Approval.IsExpired()
+		}
+		resp.Entries = append(resp.Entries, entry)
+	}
+
+	// The decision uses the same logic as admission
+	if req.Child != nil {
+		result := approval.CheckFromAnnotations(req.Approvals, "", child, req.ParentGeneration)
+		resp.Approved = result.Approved
+		resp.Reason = result.Reason
+	}
+	return resp
+}
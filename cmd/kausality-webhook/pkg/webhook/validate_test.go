@@ -0,0 +1,93 @@
+package webhook
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateApprovalsHandler(t *testing.T) {
+	post := func(t *testing.T, body string) ValidateApprovalsResponse {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		ValidateApprovalsHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/validate-approvals", strings.NewReader(body)))
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var resp ValidateApprovalsResponse
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+		return resp
+	}
+	request := func(approvals string, generation int64) string {
+		body, _ := json.Marshal(ValidateApprovalsRequest{
+			Approvals:        approvals,
+			Child:            &ValidateApprovalsChild{APIVersion: "v1", Kind: "ConfigMap", Name: "cm"},
+			ParentGeneration: generation,
+		})
+		return string(body)
+	}
+
+	t.Run("matching approval", func(t *testing.T) {
+		resp := post(t, request(`[{"apiVersion":"v1","kind":"ConfigMap","name":"cm","mode":"generation","generation":3}]`, 3))
+		assert.True(t, resp.Valid)
+		assert.True(t, resp.Approved)
+		assert.Equal(t, "approved via generation approval", resp.Reason)
+		require.Len(t, resp.Entries, 1)
+		assert.True(t, resp.Entries[0].Matches)
+		assert.True(t, resp.Entries[0].ValidForGeneration)
+	})
+
+	t.Run("stale generation", func(t *testing.T) {
+		resp := post(t, request(`[{"apiVersion":"v1","kind":"ConfigMap","name":"cm","generation":3}]`, 4))
+		assert.True(t, resp.Valid)
+		assert.False(t, resp.Approved)
+		assert.Equal(t, "approval found but invalid (stale generation)", resp.Reason)
+		assert.True(t, resp.Entries[0].Matches)
+		assert.False(t, resp.Entries[0].ValidForGeneration)
+	})
+
+	t.Run("expired approval", func(t *testing.T) {
+		resp := post(t, request(`[{"apiVersion":"v1","kind":"ConfigMap","name":"cm","mode":"always","expiresAt":"2020-01-01T00:00:00Z"}]`, 1))
+		assert.True(t, resp.Valid)
+		assert.False(t, resp.Approved)
+		assert.Equal(t, "approval found but expired", resp.Reason)
+		assert.True(t, resp.Entries[0].Expired)
+	})
+
+	t.Run("typo is reported per entry", func(t *testing.T) {
+		resp := post(t, request(`[{"apiVersion":"v1","kind":"ConfigMap","name":"other","mode":"always"},{"apiVersion":"v1","kind":"ConfigMap","name":"cm","mode":"alway"}]`, 1))
+		assert.False(t, resp.Valid)
+		assert.False(t, resp.Approved)
+		require.Len(t, resp.Entries, 2)
+		assert.Empty(t, resp.Entries[0].Errors)
+		assert.False(t, resp.Entries[0].Matches)
+		assert.Equal(t, []string{`invalid mode "alway": must be one of once, generation, range, always`}, resp.Entries[1].Errors)
+		assert.True(t, resp.Entries[1].Matches)
+	})
+
+	t.Run("unparseable annotation", func(t *testing.T) {
+		resp := post(t, request(`[{"apiVersion":"v1"`, 1))
+		assert.False(t, resp.Valid)
+		assert.Contains(t, resp.Error, "invalid approvals annotation")
+	})
+
+	t.Run("without child only validates", func(t *testing.T) {
+		resp := post(t, `{"approvals":"[{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"name\":\"cm\",\"mode\":\"always\"}]"}`)
+		assert.True(t, resp.Valid)
+		assert.False(t, resp.Approved)
+		assert.Empty(t, resp.Reason)
+	})
+
+	t.Run("rejects other methods and bad bodies", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		ValidateApprovalsHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/validate-approvals", nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+
+		rec = httptest.NewRecorder()
+		ValidateApprovalsHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/validate-approvals", strings.NewReader("not json")))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
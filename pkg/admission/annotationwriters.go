@@ -0,0 +1,96 @@
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kausalityv1alpha1 "github.com/kausality-io/kausality/api/v1alpha1"
+	"github.com/kausality-io/kausality/pkg/config"
+)
+
+// changedKausalityAnnotations returns the kausality.io/* annotation keys added, changed or
+// removed from old to new, sorted.
+func changedKausalityAnnotations(keys kausalityv1alpha1.AnnotationKeys, oldAnns, newAnns map[string]string) []string {
+	var changed []string
+	for key, oldVal := range oldAnns {
+		if newVal, ok := newAnns[key]; isKausalityAnnotation(keys, key) && (!ok || newVal != oldVal) {
+			changed = append(changed, key)
+		}
+	}
+	for key := range newAnns {
+		if _, ok := oldAnns[key]; isKausalityAnnotation(keys, key) && !ok {
+			changed = append(changed, key)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// mayWriteAnnotations returns true if the request's field manager may change kausality annotations.
+func (h *Handler) mayWriteAnnotations(cfg *config.Config, req admission.Request) bool {
+	if cfg == nil || len(cfg.DriftDetection.AnnotationWriters) == 0 {
+		return true
+	}
+	return h.isOwnWrite(req) || slices.Contains(cfg.DriftDetection.AnnotationWriters, extractFieldManager(req))
+}
+
+// checkAnnotationWriters checks an UPDATE changing kausality annotations against
+// AnnotationWriters. Changes by other field managers are denied if the object's mode
+// before the update is enforce, and warned about otherwise. It returns the denial,
+// or the warning if the request may proceed.
+func (h *Handler) checkAnnotationWriters(ctx context.Context, cfg *config.Config, req admission.Request, log logr.Logger) (*admission.Response, string) {
+	if h.mayWriteAnnotations(cfg, req) {
+		return nil, ""
+	}
+	var oldObj, newObj unstructured.Unstructured
+	if err := json.Unmarshal(req.OldObject.Raw, &oldObj); err != nil {
+		return nil, ""
+	}
+	if err := json.Unmarshal(req.Object.Raw, &newObj); err != nil {
+		return nil, ""
+	}
+	changed := changedKausalityAnnotations(h.keys, oldObj.GetAnnotations(), newObj.GetAnnotations())
+	if len(changed) == 0 {
+		return nil, ""
+	}
+
+	manager := extractFieldManager(req)
+	if manager == "" {
+		manager = "<none>"
+	}
+	msg := fmt.Sprintf("field manager %s may not change kausality annotations %s", manager, strings.Join(changed, ", "))
+	log = log.WithValues("fieldManager", manager, "annotations", changed)
+
+	// The mode before the update counts: the same request must not be able to lift enforcement
+	oldAnns := oldObj.GetAnnotations()
+	if oldAnns == nil {
+		oldAnns = map[string]string{}
+	}
+	var nsLabels, nsAnns map[string]string
+	if ns := oldObj.GetNamespace(); ns != "" {
+		var err error
+		if nsLabels, nsAnns, err = h.namespaceMetadata(ctx, nil, ns); err != nil {
+			log.V(1).Info("failed to get namespace metadata for annotation writer check", "error", err)
+		}
+	}
+	if nsAnns == nil {
+		nsAnns = map[string]string{}
+	}
+	mode := h.resolveMode(cfg, oldObj.GroupVersionKind(), oldObj.GetNamespace(), nsLabels, oldObj.GetLabels(), oldAnns, nsAnns)
+	if mode == string(kausalityv1alpha1.ModeEnforce) {
+		log.Info("ANNOTATION WRITE DENIED")
+		resp := admission.Denied(msg)
+		return &resp, ""
+	}
+	log.Info("annotation write by unlisted field manager")
+	return nil, fmt.Sprintf("[kausality] %s (would be blocked in enforce mode)", msg)
+}
@@ -0,0 +1,149 @@
+package admission
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kausality-io/kausality/pkg/approval"
+	"github.com/kausality-io/kausality/pkg/drift"
+)
+
+// Audit decisions.
+const (
+	AuditDecisionAllowed = "allowed"
+	AuditDecisionDenied  = "denied"
+)
+
+// AuditRecord is the JSON object written to Config.AuditWriter for every admission
+// decision, one per line. Fields that don't apply to a request, e.g. the phase of an
+// object without a parent, are omitted.
+type AuditRecord struct {
+	// Time is when the decision was made.
+	Time time.Time `json:"time"`
+	// RequestUID is the UID of the admission request.
+	RequestUID string `json:"requestUID"`
+	// Operation is CREATE, UPDATE or DELETE.
+	Operation string `json:"operation"`
+	// APIVersion and Kind identify the object's kind.
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	// SubResource is set for subresource requests, e.g. status.
+	SubResource string `json:"subResource,omitempty"`
+	Namespace   string `json:"namespace,omitempty"`
+	Name        string `json:"name"`
+	// User is the requesting user.
+	User string `json:"user"`
+	// FieldManager is the request's field manager, if known.
+	FieldManager string `json:"fieldManager,omitempty"`
+	// DryRun is true for dry-run requests.
+	DryRun bool `json:"dryRun,omitempty"`
+	// Phase is the lifecycle phase of the parent drift was detected against.
+	Phase drift.LifecyclePhase `json:"phase,omitempty"`
+	// DriftDetected is true if the change was drift.
+	DriftDetected bool `json:"driftDetected"`
+	// Mode is the drift mode in effect for the object, once it was resolved.
+	Mode string `json:"mode,omitempty"`
+	// Decision is AuditDecisionAllowed or AuditDecisionDenied.
+	Decision string `json:"decision"`
+	// Reason is the message of the admission response.
+	Reason string `json:"reason,omitempty"`
+	// MatchedApproval is the approval that allowed the drift, if any.
+	MatchedApproval *approval.Approval `json:"matchedApproval,omitempty"`
+	// MatchedRejection is the rejection that matched the drift, if any.
+	MatchedRejection *approval.Rejection `json:"matchedRejection,omitempty"`
+}
+
+// auditLog writes AuditRecords to a writer shared by concurrent requests.
+type auditLog struct {
+	mu  sync.Mutex
+	w   io.Writer
+	log logr.Logger
+}
+
+// newAuditLog returns nil if w is nil.
+func newAuditLog(w io.Writer, log logr.Logger) *auditLog {
+	if w == nil {
+		return nil
+	}
+	return &auditLog{w: w, log: log}
+}
+
+// start begins the record of req. It returns nil, and allocates nothing, if auditing
+// is disabled; all record methods are no-ops on nil.
+func (a *auditLog) start(req admission.Request) *AuditRecord {
+	if a == nil {
+		return nil
+	}
+	return &AuditRecord{
+		RequestUID:   string(req.UID),
+		Operation:    string(req.Operation),
+		APIVersion:   schema.GroupVersion{Group: req.Kind.Group, Version: req.Kind.Version}.String(),
+		Kind:         req.Kind.Kind,
+		SubResource:  req.SubResource,
+		Namespace:    req.Namespace,
+		Name:         req.Name,
+		User:         req.UserInfo.Username,
+		FieldManager: extractFieldManager(req),
+		DryRun:       isDryRun(req),
+	}
+}
+
+// write completes r with the final response and writes it as one line.
+func (a *auditLog) write(r *AuditRecord, resp admission.Response) {
+	if a == nil || r == nil {
+		return
+	}
+	r.Time = time.Now().UTC()
+	r.Decision = AuditDecisionDenied
+	if resp.Allowed {
+		r.Decision = AuditDecisionAllowed
+	}
+	if resp.Result != nil {
+		r.Reason = resp.Result.Message
+	}
+	line, err := json.Marshal(r)
+	if err != nil {
+		a.log.Error(err, "failed to marshal audit record")
+		return
+	}
+	line = append(line, '\n')
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, err := a.w.Write(line); err != nil {
+		a.log.Error(err, "failed to write audit record")
+	}
+}
+
+// setDrift records the outcome of drift detection.
+func (r *AuditRecord) setDrift(result *drift.DriftResult) {
+	if r == nil {
+		return
+	}
+	r.Phase = result.LifecyclePhase
+	r.DriftDetected = result.DriftDetected
+}
+
+// setMode records the resolved drift mode.
+func (r *AuditRecord) setMode(mode string) {
+	if r == nil {
+		return
+	}
+	r.Mode = mode
+}
+
+// setApproval records the approval or rejection that matched the drift.
+func (r *AuditRecord) setApproval(result approval.CheckResult) {
+	if r == nil {
+		return
+	}
+	r.MatchedApproval = result.MatchedApproval
+	r.MatchedRejection = result.MatchedRejection
+}
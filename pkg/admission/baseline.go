@@ -0,0 +1,41 @@
+package admission
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kausality-io/kausality/pkg/config"
+	"github.com/kausality-io/kausality/pkg/drift"
+)
+
+// applyBaseline clears a detected drift if the changed spec fields are covered by a
+// baseline of the parent kind for the request's field manager. Only UPDATEs can be
+// covered: a CREATE or DELETE has no changed fields to compare.
+func (h *Handler) applyBaseline(cfg *config.Config, req admission.Request, obj client.Object, result *drift.DriftResult, log logr.Logger) {
+	if h.baselines == nil || result.ParentRef == nil {
+		return
+	}
+	fields := h.changedSpecFields(cfg, req)
+	if len(fields) == 0 {
+		return
+	}
+	parentGV, err := schema.ParseGroupVersion(result.ParentRef.APIVersion)
+	if err != nil {
+		return
+	}
+	parent := schema.GroupKind{Group: parentGV.Group, Kind: result.ParentRef.Kind}
+	name, ok := h.baselines.Match(parent, obj.GetObjectKind().GroupVersionKind().GroupKind(), extractFieldManager(req), fields)
+	if !ok {
+		return
+	}
+
+	log.V(1).Info("change within baseline, not drift", "baseline", name, "fields", fields)
+	result.DriftDetected = false
+	result.Reason = fmt.Sprintf("change of %s within baseline %s", strings.Join(fields, ", "), name)
+}
@@ -0,0 +1,66 @@
+package admission
+
+import (
+	"encoding/json"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kausality-io/kausality/pkg/config"
+	"github.com/kausality-io/kausality/pkg/controller"
+)
+
+// recordsStatusWriter returns true if the writer of a status update should be
+// recorded as the parent's controller under the configured controller selection.
+// A service account already recorded as controller stays one whatever its field
+// manager, e.g. after a controller upgrade renamed it.
+func (h *Handler) recordsStatusWriter(cfg *config.Config, req admission.Request, oldObj, newObj *unstructured.Unstructured) bool {
+	if cfg == nil || cfg.DriftDetection.ControllerSelection != config.ControllerSelectionObservedGenerationOwner {
+		return true
+	}
+	if sa, ok := controller.ServiceAccount(req.UserInfo.Username); ok {
+		recorded := controller.ParseHashes(oldObj.GetAnnotations()[h.keys.ControllerServiceAccounts])
+		if controller.ContainsHash(recorded, sa) {
+			return true
+		}
+	}
+	owns, known := ownsObservedGeneration(extractFieldManager(req), oldObj, newObj)
+	return owns || !known
+}
+
+// ownsObservedGeneration determines whether the status writer owns status.observedGeneration.
+// The writer owns it if the request changes it, or if its field manager owns the field in
+// managedFields. known is false if the object has no observedGeneration or no manager owns it.
+func ownsObservedGeneration(fieldManager string, oldObj, newObj *unstructured.Unstructured) (owns, known bool) {
+	newObsGen, found, _ := unstructured.NestedInt64(newObj.Object, "status", "observedGeneration")
+	if !found {
+		return false, false
+	}
+	oldObsGen, oldFound, _ := unstructured.NestedInt64(oldObj.Object, "status", "observedGeneration")
+	if !oldFound || oldObsGen != newObsGen {
+		return true, true
+	}
+
+	var owners []string
+	for _, entry := range newObj.GetManagedFields() {
+		if entry.FieldsV1 == nil {
+			continue
+		}
+		var fields map[string]interface{}
+		if err := json.Unmarshal(entry.FieldsV1.Raw, &fields); err != nil {
+			continue
+		}
+		if _, ok, _ := unstructured.NestedFieldNoCopy(fields, "f:status", "f:observedGeneration"); ok {
+			owners = append(owners, entry.Manager)
+		}
+	}
+	if len(owners) == 0 {
+		return false, false
+	}
+	for _, owner := range owners {
+		if fieldManager != "" && owner == fieldManager {
+			return true, true
+		}
+	}
+	return false, true
+}
@@ -0,0 +1,192 @@
+package admission
+
+import (
+	"container/list"
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kausality-io/kausality/pkg/config"
+)
+
+// controllerVersionTTL is how long a resolved controller version is cached.
+const controllerVersionTTL = 5 * time.Minute
+
+// maxControllerVersions bounds the cached versions. The least recently used are evicted.
+const maxControllerVersions = 1000
+
+// serviceAccountPrefix is the username prefix of service account users.
+const serviceAccountPrefix = "system:serviceaccount:"
+
+// controllerVersionResolver resolves the version of the controller behind a request.
+// Lookups of Deployments are best-effort and run in the background, so a request
+// never waits for them: until a lookup finished, the version is unknown or the
+// expired one. Results are cached, including misses.
+type controllerVersionResolver struct {
+	client   client.Client
+	sources  map[string]config.ControllerVersionSource
+	discover bool
+	nowFunc  func() time.Time
+	log      logr.Logger
+
+	mu    sync.Mutex
+	cache map[string]*list.Element
+	// order holds *controllerVersionEntry, least recently used first.
+	order   *list.List
+	pending map[string]struct{}
+}
+
+type controllerVersionEntry struct {
+	key     string
+	version string
+	expires time.Time
+}
+
+// newControllerVersionResolver returns nil if no version source is configured.
+func newControllerVersionResolver(c client.Client, cfg *config.Config, log logr.Logger) *controllerVersionResolver {
+	if len(cfg.ControllerVersions) == 0 && !cfg.DiscoverControllerVersions {
+		return nil
+	}
+	sources := make(map[string]config.ControllerVersionSource, len(cfg.ControllerVersions))
+	for _, src := range cfg.ControllerVersions {
+		sources[src.FieldManager] = src
+	}
+	return &controllerVersionResolver{
+		client:   c,
+		sources:  sources,
+		discover: cfg.DiscoverControllerVersions,
+		nowFunc:  time.Now,
+		log:      log,
+		cache:    make(map[string]*list.Element),
+		order:    list.New(),
+		pending:  make(map[string]struct{}),
+	}
+}
+
+// Resolve returns the controller version for the request, or "" if unknown.
+func (r *controllerVersionResolver) Resolve(ctx context.Context, fieldManager, username string) string {
+	if r == nil {
+		return ""
+	}
+	version, fetch := r.lookup(fieldManager, username)
+	if fetch == nil {
+		return version
+	}
+	key := fieldManager + "\x00" + username
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if elem, ok := r.cache[key]; ok {
+		r.order.MoveToBack(elem)
+		entry := elem.Value.(*controllerVersionEntry)
+		if r.nowFunc().Before(entry.expires) {
+			return entry.version
+		}
+		// Serve the expired version while it is refreshed
+		version = entry.version
+	}
+	if _, ok := r.pending[key]; !ok {
+		r.pending[key] = struct{}{}
+		go r.refresh(context.WithoutCancel(ctx), key, fetch)
+	}
+	return version
+}
+
+// refresh fetches the version for key and caches it.
+func (r *controllerVersionResolver) refresh(ctx context.Context, key string, fetch func(context.Context) string) {
+	version := fetch(ctx)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.pending, key)
+	entry := &controllerVersionEntry{key: key, version: version, expires: r.nowFunc().Add(controllerVersionTTL)}
+	if elem, ok := r.cache[key]; ok {
+		elem.Value = entry
+		r.order.MoveToBack(elem)
+		return
+	}
+	r.cache[key] = r.order.PushBack(entry)
+	for r.order.Len() > maxControllerVersions {
+		oldest := r.order.Front()
+		r.order.Remove(oldest)
+		delete(r.cache, oldest.Value.(*controllerVersionEntry).key)
+	}
+}
+
+// lookup returns the configured version for the request, or a fetch reading it from
+// the cluster. Neither is set if the version cannot be known.
+func (r *controllerVersionResolver) lookup(fieldManager, username string) (string, func(context.Context) string) {
+	if src, ok := r.sources[fieldManager]; ok && fieldManager != "" {
+		if src.Version != "" {
+			return src.Version, nil
+		}
+		return "", func(ctx context.Context) string {
+			return r.deploymentImage(ctx, src.Deployment.Namespace, src.Deployment.Name, src.Deployment.Container)
+		}
+	}
+	if !r.discover || !strings.HasPrefix(username, serviceAccountPrefix) {
+		return "", nil
+	}
+	namespace, sa, ok := strings.Cut(strings.TrimPrefix(username, serviceAccountPrefix), ":")
+	if !ok {
+		return "", nil
+	}
+	return "", func(ctx context.Context) string {
+		return r.serviceAccountImage(ctx, namespace, sa)
+	}
+}
+
+// deploymentImage returns the image of the named container (or the first one) of a Deployment.
+func (r *controllerVersionResolver) deploymentImage(ctx context.Context, namespace, name, container string) string {
+	deploy := &unstructured.Unstructured{}
+	deploy.SetAPIVersion("apps/v1")
+	deploy.SetKind("Deployment")
+	if err := r.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, deploy); err != nil {
+		r.log.V(1).Info("failed to get controller deployment", "namespace", namespace, "name", name, "error", err)
+		return ""
+	}
+	return containerImage(deploy, container)
+}
+
+// serviceAccountImage returns the image of the first Deployment in the namespace
+// whose pods run as the given service account.
+func (r *controllerVersionResolver) serviceAccountImage(ctx context.Context, namespace, serviceAccount string) string {
+	list := &unstructured.UnstructuredList{}
+	list.SetAPIVersion("apps/v1")
+	list.SetKind("DeploymentList")
+	if err := r.client.List(ctx, list, client.InNamespace(namespace)); err != nil {
+		r.log.V(1).Info("failed to list deployments for controller version", "namespace", namespace, "error", err)
+		return ""
+	}
+	for i := range list.Items {
+		sa, _, _ := unstructured.NestedString(list.Items[i].Object, "spec", "template", "spec", "serviceAccountName")
+		if sa == "" {
+			sa = "default"
+		}
+		if sa == serviceAccount {
+			return containerImage(&list.Items[i], "")
+		}
+	}
+	return ""
+}
+
+func containerImage(deploy *unstructured.Unstructured, container string) string {
+	containers, _, _ := unstructured.NestedSlice(deploy.Object, "spec", "template", "spec", "containers")
+	for _, c := range containers {
+		m, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if container == "" || m["name"] == container {
+			image, _ := m["image"].(string)
+			return image
+		}
+	}
+	return ""
+}
@@ -0,0 +1,149 @@
+package admission
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kausality-io/kausality/pkg/callback/v1alpha1"
+	"github.com/kausality-io/kausality/pkg/config"
+	ktesting "github.com/kausality-io/kausality/pkg/testing"
+)
+
+func controllerDeployment(namespace, name, serviceAccount string, containers ...corev1.Container) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{ServiceAccountName: serviceAccount, Containers: containers},
+			},
+		},
+	}
+}
+
+// resolve resolves the version and waits for a lookup it started in the background.
+func resolve(t *testing.T, r *controllerVersionResolver, fieldManager, username string) string {
+	t.Helper()
+	r.Resolve(t.Context(), fieldManager, username)
+	require.Eventually(t, func() bool {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		return len(r.pending) == 0
+	}, 5*time.Second, time.Millisecond)
+	return r.Resolve(t.Context(), fieldManager, username)
+}
+
+func TestControllerVersionResolver(t *testing.T) {
+	ctrlDeploy := controllerDeployment("infra", "eks-controller", "eks",
+		corev1.Container{Name: "sidecar", Image: "proxy:1.0"},
+		corev1.Container{Name: "manager", Image: "ghcr.io/example/eks-controller:v1.2.3"},
+	)
+	otherDeploy := controllerDeployment("infra", "other", "other", corev1.Container{Name: "main", Image: "other:v9"})
+
+	cfg := &config.Config{
+		ControllerVersions: []config.ControllerVersionSource{
+			{FieldManager: "static-manager", Version: "v0.1.0"},
+			{FieldManager: "eks-manager", Deployment: &config.DeploymentRef{Namespace: "infra", Name: "eks-controller", Container: "manager"}},
+			{FieldManager: "missing-manager", Deployment: &config.DeploymentRef{Namespace: "infra", Name: "gone"}},
+		},
+		DiscoverControllerVersions: true,
+	}
+
+	tests := []struct {
+		name         string
+		fieldManager string
+		username     string
+		want         string
+	}{
+		{name: "static version", fieldManager: "static-manager", want: "v0.1.0"},
+		{name: "deployment container image", fieldManager: "eks-manager", want: "ghcr.io/example/eks-controller:v1.2.3"},
+		{name: "missing deployment", fieldManager: "missing-manager", want: ""},
+		{name: "discovered from service account", username: "system:serviceaccount:infra:other", want: "other:v9"},
+		{name: "no deployment for service account", username: "system:serviceaccount:infra:nobody", want: ""},
+		{name: "human user", fieldManager: "kubectl", username: "alice@example.com", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithObjects(ctrlDeploy.DeepCopy(), otherDeploy.DeepCopy()).Build()
+			r := newControllerVersionResolver(c, cfg, logr.Discard())
+			assert.Equal(t, tt.want, resolve(t, r, tt.fieldManager, tt.username))
+		})
+	}
+}
+
+func TestControllerVersionResolver_Cache(t *testing.T) {
+	deploy := controllerDeployment("infra", "eks-controller", "eks", corev1.Container{Name: "manager", Image: "ctrl:v1"})
+	c := fake.NewClientBuilder().WithObjects(deploy).Build()
+	r := newControllerVersionResolver(c, &config.Config{
+		ControllerVersions: []config.ControllerVersionSource{
+			{FieldManager: "eks-manager", Deployment: &config.DeploymentRef{Namespace: "infra", Name: "eks-controller"}},
+		},
+	}, logr.Discard())
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	r.nowFunc = func() time.Time { return now }
+
+	assert.Empty(t, r.Resolve(t.Context(), "eks-manager", ""), "looked up in the background")
+	assert.Equal(t, "ctrl:v1", resolve(t, r, "eks-manager", ""))
+
+	// The cached value is served without another lookup
+	require.NoError(t, c.Delete(t.Context(), deploy))
+	assert.Equal(t, "ctrl:v1", r.Resolve(t.Context(), "eks-manager", ""))
+
+	// An expired value is served while it is refreshed
+	now = now.Add(controllerVersionTTL)
+	assert.Equal(t, "ctrl:v1", r.Resolve(t.Context(), "eks-manager", ""))
+	assert.Empty(t, resolve(t, r, "eks-manager", ""))
+}
+
+func TestControllerVersionResolver_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := fake.NewClientBuilder().Build()
+	r := newControllerVersionResolver(c, &config.Config{DiscoverControllerVersions: true}, logr.Discard())
+
+	user := func(i int) string { return fmt.Sprintf("system:serviceaccount:infra:sa-%d", i) }
+	for i := 0; i <= maxControllerVersions; i++ {
+		resolve(t, r, "", user(i))
+	}
+
+	assert.Equal(t, maxControllerVersions, r.order.Len())
+	assert.NotContains(t, r.cache, "\x00"+user(0))
+	assert.Contains(t, r.cache, "\x00"+user(maxControllerVersions))
+}
+
+func TestControllerVersionResolver_Disabled(t *testing.T) {
+	r := newControllerVersionResolver(nil, config.Default(), logr.Discard())
+	assert.Nil(t, r)
+	assert.Equal(t, "", r.Resolve(t.Context(), "any", "any"))
+}
+
+func TestHandle_DriftReportControllerVersion(t *testing.T) {
+	sender := &ktesting.FakeSender{}
+	h, _ := newFakeHandler(t, Config{
+		CallbackSender: sender,
+		DriftConfig: &config.Config{
+			DriftDetection:     config.DriftDetectionConfig{DefaultMode: config.ModeLog},
+			ControllerVersions: []config.ControllerVersionSource{{FieldManager: "widget-controller", Version: "v1.2.3"}},
+		},
+	}, stableParent(nil))
+
+	req := newAdmissionRequest(t, admissionv1.Create, nil, ownedChild("child", nil, nil), testController)
+	req.Options = runtime.RawExtension{Raw: []byte(`{"fieldManager":"widget-controller"}`)}
+	resp := h.Handle(t.Context(), req)
+	require.True(t, resp.Allowed)
+
+	reports := sender.Sent()
+	require.Len(t, reports, 1)
+	assert.Equal(t, v1alpha1.DriftReportPhaseDetected, reports[0].Spec.Phase)
+	assert.Equal(t, "v1.2.3", reports[0].Spec.Request.ControllerVersion)
+}
@@ -691,6 +691,123 @@ func TestApprovalNotConsumed_ModeAlways(t *testing.T) {
 	}
 }
 
+// =============================================================================
+// Test: Dry-Run Does Not Consume Mode=Once Approval
+// =============================================================================
+
+func TestApprovalNotConsumed_DryRun(t *testing.T) {
+	ctx := context.Background()
+
+	// Create parent deployment
+	deploy := createDeploymentUnit(t, ctx, "dryrun-deploy")
+
+	// Create child ReplicaSet
+	rs := createReplicaSetWithOwnerUnit(t, ctx, "dryrun-rs", deploy)
+
+	// Add mode=once approval annotation with the NEXT generation
+	// (since updating annotations will bump the generation)
+	if err := k8sClientUnit.Get(ctx, client.ObjectKeyFromObject(deploy), deploy); err != nil {
+		t.Fatalf("failed to get deployment: %v", err)
+	}
+	approvals := []approval.Approval{
+		{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: rs.Name, Mode: approval.ModeOnce, Generation: deploy.Generation + 1},
+	}
+	approvalsJSON, _ := approval.MarshalApprovals(approvals)
+	annotations := deploy.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[approval.ApprovalsAnnotation] = approvalsJSON
+	annotations[controller.PhaseAnnotation] = controller.PhaseValueInitialized
+	deploy.SetAnnotations(annotations)
+	if err := k8sClientUnit.Update(ctx, deploy); err != nil {
+		t.Fatalf("failed to update deployment with approval: %v", err)
+	}
+
+	// Set parent as ready AFTER annotation update (drift scenario: gen == obsGen)
+	if err := k8sClientUnit.Get(ctx, client.ObjectKeyFromObject(deploy), deploy); err != nil {
+		t.Fatalf("failed to get deployment: %v", err)
+	}
+	deploy.Status.ObservedGeneration = deploy.Generation
+	if err := k8sClientUnit.Status().Update(ctx, deploy); err != nil {
+		t.Fatalf("failed to update status: %v", err)
+	}
+
+	// Re-fetch and find controller manager
+	if err := k8sClientUnit.Get(ctx, client.ObjectKeyFromObject(deploy), deploy); err != nil {
+		t.Fatalf("failed to get deployment: %v", err)
+	}
+	var controllerManager string
+	for _, mf := range deploy.ManagedFields {
+		if mf.Subresource == "status" {
+			controllerManager = mf.Manager
+			break
+		}
+	}
+
+	// Create handler (enforce mode - the approval must still allow the dry-run)
+	handler := kadmission.NewHandler(kadmission.Config{
+		Client: k8sClientUnit,
+		Log:    ctrl.Log.WithName("test-dryrun"),
+		DriftConfig: &config.Config{
+			DriftDetection: config.DriftDetectionConfig{
+				DefaultMode: config.ModeEnforce,
+			},
+		},
+	})
+
+	// Re-fetch RS
+	if err := k8sClientUnit.Get(ctx, client.ObjectKeyFromObject(rs), rs); err != nil {
+		t.Fatalf("failed to get rs: %v", err)
+	}
+	rs.APIVersion = "apps/v1"
+	rs.Kind = "ReplicaSet"
+
+	oldRS := rs.DeepCopy()
+	newRS := rs.DeepCopy()
+	newReplicas := int32(3)
+	newRS.Spec.Replicas = &newReplicas
+
+	oldBytes, _ := json.Marshal(oldRS)
+	newBytes, _ := json.Marshal(newRS)
+	optionsJSON := fmt.Sprintf(`{"fieldManager":%q}`, controllerManager)
+	dryRun := true
+
+	req := admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			UID:       types.UID("dryrun-uid"),
+			Operation: admissionv1.Update,
+			Kind:      metav1.GroupVersionKind{Group: "apps", Version: "v1", Kind: "ReplicaSet"},
+			Namespace: rs.Namespace,
+			Name:      rs.Name,
+			Object:    runtime.RawExtension{Raw: newBytes},
+			OldObject: runtime.RawExtension{Raw: oldBytes},
+			UserInfo:  authenticationv1.UserInfo{Username: "controller"},
+			Options:   runtime.RawExtension{Raw: []byte(optionsJSON)},
+			DryRun:    &dryRun,
+		},
+	}
+
+	resp := handler.Handle(ctx, req)
+
+	// The dry-run previews the decision of the real request
+	if !resp.Allowed {
+		t.Errorf("expected allowed=true with valid approval")
+	}
+
+	// Brief wait then verify approval was NOT consumed
+	// This is a "prove nothing happens" case - wait, then verify state unchanged
+	time.Sleep(100 * time.Millisecond)
+
+	if err := k8sClientUnit.Get(ctx, client.ObjectKeyFromObject(deploy), deploy); err != nil {
+		t.Fatalf("failed to get deployment after handler: %v", err)
+	}
+	afterApprovals := deploy.GetAnnotations()[approval.ApprovalsAnnotation]
+	if afterApprovals != approvalsJSON {
+		t.Errorf("dry-run must not consume the mode=once approval, got approvals %q, want %q", afterApprovals, approvalsJSON)
+	}
+}
+
 // =============================================================================
 // Test: Approval Pruning - Stale Approvals Removed
 // =============================================================================
@@ -5,14 +5,25 @@ package admission_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	kadmission "github.com/kausality-io/kausality/pkg/admission"
+	"github.com/kausality-io/kausality/pkg/config"
 	"github.com/kausality-io/kausality/pkg/controller"
 	"github.com/kausality-io/kausality/pkg/drift"
 )
@@ -63,6 +74,92 @@ func TestControllerIdentification_ManagedFields(t *testing.T) {
 		parentState.Generation, parentState.ObservedGeneration, parentState.Controllers)
 }
 
+// =============================================================================
+// Test: Controller Identification - Multiple Status Writers
+// =============================================================================
+
+func TestControllerIdentification_MultipleStatusWriters(t *testing.T) {
+	ctx := context.Background()
+	const (
+		ownerManager     = "kube-controller-manager"
+		secondaryManager = "status-reporter"
+		ownerUser        = "system:serviceaccount:kube-system:deployment-controller"
+		secondaryUser    = "system:serviceaccount:monitoring:status-reporter"
+	)
+
+	deploy := createDeploymentUnit(t, ctx, "multi-status-deploy")
+
+	// The deployment controller owns observedGeneration
+	deploy.Status.ObservedGeneration = deploy.Generation
+	require.NoError(t, k8sClientUnit.Status().Update(ctx, deploy, client.FieldOwner(ownerManager)))
+	require.NoError(t, k8sClientUnit.Get(ctx, client.ObjectKeyFromObject(deploy), deploy))
+	before := deploy.DeepCopy()
+
+	// A second writer only touches conditions
+	deploy.Status.Conditions = append(deploy.Status.Conditions, appsv1.DeploymentCondition{
+		Type:   "Monitored",
+		Status: corev1.ConditionTrue,
+	})
+	require.NoError(t, k8sClientUnit.Status().Update(ctx, deploy, client.FieldOwner(secondaryManager)))
+	require.NoError(t, k8sClientUnit.Get(ctx, client.ObjectKeyFromObject(deploy), deploy))
+	after := deploy.DeepCopy()
+
+	for _, mf := range after.ManagedFields {
+		t.Logf("  Manager: %s, Operation: %s, Subresource: %s, Fields: %s", mf.Manager, mf.Operation, mf.Subresource, mf.FieldsV1.Raw)
+	}
+
+	handler := kadmission.NewHandler(kadmission.Config{
+		Client: k8sClientUnit,
+		Log:    ctrl.Log,
+		DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{
+			DefaultMode:         config.ModeLog,
+			ControllerSelection: config.ControllerSelectionObservedGenerationOwner,
+		}},
+	})
+
+	statusRequest := func(user, fieldManager string, oldObj, newObj *appsv1.Deployment) admission.Request {
+		oldObj.APIVersion, oldObj.Kind = "apps/v1", "Deployment"
+		newObj.APIVersion, newObj.Kind = "apps/v1", "Deployment"
+		oldBytes, err := json.Marshal(oldObj)
+		require.NoError(t, err)
+		newBytes, err := json.Marshal(newObj)
+		require.NoError(t, err)
+		return admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			UID:         "multi-status-uid",
+			Operation:   admissionv1.Update,
+			Kind:        metav1.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+			Namespace:   newObj.Namespace,
+			Name:        newObj.Name,
+			SubResource: "status",
+			OldObject:   runtime.RawExtension{Raw: oldBytes},
+			Object:      runtime.RawExtension{Raw: newBytes},
+			Options:     runtime.RawExtension{Raw: []byte(fmt.Sprintf(`{"fieldManager":%q}`, fieldManager))},
+			UserInfo:    authenticationv1.UserInfo{Username: user},
+		}}
+	}
+	recordedControllers := func(resp admission.Response) string {
+		for _, p := range resp.Patches {
+			if p.Path == "/metadata/annotations/kausality.io~1controllers" {
+				return p.Value.(string)
+			}
+			if p.Path == "/metadata/annotations" {
+				return p.Value.(map[string]interface{})[controller.ControllersAnnotation].(string)
+			}
+		}
+		return ""
+	}
+
+	// The secondary writer does not own observedGeneration and is not recorded
+	resp := handler.Handle(ctx, statusRequest(secondaryUser, secondaryManager, before.DeepCopy(), after.DeepCopy()))
+	require.True(t, resp.Allowed)
+	assert.Empty(t, recordedControllers(resp), "secondary status writer must not be recorded as controller")
+
+	// The owner is recorded, even when it rewrites the same observedGeneration
+	resp = handler.Handle(ctx, statusRequest(ownerUser, ownerManager, after.DeepCopy(), after.DeepCopy()))
+	require.True(t, resp.Allowed)
+	assert.Equal(t, controller.HashUsername(ownerUser), recordedControllers(resp))
+}
+
 // =============================================================================
 // Test: Drift Detection - Expected Change (gen != obsGen)
 // =============================================================================
@@ -618,3 +715,125 @@ func TestAnnotationOnlyChanges_AllowedDuringDriftProtection(t *testing.T) {
 // =============================================================================
 // Test: Approval - Mode Always
 // =============================================================================
+
+// =============================================================================
+// Test: Server-Side Apply Ownership Conflicts
+// =============================================================================
+
+func TestDriftDetection_SSAOwnershipConflict(t *testing.T) {
+	ctx := context.Background()
+	const (
+		helmManager     = "helm"
+		operatorManager = "operator"
+		operatorUser    = "system:serviceaccount:operators:operator"
+	)
+
+	deploy := createDeploymentUnit(t, ctx, "ssa-conflict-deploy")
+	markParentStableUnit(t, ctx, deploy)
+
+	// Two writers have updated the child and the parent records no controllers,
+	// so the controller cannot be identified from updater hashes alone
+	testCounter++
+	name := fmt.Sprintf("ssa-conflict-rs-%d", testCounter)
+	updaters := controller.HashUsername("system:serviceaccount:helm:helm") + "," + controller.HashUsername(operatorUser)
+	apply := func(manager string, force bool, spec map[string]interface{}) {
+		t.Helper()
+		rs := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "ReplicaSet",
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": testNSUnit,
+			},
+			"spec": spec,
+		}}
+		opts := []client.PatchOption{client.FieldOwner(manager)}
+		if force {
+			opts = append(opts, client.ForceOwnership)
+		}
+		require.NoError(t, k8sClientUnit.Patch(ctx, rs, client.Apply, opts...))
+	}
+	get := func() *appsv1.ReplicaSet {
+		t.Helper()
+		rs := &appsv1.ReplicaSet{}
+		require.NoError(t, k8sClientUnit.Get(ctx, client.ObjectKey{Namespace: testNSUnit, Name: name}, rs))
+		rs.APIVersion, rs.Kind = "apps/v1", "ReplicaSet"
+		return rs
+	}
+
+	// helm owns the replica count and the template
+	rs := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "ReplicaSet",
+		"metadata": map[string]interface{}{
+			"name":        name,
+			"namespace":   testNSUnit,
+			"annotations": map[string]interface{}{controller.UpdatersAnnotation: updaters},
+			"ownerReferences": []interface{}{map[string]interface{}{
+				"apiVersion": "apps/v1",
+				"kind":       "Deployment",
+				"name":       deploy.Name,
+				"uid":        string(deploy.UID),
+				"controller": true,
+			}},
+		},
+		"spec": map[string]interface{}{
+			"replicas": int64(1),
+			"selector": map[string]interface{}{"matchLabels": map[string]interface{}{"app": name}},
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{"labels": map[string]interface{}{"app": name}},
+				"spec": map[string]interface{}{"containers": []interface{}{
+					map[string]interface{}{"name": "test", "image": "nginx:latest"},
+				}},
+			},
+		},
+	}}
+	require.NoError(t, k8sClientUnit.Patch(ctx, rs, client.Apply, client.FieldOwner(helmManager)))
+
+	// The operator co-manages minReadySeconds, then forcibly takes over replicas
+	apply(operatorManager, false, map[string]interface{}{"minReadySeconds": int64(5)})
+	before := get()
+	apply(operatorManager, true, map[string]interface{}{"minReadySeconds": int64(5), "replicas": int64(3)})
+	after := get()
+
+	for _, mf := range after.ManagedFields {
+		t.Logf("  Manager: %s, Operation: %s, Fields: %s", mf.Manager, mf.Operation, mf.FieldsV1.Raw)
+	}
+
+	handler := kadmission.NewHandler(kadmission.Config{
+		Client: k8sClientUnit,
+		Log:    ctrl.Log,
+		DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{
+			DefaultMode: config.ModeEnforce,
+		}},
+	})
+
+	request := func(fieldManager string) admission.Request {
+		oldBytes, err := json.Marshal(before)
+		require.NoError(t, err)
+		newBytes, err := json.Marshal(after)
+		require.NoError(t, err)
+		return admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			UID:       "ssa-conflict-uid",
+			Operation: admissionv1.Update,
+			Kind:      metav1.GroupVersionKind{Group: "apps", Version: "v1", Kind: "ReplicaSet"},
+			Namespace: testNSUnit,
+			Name:      name,
+			OldObject: runtime.RawExtension{Raw: oldBytes},
+			Object:    runtime.RawExtension{Raw: newBytes},
+			Options:   runtime.RawExtension{Raw: []byte(fmt.Sprintf(`{"fieldManager":%q,"force":true}`, fieldManager))},
+			UserInfo:  authenticationv1.UserInfo{Username: operatorUser},
+		}}
+	}
+
+	t.Run("established manager taking a field is drift", func(t *testing.T) {
+		resp := handler.Handle(ctx, request(operatorManager))
+		assert.False(t, resp.Allowed)
+		assert.Contains(t, resp.Result.Message, "field ownership taken over: spec.replicas (from helm)")
+	})
+
+	t.Run("manager without prior spec fields is not classified", func(t *testing.T) {
+		resp := handler.Handle(ctx, request("kubectl"))
+		assert.True(t, resp.Allowed)
+	})
+}
@@ -946,3 +946,80 @@ func TestFreeze_AllowedDuringDeletion(t *testing.T) {
 		_ = k8sClientUnit.Update(ctx, deploy)
 	}
 }
+
+// TestFreeze_BlocksChildDeletion verifies that freeze blocks the deletion of a child
+// of a frozen parent that is not itself being deleted.
+func TestFreeze_BlocksChildDeletion(t *testing.T) {
+	ctx := context.Background()
+
+	deploy := createDeploymentUnit(t, ctx, "freeze-child-delete-deploy")
+	rs := createReplicaSetWithOwnerUnit(t, ctx, "freeze-child-delete-rs", deploy)
+
+	// Freeze the parent
+	if err := k8sClientUnit.Get(ctx, client.ObjectKeyFromObject(deploy), deploy); err != nil {
+		t.Fatalf("failed to get deployment: %v", err)
+	}
+	annotations := deploy.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[approval.FreezeAnnotation] = `{"user":"admin@example.com","message":"incident #123"}`
+	deploy.SetAnnotations(annotations)
+	if err := k8sClientUnit.Update(ctx, deploy); err != nil {
+		t.Fatalf("failed to add freeze annotation: %v", err)
+	}
+
+	// Set parent as ready
+	if err := k8sClientUnit.Get(ctx, client.ObjectKeyFromObject(deploy), deploy); err != nil {
+		t.Fatalf("failed to get deployment: %v", err)
+	}
+	deploy.Status.ObservedGeneration = deploy.Generation
+	if err := k8sClientUnit.Status().Update(ctx, deploy); err != nil {
+		t.Fatalf("failed to update status: %v", err)
+	}
+
+	handler := kadmission.NewHandler(kadmission.Config{
+		Client: k8sClientUnit,
+		Log:    ctrl.Log.WithName("test-freeze-child-delete"),
+		DriftConfig: &config.Config{
+			DriftDetection: config.DriftDetectionConfig{
+				DefaultMode: config.ModeEnforce,
+			},
+		},
+	})
+
+	// Re-fetch RS and set TypeMeta
+	if err := k8sClientUnit.Get(ctx, client.ObjectKeyFromObject(rs), rs); err != nil {
+		t.Fatalf("failed to get rs: %v", err)
+	}
+	rs.APIVersion = "apps/v1"
+	rs.Kind = "ReplicaSet"
+	oldBytes, _ := json.Marshal(rs)
+
+	// DELETE carries the object in OldObject only
+	req := admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			UID:       types.UID("freeze-child-delete-uid"),
+			Operation: admissionv1.Delete,
+			Kind:      metav1.GroupVersionKind{Group: "apps", Version: "v1", Kind: "ReplicaSet"},
+			Namespace: rs.Namespace,
+			Name:      rs.Name,
+			OldObject: runtime.RawExtension{Raw: oldBytes},
+			UserInfo:  authenticationv1.UserInfo{Username: "alice@example.com"},
+		},
+	}
+
+	resp := handler.Handle(ctx, req)
+
+	t.Logf("Response: allowed=%v, result=%v", resp.Allowed, resp.Result)
+
+	if resp.Allowed {
+		t.Fatal("expected deletion of a frozen parent's child to be denied")
+	}
+	if resp.Result == nil {
+		t.Fatal("expected Result to be set")
+	}
+	if !containsSubstring(resp.Result.Message, "frozen: incident #123") {
+		t.Errorf("expected freeze message, got: %s", resp.Result.Message)
+	}
+}
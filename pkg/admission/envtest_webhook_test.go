@@ -5,6 +5,7 @@ package admission_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -12,6 +13,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -258,6 +260,57 @@ func TestWebhook_TracePropagation_ChildExtends(t *testing.T) {
 	t.Log("SUCCESS: Child has trace annotation")
 }
 
+// TestWebhook_ServerSideApply verifies that server-side apply patches go through the
+// webhook like updates: they arrive as UPDATE (or CREATE) with the merged object.
+func TestWebhook_ServerSideApply(t *testing.T) {
+	ctx := context.Background()
+
+	testCounter++
+	name := fmt.Sprintf("webhook-ssa-deploy-%d", testCounter)
+	apply := func(replicas int64) {
+		t.Helper()
+		deploy := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata":   map[string]interface{}{"name": name, "namespace": testNS},
+			"spec": map[string]interface{}{
+				"replicas": replicas,
+				"selector": map[string]interface{}{"matchLabels": map[string]interface{}{"app": name}},
+				"template": map[string]interface{}{
+					"metadata": map[string]interface{}{"labels": map[string]interface{}{"app": name}},
+					"spec": map[string]interface{}{"containers": []interface{}{
+						map[string]interface{}{"name": "test", "image": "nginx:latest"},
+					}},
+				},
+			},
+		}}
+		require.NoError(t, k8sClient.Patch(ctx, deploy, client.Apply, client.FieldOwner("ssa-test"), client.ForceOwnership))
+	}
+	hop := func() trace.Hop {
+		t.Helper()
+		var fetched appsv1.Deployment
+		require.NoError(t, k8sClient.Get(ctx, client.ObjectKey{Namespace: testNS, Name: name}, &fetched))
+		tr, err := trace.Parse(fetched.Annotations[trace.TraceAnnotation])
+		require.NoError(t, err)
+		require.Len(t, tr, 1, "a deployment without parent has an origin trace")
+		return tr[0]
+	}
+
+	// An apply creating the object is a CREATE
+	apply(1)
+	created := hop()
+	assert.NotEmpty(t, created.RequestUID)
+
+	// An apply changing the spec is an UPDATE and starts a new trace
+	apply(2)
+	updated := hop()
+	assert.NotEqual(t, created.RequestUID, updated.RequestUID, "the apply should have been traced")
+
+	// An apply without a spec change leaves the trace alone
+	apply(2)
+	assert.Equal(t, updated.RequestUID, hop().RequestUID)
+}
+
 // =============================================================================
 // Real Webhook Tests - Annotation-only changes
 // =============================================================================
@@ -0,0 +1,39 @@
+package admission
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kausality-io/kausality/pkg/drift"
+)
+
+// EventReasonDriftBlocked is the reason of the Warning Event recorded on a parent when
+// drift of one of its children is denied.
+const EventReasonDriftBlocked = "DriftBlocked"
+
+// maxEventNote is the longest Event note the API server accepts.
+const maxEventNote = 1024
+
+// recordDriftBlocked records a DriftBlocked Warning Event on the parent of a child whose
+// drift is denied, naming the child and the actor. parent may be nil if it was not
+// fetched. Recorders send asynchronously and drop what they cannot deliver, so this
+// never delays or fails the response. Dry-run requests record nothing.
+func (h *Handler) recordDriftBlocked(req admission.Request, obj client.Object, driftResult *drift.DriftResult, parent client.Object, msg string) {
+	if h.eventRecorder == nil || driftResult.ParentRef == nil || isDryRun(req) {
+		return
+	}
+	var regarding runtime.Object = parent
+	if parent == nil {
+		ref := driftResult.ParentRef
+		regarding = &corev1.ObjectReference{APIVersion: ref.APIVersion, Kind: ref.Kind, Namespace: ref.Namespace, Name: ref.Name}
+	}
+	note := fmt.Sprintf("%s %s changed by %s: %s", obj.GetObjectKind().GroupVersionKind().Kind, obj.GetName(), req.UserInfo.Username, msg)
+	if len(note) > maxEventNote {
+		note = note[:maxEventNote-3] + "..."
+	}
+	h.eventRecorder.Eventf(regarding, obj, corev1.EventTypeWarning, EventReasonDriftBlocked, "Deny", "%s", note)
+}
@@ -0,0 +1,260 @@
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kausalityv1alpha1 "github.com/kausality-io/kausality/api/v1alpha1"
+	"github.com/kausality-io/kausality/pkg/approval"
+	"github.com/kausality-io/kausality/pkg/config"
+	"github.com/kausality-io/kausality/pkg/controller"
+	"github.com/kausality-io/kausality/pkg/decide"
+	"github.com/kausality-io/kausality/pkg/drift"
+)
+
+// Explanation describes how the handler decides a child mutation.
+type Explanation struct {
+	// Parent is the parent of the child, nil if the child has none.
+	Parent *drift.ParentRef
+	// Phase is the lifecycle phase of the parent.
+	Phase drift.LifecyclePhase
+	// DriftDetected is the drift classification of the mutation.
+	DriftDetected bool
+	// Reason explains the drift classification.
+	Reason string
+	// BreakGlass is set if a valid break-glass token overrides freeze and enforcement.
+	BreakGlass bool
+	// Freeze is the parent's freeze, nil if the parent is not frozen or the freeze
+	// does not block the mutation.
+	Freeze *approval.Freeze
+	// Approval is the approval check of a drifting mutation, with the matched approval
+	// or rejection. Zero if there is no drift.
+	Approval approval.CheckResult
+	// Mode is the resolved drift detection mode.
+	Mode string
+	// Allowed is the final admission decision.
+	Allowed bool
+	// Message explains a denial, or a drift that is allowed only because the mode is not enforce.
+	Message string
+}
+
+// refineDrift refines the drift classification of a child mutation by field ownership,
+// cleared fields, baselines and recreates. oldObj is nil for CREATE.
+func (h *Handler) refineDrift(cfg *config.Config, req admission.Request, obj client.Object, oldObj *unstructured.Unstructured, userID string, driftResult *drift.DriftResult, log logr.Logger) {
+	// Server-side apply ownership moving to this request's field manager refines the classification
+	if oldObj != nil {
+		manager := extractFieldManager(req)
+		conflicts := drift.OwnershipConflicts(oldObj.GetManagedFields(), obj.GetManagedFields(), manager)
+		drift.ClassifyOwnershipConflicts(driftResult, conflicts, drift.ManagesSpec(oldObj.GetManagedFields(), manager))
+	}
+
+	// A controller clearing fields someone else set gets a precise reason
+	if u, ok := obj.(*unstructured.Unstructured); ok && oldObj != nil && driftResult.DriftDetected {
+		oldSpec, _, _ := unstructured.NestedMap(oldObj.Object, "spec")
+		newSpec, _, _ := unstructured.NestedMap(u.Object, "spec")
+		drift.ClassifyClearedFields(driftResult, drift.ClearedFields(oldSpec, newSpec, oldObj.GetManagedFields(), extractFieldManager(req)))
+	}
+
+	// Changes the controllers of the parent kind are expected to make are not drift
+	if driftResult.DriftDetected {
+		h.applyBaseline(cfg, req, obj, driftResult, log)
+	}
+
+	// A controller re-creating a recently deleted child is classified by who deleted it
+	if driftResult.DriftDetected && req.Operation == admissionv1.Create {
+		h.applyRecreate(obj, userID, driftResult, log)
+	}
+
+	// Trusted field managers, e.g. GitOps tools, are users, never the drifting controller
+	if driftResult.DriftDetected && h.trustedUser(cfg, req) {
+		driftResult.DriftDetected = false
+		driftResult.Reason = fmt.Sprintf("field manager %q is a trusted user", extractFieldManager(req))
+		log.V(1).Info("trusted user field manager, not drift", "fieldManager", extractFieldManager(req))
+	}
+
+	// Children right after creation get several controller writes while the parent settles
+	if driftResult.DriftDetected && h.youngChild(cfg, obj) {
+		driftResult.DriftDetected = false
+		driftResult.Reason = fmt.Sprintf("child is younger than minObjectAge %s", cfg.DriftDetection.MinObjectAge)
+		log.V(1).Info("young child, not drift", "creationTimestamp", obj.GetCreationTimestamp())
+	}
+}
+
+// youngChild returns true if the child was created less than minObjectAge ago.
+// Children without a creationTimestamp are not young.
+func (h *Handler) youngChild(cfg *config.Config, obj client.Object) bool {
+	if cfg == nil || cfg.DriftDetection.MinObjectAge <= 0 {
+		return false
+	}
+	created := obj.GetCreationTimestamp()
+	return !created.IsZero() && time.Since(created.Time) < cfg.DriftDetection.MinObjectAge
+}
+
+// trustedUser returns true if the request's field manager is a trusted user.
+func (h *Handler) trustedUser(cfg *config.Config, req admission.Request) bool {
+	return cfg != nil && cfg.IsTrustedUserFieldManager(extractFieldManager(req))
+}
+
+// decideDrift decides a drifting mutation from its approval check and the resolved mode.
+// chainMsg describes spec changes by other mutators in the admission chain, if any.
+func (h *Handler) decideDrift(cfg *config.Config, req admission.Request, obj client.Object, driftResult *drift.DriftResult, result approval.CheckResult, mode string, synthetic bool, chainMsg string) decide.Verdict {
+	in := decide.DriftInput{
+		Result:            driftResult,
+		Approval:          result,
+		Mode:              mode,
+		DenyClearedFields: cfg.DeniesClearedFields(),
+		Synthetic:         synthetic,
+		Render:            decide.DenyMessageRenderer(cfg, driftResult, obj, req.UserInfo.Username),
+	}
+	if chainMsg != "" {
+		in.Notes = []string{chainMsg}
+	}
+	return decide.Drift(in)
+}
+
+// Explain evaluates a child mutation like Handle, with the same drift detection, freeze,
+// approval and mode resolution, but without side effects: approvals are not consumed,
+// no callbacks or Events are sent and nothing is written. Namespace metadata that cannot
+// be read is treated as empty. Status updates are not explained.
+func (h *Handler) Explain(ctx context.Context, req admission.Request) (*Explanation, error) {
+	cfg := h.config()
+	log := h.log.WithValues("operation", req.Operation, "kind", req.Kind.String(), "namespace", req.Namespace, "name", req.Name)
+
+	if req.Operation == admissionv1.Update {
+		specChanged, err := h.hasSpecChanged(cfg, req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check spec change: %w", err)
+		}
+		if !specChanged && !h.takesSpecOwnership(req) {
+			return &Explanation{Allowed: true, Reason: "no spec change"}, nil
+		}
+	}
+
+	obj, err := h.parseObject(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse object: %w", err)
+	}
+	var childUpdaters []string
+	var oldObj *unstructured.Unstructured
+	if req.Operation == admissionv1.Update && len(req.OldObject.Raw) > 0 {
+		decoded := &unstructured.Unstructured{}
+		if err := runtime.DecodeInto(unstructured.UnstructuredJSONScheme, req.OldObject.Raw, decoded); err == nil {
+			oldObj = decoded
+			childUpdaters = drift.ParseUpdaterHashesWithKeys(oldObj, h.keys)
+		}
+	}
+	userID := controller.UserIdentifier(req.UserInfo.Username, req.UserInfo.UID)
+	if !hasUserInfo(req) {
+		userID = anonymousUserID
+	}
+
+	driftResult, reads, err := h.detect(ctx, cfg, obj, userID, childUpdaters)
+	if err != nil {
+		return nil, fmt.Errorf("drift detection failed: %w", err)
+	}
+	if !hasUserInfo(req) {
+		h.classifyAnonymous(cfg, driftResult)
+	}
+	h.refineDrift(cfg, req, obj, oldObj, userID, driftResult, log)
+
+	explanation := &Explanation{
+		Parent:        driftResult.ParentRef,
+		Phase:         driftResult.LifecyclePhase,
+		DriftDetected: driftResult.DriftDetected,
+		Reason:        driftResult.Reason,
+		Allowed:       true,
+	}
+
+	if driftResult.ParentRef != nil {
+		audit, err := h.checkBreakGlass(obj, req.UserInfo.Username)
+		explanation.BreakGlass = err == nil && audit != nil
+	}
+	if driftResult.ParentRef != nil && driftResult.LifecyclePhase != drift.PhaseDeleting && !explanation.BreakGlass {
+		if frozen, freeze := h.checkFreeze(ctx, reads, driftResult.ParentRef, obj.GetNamespace(), log); frozen && !h.freezeAllowsConvergence(cfg, driftResult, userID, childUpdaters) {
+			explanation.Freeze = freeze
+			explanation.Allowed = false
+			explanation.Message = fmt.Sprintf("mutation blocked: parent %s", freeze.String())
+			return explanation, nil
+		}
+	}
+
+	var nsLabels, nsAnnotations map[string]string
+	if obj.GetNamespace() != "" {
+		if labels, annotations, err := h.namespaceMetadata(ctx, reads, obj.GetNamespace()); err == nil {
+			nsLabels, nsAnnotations = labels, annotations
+		}
+	}
+	objAnnotations := obj.GetAnnotations()
+	if objAnnotations == nil {
+		objAnnotations = map[string]string{}
+	}
+	if nsAnnotations == nil {
+		nsAnnotations = map[string]string{}
+	}
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	explanation.Mode = h.resolveMode(cfg, gvk, obj.GetNamespace(), nsLabels, obj.GetLabels(), objAnnotations, nsAnnotations)
+	if explanation.Mode == string(kausalityv1alpha1.ModeEnforce) && driftResult.DriftDetected && h.health.Degraded(ctx) {
+		explanation.Mode = string(kausalityv1alpha1.ModeLog)
+	}
+
+	if !driftResult.DriftDetected || explanation.BreakGlass {
+		return explanation, nil
+	}
+	explanation.Approval = h.checkApprovals(ctx, cfg, reads, req, driftResult, obj, log).CheckResult
+	decision := h.decideDrift(cfg, req, obj, driftResult, explanation.Approval, explanation.Mode, false, "")
+	explanation.Allowed = decision.Allowed
+	explanation.Message = decision.Message
+	return explanation, nil
+}
+
+// NewExplainRequest builds the admission request of a child mutation for Explain. oldObj
+// is nil for CREATE, obj is nil for DELETE.
+func NewExplainRequest(op admissionv1.Operation, oldObj, obj *unstructured.Unstructured, username, fieldManager string) (admission.Request, error) {
+	req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{Operation: op}}
+	req.UserInfo.Username = username
+
+	target := obj
+	if target == nil {
+		target = oldObj
+	}
+	if target == nil {
+		return req, fmt.Errorf("no object for %s", op)
+	}
+	gvk := target.GroupVersionKind()
+	req.Kind.Group, req.Kind.Version, req.Kind.Kind = gvk.Group, gvk.Version, gvk.Kind
+	req.Namespace = target.GetNamespace()
+	req.Name = target.GetName()
+
+	for _, o := range []struct {
+		obj *unstructured.Unstructured
+		raw *runtime.RawExtension
+	}{{obj, &req.Object}, {oldObj, &req.OldObject}} {
+		if o.obj == nil {
+			continue
+		}
+		raw, err := json.Marshal(o.obj.Object)
+		if err != nil {
+			return req, fmt.Errorf("failed to marshal object: %w", err)
+		}
+		o.raw.Raw = raw
+	}
+
+	if fieldManager != "" {
+		raw, err := json.Marshal(map[string]string{"fieldManager": fieldManager})
+		if err != nil {
+			return req, fmt.Errorf("failed to marshal options: %w", err)
+		}
+		req.Options.Raw = raw
+	}
+	return req, nil
+}
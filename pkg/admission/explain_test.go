@@ -0,0 +1,157 @@
+package admission
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kausalityv1alpha1 "github.com/kausality-io/kausality/api/v1alpha1"
+	"github.com/kausality-io/kausality/pkg/config"
+	"github.com/kausality-io/kausality/pkg/controller"
+	"github.com/kausality-io/kausality/pkg/drift"
+)
+
+func TestExplain(t *testing.T) {
+	updaters := map[string]string{kausalityv1alpha1.UpdatersAnnotation: controller.HashUsername(testController)}
+	enforce := &config.Config{DriftDetection: config.DriftDetectionConfig{DefaultMode: config.ModeEnforce}}
+
+	tests := []struct {
+		name         string
+		cfg          *config.Config
+		parentAnns   map[string]string
+		user         string
+		wantDrift    bool
+		wantMode     string
+		wantApproved bool
+		wantRejected bool
+		wantFrozen   bool
+		wantAllowed  bool
+		wantMessage  string
+	}{
+		{
+			name:        "controller drift is denied in enforce mode",
+			cfg:         enforce,
+			user:        testController,
+			wantDrift:   true,
+			wantMode:    config.ModeEnforce,
+			wantMessage: "drift detected: no approval found for this mutation",
+		},
+		{
+			name:        "controller drift is allowed in log mode",
+			cfg:         config.Default(),
+			user:        testController,
+			wantDrift:   true,
+			wantMode:    config.ModeLog,
+			wantAllowed: true,
+			wantMessage: "drift detected: no approval found for this mutation",
+		},
+		{
+			name:         "approved drift is allowed",
+			cfg:          enforce,
+			parentAnns:   map[string]string{kausalityv1alpha1.ApprovalsAnnotation: `[{"apiVersion":"example.com/v1","kind":"Widget","name":"child","mode":"once","generation":1}]`},
+			user:         testController,
+			wantDrift:    true,
+			wantMode:     config.ModeEnforce,
+			wantApproved: true,
+			wantAllowed:  true,
+		},
+		{
+			name:         "rejected drift is denied",
+			cfg:          enforce,
+			parentAnns:   map[string]string{kausalityv1alpha1.RejectionsAnnotation: `[{"apiVersion":"example.com/v1","kind":"Widget","name":"child","reason":"hands off"}]`},
+			user:         testController,
+			wantDrift:    true,
+			wantMode:     config.ModeEnforce,
+			wantRejected: true,
+			wantMessage:  "drift rejected: hands off",
+		},
+		{
+			name:        "user changes are no drift",
+			cfg:         enforce,
+			user:        "alice",
+			wantMode:    config.ModeEnforce,
+			wantAllowed: true,
+		},
+		{
+			name:        "frozen parent blocks users too",
+			cfg:         enforce,
+			parentAnns:  map[string]string{kausalityv1alpha1.FreezeAnnotation: `{"user":"bob","message":"incident"}`},
+			user:        "alice",
+			wantFrozen:  true,
+			wantMessage: "mutation blocked: parent",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parent := stableParent(tt.parentAnns)
+			oldChild := ownedChild("child", updaters, map[string]interface{}{"size": int64(1)})
+			newChild := ownedChild("child", updaters, map[string]interface{}{"size": int64(2)})
+			h, c := newFakeHandler(t, Config{DriftConfig: tt.cfg}, parent, oldChild)
+
+			req, err := NewExplainRequest(admissionv1.Update, oldChild, newChild, tt.user, "")
+			require.NoError(t, err)
+			explanation, err := h.Explain(t.Context(), req)
+			require.NoError(t, err)
+
+			if !tt.wantFrozen {
+				assert.Equal(t, drift.PhaseInitialized, explanation.Phase)
+				assert.Equal(t, tt.wantMode, explanation.Mode)
+			}
+			require.NotNil(t, explanation.Parent)
+			assert.Equal(t, testParentName, explanation.Parent.Name)
+			assert.Equal(t, tt.wantDrift, explanation.DriftDetected, explanation.Reason)
+			assert.Equal(t, tt.wantApproved, explanation.Approval.Approved)
+			assert.Equal(t, tt.wantRejected, explanation.Approval.Rejected)
+			assert.Equal(t, tt.wantFrozen, explanation.Freeze != nil)
+			assert.Equal(t, tt.wantAllowed, explanation.Allowed)
+			if tt.wantMessage == "" {
+				assert.Empty(t, explanation.Message)
+			} else {
+				assert.Contains(t, explanation.Message, tt.wantMessage)
+			}
+
+			// Explain agrees with Handle, but has no side effects
+			resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, oldChild, newChild, tt.user))
+			assert.Equal(t, resp.Allowed, explanation.Allowed, "Handle decides the same")
+			current := stableParent(nil)
+			require.NoError(t, c.Get(t.Context(), client.ObjectKeyFromObject(parent), current))
+			if tt.wantApproved {
+				assert.NotContains(t, current.GetAnnotations(), kausalityv1alpha1.ApprovalsAnnotation, "only Handle consumes the approval")
+			}
+		})
+	}
+}
+
+func TestExplain_NoSpecChange(t *testing.T) {
+	child := ownedChild("child", nil, map[string]interface{}{"size": int64(1)})
+	h, _ := newFakeHandler(t, Config{}, stableParent(nil), child)
+
+	req, err := NewExplainRequest(admissionv1.Update, child, child, testController, "")
+	require.NoError(t, err)
+	explanation, err := h.Explain(t.Context(), req)
+	require.NoError(t, err)
+	assert.True(t, explanation.Allowed)
+	assert.Equal(t, "no spec change", explanation.Reason)
+	assert.Nil(t, explanation.Parent)
+}
+
+func TestNewExplainRequest(t *testing.T) {
+	child := ownedChild("child", nil, nil)
+
+	req, err := NewExplainRequest(admissionv1.Delete, child, nil, "alice", "kubectl")
+	require.NoError(t, err)
+	assert.Equal(t, "Widget", req.Kind.Kind)
+	assert.Equal(t, testNamespace, req.Namespace)
+	assert.Equal(t, "child", req.Name)
+	assert.Empty(t, req.Object.Raw)
+	assert.NotEmpty(t, req.OldObject.Raw)
+	assert.Equal(t, "kubectl", extractFieldManager(req))
+
+	_, err = NewExplainRequest(admissionv1.Create, nil, nil, "alice", "")
+	assert.Error(t, err)
+}
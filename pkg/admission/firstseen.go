@@ -0,0 +1,221 @@
+package admission
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kausalityv1alpha1 "github.com/kausality-io/kausality/api/v1alpha1"
+)
+
+const (
+	// maxFirstSeenEntries bounds the drift IDs recorded on a single parent.
+	maxFirstSeenEntries = 20
+	// maxFirstSeenCached bounds the in-memory cache. When full, the least recently
+	// used drift is evicted first; persisted times are reloaded from the parents.
+	maxFirstSeenCached = 10000
+)
+
+// firstSeenTracker tracks when each drift ID was first detected.
+// Times are cached in memory and persisted on the parent, so they survive webhook restarts.
+type firstSeenTracker struct {
+	client client.Client
+	// key is the annotation the times are persisted in.
+	key     string
+	nowFunc func() time.Time
+	log     logr.Logger
+
+	mu    sync.Mutex
+	times map[string]*list.Element // ID -> element in order
+	order *list.List               // firstSeenEntry, least recently used first
+
+	pendingMu sync.Mutex
+	pending   map[string]*pendingFirstSeen // parent key -> times to persist
+}
+
+// firstSeenEntry is a cached first-seen time.
+type firstSeenEntry struct {
+	id string
+	at time.Time
+}
+
+// pendingFirstSeen are first-seen times waiting to be persisted on a parent.
+type pendingFirstSeen struct {
+	parent client.Object
+	times  map[string]time.Time
+}
+
+func newFirstSeenTracker(c client.Client, keys kausalityv1alpha1.AnnotationKeys, log logr.Logger) *firstSeenTracker {
+	return &firstSeenTracker{
+		client:  c,
+		key:     keys.DriftFirstSeen,
+		nowFunc: time.Now,
+		log:     log,
+		times:   make(map[string]*list.Element),
+		order:   list.New(),
+		pending: make(map[string]*pendingFirstSeen),
+	}
+}
+
+// Observe returns when the drift was first seen, recording it in memory as now if
+// unknown. The parent may be nil, in which case only the in-memory cache is used.
+// Use PersistAsync to record the time on the parent.
+func (t *firstSeenTracker) Observe(parent client.Object, id string) time.Time {
+	if at, ok := t.Lookup(parent, id); ok {
+		return at
+	}
+
+	now := t.nowFunc().UTC().Truncate(time.Second)
+	t.mu.Lock()
+	t.cacheLocked(id, now)
+	t.mu.Unlock()
+	return now
+}
+
+// Lookup returns when the drift was first seen without recording it.
+func (t *firstSeenTracker) Lookup(parent client.Object, id string) (time.Time, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if elem, ok := t.times[id]; ok {
+		t.order.MoveToBack(elem)
+		return elem.Value.(*firstSeenEntry).at, true
+	}
+	if parent == nil {
+		return time.Time{}, false
+	}
+	if at, ok := parseFirstSeen(parent.GetAnnotations()[t.key])[id]; ok {
+		t.cacheLocked(id, at)
+		return at, true
+	}
+	return time.Time{}, false
+}
+
+// cacheLocked caches the time of id, evicting the least recently used entries when
+// full. The caller must hold t.mu.
+func (t *firstSeenTracker) cacheLocked(id string, at time.Time) {
+	if elem, ok := t.times[id]; ok {
+		elem.Value.(*firstSeenEntry).at = at
+		t.order.MoveToBack(elem)
+		return
+	}
+	for t.order.Len() >= maxFirstSeenCached {
+		front := t.order.Front()
+		t.order.Remove(front)
+		delete(t.times, front.Value.(*firstSeenEntry).id)
+	}
+	t.times[id] = t.order.PushBack(&firstSeenEntry{id: id, at: at})
+}
+
+// PersistAsync records the first-seen time on the parent in the background, unless the
+// parent already has it. Times for the same parent are coalesced into one patch.
+func (t *firstSeenTracker) PersistAsync(ctx context.Context, parent client.Object, id string, at time.Time) {
+	if parent == nil {
+		return
+	}
+	if _, ok := parseFirstSeen(parent.GetAnnotations()[t.key])[id]; ok {
+		return
+	}
+
+	key := parent.GetObjectKind().GroupVersionKind().String() + "/" + parent.GetNamespace() + "/" + parent.GetName()
+	t.pendingMu.Lock()
+	p, alreadyPending := t.pending[key]
+	if !alreadyPending {
+		p = &pendingFirstSeen{parent: parent.DeepCopyObject().(client.Object), times: map[string]time.Time{}}
+		t.pending[key] = p
+	}
+	p.times[id] = at
+	t.pendingMu.Unlock()
+
+	if !alreadyPending {
+		go t.flush(context.WithoutCancel(ctx), key)
+	}
+}
+
+// flush persists the pending first-seen times of a parent with a merge patch of only
+// the first-seen annotation. Failures are logged and ignored; the in-memory cache
+// still holds the times until they are evicted or the webhook restarts.
+func (t *firstSeenTracker) flush(ctx context.Context, key string) {
+	t.pendingMu.Lock()
+	p := t.pending[key]
+	delete(t.pending, key)
+	t.pendingMu.Unlock()
+
+	current := p.parent
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		if err := t.client.Get(ctx, client.ObjectKeyFromObject(p.parent), current); err != nil {
+			return err
+		}
+		entries := parseFirstSeen(current.GetAnnotations()[t.key])
+		changed := false
+		for id, at := range p.times {
+			if _, ok := entries[id]; !ok {
+				entries[id] = at
+				changed = true
+			}
+		}
+		if !changed {
+			return nil
+		}
+		value, err := marshalFirstSeen(entries)
+		if err != nil {
+			return err
+		}
+		// The resourceVersion makes concurrent writers of the annotation conflict
+		patch, err := json.Marshal(map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"annotations":     map[string]string{t.key: value},
+				"resourceVersion": current.GetResourceVersion(),
+			},
+		})
+		if err != nil {
+			return err
+		}
+		return t.client.Patch(ctx, current, client.RawPatch(types.MergePatchType, patch))
+	})
+	if err != nil {
+		t.log.V(1).Info("failed to persist drift first-seen times", "parent", key, "error", err)
+	}
+}
+
+// parseFirstSeen parses the first-seen annotation. Invalid values yield an empty map.
+func parseFirstSeen(value string) map[string]time.Time {
+	entries := map[string]time.Time{}
+	if value == "" {
+		return entries
+	}
+	if err := json.Unmarshal([]byte(value), &entries); err != nil {
+		return map[string]time.Time{}
+	}
+	return entries
+}
+
+// marshalFirstSeen serializes first-seen times, keeping only the most recent entries.
+func marshalFirstSeen(entries map[string]time.Time) (string, error) {
+	if len(entries) > maxFirstSeenEntries {
+		ids := make([]string, 0, len(entries))
+		for id := range entries {
+			ids = append(ids, id)
+		}
+		sort.Slice(ids, func(i, j int) bool {
+			return entries[ids[i]].After(entries[ids[j]])
+		})
+		for _, id := range ids[maxFirstSeenEntries:] {
+			delete(entries, id)
+		}
+	}
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
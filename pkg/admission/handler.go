@@ -5,45 +5,77 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	jsonpatch "gomodules.xyz/jsonpatch/v2"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/events"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	kausalityv1alpha1 "github.com/kausality-io/kausality/api/v1alpha1"
 	"github.com/kausality-io/kausality/pkg/approval"
+	"github.com/kausality-io/kausality/pkg/baseline"
+	"github.com/kausality-io/kausality/pkg/breakglass"
 	"github.com/kausality-io/kausality/pkg/callback"
 	"github.com/kausality-io/kausality/pkg/callback/v1alpha1"
 	"github.com/kausality-io/kausality/pkg/config"
 	"github.com/kausality-io/kausality/pkg/controller"
+	"github.com/kausality-io/kausality/pkg/decide"
 	"github.com/kausality-io/kausality/pkg/drift"
+	"github.com/kausality-io/kausality/pkg/health"
+	"github.com/kausality-io/kausality/pkg/lineage"
+	"github.com/kausality-io/kausality/pkg/metrics"
 	"github.com/kausality-io/kausality/pkg/policy"
 	"github.com/kausality-io/kausality/pkg/trace"
 )
 
 // Handler handles admission requests for drift detection and tracing.
 type Handler struct {
-	client            client.Client
-	decoder           admission.Decoder
-	detector          *drift.Detector
-	propagator        *trace.Propagator
-	approvalChecker   *approval.Checker
-	callbackSender    callback.ReportSender
-	controllerTracker *controller.Tracker
-	lifecycleDetector *drift.LifecycleDetector
-	config            *config.Config
-	policyResolver    policy.Resolver
-	log               logr.Logger
+	client             client.Client
+	decoder            admission.Decoder
+	detector           *drift.Detector
+	propagator         *trace.Propagator
+	approvalChecker    *approval.Checker
+	callbackSender     callback.ReportSender
+	controllerTracker  *controller.Tracker
+	lifecycleDetector  *drift.LifecycleDetector
+	configSource       func() *config.Config
+	policyResolver     policy.Resolver
+	breakGlass         *breakglass.Verifier
+	controllerVersions *controllerVersionResolver
+	parallelReads      bool
+	firstSeen          *firstSeenTracker
+	proposals          *proposalRecorder
+	lineage            *lineage.Exporter
+	health             *healthGate
+	parentCache        *parentCache
+	baselines          baseline.Matcher
+	recreates          *recreateTracker
+	stuck              *stuckTracker
+	auditLog           *auditLog
+	fieldManager       string
+	eventRecorder      events.EventRecorder
+	traceCondition     bool
+	traceParent        bool
+	keys               kausalityv1alpha1.AnnotationKeys
+	hash               controller.HashFunc
+	log                logr.Logger
 }
 
+// DefaultFieldManager is the field manager of kausality's own writes by default.
+const DefaultFieldManager = "kausality"
+
 // Config configures the admission handler.
 type Config struct {
 	Client client.Client
@@ -52,6 +84,12 @@ type Config struct {
 	// Used as fallback when PolicyResolver is nil or has no matching policies.
 	// If nil, defaults to log mode for all resources.
 	DriftConfig *config.Config
+	// DriftConfigSource returns the current drift configuration, e.g. config.Watcher.Get
+	// for a configuration reloaded from a ConfigMap. Takes precedence over DriftConfig.
+	// Settings read per request, like modes, overrides and the resource scope, follow it.
+	// Settings bound when the handler is created, like the annotation prefix, parent
+	// references, backends and trackers, keep the configuration it returned then.
+	DriftConfigSource func() *config.Config
 	// PolicyResolver provides policy configuration for drift detection.
 	// Can be a *policy.Store (CRD-based) or *policy.StaticResolver (in-memory).
 	// Takes precedence over DriftConfig when set.
@@ -59,32 +97,162 @@ type Config struct {
 	// CallbackSender sends drift reports to webhook endpoints.
 	// If nil, drift callbacks are disabled.
 	CallbackSender callback.ReportSender
+	// BreakGlassVerifier verifies emergency tokens in the kausality.io/break-glass annotation.
+	// If nil, break-glass tokens are ignored.
+	BreakGlassVerifier *breakglass.Verifier
+	// ParallelReads issues the parent and namespace reads concurrently with drift
+	// detection instead of one after another. Decisions are unchanged.
+	ParallelReads bool
+	// LineageExporter exports traced mutations as OpenLineage run events.
+	// If nil, lineage export is disabled.
+	LineageExporter *lineage.Exporter
+	// HealthSignal reports cluster health. While unhealthy, enforce mode is downgraded
+	// to log mode. If nil, enforcement is never downgraded.
+	HealthSignal health.Signal
+	// CreateCacheTTL caches parents read while admitting CREATEs for this long, so bursts
+	// of sibling creates (e.g. generateName Pods) reuse one parent fetch. Zero disables it.
+	CreateCacheTTL time.Duration
+	// Classifiers replace the generation/observedGeneration heuristic for parents of
+	// their kind, in all versions, see drift.Classifier.
+	Classifiers map[schema.GroupKind]drift.Classifier
+	// Baselines declare the changes controllers make to children in normal operation.
+	// A drift within a baseline is allowed. If nil, every controller change is drift.
+	Baselines baseline.Matcher
+	// FieldManager is the field manager of the handler's own writes, e.g. pruning approvals
+	// or recording controllers on parents. Updates with this field manager that don't change
+	// the spec are kausality's own and admitted unchanged, never attributed to an actor.
+	// Defaults to DefaultFieldManager.
+	FieldManager string
+	// EventRecorder records Events on parents, e.g. DriftBlocked when drift of a child
+	// is denied. If nil, no Events are recorded.
+	EventRecorder events.EventRecorder
+	// AuditWriter receives one JSON AuditRecord per line for every admission decision,
+	// for log pipelines that index decisions. If nil, no audit records are written.
+	AuditWriter io.Writer
+	// WriteTraceCondition also sets a CausalTrace condition summarizing the trace on
+	// objects that have a status.conditions array. Other objects are left alone.
+	WriteTraceCondition bool
+	// WriteTraceParent also stamps the trace as a W3C traceparent annotation, to join
+	// causal chains with distributed traces.
+	WriteTraceParent bool
 }
 
 // NewHandler creates a new admission Handler.
 func NewHandler(cfg Config) *Handler {
 	driftConfig := cfg.DriftConfig
+	if cfg.DriftConfigSource != nil {
+		driftConfig = cfg.DriftConfigSource()
+	}
 	if driftConfig == nil {
 		driftConfig = config.Default()
 	}
+	configSource := cfg.DriftConfigSource
+	if configSource == nil {
+		configSource = staticConfig(driftConfig)
+	}
 	log := cfg.Log.WithName("kausality-admission")
-	return &Handler{
-		client:            cfg.Client,
-		detector:          drift.NewDetector(cfg.Client),
-		propagator:        trace.NewPropagator(cfg.Client),
-		approvalChecker:   approval.NewChecker(),
-		callbackSender:    cfg.CallbackSender,
-		controllerTracker: controller.NewTracker(cfg.Client, log),
-		lifecycleDetector: drift.NewLifecycleDetector(),
-		config:            driftConfig,
-		policyResolver:    cfg.PolicyResolver,
-		log:               log,
+	fieldManager := cfg.FieldManager
+	if fieldManager == "" {
+		fieldManager = DefaultFieldManager
+	}
+	c := cfg.Client
+	if c != nil {
+		c = client.WithFieldOwner(c, fieldManager)
+	}
+	parentRefs := driftConfig.DriftDetection.ParentReferences
+	// Only drift detection applies the grace period; the status path records phases without it
+	lifecycle := &drift.LifecycleDetector{
+		DetectionOrder:           drift.DefaultDetectionOrder,
+		StabilizationGracePeriod: driftConfig.DriftDetection.StabilizationGracePeriod,
+	}
+	parentCache := newParentCache(cfg.CreateCacheTTL)
+	if parentCache != nil {
+		c = &parentCacheClient{Client: c, cache: parentCache}
+	}
+	keys := driftConfig.AnnotationKeys()
+	hash := controller.NewHashFunc(driftConfig.IdentityHash)
+	detectorOpts := []drift.DetectorOption{
+		drift.WithParentReferences(parentRefs),
+		drift.WithArrayMergeKeys(driftConfig.DriftDetection.ArrayMergeKeys),
+		drift.WithLifecycleDetector(lifecycle),
+		drift.WithAnnotationKeys(keys),
+		drift.WithHashFunc(hash),
+		drift.WithOwnerSelection(driftConfig.DriftDetection.OwnerSelection),
+	}
+	for gk, classifier := range cfg.Classifiers {
+		detectorOpts = append(detectorOpts, drift.WithClassifier(gk, classifier))
+	}
+	controllerTracker := controller.NewTracker(c, log)
+	controllerTracker.SetAnnotationKeys(keys)
+	controllerTracker.SetHashFunc(hash)
+	h := &Handler{
+		client:             c,
+		propagator:         trace.NewPropagatorWithOptions(c, trace.WithMaxAge(driftConfig.TraceMaxAge), trace.WithMaxTraceHops(driftConfig.MaxTraceHops), trace.WithParentReferences(parentRefs), trace.WithAnnotationKeys(keys), trace.WithHashFunc(hash)),
+		approvalChecker:    approval.NewChecker(approval.WithAnnotationKeys(keys)),
+		callbackSender:     cfg.CallbackSender,
+		controllerTracker:  controllerTracker,
+		lifecycleDetector:  drift.NewLifecycleDetector(),
+		configSource:       configSource,
+		policyResolver:     cfg.PolicyResolver,
+		breakGlass:         cfg.BreakGlassVerifier,
+		controllerVersions: newControllerVersionResolver(c, driftConfig, log),
+		parallelReads:      cfg.ParallelReads,
+		firstSeen:          newFirstSeenTracker(c, keys, log),
+		proposals:          newProposalRecorder(c, driftConfig, log),
+		lineage:            cfg.LineageExporter,
+		health:             newHealthGate(cfg.HealthSignal, driftConfig, log),
+		parentCache:        parentCache,
+		baselines:          cfg.Baselines,
+		recreates:          newRecreateTracker(driftConfig),
+		stuck:              newStuckTracker(driftConfig),
+		auditLog:           newAuditLog(cfg.AuditWriter, log),
+		fieldManager:       fieldManager,
+		eventRecorder:      cfg.EventRecorder,
+		traceCondition:     cfg.WriteTraceCondition,
+		traceParent:        cfg.WriteTraceParent,
+		keys:               keys,
+		hash:               hash,
+		log:                log,
+	}
+	// Drift detection reads parents like the rest of the handler, see fetchParent
+	detectorOpts = append(detectorOpts, drift.WithParentFetcher(func(ctx context.Context, ref drift.ParentRef, childNamespace string) (*unstructured.Unstructured, error) {
+		return h.parentCache.fetch(ctx, h.client, ref, childNamespace)
+	}))
+	h.detector = drift.NewDetectorWithOptions(c, detectorOpts...)
+	return h
+}
+
+// config returns the current drift configuration, nil for a Handler not created by
+// NewHandler.
+func (h *Handler) config() *config.Config {
+	if h.configSource == nil {
+		return nil
 	}
+	return h.configSource()
+}
+
+// staticConfig returns a configuration source that always returns cfg.
+func staticConfig(cfg *config.Config) func() *config.Config {
+	return func() *config.Config { return cfg }
 }
 
 // Handle processes an admission request for drift detection and tracing.
-func (h *Handler) Handle(ctx context.Context, req admission.Request) admission.Response {
+func (h *Handler) Handle(ctx context.Context, req admission.Request) (response admission.Response) {
+	start := time.Now()
+	defer func() {
+		metrics.AdmissionDuration.WithLabelValues(string(req.Operation)).Observe(time.Since(start).Seconds())
+	}()
+
+	// The configuration can be reloaded any time: decide the request on one snapshot
+	cfg := h.config()
+
+	// Resources out of scope are skipped before anything is decoded
+	if !cfg.InScope(schema.GroupVersionKind{Group: req.Kind.Group, Version: req.Kind.Version, Kind: req.Kind.Kind}) {
+		return admission.Allowed("not in scope")
+	}
+
 	log := h.log.WithValues(
+		"uid", req.UID,
 		"operation", req.Operation,
 		"kind", req.Kind.String(),
 		"namespace", req.Namespace,
@@ -93,31 +261,106 @@ func (h *Handler) Handle(ctx context.Context, req admission.Request) admission.R
 		"subresource", req.SubResource,
 	)
 
-	// Handle CREATE, UPDATE, and DELETE (DELETE just sets deletionTimestamp)
+	// Every decision is written to the audit log, if configured
+	audit := h.auditLog.start(req)
+	if audit != nil {
+		defer func() { h.auditLog.write(audit, response) }()
+	}
+
+	// Handle CREATE, UPDATE, and DELETE (DELETE just sets deletionTimestamp). PATCH requests,
+	// including server-side apply, arrive as UPDATE (or CREATE) with the patched object.
 	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update && req.Operation != admissionv1.Delete {
 		return admission.Allowed("operation not relevant for tracing")
 	}
 
+	// Old and new objects must agree on apiVersion and kind before anything is decoded
+	if err := checkGVKMatch(req); err != nil {
+		log.Error(err, "old and new object kinds differ")
+		if cfg != nil && cfg.DriftDetection.OnGVKMismatch == config.GVKMismatchAllowWithWarning {
+			return withWarnings(admission.Allowed("gvk mismatch"), []string{fmt.Sprintf("[kausality] %v; request not processed", err)})
+		}
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	// Requests without user info cannot be attributed to an actor
+	if !hasUserInfo(req) {
+		log.Info("request without user info", "decision", h.onMissingUserInfo(cfg))
+		if h.onMissingUserInfo(cfg) == config.MissingUserInfoDeny {
+			return admission.Denied("[kausality] request without user info cannot be attributed")
+		}
+	}
+
+	// Parents changed by this request must not be served from the CREATE burst cache
+	h.parentCache.invalidateRequest(req)
+	ctx = h.parentCache.withCreateBurst(ctx, req)
+
+	// Weakening enforcement on an object is a governed action
+	if req.Operation == admissionv1.Update && req.SubResource == "" && cfg != nil && cfg.DriftDetection.GovernPostureChanges {
+		if resp := h.governPosture(ctx, cfg, req, log); resp != nil {
+			return *resp
+		}
+	}
+
+	// Freezes and snoozes applied by an admitted UPDATE are reported for audit
+	if req.Operation == admissionv1.Update && req.SubResource == "" {
+		defer func() {
+			if response.Allowed {
+				h.auditLockdowns(ctx, cfg, req, log)
+			}
+		}()
+	}
+
+	// Only sanctioned field managers may change kausality annotations
+	if req.Operation == admissionv1.Update && req.SubResource == "" && cfg != nil && len(cfg.DriftDetection.AnnotationWriters) > 0 {
+		resp, warning := h.checkAnnotationWriters(ctx, cfg, req, log)
+		if resp != nil {
+			return *resp
+		}
+		if warning != "" {
+			defer func() {
+				if response.Allowed {
+					response = withWarnings(response, []string{warning})
+				}
+			}()
+		}
+	}
+
 	// Handle status subresource updates - record controller identity
 	if req.SubResource == "status" {
-		return h.handleStatusUpdate(ctx, req, log)
+		return h.handleStatusUpdate(ctx, cfg, req, log)
 	}
 
+	// Dry-runs are evaluated as usual, but persist nothing beyond the discarded patch
+	dryRun := isDryRun(req)
+
+	// Synthetic drift injections are dry-run requests, usually without a spec change
+	synthetic := isSyntheticDrift(h.keys, req)
+
 	// For UPDATE, check if spec changed - ignore status/metadata-only changes
 	// DELETE always traces (sets deletionTimestamp, which is significant even though it's metadata)
-	if req.Operation == admissionv1.Update {
-		specChanged, err := h.hasSpecChanged(req)
+	if req.Operation == admissionv1.Update && !synthetic {
+		specChanged, err := h.hasSpecChanged(cfg, req)
 		if err != nil {
 			log.Error(err, "failed to check spec change")
 			return admission.Errored(http.StatusBadRequest, fmt.Errorf("failed to check spec change: %w", err))
 		}
+		// A forced server-side apply may take spec fields over without changing their values
+		if !specChanged && h.takesSpecOwnership(req) {
+			log.V(1).Info("spec field ownership taken over without a spec change")
+			specChanged = true
+		}
 		if !specChanged {
+			// Our own annotation writes, e.g. pruned approvals, must not be reverted
+			if h.isOwnWrite(req) {
+				log.V(1).Info("own write, admitting unchanged")
+				return admission.Allowed("kausality's own write")
+			}
 			// No spec change: preserve all kausality annotations (regardless of actor)
 			var oldObj, newObj unstructured.Unstructured
 			if err := json.Unmarshal(req.OldObject.Raw, &oldObj); err == nil {
 				if err := json.Unmarshal(req.Object.Raw, &newObj); err == nil {
 					// specChanged=false means newTrace/newUpdaters are unused
-					merged := computeAnnotationsForUser(oldObj.GetAnnotations(), newObj.GetAnnotations(), false, "", "")
+					merged := computeAnnotationsForUser(h.keys, oldObj.GetAnnotations(), newObj.GetAnnotations(), false, "", "")
 					newObj.SetAnnotations(merged)
 					if modified, err := json.Marshal(newObj.Object); err == nil {
 						log.V(1).Info("no spec change, preserving annotations")
@@ -139,26 +382,52 @@ func (h *Handler) Handle(ctx context.Context, req admission.Request) admission.R
 
 	// Get existing updaters from OldObject (for UPDATE) or empty (for CREATE)
 	var childUpdaters []string
+	var oldObj *unstructured.Unstructured
 	if req.Operation == admissionv1.Update && len(req.OldObject.Raw) > 0 {
-		oldObj := &unstructured.Unstructured{}
-		if err := runtime.DecodeInto(unstructured.UnstructuredJSONScheme, req.OldObject.Raw, oldObj); err == nil {
-			childUpdaters = drift.ParseUpdaterHashes(oldObj)
+		decoded := &unstructured.Unstructured{}
+		if err := runtime.DecodeInto(unstructured.UnstructuredJSONScheme, req.OldObject.Raw, decoded); err == nil {
+			oldObj = decoded
+			childUpdaters = drift.ParseUpdaterHashesWithKeys(oldObj, h.keys)
 		}
 	}
 
 	// Get user identifier (username if available, UID as fallback)
 	userID := controller.UserIdentifier(req.UserInfo.Username, req.UserInfo.UID)
+	if !hasUserInfo(req) {
+		userID = anonymousUserID
+	}
 
 	// Add user hash for logging
-	userHash := controller.HashUsername(userID)
+	userHash := h.hash(userID)
 	log = log.WithValues("userHash", userHash)
 
 	// Detect drift using user hash tracking
-	driftResult, err := h.detector.Detect(ctx, obj, userID, childUpdaters)
+	driftResult, reads, err := h.detect(ctx, cfg, obj, userID, childUpdaters)
 	if err != nil {
 		log.Error(err, "drift detection failed")
 		return admission.Errored(http.StatusInternalServerError, fmt.Errorf("drift detection failed: %w", err))
 	}
+	if !hasUserInfo(req) {
+		h.classifyAnonymous(cfg, driftResult)
+	}
+
+	// A slow parent kind ran out of time: fail open rather than hold up admission
+	if reads.parentTimedOut {
+		log.Info("parent fetch timed out, admitting without drift evaluation")
+		return withWarnings(admission.Allowed("parent fetch timed out"), []string{"[kausality] parent fetch timed out; drift not evaluated"})
+	}
+
+	// A synthetic drift takes the drift path regardless of who sent it
+	if synthetic && driftResult.ParentRef != nil {
+		driftResult.DriftDetected = true
+	}
+
+	// Refine the classification by field ownership, cleared fields, baselines and recreates
+	if !synthetic {
+		h.refineDrift(cfg, req, obj, oldObj, userID, driftResult, log)
+	}
+
+	audit.setDrift(driftResult)
 
 	// Log drift detection result
 	logFields := []interface{}{
@@ -172,23 +441,68 @@ func (h *Handler) Handle(ctx context.Context, req admission.Request) admission.R
 		)
 	}
 
-	// Check for freeze annotation on parent - blocks ALL mutations, not just drift
-	// Exception: freeze does NOT block during deletion (controllers must clean up children)
-	if driftResult.ParentRef != nil && driftResult.LifecyclePhase != drift.PhaseDeleting {
-		if frozen, freeze := h.checkFreeze(ctx, driftResult.ParentRef, obj.GetNamespace(), log); frozen {
+	// Track warnings to add to the response
+	var warnings []string
+
+	// Diagnose spec changes by other mutators in the admission chain
+	var chainMsg string
+	if req.Operation != admissionv1.Delete && !synthetic && cfg != nil && cfg.DriftDetection.DetectChainMutations {
+		var oldFields []metav1.ManagedFieldsEntry
+		if oldObj != nil {
+			oldFields = oldObj.GetManagedFields()
+		}
+		if mutations := drift.ChainMutations(oldFields, obj.GetManagedFields(), extractFieldManager(req)); len(mutations) > 0 {
+			chainMsg = "object modified by webhook " + drift.DescribeChainMutations(mutations) + " in the chain"
+			logFields = append(logFields, "chainMutations", drift.DescribeChainMutations(mutations))
+			log.Info("object modified by another mutator in the admission chain", logFields...)
+			warnings = append(warnings, "[kausality] "+chainMsg)
+		}
+	}
+
+	if synthetic {
+		if driftResult.ParentRef != nil {
+			log.Info("SYNTHETIC DRIFT injected", logFields...)
+			warnings = append(warnings, "[kausality] synthetic drift injected (dry-run, nothing persisted)")
+		} else {
+			warnings = append(warnings, "[kausality] synthetic drift ignored: object has no controller owner")
+		}
+	}
+
+	// Check for a break-glass token - a valid token overrides freeze and enforcement
+	var breakGlassAudit *breakglass.Audit
+	if driftResult.ParentRef != nil {
+		audit, err := h.checkBreakGlass(obj, req.UserInfo.Username)
+		if err != nil {
+			log.Info("break-glass token rejected", "error", err.Error())
+			warnings = append(warnings, fmt.Sprintf("[kausality] break-glass token rejected: %v", err))
+		} else if audit != nil {
+			breakGlassAudit = audit
+			log.Info("BREAK-GLASS USED - freeze and enforcement bypassed",
+				append(logFields, "tokenID", audit.TokenID, "tokenUser", audit.User, "tokenReason", audit.Reason, "tokenIssuedAt", audit.IssuedAt.UTC())...)
+			h.sendBreakGlassCallback(ctx, cfg, req, obj, driftResult, log)
+		}
+	}
+
+	// Check for freeze annotation on parent - blocks ALL mutations including child DELETE, not just drift
+	// Exception: freeze does NOT block during the parent's deletion (controllers must clean up children)
+	if driftResult.ParentRef != nil && driftResult.LifecyclePhase != drift.PhaseDeleting && breakGlassAudit == nil {
+		if frozen, freeze := h.checkFreeze(ctx, reads, driftResult.ParentRef, obj.GetNamespace(), log); frozen && h.freezeAllowsConvergence(cfg, driftResult, userID, childUpdaters) {
+			log.Info("parent frozen, allowing controller to converge reconciling parent", logFields...)
+		} else if frozen {
 			freezeMsg := fmt.Sprintf("mutation blocked: parent %s", freeze.String())
 			log.Info("MUTATION FROZEN", append(logFields, "freezeUser", freeze.User, "freezeMessage", freeze.Message)...)
+			metrics.FreezeBlocked.WithLabelValues(driftMetricLabels(obj, "", driftResult.LifecyclePhase)...).Inc()
 			return admission.Denied(freezeMsg)
 		}
 	}
 
 	// Record parent's phase async if transitioning to initialized
 	// Lazy fetch: only fetch parent if phase would actually change
-	if driftResult.ParentRef != nil && driftResult.ParentState != nil && driftResult.LifecyclePhase == drift.PhaseInitialized {
+	if !dryRun && driftResult.ParentRef != nil && driftResult.ParentState != nil && driftResult.LifecyclePhase == drift.PhaseInitialized {
 		currentPhase := driftResult.ParentState.PhaseFromAnnotation
 		if currentPhase != controller.PhaseValueInitialized {
 			// Parent is now initialized but annotation doesn't reflect it - record async
-			parent, err := h.fetchParent(ctx, driftResult.ParentRef, obj.GetNamespace())
+			parent, err := h.parent(ctx, reads, driftResult.ParentRef, obj.GetNamespace())
 			if err != nil {
 				log.V(1).Info("failed to fetch parent for phase recording", "error", err)
 			} else if parent != nil {
@@ -197,9 +511,6 @@ func (h *Handler) Handle(ctx context.Context, req admission.Request) admission.R
 		}
 	}
 
-	// Track warnings to add to the response
-	var warnings []string
-
 	// Build resource context for mode matching
 	gvk := obj.GetObjectKind().GroupVersionKind()
 	resourceCtx := config.ResourceContext{
@@ -211,9 +522,11 @@ func (h *Handler) Handle(ctx context.Context, req admission.Request) admission.R
 	// Fetch namespace metadata if needed for selector matching and annotation resolution
 	var nsAnnotations map[string]string
 	if obj.GetNamespace() != "" {
-		nsLabels, nsAnns, err := h.getNamespaceMetadata(ctx, obj.GetNamespace())
+		nsLabels, nsAnns, err := h.namespaceMetadata(ctx, reads, obj.GetNamespace())
 		if err != nil {
-			log.V(1).Info("failed to get namespace metadata", "error", err)
+			if resp := h.namespaceMetadataUnavailable(cfg, obj.GetNamespace(), err, log); resp != nil {
+				return *resp
+			}
 			// Continue without namespace metadata - selectors won't match
 		} else {
 			resourceCtx.NamespaceLabels = nsLabels
@@ -230,49 +543,105 @@ func (h *Handler) Handle(ctx context.Context, req admission.Request) admission.R
 	if nsAnnotations == nil {
 		nsAnnotations = map[string]string{}
 	}
-	driftMode := h.resolveMode(gvk, obj.GetNamespace(), resourceCtx.NamespaceLabels, obj.GetLabels(), objAnnotations, nsAnnotations)
-	enforceMode := driftMode == string(kausalityv1alpha1.ModeEnforce)
+	driftMode := h.resolveMode(cfg, gvk, obj.GetNamespace(), resourceCtx.NamespaceLabels, obj.GetLabels(), objAnnotations, nsAnnotations)
+	if driftMode == string(kausalityv1alpha1.ModeEnforce) && driftResult.DriftDetected && h.health.Degraded(ctx) {
+		// Cluster is unhealthy: strict enforcement could make the incident worse
+		driftMode = string(kausalityv1alpha1.ModeLog)
+		logFields = append(logFields, "healthDowngraded", true)
+		warnings = append(warnings, "[kausality] enforcement downgraded to log: cluster health signal reports unhealthy")
+	}
 
+	audit.setMode(driftMode)
+
+	// Count drift and, once the response is final, whether it was admitted
 	if driftResult.DriftDetected {
+		labels := driftMetricLabels(obj, driftMode, driftResult.LifecyclePhase)
+		metrics.DriftDetected.WithLabelValues(labels...).Inc()
+		defer func() {
+			if response.Allowed {
+				metrics.DriftAllowed.WithLabelValues(labels...).Inc()
+			} else {
+				metrics.DriftDenied.WithLabelValues(labels...).Inc()
+			}
+		}()
+	}
+
+	if driftResult.DriftDetected && breakGlassAudit != nil {
+		log.Info("DRIFT ALLOWED by break-glass token", logFields...)
+	} else if driftResult.DriftDetected {
 		// Check for approvals when drift is detected
-		approvalResult := h.checkApprovals(ctx, driftResult, obj, log)
+		approvalResult := h.checkApprovals(ctx, cfg, reads, req, driftResult, obj, log)
+		audit.setApproval(approvalResult.CheckResult)
+		if len(driftResult.OwnershipConflicts) > 0 {
+			logFields = append(logFields, "ownershipConflicts", drift.DescribeOwnershipConflicts(driftResult.OwnershipConflicts))
+		}
 		logFields = append(logFields,
 			"approved", approvalResult.Approved,
 			"rejected", approvalResult.Rejected,
 			"driftMode", driftMode,
 		)
 
+		decision := h.decideDrift(cfg, req, obj, driftResult, approvalResult.CheckResult, driftMode, synthetic, chainMsg)
 		if approvalResult.Rejected {
-			rejectMsg := fmt.Sprintf("drift rejected: %s", approvalResult.Reason)
 			log.Info("DRIFT REJECTED", append(logFields, "rejectReason", approvalResult.Reason)...)
-			if enforceMode {
-				return admission.Denied(rejectMsg)
+			if !decision.Allowed {
+				h.recordDriftBlocked(req, obj, driftResult, approvalResult.parent, decision.Message)
+				h.observeBlocked(ctx, cfg, req, obj, driftResult, approvalResult.parent, log)
+				h.sendDriftCallback(ctx, cfg, req, obj, driftResult, approvalResult.parent, v1alpha1.DriftReportPhaseRejected, approvalResult.CheckResult, log)
+				return admission.Denied(decision.Message)
 			}
-			// Non-enforce mode: add warning but allow
-			warnings = append(warnings, fmt.Sprintf("[kausality] %s (would be blocked in enforce mode)", rejectMsg))
+			// Non-enforce mode: report the rejected drift, add warning but allow
+			h.sendDriftCallback(ctx, cfg, req, obj, driftResult, approvalResult.parent, v1alpha1.DriftReportPhaseRejected, approvalResult.CheckResult, log)
+			warnings = append(warnings, decision.Warning)
 		} else if approvalResult.Approved {
-			log.Info("DRIFT APPROVED", append(logFields, "approvalReason", approvalResult.Reason)...)
-			// Consume mode=once approvals and prune stale ones
-			h.consumeApproval(ctx, approvalResult, log)
+			approvalFields := append(logFields, "approvalReason", approvalResult.Reason)
+			if a := approvalResult.MatchedApproval; a != nil && a.Note != "" {
+				approvalFields = append(approvalFields, "approvalNote", a.Note)
+			}
+			log.Info("DRIFT APPROVED", approvalFields...)
+			// Consume mode=once approvals and prune stale ones; dry-runs persist nothing
+			if !dryRun {
+				h.consumeApproval(ctx, cfg, req, obj, approvalResult, log)
+			}
 			// Send resolved notification
-			h.sendDriftCallback(ctx, req, obj, driftResult, approvalResult.parent, v1alpha1.DriftReportPhaseResolved, log)
+			h.sendDriftCallback(ctx, cfg, req, obj, driftResult, approvalResult.parent, v1alpha1.DriftReportPhaseResolved, approvalResult.CheckResult, log)
 		} else {
-			driftMsg := "drift detected: no approval found for this mutation"
+			if len(driftResult.ClearedFields) > 0 {
+				logFields = append(logFields, "clearedFields", driftResult.ClearedFields)
+			}
 			log.Info("DRIFT DETECTED - no approval found", logFields...)
 			// Send drift detected notification
-			h.sendDriftCallback(ctx, req, obj, driftResult, approvalResult.parent, v1alpha1.DriftReportPhaseDetected, log)
-			if enforceMode {
-				return admission.Denied(driftMsg)
+			h.sendDriftCallback(ctx, cfg, req, obj, driftResult, approvalResult.parent, v1alpha1.DriftReportPhaseDetected, approvalResult.CheckResult, log)
+			// Learn recurring corrections and propose approvals for operator review
+			if h.proposals != nil && !dryRun {
+				h.proposals.Observe(ctx, req, obj, driftResult.ParentRef, h.changedSpecFields(cfg, req))
+			}
+			if !decision.Allowed {
+				h.recordDriftBlocked(req, obj, driftResult, approvalResult.parent, decision.Message)
+				h.observeBlocked(ctx, cfg, req, obj, driftResult, approvalResult.parent, log)
+				return admission.Denied(decision.Message)
 			}
 			// Non-enforce mode: add warning but allow
-			warnings = append(warnings, fmt.Sprintf("[kausality] %s (would be blocked in enforce mode)", driftMsg))
+			warnings = append(warnings, decision.Warning)
 		}
 	} else {
 		log.V(1).Info("drift check passed", logFields...)
 	}
 
-	// Propagate trace
-	traceResult, err := h.propagator.Propagate(ctx, obj, userID, childUpdaters, string(req.UID))
+	// Remember admitted deletes for RecreateAfterDelete and DetectRecreates
+	if req.Operation == admissionv1.Delete && !dryRun {
+		h.recreates.RecordDelete(driftResult, userID, obj)
+	}
+
+	// Propagate trace; writes of trusted users always start a new one
+	var traceResult *trace.PropagationResult
+	if !synthetic && h.trustedUser(cfg, req) {
+		traceResult, err = h.propagator.Origin(obj, userID, string(req.UID)), nil
+	} else if driftResult.ParentState != nil {
+		traceResult, err = h.propagator.PropagateWithParent(ctx, obj, driftResult.ParentState, userID, childUpdaters, string(req.UID))
+	} else {
+		traceResult, err = h.propagator.Propagate(ctx, obj, userID, childUpdaters, string(req.UID))
+	}
 	if err != nil {
 		log.Error(err, "trace propagation failed")
 		// Don't fail the request on trace errors - just log and continue
@@ -286,6 +655,8 @@ func (h *Handler) Handle(ctx context.Context, req admission.Request) admission.R
 		log.V(1).Info("trace: extended", "traceLen", len(traceResult.Trace), "parentTraceLen", len(traceResult.ParentTrace))
 	}
 
+	h.exportLineage(req, obj, traceResult)
+
 	// For DELETE, we can't patch (no new object), just allow after logging
 	if req.Operation == admissionv1.Delete {
 		log.V(1).Info("delete operation traced", "trace", traceResult.Trace.String())
@@ -301,57 +672,60 @@ func (h *Handler) Handle(ctx context.Context, req admission.Request) admission.R
 
 	// On CREATE, wipe ALL kausality annotations copied from parent (e.g., deployment controller
 	// copies Deployment annotations to ReplicaSet). We set fresh values based on our computation.
+	// Configured StripOnCreate annotations are removed from the object as well.
+	var remove []string
 	if req.Operation == admissionv1.Create {
 		for key := range annotations {
-			if strings.HasPrefix(key, "kausality.io/") {
+			if isKausalityAnnotation(h.keys, key) {
+				delete(annotations, key)
+			} else if cfg != nil && cfg.ShouldStripOnCreate(key) {
 				delete(annotations, key)
+				remove = append(remove, key)
 			}
 		}
+		sort.Strings(remove)
 	}
 
 	newTrace := traceResult.Trace.String()
-	newUpdaters := addHash(annotations[controller.UpdatersAnnotation], userHash)
+	newUpdaters := annotations[h.keys.Updaters]
+	if hasUserInfo(req) {
+		newUpdaters = controller.AddEntry(newUpdaters, userHash)
+	}
 
-	// Build patches - need to handle case where annotations don't exist
-	var patches []jsonpatch.JsonPatchOperation
+	set := map[string]string{
+		h.keys.Trace: newTrace,
+	}
+	if h.traceParent {
+		set[h.keys.TraceParent] = traceResult.Trace.ToTraceParent()
+	}
+	if newUpdaters != "" {
+		set[h.keys.Updaters] = newUpdaters
+	}
+	if breakGlassAudit != nil {
+		// Record the use and drop the token so it cannot be replayed by later writers
+		auditValue, err := breakglass.MarshalAudit(*breakGlassAudit)
+		if err != nil {
+			log.Error(err, "failed to marshal break-glass audit")
+		} else {
+			set[h.keys.BreakGlassAudit] = auditValue
+		}
+		remove = append(remove, h.keys.BreakGlass)
+	}
 
 	// Check if the original object has annotations
 	originalAnnotations, _, _ := unstructured.NestedStringMap(unstrObj.Object, "metadata", "annotations")
-	if len(originalAnnotations) == 0 {
-		// No annotations exist - add the whole annotations object
-		patches = append(patches, jsonpatch.JsonPatchOperation{
-			Operation: "add",
-			Path:      "/metadata/annotations",
-			Value: map[string]string{
-				trace.TraceAnnotation:         newTrace,
-				controller.UpdatersAnnotation: newUpdaters,
-			},
-		})
-	} else {
-		// Annotations exist - use replace for existing keys, add for new ones
-		tracePath := "/metadata/annotations/" + strings.ReplaceAll(trace.TraceAnnotation, "/", "~1")
-		updatersPath := "/metadata/annotations/" + strings.ReplaceAll(controller.UpdatersAnnotation, "/", "~1")
-
-		// Check if keys exist to decide add vs replace
-		traceOp := "add"
-		if _, exists := originalAnnotations[trace.TraceAnnotation]; exists {
-			traceOp = "replace"
-		}
-		updatersOp := "add"
-		if _, exists := originalAnnotations[controller.UpdatersAnnotation]; exists {
-			updatersOp = "replace"
-		}
 
-		patches = append(patches, jsonpatch.JsonPatchOperation{
-			Operation: traceOp,
-			Path:      tracePath,
-			Value:     newTrace,
-		})
-		patches = append(patches, jsonpatch.JsonPatchOperation{
-			Operation: updatersOp,
-			Path:      updatersPath,
-			Value:     newUpdaters,
-		})
+	// Inherit the parent's enforce mode down the ownership tree
+	if mode := h.propagatedMode(ctx, cfg, reads, driftResult.ParentRef, originalAnnotations, obj.GetNamespace(), resourceCtx.NamespaceLabels, nsAnnotations, log); mode != "" {
+		log.V(1).Info("propagating parent mode to child", "mode", mode)
+		set[h.keys.Mode] = mode
+	}
+
+	patches := annotationPatches(originalAnnotations, set, remove)
+	if h.traceCondition {
+		if patch := traceConditionPatch(unstrObj, traceResult, time.Now()); patch != nil {
+			patches = append(patches, *patch)
+		}
 	}
 
 	// Build response manually to ensure patch is serialized correctly
@@ -369,10 +743,14 @@ func (h *Handler) Handle(ctx context.Context, req admission.Request) admission.R
 
 // handleStatusUpdate handles status subresource updates to record controller identity.
 // It also protects our annotations from being overwritten by stale controller caches.
-func (h *Handler) handleStatusUpdate(ctx context.Context, req admission.Request, log logr.Logger) admission.Response {
+func (h *Handler) handleStatusUpdate(ctx context.Context, cfg *config.Config, req admission.Request, log logr.Logger) admission.Response {
 	if req.Operation != admissionv1.Update {
 		return admission.Allowed("status subresource: only UPDATE is relevant")
 	}
+	// Kausality never acts as a controller
+	if h.isOwnWrite(req) {
+		return admission.Allowed("kausality's own write")
+	}
 
 	// Parse the object for controller tracking
 	obj, err := h.parseObject(req)
@@ -383,103 +761,148 @@ func (h *Handler) handleStatusUpdate(ctx context.Context, req admission.Request,
 
 	// Get user identifier (username if available, UID as fallback)
 	userID := controller.UserIdentifier(req.UserInfo.Username, req.UserInfo.UID)
-	userHash := controller.HashUsername(userID)
+	if !hasUserInfo(req) {
+		userID = anonymousUserID
+	}
+	userHash := h.hash(userID)
 	log.V(1).Info("status update", "userHash", userHash)
 
+	var oldObj, newObj unstructured.Unstructured
+	oldErr := json.Unmarshal(req.OldObject.Raw, &oldObj)
+	newErr := json.Unmarshal(req.Object.Raw, &newObj)
+
+	// With multiple status writers, only the observedGeneration owner may count as controller
+	recordController := hasUserInfo(req)
+	if recordController && oldErr == nil && newErr == nil && !h.recordsStatusWriter(cfg, req, &oldObj, &newObj) {
+		log.V(1).Info("status writer does not own observedGeneration, not recording as controller")
+		recordController = false
+	}
+
 	// Record controller asynchronously as backup (in case sync patch fails)
-	h.controllerTracker.RecordControllerAsync(ctx, obj, userID)
+	if recordController && !isDryRun(req) {
+		h.controllerTracker.RecordControllerAsync(ctx, obj, userID)
+	}
 
 	// Record phase async (status update may have changed conditions)
-	parentState := extractParentStateFromObject(obj)
+	parentState := extractParentStateFromObject(h.keys, obj)
 	phase := h.lifecycleDetector.DetectPhase(parentState)
-	if phase != drift.PhaseDeleting {
+	if phase != drift.PhaseDeleting && phase != drift.PhasePaused && !isDryRun(req) {
 		h.controllerTracker.RecordPhaseAsync(ctx, obj, string(phase))
 	}
 
 	// Compute annotations: preserve kausality annotations and add user to controllers
-	var oldObj, newObj unstructured.Unstructured
-	if err := json.Unmarshal(req.OldObject.Raw, &oldObj); err == nil {
-		if err := json.Unmarshal(req.Object.Raw, &newObj); err == nil {
-			merged := computeAnnotationsForStatusUpdate(oldObj.GetAnnotations(), newObj.GetAnnotations(), userHash)
-			newObj.SetAnnotations(merged)
-			if modified, err := json.Marshal(newObj.Object); err == nil {
-				log.V(1).Info("status update, added controller hash and preserved annotations")
-				return admission.PatchResponseFromRaw(req.Object.Raw, modified)
-			}
+	if oldErr == nil && newErr == nil {
+		controllerHash := userHash
+		if !recordController {
+			controllerHash = ""
+		}
+		serviceAccount, _ := controller.ServiceAccount(userID)
+		if !recordController {
+			serviceAccount = ""
+		}
+		merged := computeAnnotationsForStatusUpdate(h.keys, oldObj.GetAnnotations(), newObj.GetAnnotations(), controllerHash, serviceAccount)
+		newObj.SetAnnotations(merged)
+		if modified, err := json.Marshal(newObj.Object); err == nil {
+			log.V(1).Info("status update, added controller hash and preserved annotations")
+			return admission.PatchResponseFromRaw(req.Object.Raw, modified)
 		}
 	}
 
 	return admission.Allowed("status update recorded")
 }
 
-// withWarnings adds warnings to an admission response.
-func withWarnings(resp admission.Response, warnings []string) admission.Response {
-	if len(warnings) > 0 {
-		resp.Warnings = append(resp.Warnings, warnings...)
+// annotationPatches builds JSON patch operations that set and remove annotations
+// relative to the annotations present on the original object.
+func annotationPatches(original, set map[string]string, remove []string) []jsonpatch.JsonPatchOperation {
+	if len(original) == 0 {
+		// No annotations exist - add the whole annotations object
+		return []jsonpatch.JsonPatchOperation{{
+			Operation: "add",
+			Path:      "/metadata/annotations",
+			Value:     set,
+		}}
 	}
-	return resp
-}
 
-// addHash adds a hash to a comma-separated string if not already present.
-func addHash(existing, hash string) string {
-	hashes := controller.ParseHashes(existing)
-	for _, h := range hashes {
-		if h == hash {
-			return existing
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	// Annotations exist - use replace for existing keys, add for new ones
+	patches := make([]jsonpatch.JsonPatchOperation, 0, len(set)+len(remove))
+	for _, key := range keys {
+		op := "add"
+		if _, exists := original[key]; exists {
+			op = "replace"
 		}
+		patches = append(patches, jsonpatch.JsonPatchOperation{
+			Operation: op,
+			Path:      annotationPath(key),
+			Value:     set[key],
+		})
 	}
-	hashes = append(hashes, hash)
-	if len(hashes) > controller.MaxHashes {
-		hashes = hashes[len(hashes)-controller.MaxHashes:]
+	for _, key := range remove {
+		if _, exists := original[key]; exists {
+			patches = append(patches, jsonpatch.JsonPatchOperation{
+				Operation: "remove",
+				Path:      annotationPath(key),
+			})
+		}
 	}
-	return strings.Join(hashes, ",")
+	return patches
 }
 
-// kausalityPrefix is the prefix for all kausality annotations.
-const kausalityPrefix = "kausality.io/"
+// annotationPath returns the JSON pointer for an annotation key.
+func annotationPath(key string) string {
+	key = strings.ReplaceAll(key, "~", "~0")
+	return "/metadata/annotations/" + strings.ReplaceAll(key, "/", "~1")
+}
 
-// systemAnnotations are annotations with special handling (recomputed on spec change).
-var systemAnnotations = map[string]bool{
-	trace.TraceAnnotation:            true,
-	controller.UpdatersAnnotation:    true,
-	controller.ControllersAnnotation: true,
+// withWarnings adds warnings to an admission response.
+func withWarnings(resp admission.Response, warnings []string) admission.Response {
+	if len(warnings) > 0 {
+		resp.Warnings = append(resp.Warnings, warnings...)
+	}
+	return resp
 }
 
-// isSystemAnnotation returns true for annotations that get special handling.
-func isSystemAnnotation(key string) bool {
-	return systemAnnotations[key]
+// isSystemAnnotation returns true for annotations that get special handling
+// (recomputed on spec change).
+func isSystemAnnotation(keys kausalityv1alpha1.AnnotationKeys, key string) bool {
+	return key == keys.Trace || key == keys.Updaters || key == keys.Controllers
 }
 
-// isKausalityAnnotation returns true for any kausality.io/* annotation.
-func isKausalityAnnotation(key string) bool {
-	return strings.HasPrefix(key, kausalityPrefix)
+// isKausalityAnnotation returns true for any annotation under the kausality prefix.
+func isKausalityAnnotation(keys kausalityv1alpha1.AnnotationKeys, key string) bool {
+	return strings.HasPrefix(key, keys.Prefix)
 }
 
 // computeAnnotationsForController computes annotations for controller updates.
 // - No spec change: preserve ALL kausality annotations from old
 // - Spec change: set system annotations to computed values, preserve user annotations from old
-func computeAnnotationsForController(old, new map[string]string, specChanged bool, newTrace, newUpdaters string) map[string]string {
+func computeAnnotationsForController(keys kausalityv1alpha1.AnnotationKeys, old, new map[string]string, specChanged bool, newTrace, newUpdaters string) map[string]string {
 	result := copyAnnotations(new)
 
 	if specChanged {
 		// Set system annotations to computed values
-		result[trace.TraceAnnotation] = newTrace
-		result[controller.UpdatersAnnotation] = newUpdaters
+		result[keys.Trace] = newTrace
+		result[keys.Updaters] = newUpdaters
 		// Preserve controllers annotation from old (not recomputed on child spec updates).
 		// A child can also be a parent (e.g., ReplicaSet is parent to Pods).
-		if oldControllers, ok := old[controller.ControllersAnnotation]; ok {
-			result[controller.ControllersAnnotation] = oldControllers
+		if oldControllers, ok := old[keys.Controllers]; ok {
+			result[keys.Controllers] = oldControllers
 		}
 		// Preserve user annotations from old
 		for key, oldVal := range old {
-			if isKausalityAnnotation(key) && !isSystemAnnotation(key) {
+			if isKausalityAnnotation(keys, key) && !isSystemAnnotation(keys, key) {
 				result[key] = oldVal
 			}
 		}
 	} else {
 		// No spec change: preserve ALL kausality annotations from old
 		for key, oldVal := range old {
-			if isKausalityAnnotation(key) {
+			if isKausalityAnnotation(keys, key) {
 				result[key] = oldVal
 			}
 		}
@@ -490,17 +913,17 @@ func computeAnnotationsForController(old, new map[string]string, specChanged boo
 // computeAnnotationsForUser computes annotations for user updates.
 // - No spec change: preserve ALL kausality annotations from old
 // - Spec change: set system annotations to computed values (new origin, no preservation)
-func computeAnnotationsForUser(old, new map[string]string, specChanged bool, newTrace, newUpdaters string) map[string]string {
+func computeAnnotationsForUser(keys kausalityv1alpha1.AnnotationKeys, old, new map[string]string, specChanged bool, newTrace, newUpdaters string) map[string]string {
 	result := copyAnnotations(new)
 
 	if specChanged {
 		// New origin: set system annotations, no preservation from old
-		result[trace.TraceAnnotation] = newTrace
-		result[controller.UpdatersAnnotation] = newUpdaters
+		result[keys.Trace] = newTrace
+		result[keys.Updaters] = newUpdaters
 	} else {
 		// No spec change: preserve ALL kausality annotations from old
 		for key, oldVal := range old {
-			if isKausalityAnnotation(key) {
+			if isKausalityAnnotation(keys, key) {
 				result[key] = oldVal
 			}
 		}
@@ -509,18 +932,26 @@ func computeAnnotationsForUser(old, new map[string]string, specChanged bool, new
 }
 
 // computeAnnotationsForStatusUpdate computes annotations for status subresource updates.
-// Preserves all kausality annotations and adds the user hash to the controllers annotation.
-func computeAnnotationsForStatusUpdate(old, new map[string]string, userHash string) map[string]string {
+// Preserves all kausality annotations and adds the user hash to the controllers annotation
+// and the user's service account, if not empty, to the controllers-sa annotation.
+// An empty userHash only preserves annotations.
+func computeAnnotationsForStatusUpdate(keys kausalityv1alpha1.AnnotationKeys, old, new map[string]string, userHash, serviceAccount string) map[string]string {
 	result := copyAnnotations(new)
 	// Preserve all kausality annotations from old
 	for key, oldVal := range old {
-		if isKausalityAnnotation(key) {
+		if isKausalityAnnotation(keys, key) {
 			result[key] = oldVal
 		}
 	}
 	// Add user to controllers annotation (status updater = controller)
-	oldControllers := result[controller.ControllersAnnotation]
-	result[controller.ControllersAnnotation] = addHash(oldControllers, userHash)
+	if userHash == "" {
+		return result
+	}
+	oldControllers := result[keys.Controllers]
+	result[keys.Controllers] = controller.AddEntry(oldControllers, userHash)
+	if serviceAccount != "" {
+		result[keys.ControllerServiceAccounts] = controller.AddEntry(result[keys.ControllerServiceAccounts], serviceAccount)
+	}
 	return result
 }
 
@@ -562,6 +993,26 @@ func (h *Handler) parseObject(req admission.Request) (client.Object, error) {
 	return obj, nil
 }
 
+// checkGVKMatch returns an error if an UPDATE's old and new objects differ in apiVersion or kind.
+func checkGVKMatch(req admission.Request) error {
+	if req.Operation != admissionv1.Update || len(req.OldObject.Raw) == 0 || len(req.Object.Raw) == 0 {
+		return nil
+	}
+
+	var oldMeta, newMeta metav1.TypeMeta
+	if err := json.Unmarshal(req.OldObject.Raw, &oldMeta); err != nil {
+		return fmt.Errorf("failed to decode old object: %w", err)
+	}
+	if err := json.Unmarshal(req.Object.Raw, &newMeta); err != nil {
+		return fmt.Errorf("failed to decode new object: %w", err)
+	}
+	if oldMeta.GroupVersionKind() != newMeta.GroupVersionKind() {
+		return fmt.Errorf("old object %s %s does not match new object %s %s",
+			oldMeta.APIVersion, oldMeta.Kind, newMeta.APIVersion, newMeta.Kind)
+	}
+	return nil
+}
+
 // InjectDecoder injects the decoder.
 func (h *Handler) InjectDecoder(d admission.Decoder) error {
 	h.decoder = d
@@ -569,7 +1020,7 @@ func (h *Handler) InjectDecoder(d admission.Decoder) error {
 }
 
 // hasSpecChanged checks if the spec field changed between old and new object.
-func (h *Handler) hasSpecChanged(req admission.Request) (bool, error) {
+func (h *Handler) hasSpecChanged(cfg *config.Config, req admission.Request) (bool, error) {
 	if len(req.OldObject.Raw) == 0 || len(req.Object.Raw) == 0 {
 		return true, nil // can't compare, assume changed
 	}
@@ -584,32 +1035,45 @@ func (h *Handler) hasSpecChanged(req admission.Request) (bool, error) {
 		return false, fmt.Errorf("failed to decode new object: %w", err)
 	}
 
-	oldSpec, _, _ := unstructured.NestedFieldCopy(oldObj.Object, "spec")
-	newSpec, _, _ := unstructured.NestedFieldCopy(newObj.Object, "spec")
-
-	return !equalSpec(oldSpec, newSpec), nil
+	// Keyed arrays are compared as sets: reordering is not a spec change
+	gvk := newObj.GroupVersionKind()
+	return !drift.EqualSpec(h.extractSpec(cfg, oldObj, gvk), h.extractSpec(cfg, newObj, gvk)), nil
 }
 
-// equalSpec compares two spec values for equality.
-func equalSpec(a, b interface{}) bool {
-	if a == nil && b == nil {
-		return true
-	}
-	if a == nil || b == nil {
+// takesSpecOwnership returns true if the request's field manager took spec fields over
+// from other field managers. Server-side applies and other patches arrive as UPDATE with
+// the merged object, so only the managedFields show such a takeover.
+func (h *Handler) takesSpecOwnership(req admission.Request) bool {
+	manager := extractFieldManager(req)
+	if manager == "" || len(req.OldObject.Raw) == 0 || len(req.Object.Raw) == 0 {
 		return false
 	}
-
-	// Use JSON encoding for deep comparison
-	aJSON, err := runtime.Encode(unstructured.UnstructuredJSONScheme, &unstructured.Unstructured{Object: map[string]interface{}{"spec": a}})
-	if err != nil {
+	var oldObj, newObj unstructured.Unstructured
+	if err := json.Unmarshal(req.OldObject.Raw, &oldObj); err != nil {
 		return false
 	}
-	bJSON, err := runtime.Encode(unstructured.UnstructuredJSONScheme, &unstructured.Unstructured{Object: map[string]interface{}{"spec": b}})
-	if err != nil {
+	if err := json.Unmarshal(req.Object.Raw, &newObj); err != nil {
 		return false
 	}
+	return len(drift.OwnershipConflicts(oldObj.GetManagedFields(), newObj.GetManagedFields(), manager)) > 0
+}
+
+// arrayMergeKeys returns the configured array merge keys for a GVK.
+func (h *Handler) arrayMergeKeys(cfg *config.Config, gvk schema.GroupVersionKind) []config.ArrayMergeKey {
+	if cfg == nil {
+		return nil
+	}
+	return cfg.ArrayMergeKeysFor(gvk)
+}
 
-	return string(aJSON) == string(bJSON)
+// extractSpec returns the spec of obj as compared for spec changes: keyed arrays are
+// normalized and the configured IgnoredSpecPaths are removed.
+func (h *Handler) extractSpec(cfg *config.Config, obj *unstructured.Unstructured, gvk schema.GroupVersionKind) interface{} {
+	spec := drift.ExtractSpec(obj, h.arrayMergeKeys(cfg, gvk))
+	if cfg == nil {
+		return spec
+	}
+	return drift.RemoveSpecPaths(spec, cfg.DriftDetection.IgnoredSpecPaths)
 }
 
 // approvalCheckResult extends approval.CheckResult with parent info for pruning.
@@ -620,28 +1084,40 @@ type approvalCheckResult struct {
 }
 
 // checkApprovals checks if the drift is approved or rejected.
-func (h *Handler) checkApprovals(ctx context.Context, driftResult *drift.DriftResult, obj client.Object, log logr.Logger) approvalCheckResult {
+func (h *Handler) checkApprovals(ctx context.Context, cfg *config.Config, reads *requestReads, req admission.Request, driftResult *drift.DriftResult, obj client.Object, log logr.Logger) approvalCheckResult {
 	if driftResult.ParentRef == nil {
 		return approvalCheckResult{CheckResult: approval.CheckResult{Reason: "no parent to check approvals on"}}
 	}
 
 	// Fetch parent object to read approval annotations
-	parent, err := h.fetchParent(ctx, driftResult.ParentRef, obj.GetNamespace())
+	parent, err := h.parent(ctx, reads, driftResult.ParentRef, obj.GetNamespace())
 	if err != nil {
 		log.Error(err, "failed to fetch parent for approval check")
 		return approvalCheckResult{CheckResult: approval.CheckResult{Reason: "failed to fetch parent: " + err.Error()}}
 	}
 
-	// Build child reference
-	gvk := obj.GetObjectKind().GroupVersionKind()
-	childRef := approval.ChildRef{
-		APIVersion: gvk.GroupVersion().String(),
-		Kind:       gvk.Kind,
-		Name:       obj.GetName(),
-	}
-
-	// Check approvals on parent
-	result := h.approvalChecker.Check(parent, childRef, parent.GetGeneration())
+	// Check approvals on parent; approvals scoped to fields need the changed fields
+	var refs []config.ParentReference
+	if cfg != nil {
+		refs = cfg.DriftDetection.ParentReferences
+	}
+	result := decide.CheckApprovals(ctx, decide.ApprovalInput{
+		Checker:          h.approvalChecker,
+		Policies:         h.policyResolver,
+		Parent:           parent,
+		Child:            obj,
+		ChangedFields:    h.changedFieldPointers(cfg, req),
+		ParentReferences: refs,
+		Keys:             h.keys,
+		NamespaceLabels: func(ctx context.Context, namespace string) (map[string]string, error) {
+			labels, _, err := h.namespaceMetadata(ctx, reads, namespace)
+			return labels, err
+		},
+		FetchParent: func(ctx context.Context, ref drift.ParentRef, childNamespace string) (client.Object, error) {
+			return h.fetchParent(ctx, &ref, childNamespace)
+		},
+		Log: log,
+	})
 	return approvalCheckResult{
 		CheckResult:      result,
 		parent:           parent,
@@ -649,18 +1125,26 @@ func (h *Handler) checkApprovals(ctx context.Context, driftResult *drift.DriftRe
 	}
 }
 
+// driftMetricLabels returns the labels of the drift decision metrics for a child.
+func driftMetricLabels(obj client.Object, mode string, phase drift.LifecyclePhase) []string {
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	return []string{gvk.Group, gvk.Kind, mode, string(phase)}
+}
+
+// approvalChildRef returns the reference approvals are matched against.
+func approvalChildRef(obj client.Object) approval.ChildRef {
+	return decide.ApprovalChildRef(obj)
+}
+
 // consumeApproval removes a mode=once approval and prunes stale approvals from the parent.
-func (h *Handler) consumeApproval(ctx context.Context, result approvalCheckResult, log logr.Logger) {
+// If the approval history is enabled, the consumed approval is recorded in it.
+func (h *Handler) consumeApproval(ctx context.Context, cfg *config.Config, req admission.Request, obj client.Object, result approvalCheckResult, log logr.Logger) {
 	if result.parent == nil || result.MatchedApproval == nil {
 		return
 	}
 
 	// Only consume mode=once approvals
-	mode := result.MatchedApproval.Mode
-	if mode == "" {
-		mode = approval.ModeOnce
-	}
-	if mode != approval.ModeOnce {
+	if result.MatchedApproval.EffectiveMode() != approval.ModeOnce {
 		return
 	}
 
@@ -669,7 +1153,7 @@ func (h *Handler) consumeApproval(ctx context.Context, result approvalCheckResul
 		return
 	}
 
-	approvalsStr := annotations[approval.ApprovalsAnnotation]
+	approvalsStr := annotations[h.keys.Approvals]
 	if approvalsStr == "" {
 		return
 	}
@@ -695,14 +1179,36 @@ func (h *Handler) consumeApproval(ctx context.Context, result approvalCheckResul
 	}
 
 	if len(pruneResult.Approvals) == 0 {
-		delete(newAnnotations, approval.ApprovalsAnnotation)
+		delete(newAnnotations, h.keys.Approvals)
 	} else {
 		newApprovalsStr, err := approval.MarshalApprovals(pruneResult.Approvals)
 		if err != nil {
 			log.Error(err, "failed to marshal pruned approvals")
 			return
 		}
-		newAnnotations[approval.ApprovalsAnnotation] = newApprovalsStr
+		newAnnotations[h.keys.Approvals] = newApprovalsStr
+	}
+
+	if maxEntries := h.approvalHistoryMaxEntries(cfg); pruneResult.Consumed && maxEntries > 0 {
+		history, err := approval.ParseApprovalHistory(newAnnotations[h.keys.ApprovalHistory])
+		if err != nil {
+			log.V(1).Info("replacing invalid approval history", "error", err.Error())
+		}
+		child := approvalChildRef(obj)
+		historyStr, err := approval.AppendApprovalHistory(history, approval.ApprovalHistoryEntry{
+			APIVersion: child.APIVersion,
+			Kind:       child.Kind,
+			Name:       child.Name,
+			Generation: result.parentGeneration,
+			User:       req.UserInfo.Username,
+			Note:       result.MatchedApproval.Note,
+			At:         metav1.Now().Rfc3339Copy(),
+		}, maxEntries)
+		if err != nil {
+			log.Error(err, "failed to marshal approval history")
+			return
+		}
+		newAnnotations[h.keys.ApprovalHistory] = historyStr
 	}
 
 	// Update the parent object
@@ -717,40 +1223,49 @@ func (h *Handler) consumeApproval(ctx context.Context, result approvalCheckResul
 
 	log.Info("pruned approvals from parent",
 		"removedCount", pruneResult.RemovedCount,
-		"remaining", len(pruneResult.Approvals))
+		"remaining", len(pruneResult.Approvals),
+		"consumedNote", result.MatchedApproval.Note)
 }
 
-// fetchParent fetches the parent object by reference.
-func (h *Handler) fetchParent(ctx context.Context, ref *drift.ParentRef, childNamespace string) (client.Object, error) {
-	gv, err := schema.ParseGroupVersion(ref.APIVersion)
-	if err != nil {
-		return nil, fmt.Errorf("invalid parent API version: %w", err)
+// approvalHistoryMaxEntries returns the number of consumed approvals recorded per parent,
+// or 0 if the approval history is disabled.
+func (h *Handler) approvalHistoryMaxEntries(cfg *config.Config) int {
+	if cfg == nil {
+		return 0
 	}
+	return cfg.ApprovalHistoryMaxEntries()
+}
 
-	parent := &unstructured.Unstructured{}
-	parent.SetGroupVersionKind(gv.WithKind(ref.Kind))
+// rejectionReportSeverity maps rejection severities to drift report severities.
+var rejectionReportSeverity = map[string]v1alpha1.DriftReportSeverity{
+	approval.SeverityInfo:     v1alpha1.DriftReportSeverityInfo,
+	approval.SeverityWarn:     v1alpha1.DriftReportSeverityWarning,
+	approval.SeverityCritical: v1alpha1.DriftReportSeverityCritical,
+}
 
-	key := client.ObjectKey{
-		Namespace: ref.Namespace,
-		Name:      ref.Name,
-	}
-	// If parent namespace is empty but child has namespace, use child's namespace
-	if key.Namespace == "" && childNamespace != "" {
-		key.Namespace = childNamespace
+// approvalNote returns the note of an approval, or "" if there is none.
+func approvalNote(a *approval.Approval) string {
+	if a == nil {
+		return ""
 	}
+	return a.Note
+}
 
-	if err := h.client.Get(ctx, key, parent); err != nil {
+// fetchParent fetches the parent object by reference, see drift.GetParent. Parents of
+// CREATE bursts are served from the parent cache.
+func (h *Handler) fetchParent(ctx context.Context, ref *drift.ParentRef, childNamespace string) (client.Object, error) {
+	parent, err := h.parentCache.fetch(ctx, h.client, *ref, childNamespace)
+	if err != nil {
 		return nil, err
 	}
-
 	return parent, nil
 }
 
 // checkFreeze checks if the parent has a freeze annotation.
 // Freeze blocks ALL mutations, not just drift - it's an emergency lockdown.
 // Returns the parsed Freeze struct with user/message/timestamp info.
-func (h *Handler) checkFreeze(ctx context.Context, ref *drift.ParentRef, childNamespace string, log logr.Logger) (frozen bool, freeze *approval.Freeze) {
-	parent, err := h.fetchParent(ctx, ref, childNamespace)
+func (h *Handler) checkFreeze(ctx context.Context, reads *requestReads, ref *drift.ParentRef, childNamespace string, log logr.Logger) (frozen bool, freeze *approval.Freeze) {
+	parent, err := h.parent(ctx, reads, ref, childNamespace)
 	if err != nil {
 		log.V(1).Info("failed to fetch parent for freeze check", "error", err)
 		return false, nil
@@ -761,7 +1276,7 @@ func (h *Handler) checkFreeze(ctx context.Context, ref *drift.ParentRef, childNa
 		return false, nil
 	}
 
-	freezeValue, ok := annotations[approval.FreezeAnnotation]
+	freezeValue, ok := annotations[h.keys.Freeze]
 	if !ok || freezeValue == "" {
 		return false, nil
 	}
@@ -779,9 +1294,34 @@ func (h *Handler) checkFreeze(ctx context.Context, ref *drift.ParentRef, childNa
 		return true, &approval.Freeze{}
 	}
 
+	// An expired freeze no longer blocks (fail open after expiry)
+	if !freeze.IsActive() {
+		log.V(1).Info("freeze expired", "until", freeze.Until)
+		return false, nil
+	}
+
 	return true, freeze
 }
 
+// freezeAllowsConvergence returns true if lifecycle-aware freeze is enabled and the
+// request is the parent's controller reconciling a parent change (generation != observedGeneration).
+func (h *Handler) freezeAllowsConvergence(cfg *config.Config, driftResult *drift.DriftResult, userID string, childUpdaters []string) bool {
+	if cfg == nil || !cfg.DriftDetection.FreezeLifecycleAware {
+		return false
+	}
+	state := driftResult.ParentState
+	if state == nil || state.Generation == state.ObservedGeneration {
+		return false
+	}
+	isController, canDetermine := drift.IsControllerByHashWithFunc(state, userID, childUpdaters, h.hash)
+	return isController && canDetermine
+}
+
+// isOwnWrite returns true if the request carries kausality's own field manager.
+func (h *Handler) isOwnWrite(req admission.Request) bool {
+	return h.fieldManager != "" && extractFieldManager(req) == h.fieldManager
+}
+
 // extractFieldManager extracts the fieldManager from admission request options.
 func extractFieldManager(req admission.Request) string {
 	if len(req.Options.Raw) == 0 {
@@ -804,24 +1344,98 @@ func extractFieldManager(req admission.Request) string {
 	return ""
 }
 
-// sendDriftCallback sends a drift report to the configured webhook endpoint.
+// checkBreakGlass verifies the break-glass token on the object, if any.
+// Returns nil without error when no token is present or break-glass is not configured.
+func (h *Handler) checkBreakGlass(obj client.Object, requestUser string) (*breakglass.Audit, error) {
+	if h.breakGlass == nil {
+		return nil, nil
+	}
+	token := obj.GetAnnotations()[h.keys.BreakGlass]
+	if token == "" {
+		return nil, nil
+	}
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	claims, err := h.breakGlass.Verify(token, breakglass.Target{
+		Group:     gvk.Group,
+		Kind:      gvk.Kind,
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &breakglass.Audit{
+		TokenID:     claims.ID,
+		User:        claims.User,
+		Reason:      claims.Reason,
+		IssuedAt:    claims.IssuedAt,
+		RequestUser: requestUser,
+		At:          metav1.Now(),
+	}, nil
+}
+
+// sendBreakGlassCallback sends a critical report for a break-glass use.
+// Unlike drift callbacks, it is never suppressed by snooze.
+func (h *Handler) sendBreakGlassCallback(ctx context.Context, cfg *config.Config, req admission.Request, obj client.Object, driftResult *drift.DriftResult, log logr.Logger) {
+	if h.callbackSender == nil || !h.callbackSender.IsEnabled() || isDryRun(req) {
+		return
+	}
+	report := h.buildDriftReport(ctx, cfg, req, obj, driftResult, v1alpha1.DriftReportPhaseBreakGlass)
+	if report == nil {
+		return
+	}
+	report.Spec.Severity = v1alpha1.DriftReportSeverityCritical
+	h.callbackSender.SendAsync(ctx, report)
+	log.V(1).Info("break-glass callback sent", "id", report.Spec.ID)
+}
+
+// sendDriftCallback sends a drift report to the configured webhook endpoint, with the
+// note of the matched approval or the matched rejection of result.
 // If the parent has an active snooze annotation, the callback is suppressed.
-func (h *Handler) sendDriftCallback(ctx context.Context, req admission.Request, obj client.Object, driftResult *drift.DriftResult, parent client.Object, phase v1alpha1.DriftReportPhase, log logr.Logger) {
+// Dry-runs are only reported for synthetic drifts.
+func (h *Handler) sendDriftCallback(ctx context.Context, cfg *config.Config, req admission.Request, obj client.Object, driftResult *drift.DriftResult, parent client.Object, phase v1alpha1.DriftReportPhase, result approval.CheckResult, log logr.Logger) {
 	if h.callbackSender == nil || !h.callbackSender.IsEnabled() {
 		return
 	}
 
+	report := h.buildDriftReport(ctx, cfg, req, obj, driftResult, phase)
+	if report == nil || (report.Spec.Request.DryRun && !report.Spec.Synthetic) {
+		return
+	}
+	report.Spec.ApprovalNote = approvalNote(result.MatchedApproval)
+	if r := result.MatchedRejection; r != nil {
+		report.Spec.Rejection = &v1alpha1.Rejection{Reason: r.Reason, Severity: r.Severity, Remediation: r.Remediation}
+		if severity, ok := rejectionReportSeverity[r.Severity]; ok {
+			report.Spec.Severity = severity
+		}
+	}
+
+	// Record first detection in memory before the snooze check, so aging covers snoozed
+	// periods, and persist it on the parent only once reported. Synthetic drifts are not
+	// recorded, as that would persist state on the parent.
+	persistFirstSeen := false
+	if report.Spec.Synthetic {
+		report.Spec.FirstSeen = &metav1.Time{Time: time.Now()}
+	} else if phase == v1alpha1.DriftReportPhaseResolved {
+		driftID := callback.GenerateDriftID(report.Spec.Parent, report.Spec.Child, h.computeSpecDiff(cfg, req))
+		if at, ok := h.firstSeen.Lookup(parent, driftID); ok {
+			report.Spec.FirstSeen = &metav1.Time{Time: at}
+		}
+	} else {
+		report.Spec.FirstSeen = &metav1.Time{Time: h.firstSeen.Observe(parent, report.Spec.ID)}
+		persistFirstSeen = true
+	}
+
 	// Check for snooze annotation on parent
 	if parent != nil {
-		if snooze := h.isParentSnoozed(parent, log); snooze != nil {
+		if snooze := h.isParentSnoozed(parent, approvalChildRef(obj), log); snooze != nil {
 			log.V(1).Info("drift callback suppressed", "phase", phase, "snooze", snooze.String())
 			return
 		}
 	}
 
-	report := h.buildDriftReport(req, obj, driftResult, phase)
-	if report == nil {
-		return
+	if persistFirstSeen {
+		h.firstSeen.PersistAsync(ctx, parent, report.Spec.ID, report.Spec.FirstSeen.Time)
 	}
 
 	// Send asynchronously to avoid blocking admission
@@ -829,9 +1443,9 @@ func (h *Handler) sendDriftCallback(ctx context.Context, req admission.Request,
 	log.V(1).Info("drift callback sent", "phase", phase, "id", report.Spec.ID)
 }
 
-// isParentSnoozed checks if the parent has an active snooze annotation.
+// isParentSnoozed checks if the parent has an active snooze annotation covering the child.
 // Returns the parsed Snooze struct if active, nil otherwise.
-func (h *Handler) isParentSnoozed(parent client.Object, log logr.Logger) *approval.Snooze {
+func (h *Handler) isParentSnoozed(parent client.Object, child approval.ChildRef, log logr.Logger) *approval.Snooze {
 	if parent == nil {
 		return nil
 	}
@@ -841,7 +1455,7 @@ func (h *Handler) isParentSnoozed(parent client.Object, log logr.Logger) *approv
 		return nil
 	}
 
-	snoozeValue, ok := annotations[approval.SnoozeAnnotation]
+	snoozeValue, ok := annotations[h.keys.Snooze]
 	if !ok || snoozeValue == "" {
 		return nil
 	}
@@ -853,8 +1467,8 @@ func (h *Handler) isParentSnoozed(parent client.Object, log logr.Logger) *approv
 		return nil
 	}
 
-	// Check if snooze is still active
-	if snooze.IsActive() {
+	// Check if snooze is still active and scoped to the child, if at all
+	if snooze.IsActive() && snooze.Covers(child) {
 		log.V(1).Info("parent is snoozed", "snooze", snooze.String())
 		return snooze
 	}
@@ -863,7 +1477,7 @@ func (h *Handler) isParentSnoozed(parent client.Object, log logr.Logger) *approv
 }
 
 // buildDriftReport constructs a DriftReport from the admission context.
-func (h *Handler) buildDriftReport(req admission.Request, obj client.Object, driftResult *drift.DriftResult, phase v1alpha1.DriftReportPhase) *v1alpha1.DriftReport {
+func (h *Handler) buildDriftReport(ctx context.Context, cfg *config.Config, req admission.Request, obj client.Object, driftResult *drift.DriftResult, phase v1alpha1.DriftReportPhase) *v1alpha1.DriftReport {
 	if driftResult.ParentRef == nil {
 		return nil
 	}
@@ -896,64 +1510,87 @@ func (h *Handler) buildDriftReport(req admission.Request, obj client.Object, dri
 
 	// Generate ID based on phase
 	var id string
-	if phase == v1alpha1.DriftReportPhaseDetected {
-		// For detected phase, include spec diff in ID
-		specDiff := computeSpecDiff(req)
-		id = callback.GenerateDriftID(parentRef, childRef, specDiff)
-	} else {
+	synthetic := isSyntheticDrift(h.keys, req)
+	if synthetic {
+		// Each injection is a distinct drift so that delivery is exercised every time
+		id = callback.GenerateDriftID(parentRef, childRef, []byte("synthetic:"+string(req.UID)))
+	} else if phase == v1alpha1.DriftReportPhaseResolved {
 		// For resolved phase, use simpler ID
 		id = callback.GenerateResolutionID(parentRef, childRef)
+	} else {
+		// For detected, rejected and break-glass phases, include spec diff in ID
+		specDiff := h.computeSpecDiff(cfg, req)
+		id = callback.GenerateDriftID(parentRef, childRef, specDiff)
 	}
 
 	// Build request context
+	fieldManager := extractFieldManager(req)
 	reqCtx := v1alpha1.RequestContext{
-		User:         req.UserInfo.Username,
-		Groups:       req.UserInfo.Groups,
-		UID:          string(req.UID),
-		FieldManager: extractFieldManager(req),
-		Operation:    string(req.Operation),
-		DryRun:       req.DryRun != nil && *req.DryRun,
+		User:              req.UserInfo.Username,
+		Groups:            req.UserInfo.Groups,
+		UID:               string(req.UID),
+		FieldManager:      fieldManager,
+		ControllerVersion: h.controllerVersions.Resolve(ctx, fieldManager, req.UserInfo.Username),
+		Operation:         string(req.Operation),
+		DryRun:            isDryRun(req),
 	}
 
 	report := &v1alpha1.DriftReport{
 		Spec: v1alpha1.DriftReportSpec{
-			ID:      id,
-			Phase:   phase,
-			Parent:  parentRef,
-			Child:   childRef,
-			Request: reqCtx,
+			ID:        id,
+			Phase:     phase,
+			Synthetic: synthetic,
+			Parent:    parentRef,
+			Child:     childRef,
+			Request:   reqCtx,
 		},
 	}
+	for _, c := range driftResult.OwnershipConflicts {
+		report.Spec.OwnershipConflicts = append(report.Spec.OwnershipConflicts, v1alpha1.OwnershipConflict{
+			Field:            c.Field,
+			PreviousManagers: c.PreviousManagers,
+		})
+	}
+	// A controller wiping out what a user set deserves attention
+	if len(driftResult.ClearedFields) > 0 {
+		report.Spec.ClearedFields = driftResult.ClearedFields
+		if phase == v1alpha1.DriftReportPhaseDetected || phase == v1alpha1.DriftReportPhaseRejected {
+			report.Spec.Severity = v1alpha1.DriftReportSeverityWarning
+		}
+	}
+
+	report.Spec.Recreated = driftResult.Recreated
+
+	// Mutation phases carry the spec change for review
+	switch phase {
+	case v1alpha1.DriftReportPhaseDetected, v1alpha1.DriftReportPhaseResolved, v1alpha1.DriftReportPhaseBreakGlass, v1alpha1.DriftReportPhaseStuck, v1alpha1.DriftReportPhaseRejected:
+		report.Spec.SpecDiff = h.renderSpecDiff(cfg, req)
+		report.Spec.SpecChanges = h.specFieldChanges(cfg, req)
+	}
 
 	// Include objects in report
-	report.Spec.NewObject = runtime.RawExtension{Raw: req.Object.Raw}
-	if req.Operation == admissionv1.Update && len(req.OldObject.Raw) > 0 {
-		report.Spec.OldObject = &runtime.RawExtension{Raw: req.OldObject.Raw}
+	if cfg == nil || cfg.FullObjectsIncluded() {
+		report.Spec.NewObject = runtime.RawExtension{Raw: req.Object.Raw}
+		if req.Operation == admissionv1.Update && len(req.OldObject.Raw) > 0 {
+			report.Spec.OldObject = &runtime.RawExtension{Raw: req.OldObject.Raw}
+		}
 	}
 
 	return report
 }
 
 // computeSpecDiff computes a hash-able representation of the spec change.
-func computeSpecDiff(req admission.Request) []byte {
+func (h *Handler) computeSpecDiff(cfg *config.Config, req admission.Request) []byte {
 	if req.Operation != admissionv1.Update {
 		return req.Object.Raw
 	}
 
 	// For updates, extract just the spec fields for comparison
-	oldObj := &unstructured.Unstructured{}
-	newObj := &unstructured.Unstructured{}
-
-	if err := runtime.DecodeInto(unstructured.UnstructuredJSONScheme, req.OldObject.Raw, oldObj); err != nil {
-		return req.Object.Raw
-	}
-	if err := runtime.DecodeInto(unstructured.UnstructuredJSONScheme, req.Object.Raw, newObj); err != nil {
+	oldSpec, newSpec, ok := h.specChange(cfg, req)
+	if !ok {
 		return req.Object.Raw
 	}
 
-	oldSpec, _, _ := unstructured.NestedFieldCopy(oldObj.Object, "spec")
-	newSpec, _, _ := unstructured.NestedFieldCopy(newObj.Object, "spec")
-
 	// Create a diff representation
 	diff := map[string]interface{}{
 		"old": oldSpec,
@@ -966,6 +1603,106 @@ func computeSpecDiff(req admission.Request) []byte {
 	return diffBytes
 }
 
+// specChange returns the normalized old and new spec of a request; the old spec is nil
+// for CREATE, the new one for DELETE. Keyed arrays are normalized, so reorderings
+// produce the same specs, and ignored spec paths are removed. It returns false if an
+// object cannot be decoded.
+func (h *Handler) specChange(cfg *config.Config, req admission.Request) (oldSpec, newSpec interface{}, ok bool) {
+	decode := func(raw []byte) (*unstructured.Unstructured, bool) {
+		if len(raw) == 0 {
+			return nil, true
+		}
+		obj := &unstructured.Unstructured{}
+		if err := runtime.DecodeInto(unstructured.UnstructuredJSONScheme, raw, obj); err != nil {
+			return nil, false
+		}
+		return obj, true
+	}
+	oldObj, oldOK := decode(req.OldObject.Raw)
+	newObj, newOK := decode(req.Object.Raw)
+	if !oldOK || !newOK {
+		return nil, nil, false
+	}
+
+	var gvk schema.GroupVersionKind
+	for _, obj := range []*unstructured.Unstructured{oldObj, newObj} {
+		if obj != nil {
+			gvk = obj.GroupVersionKind()
+		}
+	}
+	return h.extractSpec(cfg, oldObj, gvk), h.extractSpec(cfg, newObj, gvk), true
+}
+
+// changedFieldPointers returns the JSON Pointers of the spec fields an UPDATE changes.
+// It returns nil for other operations, whose changes are not confined to fields.
+func (h *Handler) changedFieldPointers(cfg *config.Config, req admission.Request) []string {
+	if req.Operation != admissionv1.Update {
+		return nil
+	}
+	oldSpec, newSpec, ok := h.specChange(cfg, req)
+	if !ok {
+		return nil
+	}
+	pointers := []string{}
+	for _, diff := range drift.DiffSpec(oldSpec, newSpec) {
+		pointers = append(pointers, diff.Pointer)
+	}
+	return pointers
+}
+
+// renderSpecDiff renders the spec change of a request as a unified diff if enabled.
+func (h *Handler) renderSpecDiff(cfg *config.Config, req admission.Request) string {
+	if cfg == nil || cfg.SpecDiff == nil {
+		return ""
+	}
+	oldSpec, newSpec, ok := h.specChange(cfg, req)
+	if !ok {
+		return ""
+	}
+	diff, err := callback.RenderSpecDiff(oldSpec, newSpec, cfg.SpecDiff.MaxBytes)
+	if err != nil {
+		h.log.V(1).Info("failed to render spec diff", "error", err)
+		return ""
+	}
+	return diff
+}
+
+// specFieldChanges returns the spec leaf fields a request changes with their old and
+// new values, or nil if the objects cannot be decoded.
+func (h *Handler) specFieldChanges(cfg *config.Config, req admission.Request) []v1alpha1.FieldChange {
+	oldSpec, newSpec, ok := h.specChange(cfg, req)
+	if !ok {
+		return nil
+	}
+	var changes []v1alpha1.FieldChange
+	for _, diff := range drift.DiffSpec(oldSpec, newSpec) {
+		change := v1alpha1.FieldChange{Path: diff.Path}
+		var err error
+		if change.OldValue, err = rawValue(diff.Old); err != nil {
+			h.log.V(1).Info("failed to encode spec field", "path", diff.Path, "error", err)
+			return nil
+		}
+		if change.NewValue, err = rawValue(diff.New); err != nil {
+			h.log.V(1).Info("failed to encode spec field", "path", diff.Path, "error", err)
+			return nil
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// rawValue encodes a decoded JSON value, nil for an absent one.
+func rawValue(v interface{}) (*runtime.RawExtension, error) {
+	if v == nil {
+		return nil, nil
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return &runtime.RawExtension{Raw: raw}, nil
+}
+
 // getNamespaceMetadata fetches labels and annotations from a namespace.
 func (h *Handler) getNamespaceMetadata(ctx context.Context, namespace string) (labels, annotations map[string]string, err error) {
 	ns := &unstructured.Unstructured{}
@@ -979,50 +1716,19 @@ func (h *Handler) getNamespaceMetadata(ctx context.Context, namespace string) (l
 
 // resolveMode determines the drift detection mode for a resource.
 // Precedence: object annotation > namespace annotation > CRD policy > legacy config.
-func (h *Handler) resolveMode(gvk schema.GroupVersionKind, namespace string, nsLabels, objLabels, objAnnotations, nsAnnotations map[string]string) string {
-	// If policy resolver is available, use it
-	if h.policyResolver != nil {
-		// Convert Kind to resource (lowercase plural)
-		resource := kindToResource(gvk.Kind)
-		policyCtx := policy.ResourceContext{
-			GVR: schema.GroupVersionResource{
-				Group:    gvk.Group,
-				Version:  gvk.Version,
-				Resource: resource,
-			},
-			Namespace:       namespace,
-			NamespaceLabels: nsLabels,
-			ObjectLabels:    objLabels,
-		}
-		mode := h.policyResolver.ResolveMode(policyCtx, objAnnotations, nsAnnotations)
-		return string(mode)
-	}
-
-	// Fallback to legacy config
-	resourceCtx := config.ResourceContext{
-		GVK:             gvk,
-		Namespace:       namespace,
-		NamespaceLabels: nsLabels,
-		ObjectLabels:    objLabels,
-	}
-	return h.config.ResolveModeWithAnnotations(objAnnotations, nsAnnotations, resourceCtx)
+func (h *Handler) resolveMode(cfg *config.Config, gvk schema.GroupVersionKind, namespace string, nsLabels, objLabels, objAnnotations, nsAnnotations map[string]string) string {
+	return decide.ModeResolver{Policies: h.policyResolver, Config: cfg}.Resolve(gvk, namespace, nsLabels, objLabels, objAnnotations, nsAnnotations)
 }
 
-// kindToResource converts a Kind to the conventional resource name.
-func kindToResource(kind string) string {
-	// Simple lowercase + 's' suffix (works for most resources)
-	// Note: This doesn't handle irregular plurals (e.g., "Ingress" -> "ingresses")
-	// but works for common cases like Deployment -> deployments
-	lower := strings.ToLower(kind)
-	if strings.HasSuffix(lower, "s") || strings.HasSuffix(lower, "x") || strings.HasSuffix(lower, "ch") || strings.HasSuffix(lower, "sh") {
-		return lower + "es"
-	}
-	return lower + "s"
+// KindToResource converts a Kind to the conventional resource name, see
+// decide.KindToResource.
+func KindToResource(kind string) string {
+	return decide.KindToResource(kind)
 }
 
 // extractParentStateFromObject extracts drift-relevant state from an object being used as a parent.
 // This is used when processing status updates to determine the parent's lifecycle phase.
-func extractParentStateFromObject(obj client.Object) *drift.ParentState {
+func extractParentStateFromObject(keys kausalityv1alpha1.AnnotationKeys, obj client.Object) *drift.ParentState {
 	state := &drift.ParentState{
 		Generation:        obj.GetGeneration(),
 		DeletionTimestamp: obj.GetDeletionTimestamp(),
@@ -1040,6 +1746,7 @@ func extractParentStateFromObject(obj client.Object) *drift.ParentState {
 			state.ObservedGeneration = obsGen
 			state.HasObservedGeneration = true
 		}
+		state.Status = status
 		state.Conditions = drift.ExtractConditions(status)
 
 		// Fallback: check conditions for observedGeneration (Crossplane style)
@@ -1047,13 +1754,15 @@ func extractParentStateFromObject(obj client.Object) *drift.ParentState {
 			state.ObservedGeneration, state.HasObservedGeneration = drift.ExtractConditionObservedGeneration(status)
 		}
 	}
+	state.Paused, _, _ = unstructured.NestedBool(unstrObj.Object, "spec", "paused")
 
 	// Check phase annotation
 	if annotations := obj.GetAnnotations(); annotations != nil {
-		state.PhaseFromAnnotation = annotations[controller.PhaseAnnotation]
+		state.PhaseFromAnnotation = annotations[keys.Phase]
 		if state.PhaseFromAnnotation == controller.PhaseValueInitialized {
 			state.IsInitialized = true
 		}
+		state.ControllerServiceAccounts = controller.ParseHashes(annotations[keys.ControllerServiceAccounts])
 	}
 
 	return state
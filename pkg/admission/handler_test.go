@@ -1,15 +1,62 @@
 package admission
 
 import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kausalityv1alpha1 "github.com/kausality-io/kausality/api/v1alpha1"
+	"github.com/kausality-io/kausality/pkg/approval"
+	"github.com/kausality-io/kausality/pkg/baseline"
+	"github.com/kausality-io/kausality/pkg/breakglass"
+	"github.com/kausality-io/kausality/pkg/callback"
+	"github.com/kausality-io/kausality/pkg/callback/v1alpha1"
+	"github.com/kausality-io/kausality/pkg/config"
+	"github.com/kausality-io/kausality/pkg/controller"
+	"github.com/kausality-io/kausality/pkg/decide"
+	"github.com/kausality-io/kausality/pkg/drift"
+	"github.com/kausality-io/kausality/pkg/health"
+	"github.com/kausality-io/kausality/pkg/lineage"
+	"github.com/kausality-io/kausality/pkg/metrics"
+	"github.com/kausality-io/kausality/pkg/policy"
+	ktesting "github.com/kausality-io/kausality/pkg/testing"
+	"github.com/kausality-io/kausality/pkg/trace"
 )
 
 func TestHasSpecChanged(t *testing.T) {
@@ -155,13 +202,124 @@ func TestHasSpecChanged(t *testing.T) {
 				},
 			}
 
-			changed, err := h.hasSpecChanged(req)
+			changed, err := h.hasSpecChanged(h.config(), req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantChanged, changed)
+		})
+	}
+}
+
+func TestHasSpecChanged_IgnoredSpecPaths(t *testing.T) {
+	deployment := func(restartedAt string, replicas int64) map[string]interface{} {
+		template := map[string]interface{}{"spec": map[string]interface{}{"containers": []interface{}{
+			map[string]interface{}{"name": "app", "image": "app:1"},
+		}}}
+		if restartedAt != "" {
+			template["metadata"] = map[string]interface{}{"annotations": map[string]interface{}{"kubectl.kubernetes.io/restartedAt": restartedAt}}
+		}
+		return map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata":   map[string]interface{}{"name": "test"},
+			"spec":       map[string]interface{}{"replicas": replicas, "template": template},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		oldObj      map[string]interface{}
+		newObj      map[string]interface{}
+		wantChanged bool
+	}{
+		{
+			name:        "restart annotation added",
+			oldObj:      deployment("", 1),
+			newObj:      deployment("2026-01-25T10:00:00Z", 1),
+			wantChanged: false,
+		},
+		{
+			name:        "restart annotation changed",
+			oldObj:      deployment("2026-01-25T10:00:00Z", 1),
+			newObj:      deployment("2026-01-25T11:00:00Z", 1),
+			wantChanged: false,
+		},
+		{
+			name:        "other spec fields still count",
+			oldObj:      deployment("2026-01-25T10:00:00Z", 1),
+			newObj:      deployment("2026-01-25T11:00:00Z", 2),
+			wantChanged: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldRaw, _ := json.Marshal(tt.oldObj)
+			newRaw, _ := json.Marshal(tt.newObj)
+			req := admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					OldObject: runtime.RawExtension{Raw: oldRaw},
+					Object:    runtime.RawExtension{Raw: newRaw},
+				},
+			}
+
+			// Without ignored paths, every case is a spec change
+			changed, err := (&Handler{}).hasSpecChanged(nil, req)
+			require.NoError(t, err)
+			assert.True(t, changed)
+
+			h := &Handler{configSource: staticConfig(&config.Config{DriftDetection: config.DriftDetectionConfig{
+				IgnoredSpecPaths: []string{"/spec/template/metadata/annotations/kubectl.kubernetes.io~1restartedAt"},
+			}})}
+			changed, err = h.hasSpecChanged(h.config(), req)
 			require.NoError(t, err)
 			assert.Equal(t, tt.wantChanged, changed)
 		})
 	}
 }
 
+func TestHandle_GVKMismatch(t *testing.T) {
+	old := ownedChild("child", nil, map[string]interface{}{"replicas": int64(1)})
+	updated := ownedChild("child", nil, map[string]interface{}{"replicas": int64(2)})
+	updated.SetAPIVersion("apps/v1")
+	updated.SetKind("Deployment")
+
+	tests := []struct {
+		name        string
+		decision    string
+		wantAllowed bool
+	}{
+		{name: "denied by default", wantAllowed: false},
+		{name: "deny", decision: config.GVKMismatchDeny, wantAllowed: false},
+		{name: "allow with warning", decision: config.GVKMismatchAllowWithWarning, wantAllowed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, _ := newFakeHandler(t, Config{DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{
+				DefaultMode:   config.ModeEnforce,
+				OnGVKMismatch: tt.decision,
+			}}}, stableParent(nil))
+
+			resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, old, updated, testController))
+			assert.Equal(t, tt.wantAllowed, resp.Allowed)
+			if tt.wantAllowed {
+				require.Len(t, resp.Warnings, 1)
+				assert.Contains(t, resp.Warnings[0], "does not match new object apps/v1 Deployment")
+				assert.Empty(t, resp.Patches)
+			} else {
+				assert.Contains(t, resp.Result.Message, "old object example.com/v1 Widget does not match new object apps/v1 Deployment")
+			}
+		})
+	}
+
+	t.Run("matching kinds are processed", func(t *testing.T) {
+		h, _ := newFakeHandler(t, Config{DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{DefaultMode: config.ModeLog}}}, stableParent(nil))
+		resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, old, ownedChild("child", nil, map[string]interface{}{"replicas": int64(2)}), testController))
+		assert.True(t, resp.Allowed)
+		assert.NotEmpty(t, resp.Patches)
+	})
+}
+
 func TestComputeAnnotationsForController(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -257,7 +415,7 @@ func TestComputeAnnotationsForController(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := computeAnnotationsForController(tt.old, tt.new, tt.specChanged, tt.newTrace, tt.newUpdaters)
+			got := computeAnnotationsForController(kausalityv1alpha1.DefaultAnnotationKeys, tt.old, tt.new, tt.specChanged, tt.newTrace, tt.newUpdaters)
 			assert.Equal(t, tt.want, got)
 		})
 	}
@@ -331,7 +489,7 @@ func TestComputeAnnotationsForUser(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := computeAnnotationsForUser(tt.old, tt.new, tt.specChanged, tt.newTrace, tt.newUpdaters)
+			got := computeAnnotationsForUser(kausalityv1alpha1.DefaultAnnotationKeys, tt.old, tt.new, tt.specChanged, tt.newTrace, tt.newUpdaters)
 			assert.Equal(t, tt.want, got)
 		})
 	}
@@ -387,12 +545,4040 @@ func TestComputeAnnotationsForStatusUpdate(t *testing.T) {
 			userHash: "ctrl1",
 			want:     map[string]string{"kausality.io/controllers": "ctrl1"},
 		},
+		{
+			name:     "empty hash only preserves",
+			old:      map[string]string{"kausality.io/controllers": "ctrl1"},
+			new:      map[string]string{},
+			userHash: "",
+			want:     map[string]string{"kausality.io/controllers": "ctrl1"},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := computeAnnotationsForStatusUpdate(tt.old, tt.new, tt.userHash)
+			got := computeAnnotationsForStatusUpdate(kausalityv1alpha1.DefaultAnnotationKeys, tt.old, tt.new, tt.userHash, "")
 			assert.Equal(t, tt.want, got)
 		})
 	}
 }
+
+func TestHandle_ObserveBackendHangs(t *testing.T) {
+	release := make(chan struct{})
+	var received atomic.Int32
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received.Add(1)
+		<-release
+	}))
+	defer backend.Close()
+
+	sender, err := callback.NewMultiSender([]callback.SenderConfig{{
+		URL:         backend.URL,
+		Mode:        config.BackendModeObserve,
+		Timeout:     time.Minute,
+		MaxInFlight: 2,
+		QueueSize:   5,
+	}}, logr.Discard())
+	require.NoError(t, err)
+	defer func() {
+		close(release)
+		require.NoError(t, sender.Close(t.Context()))
+	}()
+
+	h, _ := newFakeHandler(t, Config{
+		CallbackSender: sender,
+		DriftConfig:    &config.Config{DriftDetection: config.DriftDetectionConfig{DefaultMode: config.ModeLog}},
+	}, stableParent(nil))
+	updaters := map[string]string{kausalityv1alpha1.UpdatersAnnotation: controller.HashUsername(testController)}
+
+	start := time.Now()
+	for i := range 50 {
+		resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update,
+			ownedChild("child", updaters, map[string]interface{}{"size": int64(0)}),
+			ownedChild("child", updaters, map[string]interface{}{"size": int64(i + 1)}),
+			testController))
+		require.True(t, resp.Allowed, resp.Result)
+	}
+	elapsed := time.Since(start)
+
+	assert.Less(t, elapsed, 5*time.Second, "admission must not wait for the hung observe backend")
+	assert.Eventually(t, func() bool { return received.Load() == 2 }, 5*time.Second, 10*time.Millisecond, "both workers hang on the backend")
+}
+
+const (
+	testNamespace  = "default"
+	testParentName = "parent"
+	testController = "system:serviceaccount:kube-system:deployment-controller"
+)
+
+// testScheme knows the built-in and kausality types.
+var testScheme = func() *runtime.Scheme {
+	s := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(s))
+	utilruntime.Must(kausalityv1alpha1.AddToScheme(s))
+	return s
+}()
+
+// newFakeHandler creates a Handler backed by a fake client seeded with objs.
+func newFakeHandler(t *testing.T, cfg Config, objs ...client.Object) (*Handler, client.Client) {
+	t.Helper()
+	c := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(objs...).Build()
+	cfg.Client = c
+	cfg.Log = logr.Discard()
+	return NewHandler(cfg), c
+}
+
+// stableParent returns an initialized Deployment with generation == observedGeneration.
+func stableParent(annotations map[string]string) *appsv1.Deployment {
+	anns := map[string]string{controller.PhaseAnnotation: controller.PhaseValueInitialized}
+	for k, v := range annotations {
+		anns[k] = v
+	}
+	return &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        testParentName,
+			Namespace:   testNamespace,
+			UID:         "parent-uid",
+			Generation:  1,
+			Annotations: anns,
+		},
+		Status: appsv1.DeploymentStatus{ObservedGeneration: 1},
+	}
+}
+
+// ownedChild returns a child object controlled by the test parent.
+// A nil spec leaves the object without a spec field.
+func ownedChild(name string, annotations map[string]string, spec map[string]interface{}) *unstructured.Unstructured {
+	child := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	child.SetAPIVersion("example.com/v1")
+	child.SetKind("Widget")
+	child.SetName(name)
+	child.SetNamespace(testNamespace)
+	child.SetAnnotations(annotations)
+	child.SetOwnerReferences([]metav1.OwnerReference{{
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Name:       testParentName,
+		UID:        "parent-uid",
+		Controller: ptr.To(true),
+	}})
+	if spec != nil {
+		child.Object["spec"] = spec
+	}
+	return child
+}
+
+// newAdmissionRequest builds an admission request for the given objects.
+// oldObj is nil for CREATE, newObj is nil for DELETE.
+func newAdmissionRequest(t testing.TB, op admissionv1.Operation, oldObj, newObj runtime.Object, username string) admission.Request {
+	t.Helper()
+	req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+		UID:       types.UID("req-" + string(op)),
+		Operation: op,
+		Namespace: testNamespace,
+		UserInfo:  authenticationv1.UserInfo{Username: username},
+	}}
+	obj := newObj
+	if obj == nil {
+		obj = oldObj
+	}
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	req.Kind = metav1.GroupVersionKind{Group: gvk.Group, Version: gvk.Version, Kind: gvk.Kind}
+	req.Name = obj.(client.Object).GetName()
+	if newObj != nil {
+		raw, err := json.Marshal(newObj)
+		require.NoError(t, err)
+		req.Object = runtime.RawExtension{Raw: raw}
+	}
+	if oldObj != nil {
+		raw, err := json.Marshal(oldObj)
+		require.NoError(t, err)
+		req.OldObject = runtime.RawExtension{Raw: raw}
+	}
+	return req
+}
+
+// patchedAnnotations returns the annotation values set by the response patches.
+func patchedAnnotations(resp admission.Response) map[string]string {
+	result := map[string]string{}
+	for _, p := range resp.Patches {
+		switch {
+		case p.Path == "/metadata/annotations":
+			for k, v := range p.Value.(map[string]string) {
+				result[k] = v
+			}
+		case p.Operation == "add" || p.Operation == "replace":
+			if v, ok := p.Value.(string); ok {
+				result[unescapeAnnotationPath(p.Path)] = v
+			}
+		}
+	}
+	return result
+}
+
+// removedAnnotations returns the annotation keys removed by the response patches.
+func removedAnnotations(resp admission.Response) []string {
+	var result []string
+	for _, p := range resp.Patches {
+		if p.Operation == "remove" {
+			result = append(result, unescapeAnnotationPath(p.Path))
+		}
+	}
+	return result
+}
+
+func unescapeAnnotationPath(path string) string {
+	key := path[len("/metadata/annotations/"):]
+	out := make([]byte, 0, len(key))
+	for i := 0; i < len(key); i++ {
+		if key[i] == '~' && i+1 < len(key) {
+			if key[i+1] == '1' {
+				out = append(out, '/')
+			} else {
+				out = append(out, '~')
+			}
+			i++
+			continue
+		}
+		out = append(out, key[i])
+	}
+	return string(out)
+}
+
+func TestHandle_AnnotationPrefix(t *testing.T) {
+	staging := kausalityv1alpha1.NewAnnotationKeys("staging.kausality.io/")
+	approvals := `[{"apiVersion":"example.com/v1","kind":"Widget","name":"child","mode":"always"}]`
+
+	// Both installations track the same objects, but only staging approved the drift.
+	parent := stableParent(map[string]string{
+		staging.Phase:     controller.PhaseValueInitialized,
+		staging.Approvals: approvals,
+	})
+	childAnnotations := map[string]string{
+		kausalityv1alpha1.UpdatersAnnotation: controller.HashUsername(testController),
+		staging.Updaters:                     controller.HashUsername(testController),
+	}
+	req := newAdmissionRequest(t, admissionv1.Update,
+		ownedChild("child", childAnnotations, map[string]interface{}{"size": int64(1)}),
+		ownedChild("child", childAnnotations, map[string]interface{}{"size": int64(2)}),
+		testController)
+
+	tests := []struct {
+		name        string
+		prefix      string
+		wantAllowed bool
+		wantTrace   string
+		otherTrace  string
+	}{
+		{
+			name:        "default prefix ignores the staging approval",
+			wantAllowed: false,
+		},
+		{
+			name:        "staging prefix honours its own approval",
+			prefix:      "staging.kausality.io/",
+			wantAllowed: true,
+			wantTrace:   staging.Trace,
+			otherTrace:  kausalityv1alpha1.TraceAnnotation,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, _ := newFakeHandler(t, Config{
+				DriftConfig: &config.Config{
+					AnnotationPrefix: tt.prefix,
+					DriftDetection:   config.DriftDetectionConfig{DefaultMode: config.ModeEnforce},
+				},
+			}, parent.DeepCopy())
+
+			resp := h.Handle(t.Context(), req)
+			assert.Equal(t, tt.wantAllowed, resp.Allowed, resp.Result)
+			if tt.wantTrace != "" {
+				patched := patchedAnnotations(resp)
+				assert.Contains(t, patched, tt.wantTrace)
+				assert.NotContains(t, patched, tt.otherTrace)
+			}
+		})
+	}
+}
+
+func TestHandle_AnnotationWriters(t *testing.T) {
+	const forged = `[{"apiVersion":"example.com/v1","kind":"Widget","name":"child","mode":"always"}]`
+
+	tests := []struct {
+		name        string
+		writers     []string
+		mode        string
+		manager     string
+		wantAllowed bool
+		wantWarning string
+	}{
+		{name: "no allow-list", mode: config.ModeEnforce, manager: "kubectl-edit", wantAllowed: true},
+		{name: "listed writer", writers: []string{"approval-tool"}, mode: config.ModeEnforce, manager: "approval-tool", wantAllowed: true},
+		{name: "kausality itself", writers: []string{"approval-tool"}, mode: config.ModeEnforce, manager: DefaultFieldManager, wantAllowed: true},
+		{name: "unlisted writer in enforce mode", writers: []string{"approval-tool"}, mode: config.ModeEnforce, manager: "kubectl-edit", wantAllowed: false},
+		{
+			name: "unlisted writer in log mode", writers: []string{"approval-tool"}, mode: config.ModeLog, manager: "kubectl-edit", wantAllowed: true,
+			wantWarning: "[kausality] field manager kubectl-edit may not change kausality annotations kausality.io/approvals (would be blocked in enforce mode)",
+		},
+		{
+			name: "no field manager in log mode", writers: []string{"approval-tool"}, mode: config.ModeLog, wantAllowed: true,
+			wantWarning: "[kausality] field manager <none> may not change kausality annotations kausality.io/approvals (would be blocked in enforce mode)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, _ := newFakeHandler(t, Config{DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{
+				DefaultMode:       config.ModeLog,
+				AnnotationWriters: tt.writers,
+			}}})
+
+			old := stableParent(map[string]string{config.ModeAnnotation: tt.mode})
+			updated := stableParent(map[string]string{config.ModeAnnotation: tt.mode, kausalityv1alpha1.ApprovalsAnnotation: forged})
+			req := newAdmissionRequest(t, admissionv1.Update, old, updated, "alice")
+			if tt.manager != "" {
+				req.Options = runtime.RawExtension{Raw: []byte(`{"fieldManager":"` + tt.manager + `"}`)}
+			}
+
+			resp := h.Handle(t.Context(), req)
+			require.Equal(t, tt.wantAllowed, resp.Allowed, resp.Result)
+			if !tt.wantAllowed {
+				assert.Contains(t, resp.Result.Message, "field manager kubectl-edit may not change kausality annotations kausality.io/approvals")
+			}
+			if tt.wantWarning != "" {
+				assert.Equal(t, []string{tt.wantWarning}, resp.Warnings)
+			} else {
+				assert.Empty(t, resp.Warnings)
+			}
+		})
+	}
+
+	t.Run("lifting enforce in the same request is denied", func(t *testing.T) {
+		h, _ := newFakeHandler(t, Config{DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{
+			DefaultMode:       config.ModeLog,
+			AnnotationWriters: []string{"approval-tool"},
+		}}})
+
+		old := stableParent(map[string]string{config.ModeAnnotation: config.ModeEnforce})
+		updated := stableParent(map[string]string{config.ModeAnnotation: config.ModeLog})
+		resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, old, updated, "alice"))
+		assert.False(t, resp.Allowed)
+	})
+
+	t.Run("other annotations are not governed", func(t *testing.T) {
+		h, _ := newFakeHandler(t, Config{DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{
+			DefaultMode:       config.ModeEnforce,
+			AnnotationWriters: []string{"approval-tool"},
+		}}})
+
+		old := stableParent(nil)
+		updated := stableParent(map[string]string{"example.com/owner": "team-a"})
+		resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, old, updated, "alice"))
+		assert.True(t, resp.Allowed)
+		assert.Empty(t, resp.Warnings)
+	})
+}
+
+func TestChangedKausalityAnnotations(t *testing.T) {
+	old := map[string]string{"kausality.io/trace": "a", "kausality.io/freeze": "x", "kausality.io/mode": "log", "other": "1"}
+	updated := map[string]string{"kausality.io/trace": "b", "kausality.io/mode": "log", "kausality.io/snooze": "y", "other": "2"}
+	assert.Equal(t, []string{"kausality.io/freeze", "kausality.io/snooze", "kausality.io/trace"}, changedKausalityAnnotations(kausalityv1alpha1.DefaultAnnotationKeys, old, updated))
+}
+
+func TestHandle_FieldScopedApprovals(t *testing.T) {
+	const approvals = `[{"apiVersion":"example.com/v1","kind":"Widget","name":"child","mode":"always","fields":["/spec/size"]}]`
+	enforce := &config.Config{DriftDetection: config.DriftDetectionConfig{DefaultMode: config.ModeEnforce}}
+	updaters := map[string]string{kausalityv1alpha1.UpdatersAnnotation: controller.HashUsername(testController)}
+	old := ownedChild("child", updaters, map[string]interface{}{"size": int64(1), "color": "blue"})
+
+	tests := []struct {
+		name        string
+		spec        map[string]interface{}
+		wantAllowed bool
+	}{
+		{name: "change confined to the approved field", spec: map[string]interface{}{"size": int64(2), "color": "blue"}, wantAllowed: true},
+		{name: "change beyond the approved field", spec: map[string]interface{}{"size": int64(2), "color": "red"}, wantAllowed: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, _ := newFakeHandler(t, Config{DriftConfig: enforce}, stableParent(map[string]string{kausalityv1alpha1.ApprovalsAnnotation: approvals}))
+
+			updated := ownedChild("child", updaters, tt.spec)
+			resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, old, updated, testController))
+			assert.Equal(t, tt.wantAllowed, resp.Allowed, resp.Result)
+		})
+	}
+
+	t.Run("creates are not covered", func(t *testing.T) {
+		h, _ := newFakeHandler(t, Config{DriftConfig: enforce}, stableParent(map[string]string{kausalityv1alpha1.ApprovalsAnnotation: approvals}))
+
+		created := ownedChild("child", nil, map[string]interface{}{"size": int64(1)})
+		resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Create, nil, created, testController))
+		require.False(t, resp.Allowed)
+	})
+}
+
+func TestHandle_ApprovalHistory(t *testing.T) {
+	onceApproval := `[{"apiVersion":"example.com/v1","kind":"Widget","name":"child","generation":1,"mode":"once","note":"CHG-1234"}]`
+	updaters := map[string]string{kausalityv1alpha1.UpdatersAnnotation: controller.HashUsername(testController)}
+
+	consume := func(t *testing.T, history *config.ApprovalHistoryConfig, annotations map[string]string) *appsv1.Deployment {
+		t.Helper()
+		anns := map[string]string{kausalityv1alpha1.ApprovalsAnnotation: onceApproval}
+		for k, v := range annotations {
+			anns[k] = v
+		}
+		h, c := newFakeHandler(t, Config{
+			DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{
+				DefaultMode:     config.ModeEnforce,
+				ApprovalHistory: history,
+			}},
+		}, stableParent(anns))
+
+		resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update,
+			ownedChild("child", updaters, map[string]interface{}{"size": int64(1)}),
+			ownedChild("child", updaters, map[string]interface{}{"size": int64(2)}),
+			testController))
+		require.True(t, resp.Allowed, resp.Result)
+
+		parent := &appsv1.Deployment{}
+		require.NoError(t, c.Get(t.Context(), client.ObjectKey{Namespace: testNamespace, Name: testParentName}, parent))
+		require.NotContains(t, parent.Annotations, kausalityv1alpha1.ApprovalsAnnotation, "approval should be consumed")
+		return parent
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		parent := consume(t, nil, nil)
+		assert.NotContains(t, parent.Annotations, kausalityv1alpha1.ApprovalHistoryAnnotation)
+	})
+
+	t.Run("records consumed approval", func(t *testing.T) {
+		parent := consume(t, &config.ApprovalHistoryConfig{}, nil)
+
+		history, err := kausalityv1alpha1.ParseApprovalHistory(parent.Annotations[kausalityv1alpha1.ApprovalHistoryAnnotation])
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		assert.Equal(t, "example.com/v1", history[0].APIVersion)
+		assert.Equal(t, "Widget", history[0].Kind)
+		assert.Equal(t, "child", history[0].Name)
+		assert.Equal(t, int64(1), history[0].Generation)
+		assert.Equal(t, testController, history[0].User)
+		assert.Equal(t, "CHG-1234", history[0].Note)
+		assert.False(t, history[0].At.IsZero())
+	})
+
+	t.Run("drops oldest entries beyond maxEntries", func(t *testing.T) {
+		var existing string
+		for i := range 3 {
+			if i > 0 {
+				existing += ","
+			}
+			existing += fmt.Sprintf(`{"apiVersion":"example.com/v1","kind":"Widget","name":"old-%d","generation":1,"at":"2026-01-01T00:00:00Z"}`, i)
+		}
+		parent := consume(t, &config.ApprovalHistoryConfig{MaxEntries: 3}, map[string]string{
+			kausalityv1alpha1.ApprovalHistoryAnnotation: "[" + existing + "]",
+		})
+
+		history, err := kausalityv1alpha1.ParseApprovalHistory(parent.Annotations[kausalityv1alpha1.ApprovalHistoryAnnotation])
+		require.NoError(t, err)
+		require.Len(t, history, 3)
+		assert.Equal(t, "old-1", history[0].Name)
+		assert.Equal(t, "old-2", history[1].Name)
+		assert.Equal(t, "child", history[2].Name)
+	})
+}
+
+func TestHandle_ApprovalNoteInResolvedReport(t *testing.T) {
+	sender := &ktesting.FakeSender{}
+	approvals := `[{"apiVersion":"example.com/v1","kind":"Widget","name":"child","mode":"always","note":"approved per CHG-1234"}]`
+	h, _ := newFakeHandler(t, Config{
+		CallbackSender: sender,
+		DriftConfig:    &config.Config{DriftDetection: config.DriftDetectionConfig{DefaultMode: config.ModeEnforce}},
+	}, stableParent(map[string]string{kausalityv1alpha1.ApprovalsAnnotation: approvals}))
+
+	updaters := map[string]string{kausalityv1alpha1.UpdatersAnnotation: controller.HashUsername(testController)}
+	old := ownedChild("child", updaters, map[string]interface{}{"size": int64(1)})
+	updated := ownedChild("child", updaters, map[string]interface{}{"size": int64(2)})
+
+	resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, old, updated, testController))
+	require.True(t, resp.Allowed)
+
+	reports := sender.Sent()
+	require.Len(t, reports, 1)
+	assert.Equal(t, v1alpha1.DriftReportPhaseResolved, reports[0].Spec.Phase)
+	assert.Equal(t, "approved per CHG-1234", reports[0].Spec.ApprovalNote)
+}
+
+func TestHandle_SelectorApprovals(t *testing.T) {
+	const approvals = `[{"apiVersion":"example.com/v1","kind":"Widget","selector":{"matchLabels":{"tier":"web"}},"mode":"once","generation":1}]`
+	enforce := &config.Config{DriftDetection: config.DriftDetectionConfig{DefaultMode: config.ModeEnforce}}
+	updaters := map[string]string{kausalityv1alpha1.UpdatersAnnotation: controller.HashUsername(testController)}
+
+	labeledChild := func(name, tier string, size int64) *unstructured.Unstructured {
+		child := ownedChild(name, updaters, map[string]interface{}{"size": size})
+		child.SetLabels(map[string]string{"tier": tier})
+		return child
+	}
+
+	t.Run("children with matching labels are approved, repeatedly", func(t *testing.T) {
+		h, c := newFakeHandler(t, Config{DriftConfig: enforce}, stableParent(map[string]string{kausalityv1alpha1.ApprovalsAnnotation: approvals}))
+
+		for _, name := range []string{"web-7d9f8", "web-5c4b2"} {
+			resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, labeledChild(name, "web", 1), labeledChild(name, "web", 2), testController))
+			assert.True(t, resp.Allowed, resp.Result)
+		}
+
+		parent := stableParent(nil)
+		require.NoError(t, c.Get(t.Context(), client.ObjectKeyFromObject(parent), parent))
+		assert.Equal(t, approvals, parent.GetAnnotations()[kausalityv1alpha1.ApprovalsAnnotation], "selector approvals are not consumed")
+	})
+
+	t.Run("children with other labels are not approved", func(t *testing.T) {
+		h, _ := newFakeHandler(t, Config{DriftConfig: enforce}, stableParent(map[string]string{kausalityv1alpha1.ApprovalsAnnotation: approvals}))
+
+		resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, labeledChild("db-0", "db", 1), labeledChild("db-0", "db", 2), testController))
+		assert.False(t, resp.Allowed)
+	})
+}
+
+func TestHandle_AuditWriter(t *testing.T) {
+	childAnnotations := map[string]string{kausalityv1alpha1.UpdatersAnnotation: controller.HashUsername(testController)}
+	req := newAdmissionRequest(t, admissionv1.Update,
+		ownedChild("child", childAnnotations, map[string]interface{}{"size": int64(1)}),
+		ownedChild("child", childAnnotations, map[string]interface{}{"size": int64(2)}),
+		testController)
+	req.Options = runtime.RawExtension{Raw: []byte(`{"fieldManager":"widget-controller"}`)}
+
+	tests := []struct {
+		name          string
+		parentAnns    map[string]string
+		wantDecision  string
+		wantApproval  bool
+		wantRejection bool
+	}{
+		{
+			name:         "unapproved drift is denied",
+			wantDecision: AuditDecisionDenied,
+		},
+		{
+			name: "approved drift is allowed",
+			parentAnns: map[string]string{
+				kausalityv1alpha1.ApprovalsAnnotation: `[{"apiVersion":"example.com/v1","kind":"Widget","name":"child","mode":"always"}]`,
+			},
+			wantDecision: AuditDecisionAllowed,
+			wantApproval: true,
+		},
+		{
+			name: "rejected drift is denied",
+			parentAnns: map[string]string{
+				kausalityv1alpha1.RejectionsAnnotation: `[{"apiVersion":"example.com/v1","kind":"Widget","name":"child","reason":"hands off"}]`,
+			},
+			wantDecision:  AuditDecisionDenied,
+			wantRejection: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			h, _ := newFakeHandler(t, Config{
+				DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{DefaultMode: config.ModeEnforce}},
+				AuditWriter: &buf,
+			}, stableParent(tt.parentAnns))
+
+			resp := h.Handle(t.Context(), req)
+			assert.Equal(t, tt.wantDecision == AuditDecisionAllowed, resp.Allowed, resp.Result)
+
+			require.Equal(t, 1, bytes.Count(buf.Bytes(), []byte("\n")), "one record per line: %s", buf.String())
+			var record AuditRecord
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+			assert.Equal(t, string(req.UID), record.RequestUID)
+			assert.Equal(t, "UPDATE", record.Operation)
+			assert.Equal(t, "example.com/v1", record.APIVersion)
+			assert.Equal(t, "Widget", record.Kind)
+			assert.Equal(t, testNamespace, record.Namespace)
+			assert.Equal(t, "child", record.Name)
+			assert.Equal(t, testController, record.User)
+			assert.Equal(t, "widget-controller", record.FieldManager)
+			assert.Equal(t, drift.PhaseInitialized, record.Phase)
+			assert.True(t, record.DriftDetected)
+			assert.Equal(t, config.ModeEnforce, record.Mode)
+			assert.Equal(t, tt.wantDecision, record.Decision)
+			assert.False(t, record.Time.IsZero())
+			assert.Equal(t, tt.wantApproval, record.MatchedApproval != nil)
+			assert.Equal(t, tt.wantRejection, record.MatchedRejection != nil)
+		})
+	}
+}
+
+func TestAuditLog_Disabled(t *testing.T) {
+	var a *auditLog
+	req := newAdmissionRequest(t, admissionv1.Create, nil, ownedChild("child", nil, nil), testController)
+	resp := admission.Allowed("")
+	allocs := testing.AllocsPerRun(10, func() {
+		r := a.start(req)
+		r.setDrift(&drift.DriftResult{})
+		r.setMode(config.ModeEnforce)
+		a.write(r, resp)
+	})
+	assert.Zero(t, allocs)
+}
+
+func TestHandle_PolicyAutoApprovals(t *testing.T) {
+	widgets := kausalityv1alpha1.Kausality{
+		ObjectMeta: metav1.ObjectMeta{Name: "widgets"},
+		Spec: kausalityv1alpha1.KausalitySpec{
+			Resources: []kausalityv1alpha1.ResourceRule{{APIGroups: []string{"example.com"}, Resources: []string{"*"}}},
+			Mode:      kausalityv1alpha1.ModeEnforce,
+		},
+	}
+	deployments := kausalityv1alpha1.Kausality{
+		ObjectMeta: metav1.ObjectMeta{Name: "deployments"},
+		Spec: kausalityv1alpha1.KausalitySpec{
+			Resources: []kausalityv1alpha1.ResourceRule{{APIGroups: []string{"apps"}, Resources: []string{"deployments"}}},
+			Mode:      kausalityv1alpha1.ModeLog,
+			AutoApprovals: []kausalityv1alpha1.ApprovalSpec{
+				{APIVersion: "example.com/v1", Kind: "Widget", Name: "auto", Mode: approval.ModeAlways},
+			},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		child       string
+		parentAnns  map[string]string
+		wantAllowed bool
+	}{
+		{
+			name:        "auto-approved child",
+			child:       "auto",
+			wantAllowed: true,
+		},
+		{
+			name:  "other children drift",
+			child: "other",
+		},
+		{
+			name:  "parent rejection wins",
+			child: "auto",
+			parentAnns: map[string]string{
+				approval.RejectionsAnnotation: `[{"apiVersion":"example.com/v1","kind":"Widget","name":"auto","reason":"frozen"}]`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := policy.NewStore(fake.NewClientBuilder().WithScheme(testScheme).WithObjects(&widgets, &deployments).Build(), logr.Discard())
+			require.NoError(t, store.Refresh(t.Context()))
+
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace}}
+			h, _ := newFakeHandler(t, Config{PolicyResolver: store}, ns, stableParent(tt.parentAnns))
+
+			old := ownedChild(tt.child, nil, map[string]interface{}{"size": int64(1)})
+			changed := ownedChild(tt.child, nil, map[string]interface{}{"size": int64(2)})
+			resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, old, changed, testController))
+			assert.Equal(t, tt.wantAllowed, resp.Allowed, resp.Result)
+		})
+	}
+}
+
+func TestHandle_Baseline(t *testing.T) {
+	baselines := baseline.NewStore(nil, logr.Discard())
+	baselines.Update([]kausalityv1alpha1.Baseline{{
+		ObjectMeta: metav1.ObjectMeta{Name: "widget-scaling"},
+		Spec: kausalityv1alpha1.BaselineSpec{
+			Parent: kausalityv1alpha1.BaselineKind{APIGroup: "apps", Kind: "Deployment"},
+			Expected: []kausalityv1alpha1.ExpectedChange{{
+				Child:         kausalityv1alpha1.BaselineKind{APIGroup: "example.com", Kind: "Widget"},
+				FieldManagers: []string{"autoscaler"},
+				Fields:        []string{"spec.size"},
+			}},
+		},
+	}})
+
+	updaters := map[string]string{kausalityv1alpha1.UpdatersAnnotation: controller.HashUsername(testController)}
+	old := ownedChild("child", updaters, map[string]interface{}{"size": int64(1), "color": "blue"})
+
+	handle := func(t *testing.T, spec map[string]interface{}, fieldManager string) admission.Response {
+		sender := &ktesting.FakeSender{}
+		h, _ := newFakeHandler(t, Config{
+			DriftConfig:    &config.Config{DriftDetection: config.DriftDetectionConfig{DefaultMode: config.ModeEnforce}},
+			CallbackSender: sender,
+			Baselines:      baselines,
+		}, stableParent(nil))
+		req := newAdmissionRequest(t, admissionv1.Update, old, ownedChild("child", updaters, spec), testController)
+		req.Options = runtime.RawExtension{Raw: []byte(`{"fieldManager":"` + fieldManager + `"}`)}
+		resp := h.Handle(t.Context(), req)
+		if resp.Allowed {
+			assert.Empty(t, sender.Sent(), "no drift report for an allowed change")
+		}
+		return resp
+	}
+
+	t.Run("in-baseline change is allowed", func(t *testing.T) {
+		resp := handle(t, map[string]interface{}{"size": int64(3), "color": "blue"}, "autoscaler")
+		assert.True(t, resp.Allowed)
+	})
+
+	t.Run("change outside the baseline fields is drift", func(t *testing.T) {
+		resp := handle(t, map[string]interface{}{"size": int64(3), "color": "red"}, "autoscaler")
+		assert.False(t, resp.Allowed)
+		assert.Equal(t, "drift detected: no approval found for this mutation", resp.Result.Message)
+	})
+
+	t.Run("change by an unexpected field manager is drift", func(t *testing.T) {
+		resp := handle(t, map[string]interface{}{"size": int64(3), "color": "blue"}, "kubectl")
+		assert.False(t, resp.Allowed)
+	})
+}
+
+func TestHandle_BreakGlass(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	_, otherPriv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	childTarget := breakglass.Target{Group: "example.com", Kind: "Widget", Namespace: testNamespace, Name: "child"}
+	signFor := func(key ed25519.PrivateKey, age time.Duration, target breakglass.Target) string {
+		token, err := breakglass.Sign(breakglass.Claims{
+			ID:       "INC-42-1",
+			User:     "oncall@example.com",
+			Reason:   "INC-42 recovery",
+			IssuedAt: metav1.NewTime(time.Now().Add(-age)),
+			Target:   target,
+		}, key)
+		require.NoError(t, err)
+		return token
+	}
+	sign := func(key ed25519.PrivateKey, age time.Duration) string {
+		return signFor(key, age, childTarget)
+	}
+
+	enforce := &config.Config{DriftDetection: config.DriftDetectionConfig{DefaultMode: config.ModeEnforce}}
+
+	tests := []struct {
+		name          string
+		parentAnns    map[string]string
+		token         string
+		wantAllowed   bool
+		wantAudit     bool
+		wantWarning   bool
+		wantCallbacks []v1alpha1.DriftReportPhase
+	}{
+		{
+			name:          "valid token bypasses enforcement",
+			token:         sign(priv, time.Minute),
+			wantAllowed:   true,
+			wantAudit:     true,
+			wantCallbacks: []v1alpha1.DriftReportPhase{v1alpha1.DriftReportPhaseBreakGlass},
+		},
+		{
+			name:          "valid token bypasses freeze and snooze",
+			parentAnns:    map[string]string{kausalityv1alpha1.FreezeAnnotation: "true", kausalityv1alpha1.SnoozeAnnotation: time.Now().Add(time.Hour).Format(time.RFC3339)},
+			token:         sign(priv, time.Minute),
+			wantAllowed:   true,
+			wantAudit:     true,
+			wantCallbacks: []v1alpha1.DriftReportPhase{v1alpha1.DriftReportPhaseBreakGlass},
+		},
+		{
+			name:          "expired token is ignored",
+			token:         sign(priv, time.Hour),
+			wantAllowed:   false,
+			wantCallbacks: []v1alpha1.DriftReportPhase{v1alpha1.DriftReportPhaseDetected},
+		},
+		{
+			name:          "token signed with wrong key is ignored",
+			token:         sign(otherPriv, time.Minute),
+			wantAllowed:   false,
+			wantCallbacks: []v1alpha1.DriftReportPhase{v1alpha1.DriftReportPhaseDetected},
+		},
+		{
+			name:          "token minted for another object is rejected",
+			token:         signFor(priv, time.Minute, breakglass.Target{Group: "example.com", Kind: "Widget", Namespace: testNamespace, Name: "other"}),
+			wantAllowed:   false,
+			wantCallbacks: []v1alpha1.DriftReportPhase{v1alpha1.DriftReportPhaseDetected},
+		},
+		{
+			name:          "token minted for another kind is rejected",
+			token:         signFor(priv, time.Minute, breakglass.Target{Group: "apps", Kind: "Deployment", Namespace: testNamespace, Name: "child"}),
+			wantAllowed:   false,
+			wantCallbacks: []v1alpha1.DriftReportPhase{v1alpha1.DriftReportPhaseDetected},
+		},
+		{
+			name:        "invalid token on frozen parent stays frozen",
+			parentAnns:  map[string]string{kausalityv1alpha1.FreezeAnnotation: "true"},
+			token:       "not-a-token",
+			wantAllowed: false,
+		},
+		{
+			name:          "invalid token in log mode warns",
+			parentAnns:    map[string]string{config.ModeAnnotation: config.ModeLog},
+			token:         "not-a-token",
+			wantAllowed:   true,
+			wantWarning:   true,
+			wantCallbacks: []v1alpha1.DriftReportPhase{v1alpha1.DriftReportPhaseDetected},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sender := &ktesting.FakeSender{}
+			h, _ := newFakeHandler(t, Config{
+				DriftConfig:        enforce,
+				CallbackSender:     sender,
+				BreakGlassVerifier: breakglass.NewVerifier(pub, 15*time.Minute),
+			}, stableParent(tt.parentAnns))
+
+			childAnns := map[string]string{kausalityv1alpha1.BreakGlassAnnotation: tt.token}
+			if mode, ok := tt.parentAnns[config.ModeAnnotation]; ok {
+				childAnns[config.ModeAnnotation] = mode
+			}
+			child := ownedChild("child", childAnns, nil)
+			resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Create, nil, child, testController))
+
+			assert.Equal(t, tt.wantAllowed, resp.Allowed, resp.Result)
+
+			patched := patchedAnnotations(resp)
+			if tt.wantAudit {
+				var audit breakglass.Audit
+				require.NoError(t, json.Unmarshal([]byte(patched[kausalityv1alpha1.BreakGlassAuditAnnotation]), &audit))
+				assert.Equal(t, "INC-42-1", audit.TokenID)
+				assert.Equal(t, "oncall@example.com", audit.User)
+				assert.Equal(t, "INC-42 recovery", audit.Reason)
+				assert.Equal(t, testController, audit.RequestUser)
+				assert.Contains(t, removedAnnotations(resp), kausalityv1alpha1.BreakGlassAnnotation)
+			} else {
+				assert.NotContains(t, patched, kausalityv1alpha1.BreakGlassAuditAnnotation)
+			}
+
+			hasWarning := false
+			for _, w := range resp.Warnings {
+				if strings.HasPrefix(w, "[kausality] break-glass token rejected") {
+					hasWarning = true
+				}
+			}
+			assert.Equal(t, tt.wantWarning, hasWarning, resp.Warnings)
+
+			var phases []v1alpha1.DriftReportPhase
+			for _, r := range sender.Sent() {
+				phases = append(phases, r.Spec.Phase)
+				if r.Spec.Phase == v1alpha1.DriftReportPhaseBreakGlass {
+					assert.Equal(t, v1alpha1.DriftReportSeverityCritical, r.Spec.Severity)
+				}
+			}
+			assert.Equal(t, tt.wantCallbacks, phases)
+		})
+	}
+}
+
+func TestHandle_BreakGlassNotConfigured(t *testing.T) {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	token, err := breakglass.Sign(breakglass.Claims{
+		ID: "a", User: "a", Reason: "b", IssuedAt: metav1.Now(),
+		Target: breakglass.Target{Group: "example.com", Kind: "Widget", Namespace: testNamespace, Name: "child"},
+	}, priv)
+	require.NoError(t, err)
+
+	h, _ := newFakeHandler(t, Config{
+		DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{DefaultMode: config.ModeEnforce}},
+	}, stableParent(nil))
+
+	child := ownedChild("child", map[string]string{kausalityv1alpha1.BreakGlassAnnotation: token}, nil)
+	resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Create, nil, child, testController))
+	assert.False(t, resp.Allowed)
+}
+
+func TestHandle_DetectChainMutations(t *testing.T) {
+	entry := func(manager, fields string) metav1.ManagedFieldsEntry {
+		return metav1.ManagedFieldsEntry{
+			Manager:    manager,
+			Operation:  metav1.ManagedFieldsOperationUpdate,
+			FieldsType: "FieldsV1",
+			FieldsV1:   &metav1.FieldsV1{Raw: []byte(fields)},
+		}
+	}
+
+	updaters := map[string]string{kausalityv1alpha1.UpdatersAnnotation: controller.HashUsername(testController)}
+	old := ownedChild("child", updaters, map[string]interface{}{"size": int64(1)})
+	old.SetManagedFields([]metav1.ManagedFieldsEntry{entry("operator", `{"f:spec":{"f:size":{}}}`)})
+	updated := ownedChild("child", updaters, map[string]interface{}{"size": int64(2), "sidecar": true})
+	updated.SetManagedFields([]metav1.ManagedFieldsEntry{
+		entry("operator", `{"f:spec":{"f:size":{}}}`),
+		entry("sidecar-injector", `{"f:spec":{"f:sidecar":{}}}`),
+	})
+
+	handle := func(t *testing.T, mode string, detect bool) admission.Response {
+		h, _ := newFakeHandler(t, Config{DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{
+			DefaultMode:          mode,
+			DetectChainMutations: detect,
+		}}}, stableParent(nil))
+		req := newAdmissionRequest(t, admissionv1.Update, old, updated, testController)
+		req.Options = runtime.RawExtension{Raw: []byte(`{"fieldManager":"operator"}`)}
+		return h.Handle(t.Context(), req)
+	}
+
+	const msg = "object modified by webhook sidecar-injector (spec.sidecar) in the chain"
+
+	t.Run("warns about the mutator", func(t *testing.T) {
+		resp := handle(t, config.ModeLog, true)
+		assert.True(t, resp.Allowed)
+		assert.Contains(t, resp.Warnings, "[kausality] "+msg)
+	})
+
+	t.Run("drift denial names the mutator", func(t *testing.T) {
+		resp := handle(t, config.ModeEnforce, true)
+		assert.False(t, resp.Allowed)
+		assert.Equal(t, "drift detected: no approval found for this mutation; "+msg, resp.Result.Message)
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		resp := handle(t, config.ModeLog, false)
+		assert.True(t, resp.Allowed)
+		for _, w := range resp.Warnings {
+			assert.NotContains(t, w, "in the chain")
+		}
+	})
+}
+
+func TestHandle_ControllerClearsUserSetField(t *testing.T) {
+	updaters := map[string]string{kausalityv1alpha1.UpdatersAnnotation: controller.HashUsername(testController)}
+	old := ownedChild("child", updaters, map[string]interface{}{"size": int64(1), "color": "blue"})
+	cleared := ownedChild("child", updaters, map[string]interface{}{"size": int64(1), "color": ""})
+
+	t.Run("log mode names the field", func(t *testing.T) {
+		sender := &ktesting.FakeSender{}
+		h, _ := newFakeHandler(t, Config{
+			CallbackSender: sender,
+			DriftConfig:    &config.Config{DriftDetection: config.DriftDetectionConfig{DefaultMode: config.ModeLog}},
+		}, stableParent(nil))
+
+		resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, old, cleared, testController))
+		require.True(t, resp.Allowed)
+		require.Len(t, resp.Warnings, 1)
+		assert.Contains(t, resp.Warnings[0], "controller cleared user-set field spec.color")
+
+		reports := sender.Sent()
+		require.Len(t, reports, 1)
+		assert.Equal(t, []string{"spec.color"}, reports[0].Spec.ClearedFields)
+		assert.Equal(t, v1alpha1.DriftReportSeverityWarning, reports[0].Spec.Severity)
+	})
+
+	t.Run("deny rejects it in log mode", func(t *testing.T) {
+		h, _ := newFakeHandler(t, Config{
+			DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{
+				DefaultMode:         config.ModeLog,
+				OnClearedUserFields: config.ClearedFieldsDeny,
+			}},
+		}, stableParent(nil))
+
+		resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, old, cleared, testController))
+		require.False(t, resp.Allowed)
+		assert.Contains(t, resp.Result.Message, "controller cleared user-set field spec.color")
+	})
+
+	t.Run("deny keeps other drift in log mode", func(t *testing.T) {
+		h, _ := newFakeHandler(t, Config{
+			DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{
+				DefaultMode:         config.ModeLog,
+				OnClearedUserFields: config.ClearedFieldsDeny,
+			}},
+		}, stableParent(nil))
+
+		changed := ownedChild("child", updaters, map[string]interface{}{"size": int64(2), "color": "blue"})
+		resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, old, changed, testController))
+		require.True(t, resp.Allowed)
+	})
+
+	t.Run("user clearing a field is no drift", func(t *testing.T) {
+		h, _ := newFakeHandler(t, Config{
+			DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{
+				DefaultMode:         config.ModeEnforce,
+				OnClearedUserFields: config.ClearedFieldsDeny,
+			}},
+		}, stableParent(nil))
+
+		resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, old, cleared, "alice"))
+		require.True(t, resp.Allowed)
+		assert.Empty(t, resp.Warnings)
+	})
+}
+
+func TestHandle_ClusterScopedParent(t *testing.T) {
+	// clusterParent returns a stable cluster-scoped parent, like a Crossplane composite.
+	clusterParent := func(annotations map[string]string) *unstructured.Unstructured {
+		parent := &unstructured.Unstructured{Object: map[string]interface{}{
+			"status": map[string]interface{}{"observedGeneration": int64(1)},
+		}}
+		parent.SetAPIVersion("example.com/v1")
+		parent.SetKind("Composite")
+		parent.SetName(testParentName)
+		parent.SetUID("composite-uid")
+		parent.SetGeneration(1)
+		anns := map[string]string{controller.PhaseAnnotation: controller.PhaseValueInitialized}
+		for k, v := range annotations {
+			anns[k] = v
+		}
+		parent.SetAnnotations(anns)
+		return parent
+	}
+	child := func(namespace string, size int64) *unstructured.Unstructured {
+		c := ownedChild("child", map[string]string{kausalityv1alpha1.UpdatersAnnotation: controller.HashUsername(testController)}, map[string]interface{}{"size": size})
+		c.SetNamespace(namespace)
+		c.SetOwnerReferences([]metav1.OwnerReference{{
+			APIVersion: "example.com/v1",
+			Kind:       "Composite",
+			Name:       testParentName,
+			UID:        "composite-uid",
+			Controller: ptr.To(true),
+		}})
+		return c
+	}
+	approvals := map[string]string{
+		kausalityv1alpha1.ApprovalsAnnotation: `[{"apiVersion":"example.com/v1","kind":"Widget","name":"child","mode":"always"}]`,
+	}
+
+	tests := []struct {
+		name        string
+		namespace   string
+		annotations map[string]string
+		wantAllowed bool
+	}{
+		{
+			name:        "cluster-scoped child, drift is detected",
+			wantAllowed: false,
+		},
+		{
+			name:        "cluster-scoped child, approvals are read from the parent",
+			annotations: approvals,
+			wantAllowed: true,
+		},
+		{
+			name:        "namespaced child, drift is detected",
+			namespace:   testNamespace,
+			wantAllowed: false,
+		},
+		{
+			name:        "namespaced child, approvals are read from the parent",
+			namespace:   testNamespace,
+			annotations: approvals,
+			wantAllowed: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, _ := newFakeHandler(t, Config{
+				DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{DefaultMode: config.ModeEnforce}},
+			}, clusterParent(tt.annotations))
+
+			req := newAdmissionRequest(t, admissionv1.Update, child(tt.namespace, 1), child(tt.namespace, 2), testController)
+			req.Namespace = tt.namespace
+			resp := h.Handle(t.Context(), req)
+			assert.Equal(t, tt.wantAllowed, resp.Allowed, resp.Result)
+		})
+	}
+}
+
+func TestHandle_ConfigMapReload(t *testing.T) {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kausality-system", Name: "kausality-config"},
+		Data:       map[string]string{config.ConfigMapKey: "driftDetection:\n  defaultMode: log\n"},
+	}
+	c := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(stableParent(nil), cm).Build()
+	watcher, err := config.WatchConfigMap(t.Context(), c, cm.Namespace, cm.Name)
+	require.NoError(t, err)
+	h := NewHandler(Config{Client: c, Log: logr.Discard(), DriftConfigSource: watcher.Get})
+
+	resp := h.Handle(t.Context(), driftRequest(t))
+	assert.True(t, resp.Allowed, "drift is allowed in log mode")
+
+	// The watch may start after the first update, so updates are repeated until seen
+	require.Eventually(t, func() bool {
+		require.NoError(t, c.Get(t.Context(), client.ObjectKeyFromObject(cm), cm))
+		cm.Data[config.ConfigMapKey] = "driftDetection:\n  defaultMode: enforce\n"
+		require.NoError(t, c.Update(t.Context(), cm))
+		return watcher.Get().DriftDetection.DefaultMode == config.ModeEnforce
+	}, 5*time.Second, 50*time.Millisecond, "update not reloaded")
+
+	resp = h.Handle(t.Context(), driftRequest(t))
+	assert.False(t, resp.Allowed, "drift is denied after switching to enforce mode")
+}
+
+func TestHandle_StripOnCreate(t *testing.T) {
+	h, _ := newFakeHandler(t, Config{
+		DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{
+			DefaultMode:   config.ModeLog,
+			StripOnCreate: []string{"argocd.argoproj.io/", "example.com/owner"},
+		}},
+	}, stableParent(nil))
+
+	child := ownedChild("child", map[string]string{
+		"argocd.argoproj.io/tracking-id": "app:apps/Deployment:default/parent",
+		"argocd.argoproj.io/sync-wave":   "1",
+		"example.com/owner":              "team-a",
+		"example.com/owner-team":         "a",
+		"app.kubernetes.io/name":         "widget",
+	}, nil)
+
+	t.Run("create strips configured annotations", func(t *testing.T) {
+		resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Create, nil, child, testController))
+		require.True(t, resp.Allowed)
+		assert.Equal(t, []string{
+			"argocd.argoproj.io/sync-wave",
+			"argocd.argoproj.io/tracking-id",
+			"example.com/owner",
+		}, removedAnnotations(resp))
+		assert.NotContains(t, patchedAnnotations(resp), "app.kubernetes.io/name")
+	})
+
+	t.Run("update keeps configured annotations", func(t *testing.T) {
+		old := child.DeepCopy()
+		updated := child.DeepCopy()
+		updated.Object["spec"] = map[string]interface{}{"size": int64(2)}
+		resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, old, updated, testController))
+		require.True(t, resp.Allowed)
+		assert.Empty(t, removedAnnotations(resp))
+	})
+}
+
+func TestHandle_DenyMessageTemplate(t *testing.T) {
+	const template = "{{.ParentKind}} {{.ParentName}}: {{.Reason}} on {{.ChildKind}} {{.Namespace}}/{{.ChildName}} by {{.Actor}}, see https://wiki.example.com/drift"
+	const rendered = "Deployment parent: drift detected: no approval found for this mutation on Widget default/child by " +
+		testController + ", see https://wiki.example.com/drift"
+
+	tests := []struct {
+		name        string
+		mode        string
+		template    string
+		wantMessage string
+		wantWarning string
+	}{
+		{
+			name:        "default denial",
+			mode:        config.ModeEnforce,
+			wantMessage: "drift detected: no approval found for this mutation",
+		},
+		{
+			name:        "templated denial",
+			mode:        config.ModeEnforce,
+			template:    template,
+			wantMessage: rendered,
+		},
+		{
+			name:        "templated warning",
+			mode:        config.ModeLog,
+			template:    template,
+			wantWarning: "[kausality] " + rendered + " (would be blocked in enforce mode)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, _ := newFakeHandler(t, Config{DriftConfig: &config.Config{
+				DriftDetection:      config.DriftDetectionConfig{DefaultMode: tt.mode},
+				DenyMessageTemplate: tt.template,
+			}}, stableParent(nil))
+
+			resp := h.Handle(t.Context(), driftRequest(t))
+			if tt.wantMessage != "" {
+				assert.False(t, resp.Allowed)
+				assert.Equal(t, tt.wantMessage, resp.Result.Message)
+			} else {
+				assert.True(t, resp.Allowed)
+				assert.Contains(t, resp.Warnings, tt.wantWarning)
+			}
+		})
+	}
+}
+
+func TestHandle_DryRun(t *testing.T) {
+	const operator = "alice@example.com"
+	onceApproval := `[{"apiVersion":"example.com/v1","kind":"Widget","name":"child","generation":1,"mode":"once"}]`
+	newHandler := func(t *testing.T) (*Handler, client.Client, *ktesting.FakeSender) {
+		sender := &ktesting.FakeSender{}
+		h, c := newFakeHandler(t, Config{
+			DriftConfig:    &config.Config{DriftDetection: config.DriftDetectionConfig{DefaultMode: config.ModeEnforce}},
+			CallbackSender: sender,
+		}, stableParent(map[string]string{kausalityv1alpha1.ApprovalsAnnotation: onceApproval}))
+		return h, c, sender
+	}
+
+	for _, dryRun := range []bool{false, true} {
+		t.Run(fmt.Sprintf("dryRun=%v", dryRun), func(t *testing.T) {
+			h, c, sender := newHandler(t)
+			req := newAdmissionRequest(t, admissionv1.Update,
+				ownedChild("child", nil, map[string]interface{}{"size": int64(1)}),
+				ownedChild("child", nil, map[string]interface{}{"size": int64(2)}),
+				operator)
+			req.DryRun = ptr.To(dryRun)
+
+			resp := h.Handle(t.Context(), req)
+			assert.True(t, resp.Allowed, resp.Result)
+
+			parent := &appsv1.Deployment{}
+			require.NoError(t, c.Get(t.Context(), client.ObjectKey{Namespace: testNamespace, Name: testParentName}, parent))
+			if dryRun {
+				assert.Equal(t, onceApproval, parent.Annotations[kausalityv1alpha1.ApprovalsAnnotation], "dry-run must not consume the approval")
+				assert.Empty(t, sender.Sent(), "dry-run must not send callbacks")
+			} else {
+				assert.NotContains(t, parent.Annotations, kausalityv1alpha1.ApprovalsAnnotation, "approval should be consumed")
+				assert.NotEmpty(t, sender.Sent())
+			}
+		})
+	}
+}
+
+func TestHandle_DriftBlockedEvent(t *testing.T) {
+	updaters := map[string]string{kausalityv1alpha1.UpdatersAnnotation: controller.HashUsername(testController)}
+	old := ownedChild("child", updaters, map[string]interface{}{"size": int64(1)})
+	updated := ownedChild("child", updaters, map[string]interface{}{"size": int64(2)})
+
+	newHandler := func(t *testing.T, mode string, parentAnns map[string]string) (*Handler, *events.FakeRecorder) {
+		recorder := events.NewFakeRecorder(10)
+		h, _ := newFakeHandler(t, Config{EventRecorder: recorder, DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{DefaultMode: mode}}},
+			stableParent(parentAnns))
+		return h, recorder
+	}
+
+	t.Run("denied drift records an event", func(t *testing.T) {
+		h, recorder := newHandler(t, config.ModeEnforce, nil)
+		require.False(t, h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, old, updated, testController)).Allowed)
+		require.Len(t, recorder.Events, 1)
+		assert.Equal(t, "Warning DriftBlocked Widget child changed by "+testController+": drift detected: no approval found for this mutation", <-recorder.Events)
+	})
+
+	t.Run("rejected drift records an event", func(t *testing.T) {
+		rejections := `[{"apiVersion":"example.com/v1","kind":"Widget","name":"child","reason":"under review"}]`
+		h, recorder := newHandler(t, config.ModeEnforce, map[string]string{approval.RejectionsAnnotation: rejections})
+		require.False(t, h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, old, updated, testController)).Allowed)
+		require.Len(t, recorder.Events, 1)
+		assert.Equal(t, "Warning DriftBlocked Widget child changed by "+testController+": drift rejected: under review", <-recorder.Events)
+	})
+
+	t.Run("allowed drift records nothing", func(t *testing.T) {
+		h, recorder := newHandler(t, config.ModeLog, nil)
+		require.True(t, h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, old, updated, testController)).Allowed)
+		assert.Empty(t, recorder.Events)
+	})
+
+	t.Run("dry-run records nothing", func(t *testing.T) {
+		h, recorder := newHandler(t, config.ModeEnforce, nil)
+		req := newAdmissionRequest(t, admissionv1.Update, old, updated, testController)
+		req.DryRun = ptr.To(true)
+		require.False(t, h.Handle(t.Context(), req).Allowed)
+		assert.Empty(t, recorder.Events)
+	})
+
+	t.Run("without a recorder", func(t *testing.T) {
+		h, _ := newFakeHandler(t, Config{DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{DefaultMode: config.ModeEnforce}}}, stableParent(nil))
+		require.False(t, h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, old, updated, testController)).Allowed)
+	})
+}
+
+func TestHandle_FirstSeenSurvivesRestart(t *testing.T) {
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	cfg := Config{DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{DefaultMode: config.ModeLog}}}
+
+	updaters := map[string]string{kausalityv1alpha1.UpdatersAnnotation: controller.HashUsername(testController)}
+	old := ownedChild("child", updaters, map[string]interface{}{"size": int64(1)})
+	updated := ownedChild("child", updaters, map[string]interface{}{"size": int64(2)})
+
+	// First webhook instance detects the drift.
+	sender := &ktesting.FakeSender{}
+	cfg.CallbackSender = sender
+	h, c := newFakeHandler(t, cfg, stableParent(nil))
+	h.firstSeen.nowFunc = func() time.Time { return start }
+	h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, old, updated, testController))
+
+	reports := sender.Sent()
+	require.Len(t, reports, 1)
+	require.NotNil(t, reports[0].Spec.FirstSeen)
+	assert.True(t, start.Equal(reports[0].Spec.FirstSeen.Time))
+
+	// The time is persisted on the parent in the background.
+	parent := &appsv1.Deployment{}
+	require.Eventually(t, func() bool {
+		require.NoError(t, c.Get(t.Context(), client.ObjectKey{Namespace: testNamespace, Name: testParentName}, parent))
+		return strings.Contains(parent.Annotations[kausalityv1alpha1.DriftFirstSeenAnnotation], reports[0].Spec.ID)
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// A restarted webhook instance sees the same drift an hour later.
+	restartedSender := &ktesting.FakeSender{}
+	restarted := NewHandler(Config{
+		Client:         c,
+		Log:            h.log,
+		DriftConfig:    cfg.DriftConfig,
+		CallbackSender: restartedSender,
+	})
+	restarted.firstSeen.nowFunc = func() time.Time { return start.Add(time.Hour) }
+	restarted.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, old, updated, testController))
+
+	reports = restartedSender.Sent()
+	require.Len(t, reports, 1)
+	require.NotNil(t, reports[0].Spec.FirstSeen)
+	assert.True(t, start.Equal(reports[0].Spec.FirstSeen.Time), "got %v", reports[0].Spec.FirstSeen)
+
+	// A resolved report after the restart carries the same first-seen time.
+	driftResult, err := restarted.detector.Detect(t.Context(), updated, testController, []string{controller.HashUsername(testController)})
+	require.NoError(t, err)
+	restarted.sendDriftCallback(t.Context(), restarted.config(), newAdmissionRequest(t, admissionv1.Update, old, updated, testController),
+		updated, driftResult, parent, v1alpha1.DriftReportPhaseResolved, approval.CheckResult{}, h.log)
+	reports = restartedSender.Sent()
+	require.Len(t, reports, 2)
+	assert.Equal(t, v1alpha1.DriftReportPhaseResolved, reports[1].Spec.Phase)
+	require.NotNil(t, reports[1].Spec.FirstSeen)
+	assert.True(t, start.Equal(reports[1].Spec.FirstSeen.Time))
+}
+
+func TestMarshalFirstSeen_KeepsMostRecent(t *testing.T) {
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	entries := map[string]time.Time{}
+	for i := 0; i < maxFirstSeenEntries+5; i++ {
+		entries[string(rune('a'+i))] = start.Add(time.Duration(i) * time.Minute)
+	}
+
+	value, err := marshalFirstSeen(entries)
+	require.NoError(t, err)
+	parsed := parseFirstSeen(value)
+	assert.Len(t, parsed, maxFirstSeenEntries)
+	assert.NotContains(t, parsed, "a")
+	assert.Contains(t, parsed, string(rune('a'+maxFirstSeenEntries+4)))
+
+	assert.Empty(t, parseFirstSeen("not json"))
+}
+
+func TestHandle_FirstSeenNotPersistedWhileSnoozed(t *testing.T) {
+	snooze, err := approval.MarshalSnooze(&approval.Snooze{Expiry: metav1.NewTime(time.Now().Add(time.Hour))})
+	require.NoError(t, err)
+	h, c := newFakeHandler(t, Config{
+		DriftConfig:    &config.Config{DriftDetection: config.DriftDetectionConfig{DefaultMode: config.ModeLog}},
+		CallbackSender: &ktesting.FakeSender{},
+	}, stableParent(map[string]string{approval.SnoozeAnnotation: snooze}))
+
+	updaters := map[string]string{kausalityv1alpha1.UpdatersAnnotation: controller.HashUsername(testController)}
+	old := ownedChild("child", updaters, map[string]interface{}{"size": int64(1)})
+	updated := ownedChild("child", updaters, map[string]interface{}{"size": int64(2)})
+	h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, old, updated, testController))
+
+	// The time is still known in memory, so aging covers the snoozed period.
+	h.firstSeen.mu.Lock()
+	assert.Equal(t, 1, h.firstSeen.order.Len())
+	h.firstSeen.mu.Unlock()
+
+	h.firstSeen.pendingMu.Lock()
+	assert.Empty(t, h.firstSeen.pending)
+	h.firstSeen.pendingMu.Unlock()
+	parent := &appsv1.Deployment{}
+	require.NoError(t, c.Get(t.Context(), client.ObjectKey{Namespace: testNamespace, Name: testParentName}, parent))
+	assert.NotContains(t, parent.Annotations, kausalityv1alpha1.DriftFirstSeenAnnotation)
+}
+
+func TestFirstSeenTracker_EvictsLeastRecentlyUsed(t *testing.T) {
+	tracker := newFirstSeenTracker(nil, kausalityv1alpha1.NewAnnotationKeys(""), logr.Discard())
+	for i := 0; i < maxFirstSeenCached; i++ {
+		tracker.Observe(nil, fmt.Sprintf("drift-%d", i))
+	}
+	// Using the oldest drift keeps it cached.
+	_, ok := tracker.Lookup(nil, "drift-0")
+	require.True(t, ok)
+
+	tracker.Observe(nil, "new")
+	assert.Equal(t, maxFirstSeenCached, tracker.order.Len())
+	_, ok = tracker.Lookup(nil, "drift-0")
+	assert.True(t, ok)
+	_, ok = tracker.Lookup(nil, "drift-1")
+	assert.False(t, ok, "least recently used drift is evicted")
+	_, ok = tracker.Lookup(nil, "new")
+	assert.True(t, ok)
+}
+
+func TestHandle_FreezeLifecycleAware(t *testing.T) {
+	frozen := map[string]string{kausalityv1alpha1.FreezeAnnotation: `{"user":"admin","message":"incident"}`}
+	controllerHash := controller.HashUsername(testController)
+
+	tests := []struct {
+		name           string
+		lifecycleAware bool
+		generation     int64
+		username       string
+		wantAllowed    bool
+	}{
+		{
+			name:        "strict: frozen reconciling parent blocks controller",
+			generation:  2,
+			username:    testController,
+			wantAllowed: false,
+		},
+		{
+			name:           "lifecycle-aware: frozen reconciling parent allows controller",
+			lifecycleAware: true,
+			generation:     2,
+			username:       testController,
+			wantAllowed:    true,
+		},
+		{
+			name:           "lifecycle-aware: frozen reconciling parent blocks other actors",
+			lifecycleAware: true,
+			generation:     2,
+			username:       "alice@example.com",
+			wantAllowed:    false,
+		},
+		{
+			name:           "lifecycle-aware: frozen stable parent blocks controller",
+			lifecycleAware: true,
+			generation:     1,
+			username:       testController,
+			wantAllowed:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parent := stableParent(frozen)
+			parent.Generation = tt.generation
+			h, _ := newFakeHandler(t, Config{
+				DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{
+					DefaultMode:          config.ModeLog,
+					FreezeLifecycleAware: tt.lifecycleAware,
+				}},
+			}, parent)
+
+			old := ownedChild("child", map[string]string{kausalityv1alpha1.UpdatersAnnotation: controllerHash}, map[string]interface{}{"size": int64(1)})
+			updated := ownedChild("child", map[string]string{kausalityv1alpha1.UpdatersAnnotation: controllerHash}, map[string]interface{}{"size": int64(2)})
+			resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, old, updated, tt.username))
+
+			assert.Equal(t, tt.wantAllowed, resp.Allowed, resp.Result)
+		})
+	}
+}
+
+func TestHandle_FreezeUntil(t *testing.T) {
+	tests := []struct {
+		name        string
+		freeze      string
+		wantAllowed bool
+	}{
+		{
+			name:        "expired freeze allows mutations",
+			freeze:      `{"user":"admin","until":"` + time.Now().Add(-time.Minute).UTC().Format(time.RFC3339) + `"}`,
+			wantAllowed: true,
+		},
+		{
+			name:   "future freeze blocks mutations",
+			freeze: `{"user":"admin","until":"` + time.Now().Add(time.Hour).UTC().Format(time.RFC3339) + `"}`,
+		},
+		{
+			name:        "false disables the freeze",
+			freeze:      "false",
+			wantAllowed: true,
+		},
+		{
+			name:   "invalid freeze fails closed",
+			freeze: `{broken`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, _ := newFakeHandler(t, Config{
+				DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{DefaultMode: config.ModeLog}},
+			}, stableParent(map[string]string{kausalityv1alpha1.FreezeAnnotation: tt.freeze}))
+
+			old := ownedChild("child", nil, map[string]interface{}{"size": int64(1)})
+			updated := ownedChild("child", nil, map[string]interface{}{"size": int64(2)})
+			resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, old, updated, "alice@example.com"))
+
+			assert.Equal(t, tt.wantAllowed, resp.Allowed, resp.Result)
+		})
+	}
+}
+
+func TestHandle_FreezeBlocksDelete(t *testing.T) {
+	frozen := map[string]string{kausalityv1alpha1.FreezeAnnotation: `{"user":"admin","message":"incident"}`}
+	h, _ := newFakeHandler(t, Config{
+		DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{DefaultMode: config.ModeLog}},
+	}, stableParent(frozen))
+
+	resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Delete,
+		ownedChild("child", nil, map[string]interface{}{"size": int64(1)}), nil, "alice@example.com"))
+
+	assert.False(t, resp.Allowed, "deleting a child of a frozen parent must be denied")
+	assert.Contains(t, resp.Result.Message, "mutation blocked: parent frozen: incident")
+}
+
+func TestHandle_HealthSignalDowngradesEnforcement(t *testing.T) {
+	healthCM := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kausality-system", Name: "cluster-health"},
+		Data:       map[string]string{"healthy": "true"},
+	}
+	c := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(stableParent(nil), healthCM).Build()
+	h := NewHandler(Config{
+		Client: c,
+		DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{
+			DefaultMode:  config.ModeEnforce,
+			HealthSignal: &config.HealthSignalConfig{CheckInterval: time.Minute},
+		}},
+		HealthSignal: health.NewConfigMapSignal(c, "kausality-system", "cluster-health", ""),
+	})
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	h.health.nowFunc = func() time.Time { return now }
+
+	updaters := map[string]string{kausalityv1alpha1.UpdatersAnnotation: controller.HashUsername(testController)}
+	old := ownedChild("child", updaters, map[string]interface{}{"replicas": int64(1)})
+	updated := ownedChild("child", updaters, map[string]interface{}{"replicas": int64(3)})
+	drift := func() bool {
+		return h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, old, updated, testController)).Allowed
+	}
+	setHealthy := func(value string) {
+		healthCM.Data["healthy"] = value
+		require.NoError(t, c.Update(t.Context(), healthCM))
+	}
+
+	assert.False(t, drift(), "healthy cluster enforces")
+
+	setHealthy("false")
+	assert.False(t, drift(), "signal is not re-read within the check interval")
+
+	now = now.Add(time.Minute)
+	resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, old, updated, testController))
+	assert.True(t, resp.Allowed, "unhealthy cluster downgrades enforce to log")
+	assert.Contains(t, resp.Warnings, "[kausality] enforcement downgraded to log: cluster health signal reports unhealthy")
+	assert.Contains(t, resp.Warnings, "[kausality] drift detected: no approval found for this mutation (would be blocked in enforce mode)")
+
+	setHealthy("true")
+	now = now.Add(time.Minute)
+	assert.False(t, drift(), "enforcement is restored once healthy")
+
+	// A missing ConfigMap counts as healthy
+	require.NoError(t, c.Delete(t.Context(), healthCM))
+	now = now.Add(time.Minute)
+	assert.False(t, drift())
+}
+
+func TestHealthGate_ErrorKeepsLastState(t *testing.T) {
+	signal := &stubSignal{healthy: false}
+	gate := newHealthGate(signal, config.Default(), logr.Discard())
+	now := time.Now()
+	gate.nowFunc = func() time.Time { return now }
+
+	assert.True(t, gate.Degraded(t.Context()))
+
+	signal.err = assert.AnError
+	now = now.Add(time.Hour)
+	assert.True(t, gate.Degraded(t.Context()), "read error keeps the unhealthy state")
+
+	signal.err, signal.healthy = nil, true
+	now = now.Add(time.Hour)
+	assert.False(t, gate.Degraded(t.Context()))
+
+	var disabled *healthGate
+	assert.False(t, disabled.Degraded(t.Context()))
+}
+
+func TestHealthGate_ReadDoesNotBlockOthers(t *testing.T) {
+	signal := &blockingSignal{started: make(chan struct{}), release: make(chan struct{})}
+	gate := newHealthGate(signal, config.Default(), logr.Discard())
+
+	done := make(chan bool)
+	go func() { done <- gate.Degraded(context.Background()) }()
+	<-signal.started
+
+	// The read in flight holds no lock: others get the last state right away
+	assert.False(t, gate.Degraded(t.Context()))
+
+	close(signal.release)
+	assert.True(t, <-done)
+	assert.True(t, gate.Degraded(t.Context()))
+}
+
+// blockingSignal reports unhealthy once released.
+type blockingSignal struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (s *blockingSignal) Healthy(context.Context) (bool, error) {
+	close(s.started)
+	<-s.release
+	return false, nil
+}
+
+// stubSignal is a health signal with a fixed answer.
+type stubSignal struct {
+	healthy bool
+	err     error
+}
+
+func (s *stubSignal) Healthy(context.Context) (bool, error) { return s.healthy, s.err }
+
+func TestHandle_LineageExport(t *testing.T) {
+	events := make(chan lineage.RunEvent, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event lineage.RunEvent
+		if err := json.NewDecoder(r.Body).Decode(&event); err == nil {
+			events <- event
+		}
+	}))
+	defer srv.Close()
+
+	exporter, err := lineage.NewExporter(lineage.ExporterConfig{URL: srv.URL})
+	require.NoError(t, err)
+
+	// The parent is reconciling and records testController as its controller
+	parent := stableParent(map[string]string{kausalityv1alpha1.ControllersAnnotation: controller.HashUsername(testController)})
+	parent.Generation = 2
+	h, _ := newFakeHandler(t, Config{
+		DriftConfig:     &config.Config{DriftDetection: config.DriftDetectionConfig{DefaultMode: config.ModeLog}},
+		LineageExporter: exporter,
+	}, parent)
+
+	next := func(t *testing.T) lineage.RunEvent {
+		t.Helper()
+		select {
+		case event := <-events:
+			return event
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for lineage event")
+			return lineage.RunEvent{}
+		}
+	}
+	assertNone := func(t *testing.T) {
+		t.Helper()
+		select {
+		case event := <-events:
+			t.Fatalf("unexpected lineage event for %s", event.Outputs[0].Name)
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+
+	updaters := map[string]string{kausalityv1alpha1.UpdatersAnnotation: controller.HashUsername(testController)}
+
+	t.Run("controller create is exported", func(t *testing.T) {
+		h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Create, nil, ownedChild("child", nil, map[string]interface{}{"size": int64(1)}), testController))
+
+		event := next(t)
+		assert.Equal(t, testController, event.Job.Name)
+		assert.Equal(t, "example.com/v1/Widget/default/child", event.Outputs[0].Name)
+		assert.Equal(t, lineage.LifecycleCreate, event.Outputs[0].Facets.LifecycleStateChange.LifecycleStateChange)
+		assert.Equal(t, []lineage.Dataset{{Namespace: "kubernetes", Name: "apps/v1/Deployment/default/parent"}}, event.Inputs)
+		assert.NotNil(t, event.Run.Facets.Parent)
+	})
+
+	t.Run("controller update is not exported", func(t *testing.T) {
+		old := ownedChild("child", updaters, map[string]interface{}{"size": int64(1)})
+		updated := ownedChild("child", updaters, map[string]interface{}{"size": int64(2)})
+		h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, old, updated, testController))
+		assertNone(t)
+	})
+
+	t.Run("origin update is exported", func(t *testing.T) {
+		old := ownedChild("child", updaters, map[string]interface{}{"size": int64(1)})
+		updated := ownedChild("child", updaters, map[string]interface{}{"size": int64(2)})
+		h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, old, updated, "alice@example.com"))
+
+		event := next(t)
+		assert.Equal(t, "alice@example.com", event.Job.Name)
+		assert.Equal(t, lineage.LifecycleAlter, event.Outputs[0].Facets.LifecycleStateChange.LifecycleStateChange)
+		assert.Nil(t, event.Run.Facets.Parent)
+		assert.Empty(t, event.Inputs)
+	})
+
+	t.Run("dry-run is not exported", func(t *testing.T) {
+		req := newAdmissionRequest(t, admissionv1.Create, nil, ownedChild("other", nil, map[string]interface{}{"size": int64(1)}), testController)
+		req.DryRun = ptr.To(true)
+		h.Handle(t.Context(), req)
+		assertNone(t)
+	})
+}
+
+func TestHandle_LockdownAudit(t *testing.T) {
+	logMode := &config.Config{DriftDetection: config.DriftDetectionConfig{DefaultMode: config.ModeLog}}
+
+	freeze, err := approval.MarshalFreeze(&approval.Freeze{User: "oncall", Message: "incident INC-42", At: metav1.Now()})
+	require.NoError(t, err)
+	expiry := metav1.NewTime(time.Now().Add(time.Hour).UTC().Truncate(time.Second))
+	snooze, err := approval.MarshalSnooze(&approval.Snooze{Expiry: expiry, User: "oncall", Message: "known noise"})
+	require.NoError(t, err)
+
+	t.Run("adding a freeze is reported", func(t *testing.T) {
+		sender := &ktesting.FakeSender{}
+		h, _ := newFakeHandler(t, Config{CallbackSender: sender, DriftConfig: logMode})
+
+		old := stableParent(nil)
+		frozen := stableParent(map[string]string{approval.FreezeAnnotation: freeze})
+		resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, old, frozen, "alice"))
+		require.True(t, resp.Allowed)
+
+		reports := sender.Sent()
+		require.Len(t, reports, 1)
+		assert.Equal(t, v1alpha1.DriftReportPhaseFreezeApplied, reports[0].Spec.Phase)
+		assert.Equal(t, "alice", reports[0].Spec.Request.User)
+		assert.Equal(t, "Deployment", reports[0].Spec.Parent.Kind)
+		assert.Equal(t, testParentName, reports[0].Spec.Parent.Name)
+		assert.Equal(t, &v1alpha1.Lockdown{User: "oncall", Message: "incident INC-42"}, reports[0].Spec.Lockdown)
+	})
+
+	t.Run("adding a snooze is reported", func(t *testing.T) {
+		sender := &ktesting.FakeSender{}
+		h, _ := newFakeHandler(t, Config{CallbackSender: sender, DriftConfig: logMode})
+
+		old := stableParent(nil)
+		snoozed := stableParent(map[string]string{approval.SnoozeAnnotation: snooze})
+		require.True(t, h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, old, snoozed, "alice")).Allowed)
+
+		reports := sender.Sent()
+		require.Len(t, reports, 1)
+		assert.Equal(t, v1alpha1.DriftReportPhaseSnoozeApplied, reports[0].Spec.Phase)
+		require.NotNil(t, reports[0].Spec.Lockdown)
+		assert.Equal(t, "known noise", reports[0].Spec.Lockdown.Message)
+		assert.True(t, expiry.Equal(reports[0].Spec.Lockdown.Expiry))
+	})
+
+	t.Run("unchanged and removed lockdowns are not reported", func(t *testing.T) {
+		sender := &ktesting.FakeSender{}
+		h, _ := newFakeHandler(t, Config{CallbackSender: sender, DriftConfig: logMode})
+
+		frozen := stableParent(map[string]string{approval.FreezeAnnotation: freeze})
+		require.True(t, h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, frozen, frozen, "alice")).Allowed)
+		require.True(t, h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, frozen, stableParent(nil), "alice")).Allowed)
+		disabled := stableParent(map[string]string{approval.FreezeAnnotation: "false"})
+		require.True(t, h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, stableParent(nil), disabled, "alice")).Allowed)
+		assert.Empty(t, sender.Sent())
+	})
+
+	t.Run("reverted changes are not reported", func(t *testing.T) {
+		sender := &ktesting.FakeSender{}
+		h, _ := newFakeHandler(t, Config{CallbackSender: sender, DriftConfig: logMode})
+
+		disabled := stableParent(map[string]string{approval.FreezeAnnotation: "false"})
+		frozen := stableParent(map[string]string{approval.FreezeAnnotation: freeze})
+		resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, disabled, frozen, "alice"))
+		require.True(t, resp.Allowed)
+		assert.Equal(t, "false", patchedAnnotations(resp)[approval.FreezeAnnotation])
+		assert.Empty(t, sender.Sent())
+	})
+
+	t.Run("denied and dry-run updates are not reported", func(t *testing.T) {
+		sender := &ktesting.FakeSender{}
+		h, _ := newFakeHandler(t, Config{CallbackSender: sender, DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{
+			DefaultMode:       config.ModeEnforce,
+			AnnotationWriters: []string{"approval-tool"},
+		}}})
+
+		old := stableParent(nil)
+		frozen := stableParent(map[string]string{approval.FreezeAnnotation: freeze})
+		require.False(t, h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, old, frozen, "alice")).Allowed)
+
+		h, _ = newFakeHandler(t, Config{CallbackSender: sender, DriftConfig: logMode})
+		req := newAdmissionRequest(t, admissionv1.Update, old, frozen, "alice")
+		req.DryRun = ptr.To(true)
+		require.True(t, h.Handle(t.Context(), req).Allowed)
+		assert.Empty(t, sender.Sent())
+	})
+}
+
+func TestHandle_ChildScopedSnooze(t *testing.T) {
+	handle := func(t *testing.T, snooze *approval.Snooze, childName string) []*v1alpha1.DriftReport {
+		t.Helper()
+		value, err := approval.MarshalSnooze(snooze)
+		require.NoError(t, err)
+		sender := &ktesting.FakeSender{}
+		h, _ := newFakeHandler(t, Config{
+			DriftConfig:    &config.Config{DriftDetection: config.DriftDetectionConfig{DefaultMode: config.ModeLog}},
+			CallbackSender: sender,
+		}, stableParent(map[string]string{approval.SnoozeAnnotation: value}))
+
+		updaters := map[string]string{kausalityv1alpha1.UpdatersAnnotation: controller.HashUsername(testController)}
+		old := ownedChild(childName, updaters, map[string]interface{}{"size": int64(1)})
+		changed := ownedChild(childName, updaters, map[string]interface{}{"size": int64(2)})
+		resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, old, changed, testController))
+		require.True(t, resp.Allowed)
+		return sender.Sent()
+	}
+	expiry := metav1.NewTime(time.Now().Add(time.Hour))
+
+	t.Run("matching child is suppressed", func(t *testing.T) {
+		snooze := &approval.Snooze{Expiry: expiry, Child: &approval.ChildRef{APIVersion: "example.com/v1", Kind: "Widget", Name: "flapping"}}
+		assert.Empty(t, handle(t, snooze, "flapping"))
+	})
+
+	t.Run("other child is delivered", func(t *testing.T) {
+		snooze := &approval.Snooze{Expiry: expiry, Child: &approval.ChildRef{APIVersion: "example.com/v1", Kind: "Widget", Name: "flapping"}}
+		reports := handle(t, snooze, "healthy")
+		require.Len(t, reports, 1)
+		assert.Equal(t, v1alpha1.DriftReportPhaseDetected, reports[0].Spec.Phase)
+		assert.Equal(t, "healthy", reports[0].Spec.Child.Name)
+	})
+
+	t.Run("list of children", func(t *testing.T) {
+		snooze := &approval.Snooze{Expiry: expiry, Children: []approval.ChildRef{
+			{APIVersion: "example.com/v1", Kind: "Widget", Name: "a"},
+			{APIVersion: "*", Kind: "Widget", Name: "flapping"},
+		}}
+		assert.Empty(t, handle(t, snooze, "flapping"))
+		assert.Len(t, handle(t, snooze, "healthy"), 1)
+	})
+
+	t.Run("unscoped snooze suppresses all children", func(t *testing.T) {
+		assert.Empty(t, handle(t, &approval.Snooze{Expiry: expiry}, "healthy"))
+	})
+}
+
+func TestHandle_DriftMetrics(t *testing.T) {
+	updaters := map[string]string{kausalityv1alpha1.UpdatersAnnotation: controller.HashUsername(testController)}
+	old := ownedChild("child", updaters, map[string]interface{}{"size": int64(1)})
+	updated := ownedChild("child", updaters, map[string]interface{}{"size": int64(2)})
+
+	counts := func(mode string) (detected, allowed, denied float64) {
+		return testutil.ToFloat64(metrics.DriftDetected.WithLabelValues("example.com", "Widget", mode, "Initialized")),
+			testutil.ToFloat64(metrics.DriftAllowed.WithLabelValues("example.com", "Widget", mode, "Initialized")),
+			testutil.ToFloat64(metrics.DriftDenied.WithLabelValues("example.com", "Widget", mode, "Initialized"))
+	}
+
+	t.Run("drift in log mode is allowed", func(t *testing.T) {
+		h, _ := newFakeHandler(t, Config{DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{DefaultMode: config.ModeLog}}}, stableParent(nil))
+
+		detected, allowed, denied := counts(config.ModeLog)
+		require.True(t, h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, old, updated, testController)).Allowed)
+		d, a, n := counts(config.ModeLog)
+		assert.Equal(t, []float64{detected + 1, allowed + 1, denied}, []float64{d, a, n})
+	})
+
+	t.Run("drift in enforce mode is denied", func(t *testing.T) {
+		h, _ := newFakeHandler(t, Config{DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{DefaultMode: config.ModeEnforce}}}, stableParent(nil))
+
+		detected, allowed, denied := counts(config.ModeEnforce)
+		require.False(t, h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, old, updated, testController)).Allowed)
+		d, a, n := counts(config.ModeEnforce)
+		assert.Equal(t, []float64{detected + 1, allowed, denied + 1}, []float64{d, a, n})
+	})
+
+	t.Run("frozen parent blocks", func(t *testing.T) {
+		freeze, err := approval.MarshalFreeze(&approval.Freeze{User: "oncall", Message: "incident", At: metav1.Now()})
+		require.NoError(t, err)
+		h, _ := newFakeHandler(t, Config{DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{DefaultMode: config.ModeLog}}},
+			stableParent(map[string]string{approval.FreezeAnnotation: freeze}))
+
+		blocked := metrics.FreezeBlocked.WithLabelValues("example.com", "Widget", "", "Initialized")
+		before := testutil.ToFloat64(blocked)
+		require.False(t, h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, old, updated, testController)).Allowed)
+		assert.Equal(t, before+1, testutil.ToFloat64(blocked))
+	})
+
+	t.Run("latency is observed", func(t *testing.T) {
+		h, _ := newFakeHandler(t, Config{})
+		h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Connect, nil, stableParent(nil), "alice"))
+		assert.Positive(t, testutil.CollectAndCount(metrics.AdmissionDuration, "kausality_admission_duration_seconds"))
+	})
+}
+
+func TestHandle_MinObjectAge(t *testing.T) {
+	tests := []struct {
+		name         string
+		minObjectAge time.Duration
+		age          time.Duration
+		wantAllowed  bool
+	}{
+		{
+			name:         "freshly created child is exempt",
+			minObjectAge: time.Minute,
+			age:          5 * time.Second,
+			wantAllowed:  true,
+		},
+		{
+			name:         "aged child drifts",
+			minObjectAge: time.Minute,
+			age:          time.Hour,
+		},
+		{
+			name: "disabled by default",
+			age:  5 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, _ := newFakeHandler(t, Config{DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{
+				DefaultMode:  config.ModeEnforce,
+				MinObjectAge: tt.minObjectAge,
+			}}}, stableParent(nil))
+
+			// The writer is the only updater of the child, so it is taken for the controller
+			updaters := map[string]string{kausalityv1alpha1.UpdatersAnnotation: controller.HashUsername(testController)}
+			created := metav1.NewTime(time.Now().Add(-tt.age))
+			old := ownedChild("child", updaters, map[string]interface{}{"size": int64(1)})
+			old.SetCreationTimestamp(created)
+			changed := ownedChild("child", updaters, map[string]interface{}{"size": int64(2)})
+			changed.SetCreationTimestamp(created)
+
+			resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, old, changed, testController))
+			require.Equal(t, tt.wantAllowed, resp.Allowed, resp.Result)
+			if tt.wantAllowed {
+				// Exempt changes are still traced
+				assert.NotEmpty(t, patchedAnnotations(resp)[trace.TraceAnnotation])
+			}
+		})
+	}
+}
+
+func TestHandle_PropagateModeToChildren(t *testing.T) {
+	enforce := map[string]string{config.ModeAnnotation: config.ModeEnforce}
+	log := map[string]string{config.ModeAnnotation: config.ModeLog}
+
+	tests := []struct {
+		name        string
+		propagate   bool
+		defaultMode string
+		parentAnns  map[string]string
+		childAnns   map[string]string
+		want        string
+	}{
+		{name: "child of an enforce parent inherits enforce", propagate: true, defaultMode: config.ModeLog, parentAnns: enforce, want: config.ModeEnforce},
+		{name: "parent enforced by default", propagate: true, defaultMode: config.ModeEnforce, want: config.ModeEnforce},
+		{name: "explicit child mode is kept", propagate: true, defaultMode: config.ModeLog, parentAnns: enforce, childAnns: log},
+		{name: "log mode is not propagated", propagate: true, defaultMode: config.ModeEnforce, parentAnns: log},
+		{name: "disabled", defaultMode: config.ModeLog, parentAnns: enforce},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// A reconciling parent, so that the controller's CREATE is allowed in enforce mode
+			parent := stableParent(tt.parentAnns)
+			parent.Generation = 2
+			h, _ := newFakeHandler(t, Config{
+				DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{
+					DefaultMode:             tt.defaultMode,
+					PropagateModeToChildren: tt.propagate,
+				}},
+			}, parent)
+
+			child := ownedChild("child", tt.childAnns, nil)
+			resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Create, nil, child, testController))
+			require.True(t, resp.Allowed)
+			mode, ok := patchedAnnotations(resp)[config.ModeAnnotation]
+			if tt.want == "" {
+				assert.False(t, ok, "mode patched to %q", mode)
+			} else {
+				assert.Equal(t, tt.want, mode)
+			}
+		})
+	}
+}
+
+func TestHandle_WarnAndDryRunModes(t *testing.T) {
+	const rejections = `[{"apiVersion":"example.com/v1","kind":"Widget","name":"child","reason":"hands off"}]`
+
+	tests := []struct {
+		name        string
+		mode        string
+		parentAnns  map[string]string
+		cleared     bool
+		wantAllowed bool
+		wantWarning string
+	}{
+		{
+			name:        "enforce denies drift",
+			mode:        config.ModeEnforce,
+			wantAllowed: false,
+		},
+		{
+			name:        "warn allows drift with a warning",
+			mode:        config.ModeWarn,
+			wantAllowed: true,
+			wantWarning: "[kausality] drift detected: no approval found for this mutation (not blocked in warn mode)",
+		},
+		{
+			name:        "warn allows rejected drift with a warning",
+			mode:        config.ModeWarn,
+			parentAnns:  map[string]string{kausalityv1alpha1.RejectionsAnnotation: rejections},
+			wantAllowed: true,
+			wantWarning: "[kausality] drift rejected: hands off (not blocked in warn mode)",
+		},
+		{
+			name:        "warn allows cleared fields denied in log mode",
+			mode:        config.ModeWarn,
+			cleared:     true,
+			wantAllowed: true,
+			wantWarning: "controller cleared user-set field spec.color",
+		},
+		{
+			name:        "log denies cleared fields",
+			mode:        config.ModeLog,
+			cleared:     true,
+			wantAllowed: false,
+		},
+		{
+			name:        "dryrun tags warnings",
+			mode:        config.ModeDryRun,
+			wantAllowed: true,
+			wantWarning: "[kausality] [dry-run] drift detected: no approval found for this mutation (would be blocked in enforce mode)",
+		},
+		{
+			name:        "dryrun tags rejections",
+			mode:        config.ModeDryRun,
+			parentAnns:  map[string]string{kausalityv1alpha1.RejectionsAnnotation: rejections},
+			wantAllowed: true,
+			wantWarning: "[kausality] [dry-run] drift rejected: hands off (would be blocked in enforce mode)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The mode is set per object, over an enforce default
+			anns := map[string]string{
+				kausalityv1alpha1.UpdatersAnnotation: controller.HashUsername(testController),
+				config.ModeAnnotation:                tt.mode,
+			}
+			old := ownedChild("child", anns, map[string]interface{}{"size": int64(1), "color": "blue"})
+			changed := ownedChild("child", anns, map[string]interface{}{"size": int64(2), "color": "blue"})
+			if tt.cleared {
+				changed = ownedChild("child", anns, map[string]interface{}{"size": int64(1), "color": ""})
+			}
+			h, _ := newFakeHandler(t, Config{
+				DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{
+					DefaultMode:         config.ModeEnforce,
+					OnClearedUserFields: config.ClearedFieldsDeny,
+				}},
+			}, stableParent(tt.parentAnns))
+
+			resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, old, changed, testController))
+			require.Equal(t, tt.wantAllowed, resp.Allowed, resp.Result)
+			if tt.wantWarning != "" {
+				require.Len(t, resp.Warnings, 1)
+				assert.Contains(t, resp.Warnings[0], tt.wantWarning)
+			}
+		})
+	}
+}
+
+func TestHandle_NamespaceMetadataUnavailable(t *testing.T) {
+	// The webhook may not read namespaces, as in a locked-down cluster without namespace RBAC
+	forbidden := interceptor.Funcs{
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			if u, ok := obj.(*unstructured.Unstructured); ok && u.GetKind() == "Namespace" {
+				return apierrors.NewForbidden(corev1.Resource("namespaces"), key.Name, nil)
+			}
+			return c.Get(ctx, key, obj, opts...)
+		},
+	}
+
+	updaters := map[string]string{kausalityv1alpha1.UpdatersAnnotation: controller.HashUsername(testController)}
+	old := ownedChild("child", updaters, map[string]interface{}{"size": int64(1)})
+	updated := ownedChild("child", updaters, map[string]interface{}{"size": int64(2)})
+	failures := metrics.NamespaceReadFailures.WithLabelValues("forbidden")
+
+	handle := func(t *testing.T, decision string) admission.Response {
+		c := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(stableParent(nil)).WithInterceptorFuncs(forbidden).Build()
+		h := NewHandler(Config{
+			Client: c,
+			Log:    logr.Discard(),
+			DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{
+				DefaultMode:                    config.ModeLog,
+				OnNamespaceMetadataUnavailable: decision,
+			}},
+		})
+		return h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, old, updated, testController))
+	}
+
+	t.Run("ignore selectors by default", func(t *testing.T) {
+		before := testutil.ToFloat64(failures)
+		resp := handle(t, "")
+		assert.True(t, resp.Allowed)
+		assert.Contains(t, resp.Warnings, "[kausality] drift detected: no approval found for this mutation (would be blocked in enforce mode)")
+		assert.Equal(t, before+1, testutil.ToFloat64(failures))
+	})
+
+	t.Run("allow with warning", func(t *testing.T) {
+		resp := handle(t, config.NamespaceMetadataAllowWithWarning)
+		assert.True(t, resp.Allowed)
+		assert.Equal(t, []string{"[kausality] namespace " + testNamespace + " metadata unavailable (forbidden); drift not evaluated"}, resp.Warnings)
+	})
+
+	t.Run("deny", func(t *testing.T) {
+		resp := handle(t, config.NamespaceMetadataDeny)
+		assert.False(t, resp.Allowed)
+		assert.Equal(t, int32(http.StatusInternalServerError), resp.Result.Code)
+	})
+}
+
+func TestHandle_SSAOwnershipConflict(t *testing.T) {
+	entry := func(manager, fields string) metav1.ManagedFieldsEntry {
+		return metav1.ManagedFieldsEntry{
+			Manager:    manager,
+			Operation:  metav1.ManagedFieldsOperationApply,
+			FieldsType: "FieldsV1",
+			FieldsV1:   &metav1.FieldsV1{Raw: []byte(fields)},
+		}
+	}
+
+	// Two updaters and no parent controllers: the controller identity is unknown
+	updaters := map[string]string{kausalityv1alpha1.UpdatersAnnotation: controller.HashUsername("helm-user") + "," + controller.HashUsername(testController)}
+	old := ownedChild("child", updaters, map[string]interface{}{"size": int64(1), "color": "blue"})
+	old.SetManagedFields([]metav1.ManagedFieldsEntry{
+		entry("helm", `{"f:spec":{"f:size":{}}}`),
+		entry("operator", `{"f:spec":{"f:color":{}}}`),
+	})
+	updated := ownedChild("child", updaters, map[string]interface{}{"size": int64(3), "color": "blue"})
+	updated.SetManagedFields([]metav1.ManagedFieldsEntry{
+		entry("operator", `{"f:spec":{"f:size":{},"f:color":{}}}`),
+	})
+
+	handle := func(t *testing.T, fieldManager string) admission.Response {
+		h, _ := newFakeHandler(t, Config{DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{DefaultMode: config.ModeEnforce}}}, stableParent(nil))
+		req := newAdmissionRequest(t, admissionv1.Update, old, updated, testController)
+		req.Options = runtime.RawExtension{Raw: []byte(`{"fieldManager":"` + fieldManager + `","force":true}`)}
+		return h.Handle(t.Context(), req)
+	}
+
+	t.Run("established manager taking a field is drift", func(t *testing.T) {
+		resp := handle(t, "operator")
+		assert.False(t, resp.Allowed)
+		assert.Equal(t, "drift detected: no approval found for this mutation; field ownership taken over: spec.size (from helm)", resp.Result.Message)
+	})
+
+	t.Run("manager without prior spec fields is not classified", func(t *testing.T) {
+		assert.True(t, handle(t, "kubectl").Allowed)
+	})
+}
+
+func TestHandle_SSAOwnershipTakeoverWithoutChange(t *testing.T) {
+	entry := func(manager, fields string) metav1.ManagedFieldsEntry {
+		return metav1.ManagedFieldsEntry{
+			Manager:    manager,
+			Operation:  metav1.ManagedFieldsOperationApply,
+			FieldsType: "FieldsV1",
+			FieldsV1:   &metav1.FieldsV1{Raw: []byte(fields)},
+		}
+	}
+
+	// The operator takes size over from helm, keeping its value
+	updaters := map[string]string{kausalityv1alpha1.UpdatersAnnotation: controller.HashUsername("helm-user") + "," + controller.HashUsername(testController)}
+	old := ownedChild("child", updaters, map[string]interface{}{"size": int64(1), "color": "blue"})
+	old.SetManagedFields([]metav1.ManagedFieldsEntry{
+		entry("helm", `{"f:spec":{"f:size":{}}}`),
+		entry("operator", `{"f:spec":{"f:color":{}}}`),
+	})
+	updated := ownedChild("child", updaters, map[string]interface{}{"size": int64(1), "color": "blue"})
+	updated.SetManagedFields([]metav1.ManagedFieldsEntry{
+		entry("operator", `{"f:spec":{"f:size":{},"f:color":{}}}`),
+	})
+	shared := ownedChild("child", updaters, map[string]interface{}{"size": int64(1), "color": "blue"})
+	shared.SetManagedFields([]metav1.ManagedFieldsEntry{
+		entry("helm", `{"f:spec":{"f:size":{}}}`),
+		entry("operator", `{"f:spec":{"f:size":{},"f:color":{}}}`),
+	})
+
+	handle := func(t *testing.T, updated runtime.Object) admission.Response {
+		h, _ := newFakeHandler(t, Config{DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{DefaultMode: config.ModeEnforce}}}, stableParent(nil))
+		req := newAdmissionRequest(t, admissionv1.Update, old, updated, testController)
+		req.Options = runtime.RawExtension{Raw: []byte(`{"kind":"PatchOptions","apiVersion":"meta.k8s.io/v1","fieldManager":"operator","force":true}`)}
+		return h.Handle(t.Context(), req)
+	}
+
+	t.Run("takeover is drift", func(t *testing.T) {
+		resp := handle(t, updated)
+		assert.False(t, resp.Allowed)
+		assert.Equal(t, "drift detected: no approval found for this mutation; field ownership taken over: spec.size (from helm)", resp.Result.Message)
+	})
+
+	t.Run("shared ownership is no spec change", func(t *testing.T) {
+		resp := handle(t, shared)
+		assert.True(t, resp.Allowed)
+		assert.Empty(t, resp.Warnings)
+	})
+}
+
+func TestHandle_OwnWrites(t *testing.T) {
+	updaters := map[string]string{kausalityv1alpha1.UpdatersAnnotation: controller.HashUsername(testController)}
+	enforce := &config.Config{DriftDetection: config.DriftDetectionConfig{DefaultMode: config.ModeEnforce}}
+
+	withFieldManager := func(req admission.Request, manager string) admission.Request {
+		req.Options = runtime.RawExtension{Raw: []byte(`{"fieldManager":"` + manager + `"}`)}
+		return req
+	}
+
+	t.Run("consumed approval is written with kausality's field manager", func(t *testing.T) {
+		var managers []string
+		c := fake.NewClientBuilder().WithScheme(testScheme).
+			WithObjects(stableParent(map[string]string{
+				kausalityv1alpha1.ApprovalsAnnotation: `[{"apiVersion":"example.com/v1","kind":"Widget","name":"child","mode":"once","generation":1}]`,
+			})).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+					o := &client.UpdateOptions{}
+					o.ApplyOptions(opts)
+					managers = append(managers, o.FieldManager)
+					return c.Update(ctx, obj, opts...)
+				},
+			}).Build()
+		h := NewHandler(Config{Client: c, Log: logr.Discard(), DriftConfig: enforce})
+
+		old := ownedChild("child", updaters, map[string]interface{}{"size": int64(1)})
+		updated := ownedChild("child", updaters, map[string]interface{}{"size": int64(2)})
+		resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, old, updated, testController))
+		require.True(t, resp.Allowed)
+		assert.Equal(t, []string{DefaultFieldManager}, managers)
+	})
+
+	// The pruned parent as written back by consumeApproval
+	pruneRequest := func(t *testing.T, manager string) admission.Request {
+		old := stableParent(map[string]string{
+			kausalityv1alpha1.ApprovalsAnnotation: `[{"apiVersion":"example.com/v1","kind":"Widget","name":"child","mode":"once","generation":1}]`,
+		})
+		pruned := stableParent(nil)
+		req := newAdmissionRequest(t, admissionv1.Update, old, pruned, "system:serviceaccount:kausality-system:kausality")
+		return withFieldManager(req, manager)
+	}
+
+	t.Run("own annotation writes are admitted as is", func(t *testing.T) {
+		sender := &ktesting.FakeSender{}
+		h, _ := newFakeHandler(t, Config{CallbackSender: sender, DriftConfig: enforce})
+
+		resp := h.Handle(t.Context(), pruneRequest(t, DefaultFieldManager))
+		require.True(t, resp.Allowed)
+		assert.Empty(t, resp.Patches)
+		assert.Empty(t, resp.Warnings)
+		assert.Empty(t, sender.Sent())
+	})
+
+	t.Run("other field managers cannot remove kausality annotations", func(t *testing.T) {
+		h, _ := newFakeHandler(t, Config{DriftConfig: enforce})
+
+		resp := h.Handle(t.Context(), pruneRequest(t, "kubectl-edit"))
+		require.True(t, resp.Allowed)
+		assert.Contains(t, patchedAnnotations(resp), kausalityv1alpha1.ApprovalsAnnotation)
+	})
+
+	t.Run("configured field manager", func(t *testing.T) {
+		h, _ := newFakeHandler(t, Config{DriftConfig: enforce, FieldManager: "kausality-webhook"})
+
+		resp := h.Handle(t.Context(), pruneRequest(t, "kausality-webhook"))
+		require.True(t, resp.Allowed)
+		assert.Empty(t, resp.Patches)
+
+		resp = h.Handle(t.Context(), pruneRequest(t, DefaultFieldManager))
+		assert.NotEmpty(t, resp.Patches)
+	})
+
+	t.Run("own spec changes are still checked for drift", func(t *testing.T) {
+		h, _ := newFakeHandler(t, Config{DriftConfig: enforce}, stableParent(nil))
+
+		old := ownedChild("child", updaters, map[string]interface{}{"size": int64(1)})
+		updated := ownedChild("child", updaters, map[string]interface{}{"size": int64(2)})
+		req := withFieldManager(newAdmissionRequest(t, admissionv1.Update, old, updated, testController), DefaultFieldManager)
+		resp := h.Handle(t.Context(), req)
+		assert.False(t, resp.Allowed)
+	})
+
+	t.Run("own status writes do not record a controller", func(t *testing.T) {
+		h, c := newFakeHandler(t, Config{DriftConfig: enforce}, stableParent(nil))
+
+		old := stableParent(nil)
+		updated := stableParent(nil)
+		updated.Status.Replicas = 1
+		req := withFieldManager(newAdmissionRequest(t, admissionv1.Update, old, updated, "kausality"), DefaultFieldManager)
+		req.SubResource = "status"
+		resp := h.Handle(t.Context(), req)
+		require.True(t, resp.Allowed)
+		assert.Empty(t, resp.Patches)
+
+		parent := &metav1.PartialObjectMetadata{}
+		parent.SetGroupVersionKind(old.GroupVersionKind())
+		require.NoError(t, c.Get(t.Context(), client.ObjectKeyFromObject(old), parent))
+		assert.NotContains(t, parent.GetAnnotations(), controller.ControllersAnnotation)
+	})
+}
+
+// newCountingHandler returns a handler whose client counts parent reads and sleeps latency per read.
+// The parent is reconciling and records testController as its controller, so creates are allowed.
+func newCountingHandler(tb testing.TB, ttl, latency time.Duration, parentReads *atomic.Int64) (*Handler, client.Client) {
+	parent := stableParent(map[string]string{kausalityv1alpha1.ControllersAnnotation: controller.HashUsername(testController)})
+	parent.Generation = 2
+	c := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(parent).WithInterceptorFuncs(interceptor.Funcs{
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			if key.Name == testParentName {
+				parentReads.Add(1)
+			}
+			time.Sleep(latency)
+			return c.Get(ctx, key, obj, opts...)
+		},
+	}).Build()
+	h := NewHandler(Config{
+		Client:         c,
+		Log:            logr.Discard(),
+		DriftConfig:    &config.Config{DriftDetection: config.DriftDetectionConfig{DefaultMode: config.ModeEnforce}},
+		CreateCacheTTL: ttl,
+	})
+	return h, c
+}
+
+func TestHandle_CreateCache(t *testing.T) {
+	create := func(t *testing.T, h *Handler, i int) bool {
+		child := ownedChild(fmt.Sprintf("pod-%d", i), nil, map[string]interface{}{"size": int64(1)})
+		return h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Create, nil, child, testController)).Allowed
+	}
+
+	t.Run("burst of sibling creates reads the parent once", func(t *testing.T) {
+		var uncached, cached atomic.Int64
+		h, _ := newCountingHandler(t, 0, 0, &uncached)
+		hc, _ := newCountingHandler(t, time.Minute, 0, &cached)
+		for i := 0; i < 10; i++ {
+			assert.Equal(t, create(t, h, i), create(t, hc, i), "decisions are unchanged")
+		}
+		assert.GreaterOrEqual(t, uncached.Load(), int64(10))
+		assert.Equal(t, int64(1), cached.Load())
+	})
+
+	t.Run("admitted parent change invalidates", func(t *testing.T) {
+		var reads atomic.Int64
+		h, c := newCountingHandler(t, time.Minute, 0, &reads)
+		require.True(t, create(t, h, 0))
+
+		// Freeze the parent; the webhook admits the parent update
+		parent := &appsv1.Deployment{}
+		require.NoError(t, c.Get(t.Context(), client.ObjectKey{Namespace: testNamespace, Name: testParentName}, parent))
+		frozen := parent.DeepCopy()
+		frozen.Annotations[kausalityv1alpha1.FreezeAnnotation] = `{"user":"admin"}`
+		h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, parent, frozen, "admin"))
+		require.NoError(t, c.Update(t.Context(), frozen))
+
+		assert.False(t, create(t, h, 1), "frozen parent is read again")
+	})
+
+	t.Run("fresh parent read at a newer resourceVersion invalidates", func(t *testing.T) {
+		var reads atomic.Int64
+		h, c := newCountingHandler(t, time.Minute, 0, &reads)
+		require.True(t, create(t, h, 0))
+
+		// The parent changes without passing the webhook
+		parent := &appsv1.Deployment{}
+		require.NoError(t, c.Get(t.Context(), client.ObjectKey{Namespace: testNamespace, Name: testParentName}, parent))
+		parent.Annotations[kausalityv1alpha1.FreezeAnnotation] = `{"user":"admin"}`
+		require.NoError(t, c.Update(t.Context(), parent))
+
+		// An UPDATE of a sibling reads the parent uncached
+		old := ownedChild("pod-0", nil, map[string]interface{}{"size": int64(1)})
+		updated := ownedChild("pod-0", nil, map[string]interface{}{"size": int64(2)})
+		h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, old, updated, testController))
+
+		assert.False(t, create(t, h, 1))
+	})
+
+	t.Run("entries expire after the TTL", func(t *testing.T) {
+		var reads atomic.Int64
+		h, _ := newCountingHandler(t, time.Minute, 0, &reads)
+		now := time.Now()
+		h.parentCache.nowFunc = func() time.Time { return now }
+
+		require.True(t, create(t, h, 0))
+		require.True(t, create(t, h, 1))
+		assert.Equal(t, int64(1), reads.Load())
+
+		now = now.Add(time.Minute)
+		require.True(t, create(t, h, 2))
+		assert.Equal(t, int64(2), reads.Load())
+	})
+}
+
+func BenchmarkHandle_CreateBurst(b *testing.B) {
+	const latency = time.Millisecond
+	const siblings = 20
+
+	for _, ttl := range []time.Duration{0, time.Minute} {
+		name := "uncached"
+		if ttl > 0 {
+			name = "cached"
+		}
+		b.Run(name, func(b *testing.B) {
+			var reads atomic.Int64
+			h, _ := newCountingHandler(b, ttl, latency, &reads)
+			reqs := make([]admission.Request, siblings)
+			for i := range reqs {
+				child := ownedChild(fmt.Sprintf("pod-%d", i), nil, map[string]interface{}{"size": int64(1)})
+				reqs[i] = newAdmissionRequest(b, admissionv1.Create, nil, child, testController)
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if ttl > 0 {
+					h.parentCache.invalidate(stableParent(nil).UID, "")
+				}
+				for _, req := range reqs {
+					if resp := h.Handle(context.Background(), req); !resp.Allowed {
+						b.Fatalf("request denied: %v", resp.Result)
+					}
+				}
+			}
+			b.ReportMetric(float64(reads.Load())/float64(b.N), "parent-reads/burst")
+		})
+	}
+}
+
+func TestHandle_LabelReferencedParent(t *testing.T) {
+	const driftWarning = "[kausality] drift detected: no approval found for this mutation (would be blocked in enforce mode)"
+
+	labeledChild := func(size int64) *unstructured.Unstructured {
+		child := ownedChild("child", map[string]string{kausalityv1alpha1.UpdatersAnnotation: controller.HashUsername(testController)}, map[string]interface{}{"size": size})
+		child.SetOwnerReferences(nil)
+		child.SetLabels(map[string]string{"example.com/deployment": testParentName})
+		return child
+	}
+	old, updated := labeledChild(1), labeledChild(2)
+
+	t.Run("controller change on a stable parent is drift", func(t *testing.T) {
+		sender := &ktesting.FakeSender{}
+		h, _ := newFakeHandler(t, Config{CallbackSender: sender, DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{
+			DefaultMode: config.ModeLog,
+			ParentReferences: []config.ParentReference{
+				{APIGroup: "example.com", Kind: "Widget", ParentAPIVersion: "apps/v1", ParentKind: "Deployment", Label: "example.com/deployment"},
+			},
+		}}}, stableParent(nil))
+
+		resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, old, updated, testController))
+		require.True(t, resp.Allowed)
+		assert.Contains(t, resp.Warnings, driftWarning)
+
+		reports := sender.Sent()
+		require.Len(t, reports, 1)
+		assert.Equal(t, "Deployment", reports[0].Spec.Parent.Kind)
+		assert.Equal(t, testParentName, reports[0].Spec.Parent.Name)
+	})
+
+	t.Run("without a parent reference the child has no parent", func(t *testing.T) {
+		h, _ := newFakeHandler(t, Config{DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{
+			DefaultMode: config.ModeLog,
+		}}}, stableParent(nil))
+
+		resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, old, updated, testController))
+		require.True(t, resp.Allowed)
+		assert.NotContains(t, resp.Warnings, driftWarning)
+	})
+}
+
+func TestHandle_ParentFetchTimeout(t *testing.T) {
+	// Deployment parents are slow to fetch: Get takes 100ms unless the context is done first
+	slowDeployments := interceptor.Funcs{
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			if u, ok := obj.(*unstructured.Unstructured); ok && u.GetKind() == "Deployment" {
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(100 * time.Millisecond):
+				}
+			}
+			return c.Get(ctx, key, obj, opts...)
+		},
+	}
+	newHandler := func(t *testing.T, timeouts []config.ParentFetchTimeout, parallel bool) *Handler {
+		c := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(stableParent(nil)).WithInterceptorFuncs(slowDeployments).Build()
+		return NewHandler(Config{
+			Client:        c,
+			Log:           logr.Discard(),
+			ParallelReads: parallel,
+			DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{
+				DefaultMode:         config.ModeEnforce,
+				ParentFetchTimeouts: timeouts,
+			}},
+		})
+	}
+
+	// A controller correcting the child of a stable parent: drift, denied in enforce mode
+	updaters := map[string]string{kausalityv1alpha1.UpdatersAnnotation: controller.HashUsername(testController)}
+	old := ownedChild("child", updaters, map[string]interface{}{"size": int64(1)})
+	updated := ownedChild("child", updaters, map[string]interface{}{"size": int64(2)})
+	deployments := metrics.ParentFetchTimeouts.WithLabelValues(appsv1.GroupName, "Deployment")
+
+	for _, parallel := range []bool{false, true} {
+		name := "sequential reads"
+		if parallel {
+			name = "parallel reads"
+		}
+		t.Run(name, func(t *testing.T) {
+			t.Run("slow parent kind fails open", func(t *testing.T) {
+				before := testutil.ToFloat64(deployments)
+				h := newHandler(t, []config.ParentFetchTimeout{{APIGroup: "apps", Kind: "Deployment", Timeout: 20 * time.Millisecond}}, parallel)
+
+				resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, old, updated, testController))
+				assert.True(t, resp.Allowed)
+				assert.Contains(t, resp.Warnings, "[kausality] parent fetch timed out; drift not evaluated")
+				assert.Equal(t, before+1, testutil.ToFloat64(deployments))
+			})
+
+			t.Run("other parent kinds keep full evaluation", func(t *testing.T) {
+				before := testutil.ToFloat64(deployments)
+				h := newHandler(t, []config.ParentFetchTimeout{{APIGroup: "apps", Kind: "StatefulSet", Timeout: 20 * time.Millisecond}}, parallel)
+
+				resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, old, updated, testController))
+				assert.False(t, resp.Allowed)
+				assert.Equal(t, "drift detected: no approval found for this mutation", resp.Result.Message)
+				assert.Equal(t, before, testutil.ToFloat64(deployments))
+			})
+		})
+	}
+}
+
+func TestHandle_PausedParent(t *testing.T) {
+	tests := []struct {
+		name      string
+		paused    bool
+		wantAllow bool
+	}{
+		{name: "unpaused stable parent denies drift"},
+		{name: "paused parent allows controller changes", paused: true, wantAllow: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parent := stableParent(nil)
+			parent.Spec.Paused = tt.paused
+			h, _ := newFakeHandler(t, Config{DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{
+				DefaultMode: config.ModeEnforce,
+			}}}, parent)
+
+			resp := h.Handle(t.Context(), driftRequest(t))
+			assert.Equal(t, tt.wantAllow, resp.Allowed, resp.Result)
+			if tt.wantAllow {
+				assert.Empty(t, resp.Warnings)
+			}
+		})
+	}
+}
+
+func TestHandle_GovernPostureChanges(t *testing.T) {
+	const admin = "admin@example.com"
+	wildcard, err := approval.MarshalApprovals([]approval.Approval{{APIVersion: "v1", Kind: "ConfigMap", Name: "*", Mode: approval.ModeAlways}})
+	require.NoError(t, err)
+
+	// newHandler returns a handler whose SubjectAccessReviews allow only admin.
+	newHandler := func(t *testing.T, govern bool, sarErr error) (*Handler, *ktesting.FakeSender, *int) {
+		var reviews int
+		c := fake.NewClientBuilder().WithScheme(testScheme).WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				sar, ok := obj.(*authorizationv1.SubjectAccessReview)
+				if !ok {
+					return c.Create(ctx, obj, opts...)
+				}
+				reviews++
+				assert.Equal(t, authorizationv1.ResourceAttributes{
+					Namespace: testNamespace, Verb: "weaken", Group: "kausality.io", Resource: "postures", Name: testParentName,
+				}, *sar.Spec.ResourceAttributes)
+				if sarErr != nil {
+					return sarErr
+				}
+				sar.Status.Allowed = sar.Spec.User == admin
+				return nil
+			},
+		}).Build()
+		sender := &ktesting.FakeSender{}
+		h := NewHandler(Config{
+			Client:         c,
+			Log:            logr.Discard(),
+			CallbackSender: sender,
+			DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{
+				DefaultMode:          config.ModeEnforce,
+				GovernPostureChanges: govern,
+			}},
+		})
+		return h, sender, &reviews
+	}
+	update := func(t *testing.T, h *Handler, oldAnns, newAnns map[string]string, user string) admission.Response {
+		return h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, stableParent(oldAnns), stableParent(newAnns), user))
+	}
+	enforce := map[string]string{config.ModeAnnotation: config.ModeEnforce}
+	log := map[string]string{config.ModeAnnotation: config.ModeLog}
+
+	t.Run("mode downgrade is denied without permission", func(t *testing.T) {
+		h, sender, _ := newHandler(t, true, nil)
+		resp := update(t, h, enforce, log, "alice@example.com")
+		assert.False(t, resp.Allowed)
+		assert.Equal(t, "weakening kausality enforcement (mode downgraded from enforce to log) requires permission to weaken postures.kausality.io", resp.Result.Message)
+		assert.Empty(t, sender.Sent())
+	})
+
+	t.Run("mode downgrade with permission is reported", func(t *testing.T) {
+		h, sender, _ := newHandler(t, true, nil)
+		resp := update(t, h, enforce, log, admin)
+		assert.True(t, resp.Allowed)
+		reports := sender.Sent()
+		require.Len(t, reports, 1)
+		assert.Equal(t, v1alpha1.DriftReportPhasePostureChange, reports[0].Spec.Phase)
+		assert.Equal(t, v1alpha1.DriftReportSeverityCritical, reports[0].Spec.Severity)
+		assert.Equal(t, []string{"mode downgraded from enforce to log"}, reports[0].Spec.PostureChanges)
+		assert.Equal(t, testParentName, reports[0].Spec.Parent.Name)
+		assert.Equal(t, admin, reports[0].Spec.Request.User)
+	})
+
+	t.Run("removing enforce is a downgrade", func(t *testing.T) {
+		h, _, _ := newHandler(t, true, nil)
+		resp := update(t, h, enforce, nil, "alice@example.com")
+		assert.False(t, resp.Allowed)
+		assert.Contains(t, resp.Result.Message, "mode enforce removed")
+	})
+
+	t.Run("setting log without a previous mode is a downgrade", func(t *testing.T) {
+		h, _, _ := newHandler(t, true, nil)
+		resp := update(t, h, nil, log, "alice@example.com")
+		assert.False(t, resp.Allowed)
+		assert.Contains(t, resp.Result.Message, "mode downgraded from default to log")
+	})
+
+	t.Run("setting warn or dryrun is a downgrade", func(t *testing.T) {
+		h, _, _ := newHandler(t, true, nil)
+		resp := update(t, h, enforce, map[string]string{config.ModeAnnotation: config.ModeWarn}, "alice@example.com")
+		assert.False(t, resp.Allowed)
+		assert.Contains(t, resp.Result.Message, "mode downgraded from enforce to warn")
+
+		resp = update(t, h, nil, map[string]string{config.ModeAnnotation: config.ModeDryRun}, "alice@example.com")
+		assert.False(t, resp.Allowed)
+		assert.Contains(t, resp.Result.Message, "mode downgraded from default to dryrun")
+	})
+
+	t.Run("switching from log to warn is not a downgrade", func(t *testing.T) {
+		h, _, _ := newHandler(t, true, nil)
+		resp := update(t, h, log, map[string]string{config.ModeAnnotation: config.ModeWarn}, "alice@example.com")
+		assert.True(t, resp.Allowed, resp.Result)
+	})
+
+	t.Run("wildcard approval is denied without permission", func(t *testing.T) {
+		h, _, _ := newHandler(t, true, nil)
+		resp := update(t, h, nil, map[string]string{kausalityv1alpha1.ApprovalsAnnotation: wildcard}, "alice@example.com")
+		assert.False(t, resp.Allowed)
+		assert.Contains(t, resp.Result.Message, "wildcard approval added for v1 ConfigMap *")
+	})
+
+	t.Run("existing wildcard approval is not a change", func(t *testing.T) {
+		h, _, reviews := newHandler(t, true, nil)
+		anns := map[string]string{kausalityv1alpha1.ApprovalsAnnotation: wildcard}
+		withNote := map[string]string{kausalityv1alpha1.ApprovalsAnnotation: wildcard, "note": "x"}
+		assert.True(t, update(t, h, anns, withNote, "alice@example.com").Allowed)
+		assert.Zero(t, *reviews)
+	})
+
+	t.Run("freeze removal is denied without permission", func(t *testing.T) {
+		h, _, _ := newHandler(t, true, nil)
+		resp := update(t, h, map[string]string{kausalityv1alpha1.FreezeAnnotation: `{"user":"ops"}`}, nil, "alice@example.com")
+		assert.False(t, resp.Allowed)
+		assert.Contains(t, resp.Result.Message, "freeze removed")
+	})
+
+	t.Run("strengthening needs no permission", func(t *testing.T) {
+		h, sender, reviews := newHandler(t, true, nil)
+		assert.True(t, update(t, h, log, enforce, "alice@example.com").Allowed)
+		assert.True(t, update(t, h, nil, map[string]string{kausalityv1alpha1.FreezeAnnotation: `{"user":"alice"}`}, "alice@example.com").Allowed)
+		assert.Zero(t, *reviews)
+		// Only the freeze audit, no posture change
+		reports := sender.Sent()
+		require.Len(t, reports, 1)
+		assert.Equal(t, v1alpha1.DriftReportPhaseFreezeApplied, reports[0].Spec.Phase)
+	})
+
+	t.Run("failed permission check denies", func(t *testing.T) {
+		h, _, _ := newHandler(t, true, errors.New("connection refused"))
+		resp := update(t, h, enforce, log, admin)
+		assert.False(t, resp.Allowed)
+		assert.Contains(t, resp.Result.Message, "permission check failed: connection refused")
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		h, _, reviews := newHandler(t, false, nil)
+		assert.True(t, update(t, h, enforce, log, "alice@example.com").Allowed)
+		assert.Zero(t, *reviews)
+	})
+}
+
+func TestHandle_ApprovalProposals(t *testing.T) {
+	updaters := map[string]string{kausalityv1alpha1.UpdatersAnnotation: controller.HashUsername(testController)}
+	correct := func(h *Handler, from, to int64) {
+		old := ownedChild("child", updaters, map[string]interface{}{"replicas": from})
+		updated := ownedChild("child", updaters, map[string]interface{}{"replicas": to})
+		h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, old, updated, testController))
+	}
+	listProposals := func(t *testing.T, c client.Client) []kausalityv1alpha1.DriftApprovalProposal {
+		var list kausalityv1alpha1.DriftApprovalProposalList
+		require.NoError(t, c.List(t.Context(), &list, client.InNamespace(testNamespace)))
+		return list.Items
+	}
+	// Proposals are written in the background
+	requireProposals := func(t *testing.T, c client.Client, n int) []kausalityv1alpha1.DriftApprovalProposal {
+		require.Eventually(t, func() bool { return len(listProposals(t, c)) == n }, 5*time.Second, 10*time.Millisecond)
+		return listProposals(t, c)
+	}
+	newHandler := func(t *testing.T, proposals *config.ApprovalProposalsConfig) (*Handler, client.Client) {
+		return newFakeHandler(t, Config{DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{
+			DefaultMode:       config.ModeLog,
+			ApprovalProposals: proposals,
+		}}}, stableParent(nil))
+	}
+
+	t.Run("repeated identical drift produces exactly one proposal", func(t *testing.T) {
+		h, c := newHandler(t, &config.ApprovalProposalsConfig{Threshold: 3})
+
+		correct(h, 1, 3)
+		correct(h, 1, 3)
+		assert.Empty(t, listProposals(t, c), "below threshold")
+
+		for i := 0; i < 5; i++ {
+			correct(h, 1, 3)
+		}
+		proposals := requireProposals(t, c, 1)
+
+		spec := proposals[0].Spec
+		assert.Equal(t, kausalityv1alpha1.ProposalParentReference{APIVersion: "apps/v1", Kind: "Deployment", Name: testParentName}, spec.Parent)
+		assert.Equal(t, kausalityv1alpha1.Approval{APIVersion: "example.com/v1", Kind: "Widget", Name: "child", Mode: kausalityv1alpha1.ApprovalModeAlways}, spec.Approval)
+		assert.Equal(t, testController, spec.Controller)
+		assert.Equal(t, []string{"spec.replicas"}, spec.Fields)
+		assert.Equal(t, int32(3), spec.Occurrences)
+		assert.Equal(t, kausalityv1alpha1.ProposalDecisionPending, spec.Decision)
+	})
+
+	t.Run("restart does not duplicate proposal", func(t *testing.T) {
+		h, c := newHandler(t, &config.ApprovalProposalsConfig{Threshold: 2})
+		correct(h, 1, 3)
+		correct(h, 1, 3)
+		requireProposals(t, c, 1)
+
+		restarted := NewHandler(Config{Client: c, Log: h.log, DriftConfig: h.config()})
+		correct(restarted, 1, 3)
+		correct(restarted, 1, 3)
+		assert.Never(t, func() bool { return len(listProposals(t, c)) != 1 }, 100*time.Millisecond, 10*time.Millisecond)
+	})
+
+	t.Run("proposal is written again after the TTL", func(t *testing.T) {
+		h, c := newHandler(t, &config.ApprovalProposalsConfig{Threshold: 1})
+		now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+		h.proposals.nowFunc = func() time.Time { return now }
+
+		correct(h, 1, 3)
+		proposal := requireProposals(t, c, 1)[0]
+		require.NoError(t, c.Delete(t.Context(), &proposal))
+
+		correct(h, 1, 3)
+		assert.Never(t, func() bool { return len(listProposals(t, c)) != 0 }, 100*time.Millisecond, 10*time.Millisecond, "within TTL")
+
+		now = now.Add(proposedTTL)
+		correct(h, 1, 3)
+		requireProposals(t, c, 1)
+	})
+
+	t.Run("different corrections are counted separately", func(t *testing.T) {
+		h, c := newHandler(t, &config.ApprovalProposalsConfig{Threshold: 2})
+		correct(h, 1, 3)
+		old := ownedChild("child", updaters, map[string]interface{}{"replicas": int64(1), "image": "a"})
+		updated := ownedChild("child", updaters, map[string]interface{}{"replicas": int64(1), "image": "b"})
+		h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, old, updated, testController))
+		assert.Empty(t, listProposals(t, c))
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		h, c := newHandler(t, nil)
+		for i := 0; i < 5; i++ {
+			correct(h, 1, 3)
+		}
+		assert.Empty(t, listProposals(t, c))
+	})
+
+	t.Run("proposals are never applied", func(t *testing.T) {
+		h, c := newHandler(t, &config.ApprovalProposalsConfig{Threshold: 1})
+		correct(h, 1, 3)
+		requireProposals(t, c, 1)
+
+		parent := stableParent(nil)
+		require.NoError(t, c.Get(t.Context(), client.ObjectKeyFromObject(parent), parent))
+		assert.NotContains(t, parent.Annotations, kausalityv1alpha1.ApprovalsAnnotation)
+	})
+}
+
+func TestHandle_ParallelReadsMatchSequential(t *testing.T) {
+	childApproval := `[{"apiVersion":"example.com/v1","kind":"Widget","name":"child","mode":"always"}]`
+	childRejection := `[{"apiVersion":"example.com/v1","kind":"Widget","name":"child","reason":"not now"}]`
+
+	tests := []struct {
+		name       string
+		mode       string
+		nsMode     string
+		parentAnns map[string]string
+		username   string
+		orphan     bool
+	}{
+		{name: "drift in log mode", mode: config.ModeLog},
+		{name: "drift in enforce mode", mode: config.ModeEnforce},
+		{name: "enforce mode from namespace", mode: config.ModeLog, nsMode: config.ModeEnforce},
+		{name: "frozen parent", mode: config.ModeLog, parentAnns: map[string]string{kausalityv1alpha1.FreezeAnnotation: `{"user":"admin"}`}},
+		{name: "approved drift", mode: config.ModeEnforce, parentAnns: map[string]string{kausalityv1alpha1.ApprovalsAnnotation: childApproval}},
+		{name: "rejected drift", mode: config.ModeEnforce, parentAnns: map[string]string{kausalityv1alpha1.RejectionsAnnotation: childRejection}},
+		{name: "non-controller update", mode: config.ModeEnforce, username: "alice@example.com"},
+		{name: "orphan child", mode: config.ModeEnforce, orphan: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			username := tt.username
+			if username == "" {
+				username = testController
+			}
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace}}
+			if tt.nsMode != "" {
+				ns.Annotations = map[string]string{config.ModeAnnotation: tt.nsMode}
+			}
+			updaters := map[string]string{kausalityv1alpha1.UpdatersAnnotation: controller.HashUsername(testController)}
+			old := ownedChild("child", updaters, map[string]interface{}{"size": int64(1)})
+			updated := ownedChild("child", updaters, map[string]interface{}{"size": int64(2)})
+			if tt.orphan {
+				old.SetOwnerReferences(nil)
+				updated.SetOwnerReferences(nil)
+			}
+
+			var responses []admission.Response
+			for _, parallel := range []bool{false, true} {
+				h, _ := newFakeHandler(t, Config{
+					DriftConfig:   &config.Config{DriftDetection: config.DriftDetectionConfig{DefaultMode: tt.mode}},
+					ParallelReads: parallel,
+				}, stableParent(tt.parentAnns), ns)
+				responses = append(responses, h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, old, updated, username)))
+			}
+
+			sequential, parallel := responses[0], responses[1]
+			assert.Equal(t, sequential.Allowed, parallel.Allowed)
+			assert.Equal(t, sequential.Result, parallel.Result)
+			assert.Equal(t, sequential.Warnings, parallel.Warnings)
+			assert.Equal(t, patchPaths(sequential), patchPaths(parallel))
+		})
+	}
+}
+
+func TestHandle_ReadsParentOnce(t *testing.T) {
+	approvals := `[{"apiVersion":"example.com/v1","kind":"Widget","name":"child","mode":"always"}]`
+	tests := []struct {
+		name       string
+		parentAnns map[string]string
+		wantAllow  bool
+	}{
+		{name: "approved drift", parentAnns: map[string]string{kausalityv1alpha1.ApprovalsAnnotation: approvals}, wantAllow: true},
+		{name: "unapproved drift", wantAllow: false},
+		{name: "frozen parent", parentAnns: map[string]string{kausalityv1alpha1.FreezeAnnotation: `{"user":"admin"}`}, wantAllow: false},
+	}
+	for _, tt := range tests {
+		for _, parallel := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%s, parallel=%v", tt.name, parallel), func(t *testing.T) {
+				h, parentReads := newParentReadCountingHandler(stableParent(tt.parentAnns), parallel)
+
+				resp := h.Handle(t.Context(), driftRequest(t))
+				assert.Equal(t, tt.wantAllow, resp.Allowed, resp.Result)
+				wantReads := int64(1)
+				if parallel {
+					// The prefetch races detection
+					wantReads = 2
+				}
+				assert.Equal(t, wantReads, parentReads.Load())
+			})
+		}
+	}
+}
+
+// newParentReadCountingHandler returns an enforcing handler whose client counts reads of parent.
+func newParentReadCountingHandler(parent client.Object, parallel bool) (*Handler, *atomic.Int64) {
+	var parentReads atomic.Int64
+	c := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(parent).WithInterceptorFuncs(interceptor.Funcs{
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			if key.Name == parent.GetName() {
+				parentReads.Add(1)
+			}
+			return c.Get(ctx, key, obj, opts...)
+		},
+	}).Build()
+	return NewHandler(Config{
+		Client:        c,
+		Log:           logr.Discard(),
+		DriftConfig:   &config.Config{DriftDetection: config.DriftDetectionConfig{DefaultMode: config.ModeEnforce}},
+		ParallelReads: parallel,
+	}), &parentReads
+}
+
+// driftRequest returns a controller update of the test child, which is drift under a stable parent.
+func driftRequest(tb testing.TB) admission.Request {
+	updaters := map[string]string{kausalityv1alpha1.UpdatersAnnotation: controller.HashUsername(testController)}
+	old := ownedChild("child", updaters, map[string]interface{}{"size": int64(1)})
+	updated := ownedChild("child", updaters, map[string]interface{}{"size": int64(2)})
+	return newAdmissionRequest(tb, admissionv1.Update, old, updated, testController)
+}
+
+// patchPaths returns the sorted operations and paths of the response patches.
+// Values are omitted because trace hops carry timestamps.
+func patchPaths(resp admission.Response) []string {
+	var result []string
+	for _, p := range resp.Patches {
+		result = append(result, p.Operation+" "+p.Path)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func BenchmarkHandle_Reads(b *testing.B) {
+	const latency = time.Millisecond
+
+	for _, parallel := range []bool{false, true} {
+		name := "sequential"
+		if parallel {
+			name = "parallel"
+		}
+		b.Run(name, func(b *testing.B) {
+			parent := stableParent(map[string]string{
+				kausalityv1alpha1.ApprovalsAnnotation: `[{"apiVersion":"example.com/v1","kind":"Widget","name":"child","mode":"always"}]`,
+			})
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace}}
+			c := fake.NewClientBuilder().WithObjects(parent, ns).WithInterceptorFuncs(interceptor.Funcs{
+				// Simulate an API server round-trip for every read.
+				Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+					time.Sleep(latency)
+					return c.Get(ctx, key, obj, opts...)
+				},
+			}).Build()
+			h := NewHandler(Config{
+				Client:        c,
+				Log:           logr.Discard(),
+				DriftConfig:   &config.Config{DriftDetection: config.DriftDetectionConfig{DefaultMode: config.ModeEnforce}},
+				ParallelReads: parallel,
+			})
+
+			updaters := map[string]string{kausalityv1alpha1.UpdatersAnnotation: controller.HashUsername(testController)}
+			old := ownedChild("child", updaters, map[string]interface{}{"size": int64(1)})
+			updated := ownedChild("child", updaters, map[string]interface{}{"size": int64(2)})
+			req := newAdmissionRequest(b, admissionv1.Update, old, updated, testController)
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if resp := h.Handle(context.Background(), req); !resp.Allowed {
+					b.Fatalf("request denied: %v", resp.Result)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkHandle_ParentReads(b *testing.B) {
+	parent := stableParent(map[string]string{
+		kausalityv1alpha1.ApprovalsAnnotation: `[{"apiVersion":"example.com/v1","kind":"Widget","name":"child","mode":"always"}]`,
+	})
+	h, parentReads := newParentReadCountingHandler(parent, false)
+	req := driftRequest(b)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if resp := h.Handle(context.Background(), req); !resp.Allowed {
+			b.Fatalf("request denied: %v", resp.Result)
+		}
+	}
+	b.ReportMetric(float64(parentReads.Load())/float64(b.N), "parentReads/op")
+}
+
+func TestHandle_RecreateAfterDelete(t *testing.T) {
+	const driftWarning = "[kausality] drift detected: no approval found for this mutation (would be blocked in enforce mode)"
+
+	updaters := map[string]string{kausalityv1alpha1.UpdatersAnnotation: controller.HashUsername(testController)}
+	existing := ownedChild("child", updaters, map[string]interface{}{"size": int64(1)})
+	recreated := ownedChild("child", nil, map[string]interface{}{"size": int64(1)})
+
+	tests := []struct {
+		name           string
+		classification string
+		deleter        string
+		wantDrift      bool
+	}{
+		{name: "expected: user delete then controller re-create", classification: config.RecreateExpected, deleter: "alice", wantDrift: false},
+		{name: "drift: user delete then controller re-create", classification: config.RecreateDrift, deleter: "alice", wantDrift: true},
+		{name: "expected: controller delete is not a user delete", classification: config.RecreateExpected, deleter: testController, wantDrift: true},
+		{name: "expected: no delete", classification: config.RecreateExpected, wantDrift: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, _ := newFakeHandler(t, Config{DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{
+				DefaultMode: config.ModeLog,
+				RecreateAfterDelete: []config.RecreateAfterDelete{
+					{APIGroup: "example.com", Kind: "Widget", Classification: tt.classification},
+				},
+			}}}, stableParent(nil))
+
+			if tt.deleter != "" {
+				resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Delete, existing, nil, tt.deleter))
+				require.True(t, resp.Allowed)
+			}
+
+			resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Create, nil, recreated, testController))
+			require.True(t, resp.Allowed)
+			if tt.wantDrift {
+				assert.Contains(t, resp.Warnings, driftWarning)
+			} else {
+				assert.NotContains(t, resp.Warnings, driftWarning)
+			}
+		})
+	}
+
+	t.Run("other child kinds are not tracked", func(t *testing.T) {
+		h, _ := newFakeHandler(t, Config{DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{
+			DefaultMode: config.ModeLog,
+			RecreateAfterDelete: []config.RecreateAfterDelete{
+				{APIGroup: "example.com", Kind: "Gadget", Classification: config.RecreateExpected},
+			},
+		}}}, stableParent(nil))
+
+		require.True(t, h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Delete, existing, nil, "alice")).Allowed)
+		resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Create, nil, recreated, testController))
+		assert.Contains(t, resp.Warnings, driftWarning)
+	})
+
+	t.Run("deletes expire after the window", func(t *testing.T) {
+		h, _ := newFakeHandler(t, Config{DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{
+			DefaultMode: config.ModeLog,
+			RecreateAfterDelete: []config.RecreateAfterDelete{
+				{APIGroup: "example.com", Kind: "Widget", Classification: config.RecreateExpected},
+			},
+		}}}, stableParent(nil))
+
+		require.True(t, h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Delete, existing, nil, "alice")).Allowed)
+		h.recreates.nowFunc = func() time.Time { return time.Now().Add(config.DefaultRecreateWindow + time.Second) }
+		resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Create, nil, recreated, testController))
+		assert.Contains(t, resp.Warnings, driftWarning)
+	})
+}
+
+func TestHandle_DetectRecreates(t *testing.T) {
+	const recreateWarning = "[kausality] drift detected: no approval found for this mutation; controller deleted and recreated the child (would be blocked in enforce mode)"
+
+	updaters := map[string]string{kausalityv1alpha1.UpdatersAnnotation: controller.HashUsername(testController)}
+	existing := ownedChild("child", updaters, map[string]interface{}{"size": int64(1)})
+	recreated := ownedChild("child", nil, map[string]interface{}{"size": int64(2)})
+
+	newHandler := func(t *testing.T, sender *ktesting.FakeSender, detect bool) *Handler {
+		h, _ := newFakeHandler(t, Config{CallbackSender: sender, DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{
+			DefaultMode:     config.ModeLog,
+			DetectRecreates: detect,
+		}}}, stableParent(nil))
+		return h
+	}
+
+	t.Run("controller delete then create is a recreate", func(t *testing.T) {
+		sender := &ktesting.FakeSender{}
+		h := newHandler(t, sender, true)
+
+		require.True(t, h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Delete, existing, nil, testController)).Allowed)
+		resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Create, nil, recreated, testController))
+		require.True(t, resp.Allowed)
+		assert.Contains(t, resp.Warnings, recreateWarning)
+
+		reports := sender.Sent()
+		require.Len(t, reports, 2)
+		assert.False(t, reports[0].Spec.Recreated, "the delete alone is no recreate")
+		assert.True(t, reports[1].Spec.Recreated)
+
+		// The delete is consumed by the pairing
+		resp = h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Create, nil, recreated, testController))
+		assert.NotContains(t, resp.Warnings, recreateWarning)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		h := newHandler(t, &ktesting.FakeSender{}, false)
+
+		require.True(t, h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Delete, existing, nil, testController)).Allowed)
+		resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Create, nil, recreated, testController))
+		assert.NotContains(t, resp.Warnings, recreateWarning)
+	})
+
+	t.Run("create by another actor is no recreate", func(t *testing.T) {
+		h := newHandler(t, &ktesting.FakeSender{}, true)
+
+		require.True(t, h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Delete, existing, nil, testController)).Allowed)
+		resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Create, nil, recreated, "system:serviceaccount:other:operator"))
+		assert.NotContains(t, resp.Warnings, recreateWarning)
+	})
+
+	t.Run("other child names are not paired", func(t *testing.T) {
+		h := newHandler(t, &ktesting.FakeSender{}, true)
+
+		require.True(t, h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Delete, existing, nil, testController)).Allowed)
+		other := ownedChild("child-2", nil, map[string]interface{}{"size": int64(2)})
+		resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Create, nil, other, testController))
+		assert.NotContains(t, resp.Warnings, recreateWarning)
+	})
+
+	t.Run("deletes expire after the window", func(t *testing.T) {
+		h := newHandler(t, &ktesting.FakeSender{}, true)
+
+		require.True(t, h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Delete, existing, nil, testController)).Allowed)
+		h.recreates.nowFunc = func() time.Time { return time.Now().Add(config.DefaultRecreateWindow + time.Second) }
+		resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Create, nil, recreated, testController))
+		assert.NotContains(t, resp.Warnings, recreateWarning)
+	})
+}
+
+func TestHandle_RecursiveApproval(t *testing.T) {
+	// root Deployment -> parent Deployment -> child Widget
+	rootApprovals := func(approvals string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "root",
+				Namespace:   testNamespace,
+				UID:         "root-uid",
+				Generation:  2,
+				Annotations: map[string]string{kausalityv1alpha1.ApprovalsAnnotation: approvals},
+			},
+			Status: appsv1.DeploymentStatus{ObservedGeneration: 2},
+		}
+	}
+	midParent := func(rejections string) client.Object {
+		parent := stableParent(nil)
+		if rejections != "" {
+			parent.Annotations[kausalityv1alpha1.RejectionsAnnotation] = rejections
+		}
+		parent.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+			Name:       "root",
+			UID:        "root-uid",
+			Controller: ptr.To(true),
+		}}
+		return parent
+	}
+	updaters := map[string]string{kausalityv1alpha1.UpdatersAnnotation: controller.HashUsername(testController)}
+
+	tests := []struct {
+		name        string
+		root        string
+		rejections  string
+		wantAllowed bool
+	}{
+		{
+			name:        "recursive always-approval on the root covers the leaf",
+			root:        `[{"apiVersion":"*","kind":"*","name":"*","mode":"always","recursive":true}]`,
+			wantAllowed: true,
+		},
+		{
+			name:        "recursive approval of the parent on the root covers the leaf",
+			root:        `[{"apiVersion":"apps/v1","kind":"Deployment","name":"parent","generation":2,"recursive":true}]`,
+			wantAllowed: true,
+		},
+		{
+			name:        "recursive approval at a stale root generation",
+			root:        `[{"apiVersion":"apps/v1","kind":"Deployment","name":"parent","generation":1,"recursive":true}]`,
+			wantAllowed: false,
+		},
+		{
+			name:        "recursive approval of another subtree",
+			root:        `[{"apiVersion":"apps/v1","kind":"Deployment","name":"other","mode":"always","recursive":true}]`,
+			wantAllowed: false,
+		},
+		{
+			name:        "non-recursive approval on the root",
+			root:        `[{"apiVersion":"*","kind":"*","name":"*","mode":"always"}]`,
+			wantAllowed: false,
+		},
+		{
+			name:        "rejection on the parent wins over a recursive approval",
+			root:        `[{"apiVersion":"*","kind":"*","name":"*","mode":"always","recursive":true}]`,
+			rejections:  `[{"apiVersion":"example.com/v1","kind":"Widget","name":"child","reason":"hands off"}]`,
+			wantAllowed: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, c := newFakeHandler(t, Config{
+				DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{DefaultMode: config.ModeEnforce}},
+			}, rootApprovals(tt.root), midParent(tt.rejections))
+			oldChild := ownedChild("child", updaters, map[string]interface{}{"size": int64(1)})
+			newChild := ownedChild("child", updaters, map[string]interface{}{"size": int64(2)})
+
+			// The embeddable decision walks the same approvals as the webhook
+			decision, err := decide.DecideDrift(t.Context(), c, newChild.DeepCopy(), decide.Options{
+				Username:  testController,
+				OldObject: oldChild.DeepCopy(),
+				Config:    h.config(),
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAllowed, decision.Allowed, decision.Reason)
+
+			resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, oldChild, newChild, testController))
+			assert.Equal(t, tt.wantAllowed, resp.Allowed, resp.Result)
+
+			if tt.wantAllowed {
+				root := &appsv1.Deployment{}
+				require.NoError(t, c.Get(t.Context(), client.ObjectKey{Namespace: testNamespace, Name: "root"}, root))
+				assert.Equal(t, tt.root, root.Annotations[kausalityv1alpha1.ApprovalsAnnotation], "recursive approvals are never consumed")
+			}
+		})
+	}
+}
+
+func TestHandle_RejectionSeverityAndRemediation(t *testing.T) {
+	const rejections = `[{"apiVersion":"example.com/v1","kind":"Widget","name":"child","reason":"hands off","severity":"critical","remediation":"ask #platform for an approval"}]`
+
+	handle := func(t *testing.T, mode string) (bool, string, []string, []*v1alpha1.DriftReport) {
+		t.Helper()
+		sender := &ktesting.FakeSender{}
+		h, _ := newFakeHandler(t, Config{
+			DriftConfig:    &config.Config{DriftDetection: config.DriftDetectionConfig{DefaultMode: mode}},
+			CallbackSender: sender,
+		}, stableParent(map[string]string{kausalityv1alpha1.RejectionsAnnotation: rejections}))
+
+		updaters := map[string]string{kausalityv1alpha1.UpdatersAnnotation: controller.HashUsername(testController)}
+		old := ownedChild("child", updaters, map[string]interface{}{"size": int64(1)})
+		changed := ownedChild("child", updaters, map[string]interface{}{"size": int64(2)})
+		resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, old, changed, testController))
+		var msg string
+		if resp.Result != nil {
+			msg = resp.Result.Message
+		}
+		return resp.Allowed, msg, resp.Warnings, sender.Sent()
+	}
+
+	rejection := &v1alpha1.Rejection{Reason: "hands off", Severity: "critical", Remediation: "ask #platform for an approval"}
+
+	t.Run("denial names severity and remediation", func(t *testing.T) {
+		allowed, msg, _, _ := handle(t, config.ModeEnforce)
+		require.False(t, allowed)
+		assert.Equal(t, "drift rejected (critical): hands off; remediation: ask #platform for an approval", msg)
+	})
+
+	t.Run("denied rejected drift is reported", func(t *testing.T) {
+		allowed, _, _, reports := handle(t, config.ModeEnforce)
+		require.False(t, allowed)
+		require.Len(t, reports, 1)
+		assert.Equal(t, v1alpha1.DriftReportPhaseRejected, reports[0].Spec.Phase)
+		assert.Equal(t, v1alpha1.DriftReportSeverityCritical, reports[0].Spec.Severity)
+		assert.Equal(t, rejection, reports[0].Spec.Rejection)
+	})
+
+	t.Run("rejected drift allowed in log mode is reported", func(t *testing.T) {
+		allowed, _, _, reports := handle(t, config.ModeLog)
+		require.True(t, allowed)
+		require.Len(t, reports, 1)
+		assert.Equal(t, v1alpha1.DriftReportPhaseRejected, reports[0].Spec.Phase)
+		assert.Equal(t, v1alpha1.DriftReportSeverityCritical, reports[0].Spec.Severity)
+		assert.Equal(t, rejection, reports[0].Spec.Rejection)
+	})
+
+	t.Run("warn mode warns with the remediation", func(t *testing.T) {
+		allowed, _, warnings, _ := handle(t, config.ModeWarn)
+		require.True(t, allowed)
+		require.Len(t, warnings, 1)
+		assert.Contains(t, warnings[0], "remediation: ask #platform for an approval")
+	})
+}
+
+func TestHandle_ResourceScope(t *testing.T) {
+	widgets := []config.ResourceFilter{{APIGroup: "example.com", Kind: "Widget"}}
+
+	tests := []struct {
+		name        string
+		include     []config.ResourceFilter
+		exclude     []config.ResourceFilter
+		wantSkipped bool
+	}{
+		{name: "no filters process everything"},
+		{name: "included resource is processed", include: widgets},
+		{name: "resource not included is skipped", include: []config.ResourceFilter{{APIGroup: "apps", Kind: "*"}}, wantSkipped: true},
+		{name: "excluded resource is skipped", exclude: widgets, wantSkipped: true},
+		{name: "exclude wins over include", include: widgets, exclude: []config.ResourceFilter{{APIGroup: "*", Kind: "*"}}, wantSkipped: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, _ := newFakeHandler(t, Config{DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{
+				DefaultMode:      config.ModeEnforce,
+				IncludeResources: tt.include,
+				ExcludeResources: tt.exclude,
+			}}}, stableParent(nil))
+
+			// Drift in enforce mode: only denied if the request is processed
+			resp := h.Handle(t.Context(), driftRequest(t))
+			if tt.wantSkipped {
+				assert.True(t, resp.Allowed)
+				assert.Equal(t, "not in scope", resp.Result.Message)
+				assert.Empty(t, resp.Patches)
+			} else {
+				assert.False(t, resp.Allowed)
+			}
+		})
+	}
+}
+
+func BenchmarkHandle_ResourceScope(b *testing.B) {
+	for _, excluded := range []bool{false, true} {
+		name := "included"
+		var exclude []config.ResourceFilter
+		if excluded {
+			name = "excluded"
+			exclude = []config.ResourceFilter{{APIGroup: "example.com", Kind: "Widget"}}
+		}
+		b.Run(name, func(b *testing.B) {
+			c := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(stableParent(nil)).Build()
+			h := NewHandler(Config{
+				Client: c,
+				Log:    logr.Discard(),
+				DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{
+					DefaultMode:      config.ModeLog,
+					ExcludeResources: exclude,
+				}},
+			})
+			req := driftRequest(b)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if resp := h.Handle(context.Background(), req); !resp.Allowed {
+					b.Fatalf("request denied: %v", resp.Result)
+				}
+			}
+		})
+	}
+}
+
+func TestHandle_SpecDiff(t *testing.T) {
+	updaters := map[string]string{kausalityv1alpha1.UpdatersAnnotation: controller.HashUsername(testController)}
+	old := ownedChild("child", updaters, map[string]interface{}{"replicas": int64(3)})
+	updated := ownedChild("child", updaters, map[string]interface{}{"replicas": int64(5)})
+
+	t.Run("drift reports carry the diff", func(t *testing.T) {
+		sender := &ktesting.FakeSender{}
+		h, _ := newFakeHandler(t, Config{CallbackSender: sender, DriftConfig: &config.Config{
+			DriftDetection: config.DriftDetectionConfig{DefaultMode: config.ModeLog},
+			SpecDiff:       &config.SpecDiffConfig{},
+		}}, stableParent(nil))
+
+		require.True(t, h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, old, updated, testController)).Allowed)
+
+		reports := sender.Sent()
+		require.Len(t, reports, 1)
+		assert.Equal(t, "--- a/spec\n+++ b/spec\n@@ -1 +1 @@\n-replicas: 3\n+replicas: 5\n", reports[0].Spec.SpecDiff)
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		sender := &ktesting.FakeSender{}
+		h, _ := newFakeHandler(t, Config{CallbackSender: sender, DriftConfig: &config.Config{
+			DriftDetection: config.DriftDetectionConfig{DefaultMode: config.ModeLog},
+		}}, stableParent(nil))
+
+		require.True(t, h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, old, updated, testController)).Allowed)
+
+		reports := sender.Sent()
+		require.Len(t, reports, 1)
+		assert.Empty(t, reports[0].Spec.SpecDiff)
+	})
+}
+
+func TestHandle_SpecChanges(t *testing.T) {
+	updaters := map[string]string{kausalityv1alpha1.UpdatersAnnotation: controller.HashUsername(testController)}
+	old := ownedChild("child", updaters, map[string]interface{}{
+		"replicas": int64(3),
+		"template": map[string]interface{}{"containers": []interface{}{
+			map[string]interface{}{"name": "app", "image": "app:v1"},
+		}},
+		"paused": true,
+	})
+	updated := ownedChild("child", updaters, map[string]interface{}{
+		"replicas": int64(5),
+		"template": map[string]interface{}{"containers": []interface{}{
+			map[string]interface{}{"name": "app", "image": "app:v2"},
+		}},
+		"selector": map[string]interface{}{"app": "web"},
+	})
+
+	newHandler := func(t *testing.T, includeFullObjects *bool) (*Handler, *ktesting.FakeSender) {
+		sender := &ktesting.FakeSender{}
+		h, _ := newFakeHandler(t, Config{CallbackSender: sender, DriftConfig: &config.Config{
+			DriftDetection:     config.DriftDetectionConfig{DefaultMode: config.ModeLog},
+			IncludeFullObjects: includeFullObjects,
+		}}, stableParent(nil))
+		return h, sender
+	}
+
+	t.Run("drift reports carry the changed fields", func(t *testing.T) {
+		h, sender := newHandler(t, nil)
+		require.True(t, h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, old, updated, testController)).Allowed)
+
+		reports := sender.Sent()
+		require.Len(t, reports, 1)
+		changes := reports[0].Spec.SpecChanges
+		require.Len(t, changes, 4)
+
+		type change struct{ path, old, new string }
+		var got []change
+		for _, c := range changes {
+			got = append(got, change{c.Path, rawString(c.OldValue), rawString(c.NewValue)})
+		}
+		assert.Equal(t, []change{
+			{"spec.paused", "true", ""},
+			{"spec.replicas", "3", "5"},
+			{"spec.selector.app", "", `"web"`},
+			{"spec.template.containers[0].image", `"app:v1"`, `"app:v2"`},
+		}, got)
+
+		assert.NotEmpty(t, reports[0].Spec.NewObject.Raw, "full objects are included by default")
+		assert.NotNil(t, reports[0].Spec.OldObject)
+	})
+
+	t.Run("full objects can be omitted", func(t *testing.T) {
+		h, sender := newHandler(t, ptr.To(false))
+		require.True(t, h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, old, updated, testController)).Allowed)
+
+		reports := sender.Sent()
+		require.Len(t, reports, 1)
+		assert.Len(t, reports[0].Spec.SpecChanges, 4)
+		assert.Empty(t, reports[0].Spec.NewObject.Raw)
+		assert.Nil(t, reports[0].Spec.OldObject)
+	})
+}
+
+// rawString returns the JSON of a raw value, "" for nil.
+func rawString(raw *runtime.RawExtension) string {
+	if raw == nil {
+		return ""
+	}
+	return string(raw.Raw)
+}
+
+func TestHandle_StabilizationGracePeriod(t *testing.T) {
+	const driftWarning = "[kausality] drift detected: no approval found for this mutation (would be blocked in enforce mode)"
+
+	updaters := map[string]string{kausalityv1alpha1.UpdatersAnnotation: controller.HashUsername(testController)}
+	old := ownedChild("child", updaters, map[string]interface{}{"size": int64(1)})
+	updated := ownedChild("child", updaters, map[string]interface{}{"size": int64(2)})
+
+	// The fake client drops managedFields, so the status update time is added on reads
+	newHandler := func(grace time.Duration, statusAt *metav1.Time) *Handler {
+		c := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(stableParent(nil)).WithInterceptorFuncs(interceptor.Funcs{
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				if err := c.Get(ctx, key, obj, opts...); err != nil {
+					return err
+				}
+				if key.Name == testParentName {
+					obj.SetManagedFields([]metav1.ManagedFieldsEntry{{Manager: "deployment-controller", Subresource: "status", Time: statusAt}})
+				}
+				return nil
+			},
+		}).Build()
+		return NewHandler(Config{Client: c, Log: logr.Discard(), DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{
+			DefaultMode:              config.ModeLog,
+			StabilizationGracePeriod: grace,
+		}}})
+	}
+
+	tests := []struct {
+		name        string
+		grace       time.Duration
+		statusAt    *metav1.Time
+		wantWarning bool
+	}{
+		{name: "within the grace period", grace: time.Minute, statusAt: &metav1.Time{Time: time.Now()}},
+		{name: "after the grace period", grace: time.Minute, statusAt: &metav1.Time{Time: time.Now().Add(-2 * time.Minute)}, wantWarning: true},
+		{name: "status update without time", grace: time.Minute, wantWarning: true},
+		{name: "no grace period", statusAt: &metav1.Time{Time: time.Now()}, wantWarning: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newHandler(tt.grace, tt.statusAt)
+
+			resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update, old, updated, testController))
+			require.True(t, resp.Allowed)
+			if tt.wantWarning {
+				assert.Contains(t, resp.Warnings, driftWarning)
+			} else {
+				assert.NotContains(t, resp.Warnings, driftWarning)
+			}
+		})
+	}
+}
+
+func TestHandleStatusUpdate_ControllerSelection(t *testing.T) {
+	const statusWriter = "system:serviceaccount:monitoring:status-reporter"
+	obsGenOwner := func(manager string) metav1.ManagedFieldsEntry {
+		return metav1.ManagedFieldsEntry{
+			Manager:     manager,
+			Operation:   metav1.ManagedFieldsOperationUpdate,
+			Subresource: "status",
+			FieldsType:  "FieldsV1",
+			FieldsV1:    &metav1.FieldsV1{Raw: []byte(`{"f:status":{"f:observedGeneration":{}}}`)},
+		}
+	}
+	otherStatusField := func(manager string) metav1.ManagedFieldsEntry {
+		return metav1.ManagedFieldsEntry{
+			Manager:     manager,
+			Operation:   metav1.ManagedFieldsOperationUpdate,
+			Subresource: "status",
+			FieldsType:  "FieldsV1",
+			FieldsV1:    &metav1.FieldsV1{Raw: []byte(`{"f:status":{"f:conditions":{}}}`)},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		selection     string
+		oldObsGen     int64
+		newObsGen     int64
+		noObsGen      bool
+		managedFields []metav1.ManagedFieldsEntry
+		fieldManager  string
+		recordedSAs   string
+		wantRecorded  bool
+	}{
+		{
+			name:         "owner mode: writer changing observedGeneration is recorded",
+			selection:    config.ControllerSelectionObservedGenerationOwner,
+			oldObsGen:    1,
+			newObsGen:    2,
+			fieldManager: "status-reporter",
+			wantRecorded: true,
+		},
+		{
+			name:          "owner mode: field manager owning observedGeneration is recorded",
+			selection:     config.ControllerSelectionObservedGenerationOwner,
+			oldObsGen:     2,
+			newObsGen:     2,
+			managedFields: []metav1.ManagedFieldsEntry{obsGenOwner("kube-controller-manager"), otherStatusField("status-reporter")},
+			fieldManager:  "kube-controller-manager",
+			wantRecorded:  true,
+		},
+		{
+			name:          "owner mode: secondary status writer is not recorded",
+			selection:     config.ControllerSelectionObservedGenerationOwner,
+			oldObsGen:     2,
+			newObsGen:     2,
+			managedFields: []metav1.ManagedFieldsEntry{obsGenOwner("kube-controller-manager"), otherStatusField("status-reporter")},
+			fieldManager:  "status-reporter",
+			wantRecorded:  false,
+		},
+		{
+			name:          "owner mode: recorded service account is recorded under another field manager",
+			selection:     config.ControllerSelectionObservedGenerationOwner,
+			oldObsGen:     2,
+			newObsGen:     2,
+			managedFields: []metav1.ManagedFieldsEntry{obsGenOwner("kube-controller-manager"), otherStatusField("status-reporter")},
+			fieldManager:  "status-reporter",
+			recordedSAs:   "monitoring/status-reporter",
+			wantRecorded:  true,
+		},
+		{
+			name:          "owner mode: other recorded service account does not match",
+			selection:     config.ControllerSelectionObservedGenerationOwner,
+			oldObsGen:     2,
+			newObsGen:     2,
+			managedFields: []metav1.ManagedFieldsEntry{obsGenOwner("kube-controller-manager"), otherStatusField("status-reporter")},
+			fieldManager:  "status-reporter",
+			recordedSAs:   "kube-system/deployment-controller",
+			wantRecorded:  false,
+		},
+		{
+			name:         "owner mode: unknown owner falls back to recording",
+			selection:    config.ControllerSelectionObservedGenerationOwner,
+			oldObsGen:    2,
+			newObsGen:    2,
+			fieldManager: "status-reporter",
+			wantRecorded: true,
+		},
+		{
+			name:         "owner mode: resources without observedGeneration record every writer",
+			selection:    config.ControllerSelectionObservedGenerationOwner,
+			noObsGen:     true,
+			fieldManager: "status-reporter",
+			wantRecorded: true,
+		},
+		{
+			name:          "default mode records every status writer",
+			oldObsGen:     2,
+			newObsGen:     2,
+			managedFields: []metav1.ManagedFieldsEntry{obsGenOwner("kube-controller-manager"), otherStatusField("status-reporter")},
+			fieldManager:  "status-reporter",
+			wantRecorded:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, _ := newFakeHandler(t, Config{DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{
+				DefaultMode:         config.ModeLog,
+				ControllerSelection: tt.selection,
+			}}})
+
+			anns := map[string]string{kausalityv1alpha1.PhaseAnnotation: kausalityv1alpha1.PhaseValueInitialized}
+			if tt.recordedSAs != "" {
+				anns[kausalityv1alpha1.ControllerServiceAccountsAnnotation] = tt.recordedSAs
+			}
+			old := ownedChild("parent", anns, map[string]interface{}{})
+			old.SetOwnerReferences(nil)
+			updated := old.DeepCopy()
+			updated.SetManagedFields(tt.managedFields)
+			if !tt.noObsGen {
+				old.Object["status"] = map[string]interface{}{"observedGeneration": tt.oldObsGen}
+				updated.Object["status"] = map[string]interface{}{"observedGeneration": tt.newObsGen, "ready": true}
+			}
+
+			req := newAdmissionRequest(t, admissionv1.Update, old, updated, statusWriter)
+			req.SubResource = "status"
+			req.Options = runtime.RawExtension{Raw: []byte(`{"fieldManager":"` + tt.fieldManager + `"}`)}
+			resp := h.Handle(t.Context(), req)
+
+			assert.True(t, resp.Allowed)
+			patched := patchedAnnotations(resp)
+			controllers := patched[kausalityv1alpha1.ControllersAnnotation]
+			serviceAccounts, ok := patched[kausalityv1alpha1.ControllerServiceAccountsAnnotation]
+			if !ok {
+				serviceAccounts = tt.recordedSAs
+			}
+			if tt.wantRecorded {
+				assert.Equal(t, controller.HashUsername(statusWriter), controllers)
+				assert.Equal(t, controller.AddEntry(tt.recordedSAs, "monitoring/status-reporter"), serviceAccounts)
+			} else {
+				assert.Empty(t, controllers)
+				assert.Equal(t, tt.recordedSAs, serviceAccounts)
+			}
+		})
+	}
+}
+
+func TestHandle_StuckController(t *testing.T) {
+	updaters := map[string]string{kausalityv1alpha1.UpdatersAnnotation: controller.HashUsername(testController)}
+	newHandler := func(t *testing.T) (*Handler, *ktesting.FakeSender, *time.Time) {
+		sender := &ktesting.FakeSender{}
+		h, _ := newFakeHandler(t, Config{
+			CallbackSender: sender,
+			DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{
+				DefaultMode:    config.ModeEnforce,
+				StuckDetection: &config.StuckDetectionConfig{Threshold: 3, Window: time.Minute},
+			}},
+		}, stableParent(nil))
+		now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		h.stuck.nowFunc = func() time.Time { return now }
+		return h, sender, &now
+	}
+	correct := func(t *testing.T, h *Handler, size int64) {
+		t.Helper()
+		resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Update,
+			ownedChild("child", updaters, map[string]interface{}{"size": int64(1)}),
+			ownedChild("child", updaters, map[string]interface{}{"size": size}),
+			testController))
+		require.False(t, resp.Allowed, "drift must be blocked")
+	}
+	stuckReports := func(sender *ktesting.FakeSender) []*v1alpha1.DriftReport {
+		var stuck []*v1alpha1.DriftReport
+		for _, r := range sender.Sent() {
+			if r.Spec.Phase == v1alpha1.DriftReportPhaseStuck {
+				stuck = append(stuck, r)
+			}
+		}
+		return stuck
+	}
+
+	t.Run("alerts at the threshold and at every doubling", func(t *testing.T) {
+		h, sender, _ := newHandler(t)
+		for range 2 {
+			correct(t, h, 2)
+		}
+		assert.Empty(t, stuckReports(sender))
+
+		correct(t, h, 2)
+		stuck := stuckReports(sender)
+		require.Len(t, stuck, 1)
+		assert.Equal(t, 3, stuck[0].Spec.BlockedAttempts)
+		assert.Equal(t, v1alpha1.DriftReportSeverityCritical, stuck[0].Spec.Severity)
+		assert.Equal(t, testController, stuck[0].Spec.Request.User)
+		assert.NotEmpty(t, stuck[0].Spec.SpecChanges)
+
+		for range 2 {
+			correct(t, h, 2)
+		}
+		assert.Len(t, stuckReports(sender), 1, "no alert between thresholds")
+		correct(t, h, 2)
+		stuck = stuckReports(sender)
+		require.Len(t, stuck, 2)
+		assert.Equal(t, 6, stuck[1].Spec.BlockedAttempts)
+		assert.Equal(t, stuck[0].Spec.ID, stuck[1].Spec.ID)
+	})
+
+	t.Run("different changes are counted separately", func(t *testing.T) {
+		h, sender, _ := newHandler(t)
+		correct(t, h, 2)
+		correct(t, h, 3)
+		correct(t, h, 4)
+		assert.Empty(t, stuckReports(sender))
+	})
+
+	t.Run("attempts expire after the window", func(t *testing.T) {
+		h, sender, now := newHandler(t)
+		correct(t, h, 2)
+		correct(t, h, 2)
+		*now = now.Add(2 * time.Minute)
+		correct(t, h, 2)
+		assert.Empty(t, stuckReports(sender))
+		correct(t, h, 2)
+		correct(t, h, 2)
+		assert.Len(t, stuckReports(sender), 1)
+	})
+}
+
+func TestHandle_SyntheticDrift(t *testing.T) {
+	const operator = "alice@example.com"
+	spec := map[string]interface{}{"replicas": int64(1)}
+	inject := func(t *testing.T, h *Handler, child func() (old, updated client.Object), dryRun bool) admission.Response {
+		old, updated := child()
+		req := newAdmissionRequest(t, admissionv1.Update, old, updated, operator)
+		req.UID = types.UID(utilrand.String(8))
+		req.DryRun = ptr.To(dryRun)
+		return h.Handle(t.Context(), req)
+	}
+	owned := func() (client.Object, client.Object) {
+		old := ownedChild("child", nil, spec)
+		updated := ownedChild("child", map[string]string{kausalityv1alpha1.SyntheticDriftAnnotation: "true"}, spec)
+		return old, updated
+	}
+	newHandler := func(t *testing.T, mode string) (*Handler, client.Client, *ktesting.FakeSender) {
+		sender := &ktesting.FakeSender{}
+		h, c := newFakeHandler(t, Config{
+			DriftConfig:    &config.Config{DriftDetection: config.DriftDetectionConfig{DefaultMode: mode}},
+			CallbackSender: sender,
+		}, stableParent(nil))
+		return h, c, sender
+	}
+
+	t.Run("enforce mode denies and reports synthetic drift", func(t *testing.T) {
+		h, c, sender := newHandler(t, config.ModeEnforce)
+		resp := inject(t, h, owned, true)
+
+		assert.False(t, resp.Allowed)
+		assert.Contains(t, resp.Result.Message, "synthetic drift detected")
+
+		reports := sender.Sent()
+		require.Len(t, reports, 1)
+		assert.True(t, reports[0].Spec.Synthetic)
+		assert.Equal(t, v1alpha1.DriftReportPhaseDetected, reports[0].Spec.Phase)
+		assert.Equal(t, testParentName, reports[0].Spec.Parent.Name)
+		assert.Equal(t, "child", reports[0].Spec.Child.Name)
+		assert.NotNil(t, reports[0].Spec.FirstSeen)
+
+		// Nothing is persisted on the parent
+		parent := &appsv1.Deployment{}
+		require.NoError(t, c.Get(t.Context(), client.ObjectKey{Namespace: testNamespace, Name: testParentName}, parent))
+		assert.NotContains(t, parent.Annotations, kausalityv1alpha1.DriftFirstSeenAnnotation)
+	})
+
+	t.Run("log mode allows and reports synthetic drift", func(t *testing.T) {
+		h, _, sender := newHandler(t, config.ModeLog)
+		resp := inject(t, h, owned, true)
+
+		assert.True(t, resp.Allowed)
+		assert.Contains(t, resp.Warnings, "[kausality] synthetic drift injected (dry-run, nothing persisted)")
+		require.Len(t, sender.Sent(), 1)
+		assert.True(t, sender.Sent()[0].Spec.Synthetic)
+	})
+
+	t.Run("each injection is a distinct report", func(t *testing.T) {
+		h, _, sender := newHandler(t, config.ModeLog)
+		inject(t, h, owned, true)
+		inject(t, h, owned, true)
+
+		reports := sender.Sent()
+		require.Len(t, reports, 2)
+		assert.NotEqual(t, reports[0].Spec.ID, reports[1].Spec.ID)
+	})
+
+	t.Run("annotation is ignored without dry-run", func(t *testing.T) {
+		h, _, sender := newHandler(t, config.ModeEnforce)
+		resp := inject(t, h, owned, false)
+
+		assert.True(t, resp.Allowed)
+		assert.Empty(t, sender.Sent())
+	})
+
+	t.Run("object without controller owner only warns", func(t *testing.T) {
+		h, _, sender := newHandler(t, config.ModeEnforce)
+		resp := inject(t, h, func() (client.Object, client.Object) {
+			old, updated := owned()
+			old.SetOwnerReferences(nil)
+			updated.SetOwnerReferences(nil)
+			return old, updated
+		}, true)
+
+		assert.True(t, resp.Allowed)
+		assert.Contains(t, resp.Warnings, "[kausality] synthetic drift ignored: object has no controller owner")
+		assert.Empty(t, sender.Sent())
+	})
+}
+
+func TestHandle_TraceHopCorrelatesWithAudit(t *testing.T) {
+	// A reconciling parent makes the controller's writes hops of the parent's trace
+	parent := stableParent(nil)
+	parent.Generation = 2
+	h, _ := newFakeHandler(t, Config{DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{
+		DefaultMode: config.ModeLog,
+	}}}, parent)
+
+	updaters := map[string]string{kausalityv1alpha1.UpdatersAnnotation: controller.HashUsername(testController)}
+	updated := ownedChild("child", updaters, map[string]interface{}{"size": int64(2)})
+	tests := []struct {
+		name string
+		op   admissionv1.Operation
+		old  *unstructured.Unstructured
+	}{
+		{name: "create", op: admissionv1.Create},
+		{name: "update", op: admissionv1.Update, old: ownedChild("child", updaters, map[string]interface{}{"size": int64(1)})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var old runtime.Object
+			if tt.old != nil {
+				old = tt.old
+			}
+			req := newAdmissionRequest(t, tt.op, old, updated, testController)
+
+			before := time.Now().Add(-time.Second)
+			resp := h.Handle(t.Context(), req)
+			require.True(t, resp.Allowed)
+
+			tr, err := trace.Parse(patchedAnnotations(resp)[trace.TraceAnnotation])
+			require.NoError(t, err)
+			require.NotEmpty(t, tr)
+			hop := tr[len(tr)-1]
+			assert.Equal(t, "child", hop.Name)
+			assert.Equal(t, string(req.UID), hop.RequestUID)
+			assert.True(t, hop.Timestamp.After(before), "timestamp %v should be recent", hop.Timestamp)
+		})
+	}
+}
+
+func TestHandle_TraceParent(t *testing.T) {
+	for _, enabled := range []bool{true, false} {
+		parent := stableParent(nil)
+		parent.Generation = 2
+		h, _ := newFakeHandler(t, Config{
+			DriftConfig:      &config.Config{DriftDetection: config.DriftDetectionConfig{DefaultMode: config.ModeLog}},
+			WriteTraceParent: enabled,
+		}, parent)
+
+		resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Create, nil,
+			ownedChild("child", nil, map[string]interface{}{"size": int64(1)}), testController))
+		require.True(t, resp.Allowed)
+
+		annotations := patchedAnnotations(resp)
+		if !enabled {
+			assert.NotContains(t, annotations, trace.TraceParentAnnotation)
+			continue
+		}
+		tr, err := trace.Parse(annotations[trace.TraceAnnotation])
+		require.NoError(t, err)
+		require.Len(t, tr, 2)
+		assert.Equal(t, tr.ToTraceParent(), annotations[trace.TraceParentAnnotation])
+		assert.Equal(t, tr[:1].ToTraceParent()[:35], annotations[trace.TraceParentAnnotation][:35], "trace-id is the origin's")
+	}
+}
+
+func TestHandle_TraceCondition(t *testing.T) {
+	existing := []interface{}{
+		map[string]interface{}{"type": "Ready", "status": "True"},
+		map[string]interface{}{"type": TraceConditionType, "status": "True", "lastTransitionTime": "2020-01-01T00:00:00Z"},
+	}
+
+	tests := []struct {
+		name       string
+		disabled   bool
+		old        []interface{}
+		conditions []interface{}
+		noStatus   bool
+		wantOp     string
+		wantPath   string
+		wantTime   string
+	}{
+		{
+			name:       "added to empty conditions",
+			conditions: []interface{}{},
+			wantOp:     "add",
+			wantPath:   "/status/conditions/-",
+		},
+		{
+			name:       "existing condition is replaced",
+			old:        existing,
+			conditions: existing,
+			wantOp:     "replace",
+			wantPath:   "/status/conditions/1",
+			wantTime:   "2020-01-01T00:00:00Z",
+		},
+		{
+			name:     "objects without conditions are left alone",
+			noStatus: true,
+		},
+		{
+			name:       "disabled",
+			disabled:   true,
+			conditions: []interface{}{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// A reconciling parent makes the controller's write a hop of the parent's trace
+			parent := stableParent(nil)
+			parent.Generation = 2
+			h, _ := newFakeHandler(t, Config{
+				DriftConfig:         &config.Config{DriftDetection: config.DriftDetectionConfig{DefaultMode: config.ModeLog}},
+				WriteTraceCondition: !tt.disabled,
+			}, parent)
+
+			child := ownedChild("child", nil, map[string]interface{}{"size": int64(1)})
+			if !tt.noStatus {
+				require.NoError(t, unstructured.SetNestedSlice(child.Object, tt.conditions, "status", "conditions"))
+			}
+			op := admissionv1.Create
+			var old runtime.Object
+			if tt.old != nil {
+				op = admissionv1.Update
+				oldChild := ownedChild("child", nil, map[string]interface{}{"size": int64(0)})
+				require.NoError(t, unstructured.SetNestedSlice(oldChild.Object, tt.old, "status", "conditions"))
+				old = oldChild
+			}
+
+			resp := h.Handle(t.Context(), newAdmissionRequest(t, op, old, child, testController))
+			require.True(t, resp.Allowed, resp.Result)
+
+			var statusPatches int
+			for _, p := range resp.Patches {
+				if !strings.HasPrefix(p.Path, "/status") {
+					continue
+				}
+				statusPatches++
+				assert.Equal(t, tt.wantOp, p.Operation)
+				assert.Equal(t, tt.wantPath, p.Path)
+				condition, ok := p.Value.(map[string]interface{})
+				require.True(t, ok)
+				assert.Equal(t, TraceConditionType, condition["type"])
+				assert.Equal(t, "True", condition["status"])
+				assert.Equal(t, TraceReasonPropagated, condition["reason"])
+				assert.Equal(t, "origin Deployment "+testParentName+" by unknown, 2 hops", condition["message"])
+				if tt.wantTime != "" {
+					assert.Equal(t, tt.wantTime, condition["lastTransitionTime"])
+				}
+			}
+			if tt.wantOp == "" {
+				assert.Zero(t, statusPatches, "no condition expected")
+			} else {
+				assert.Equal(t, 1, statusPatches)
+			}
+		})
+	}
+}
+
+func TestHandle_TrustedUserFieldManagers(t *testing.T) {
+	tests := []struct {
+		name         string
+		fieldManager string
+		reconciling  bool
+		wantAllowed  bool
+		wantOrigin   bool
+	}{
+		{
+			name:         "argo write on a stable parent is never blocked",
+			fieldManager: "argocd-controller",
+			wantAllowed:  true,
+			wantOrigin:   true,
+		},
+		{
+			name:         "argo write on a reconciling parent starts a new trace",
+			fieldManager: "argocd-application-controller",
+			reconciling:  true,
+			wantAllowed:  true,
+			wantOrigin:   true,
+		},
+		{
+			name:         "other field managers drift",
+			fieldManager: "widget-controller",
+			wantAllowed:  false,
+		},
+		{
+			name:         "other field managers extend the trace of a reconciling parent",
+			fieldManager: "widget-controller",
+			reconciling:  true,
+			wantAllowed:  true,
+			wantOrigin:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parent := stableParent(nil)
+			if tt.reconciling {
+				parent.Generation = 2
+			}
+			h, _ := newFakeHandler(t, Config{DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{
+				DefaultMode:              config.ModeEnforce,
+				TrustedUserFieldManagers: []string{"argocd-*"},
+			}}}, parent)
+
+			// The writer is the only updater of the child, so it is taken for the controller
+			updaters := map[string]string{kausalityv1alpha1.UpdatersAnnotation: controller.HashUsername(testController)}
+			old := ownedChild("child", updaters, map[string]interface{}{"size": int64(1)})
+			changed := ownedChild("child", updaters, map[string]interface{}{"size": int64(2)})
+			req := newAdmissionRequest(t, admissionv1.Update, old, changed, testController)
+			req.Options = runtime.RawExtension{Raw: []byte(`{"fieldManager":"` + tt.fieldManager + `"}`)}
+
+			resp := h.Handle(t.Context(), req)
+			require.Equal(t, tt.wantAllowed, resp.Allowed, resp.Result)
+			if !tt.wantAllowed {
+				return
+			}
+			assert.Empty(t, resp.Warnings)
+
+			tr, err := trace.Parse(patchedAnnotations(resp)[trace.TraceAnnotation])
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantOrigin, len(tr) == 1, "trace %s", tr)
+		})
+	}
+}
+
+func TestHandle_MissingUserInfo(t *testing.T) {
+	const driftWarning = "[kausality] drift detected: no approval found for this mutation (would be blocked in enforce mode)"
+
+	tests := []struct {
+		name        string
+		decision    string
+		wantAllowed bool
+		wantDrift   bool
+	}{
+		{name: "default treats it as a user", wantAllowed: true},
+		{name: "anonymous user", decision: config.MissingUserInfoAnonymousUser, wantAllowed: true},
+		{name: "anonymous controller", decision: config.MissingUserInfoAnonymousController, wantAllowed: true, wantDrift: true},
+		{name: "deny", decision: config.MissingUserInfoDeny, wantAllowed: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, _ := newFakeHandler(t, Config{DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{
+				DefaultMode:       config.ModeLog,
+				OnMissingUserInfo: tt.decision,
+			}}}, stableParent(nil))
+
+			child := ownedChild("child", nil, map[string]interface{}{"size": int64(1)})
+			resp := h.Handle(t.Context(), newAdmissionRequest(t, admissionv1.Create, nil, child, ""))
+			require.Equal(t, tt.wantAllowed, resp.Allowed, resp.Result)
+			if !tt.wantAllowed {
+				assert.Contains(t, resp.Result.Message, "request without user info cannot be attributed")
+				return
+			}
+			if tt.wantDrift {
+				assert.Contains(t, resp.Warnings, driftWarning)
+			} else {
+				assert.NotContains(t, resp.Warnings, driftWarning)
+			}
+			assert.NotContains(t, patchedAnnotations(resp), controller.UpdatersAnnotation, "never recorded as updater")
+		})
+	}
+
+	t.Run("status writes are not recorded as controller", func(t *testing.T) {
+		h, _ := newFakeHandler(t, Config{DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{
+			DefaultMode: config.ModeLog,
+		}}}, stableParent(nil))
+
+		old := stableParent(nil)
+		updated := stableParent(nil)
+		updated.Status.Replicas = 1
+		req := newAdmissionRequest(t, admissionv1.Update, old, updated, "")
+		req.SubResource = "status"
+		resp := h.Handle(t.Context(), req)
+		require.True(t, resp.Allowed)
+		assert.NotContains(t, patchedAnnotations(resp), controller.ControllersAnnotation)
+	})
+}
@@ -0,0 +1,79 @@
+package admission
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/kausality-io/kausality/pkg/config"
+	"github.com/kausality-io/kausality/pkg/health"
+)
+
+// healthGate downgrades enforcement while the cluster health signal reports unhealthy.
+// The signal is re-read at most once per interval; read errors keep the last known state.
+// The lock is never held while reading the signal, so only the request that triggers a
+// re-read waits for it, and others use the last known state meanwhile.
+type healthGate struct {
+	signal   health.Signal
+	interval time.Duration
+	nowFunc  func() time.Time
+	log      logr.Logger
+
+	mu        sync.Mutex
+	checkedAt time.Time
+	healthy   bool
+}
+
+// newHealthGate returns nil if no health signal is configured.
+func newHealthGate(signal health.Signal, cfg *config.Config, log logr.Logger) *healthGate {
+	if signal == nil {
+		return nil
+	}
+	interval := config.DefaultHealthCheckInterval
+	if hs := cfg.DriftDetection.HealthSignal; hs != nil && hs.CheckInterval > 0 {
+		interval = hs.CheckInterval
+	}
+	return &healthGate{
+		signal:   signal,
+		interval: interval,
+		nowFunc:  time.Now,
+		log:      log,
+		healthy:  true,
+	}
+}
+
+// Degraded returns true if enforcement should be downgraded to log mode.
+func (g *healthGate) Degraded(ctx context.Context) bool {
+	if g == nil {
+		return false
+	}
+	g.mu.Lock()
+	now := g.nowFunc()
+	if !g.checkedAt.IsZero() && now.Sub(g.checkedAt) < g.interval {
+		defer g.mu.Unlock()
+		return !g.healthy
+	}
+	// Claim the re-read, so concurrent requests keep using the last state
+	g.checkedAt = now
+	g.mu.Unlock()
+
+	healthy, err := g.signal.Healthy(ctx)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if err != nil {
+		g.log.Error(err, "failed to read cluster health signal, keeping last state", "healthy", g.healthy)
+		return !g.healthy
+	}
+	if healthy != g.healthy {
+		if healthy {
+			g.log.Info("CLUSTER HEALTHY - enforcement restored")
+		} else {
+			g.log.Info("CLUSTER UNHEALTHY - downgrading enforce to log until the health signal recovers")
+		}
+		g.healthy = healthy
+	}
+	return !g.healthy
+}
@@ -0,0 +1,39 @@
+package admission
+
+import (
+	admissionv1 "k8s.io/api/admission/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kausality-io/kausality/pkg/lineage"
+	"github.com/kausality-io/kausality/pkg/trace"
+)
+
+// exportLineage emits an OpenLineage run event for trace origins and for object
+// creation and deletion. Controller hops updating existing objects are routine
+// reconciliation and are not exported. Dry-run requests are never exported.
+func (h *Handler) exportLineage(req admission.Request, obj client.Object, traceResult *trace.PropagationResult) {
+	if h.lineage == nil || len(traceResult.Trace) == 0 || isDryRun(req) {
+		return
+	}
+
+	var lifecycle string
+	switch {
+	case req.Operation == admissionv1.Create:
+		lifecycle = lineage.LifecycleCreate
+	case req.Operation == admissionv1.Delete:
+		lifecycle = lineage.LifecycleDrop
+	case traceResult.IsOrigin:
+		lifecycle = lineage.LifecycleAlter
+	default:
+		return
+	}
+
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	h.lineage.EmitAsync(h.lineage.Event(lineage.Object{
+		APIVersion: gvk.GroupVersion().String(),
+		Kind:       gvk.Kind,
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+	}, lifecycle, traceResult.Trace))
+}
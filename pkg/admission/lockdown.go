@@ -0,0 +1,95 @@
+package admission
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/go-logr/logr"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kausalityv1alpha1 "github.com/kausality-io/kausality/api/v1alpha1"
+	"github.com/kausality-io/kausality/pkg/approval"
+	"github.com/kausality-io/kausality/pkg/callback"
+	"github.com/kausality-io/kausality/pkg/callback/v1alpha1"
+	"github.com/kausality-io/kausality/pkg/config"
+	"github.com/kausality-io/kausality/pkg/drift"
+)
+
+// appliedLockdown is a freeze or snooze applied by an UPDATE.
+type appliedLockdown struct {
+	phase      v1alpha1.DriftReportPhase
+	annotation string
+	lockdown   *v1alpha1.Lockdown
+}
+
+// appliedLockdowns returns the freeze and snooze an UPDATE from old to new annotations
+// newly applies or changes. Cleared values and "false" are not lockdowns; unparseable
+// values are returned without details.
+func appliedLockdowns(keys kausalityv1alpha1.AnnotationKeys, oldAnns, newAnns map[string]string) []appliedLockdown {
+	var applied []appliedLockdown
+	if value := newAnns[keys.Freeze]; value != "" && value != "false" && value != oldAnns[keys.Freeze] {
+		lockdown := &v1alpha1.Lockdown{}
+		if freeze, err := approval.ParseFreeze(value); err == nil {
+			lockdown.User, lockdown.Message = freeze.User, freeze.Message
+		}
+		applied = append(applied, appliedLockdown{v1alpha1.DriftReportPhaseFreezeApplied, keys.Freeze, lockdown})
+	}
+	if value := newAnns[keys.Snooze]; value != "" && value != oldAnns[keys.Snooze] {
+		lockdown := &v1alpha1.Lockdown{}
+		if snooze, err := approval.ParseSnooze(value); err == nil {
+			lockdown.User, lockdown.Message = snooze.User, snooze.Message
+			lockdown.Expiry = snooze.Expiry.DeepCopy()
+		}
+		applied = append(applied, appliedLockdown{v1alpha1.DriftReportPhaseSnoozeApplied, keys.Snooze, lockdown})
+	}
+	return applied
+}
+
+// auditLockdowns sends a FreezeApplied or SnoozeApplied report for each freeze or snooze
+// an UPDATE applies. Changes to existing annotations that the webhook reverts, i.e. on
+// UPDATEs by others than kausality without a spec change, are not reported. Like posture
+// reports, these are never suppressed by snooze.
+func (h *Handler) auditLockdowns(ctx context.Context, cfg *config.Config, req admission.Request, log logr.Logger) {
+	if h.callbackSender == nil || !h.callbackSender.IsEnabled() || isDryRun(req) {
+		return
+	}
+	var oldObj, newObj unstructured.Unstructured
+	if err := json.Unmarshal(req.OldObject.Raw, &oldObj); err != nil {
+		return
+	}
+	if err := json.Unmarshal(req.Object.Raw, &newObj); err != nil {
+		return
+	}
+	oldAnns := oldObj.GetAnnotations()
+	applied := appliedLockdowns(h.keys, oldAnns, newObj.GetAnnotations())
+	if len(applied) == 0 {
+		return
+	}
+
+	reverted := false
+	if !h.isOwnWrite(req) {
+		specChanged, err := h.hasSpecChanged(cfg, req)
+		reverted = err == nil && !specChanged
+	}
+
+	ref := &drift.ParentRef{
+		APIVersion: newObj.GetAPIVersion(),
+		Kind:       newObj.GetKind(),
+		Namespace:  newObj.GetNamespace(),
+		Name:       newObj.GetName(),
+	}
+	for _, a := range applied {
+		if _, existed := oldAnns[a.annotation]; existed && reverted {
+			log.V(1).Info("lockdown change is reverted, not reporting", "phase", a.phase)
+			continue
+		}
+		report := h.buildDriftReport(ctx, cfg, req, &newObj, &drift.DriftResult{ParentRef: ref}, a.phase)
+		report.Spec.ID = callback.GenerateDriftID(report.Spec.Parent, report.Spec.Child, []byte(string(a.phase)+":"+string(req.UID)))
+		report.Spec.Severity = v1alpha1.DriftReportSeverityInfo
+		report.Spec.Lockdown = a.lockdown
+		h.callbackSender.SendAsync(ctx, report)
+		log.Info("LOCKDOWN APPLIED", "phase", a.phase, "lockdownUser", a.lockdown.User, "message", a.lockdown.Message)
+	}
+}
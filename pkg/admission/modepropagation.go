@@ -0,0 +1,43 @@
+package admission
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+
+	kausalityv1alpha1 "github.com/kausality-io/kausality/api/v1alpha1"
+	"github.com/kausality-io/kausality/pkg/config"
+	"github.com/kausality-io/kausality/pkg/drift"
+)
+
+// propagatedMode returns the mode to set on a child admitted under ref with
+// PropagateModeToChildren, or "" to leave the child alone. Only enforce is propagated:
+// a propagated log mode would opt the child out of stricter policies that match it.
+func (h *Handler) propagatedMode(ctx context.Context, cfg *config.Config, reads *requestReads, ref *drift.ParentRef, childAnnotations map[string]string, namespace string, nsLabels, nsAnnotations map[string]string, log logr.Logger) string {
+	if cfg == nil || !cfg.DriftDetection.PropagateModeToChildren || ref == nil {
+		return ""
+	}
+	// An explicit mode on the child wins
+	if _, ok := childAnnotations[h.keys.Mode]; ok {
+		return ""
+	}
+
+	parent, err := h.parent(ctx, reads, ref, namespace)
+	if err != nil || parent == nil {
+		log.V(1).Info("failed to fetch parent for mode propagation", "error", err)
+		return ""
+	}
+	parentAnnotations := parent.GetAnnotations()
+	if parentAnnotations == nil {
+		parentAnnotations = map[string]string{}
+	}
+	if parent.GetNamespace() != namespace {
+		// Cluster-scoped parent: the child's namespace does not apply
+		nsLabels, nsAnnotations = nil, map[string]string{}
+	}
+	mode := h.resolveMode(cfg, parent.GetObjectKind().GroupVersionKind(), parent.GetNamespace(), nsLabels, parent.GetLabels(), parentAnnotations, nsAnnotations)
+	if mode != string(kausalityv1alpha1.ModeEnforce) {
+		return ""
+	}
+	return mode
+}
@@ -0,0 +1,48 @@
+package admission
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-logr/logr"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kausality-io/kausality/pkg/config"
+	"github.com/kausality-io/kausality/pkg/metrics"
+)
+
+// namespaceMetadataUnavailable records a failed namespace read and returns the response
+// configured by OnNamespaceMetadataUnavailable, or nil to continue with namespace selectors
+// not matching and without namespace annotations.
+func (h *Handler) namespaceMetadataUnavailable(cfg *config.Config, namespace string, err error, log logr.Logger) *admission.Response {
+	reason := "error"
+	switch {
+	case apierrors.IsForbidden(err):
+		reason = "forbidden"
+		// Missing RBAC silently disables namespace selectors: make it visible
+		log.Error(err, "not allowed to read namespace, namespace selectors and annotations are ignored", "namespace", namespace)
+	case apierrors.IsNotFound(err):
+		reason = "notFound"
+		log.V(1).Info("namespace not found", "namespace", namespace)
+	default:
+		log.V(1).Info("failed to get namespace metadata", "namespace", namespace, "error", err)
+	}
+	metrics.NamespaceReadFailures.WithLabelValues(reason).Inc()
+
+	var decision string
+	if cfg != nil {
+		decision = cfg.DriftDetection.OnNamespaceMetadataUnavailable
+	}
+	switch decision {
+	case config.NamespaceMetadataAllowWithWarning:
+		resp := withWarnings(admission.Allowed("namespace metadata unavailable"),
+			[]string{fmt.Sprintf("[kausality] namespace %s metadata unavailable (%s); drift not evaluated", namespace, reason)})
+		return &resp
+	case config.NamespaceMetadataDeny:
+		resp := admission.Errored(http.StatusInternalServerError, fmt.Errorf("namespace %s metadata unavailable: %w", namespace, err))
+		return &resp
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package admission
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kausality-io/kausality/pkg/config"
+)
+
+func TestHasSpecChanged_ArrayMergeKeys(t *testing.T) {
+	h := &Handler{configSource: staticConfig(&config.Config{DriftDetection: config.DriftDetectionConfig{
+		DefaultMode: config.ModeLog,
+		ArrayMergeKeys: []config.ArrayMergeKey{
+			{APIGroup: "apps", Kind: "Deployment", Path: "spec.template.spec.containers", Key: "name"},
+			{APIGroup: "apps", Kind: "Deployment", Path: "spec.template.spec.containers[].env", Key: "name"},
+		},
+	}})}
+
+	container := func(name, image string, env ...string) map[string]interface{} {
+		c := map[string]interface{}{"name": name, "image": image}
+		if len(env) > 0 {
+			vars := make([]interface{}, 0, len(env)/2)
+			for i := 0; i < len(env); i += 2 {
+				vars = append(vars, map[string]interface{}{"name": env[i], "value": env[i+1]})
+			}
+			c["env"] = vars
+		}
+		return c
+	}
+	deployment := func(kind string, containers ...interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       kind,
+			"metadata":   map[string]interface{}{"name": "test"},
+			"spec": map[string]interface{}{
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{"containers": containers},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		oldObj      map[string]interface{}
+		newObj      map[string]interface{}
+		wantChanged bool
+	}{
+		{
+			name:        "containers reordered",
+			oldObj:      deployment("Deployment", container("app", "app:1"), container("sidecar", "proxy:1")),
+			newObj:      deployment("Deployment", container("sidecar", "proxy:1"), container("app", "app:1")),
+			wantChanged: false,
+		},
+		{
+			name:        "env reordered inside reordered containers",
+			oldObj:      deployment("Deployment", container("app", "app:1", "A", "1", "B", "2"), container("sidecar", "proxy:1")),
+			newObj:      deployment("Deployment", container("sidecar", "proxy:1"), container("app", "app:1", "B", "2", "A", "1")),
+			wantChanged: false,
+		},
+		{
+			name:        "containers reordered with image change",
+			oldObj:      deployment("Deployment", container("app", "app:1"), container("sidecar", "proxy:1")),
+			newObj:      deployment("Deployment", container("sidecar", "proxy:2"), container("app", "app:1")),
+			wantChanged: true,
+		},
+		{
+			name:        "env value changed",
+			oldObj:      deployment("Deployment", container("app", "app:1", "A", "1", "B", "2")),
+			newObj:      deployment("Deployment", container("app", "app:1", "B", "3", "A", "1")),
+			wantChanged: true,
+		},
+		{
+			name:        "container added",
+			oldObj:      deployment("Deployment", container("app", "app:1")),
+			newObj:      deployment("Deployment", container("sidecar", "proxy:1"), container("app", "app:1")),
+			wantChanged: true,
+		},
+		{
+			name:        "other kind is compared by position",
+			oldObj:      deployment("StatefulSet", container("app", "app:1"), container("sidecar", "proxy:1")),
+			newObj:      deployment("StatefulSet", container("sidecar", "proxy:1"), container("app", "app:1")),
+			wantChanged: true,
+		},
+		{
+			name:        "elements without key are compared by position",
+			oldObj:      deployment("Deployment", map[string]interface{}{"image": "a"}, map[string]interface{}{"image": "b"}),
+			newObj:      deployment("Deployment", map[string]interface{}{"image": "b"}, map[string]interface{}{"image": "a"}),
+			wantChanged: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldRaw, err := json.Marshal(tt.oldObj)
+			require.NoError(t, err)
+			newRaw, err := json.Marshal(tt.newObj)
+			require.NoError(t, err)
+
+			req := admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Update,
+					OldObject: runtime.RawExtension{Raw: oldRaw},
+					Object:    runtime.RawExtension{Raw: newRaw},
+				},
+			}
+
+			changed, err := h.hasSpecChanged(h.config(), req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantChanged, changed)
+
+			// Reorderings must also produce the same drift ID input
+			if !tt.wantChanged {
+				unchanged := req
+				unchanged.Object = req.OldObject
+				assert.Equal(t, string(h.computeSpecDiff(h.config(), unchanged)), string(h.computeSpecDiff(h.config(), req)))
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kausality-io/kausality/pkg/drift"
+)
+
+// maxParentCacheEntries bounds the parents cached in memory. The cache is reset when full.
+const maxParentCacheEntries = 1000
+
+// parentCacheKey marks a request context as allowed to use the parent cache.
+// The value is the UID of the controller owner of the admitted child.
+type parentCacheKey struct{}
+
+// parentCache caches parents fetched while admitting CREATEs, so a burst of sibling
+// creates (e.g. generateName Pods of a Job) resolves phase, freeze and approvals from one
+// fetch. It sits below the per-request reads: every parent read of the handler, including
+// drift detection's, goes through Handler.fetchParent and thereby through fetch. Entries are keyed by parent UID and remember the resourceVersion they were read at.
+// They expire after the TTL and are invalidated when the webhook sees the parent change.
+type parentCache struct {
+	ttl     time.Duration
+	nowFunc func() time.Time
+
+	mu      sync.Mutex
+	entries map[types.UID]parentCacheEntry
+}
+
+type parentCacheEntry struct {
+	apiVersion      string
+	kind            string
+	resourceVersion string
+	obj             *unstructured.Unstructured
+	expires         time.Time
+}
+
+// newParentCache returns nil if ttl is not positive.
+func newParentCache(ttl time.Duration) *parentCache {
+	if ttl <= 0 {
+		return nil
+	}
+	return &parentCache{
+		ttl:     ttl,
+		nowFunc: time.Now,
+		entries: make(map[types.UID]parentCacheEntry),
+	}
+}
+
+// withCreateBurst returns a context whose parent reads may be served from the cache.
+// Only CREATEs with a controller owner qualify.
+func (c *parentCache) withCreateBurst(ctx context.Context, req admission.Request) context.Context {
+	if c == nil || req.Operation != admissionv1.Create || len(req.Object.Raw) == 0 {
+		return ctx
+	}
+	var meta struct {
+		Metadata struct {
+			OwnerReferences []struct {
+				UID        types.UID `json:"uid"`
+				Controller *bool     `json:"controller"`
+			} `json:"ownerReferences"`
+		} `json:"metadata"`
+	}
+	if err := json.Unmarshal(req.Object.Raw, &meta); err != nil {
+		return ctx
+	}
+	for _, ref := range meta.Metadata.OwnerReferences {
+		if ref.Controller != nil && *ref.Controller && ref.UID != "" {
+			return context.WithValue(ctx, parentCacheKey{}, ref.UID)
+		}
+	}
+	return ctx
+}
+
+// fetch fetches the parent identified by ref, see drift.GetParent. The parent of the
+// child of a CREATE burst is served from the cache if it was read before within the TTL.
+// Any other read invalidates the cached parent if it changed since.
+func (c *parentCache) fetch(ctx context.Context, reader client.Reader, ref drift.ParentRef, childNamespace string) (*unstructured.Unstructured, error) {
+	if c == nil {
+		return drift.GetParent(ctx, reader, ref, childNamespace)
+	}
+	uid, _ := ctx.Value(parentCacheKey{}).(types.UID)
+	burst := uid != "" && uid == ref.UID
+	if burst {
+		if parent := c.get(ref); parent != nil {
+			return parent, nil
+		}
+	}
+	parent, err := drift.GetParent(ctx, reader, ref, childNamespace)
+	if err != nil {
+		return nil, err
+	}
+	if burst {
+		c.put(parent)
+	} else if uid == "" {
+		// A fresh read at a newer resourceVersion means the cached parent changed
+		c.invalidate(parent.GetUID(), parent.GetResourceVersion())
+	}
+	return parent, nil
+}
+
+// get returns a copy of the cached parent ref names, nil if none is cached.
+func (c *parentCache) get(ref drift.ParentRef) *unstructured.Unstructured {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[ref.UID]
+	if !ok || entry.apiVersion != ref.APIVersion || entry.kind != ref.Kind || entry.obj.GetName() != ref.Name {
+		return nil
+	}
+	if !c.nowFunc().Before(entry.expires) {
+		delete(c.entries, ref.UID)
+		return nil
+	}
+	return entry.obj.DeepCopy()
+}
+
+// put caches a fetched parent.
+func (c *parentCache) put(obj *unstructured.Unstructured) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= maxParentCacheEntries {
+		c.entries = make(map[types.UID]parentCacheEntry)
+	}
+	c.entries[obj.GetUID()] = parentCacheEntry{
+		apiVersion:      obj.GetAPIVersion(),
+		kind:            obj.GetKind(),
+		resourceVersion: obj.GetResourceVersion(),
+		obj:             obj.DeepCopy(),
+		expires:         c.nowFunc().Add(c.ttl),
+	}
+}
+
+// invalidate drops the cached parent with the given UID unless it is already at resourceVersion.
+// An empty resourceVersion always drops it.
+func (c *parentCache) invalidate(uid types.UID, resourceVersion string) {
+	if c == nil || uid == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if entry, ok := c.entries[uid]; ok && (resourceVersion == "" || entry.resourceVersion != resourceVersion) {
+		delete(c.entries, uid)
+	}
+}
+
+// invalidateRequest drops the cached parent if the admitted request changes it.
+func (c *parentCache) invalidateRequest(req admission.Request) {
+	if c == nil || req.Operation == admissionv1.Create || len(req.OldObject.Raw) == 0 {
+		return
+	}
+	var meta struct {
+		Metadata struct {
+			UID types.UID `json:"uid"`
+		} `json:"metadata"`
+	}
+	if err := json.Unmarshal(req.OldObject.Raw, &meta); err == nil {
+		c.invalidate(meta.Metadata.UID, "")
+	}
+}
+
+// parentCacheClient invalidates the cached parents written through it.
+type parentCacheClient struct {
+	client.Client
+	cache *parentCache
+}
+
+// Update implements client.Writer.
+func (c *parentCacheClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	c.cache.invalidate(obj.GetUID(), "")
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+// Patch implements client.Writer.
+func (c *parentCacheClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	c.cache.invalidate(obj.GetUID(), "")
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
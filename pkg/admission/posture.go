@@ -0,0 +1,160 @@
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kausalityv1alpha1 "github.com/kausality-io/kausality/api/v1alpha1"
+	"github.com/kausality-io/kausality/pkg/approval"
+	"github.com/kausality-io/kausality/pkg/callback"
+	"github.com/kausality-io/kausality/pkg/callback/v1alpha1"
+	"github.com/kausality-io/kausality/pkg/config"
+	"github.com/kausality-io/kausality/pkg/drift"
+)
+
+// Permission required to weaken enforcement with GovernPostureChanges.
+const (
+	postureGroup    = v1alpha1.GroupName
+	postureResource = "postures"
+	postureVerb     = "weaken"
+)
+
+// postureChanges returns how an UPDATE from old to new annotations weakens enforcement.
+func postureChanges(keys kausalityv1alpha1.AnnotationKeys, oldAnns, newAnns map[string]string) []string {
+	var changes []string
+
+	oldMode, newMode := oldAnns[keys.Mode], newAnns[keys.Mode]
+	switch {
+	case nonEnforcingMode(newMode) && !nonEnforcingMode(oldMode):
+		changes = append(changes, fmt.Sprintf("mode downgraded from %s to %s", modeOrDefault(oldMode), newMode))
+	case oldMode == config.ModeEnforce && newMode != config.ModeEnforce:
+		changes = append(changes, "mode enforce removed")
+	}
+
+	if newAnns[keys.Approvals] != oldAnns[keys.Approvals] {
+		oldApprovals, _ := approval.ParseApprovals(oldAnns[keys.Approvals])
+		newApprovals, _ := approval.ParseApprovals(newAnns[keys.Approvals])
+		existing := map[string]struct{}{}
+		for _, a := range oldApprovals {
+			existing[a.APIVersion+"/"+a.Kind+"/"+a.Name] = struct{}{}
+		}
+		for _, a := range newApprovals {
+			if !a.HasWildcard() {
+				continue
+			}
+			if _, ok := existing[a.APIVersion+"/"+a.Kind+"/"+a.Name]; ok {
+				continue
+			}
+			changes = append(changes, fmt.Sprintf("wildcard approval added for %s %s %s", a.APIVersion, a.Kind, a.Name))
+		}
+	}
+
+	if oldAnns[keys.Freeze] != "" && newAnns[keys.Freeze] == "" {
+		changes = append(changes, "freeze removed")
+	}
+
+	return changes
+}
+
+func modeOrDefault(mode string) string {
+	if mode == "" {
+		return "default"
+	}
+	return mode
+}
+
+// nonEnforcingMode returns true for modes that admit drift: log, warn and dryrun.
+func nonEnforcingMode(mode string) bool {
+	return mode == config.ModeLog || mode == config.ModeWarn || mode == config.ModeDryRun
+}
+
+// governPosture denies UPDATEs that weaken enforcement unless the user may weaken
+// postures, and reports allowed ones. It returns nil if the request may proceed.
+func (h *Handler) governPosture(ctx context.Context, cfg *config.Config, req admission.Request, log logr.Logger) *admission.Response {
+	var oldObj, newObj unstructured.Unstructured
+	if err := json.Unmarshal(req.OldObject.Raw, &oldObj); err != nil {
+		return nil
+	}
+	if err := json.Unmarshal(req.Object.Raw, &newObj); err != nil {
+		return nil
+	}
+	changes := postureChanges(h.keys, oldObj.GetAnnotations(), newObj.GetAnnotations())
+	if len(changes) == 0 {
+		return nil
+	}
+
+	log = log.WithValues("postureChanges", changes)
+	allowed, err := h.mayWeakenPosture(ctx, req)
+	if err != nil {
+		// Fail closed: the whole point is that weakening is governed
+		log.Error(err, "failed to check permission to weaken enforcement")
+		resp := admission.Denied(fmt.Sprintf("weakening kausality enforcement (%s): permission check failed: %v", strings.Join(changes, ", "), err))
+		return &resp
+	}
+	if !allowed {
+		log.Info("POSTURE CHANGE DENIED")
+		resp := admission.Denied(fmt.Sprintf("weakening kausality enforcement (%s) requires permission to %s %s.%s",
+			strings.Join(changes, ", "), postureVerb, postureResource, postureGroup))
+		return &resp
+	}
+
+	log.Info("POSTURE WEAKENED")
+	h.sendPostureCallback(ctx, cfg, req, &newObj, changes, log)
+	return nil
+}
+
+// mayWeakenPosture checks via SubjectAccessReview whether the requesting user may
+// weaken postures of the object.
+func (h *Handler) mayWeakenPosture(ctx context.Context, req admission.Request) (bool, error) {
+	extra := make(map[string]authorizationv1.ExtraValue, len(req.UserInfo.Extra))
+	for k, v := range req.UserInfo.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+	sar := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   req.UserInfo.Username,
+			Groups: req.UserInfo.Groups,
+			UID:    req.UserInfo.UID,
+			Extra:  extra,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: req.Namespace,
+				Verb:      postureVerb,
+				Group:     postureGroup,
+				Resource:  postureResource,
+				Name:      req.Name,
+			},
+		},
+	}
+	if err := h.client.Create(ctx, sar); err != nil {
+		return false, err
+	}
+	return sar.Status.Allowed, nil
+}
+
+// sendPostureCallback sends a critical report for an allowed posture change.
+// Like break-glass reports, it is never suppressed by snooze.
+func (h *Handler) sendPostureCallback(ctx context.Context, cfg *config.Config, req admission.Request, obj *unstructured.Unstructured, changes []string, log logr.Logger) {
+	if h.callbackSender == nil || !h.callbackSender.IsEnabled() || isDryRun(req) {
+		return
+	}
+	ref := &drift.ParentRef{
+		APIVersion: obj.GetAPIVersion(),
+		Kind:       obj.GetKind(),
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+	}
+	report := h.buildDriftReport(ctx, cfg, req, obj, &drift.DriftResult{ParentRef: ref}, v1alpha1.DriftReportPhasePostureChange)
+	report.Spec.ID = callback.GenerateDriftID(report.Spec.Parent, report.Spec.Child, []byte("posture:"+string(req.UID)))
+	report.Spec.Severity = v1alpha1.DriftReportSeverityCritical
+	report.Spec.PostureChanges = changes
+	h.callbackSender.SendAsync(ctx, report)
+	log.V(1).Info("posture change callback sent", "id", report.Spec.ID)
+}
@@ -0,0 +1,221 @@
+package admission
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kausalityv1alpha1 "github.com/kausality-io/kausality/api/v1alpha1"
+	"github.com/kausality-io/kausality/pkg/config"
+	"github.com/kausality-io/kausality/pkg/drift"
+)
+
+// maxProposalCandidates bounds the corrections tracked in memory. The maps are reset when full.
+const maxProposalCandidates = 10000
+
+// proposedTTL is how long a written proposal suppresses further writes for its
+// correction. Afterwards the correction is counted again; a proposal that still
+// exists makes the next write a no-op.
+const proposedTTL = time.Hour
+
+// proposalRecorder counts unapproved corrections and writes a DriftApprovalProposal
+// once the same controller made the same correction to a child threshold times.
+// It only writes proposals; approvals are never applied automatically.
+type proposalRecorder struct {
+	client    client.Client
+	threshold int
+	nowFunc   func() time.Time
+	log       logr.Logger
+
+	mu         sync.Mutex
+	candidates map[string]*proposalCandidate
+	// proposed maps corrections to the time their proposal was written.
+	proposed map[string]time.Time
+}
+
+type proposalCandidate struct {
+	count     int
+	firstSeen time.Time
+}
+
+// newProposalRecorder returns nil if approval proposals are not enabled.
+func newProposalRecorder(c client.Client, cfg *config.Config, log logr.Logger) *proposalRecorder {
+	if cfg == nil || cfg.DriftDetection.ApprovalProposals == nil {
+		return nil
+	}
+	threshold := cfg.DriftDetection.ApprovalProposals.Threshold
+	if threshold <= 0 {
+		threshold = config.DefaultProposalThreshold
+	}
+	return &proposalRecorder{
+		client:     c,
+		threshold:  threshold,
+		nowFunc:    time.Now,
+		log:        log,
+		candidates: make(map[string]*proposalCandidate),
+		proposed:   make(map[string]time.Time),
+	}
+}
+
+// Observe records an unapproved correction of obj and writes a proposal in the
+// background when the correction reaches the threshold. Proposals are only written
+// once per correction within proposedTTL.
+func (r *proposalRecorder) Observe(ctx context.Context, req admission.Request, obj client.Object, parentRef *drift.ParentRef, fields []string) {
+	if r == nil || parentRef == nil {
+		return
+	}
+	namespace := parentRef.Namespace
+	if namespace == "" {
+		namespace = obj.GetNamespace()
+	}
+	if namespace == "" {
+		// Proposals are namespaced; cluster-scoped parents are not supported
+		return
+	}
+
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	user := req.UserInfo.Username
+	key := strings.Join([]string{
+		parentRef.APIVersion, parentRef.Kind, namespace, parentRef.Name,
+		gvk.GroupVersion().String(), gvk.Kind, obj.GetName(),
+		string(req.Operation), user, strings.Join(fields, ","),
+	}, "\x00")
+
+	r.mu.Lock()
+	now := r.nowFunc()
+	if at, ok := r.proposed[key]; ok {
+		if now.Sub(at) < proposedTTL {
+			r.mu.Unlock()
+			return
+		}
+		delete(r.proposed, key)
+	}
+	candidate, ok := r.candidates[key]
+	if !ok {
+		if len(r.candidates) >= maxProposalCandidates {
+			r.candidates = make(map[string]*proposalCandidate)
+		}
+		candidate = &proposalCandidate{firstSeen: now}
+		r.candidates[key] = candidate
+	}
+	candidate.count++
+	if candidate.count < r.threshold {
+		r.mu.Unlock()
+		return
+	}
+	// Reserve the key so concurrent requests don't write a second proposal
+	if len(r.proposed) >= maxProposalCandidates {
+		r.pruneProposed(now)
+	}
+	r.proposed[key] = now
+	delete(r.candidates, key)
+	r.mu.Unlock()
+
+	sum := sha256.Sum256([]byte(key))
+	proposal := &kausalityv1alpha1.DriftApprovalProposal{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "drift-" + hex.EncodeToString(sum[:])[:16],
+			Namespace: namespace,
+		},
+		Spec: kausalityv1alpha1.DriftApprovalProposalSpec{
+			Parent: kausalityv1alpha1.ProposalParentReference{
+				APIVersion: parentRef.APIVersion,
+				Kind:       parentRef.Kind,
+				Name:       parentRef.Name,
+			},
+			Approval: kausalityv1alpha1.Approval{
+				APIVersion: gvk.GroupVersion().String(),
+				Kind:       gvk.Kind,
+				Name:       obj.GetName(),
+				Mode:       kausalityv1alpha1.ApprovalModeAlways,
+			},
+			Controller:  user,
+			Fields:      fields,
+			Occurrences: int32(candidate.count),
+			FirstSeen:   metav1.NewTime(candidate.firstSeen),
+			Decision:    kausalityv1alpha1.ProposalDecisionPending,
+		},
+	}
+
+	// Don't hold up admission on the write
+	go r.create(context.WithoutCancel(ctx), key, proposal)
+}
+
+// create writes proposal. If that fails, key is released so the correction is
+// proposed again when it reaches the threshold once more.
+func (r *proposalRecorder) create(ctx context.Context, key string, proposal *kausalityv1alpha1.DriftApprovalProposal) {
+	spec := proposal.Spec
+	log := r.log.WithValues("proposal", proposal.Name, "namespace", proposal.Namespace, "parent", spec.Parent.Name, "child", spec.Approval.Name)
+	if err := r.client.Create(ctx, proposal); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			log.V(1).Info("approval proposal already exists")
+			return
+		}
+		log.Error(err, "failed to write approval proposal")
+		r.mu.Lock()
+		delete(r.proposed, key)
+		r.mu.Unlock()
+		return
+	}
+	log.Info("APPROVAL PROPOSED for operator review", "controller", spec.Controller, "fields", spec.Fields, "occurrences", spec.Occurrences)
+}
+
+// pruneProposed drops expired entries and resets proposed if it is still full.
+// The caller must hold r.mu.
+func (r *proposalRecorder) pruneProposed(now time.Time) {
+	for key, at := range r.proposed {
+		if now.Sub(at) >= proposedTTL {
+			delete(r.proposed, key)
+		}
+	}
+	if len(r.proposed) >= maxProposalCandidates {
+		r.proposed = make(map[string]time.Time)
+	}
+}
+
+// changedSpecFields returns the sorted top-level spec fields that differ between
+// the old and new object. Returns nil for CREATE and DELETE.
+func (h *Handler) changedSpecFields(cfg *config.Config, req admission.Request) []string {
+	if len(req.OldObject.Raw) == 0 || len(req.Object.Raw) == 0 {
+		return nil
+	}
+	oldObj := &unstructured.Unstructured{}
+	if err := runtime.DecodeInto(unstructured.UnstructuredJSONScheme, req.OldObject.Raw, oldObj); err != nil {
+		return nil
+	}
+	newObj := &unstructured.Unstructured{}
+	if err := runtime.DecodeInto(unstructured.UnstructuredJSONScheme, req.Object.Raw, newObj); err != nil {
+		return nil
+	}
+
+	gvk := newObj.GroupVersionKind()
+	oldSpec, _ := h.extractSpec(cfg, oldObj, gvk).(map[string]interface{})
+	newSpec, _ := h.extractSpec(cfg, newObj, gvk).(map[string]interface{})
+
+	var fields []string
+	for k, v := range newSpec {
+		if !drift.EqualSpec(oldSpec[k], v) {
+			fields = append(fields, "spec."+k)
+		}
+	}
+	for k := range oldSpec {
+		if _, ok := newSpec[k]; !ok {
+			fields = append(fields, "spec."+k)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
@@ -0,0 +1,124 @@
+package admission
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kausality-io/kausality/pkg/config"
+	"github.com/kausality-io/kausality/pkg/drift"
+	"github.com/kausality-io/kausality/pkg/metrics"
+)
+
+// requestReads holds API reads for a single request. The parent is the one drift was
+// detected against, or is fetched on first use. With parallel reads, the parent and
+// namespace reads are issued ahead of time.
+type requestReads struct {
+	// parentRef is the reference the parent was read for, nil if not read yet.
+	parentRef *drift.ParentRef
+	parent    client.Object
+	parentErr error
+
+	// namespace is the namespace whose metadata was prefetched, "" if not prefetched.
+	namespace     string
+	nsLabels      map[string]string
+	nsAnnotations map[string]string
+	nsErr         error
+
+	// parentTimedOut is set if resolving the parent exceeded its ParentFetchTimeout.
+	parentTimedOut bool
+}
+
+// detect runs drift detection. With parallel reads enabled, the parent fetch for
+// freeze and approvals and the namespace metadata fetch are issued concurrently
+// with detection. Decisions are still taken in order by the caller.
+// Detection of children of parent kinds with a ParentFetchTimeout is bounded by it;
+// reads.parentTimedOut reports that it ran out.
+func (h *Handler) detect(ctx context.Context, cfg *config.Config, obj client.Object, userID string, childUpdaters []string) (*drift.DriftResult, *requestReads, error) {
+	reads := &requestReads{}
+
+	var refs []config.ParentReference
+	var selection string
+	if cfg != nil {
+		refs = cfg.DriftDetection.ParentReferences
+		selection = cfg.DriftDetection.OwnerSelection
+	}
+	// With several parents, the first is prefetched and bounds the detection
+	var ownerRef *metav1.OwnerReference
+	if ownerRefs := drift.OwnerRefs(obj, selection, refs, h.keys); len(ownerRefs) > 0 {
+		ownerRef = &ownerRefs[0]
+	}
+	var parentGK schema.GroupKind
+	detectCtx := ctx
+	if ownerRef != nil && cfg != nil {
+		gv, _ := schema.ParseGroupVersion(ownerRef.APIVersion)
+		parentGK = schema.GroupKind{Group: gv.Group, Kind: ownerRef.Kind}
+		if timeout := cfg.ParentFetchTimeoutFor(parentGK); timeout > 0 {
+			var cancel context.CancelFunc
+			detectCtx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+	}
+
+	var wg sync.WaitGroup
+	if h.parallelReads {
+		if ownerRef != nil {
+			ref := drift.ParentRefFromOwnerRef(*ownerRef, obj.GetNamespace())
+			reads.parentRef = &ref
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				reads.parent, reads.parentErr = h.fetchParent(detectCtx, &ref, obj.GetNamespace())
+			}()
+		}
+		if ns := obj.GetNamespace(); ns != "" {
+			reads.namespace = ns
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				reads.nsLabels, reads.nsAnnotations, reads.nsErr = h.getNamespaceMetadata(detectCtx, ns)
+			}()
+		}
+	}
+
+	result, err := h.detector.Detect(detectCtx, obj, userID, childUpdaters)
+	wg.Wait()
+
+	// Later checks use the parent drift was detected against
+	if result != nil && result.ParentState != nil && result.ParentState.Object != nil {
+		ref := result.ParentState.Ref
+		reads.parentRef, reads.parent, reads.parentErr = &ref, result.ParentState.Object, nil
+	}
+
+	if errors.Is(detectCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil {
+		reads.parentTimedOut = true
+		metrics.ParentFetchTimeouts.WithLabelValues(parentGK.Group, parentGK.Kind).Inc()
+	}
+	return result, reads, err
+}
+
+// parent returns the parent read for this request if it matches ref, otherwise fetches
+// and remembers it, so that a request reads its parent at most once.
+func (h *Handler) parent(ctx context.Context, reads *requestReads, ref *drift.ParentRef, childNamespace string) (client.Object, error) {
+	if reads == nil {
+		return h.fetchParent(ctx, ref, childNamespace)
+	}
+	if reads.parentRef == nil || *reads.parentRef != *ref {
+		parentRef := *ref
+		reads.parentRef = &parentRef
+		reads.parent, reads.parentErr = h.fetchParent(ctx, ref, childNamespace)
+	}
+	return reads.parent, reads.parentErr
+}
+
+// namespaceMetadata returns the prefetched namespace metadata, otherwise fetches it.
+func (h *Handler) namespaceMetadata(ctx context.Context, reads *requestReads, namespace string) (labels, annotations map[string]string, err error) {
+	if reads != nil && reads.namespace == namespace {
+		return reads.nsLabels, reads.nsAnnotations, reads.nsErr
+	}
+	return h.getNamespaceMetadata(ctx, namespace)
+}
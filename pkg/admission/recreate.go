@@ -0,0 +1,186 @@
+package admission
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kausalityv1alpha1 "github.com/kausality-io/kausality/api/v1alpha1"
+	"github.com/kausality-io/kausality/pkg/config"
+	"github.com/kausality-io/kausality/pkg/controller"
+	"github.com/kausality-io/kausality/pkg/drift"
+)
+
+// maxRecentDeletes bounds the deletes remembered in memory. The map is reset when full.
+const maxRecentDeletes = 10000
+
+// recentDeleteKey identifies a child by its parent and its own identity.
+type recentDeleteKey struct {
+	parent    schema.GroupKind
+	parentNS  string
+	parentObj string
+	child     schema.GroupKind
+	namespace string
+	name      string
+}
+
+// recentDelete is a remembered delete of a child.
+type recentDelete struct {
+	at time.Time
+	// controllerHash is the user hash of the deleting controller, empty for user deletes.
+	controllerHash string
+}
+
+// recreate is how a CREATE pairs with a remembered delete of the same child.
+type recreate int
+
+const (
+	recreateNone recreate = iota
+	// recreateAfterUserDelete is a CREATE after a user delete, see RecreateAfterDelete.
+	recreateAfterUserDelete
+	// recreateByController is a CREATE after a delete by the same controller, see DetectRecreates.
+	recreateByController
+)
+
+// recreateTracker remembers recently deleted children, so that re-creating them can be
+// classified: after user deletes by RecreateAfterDelete, after controller deletes by
+// DetectRecreates. Deletes are kept in memory only: after a webhook restart, re-creations
+// are plain CREATEs again.
+type recreateTracker struct {
+	config  *config.Config
+	keys    kausalityv1alpha1.AnnotationKeys
+	hash    controller.HashFunc
+	window  time.Duration
+	nowFunc func() time.Time
+
+	mu      sync.Mutex
+	deletes map[recentDeleteKey]recentDelete
+}
+
+// newRecreateTracker returns nil if neither RecreateAfterDelete nor DetectRecreates is configured.
+func newRecreateTracker(cfg *config.Config) *recreateTracker {
+	if cfg == nil || (len(cfg.DriftDetection.RecreateAfterDelete) == 0 && !cfg.DriftDetection.DetectRecreates) {
+		return nil
+	}
+	window := cfg.DriftDetection.RecreateWindow
+	if window == 0 {
+		window = config.DefaultRecreateWindow
+	}
+	return &recreateTracker{
+		config:  cfg,
+		keys:    cfg.AnnotationKeys(),
+		hash:    controller.NewHashFunc(cfg.IdentityHash),
+		window:  window,
+		nowFunc: time.Now,
+		deletes: make(map[recentDeleteKey]recentDelete),
+	}
+}
+
+// key returns the tracking key of a child.
+func (t *recreateTracker) key(ref *drift.ParentRef, obj client.Object) (recentDeleteKey, bool) {
+	if ref == nil {
+		return recentDeleteKey{}, false
+	}
+	parentGV, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil {
+		return recentDeleteKey{}, false
+	}
+	return recentDeleteKey{
+		parent:    schema.GroupKind{Group: parentGV.Group, Kind: ref.Kind},
+		parentNS:  ref.Namespace,
+		parentObj: ref.Name,
+		child:     obj.GetObjectKind().GroupVersionKind().GroupKind(),
+		namespace: obj.GetNamespace(),
+		name:      obj.GetName(),
+	}, true
+}
+
+// RecordDelete remembers an admitted delete of the child by userID. User deletes are
+// remembered for kinds configured in RecreateAfterDelete, controller deletes if
+// DetectRecreates is enabled. Deletes whose actor cannot be determined are ignored.
+func (t *recreateTracker) RecordDelete(result *drift.DriftResult, userID string, obj client.Object) {
+	if t == nil || result.ParentState == nil {
+		return
+	}
+	isController, canDetermine := drift.IsControllerByHashWithFunc(result.ParentState, userID, drift.ParseUpdaterHashesWithKeys(obj, t.keys), t.hash)
+	if !canDetermine {
+		return
+	}
+	key, ok := t.key(result.ParentRef, obj)
+	if !ok {
+		return
+	}
+	entry := recentDelete{}
+	switch {
+	case !isController && t.config.RecreateClassificationFor(key.child) != "":
+	case isController && t.config.DriftDetection.DetectRecreates:
+		entry.controllerHash = t.hash(userID)
+	default:
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.deletes) >= maxRecentDeletes {
+		t.deletes = make(map[recentDeleteKey]recentDelete)
+	}
+	entry.at = t.nowFunc()
+	t.deletes[key] = entry
+}
+
+// Recreated returns how a CREATE of the child by userID pairs with a delete within the
+// window. The delete is forgotten once matched.
+func (t *recreateTracker) Recreated(ref *drift.ParentRef, obj client.Object, userID string) (recreate, string) {
+	if t == nil {
+		return recreateNone, ""
+	}
+	key, ok := t.key(ref, obj)
+	if !ok {
+		return recreateNone, ""
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	deleted, ok := t.deletes[key]
+	if !ok {
+		return recreateNone, ""
+	}
+	delete(t.deletes, key)
+	if t.nowFunc().Sub(deleted.at) > t.window {
+		return recreateNone, ""
+	}
+	if deleted.controllerHash == "" {
+		return recreateAfterUserDelete, t.config.RecreateClassificationFor(key.child)
+	}
+	if deleted.controllerHash == t.hash(userID) {
+		return recreateByController, ""
+	}
+	return recreateNone, ""
+}
+
+// applyRecreate classifies a controller CREATE of a child that was recently deleted.
+// After a user delete, it is classified per RecreateAfterDelete: as expected, the drift
+// is cleared; as drift, only the reason is refined. After a delete by the same
+// controller, the pair is reported as a recreate.
+func (h *Handler) applyRecreate(obj client.Object, userID string, result *drift.DriftResult, log logr.Logger) {
+	kind, classification := h.recreates.Recreated(result.ParentRef, obj, userID)
+	switch kind {
+	case recreateAfterUserDelete:
+		switch classification {
+		case config.RecreateExpected:
+			log.V(1).Info("controller recreated child deleted by a user, not drift")
+			result.DriftDetected = false
+			result.Reason = "expected change: controller recreated child deleted by a user"
+		case config.RecreateDrift:
+			result.Reason = fmt.Sprintf("%s; controller recreated child deleted by a user", result.Reason)
+		}
+	case recreateByController:
+		log.V(1).Info("controller recreated child it deleted")
+		result.Recreated = true
+		result.Reason = fmt.Sprintf("%s; controller deleted and recreated the child", result.Reason)
+	}
+}
@@ -0,0 +1,146 @@
+package admission
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kausality-io/kausality/pkg/callback/v1alpha1"
+	"github.com/kausality-io/kausality/pkg/config"
+	"github.com/kausality-io/kausality/pkg/drift"
+)
+
+// maxStuckDrifts bounds the blocked drifts tracked in memory. When full, expired
+// entries are evicted, and the map is reset if none expired.
+const maxStuckDrifts = 10000
+
+// stuckTracker counts blocked attempts of the same drift, i.e. of the same controller
+// change to the same child of the same parent, to detect controllers retrying a blocked
+// drift forever. Attempts are kept in memory only.
+type stuckTracker struct {
+	threshold int
+	window    time.Duration
+	nowFunc   func() time.Time
+
+	mu     sync.Mutex
+	drifts map[string]*stuckDrift
+}
+
+type stuckDrift struct {
+	attempts  int
+	nextAlert int
+	last      time.Time
+}
+
+// newStuckTracker returns nil if stuck detection is not enabled.
+func newStuckTracker(cfg *config.Config) *stuckTracker {
+	if cfg == nil || cfg.DriftDetection.StuckDetection == nil {
+		return nil
+	}
+	threshold := cfg.DriftDetection.StuckDetection.Threshold
+	if threshold <= 0 {
+		threshold = config.DefaultStuckThreshold
+	}
+	window := cfg.DriftDetection.StuckDetection.Window
+	if window <= 0 {
+		window = config.DefaultStuckWindow
+	}
+	return &stuckTracker{
+		threshold: threshold,
+		window:    window,
+		nowFunc:   time.Now,
+		drifts:    make(map[string]*stuckDrift),
+	}
+}
+
+// Observe records a blocked attempt of the drift identified by key. It returns the
+// attempts so far and whether they reached the next alert, which is at the threshold
+// and then at every doubling of it.
+func (t *stuckTracker) Observe(key string) (attempts int, alert bool) {
+	now := t.nowFunc()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	d, ok := t.drifts[key]
+	if ok && now.Sub(d.last) > t.window {
+		ok = false
+	}
+	if !ok {
+		if len(t.drifts) >= maxStuckDrifts {
+			t.evictLocked(now)
+		}
+		d = &stuckDrift{nextAlert: t.threshold}
+		t.drifts[key] = d
+	}
+	d.attempts++
+	d.last = now
+	if d.attempts < d.nextAlert {
+		return d.attempts, false
+	}
+	d.nextAlert *= 2
+	return d.attempts, true
+}
+
+func (t *stuckTracker) evictLocked(now time.Time) {
+	for key, d := range t.drifts {
+		if now.Sub(d.last) > t.window {
+			delete(t.drifts, key)
+		}
+	}
+	if len(t.drifts) >= maxStuckDrifts {
+		t.drifts = make(map[string]*stuckDrift)
+	}
+}
+
+// stuckKey identifies a drift by parent, child and spec change.
+func stuckKey(ref *drift.ParentRef, obj client.Object, specDiff []byte) string {
+	parent := string(ref.UID)
+	if parent == "" {
+		parent = ref.String()
+	}
+	child := string(obj.GetUID())
+	if child == "" {
+		gvk := obj.GetObjectKind().GroupVersionKind()
+		child = strings.Join([]string{gvk.Group, gvk.Kind, obj.GetNamespace(), obj.GetName()}, "/")
+	}
+	sum := sha256.Sum256(specDiff)
+	return parent + "\x00" + child + "\x00" + hex.EncodeToString(sum[:])
+}
+
+// observeBlocked records a drift blocked in enforce mode and sends a critical Stuck
+// report when the controller retried it often enough. Dry-runs are not counted.
+func (h *Handler) observeBlocked(ctx context.Context, cfg *config.Config, req admission.Request, obj client.Object, driftResult *drift.DriftResult, parent client.Object, log logr.Logger) {
+	if h.stuck == nil || driftResult.ParentRef == nil || isDryRun(req) {
+		return
+	}
+	attempts, alert := h.stuck.Observe(stuckKey(driftResult.ParentRef, obj, h.computeSpecDiff(cfg, req)))
+	if !alert {
+		return
+	}
+	log.Info("CONTROLLER STUCK - drift blocked repeatedly", "attempts", attempts, "user", req.UserInfo.Username)
+
+	if h.callbackSender == nil || !h.callbackSender.IsEnabled() {
+		return
+	}
+	report := h.buildDriftReport(ctx, cfg, req, obj, driftResult, v1alpha1.DriftReportPhaseStuck)
+	if report == nil {
+		return
+	}
+	if snooze := h.isParentSnoozed(parent, approvalChildRef(obj), log); snooze != nil {
+		log.V(1).Info("stuck callback suppressed", "snooze", snooze.String())
+		return
+	}
+	report.Spec.Severity = v1alpha1.DriftReportSeverityCritical
+	report.Spec.BlockedAttempts = attempts
+	h.callbackSender.SendAsync(ctx, report)
+	log.V(1).Info("stuck callback sent", "id", report.Spec.ID, "attempts", attempts)
+}
@@ -0,0 +1,32 @@
+package admission
+
+import (
+	"encoding/json"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kausalityv1alpha1 "github.com/kausality-io/kausality/api/v1alpha1"
+)
+
+// isDryRun returns whether the API server discards the result of req.
+func isDryRun(req admission.Request) bool {
+	return req.DryRun != nil && *req.DryRun
+}
+
+// isSyntheticDrift returns true if the request injects a synthetic drift via the
+// kausality.io/synthetic-drift annotation. The annotation is only honored on dry-run
+// requests, so an injection exercises enforcement and callbacks without persisting anything.
+func isSyntheticDrift(keys kausalityv1alpha1.AnnotationKeys, req admission.Request) bool {
+	if !isDryRun(req) || len(req.Object.Raw) == 0 {
+		return false
+	}
+	var obj struct {
+		Metadata struct {
+			Annotations map[string]string `json:"annotations"`
+		} `json:"metadata"`
+	}
+	if err := json.Unmarshal(req.Object.Raw, &obj); err != nil {
+		return false
+	}
+	return obj.Metadata.Annotations[keys.SyntheticDrift] == "true"
+}
@@ -0,0 +1,92 @@
+package admission
+
+import (
+	"fmt"
+	"time"
+
+	jsonpatch "gomodules.xyz/jsonpatch/v2"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kausality-io/kausality/pkg/trace"
+)
+
+// TraceConditionType is the type of the condition summarizing an object's causal trace.
+const TraceConditionType = "CausalTrace"
+
+// Reasons of the CausalTrace condition.
+const (
+	// TraceReasonOrigin means the mutation started a new trace.
+	TraceReasonOrigin = "Origin"
+	// TraceReasonPropagated means the mutation extended its parent's trace.
+	TraceReasonPropagated = "Propagated"
+)
+
+// traceConditionPatch returns the patch that sets the CausalTrace condition in the
+// status.conditions of obj, or nil if obj has no conditions array. Objects without one
+// never get one. The lastTransitionTime is kept while the condition stays true.
+//
+// For objects with a status subresource, the API server drops status changes of the
+// main resource, so the condition only sticks for objects without one.
+func traceConditionPatch(obj *unstructured.Unstructured, result *trace.PropagationResult, now time.Time) *jsonpatch.JsonPatchOperation {
+	conditions, found, err := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	if !found || err != nil || len(result.Trace) == 0 {
+		return nil
+	}
+
+	reason := TraceReasonPropagated
+	if result.IsOrigin {
+		reason = TraceReasonOrigin
+	}
+	count := 0
+	for _, hop := range result.Trace {
+		if hop.IsOmitted() {
+			count += hop.Omitted
+		} else {
+			count++
+		}
+	}
+	hops := "1 hop"
+	if count != 1 {
+		hops = fmt.Sprintf("%d hops", count)
+	}
+	origin := result.Trace.Origin()
+	condition := map[string]interface{}{
+		"type":               TraceConditionType,
+		"status":             "True",
+		"reason":             reason,
+		"message":            fmt.Sprintf("origin %s %s by %s, %s", origin.Kind, origin.Name, valueOrUnknown(origin.User), hops),
+		"observedGeneration": obj.GetGeneration(),
+		"lastTransitionTime": now.UTC().Format(time.RFC3339),
+	}
+
+	for i, c := range conditions {
+		existing, ok := c.(map[string]interface{})
+		if !ok || existing["type"] != TraceConditionType {
+			continue
+		}
+		if existing["status"] == "True" {
+			if t, ok := existing["lastTransitionTime"].(string); ok && t != "" {
+				condition["lastTransitionTime"] = t
+			}
+		}
+		return &jsonpatch.JsonPatchOperation{
+			Operation: "replace",
+			Path:      fmt.Sprintf("/status/conditions/%d", i),
+			Value:     condition,
+		}
+	}
+	return &jsonpatch.JsonPatchOperation{
+		Operation: "add",
+		Path:      "/status/conditions/-",
+		Value:     condition,
+	}
+}
+
+// valueOrUnknown returns s, or "unknown" if s is empty.
+func valueOrUnknown(s string) string {
+	if s == "" {
+		return "unknown"
+	}
+	return s
+}
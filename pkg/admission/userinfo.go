@@ -0,0 +1,55 @@
+package admission
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kausality-io/kausality/pkg/config"
+	"github.com/kausality-io/kausality/pkg/drift"
+)
+
+// anonymousUserID is the identifier requests without user info are attributed to. It is
+// never recorded as updater or controller, so it never matches a child's updaters.
+const anonymousUserID = "kausality:anonymous"
+
+// hasUserInfo returns whether the request carries a username or UID to attribute it to.
+func hasUserInfo(req admission.Request) bool {
+	return req.UserInfo.Username != "" || req.UserInfo.UID != ""
+}
+
+// onMissingUserInfo returns the configured OnMissingUserInfo decision.
+func (h *Handler) onMissingUserInfo(cfg *config.Config) string {
+	if cfg == nil || cfg.DriftDetection.OnMissingUserInfo == "" {
+		return config.MissingUserInfoAnonymousUser
+	}
+	return cfg.DriftDetection.OnMissingUserInfo
+}
+
+// classifyAnonymous overrides the actor classification of drift detection for a request
+// without user info, as configured by OnMissingUserInfo. Lifecycle decisions are kept.
+func (h *Handler) classifyAnonymous(cfg *config.Config, result *drift.DriftResult) {
+	if result.ParentState == nil {
+		return
+	}
+	switch result.LifecyclePhase {
+	case drift.PhaseDeleting, drift.PhasePaused, drift.PhaseInitializing, drift.PhaseReconciling:
+		return
+	}
+
+	result.ControllerUnknown = false
+	state := result.ParentState
+	if h.onMissingUserInfo(cfg) != config.MissingUserInfoAnonymousController {
+		result.DriftDetected = false
+		result.Reason = "request without user info treated as a user change"
+		return
+	}
+	result.DriftDetected = state.Generation == state.ObservedGeneration
+	if result.DriftDetected {
+		result.Reason = fmt.Sprintf("drift detected: request without user info treated as controller, parent generation (%d) == observedGeneration (%d)",
+			state.Generation, state.ObservedGeneration)
+	} else {
+		result.Reason = fmt.Sprintf("expected change: parent generation (%d) != observedGeneration (%d)",
+			state.Generation, state.ObservedGeneration)
+	}
+}
@@ -0,0 +1,103 @@
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kausalityv1alpha1 "github.com/kausality-io/kausality/api/v1alpha1"
+	"github.com/kausality-io/kausality/pkg/approval"
+	"github.com/kausality-io/kausality/pkg/drift"
+)
+
+// annotationParser parses one of the kausality annotations users write by hand.
+type annotationParser struct {
+	key   string
+	parse func(string) error
+}
+
+// annotationParsers returns the parsers for the annotations under keys.
+func annotationParsers(keys kausalityv1alpha1.AnnotationKeys) []annotationParser {
+	return []annotationParser{
+		{keys.Approvals, func(v string) error {
+			_, err := approval.ParseApprovals(v)
+			return err
+		}},
+		{keys.Rejections, func(v string) error {
+			_, err := approval.ParseRejections(v)
+			return err
+		}},
+		{keys.Freeze, func(v string) error {
+			// "false" explicitly disables a freeze
+			if v == "false" {
+				return nil
+			}
+			_, err := approval.ParseFreeze(v)
+			return err
+		}},
+		{keys.Snooze, func(v string) error {
+			_, err := approval.ParseSnooze(v)
+			return err
+		}},
+		{keys.Parent, func(v string) error {
+			_, err := drift.ParseParentAnnotation(v)
+			return err
+		}},
+	}
+}
+
+// ValidateAnnotations is a validating admission handler that denies CREATEs and UPDATEs
+// setting a malformed kausality.io/approvals, rejections, freeze, snooze or parent annotation,
+// which would otherwise only surface when a drift is not handled as intended. Objects
+// without these annotations, and annotations an UPDATE leaves unchanged, are never denied.
+func ValidateAnnotations(ctx context.Context, req admission.Request) admission.Response {
+	return AnnotationValidator(kausalityv1alpha1.DefaultAnnotationKeys)(ctx, req)
+}
+
+// AnnotationValidator returns ValidateAnnotations for the annotations under keys.
+func AnnotationValidator(keys kausalityv1alpha1.AnnotationKeys) admission.HandlerFunc {
+	parsers := annotationParsers(keys)
+	return func(_ context.Context, req admission.Request) admission.Response {
+		return validateAnnotations(parsers, req)
+	}
+}
+
+func validateAnnotations(parsers []annotationParser, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return admission.Allowed("operation not relevant for annotations")
+	}
+	if req.SubResource != "" {
+		return admission.Allowed("subresource")
+	}
+
+	var obj metav1.PartialObjectMetadata
+	if err := json.Unmarshal(req.Object.Raw, &obj); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	var old metav1.PartialObjectMetadata
+	if req.Operation == admissionv1.Update && len(req.OldObject.Raw) > 0 {
+		if err := json.Unmarshal(req.OldObject.Raw, &old); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+	}
+
+	var errs []string
+	for _, p := range parsers {
+		value := obj.Annotations[p.key]
+		if value == "" || value == old.Annotations[p.key] {
+			continue
+		}
+		if err := p.parse(value); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return admission.Denied("[kausality] " + strings.Join(errs, "; "))
+	}
+	return admission.Allowed("")
+}
@@ -0,0 +1,121 @@
+package admission
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	kausalityv1alpha1 "github.com/kausality-io/kausality/api/v1alpha1"
+)
+
+func TestValidateAnnotations(t *testing.T) {
+	tests := []struct {
+		name        string
+		op          admissionv1.Operation
+		old         map[string]string
+		anns        map[string]string
+		subResource string
+		wantAllowed bool
+		wantMessage string
+	}{
+		{
+			name:        "no annotations",
+			op:          admissionv1.Create,
+			wantAllowed: true,
+		},
+		{
+			name:        "unrelated annotations",
+			op:          admissionv1.Create,
+			anns:        map[string]string{"example.com/approvals": "{broken"},
+			wantAllowed: true,
+		},
+		{
+			name: "valid annotations",
+			op:   admissionv1.Create,
+			anns: map[string]string{
+				kausalityv1alpha1.ApprovalsAnnotation:  `[{"apiVersion":"v1","kind":"ConfigMap","name":"cm","mode":"always"}]`,
+				kausalityv1alpha1.RejectionsAnnotation: `[{"apiVersion":"v1","kind":"ConfigMap","name":"cm","reason":"no"}]`,
+				kausalityv1alpha1.FreezeAnnotation:     `{"user":"admin"}`,
+				kausalityv1alpha1.SnoozeAnnotation:     "2026-01-25T12:00:00Z",
+				kausalityv1alpha1.ParentAnnotation:     "apps/v1/Deployment/default/web",
+			},
+			wantAllowed: true,
+		},
+		{
+			name:        "legacy and disabled freeze",
+			op:          admissionv1.Create,
+			anns:        map[string]string{kausalityv1alpha1.FreezeAnnotation: "false"},
+			wantAllowed: true,
+		},
+		{
+			name:        "malformed approvals",
+			op:          admissionv1.Create,
+			anns:        map[string]string{kausalityv1alpha1.ApprovalsAnnotation: `{"apiVersion":"v1"}`},
+			wantMessage: "[kausality] invalid approvals annotation: ",
+		},
+		{
+			name: "all errors are reported",
+			op:   admissionv1.Update,
+			anns: map[string]string{
+				kausalityv1alpha1.RejectionsAnnotation: `[{broken`,
+				kausalityv1alpha1.SnoozeAnnotation:     "tomorrow",
+			},
+			wantMessage: "[kausality] invalid rejections annotation: invalid character 'b' looking for beginning of object key string; invalid snooze annotation: ",
+		},
+		{
+			name:        "malformed parent",
+			op:          admissionv1.Create,
+			anns:        map[string]string{kausalityv1alpha1.ParentAnnotation: "Deployment/web"},
+			wantMessage: "[kausality] invalid parent annotation ",
+		},
+		{
+			name:        "malformed freeze",
+			op:          admissionv1.Update,
+			anns:        map[string]string{kausalityv1alpha1.FreezeAnnotation: "yes"},
+			wantMessage: "[kausality] invalid freeze annotation: ",
+		},
+		{
+			name:        "unchanged malformed annotation does not block updates",
+			op:          admissionv1.Update,
+			old:         map[string]string{kausalityv1alpha1.ApprovalsAnnotation: "{broken"},
+			anns:        map[string]string{kausalityv1alpha1.ApprovalsAnnotation: "{broken"},
+			wantAllowed: true,
+		},
+		{
+			name:        "subresources are ignored",
+			op:          admissionv1.Update,
+			anns:        map[string]string{kausalityv1alpha1.ApprovalsAnnotation: "{broken"},
+			subResource: "status",
+			wantAllowed: true,
+		},
+		{
+			name:        "deletes are ignored",
+			op:          admissionv1.Delete,
+			old:         map[string]string{kausalityv1alpha1.ApprovalsAnnotation: "{broken"},
+			wantAllowed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var old, obj runtime.Object
+			if tt.op != admissionv1.Create {
+				old = ownedChild("child", tt.old, nil)
+			}
+			if tt.op != admissionv1.Delete {
+				obj = ownedChild("child", tt.anns, nil)
+			}
+			req := newAdmissionRequest(t, tt.op, old, obj, testController)
+			req.SubResource = tt.subResource
+
+			resp := ValidateAnnotations(t.Context(), req)
+			assert.Equal(t, tt.wantAllowed, resp.Allowed, resp.Result)
+			if tt.wantMessage != "" {
+				assert.Contains(t, resp.Result.Message, tt.wantMessage)
+			}
+		})
+	}
+}
@@ -10,6 +10,8 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kausality-io/kausality/api/v1alpha1"
 )
 
 // ObjectRef identifies a Kubernetes object for actions.
@@ -23,11 +25,17 @@ type ObjectRef struct {
 // ActionApplier applies drift actions to Kubernetes objects.
 type ActionApplier struct {
 	client client.Client
+	keys   v1alpha1.AnnotationKeys
 }
 
 // NewActionApplier creates a new ActionApplier.
 func NewActionApplier(c client.Client) *ActionApplier {
-	return &ActionApplier{client: c}
+	return &ActionApplier{client: c, keys: v1alpha1.DefaultAnnotationKeys}
+}
+
+// SetAnnotationKeys writes the annotations of keys rather than the kausality.io/ ones.
+func (a *ActionApplier) SetAnnotationKeys(keys v1alpha1.AnnotationKeys) {
+	a.keys = keys
 }
 
 // ApplyApproval adds an approval annotation to the parent object.
@@ -50,16 +58,16 @@ func (a *ActionApplier) ApplyApproval(ctx context.Context, parent ObjectRef, chi
 	}
 
 	var approvals []Approval
-	if existing := annotations[ApprovalsAnnotation]; existing != "" {
+	if existing := annotations[a.keys.Approvals]; existing != "" {
 		approvals, err = ParseApprovals(existing)
 		if err != nil {
 			return fmt.Errorf("failed to parse existing approvals: %w", err)
 		}
 	}
 
-	// Check if approval already exists
+	// Check if approval already exists; wildcard and selector approvals are kept as they are
 	for i, app := range approvals {
-		if app.Matches(child) {
+		if app.Selector == nil && sameTarget(app.APIVersion, app.Kind, app.Name, child) {
 			// Update existing approval
 			approvals[i].Mode = mode
 			if mode != ModeAlways {
@@ -103,16 +111,16 @@ func (a *ActionApplier) ApplyRejection(ctx context.Context, parent ObjectRef, ch
 	}
 
 	var rejections []Rejection
-	if existing := annotations[RejectionsAnnotation]; existing != "" {
+	if existing := annotations[a.keys.Rejections]; existing != "" {
 		rejections, err = ParseRejections(existing)
 		if err != nil {
 			return fmt.Errorf("failed to parse existing rejections: %w", err)
 		}
 	}
 
-	// Check if rejection already exists
+	// Check if rejection already exists; wildcard and selector rejections are kept as they are
 	for i, rej := range rejections {
-		if rej.Matches(child) {
+		if rej.Selector == nil && sameTarget(rej.APIVersion, rej.Kind, rej.Name, child) {
 			// Update existing rejection
 			rejections[i].Reason = reason
 			rejections[i].Generation = parentObj.GetGeneration()
@@ -157,7 +165,7 @@ func (a *ActionApplier) ApplySnooze(ctx context.Context, parent ObjectRef, durat
 	if err != nil {
 		return fmt.Errorf("failed to marshal snooze: %w", err)
 	}
-	annotations[SnoozeAnnotation] = snoozeValue
+	annotations[a.keys.Snooze] = snoozeValue
 
 	parentObj.SetAnnotations(annotations)
 	if err := a.client.Update(ctx, parentObj); err != nil {
@@ -191,7 +199,7 @@ func (a *ActionApplier) ApplyFreeze(ctx context.Context, parent ObjectRef, user,
 	if err != nil {
 		return fmt.Errorf("failed to marshal freeze: %w", err)
 	}
-	annotations[FreezeAnnotation] = freezeValue
+	annotations[a.keys.Freeze] = freezeValue
 
 	parentObj.SetAnnotations(annotations)
 	if err := a.client.Update(ctx, parentObj); err != nil {
@@ -210,11 +218,11 @@ func (a *ActionApplier) ClearFreeze(ctx context.Context, parent ObjectRef) error
 	}
 
 	annotations := parentObj.GetAnnotations()
-	if annotations == nil || annotations[FreezeAnnotation] == "" {
+	if annotations == nil || annotations[a.keys.Freeze] == "" {
 		return nil // No freeze to clear
 	}
 
-	delete(annotations, FreezeAnnotation)
+	delete(annotations, a.keys.Freeze)
 	parentObj.SetAnnotations(annotations)
 
 	if err := a.client.Update(ctx, parentObj); err != nil {
@@ -237,7 +245,7 @@ func (a *ActionApplier) RemoveApproval(ctx context.Context, parent ObjectRef, ch
 		return nil // No annotations, nothing to remove
 	}
 
-	existing := annotations[ApprovalsAnnotation]
+	existing := annotations[a.keys.Approvals]
 	if existing == "" {
 		return nil // No approvals
 	}
@@ -275,7 +283,7 @@ func (a *ActionApplier) RemoveRejection(ctx context.Context, parent ObjectRef, c
 		return nil // No annotations, nothing to remove
 	}
 
-	existing := annotations[RejectionsAnnotation]
+	existing := annotations[a.keys.Rejections]
 	if existing == "" {
 		return nil // No rejections
 	}
@@ -309,11 +317,11 @@ func (a *ActionApplier) ClearSnooze(ctx context.Context, parent ObjectRef) error
 	}
 
 	annotations := parentObj.GetAnnotations()
-	if annotations == nil || annotations[SnoozeAnnotation] == "" {
+	if annotations == nil || annotations[a.keys.Snooze] == "" {
 		return nil // No snooze to clear
 	}
 
-	delete(annotations, SnoozeAnnotation)
+	delete(annotations, a.keys.Snooze)
 	parentObj.SetAnnotations(annotations)
 
 	if err := a.client.Update(ctx, parentObj); err != nil {
@@ -348,13 +356,13 @@ func (a *ActionApplier) fetchObject(ctx context.Context, ref ObjectRef) (*unstru
 // updateApprovals updates the approvals annotation on the object.
 func (a *ActionApplier) updateApprovals(ctx context.Context, obj *unstructured.Unstructured, annotations map[string]string, approvals []Approval) error {
 	if len(approvals) == 0 {
-		delete(annotations, ApprovalsAnnotation)
+		delete(annotations, a.keys.Approvals)
 	} else {
 		data, err := json.Marshal(approvals)
 		if err != nil {
 			return fmt.Errorf("failed to marshal approvals: %w", err)
 		}
-		annotations[ApprovalsAnnotation] = string(data)
+		annotations[a.keys.Approvals] = string(data)
 	}
 
 	obj.SetAnnotations(annotations)
@@ -368,13 +376,13 @@ func (a *ActionApplier) updateApprovals(ctx context.Context, obj *unstructured.U
 // updateRejections updates the rejections annotation on the object.
 func (a *ActionApplier) updateRejections(ctx context.Context, obj *unstructured.Unstructured, annotations map[string]string, rejections []Rejection) error {
 	if len(rejections) == 0 {
-		delete(annotations, RejectionsAnnotation)
+		delete(annotations, a.keys.Rejections)
 	} else {
 		data, err := json.Marshal(rejections)
 		if err != nil {
 			return fmt.Errorf("failed to marshal rejections: %w", err)
 		}
-		annotations[RejectionsAnnotation] = string(data)
+		annotations[a.keys.Rejections] = string(data)
 	}
 
 	obj.SetAnnotations(annotations)
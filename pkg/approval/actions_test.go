@@ -12,6 +12,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kausality-io/kausality/api/v1alpha1"
 )
 
 func TestActionApplier_ApplyApproval(t *testing.T) {
@@ -105,6 +107,26 @@ func TestActionApplier_ApplyApproval(t *testing.T) {
 	}
 }
 
+func TestActionApplier_AnnotationKeys(t *testing.T) {
+	keys := v1alpha1.NewAnnotationKeys("staging.kausality.io/")
+	parent := createTestParent(5, map[string]string{keys.Freeze: `{"user":"admin"}`})
+	fakeClient := fake.NewClientBuilder().WithObjects(parent).Build()
+
+	applier := NewActionApplier(fakeClient)
+	applier.SetAnnotationKeys(keys)
+	parentRef := ObjectRef{APIVersion: "example.com/v1alpha1", Kind: "TestParent", Namespace: "default", Name: "test-parent"}
+	require.NoError(t, applier.ApplyApproval(context.Background(), parentRef, ChildRef{APIVersion: "v1", Kind: "ConfigMap", Name: "test-cm"}, ModeOnce))
+	require.NoError(t, applier.ClearFreeze(context.Background(), parentRef))
+
+	updated := &unstructured.Unstructured{}
+	updated.SetGroupVersionKind(parent.GroupVersionKind())
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(parent), updated))
+	annotations := updated.GetAnnotations()
+	assert.NotEmpty(t, annotations[keys.Approvals])
+	assert.NotContains(t, annotations, keys.Freeze)
+	assert.NotContains(t, annotations, ApprovalsAnnotation)
+}
+
 func TestActionApplier_ApplyRejection(t *testing.T) {
 	parent := createTestParent(3, nil)
 	fakeClient := fake.NewClientBuilder().WithObjects(parent).Build()
@@ -1,7 +1,12 @@
 package approval
 
 import (
+	"cmp"
+	"slices"
+
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kausality-io/kausality/api/v1alpha1"
 )
 
 // CheckResult contains the result of an approval check.
@@ -20,11 +25,28 @@ type CheckResult struct {
 }
 
 // Checker checks if a child mutation is approved or rejected.
-type Checker struct{}
+type Checker struct {
+	keys v1alpha1.AnnotationKeys
+}
+
+// CheckerOption configures a Checker.
+type CheckerOption func(*Checker)
+
+// WithAnnotationKeys reads approvals and rejections from the annotations of keys
+// rather than the kausality.io/ ones.
+func WithAnnotationKeys(keys v1alpha1.AnnotationKeys) CheckerOption {
+	return func(c *Checker) {
+		c.keys = keys
+	}
+}
 
 // NewChecker creates a new Checker.
-func NewChecker() *Checker {
-	return &Checker{}
+func NewChecker(opts ...CheckerOption) *Checker {
+	c := &Checker{keys: v1alpha1.DefaultAnnotationKeys}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
 // Check checks if a mutation to the given child is approved or rejected.
@@ -34,7 +56,20 @@ func NewChecker() *Checker {
 // 1. Rejection (if matched) - returns Rejected=true
 // 2. Approval (if matched and valid) - returns Approved=true
 // 3. Neither - returns Approved=false, Rejected=false
+//
+// If several approvals or rejections match, the most specific one decides, e.g. an
+// approval for a named child before one for "*" of its kind.
+//
+// Approvals scoped to fields never match, as the changed fields are unknown; use
+// CheckChanges to check them.
 func (c *Checker) Check(parent client.Object, child ChildRef, parentGeneration int64) CheckResult {
+	return c.CheckChanges(parent, child, parentGeneration, nil)
+}
+
+// CheckChanges is like Check for a change of the given spec fields, as JSON Pointers
+// (e.g. "/spec/replicas"). Approvals scoped to fields only match if they cover all
+// changed fields. nil means the changed fields are unknown.
+func (c *Checker) CheckChanges(parent client.Object, child ChildRef, parentGeneration int64, changedFields []string) CheckResult {
 	annotations := parent.GetAnnotations()
 	if annotations == nil {
 		return CheckResult{
@@ -48,12 +83,12 @@ func (c *Checker) Check(parent client.Object, child ChildRef, parentGeneration i
 	}
 
 	// Check approvals
-	return c.checkApprovals(annotations, child, parentGeneration)
+	return c.checkApprovals(annotations, child, parentGeneration, changedFields)
 }
 
 // checkRejections checks if the child is rejected.
 func (c *Checker) checkRejections(annotations map[string]string, child ChildRef, parentGeneration int64) CheckResult {
-	rejectionsStr := annotations[RejectionsAnnotation]
+	rejectionsStr := annotations[c.keys.Rejections]
 	if rejectionsStr == "" {
 		return CheckResult{}
 	}
@@ -65,7 +100,7 @@ func (c *Checker) checkRejections(annotations map[string]string, child ChildRef,
 		}
 	}
 
-	for i := range rejections {
+	for _, i := range bySpecificity(len(rejections), func(i int) int { return rejections[i].Specificity() }) {
 		r := &rejections[i]
 		if r.Matches(child) && r.IsActive(parentGeneration) {
 			return CheckResult{
@@ -80,8 +115,8 @@ func (c *Checker) checkRejections(annotations map[string]string, child ChildRef,
 }
 
 // checkApprovals checks if the child is approved.
-func (c *Checker) checkApprovals(annotations map[string]string, child ChildRef, parentGeneration int64) CheckResult {
-	approvalsStr := annotations[ApprovalsAnnotation]
+func (c *Checker) checkApprovals(annotations map[string]string, child ChildRef, parentGeneration int64, changedFields []string) CheckResult {
+	approvalsStr := annotations[c.keys.Approvals]
 	if approvalsStr == "" {
 		return CheckResult{
 			Reason: "no approval found for child",
@@ -95,9 +130,27 @@ func (c *Checker) checkApprovals(annotations map[string]string, child ChildRef,
 		}
 	}
 
-	for i := range approvals {
+	return c.CheckApprovals(approvals, child, parentGeneration, changedFields)
+}
+
+// CheckApprovals checks if a change of the given spec fields of the child is approved by
+// one of approvals, e.g. auto-approvals of a policy. Like CheckChanges, the most specific
+// matching approval decides.
+func (c *Checker) CheckApprovals(approvals []Approval, child ChildRef, parentGeneration int64, changedFields []string) CheckResult {
+	reason := "no approval found for child"
+	for _, i := range bySpecificity(len(approvals), func(i int) int { return approvals[i].Specificity() }) {
 		a := &approvals[i]
 		if a.Matches(child) {
+			// Scoped approvals not covering the change leave room for another approval
+			if !a.Covers(changedFields) {
+				reason = "approval found but does not cover the changed fields"
+				continue
+			}
+			// Expired approvals never match
+			if a.IsExpired() {
+				reason = "approval found but expired"
+				continue
+			}
 			if a.IsValid(parentGeneration) {
 				return CheckResult{
 					Approved:        true,
@@ -113,14 +166,53 @@ func (c *Checker) checkApprovals(annotations map[string]string, child ChildRef,
 	}
 
 	return CheckResult{
-		Reason: "no approval found for child",
+		Reason: reason,
+	}
+}
+
+// CheckRecursive checks if a change of the given spec fields of a descendant of child is
+// approved by a recursive approval on ancestor, child being the ancestor's child on the
+// path to the descendant. Only recursive approvals are considered, valid at the ancestor's
+// generation, and rejections on ancestors are ignored.
+func (c *Checker) CheckRecursive(ancestor client.Object, child ChildRef, changedFields []string) CheckResult {
+	approvals, err := ParseApprovals(ancestor.GetAnnotations()[c.keys.Approvals])
+	if err != nil {
+		return CheckResult{
+			Reason: "failed to parse approvals: " + err.Error(),
+		}
+	}
+
+	var recursive []Approval
+	for _, a := range approvals {
+		if a.Recursive {
+			recursive = append(recursive, a)
+		}
+	}
+	if len(recursive) == 0 {
+		return CheckResult{
+			Reason: "no recursive approval found",
+		}
+	}
+	return c.CheckApprovals(recursive, child, ancestor.GetGeneration(), changedFields)
+}
+
+// bySpecificity returns the indices of n entries from the most to the least specific,
+// keeping annotation order among equally specific entries.
+func bySpecificity(n int, specificity func(i int) int) []int {
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
 	}
+	slices.SortStableFunc(order, func(i, j int) int {
+		return cmp.Compare(specificity(j), specificity(i))
+	})
+	return order
 }
 
 // CheckFromAnnotations is a convenience function that checks approvals
-// directly from annotation strings.
+// directly from annotation strings. Like Check, approvals scoped to fields never match.
 func CheckFromAnnotations(approvalsStr, rejectionsStr string, child ChildRef, parentGeneration int64) CheckResult {
-	c := &Checker{}
+	c := NewChecker()
 	annotations := map[string]string{
 		ApprovalsAnnotation:  approvalsStr,
 		RejectionsAnnotation: rejectionsStr,
@@ -131,5 +223,5 @@ func CheckFromAnnotations(approvalsStr, rejectionsStr string, child ChildRef, pa
 		return result
 	}
 
-	return c.checkApprovals(annotations, child, parentGeneration)
+	return c.checkApprovals(annotations, child, parentGeneration, nil)
 }
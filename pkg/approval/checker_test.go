@@ -2,6 +2,7 @@ package approval
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -48,6 +49,22 @@ func TestChecker_Check(t *testing.T) {
 			wantApproved:     true,
 			wantRejected:     false,
 		},
+		{
+			name: "matching approval - mode range in range",
+			annotations: map[string]string{
+				ApprovalsAnnotation: `[{"apiVersion":"v1","kind":"ConfigMap","name":"test-cm","generationMin":4,"generationMax":6,"mode":"range"}]`,
+			},
+			parentGeneration: 5,
+			wantApproved:     true,
+		},
+		{
+			name: "matching approval - mode range below range",
+			annotations: map[string]string{
+				ApprovalsAnnotation: `[{"apiVersion":"v1","kind":"ConfigMap","name":"test-cm","generationMin":4,"generationMax":6,"mode":"range"}]`,
+			},
+			parentGeneration: 3,
+			wantApproved:     false,
+		},
 		{
 			name: "matching approval - mode once valid",
 			annotations: map[string]string{
@@ -154,6 +171,178 @@ func TestChecker_Check(t *testing.T) {
 	}
 }
 
+func TestChecker_CheckChanges(t *testing.T) {
+	checker := NewChecker()
+	child := ChildRef{APIVersion: "apps/v1", Kind: "Deployment", Name: "web"}
+	parent := func(approvals string) *unstructured.Unstructured {
+		p := &unstructured.Unstructured{}
+		p.SetAPIVersion("example.com/v1")
+		p.SetKind("App")
+		p.SetName("parent")
+		p.SetAnnotations(map[string]string{ApprovalsAnnotation: approvals})
+		return p
+	}
+	const scoped = `{"apiVersion":"apps/v1","kind":"Deployment","name":"web","mode":"always","fields":["/spec/replicas","/spec/template/metadata/"]}`
+	const unscoped = `{"apiVersion":"apps/v1","kind":"Deployment","name":"web","mode":"always","note":"unscoped"}`
+
+	tests := []struct {
+		name          string
+		approvals     string
+		changedFields []string
+		wantApproved  bool
+	}{
+		{name: "scoped covers the change", approvals: "[" + scoped + "]", changedFields: []string{"/spec/replicas"}, wantApproved: true},
+		{name: "scoped covers fields below a pointer", approvals: "[" + scoped + "]", changedFields: []string{"/spec/replicas", "/spec/template/metadata/labels/app"}, wantApproved: true},
+		{name: "scoped does not cover other fields", approvals: "[" + scoped + "]", changedFields: []string{"/spec/replicas", "/spec/template/spec/containers/0/image"}},
+		{name: "scoped does not cover a sibling with the same prefix", approvals: "[" + scoped + "]", changedFields: []string{"/spec/replicasMax"}},
+		{name: "scoped does not cover unknown changes", approvals: "[" + scoped + "]"},
+		{name: "scoped does not cover no spec change", approvals: "[" + scoped + "]", changedFields: []string{}},
+		{name: "unscoped covers unknown changes", approvals: "[" + unscoped + "]", wantApproved: true},
+		{name: "unscoped covers any change", approvals: "[" + unscoped + "]", changedFields: []string{"/spec/template/spec/containers/0/image"}, wantApproved: true},
+		{name: "unscoped after a non-covering scoped", approvals: "[" + scoped + "," + unscoped + "]", changedFields: []string{"/spec/paused"}, wantApproved: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := checker.CheckChanges(parent(tt.approvals), child, 1, tt.changedFields)
+			assert.Equal(t, tt.wantApproved, result.Approved, result.Reason)
+			if !tt.wantApproved {
+				assert.Equal(t, "approval found but does not cover the changed fields", result.Reason)
+			}
+		})
+	}
+
+	// Check knows no changed fields
+	assert.False(t, checker.Check(parent("["+scoped+"]"), child, 1).Approved)
+}
+
+func TestChecker_CheckApprovals(t *testing.T) {
+	checker := NewChecker()
+	child := ChildRef{APIVersion: "apps/v1", Kind: "Deployment", Name: "web"}
+	approvals := []Approval{
+		{APIVersion: "apps/v1", Kind: "Deployment", Name: "api", Mode: ModeAlways},
+		{APIVersion: "apps/v1", Kind: "Deployment", Name: "web", Mode: ModeGeneration, Generation: 2},
+	}
+
+	result := checker.CheckApprovals(approvals, child, 2, nil)
+	assert.True(t, result.Approved, result.Reason)
+	require.NotNil(t, result.MatchedApproval)
+	assert.Equal(t, "web", result.MatchedApproval.Name)
+
+	result = checker.CheckApprovals(approvals, child, 3, nil)
+	assert.False(t, result.Approved)
+	assert.Equal(t, "approval found but invalid (stale generation)", result.Reason)
+
+	assert.False(t, checker.CheckApprovals(nil, child, 1, nil).Approved)
+}
+
+func TestChecker_ExpiredApprovals(t *testing.T) {
+	checker := NewChecker()
+	child := ChildRef{APIVersion: "v1", Kind: "ConfigMap", Name: "test-cm"}
+	parent := func(approvals string) *unstructured.Unstructured {
+		p := &unstructured.Unstructured{}
+		p.SetAnnotations(map[string]string{ApprovalsAnnotation: approvals})
+		return p
+	}
+	past := time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+	future := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+
+	result := checker.Check(parent(`[{"apiVersion":"v1","kind":"ConfigMap","name":"test-cm","mode":"always","expiresAt":"`+past+`"}]`), child, 1)
+	assert.False(t, result.Approved)
+	assert.Equal(t, "approval found but expired", result.Reason)
+
+	result = checker.Check(parent(`[{"apiVersion":"v1","kind":"ConfigMap","name":"test-cm","mode":"always","expiresAt":"`+future+`"}]`), child, 1)
+	assert.True(t, result.Approved)
+
+	result = checker.Check(parent(`[{"apiVersion":"v1","kind":"ConfigMap","name":"test-cm","mode":"always","expiresAt":"`+past+`"},{"apiVersion":"v1","kind":"ConfigMap","name":"test-cm","generation":1}]`), child, 1)
+	assert.True(t, result.Approved, "a later approval still matches")
+	assert.Nil(t, result.MatchedApproval.ExpiresAt)
+}
+
+func TestChecker_Specificity(t *testing.T) {
+	checker := NewChecker()
+	child := ChildRef{APIVersion: "ec2.aws.crossplane.io/v1beta1", Kind: "Instance", Name: "web"}
+	parent := func(anns map[string]string) *unstructured.Unstructured {
+		p := &unstructured.Unstructured{}
+		p.SetAnnotations(anns)
+		return p
+	}
+
+	result := checker.Check(parent(map[string]string{ApprovalsAnnotation: `[
+		{"apiVersion":"*.aws.crossplane.io/v1beta1","kind":"*","name":"*","mode":"always"},
+		{"apiVersion":"ec2.aws.crossplane.io/v1beta1","kind":"Instance","name":"web","generation":1}
+	]`}), child, 1)
+	require.True(t, result.Approved)
+	assert.Equal(t, "web", result.MatchedApproval.Name, "the specific approval is consumed, not the broad one")
+
+	result = checker.Check(parent(map[string]string{ApprovalsAnnotation: `[
+		{"apiVersion":"*.aws.crossplane.io/v1beta1","kind":"*","name":"*","mode":"always"},
+		{"apiVersion":"ec2.aws.crossplane.io/v1beta1","kind":"Instance","name":"web","generation":1}
+	]`}), child, 2)
+	assert.False(t, result.Approved, "a stale specific approval decides")
+
+	result = checker.Check(parent(map[string]string{
+		ApprovalsAnnotation:  `[{"apiVersion":"ec2.aws.crossplane.io/v1beta1","kind":"Instance","name":"web","mode":"always"}]`,
+		RejectionsAnnotation: `[{"apiVersion":"*.aws.crossplane.io/v1beta1","kind":"*","name":"*","reason":"AWS change freeze"},{"apiVersion":"*","kind":"Instance","name":"*","reason":"no instances"}]`,
+	}), child, 1)
+	assert.True(t, result.Rejected, "a broad rejection still wins over an approval")
+	assert.Equal(t, "no instances", result.Reason, "the most specific rejection decides")
+}
+
+func TestChecker_Selector(t *testing.T) {
+	checker := NewChecker()
+	child := ChildRef{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "web-7d9f8", Labels: map[string]string{"app": "web"}}
+	parent := func(anns map[string]string) *unstructured.Unstructured {
+		p := &unstructured.Unstructured{}
+		p.SetAnnotations(anns)
+		return p
+	}
+
+	result := checker.Check(parent(map[string]string{ApprovalsAnnotation: `[
+		{"apiVersion":"apps/v1","kind":"ReplicaSet","selector":{"matchLabels":{"app":"web"}},"mode":"once","generation":1}
+	]`}), child, 3)
+	require.True(t, result.Approved, "a selector approval without name behaves as always")
+	assert.Equal(t, ModeAlways, result.MatchedApproval.EffectiveMode())
+
+	result = checker.Check(parent(map[string]string{ApprovalsAnnotation: `[
+		{"apiVersion":"apps/v1","kind":"ReplicaSet","selector":{"matchLabels":{"app":"web"}},"mode":"always"}
+	]`}), ChildRef{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "api-5c4b2", Labels: map[string]string{"app": "api"}}, 1)
+	assert.False(t, result.Approved)
+
+	result = checker.Check(parent(map[string]string{
+		ApprovalsAnnotation:  `[{"apiVersion":"apps/v1","kind":"ReplicaSet","name":"*","mode":"always"}]`,
+		RejectionsAnnotation: `[{"apiVersion":"apps/v1","kind":"ReplicaSet","selector":{"matchLabels":{"app":"web"}},"reason":"web is frozen"}]`,
+	}), child, 1)
+	assert.True(t, result.Rejected)
+	assert.Equal(t, "web is frozen", result.Reason)
+}
+
+func TestChecker_CheckRecursive(t *testing.T) {
+	checker := NewChecker()
+	child := ChildRef{APIVersion: "example.com/v1", Kind: "Network", Name: "vpc"}
+	ancestor := func(generation int64, approvals string) *unstructured.Unstructured {
+		a := &unstructured.Unstructured{}
+		a.SetGeneration(generation)
+		a.SetAnnotations(map[string]string{ApprovalsAnnotation: approvals})
+		return a
+	}
+
+	result := checker.CheckRecursive(ancestor(1, `[{"apiVersion":"*","kind":"*","name":"*","mode":"always","recursive":true}]`), child, nil)
+	assert.True(t, result.Approved, "recursive wildcard approval covers every child")
+
+	result = checker.CheckRecursive(ancestor(1, `[{"apiVersion":"example.com/v1","kind":"Network","name":"vpc","mode":"always"}]`), child, nil)
+	assert.False(t, result.Approved, "non-recursive approvals on ancestors are ignored")
+
+	result = checker.CheckRecursive(ancestor(1, `[{"apiVersion":"example.com/v1","kind":"Network","name":"other","mode":"always","recursive":true}]`), child, nil)
+	assert.False(t, result.Approved, "recursive approval of another child")
+
+	result = checker.CheckRecursive(ancestor(3, `[{"apiVersion":"example.com/v1","kind":"Network","name":"vpc","generation":3,"recursive":true}]`), child, nil)
+	require.True(t, result.Approved, "generation is compared against the ancestor's")
+	assert.Equal(t, ModeGeneration, result.MatchedApproval.EffectiveMode(), "recursive once approvals are never consumed")
+
+	result = checker.CheckRecursive(ancestor(4, `[{"apiVersion":"example.com/v1","kind":"Network","name":"vpc","generation":3,"recursive":true}]`), child, nil)
+	assert.False(t, result.Approved, "stale generation")
+}
+
 func TestChecker_MatchedApproval(t *testing.T) {
 	checker := NewChecker()
 	child := ChildRef{
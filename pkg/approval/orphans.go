@@ -0,0 +1,159 @@
+package approval
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// maxOrphanEntries bounds the approvals tracked in memory. Approvals beyond it are
+// not tracked until a sweep forgets approvals that are gone.
+const maxOrphanEntries = 10000
+
+// OrphanTracker flags approvals whose referenced child hasn't existed within the TTL
+// after the approval was first observed by a sweep. State is in memory: after a
+// restart every approval gets a fresh TTL, which errs on the side of keeping
+// pre-staged approvals.
+type OrphanTracker struct {
+	ttl     time.Duration
+	remove  bool
+	nowFunc func() time.Time
+
+	mu        sync.Mutex
+	firstSeen map[string]time.Time
+	matched   map[string]struct{}
+	flagged   map[string]struct{}
+	// swept are the approvals observed by the running sweep.
+	swept map[string]struct{}
+}
+
+// NewOrphanTracker creates an OrphanTracker. If remove is set, orphans are removed
+// from the parent; otherwise they are only logged.
+func NewOrphanTracker(ttl time.Duration, remove bool) *OrphanTracker {
+	return &OrphanTracker{
+		ttl:       ttl,
+		remove:    remove,
+		nowFunc:   time.Now,
+		firstSeen: make(map[string]time.Time),
+		matched:   make(map[string]struct{}),
+		flagged:   make(map[string]struct{}),
+		swept:     make(map[string]struct{}),
+	}
+}
+
+// check returns the keys of the approvals of parent that became orphans, if they are
+// to be removed. Children are looked up through reader in the parent's namespace.
+func (t *OrphanTracker) check(ctx context.Context, reader client.Reader, parent client.Object, approvals []Approval, log logr.Logger) map[string]struct{} {
+	orphans := map[string]struct{}{}
+	for _, a := range approvals {
+		if a.HasWildcard() {
+			continue
+		}
+		key := orphanKey(parent, a)
+		if _, ok := orphans[key]; ok {
+			continue
+		}
+		if !t.due(key) || t.childExists(ctx, reader, a, parent.GetNamespace(), key, log) {
+			continue
+		}
+		orphans[key] = struct{}{}
+		log.Info("ORPHAN APPROVAL - referenced child never existed within TTL",
+			"child", fmt.Sprintf("%s/%s/%s", a.APIVersion, a.Kind, a.Name),
+			"ttl", t.ttl, "remove", t.remove)
+	}
+	if len(orphans) == 0 {
+		return nil
+	}
+
+	if t.remove {
+		// Removed orphans are forgotten by the next complete sweep; if the removal
+		// fails, they are still due on the next sweep
+		return orphans
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for key := range orphans {
+		t.flagged[key] = struct{}{}
+	}
+	return nil
+}
+
+// due records the approval and returns true if it is unmatched, unflagged and past the TTL.
+func (t *OrphanTracker) due(key string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.swept[key] = struct{}{}
+	if _, ok := t.matched[key]; ok {
+		return false
+	}
+	if _, ok := t.flagged[key]; ok {
+		return false
+	}
+	first, ok := t.firstSeen[key]
+	if !ok {
+		if len(t.firstSeen) < maxOrphanEntries {
+			t.firstSeen[key] = t.nowFunc()
+		}
+		return false
+	}
+	return t.nowFunc().Sub(first) >= t.ttl
+}
+
+// childExists looks up the approved child. Lookup errors other than NotFound or an
+// unknown kind count as existing, so approvals are only flagged on certain evidence.
+func (t *OrphanTracker) childExists(ctx context.Context, reader client.Reader, a Approval, namespace, key string, log logr.Logger) bool {
+	gv, err := schema.ParseGroupVersion(a.APIVersion)
+	if err != nil {
+		return false
+	}
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gv.WithKind(a.Kind))
+	err = reader.Get(ctx, client.ObjectKey{Namespace: namespace, Name: a.Name}, obj)
+	if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+		return false
+	}
+	if err != nil {
+		log.V(1).Info("failed to look up approved child", "child", a.Kind+"/"+a.Name, "error", err)
+		return true
+	}
+	t.mu.Lock()
+	t.matched[key] = struct{}{}
+	t.mu.Unlock()
+	return true
+}
+
+// finishSweep forgets approvals not observed by a complete sweep, i.e. approvals
+// removed from their parent since.
+func (t *OrphanTracker) finishSweep() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, m := range []map[string]struct{}{t.matched, t.flagged} {
+		for key := range m {
+			if _, ok := t.swept[key]; !ok {
+				delete(m, key)
+			}
+		}
+	}
+	for key := range t.firstSeen {
+		if _, ok := t.swept[key]; !ok {
+			delete(t.firstSeen, key)
+		}
+	}
+	t.swept = make(map[string]struct{})
+}
+
+// orphanKey identifies an approval on a parent, independent of mode and generation.
+func orphanKey(parent client.Object, a Approval) string {
+	gvk := parent.GetObjectKind().GroupVersionKind()
+	return gvk.GroupKind().String() + "/" + parent.GetNamespace() + "/" + parent.GetName() + "/" + a.APIVersion + "/" + a.Kind + "/" + a.Name
+}
@@ -0,0 +1,87 @@
+package approval
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestSweeper_Sweep_OrphanApprovals(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "example.com", Version: "v1alpha1", Kind: "TestParent"}
+	approvals, err := MarshalApprovals([]Approval{
+		{APIVersion: "v1", Kind: "ConfigMap", Name: "existing", Mode: ModeAlways},
+		{APIVersion: "v1", Kind: "ConfigMap", Name: "pre-staged", Mode: ModeAlways},
+		{APIVersion: "v1", Kind: "ConfigMap", Name: "typo", Mode: ModeAlways},
+		{APIVersion: "v1", Kind: "ConfigMap", Name: "*", Mode: ModeAlways},
+	})
+	require.NoError(t, err)
+
+	setup := func(t *testing.T, remove bool) (*Sweeper, client.Client, *time.Time) {
+		existing := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "existing"}}
+		c := fake.NewClientBuilder().WithObjects(createTestParent(5, map[string]string{ApprovalsAnnotation: approvals}), existing).Build()
+		now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+		orphans := NewOrphanTracker(time.Hour, remove)
+		orphans.nowFunc = func() time.Time { return now }
+		sweeper := NewSweeper(c, c, []schema.GroupVersionKind{gvk}, time.Minute, logr.Discard())
+		sweeper.SetOrphanTracker(orphans)
+		return sweeper, c, &now
+	}
+	sweep := func(t *testing.T, s *Sweeper) int {
+		pruned, err := s.Sweep(context.Background())
+		require.NoError(t, err)
+		return pruned
+	}
+	parentApprovals := func(t *testing.T, c client.Client) []string {
+		parent := &unstructured.Unstructured{}
+		parent.SetGroupVersionKind(gvk)
+		require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: "test-parent"}, parent))
+		parsed, err := ParseApprovals(parent.GetAnnotations()[ApprovalsAnnotation])
+		require.NoError(t, err)
+		var names []string
+		for _, a := range parsed {
+			names = append(names, a.Name)
+		}
+		return names
+	}
+
+	t.Run("warn keeps never-matched approvals and flags them once", func(t *testing.T) {
+		s, c, now := setup(t, false)
+
+		assert.Zero(t, sweep(t, s), "first observation starts the TTL")
+		*now = now.Add(time.Hour)
+		assert.Zero(t, sweep(t, s))
+		assert.Equal(t, []string{"existing", "pre-staged", "typo", "*"}, parentApprovals(t, c))
+		assert.Contains(t, s.orphans.flagged, orphanKey(createTestParent(5, nil), Approval{APIVersion: "v1", Kind: "ConfigMap", Name: "typo"}))
+	})
+
+	t.Run("remove drops never-matched approvals after the TTL", func(t *testing.T) {
+		s, c, now := setup(t, true)
+
+		assert.Zero(t, sweep(t, s))
+		*now = now.Add(30 * time.Minute)
+		assert.Zero(t, sweep(t, s), "within TTL")
+
+		// The pre-staged child shows up before the TTL expires
+		require.NoError(t, c.Create(context.Background(), &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pre-staged"}}))
+
+		*now = now.Add(30 * time.Minute)
+		assert.Equal(t, 1, sweep(t, s))
+		assert.Equal(t, []string{"existing", "pre-staged", "*"}, parentApprovals(t, c))
+
+		*now = now.Add(time.Hour)
+		assert.Zero(t, sweep(t, s))
+		assert.NotContains(t, s.orphans.firstSeen, orphanKey(createTestParent(5, nil), Approval{APIVersion: "v1", Kind: "ConfigMap", Name: "typo"}),
+			"removed approvals are forgotten")
+	})
+}
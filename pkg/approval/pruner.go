@@ -1,5 +1,11 @@
 package approval
 
+import (
+	"slices"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
 // Pruner removes stale or consumed approvals.
 type Pruner struct{}
 
@@ -9,17 +15,14 @@ func NewPruner() *Pruner {
 }
 
 // ConsumeOnce removes a mode=once approval from the list after it's used.
-// Returns the updated list and true if an approval was consumed.
+// Returns the updated list and true if an approval was consumed. Only the consumed
+// entry itself is removed, not broader wildcard approvals that also match its child.
 func (p *Pruner) ConsumeOnce(approvals []Approval, consumed *Approval) ([]Approval, bool) {
 	if consumed == nil {
 		return approvals, false
 	}
 
-	mode := consumed.Mode
-	if mode == "" {
-		mode = ModeOnce
-	}
-	if mode != ModeOnce {
+	if consumed.EffectiveMode() != ModeOnce {
 		return approvals, false
 	}
 
@@ -27,11 +30,13 @@ func (p *Pruner) ConsumeOnce(approvals []Approval, consumed *Approval) ([]Approv
 	result := make([]Approval, 0, len(approvals))
 	found := false
 	for _, a := range approvals {
-		if !found && a.Matches(ChildRef{
+		if !found && sameTarget(a.APIVersion, a.Kind, a.Name, ChildRef{
 			APIVersion: consumed.APIVersion,
 			Kind:       consumed.Kind,
 			Name:       consumed.Name,
-		}) && a.Generation == consumed.Generation && a.Mode == consumed.Mode {
+		}) && equality.Semantic.DeepEqual(a.Selector, consumed.Selector) &&
+			a.Generation == consumed.Generation && a.Mode == consumed.Mode &&
+			slices.Equal(a.Fields, consumed.Fields) && a.ExpiresAt.Equal(consumed.ExpiresAt) {
 			found = true
 			continue // Skip this one (consume it)
 		}
@@ -41,19 +46,27 @@ func (p *Pruner) ConsumeOnce(approvals []Approval, consumed *Approval) ([]Approv
 	return result, found
 }
 
-// PruneStale removes approvals that are stale due to parent generation change.
-// Removes mode=once and mode=generation approvals where approval.generation < parentGeneration.
-// mode=always approvals are never pruned.
+// sameTarget checks if apiVersion/kind/name are literally the child's, without
+// wildcard matching.
+func sameTarget(apiVersion, kind, name string, child ChildRef) bool {
+	return apiVersion == child.APIVersion && kind == child.Kind && name == child.Name
+}
+
+// PruneStale removes approvals that are stale due to parent generation change or expiry.
+// Removes mode=once and mode=generation approvals where approval.generation < parentGeneration,
+// mode=range approvals where approval.generationMax < parentGeneration, and expired
+// approvals in any mode. Other mode=always approvals, including mode=once
+// selector approvals without a name, are never pruned.
 func (p *Pruner) PruneStale(approvals []Approval, parentGeneration int64) []Approval {
 	result := make([]Approval, 0, len(approvals))
 
 	for _, a := range approvals {
-		mode := a.Mode
-		if mode == "" {
-			mode = ModeOnce
+		// Expired approvals are stale in any mode
+		if a.IsExpired() {
+			continue
 		}
 
-		switch mode {
+		switch a.EffectiveMode() {
 		case ModeAlways:
 			// Never prune
 			result = append(result, a)
@@ -63,6 +76,11 @@ func (p *Pruner) PruneStale(approvals []Approval, parentGeneration int64) []Appr
 				result = append(result, a)
 			}
 			// Otherwise it's stale, don't include
+		case ModeRange:
+			// Keep until the parent generation has passed the range
+			if a.GenerationMax >= parentGeneration {
+				result = append(result, a)
+			}
 		default:
 			// Unknown mode - keep it to be safe
 			result = append(result, a)
@@ -72,6 +90,20 @@ func (p *Pruner) PruneStale(approvals []Approval, parentGeneration int64) []Appr
 	return result
 }
 
+// PruneOrphans removes approvals for which orphaned returns true.
+// Returns the kept approvals and the removed ones.
+func (p *Pruner) PruneOrphans(approvals []Approval, orphaned func(Approval) bool) (kept, removed []Approval) {
+	kept = make([]Approval, 0, len(approvals))
+	for _, a := range approvals {
+		if orphaned(a) {
+			removed = append(removed, a)
+			continue
+		}
+		kept = append(kept, a)
+	}
+	return kept, removed
+}
+
 // PruneResult contains the result of pruning operations.
 type PruneResult struct {
 	// Approvals is the updated list after pruning.
@@ -80,6 +112,8 @@ type PruneResult struct {
 	Changed bool
 	// RemovedCount is the number of approvals removed.
 	RemovedCount int
+	// Consumed indicates if the consumed mode=once approval was found and removed.
+	Consumed bool
 }
 
 // Prune performs both consume and stale pruning in one operation.
@@ -88,7 +122,7 @@ func (p *Pruner) Prune(approvals []Approval, consumed *Approval, parentGeneratio
 	originalLen := len(approvals)
 
 	// First consume the used approval
-	result, _ := p.ConsumeOnce(approvals, consumed)
+	result, found := p.ConsumeOnce(approvals, consumed)
 
 	// Then prune stale approvals
 	result = p.PruneStale(result, parentGeneration)
@@ -97,5 +131,6 @@ func (p *Pruner) Prune(approvals []Approval, consumed *Approval, parentGeneratio
 		Approvals:    result,
 		Changed:      len(result) != originalLen,
 		RemovedCount: originalLen - len(result),
+		Consumed:     found,
 	}
 }
@@ -2,8 +2,11 @@ package approval
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestPruner_ConsumeOnce(t *testing.T) {
@@ -72,6 +75,42 @@ func TestPruner_ConsumeOnce(t *testing.T) {
 	}
 }
 
+func TestPruner_ConsumeOnceScoped(t *testing.T) {
+	unscoped := Approval{APIVersion: "v1", Kind: "ConfigMap", Name: "a", Generation: 5, Mode: ModeOnce}
+	scoped := Approval{APIVersion: "v1", Kind: "ConfigMap", Name: "a", Generation: 5, Mode: ModeOnce, Fields: []string{"/spec/replicas"}}
+
+	result, changed := NewPruner().ConsumeOnce([]Approval{unscoped, scoped}, &scoped)
+	assert.True(t, changed)
+	assert.Equal(t, []Approval{unscoped}, result)
+}
+
+func TestPruner_ConsumeOnceKeepsWildcards(t *testing.T) {
+	broad := Approval{APIVersion: "v1", Kind: "ConfigMap", Name: "*", Generation: 1, Mode: ModeOnce}
+	specific := Approval{APIVersion: "v1", Kind: "ConfigMap", Name: "cm", Generation: 1, Mode: ModeOnce}
+
+	result, consumed := NewPruner().ConsumeOnce([]Approval{broad, specific}, &specific)
+	assert.True(t, consumed)
+	assert.Equal(t, []Approval{broad}, result)
+}
+
+func TestPruner_Selector(t *testing.T) {
+	web := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}
+	unnamed := Approval{APIVersion: "apps/v1", Kind: "ReplicaSet", Selector: web, Generation: 1, Mode: ModeOnce}
+	named := Approval{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "web-7d9f8", Selector: web, Generation: 1, Mode: ModeOnce}
+	plain := Approval{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "web-7d9f8", Generation: 1, Mode: ModeOnce}
+
+	pruner := NewPruner()
+	result, consumed := pruner.ConsumeOnce([]Approval{unnamed}, &unnamed)
+	assert.False(t, consumed, "selector approvals without a name are never consumed")
+	assert.Equal(t, []Approval{unnamed}, result)
+
+	result, consumed = pruner.ConsumeOnce([]Approval{plain, named}, &named)
+	assert.True(t, consumed)
+	assert.Equal(t, []Approval{plain}, result, "only the entry with the same selector is consumed")
+
+	assert.Equal(t, []Approval{unnamed}, pruner.PruneStale([]Approval{unnamed, named}, 2), "selector approvals without a name are never stale")
+}
+
 func TestPruner_PruneStale(t *testing.T) {
 	pruner := NewPruner()
 
@@ -126,6 +165,28 @@ func TestPruner_PruneStale(t *testing.T) {
 			wantLen:          3, // always, current, future
 			wantNames:        []string{"always", "current", "future"},
 		},
+		{
+			name: "prune expired in any mode",
+			approvals: []Approval{
+				{APIVersion: "v1", Kind: "ConfigMap", Name: "expired", Mode: ModeAlways, ExpiresAt: &metav1.Time{Time: time.Now().Add(-time.Minute)}},
+				{APIVersion: "v1", Kind: "ConfigMap", Name: "expired-current", Generation: 5, Mode: ModeGeneration, ExpiresAt: &metav1.Time{Time: time.Now().Add(-time.Minute)}},
+				{APIVersion: "v1", Kind: "ConfigMap", Name: "unexpired", Mode: ModeAlways, ExpiresAt: &metav1.Time{Time: time.Now().Add(time.Hour)}},
+			},
+			parentGeneration: 5,
+			wantLen:          1,
+			wantNames:        []string{"unexpired"},
+		},
+		{
+			name: "keep mode=range until the generation exceeds max",
+			approvals: []Approval{
+				{APIVersion: "v1", Kind: "ConfigMap", Name: "upcoming", GenerationMin: 6, GenerationMax: 8, Mode: ModeRange},
+				{APIVersion: "v1", Kind: "ConfigMap", Name: "current", GenerationMin: 3, GenerationMax: 5, Mode: ModeRange},
+				{APIVersion: "v1", Kind: "ConfigMap", Name: "passed", GenerationMin: 1, GenerationMax: 4, Mode: ModeRange},
+			},
+			parentGeneration: 5,
+			wantLen:          2,
+			wantNames:        []string{"upcoming", "current"},
+		},
 		{
 			name: "prune mode=generation when stale",
 			approvals: []Approval{
@@ -159,6 +220,7 @@ func TestPruner_Prune(t *testing.T) {
 		parentGeneration int64
 		wantLen          int
 		wantChanged      bool
+		wantConsumed     bool
 	}{
 		{
 			name: "consume and prune",
@@ -171,6 +233,17 @@ func TestPruner_Prune(t *testing.T) {
 			parentGeneration: 5,
 			wantLen:          1, // only "keep" remains
 			wantChanged:      true,
+			wantConsumed:     true,
+		},
+		{
+			name: "range approval is not consumed",
+			approvals: []Approval{
+				{APIVersion: "v1", Kind: "ConfigMap", Name: "rollout", GenerationMin: 4, GenerationMax: 6, Mode: ModeRange},
+			},
+			consumed:         &Approval{APIVersion: "v1", Kind: "ConfigMap", Name: "rollout", GenerationMin: 4, GenerationMax: 6, Mode: ModeRange},
+			parentGeneration: 5,
+			wantLen:          1,
+			wantChanged:      false,
 		},
 		{
 			name: "nothing to prune",
@@ -189,6 +262,41 @@ func TestPruner_Prune(t *testing.T) {
 			result := pruner.Prune(tt.approvals, tt.consumed, tt.parentGeneration)
 			assert.Len(t, result.Approvals, tt.wantLen)
 			assert.Equal(t, tt.wantChanged, result.Changed)
+			assert.Equal(t, tt.wantConsumed, result.Consumed)
 		})
 	}
 }
+
+func TestPruner_PruneOrphans(t *testing.T) {
+	pruner := NewPruner()
+	approvals := []Approval{
+		{APIVersion: "v1", Kind: "ConfigMap", Name: "exists", Mode: ModeAlways},
+		{APIVersion: "v1", Kind: "ConfigMap", Name: "typo", Mode: ModeAlways},
+		{APIVersion: "v1", Kind: "ConfigMap", Name: "exists", Generation: 3},
+	}
+
+	kept, removed := pruner.PruneOrphans(approvals, func(a Approval) bool { return a.Name == "typo" })
+	assert.Equal(t, []Approval{approvals[0], approvals[2]}, kept)
+	assert.Equal(t, []Approval{approvals[1]}, removed)
+
+	kept, removed = pruner.PruneOrphans(approvals, func(Approval) bool { return false })
+	assert.Equal(t, approvals, kept)
+	assert.Empty(t, removed)
+}
+
+func TestPruner_PreservesNotes(t *testing.T) {
+	pruner := NewPruner()
+	approvals := []Approval{
+		{APIVersion: "v1", Kind: "ConfigMap", Name: "a", Generation: 5, Mode: ModeOnce, Note: "approved per CHG-1"},
+		{APIVersion: "v1", Kind: "ConfigMap", Name: "b", Generation: 5, Mode: ModeGeneration, Note: "approved per CHG-2"},
+		{APIVersion: "v1", Kind: "ConfigMap", Name: "c", Generation: 4, Mode: ModeGeneration, Note: "approved per CHG-3"},
+		{APIVersion: "v1", Kind: "Secret", Name: "d", Mode: ModeAlways, Note: "approved per CHG-4"},
+	}
+
+	result := pruner.Prune(approvals, &approvals[0], 5)
+	assert.True(t, result.Changed)
+	assert.Equal(t, []Approval{
+		{APIVersion: "v1", Kind: "ConfigMap", Name: "b", Generation: 5, Mode: ModeGeneration, Note: "approved per CHG-2"},
+		{APIVersion: "v1", Kind: "Secret", Name: "d", Mode: ModeAlways, Note: "approved per CHG-4"},
+	}, result.Approvals)
+}
@@ -0,0 +1,183 @@
+package approval
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kausality-io/kausality/api/v1alpha1"
+)
+
+// sweepPageSize is the number of objects fetched per list call during a sweep.
+const sweepPageSize int64 = 500
+
+// Sweeper periodically prunes stale approvals from parents, e.g. mode=once approvals
+// of parents whose children never drift again. Approvals are otherwise only pruned
+// when one is consumed on admission. With an OrphanTracker, it also flags or removes
+// approvals whose child never existed.
+type Sweeper struct {
+	reader   client.Reader
+	writer   client.Client
+	kinds    []schema.GroupVersionKind
+	interval time.Duration
+	pruner   *Pruner
+	orphans  *OrphanTracker
+	keys     v1alpha1.AnnotationKeys
+	log      logr.Logger
+}
+
+// NewSweeper creates a Sweeper that lists the given parent kinds through reader
+// every interval and writes pruned approvals back through writer.
+func NewSweeper(reader client.Reader, writer client.Client, kinds []schema.GroupVersionKind, interval time.Duration, log logr.Logger) *Sweeper {
+	return &Sweeper{
+		reader:   reader,
+		writer:   writer,
+		kinds:    kinds,
+		interval: interval,
+		pruner:   NewPruner(),
+		keys:     v1alpha1.DefaultAnnotationKeys,
+		log:      log.WithName("approval-sweeper"),
+	}
+}
+
+// SetAnnotationKeys sweeps the approvals annotation of keys rather than the kausality.io/ one.
+func (s *Sweeper) SetAnnotationKeys(keys v1alpha1.AnnotationKeys) {
+	s.keys = keys
+}
+
+// SetOrphanTracker makes sweeps check for orphan approvals. Nil disables the check.
+func (s *Sweeper) SetOrphanTracker(orphans *OrphanTracker) {
+	s.orphans = orphans
+}
+
+// SetupSweeper registers a Sweeper with the manager. Parents and approved children
+// are read uncached to avoid informers on every kind, and parents are updated as
+// fieldManager, so that the webhook recognizes the writes as its own. orphans may be nil.
+func SetupSweeper(mgr ctrl.Manager, kinds []schema.GroupVersionKind, interval time.Duration, fieldManager string, keys v1alpha1.AnnotationKeys, orphans *OrphanTracker, log logr.Logger) error {
+	writer := client.WithFieldOwner(mgr.GetClient(), fieldManager)
+	sweeper := NewSweeper(mgr.GetAPIReader(), writer, kinds, interval, log)
+	sweeper.SetAnnotationKeys(keys)
+	sweeper.SetOrphanTracker(orphans)
+	return mgr.Add(sweeper)
+}
+
+// Start sweeps every interval until ctx is done. It implements manager.Runnable.
+func (s *Sweeper) Start(ctx context.Context) error {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			pruned, err := s.Sweep(ctx)
+			if err != nil {
+				s.log.Error(err, "approval sweep failed", "pruned", pruned)
+				continue
+			}
+			s.log.V(1).Info("approval sweep done", "pruned", pruned)
+		}
+	}
+}
+
+// Sweep prunes stale approvals from all objects of the configured kinds once and
+// returns the number of updated objects. Objects are only updated if approvals were
+// removed, so repeated sweeps do not write. Objects that changed concurrently are
+// skipped until the next sweep.
+func (s *Sweeper) Sweep(ctx context.Context) (int, error) {
+	pruned := 0
+	for _, gvk := range s.kinds {
+		cont := ""
+		for {
+			list := &unstructured.UnstructuredList{}
+			list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+			opts := []client.ListOption{client.Limit(sweepPageSize)}
+			if cont != "" {
+				opts = append(opts, client.Continue(cont))
+			}
+			if err := s.reader.List(ctx, list, opts...); err != nil {
+				return pruned, fmt.Errorf("failed to list %s: %w", gvk.Kind, err)
+			}
+			for i := range list.Items {
+				obj := &list.Items[i]
+				obj.SetGroupVersionKind(gvk)
+				changed, err := s.prune(ctx, obj)
+				if err != nil {
+					return pruned, err
+				}
+				if changed {
+					pruned++
+				}
+			}
+			cont = list.GetContinue()
+			if cont == "" {
+				break
+			}
+		}
+	}
+	if s.orphans != nil {
+		s.orphans.finishSweep()
+	}
+	return pruned, nil
+}
+
+// prune removes stale approvals from obj and returns whether it was updated.
+func (s *Sweeper) prune(ctx context.Context, obj *unstructured.Unstructured) (bool, error) {
+	annotations := obj.GetAnnotations()
+	value := annotations[s.keys.Approvals]
+	if value == "" {
+		return false, nil
+	}
+	log := s.log.WithValues("kind", obj.GetKind(), "namespace", obj.GetNamespace(), "name", obj.GetName())
+
+	approvals, err := ParseApprovals(value)
+	if err != nil {
+		log.V(1).Info("skipping unparseable approvals", "error", err)
+		return false, nil
+	}
+	result := s.pruner.Prune(approvals, nil, obj.GetGeneration())
+	kept, removedCount := result.Approvals, result.RemovedCount
+	if s.orphans != nil {
+		if orphans := s.orphans.check(ctx, s.reader, obj, kept, log); len(orphans) > 0 {
+			var removed []Approval
+			kept, removed = s.pruner.PruneOrphans(kept, func(a Approval) bool {
+				_, ok := orphans[orphanKey(obj, a)]
+				return ok
+			})
+			removedCount += len(removed)
+		}
+	}
+	if removedCount == 0 {
+		return false, nil
+	}
+
+	if len(kept) == 0 {
+		delete(annotations, s.keys.Approvals)
+	} else {
+		pruned, err := MarshalApprovals(kept)
+		if err != nil {
+			return false, fmt.Errorf("failed to marshal approvals: %w", err)
+		}
+		annotations[s.keys.Approvals] = pruned
+	}
+	obj.SetAnnotations(annotations)
+
+	// The list's resourceVersion guards against overwriting concurrent changes
+	if err := s.writer.Update(ctx, obj); err != nil {
+		if apierrors.IsConflict(err) || apierrors.IsNotFound(err) {
+			log.V(1).Info("skipping concurrently changed object", "error", err)
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to update %s %s/%s: %w", obj.GetKind(), obj.GetNamespace(), obj.GetName(), err)
+	}
+	log.Info("pruned stale approvals", "removedCount", removedCount, "remaining", len(kept))
+	return true, nil
+}
@@ -0,0 +1,112 @@
+package approval
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestSweeper_Sweep(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "example.com", Version: "v1alpha1", Kind: "TestParent"}
+	expired := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+
+	tests := []struct {
+		name          string
+		approvals     string
+		wantApprovals string // "" means the annotation is removed
+		wantPruned    int
+	}{
+		{
+			name:          "stale once and generation approvals are pruned",
+			approvals:     `[{"apiVersion":"v1","kind":"ConfigMap","name":"a","mode":"once","generation":4},{"apiVersion":"v1","kind":"ConfigMap","name":"b","mode":"generation","generation":5},{"apiVersion":"v1","kind":"ConfigMap","name":"c","mode":"always"}]`,
+			wantApprovals: `[{"apiVersion":"v1","kind":"ConfigMap","name":"b","generation":5,"mode":"generation"},{"apiVersion":"v1","kind":"ConfigMap","name":"c","mode":"always"}]`,
+			wantPruned:    1,
+		},
+		{
+			name:       "expired approvals are pruned",
+			approvals:  `[{"apiVersion":"v1","kind":"ConfigMap","name":"a","mode":"always","expiresAt":"` + expired + `"}]`,
+			wantPruned: 1,
+		},
+		{
+			name:          "current approvals are kept",
+			approvals:     `[{"apiVersion":"v1","kind":"ConfigMap","name":"a","mode":"once","generation":5}]`,
+			wantApprovals: `[{"apiVersion":"v1","kind":"ConfigMap","name":"a","mode":"once","generation":5}]`,
+		},
+		{
+			name:          "unparseable approvals are left alone",
+			approvals:     `not json`,
+			wantApprovals: `not json`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parent := createTestParent(5, map[string]string{ApprovalsAnnotation: tt.approvals})
+			updates := 0
+			fakeClient := fake.NewClientBuilder().WithObjects(parent).WithInterceptorFuncs(interceptor.Funcs{
+				Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+					updates++
+					return c.Update(ctx, obj, opts...)
+				},
+			}).Build()
+
+			sweeper := NewSweeper(fakeClient, fakeClient, []schema.GroupVersionKind{gvk}, time.Minute, logr.Discard())
+			pruned, err := sweeper.Sweep(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantPruned, pruned)
+			assert.Equal(t, tt.wantPruned, updates, "only changed objects are written")
+
+			updated := &unstructured.Unstructured{}
+			updated.SetGroupVersionKind(gvk)
+			require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(parent), updated))
+			switch {
+			case tt.wantApprovals == "":
+				assert.NotContains(t, updated.GetAnnotations(), ApprovalsAnnotation)
+			case tt.wantPruned == 0:
+				assert.Equal(t, tt.approvals, updated.GetAnnotations()[ApprovalsAnnotation], "untouched")
+			default:
+				assert.JSONEq(t, tt.wantApprovals, updated.GetAnnotations()[ApprovalsAnnotation])
+			}
+
+			// A second sweep finds nothing to do
+			pruned, err = sweeper.Sweep(context.Background())
+			require.NoError(t, err)
+			assert.Zero(t, pruned)
+			assert.Equal(t, tt.wantPruned, updates)
+		})
+	}
+}
+
+func TestSweeper_Sweep_SkipsConflicts(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "example.com", Version: "v1alpha1", Kind: "TestParent"}
+	parent := createTestParent(5, map[string]string{ApprovalsAnnotation: `[{"apiVersion":"v1","kind":"ConfigMap","name":"a","mode":"once","generation":4}]`})
+	fakeClient := fake.NewClientBuilder().WithObjects(parent).Build()
+
+	// The parent changes between list and update
+	reader := interceptor.NewClient(fakeClient, interceptor.Funcs{
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			if err := c.List(ctx, list, opts...); err != nil {
+				return err
+			}
+			current := createTestParent(5, nil)
+			require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(parent), current))
+			current.SetLabels(map[string]string{"changed": "true"})
+			return c.Update(ctx, current)
+		},
+	})
+
+	sweeper := NewSweeper(reader, fakeClient, []schema.GroupVersionKind{gvk}, time.Minute, logr.Discard())
+	pruned, err := sweeper.Sweep(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, pruned)
+}
@@ -18,15 +18,24 @@ const (
 	ModeOnce       = v1alpha1.ApprovalModeOnce
 	ModeGeneration = v1alpha1.ApprovalModeGeneration
 	ModeAlways     = v1alpha1.ApprovalModeAlways
+	ModeRange      = v1alpha1.ApprovalModeRange
+)
+
+// Rejection severities - re-exported from api/v1alpha1.
+const (
+	SeverityInfo     = v1alpha1.RejectionSeverityInfo
+	SeverityWarn     = v1alpha1.RejectionSeverityWarn
+	SeverityCritical = v1alpha1.RejectionSeverityCritical
 )
 
 // Types - re-exported from api/v1alpha1.
 type (
-	Approval  = v1alpha1.Approval
-	Rejection = v1alpha1.Rejection
-	ChildRef  = v1alpha1.ChildRef
-	Freeze    = v1alpha1.Freeze
-	Snooze    = v1alpha1.Snooze
+	Approval             = v1alpha1.Approval
+	ApprovalHistoryEntry = v1alpha1.ApprovalHistoryEntry
+	Rejection            = v1alpha1.Rejection
+	ChildRef             = v1alpha1.ChildRef
+	Freeze               = v1alpha1.Freeze
+	Snooze               = v1alpha1.Snooze
 )
 
 // Functions - re-exported from api/v1alpha1.
@@ -38,4 +47,7 @@ var (
 	MarshalFreeze    = v1alpha1.MarshalFreeze
 	ParseSnooze      = v1alpha1.ParseSnooze
 	MarshalSnooze    = v1alpha1.MarshalSnooze
+
+	ParseApprovalHistory  = v1alpha1.ParseApprovalHistory
+	AppendApprovalHistory = v1alpha1.AppendApprovalHistory
 )
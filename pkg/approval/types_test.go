@@ -1,10 +1,12 @@
 package approval
 
 import (
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -70,6 +72,42 @@ func TestApproval_Matches(t *testing.T) {
 			child:    ChildRef{APIVersion: "apps/v1", Kind: "Deployment", Name: "foo"},
 			want:     false,
 		},
+		{
+			name:     "leading group glob matches subgroups",
+			approval: Approval{APIVersion: "*.aws.crossplane.io/v1beta1", Kind: "*", Name: "*"},
+			child:    ChildRef{APIVersion: "ec2.aws.crossplane.io/v1beta1", Kind: "Instance", Name: "web"},
+			want:     true,
+		},
+		{
+			name:     "leading group glob requires the suffix",
+			approval: Approval{APIVersion: "*.aws.crossplane.io/v1beta1", Kind: "*", Name: "*"},
+			child:    ChildRef{APIVersion: "compute.gcp.crossplane.io/v1beta1", Kind: "Instance", Name: "web"},
+			want:     false,
+		},
+		{
+			name:     "group glob requires the version",
+			approval: Approval{APIVersion: "*.aws.crossplane.io/v1beta1", Kind: "*", Name: "*"},
+			child:    ChildRef{APIVersion: "ec2.aws.crossplane.io/v1", Kind: "Instance", Name: "web"},
+			want:     false,
+		},
+		{
+			name:     "trailing group glob and wildcard version",
+			approval: Approval{APIVersion: "ec2.*/*", Kind: "Instance", Name: "*"},
+			child:    ChildRef{APIVersion: "ec2.aws.crossplane.io/v1", Kind: "Instance", Name: "web"},
+			want:     true,
+		},
+		{
+			name:     "wildcard group matches the core group",
+			approval: Approval{APIVersion: "*/v1", Kind: "ConfigMap", Name: "*"},
+			child:    ChildRef{APIVersion: "v1", Kind: "ConfigMap", Name: "cm"},
+			want:     true,
+		},
+		{
+			name:     "suffix glob does not match the core group",
+			approval: Approval{APIVersion: "*.io/v1", Kind: "ConfigMap", Name: "*"},
+			child:    ChildRef{APIVersion: "v1", Kind: "ConfigMap", Name: "cm"},
+			want:     false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -148,6 +186,36 @@ func TestApproval_IsValid(t *testing.T) {
 			parentGeneration: 4,
 			want:             false,
 		},
+		{
+			name: "mode=range - in range",
+			approval: Approval{
+				Mode:          ModeRange,
+				GenerationMin: 5,
+				GenerationMax: 8,
+			},
+			parentGeneration: 8,
+			want:             true,
+		},
+		{
+			name: "mode=range - below range",
+			approval: Approval{
+				Mode:          ModeRange,
+				GenerationMin: 5,
+				GenerationMax: 8,
+			},
+			parentGeneration: 4,
+			want:             false,
+		},
+		{
+			name: "mode=range - above range",
+			approval: Approval{
+				Mode:          ModeRange,
+				GenerationMin: 5,
+				GenerationMax: 8,
+			},
+			parentGeneration: 9,
+			want:             false,
+		},
 		{
 			name: "unknown mode - invalid",
 			approval: Approval{
@@ -197,6 +265,12 @@ func TestRejection_Matches(t *testing.T) {
 			child:     ChildRef{APIVersion: "apps/v1", Kind: "Deployment", Name: "deploy-xyz"},
 			want:      false,
 		},
+		{
+			name:      "group glob matches any kind in the group",
+			rejection: Rejection{APIVersion: "*.aws.crossplane.io/v1beta1", Kind: "*", Name: "*", Reason: "frozen"},
+			child:     ChildRef{APIVersion: "rds.aws.crossplane.io/v1beta1", Kind: "DBInstance", Name: "db"},
+			want:      true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -206,6 +280,145 @@ func TestRejection_Matches(t *testing.T) {
 	}
 }
 
+func TestSpecificity(t *testing.T) {
+	ordered := []Approval{
+		{APIVersion: "v1", Kind: "ConfigMap", Name: "cm"},
+		{APIVersion: "*", Kind: "*", Name: "cm"},
+		{APIVersion: "v1", Kind: "ConfigMap", Name: "*"},
+		{APIVersion: "*.aws.crossplane.io/v1beta1", Kind: "Instance", Name: "*"},
+		{APIVersion: "*", Kind: "Instance", Name: "*"},
+		{APIVersion: "*.aws.crossplane.io/v1beta1", Kind: "*", Name: "*"},
+		{APIVersion: "*", Kind: "*", Name: "*"},
+	}
+	for i := 1; i < len(ordered); i++ {
+		assert.Greater(t, ordered[i-1].Specificity(), ordered[i].Specificity(), "%v before %v", ordered[i-1], ordered[i])
+	}
+	withSelector := []Approval{
+		{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "web-7d9f8"},
+		{APIVersion: "apps/v1", Kind: "ReplicaSet", Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}},
+		{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "*"},
+	}
+	for i := 1; i < len(withSelector); i++ {
+		assert.Greater(t, withSelector[i-1].Specificity(), withSelector[i].Specificity(), "%v before %v", withSelector[i-1], withSelector[i])
+	}
+	r := Rejection{APIVersion: "*.aws.crossplane.io/v1beta1", Kind: "*", Name: "*"}
+	assert.Equal(t, ordered[5].Specificity(), r.Specificity())
+}
+
+func TestApproval_MatchesSelector(t *testing.T) {
+	web := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}
+	child := ChildRef{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "web-7d9f8", Labels: map[string]string{"app": "web", "pod-template-hash": "7d9f8"}}
+
+	tests := []struct {
+		name     string
+		approval Approval
+		child    ChildRef
+		want     bool
+	}{
+		{
+			name:     "selector matches labels",
+			approval: Approval{APIVersion: "apps/v1", Kind: "ReplicaSet", Selector: web},
+			child:    child,
+			want:     true,
+		},
+		{
+			name:     "selector does not match labels",
+			approval: Approval{APIVersion: "apps/v1", Kind: "ReplicaSet", Selector: web},
+			child:    ChildRef{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "api-5c4b2", Labels: map[string]string{"app": "api"}},
+			want:     false,
+		},
+		{
+			name:     "selector does not match a child without labels",
+			approval: Approval{APIVersion: "apps/v1", Kind: "ReplicaSet", Selector: web},
+			child:    ChildRef{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "web-7d9f8"},
+			want:     false,
+		},
+		{
+			name:     "name and selector must both match",
+			approval: Approval{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "web-other", Selector: web},
+			child:    child,
+			want:     false,
+		},
+		{
+			name:     "name and selector match",
+			approval: Approval{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "web-7d9f8", Selector: web},
+			child:    child,
+			want:     true,
+		},
+		{
+			name: "match expressions",
+			approval: Approval{APIVersion: "apps/v1", Kind: "ReplicaSet", Selector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "pod-template-hash", Operator: metav1.LabelSelectorOpExists},
+			}}},
+			child: child,
+			want:  true,
+		},
+		{
+			name:     "empty selector matches any labels",
+			approval: Approval{APIVersion: "apps/v1", Kind: "ReplicaSet", Selector: &metav1.LabelSelector{}},
+			child:    child,
+			want:     true,
+		},
+		{
+			name: "invalid selector matches nothing",
+			approval: Approval{APIVersion: "apps/v1", Kind: "ReplicaSet", Selector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "app", Operator: "Near"},
+			}}},
+			child: child,
+			want:  false,
+		},
+		{
+			name:     "kind must still match",
+			approval: Approval{APIVersion: "apps/v1", Kind: "Deployment", Selector: web},
+			child:    child,
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.approval.Matches(tt.child))
+		})
+	}
+
+	rejection := Rejection{APIVersion: "apps/v1", Kind: "ReplicaSet", Selector: web}
+	assert.True(t, rejection.Matches(child))
+	assert.False(t, rejection.Matches(ChildRef{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "api-5c4b2", Labels: map[string]string{"app": "api"}}))
+}
+
+func TestApproval_EffectiveMode(t *testing.T) {
+	web := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}
+	tests := []struct {
+		name     string
+		approval Approval
+		want     string
+	}{
+		{name: "default", approval: Approval{Name: "cm"}, want: ModeOnce},
+		{name: "generation", approval: Approval{Name: "cm", Mode: ModeGeneration}, want: ModeGeneration},
+		{name: "selector once behaves as always", approval: Approval{Selector: web, Mode: ModeOnce}, want: ModeAlways},
+		{name: "selector default behaves as always", approval: Approval{Selector: web}, want: ModeAlways},
+		{name: "selector with wildcard name behaves as always", approval: Approval{Name: "*", Selector: web}, want: ModeAlways},
+		{name: "selector with name stays once", approval: Approval{Name: "web-7d9f8", Selector: web}, want: ModeOnce},
+		{name: "selector generation stays generation", approval: Approval{Selector: web, Mode: ModeGeneration}, want: ModeGeneration},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.approval.EffectiveMode())
+		})
+	}
+
+	// Selector approvals without a name are valid at any generation
+	assert.True(t, (&Approval{Selector: web, Generation: 1}).IsValid(5))
+}
+
+func TestApproval_HasWildcard(t *testing.T) {
+	assert.False(t, (&Approval{APIVersion: "apps/v1", Kind: "Deployment", Name: "web"}).HasWildcard())
+	assert.True(t, (&Approval{APIVersion: "apps/v1", Kind: "Deployment", Name: "*"}).HasWildcard())
+	assert.True(t, (&Approval{APIVersion: "*.aws.crossplane.io/v1beta1", Kind: "Instance", Name: "web"}).HasWildcard())
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}
+	assert.True(t, (&Approval{APIVersion: "apps/v1", Kind: "ReplicaSet", Selector: selector}).HasWildcard())
+	assert.False(t, (&Approval{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "web-7d9f8", Selector: selector}).HasWildcard())
+}
+
 func TestRejection_IsActive(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -283,6 +496,16 @@ func TestParseApprovals(t *testing.T) {
 			wantLen: 0,
 			wantErr: true,
 		},
+		{
+			name:    "range approval",
+			input:   `[{"apiVersion":"v1","kind":"ConfigMap","name":"test","mode":"range","generationMin":3,"generationMax":5}]`,
+			wantLen: 1,
+		},
+		{
+			name:    "range approval with min above max",
+			input:   `[{"apiVersion":"v1","kind":"ConfigMap","name":"test","mode":"range","generationMin":6,"generationMax":5}]`,
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -388,6 +611,39 @@ func TestParseFreeze(t *testing.T) {
 	}
 }
 
+func TestFreeze_RoundTrip(t *testing.T) {
+	until := metav1.NewTime(time.Date(2026, 1, 25, 10, 30, 0, 0, time.UTC))
+	freeze := &Freeze{User: "admin@example.com", Message: "deploy", At: metav1.NewTime(until.Add(-30 * time.Minute)), Until: &until}
+
+	s, err := MarshalFreeze(freeze)
+	require.NoError(t, err)
+	assert.Contains(t, s, `"until":"2026-01-25T10:30:00Z"`)
+
+	got, err := ParseFreeze(s)
+	require.NoError(t, err)
+	require.NotNil(t, got.Until)
+	assert.True(t, until.Equal(got.Until))
+	assert.Equal(t, freeze.User, got.User)
+
+	// A freeze without until stays without it
+	s, err = MarshalFreeze(&Freeze{User: "admin@example.com"})
+	require.NoError(t, err)
+	assert.NotContains(t, s, "until")
+	got, err = ParseFreeze(s)
+	require.NoError(t, err)
+	assert.Nil(t, got.Until)
+}
+
+func TestFreeze_IsActive(t *testing.T) {
+	past := metav1.NewTime(time.Now().Add(-time.Minute))
+	future := metav1.NewTime(time.Now().Add(time.Hour))
+
+	assert.False(t, (*Freeze)(nil).IsActive())
+	assert.True(t, (&Freeze{}).IsActive())
+	assert.True(t, (&Freeze{Until: &future}).IsActive())
+	assert.False(t, (&Freeze{Until: &past}).IsActive())
+}
+
 func TestParseSnooze(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -395,6 +651,7 @@ func TestParseSnooze(t *testing.T) {
 		wantNil    bool
 		wantUser   string
 		wantExpiry bool
+		wantScoped bool
 		wantErr    bool
 	}{
 		{
@@ -416,6 +673,12 @@ func TestParseSnooze(t *testing.T) {
 			wantUser:   "ops@example.com",
 			wantExpiry: true,
 		},
+		{
+			name:       "child-scoped JSON",
+			input:      `{"expiry":"2026-01-25T12:00:00Z","children":[{"apiVersion":"apps/v1","kind":"ReplicaSet","name":"web-1"}]}`,
+			wantExpiry: true,
+			wantScoped: true,
+		},
 		{
 			name:    "invalid JSON",
 			input:   `{broken`,
@@ -440,6 +703,35 @@ func TestParseSnooze(t *testing.T) {
 			if tt.wantExpiry {
 				assert.False(t, got.Expiry.IsZero())
 			}
+			assert.Equal(t, tt.wantScoped, !got.Covers(ChildRef{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "web-2"}))
+		})
+	}
+}
+
+func TestSnooze_Covers(t *testing.T) {
+	child := ChildRef{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "web-1", Labels: map[string]string{"app": "web"}}
+	tests := []struct {
+		name   string
+		snooze *Snooze
+		want   bool
+	}{
+		{"nil snooze", nil, false},
+		{"unscoped", &Snooze{}, true},
+		{"matching child", &Snooze{Child: &ChildRef{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "web-1"}}, true},
+		{"other child", &Snooze{Child: &ChildRef{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "web-2"}}, false},
+		{"wildcard name", &Snooze{Child: &ChildRef{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "*"}}, true},
+		{"matching labels", &Snooze{Child: &ChildRef{APIVersion: "apps/v1", Kind: "ReplicaSet", Labels: map[string]string{"app": "web"}}}, true},
+		{"other labels", &Snooze{Child: &ChildRef{APIVersion: "apps/v1", Kind: "ReplicaSet", Labels: map[string]string{"app": "db"}}}, false},
+		{"matching list entry", &Snooze{Children: []ChildRef{
+			{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "web-2"},
+			{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "web-1"},
+		}}, true},
+		{"no matching list entry", &Snooze{Children: []ChildRef{{APIVersion: "v1", Kind: "ConfigMap", Name: "web-1"}}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.snooze.Covers(child))
 		})
 	}
 }
@@ -512,3 +804,63 @@ func TestMarshalApprovals(t *testing.T) {
 		})
 	}
 }
+
+func TestApprovalNote_RoundTrip(t *testing.T) {
+	approvals := []Approval{
+		{APIVersion: "v1", Kind: "ConfigMap", Name: "a", Generation: 5, Mode: ModeOnce, Note: "approved per CHG-1234"},
+		{APIVersion: "v1", Kind: "ConfigMap", Name: "b", Mode: ModeAlways},
+	}
+
+	value, err := MarshalApprovals(approvals)
+	require.NoError(t, err)
+	assert.Contains(t, value, `"note":"approved per CHG-1234"`)
+	assert.Equal(t, 1, strings.Count(value, `"note"`), "empty notes are omitted")
+
+	parsed, err := ParseApprovals(value)
+	require.NoError(t, err)
+	assert.Equal(t, approvals, parsed)
+}
+
+func TestApprovalExpiresAt_RoundTrip(t *testing.T) {
+	expiresAt := metav1.NewTime(time.Date(2026, 1, 26, 8, 0, 0, 0, time.UTC))
+	approvals := []Approval{
+		{APIVersion: "v1", Kind: "ConfigMap", Name: "a", Mode: ModeAlways, ExpiresAt: &expiresAt},
+		{APIVersion: "v1", Kind: "ConfigMap", Name: "b", Mode: ModeAlways},
+	}
+
+	value, err := MarshalApprovals(approvals)
+	require.NoError(t, err)
+	assert.Contains(t, value, `"expiresAt":"2026-01-26T08:00:00Z"`)
+	assert.Equal(t, 1, strings.Count(value, `"expiresAt"`), "unset expiry is omitted")
+
+	parsed, err := ParseApprovals(value)
+	require.NoError(t, err)
+	require.Len(t, parsed, 2)
+	require.NotNil(t, parsed[0].ExpiresAt)
+	assert.True(t, expiresAt.Equal(parsed[0].ExpiresAt))
+	assert.Nil(t, parsed[1].ExpiresAt)
+
+	// Offsets other than UTC denote the same instant
+	parsed, err = ParseApprovals(`[{"apiVersion":"v1","kind":"ConfigMap","name":"a","mode":"always","expiresAt":"2026-01-26T09:00:00+01:00"}]`)
+	require.NoError(t, err)
+	assert.True(t, expiresAt.Equal(parsed[0].ExpiresAt))
+}
+
+func TestApproval_IsExpired(t *testing.T) {
+	tests := []struct {
+		name      string
+		expiresAt *metav1.Time
+		want      bool
+	}{
+		{name: "no expiry", want: false},
+		{name: "future expiry", expiresAt: &metav1.Time{Time: time.Now().Add(time.Hour)}, want: false},
+		{name: "past expiry", expiresAt: &metav1.Time{Time: time.Now().Add(-time.Second)}, want: true},
+		{name: "past expiry in another zone", expiresAt: &metav1.Time{Time: time.Now().Add(-time.Second).In(time.FixedZone("UTC+5", 5*3600))}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &Approval{Mode: ModeAlways, ExpiresAt: tt.expiresAt}
+			assert.Equal(t, tt.want, a.IsExpired())
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package approval
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EntryValidation is the validation result of one entry of an approvals annotation.
+type EntryValidation struct {
+	// Index of the entry in the annotation.
+	Index int `json:"index"`
+	// Approval is the decoded entry. Nil if the entry could not be decoded.
+	Approval *Approval `json:"approval,omitempty"`
+	// Errors lists everything wrong with the entry. Empty if the entry is valid.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// Valid returns true if the entry has no errors.
+func (v EntryValidation) Valid() bool {
+	return len(v.Errors) == 0
+}
+
+// ValidateApprovals validates each entry of an approvals annotation value. Entries are
+// decoded strictly, so unknown fields and mistyped values (e.g. a quoted generation) are
+// reported per entry instead of failing the whole annotation. An error is returned only
+// if the value is not a JSON array.
+func ValidateApprovals(annotationValue string) ([]EntryValidation, error) {
+	if annotationValue == "" {
+		return nil, nil
+	}
+	var entries []json.RawMessage
+	if err := json.Unmarshal([]byte(annotationValue), &entries); err != nil {
+		return nil, fmt.Errorf("invalid approvals annotation: %w", err)
+	}
+
+	result := make([]EntryValidation, 0, len(entries))
+	for i, raw := range entries {
+		result = append(result, validateApproval(i, raw))
+	}
+	return result, nil
+}
+
+func validateApproval(index int, raw json.RawMessage) EntryValidation {
+	v := EntryValidation{Index: index}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	var a Approval
+	if err := dec.Decode(&a); err != nil {
+		v.Errors = append(v.Errors, err.Error())
+		return v
+	}
+	v.Approval = &a
+
+	if a.APIVersion == "" {
+		v.Errors = append(v.Errors, "apiVersion is required")
+	}
+	if a.Kind == "" {
+		v.Errors = append(v.Errors, "kind is required")
+	}
+	if a.Name == "" && a.Selector == nil {
+		v.Errors = append(v.Errors, "name or selector is required")
+	}
+	if a.Selector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(a.Selector); err != nil {
+			v.Errors = append(v.Errors, fmt.Sprintf("invalid selector: %v", err))
+		}
+	}
+	if a.Generation < 0 {
+		v.Errors = append(v.Errors, fmt.Sprintf("generation %d must not be negative", a.Generation))
+	}
+
+	switch a.Mode {
+	case "", ModeOnce, ModeGeneration:
+		mode := a.Mode
+		if mode == "" {
+			mode = ModeOnce
+		}
+		// Selector approvals without a name behave as mode always
+		if a.Generation == 0 && a.EffectiveMode() != ModeAlways {
+			v.Errors = append(v.Errors, fmt.Sprintf("generation is required for mode %s", mode))
+		}
+	case ModeRange:
+		if a.GenerationMin < 0 {
+			v.Errors = append(v.Errors, fmt.Sprintf("generationMin %d must not be negative", a.GenerationMin))
+		}
+		if a.GenerationMax == 0 {
+			v.Errors = append(v.Errors, "generationMax is required for mode range")
+		} else if a.GenerationMin > a.GenerationMax {
+			v.Errors = append(v.Errors, fmt.Sprintf("generationMin %d exceeds generationMax %d", a.GenerationMin, a.GenerationMax))
+		}
+	case ModeAlways:
+	default:
+		v.Errors = append(v.Errors, fmt.Sprintf("invalid mode %q: must be one of %s, %s, %s, %s", a.Mode, ModeOnce, ModeGeneration, ModeRange, ModeAlways))
+	}
+
+	for _, f := range a.Fields {
+		if err := validateFieldPointer(f); err != nil {
+			v.Errors = append(v.Errors, fmt.Sprintf("invalid field %q: %v", f, err))
+		}
+	}
+	return v
+}
+
+// validateFieldPointer checks that f is a JSON Pointer to a spec field.
+func validateFieldPointer(f string) error {
+	if f != "/spec" && !strings.HasPrefix(f, "/spec/") {
+		return fmt.Errorf("must be a JSON Pointer starting with /spec")
+	}
+	for i := 0; i < len(f); i++ {
+		if f[i] == '~' && (i+1 == len(f) || (f[i+1] != '0' && f[i+1] != '1')) {
+			return fmt.Errorf("~ must be escaped as ~0")
+		}
+	}
+	return nil
+}
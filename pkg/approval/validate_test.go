@@ -0,0 +1,108 @@
+package approval
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateApprovals(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    [][]string
+		wantErr bool
+	}{
+		{
+			name:  "valid entries",
+			value: `[{"apiVersion":"v1","kind":"ConfigMap","name":"cm","generation":3},{"apiVersion":"v1","kind":"Secret","name":"*","mode":"always"}]`,
+			want:  [][]string{nil, nil},
+		},
+		{
+			name:  "empty annotation",
+			value: "",
+		},
+		{
+			name:  "invalid mode",
+			value: `[{"apiVersion":"v1","kind":"ConfigMap","name":"cm","mode":"onec","generation":1}]`,
+			want:  [][]string{{`invalid mode "onec": must be one of once, generation, range, always`}},
+		},
+		{
+			name:  "unparseable generation",
+			value: `[{"apiVersion":"v1","kind":"ConfigMap","name":"cm","generation":"3"}]`,
+			want:  [][]string{{"json: cannot unmarshal string into Go struct field Approval.generation of type int64"}},
+		},
+		{
+			name:  "unknown field",
+			value: `[{"apiVersion":"v1","kind":"ConfigMap","name":"cm","generaton":3}]`,
+			want:  [][]string{{`json: unknown field "generaton"`}},
+		},
+		{
+			name:  "missing fields and generation",
+			value: `[{"kind":"ConfigMap","mode":"generation"}]`,
+			want:  [][]string{{"apiVersion is required", "name or selector is required", "generation is required for mode generation"}},
+		},
+		{
+			name:  "default mode needs a generation",
+			value: `[{"apiVersion":"v1","kind":"ConfigMap","name":"cm"}]`,
+			want:  [][]string{{"generation is required for mode once"}},
+		},
+		{
+			name:  "only the broken entry is reported",
+			value: `[{"apiVersion":"v1","kind":"ConfigMap","name":"cm","mode":"always"},{"apiVersion":"v1","kind":"ConfigMap","name":"cm","generation":-1}]`,
+			want:  [][]string{nil, {"generation -1 must not be negative"}},
+		},
+		{
+			name:  "range approvals",
+			value: `[{"apiVersion":"v1","kind":"ConfigMap","name":"cm","mode":"range","generationMin":3,"generationMax":5},{"apiVersion":"v1","kind":"ConfigMap","name":"cm","mode":"range","generationMin":6,"generationMax":5},{"apiVersion":"v1","kind":"ConfigMap","name":"cm","mode":"range"}]`,
+			want:  [][]string{nil, {"generationMin 6 exceeds generationMax 5"}, {"generationMax is required for mode range"}},
+		},
+		{
+			name:  "selector instead of name",
+			value: `[{"apiVersion":"apps/v1","kind":"ReplicaSet","selector":{"matchLabels":{"app":"web"}}}]`,
+			want:  [][]string{nil},
+		},
+		{
+			name:  "invalid selector",
+			value: `[{"apiVersion":"apps/v1","kind":"ReplicaSet","selector":{"matchExpressions":[{"key":"app","operator":"Near"}]},"mode":"always"}]`,
+			want:  [][]string{{`invalid selector: "Near" is not a valid label selector operator`}},
+		},
+		{
+			name:  "valid fields",
+			value: `[{"apiVersion":"v1","kind":"Service","name":"svc","mode":"always","fields":["/spec/ports","/spec/selector/app.kubernetes.io~1name"]}]`,
+			want:  [][]string{nil},
+		},
+		{
+			name:  "invalid fields",
+			value: `[{"apiVersion":"v1","kind":"Service","name":"svc","mode":"always","fields":["spec.ports","/metadata/labels","/spec/a~b"]}]`,
+			want: [][]string{{
+				`invalid field "spec.ports": must be a JSON Pointer starting with /spec`,
+				`invalid field "/metadata/labels": must be a JSON Pointer starting with /spec`,
+				`invalid field "/spec/a~b": ~ must be escaped as ~0`,
+			}},
+		},
+		{
+			name:    "not an array",
+			value:   `{"apiVersion":"v1"}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := ValidateApprovals(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, entries, len(tt.want))
+			for i, e := range entries {
+				assert.Equal(t, i, e.Index)
+				assert.Equal(t, tt.want[i], e.Errors)
+				assert.Equal(t, len(tt.want[i]) == 0, e.Valid())
+			}
+		})
+	}
+}
@@ -4,20 +4,26 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/kausality-io/kausality/pkg/callback/v1alpha1"
 )
 
+// defaultTopDrifters is the number of parents returned by /stats/top-drifters by default
+const defaultTopDrifters = 10
+
 // Server handles DriftReport webhooks and serves the API
 type Server struct {
 	store *Store
+	stats *Stats
 }
 
 // NewServer creates a new backend server
 func NewServer() *Server {
 	return &Server{
 		store: NewStore(),
+		stats: NewStats(),
 	}
 }
 
@@ -26,6 +32,11 @@ func (s *Server) Store() *Store {
 	return s.store
 }
 
+// Stats returns the drift statistics aggregator
+func (s *Server) Stats() *Stats {
+	return s.stats
+}
+
 // Handler returns the HTTP handler for the server
 func (s *Server) Handler() http.Handler {
 	mux := http.NewServeMux()
@@ -38,6 +49,9 @@ func (s *Server) Handler() http.Handler {
 	mux.HandleFunc("GET /api/v1/drifts/{id}", s.handleGetDrift)
 	mux.HandleFunc("DELETE /api/v1/drifts/{id}", s.handleDeleteDrift)
 
+	// Stats endpoints
+	mux.HandleFunc("GET /stats/top-drifters", s.handleTopDrifters)
+
 	// Health endpoint
 	mux.HandleFunc("GET /healthz", s.handleHealth)
 
@@ -60,6 +74,7 @@ func (s *Server) handleWebhook(w http.ResponseWriter, r *http.Request) {
 
 	// Store the report
 	s.store.Add(&report)
+	s.stats.Record(&report)
 
 	// Send acknowledgement
 	response := v1alpha1.DriftReportResponse{Acknowledged: true}
@@ -108,6 +123,26 @@ func (s *Server) handleDeleteDrift(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// handleTopDrifters returns the parents with the most drift events in the stats window
+func (s *Server) handleTopDrifters(w http.ResponseWriter, r *http.Request) {
+	n := defaultTopDrifters
+	if v := r.URL.Query().Get("n"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed < 1 {
+			http.Error(w, "invalid n", http.StatusBadRequest)
+			return
+		}
+		n = parsed
+	}
+
+	top := s.stats.Top(n)
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"items": top,
+		"count": len(top),
+	})
+}
+
 // handleHealth returns health status
 func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
@@ -0,0 +1,232 @@
+package backend
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kausality-io/kausality/pkg/callback/v1alpha1"
+)
+
+const (
+	// DefaultSlackDebounce is the default window in which duplicate reports are not posted again.
+	DefaultSlackDebounce = 10 * time.Minute
+	// DefaultSlackLinkTemplate is the default link added to Slack messages.
+	DefaultSlackLinkTemplate = "https://kausality.example.com/drifts/{id}"
+	// slackTimeout bounds a single post to Slack.
+	slackTimeout = 10 * time.Second
+)
+
+// SlackConfig configures a SlackNotifier.
+type SlackConfig struct {
+	// WebhookURL is the Slack incoming webhook URL messages are posted to.
+	WebhookURL string
+	// LinkTemplate is the link added to each message. "{id}" is replaced by the report ID.
+	// Default is DefaultSlackLinkTemplate.
+	LinkTemplate string
+	// Debounce is the window in which reports with the same ID and phase are posted only
+	// once, e.g. while a controller fights a drift in a reconcile loop. Default is
+	// DefaultSlackDebounce; negative disables debouncing.
+	Debounce time.Duration
+	// Client is the HTTP client used to post to Slack. Default is a client with a
+	// 10 second timeout.
+	Client *http.Client
+}
+
+// SlackNotifier receives DriftReports and posts them as messages to a Slack incoming webhook.
+type SlackNotifier struct {
+	webhookURL   string
+	linkTemplate string
+	debounce     time.Duration
+	client       *http.Client
+	nowFunc      func() time.Time
+
+	mu     sync.Mutex
+	posted map[string]time.Time // keyed by phase and report ID
+}
+
+// NewSlackNotifier creates a new SlackNotifier.
+func NewSlackNotifier(cfg SlackConfig) (*SlackNotifier, error) {
+	if cfg.WebhookURL == "" {
+		return nil, fmt.Errorf("slack webhook URL is required")
+	}
+	if cfg.LinkTemplate == "" {
+		cfg.LinkTemplate = DefaultSlackLinkTemplate
+	}
+	if cfg.Debounce == 0 {
+		cfg.Debounce = DefaultSlackDebounce
+	}
+	if cfg.Client == nil {
+		cfg.Client = &http.Client{Timeout: slackTimeout}
+	}
+	return &SlackNotifier{
+		webhookURL:   cfg.WebhookURL,
+		linkTemplate: cfg.LinkTemplate,
+		debounce:     cfg.Debounce,
+		client:       cfg.Client,
+		nowFunc:      time.Now,
+		posted:       make(map[string]time.Time),
+	}, nil
+}
+
+// Handler returns the HTTP handler for the notifier
+func (n *SlackNotifier) Handler() http.Handler {
+	mux := http.NewServeMux()
+
+	// Webhook endpoint - receives DriftReports
+	mux.HandleFunc("POST /webhook", n.handleWebhook)
+
+	// Health endpoint
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"status":"ok","time":"%s"}`, time.Now().Format(time.RFC3339))
+	})
+
+	return mux
+}
+
+// handleWebhook posts a DriftReport to Slack. It acknowledges reports that are posted
+// or debounced, and answers 502 Bad Gateway if Slack rejects the message.
+func (n *SlackNotifier) handleWebhook(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+
+	var report v1alpha1.DriftReport
+	if err := json.Unmarshal(body, &report); err != nil {
+		http.Error(w, "invalid DriftReport", http.StatusBadRequest)
+		return
+	}
+
+	response := v1alpha1.DriftReportResponse{Acknowledged: true}
+	status := http.StatusOK
+	if err := n.Notify(r.Context(), &report); err != nil {
+		response = v1alpha1.DriftReportResponse{Error: err.Error()}
+		status = http.StatusBadGateway
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// Notify posts the report to Slack unless a report with the same ID and phase was
+// posted within the debounce window. Failed posts are not debounced.
+func (n *SlackNotifier) Notify(ctx context.Context, report *v1alpha1.DriftReport) error {
+	key := string(report.Spec.Phase) + "/" + report.Spec.ID
+	if !n.claim(key) {
+		return nil
+	}
+
+	if err := n.post(ctx, report); err != nil {
+		n.mu.Lock()
+		delete(n.posted, key)
+		n.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// claim records key as posted now and returns true, or returns false if it was
+// posted within the debounce window.
+func (n *SlackNotifier) claim(key string) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	now := n.nowFunc()
+	for k, at := range n.posted {
+		if now.Sub(at) >= n.debounce {
+			delete(n.posted, k)
+		}
+	}
+	if _, ok := n.posted[key]; ok {
+		return false
+	}
+	if n.debounce > 0 {
+		n.posted[key] = now
+	}
+	return true
+}
+
+// post sends the formatted report to the Slack webhook.
+func (n *SlackNotifier) post(ctx context.Context, report *v1alpha1.DriftReport) error {
+	payload, err := json.Marshal(map[string]string{"text": n.FormatMessage(report)})
+	if err != nil {
+		return fmt.Errorf("failed to marshal slack message: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.webhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create slack request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post to slack: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("slack rejected message: %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// FormatMessage renders a report as Slack mrkdwn text.
+func (n *SlackNotifier) FormatMessage(report *v1alpha1.DriftReport) string {
+	spec := report.Spec
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "*Kausality: %s*", spec.Phase)
+	if spec.Severity != "" {
+		fmt.Fprintf(&b, " (%s)", spec.Severity)
+	}
+	if spec.Synthetic {
+		b.WriteString(" _synthetic_")
+	}
+	b.WriteString("\n")
+
+	fmt.Fprintf(&b, "*Parent:* `%s`", formatRef(spec.Parent))
+	if spec.Parent.LifecyclePhase != "" {
+		fmt.Fprintf(&b, " (lifecycle phase: %s)", spec.Parent.LifecyclePhase)
+	}
+	b.WriteString("\n")
+	fmt.Fprintf(&b, "*Child:* `%s`\n", formatRef(spec.Child))
+
+	if spec.Request.User != "" {
+		fmt.Fprintf(&b, "*Actor:* `%s`", spec.Request.User)
+		if spec.Request.Operation != "" {
+			fmt.Fprintf(&b, " (%s)", spec.Request.Operation)
+		}
+		b.WriteString("\n")
+	}
+	if spec.BlockedAttempts > 0 {
+		fmt.Fprintf(&b, "*Blocked:* %d attempts\n", spec.BlockedAttempts)
+	}
+	if spec.Lockdown != nil && spec.Lockdown.Message != "" {
+		fmt.Fprintf(&b, "*Message:* %s\n", spec.Lockdown.Message)
+	}
+	for _, change := range spec.PostureChanges {
+		fmt.Fprintf(&b, "• %s\n", change)
+	}
+
+	fmt.Fprintf(&b, "<%s|Details>", strings.ReplaceAll(n.linkTemplate, "{id}", spec.ID))
+	return b.String()
+}
+
+// formatRef renders an object reference as "apiVersion Kind namespace/name".
+func formatRef(ref v1alpha1.ObjectReference) string {
+	name := ref.Name
+	if ref.Namespace != "" {
+		name = ref.Namespace + "/" + name
+	}
+	return fmt.Sprintf("%s %s %s", ref.APIVersion, ref.Kind, name)
+}
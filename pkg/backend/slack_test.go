@@ -0,0 +1,193 @@
+package backend
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kausality-io/kausality/pkg/callback/v1alpha1"
+)
+
+// fakeSlack records the messages posted to it and answers with status.
+type fakeSlack struct {
+	mu       sync.Mutex
+	status   int
+	messages []string
+}
+
+func (f *fakeSlack) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var payload struct {
+		Text string `json:"text"`
+	}
+	_ = json.NewDecoder(r.Body).Decode(&payload)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.messages = append(f.messages, payload.Text)
+	if f.status != 0 && f.status != http.StatusOK {
+		http.Error(w, "invalid_payload", f.status)
+		return
+	}
+	_, _ = w.Write([]byte("ok"))
+}
+
+func (f *fakeSlack) Messages() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.messages...)
+}
+
+func slackTestReport(id string, phase v1alpha1.DriftReportPhase) *v1alpha1.DriftReport {
+	return &v1alpha1.DriftReport{
+		Spec: v1alpha1.DriftReportSpec{
+			ID:       id,
+			Phase:    phase,
+			Severity: v1alpha1.DriftReportSeverityWarning,
+			Parent: v1alpha1.ObjectReference{
+				APIVersion:     "apps/v1",
+				Kind:           "Deployment",
+				Namespace:      "production",
+				Name:           "api-server",
+				LifecyclePhase: "Initialized",
+			},
+			Child: v1alpha1.ObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "ReplicaSet",
+				Namespace:  "production",
+				Name:       "api-server-abc123",
+			},
+			Request: v1alpha1.RequestContext{
+				User:      "system:serviceaccount:kube-system:deployment-controller",
+				Operation: "UPDATE",
+			},
+		},
+	}
+}
+
+func postReport(t *testing.T, handler http.Handler, report *v1alpha1.DriftReport) (*httptest.ResponseRecorder, v1alpha1.DriftReportResponse) {
+	t.Helper()
+	body, err := json.Marshal(report)
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(body)))
+
+	var response v1alpha1.DriftReportResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+	return rec, response
+}
+
+func TestSlackNotifier_FormatMessage(t *testing.T) {
+	n, err := NewSlackNotifier(SlackConfig{WebhookURL: "https://hooks.slack.example.com/x", LinkTemplate: "https://ui.example.com/drifts/{id}"})
+	require.NoError(t, err)
+
+	msg := n.FormatMessage(slackTestReport("abc123", v1alpha1.DriftReportPhaseDetected))
+	assert.Equal(t, "*Kausality: Detected* (Warning)\n"+
+		"*Parent:* `apps/v1 Deployment production/api-server` (lifecycle phase: Initialized)\n"+
+		"*Child:* `apps/v1 ReplicaSet production/api-server-abc123`\n"+
+		"*Actor:* `system:serviceaccount:kube-system:deployment-controller` (UPDATE)\n"+
+		"<https://ui.example.com/drifts/abc123|Details>", msg)
+}
+
+func TestSlackNotifier_Webhook(t *testing.T) {
+	slack := &fakeSlack{}
+	server := httptest.NewServer(slack)
+	defer server.Close()
+
+	n, err := NewSlackNotifier(SlackConfig{WebhookURL: server.URL})
+	require.NoError(t, err)
+
+	rec, response := postReport(t, n.Handler(), slackTestReport("abc123", v1alpha1.DriftReportPhaseDetected))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, response.Acknowledged)
+
+	messages := slack.Messages()
+	require.Len(t, messages, 1)
+	assert.Contains(t, messages[0], "production/api-server")
+	assert.Contains(t, messages[0], "<https://kausality.example.com/drifts/abc123|Details>")
+}
+
+func TestSlackNotifier_Webhook_SlackRejects(t *testing.T) {
+	slack := &fakeSlack{status: http.StatusBadRequest}
+	server := httptest.NewServer(slack)
+	defer server.Close()
+
+	n, err := NewSlackNotifier(SlackConfig{WebhookURL: server.URL})
+	require.NoError(t, err)
+
+	report := slackTestReport("abc123", v1alpha1.DriftReportPhaseDetected)
+	rec, response := postReport(t, n.Handler(), report)
+	assert.Equal(t, http.StatusBadGateway, rec.Code)
+	assert.False(t, response.Acknowledged)
+	assert.Contains(t, response.Error, "invalid_payload")
+
+	// Failed posts are not debounced.
+	slack.mu.Lock()
+	slack.status = http.StatusOK
+	slack.mu.Unlock()
+	rec, _ = postReport(t, n.Handler(), report)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Len(t, slack.Messages(), 2)
+}
+
+func TestSlackNotifier_Webhook_InvalidJSON(t *testing.T) {
+	n, err := NewSlackNotifier(SlackConfig{WebhookURL: "https://hooks.slack.example.com/x"})
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	n.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader([]byte("not json"))))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestSlackNotifier_Debounce(t *testing.T) {
+	slack := &fakeSlack{}
+	server := httptest.NewServer(slack)
+	defer server.Close()
+
+	n, err := NewSlackNotifier(SlackConfig{WebhookURL: server.URL, Debounce: time.Minute})
+	require.NoError(t, err)
+	now := time.Now()
+	n.nowFunc = func() time.Time { return now }
+
+	detected := slackTestReport("abc123", v1alpha1.DriftReportPhaseDetected)
+	for range 3 {
+		rec, response := postReport(t, n.Handler(), detected)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.True(t, response.Acknowledged)
+	}
+	assert.Len(t, slack.Messages(), 1, "duplicates within the window are acknowledged but not posted")
+
+	postReport(t, n.Handler(), slackTestReport("def456", v1alpha1.DriftReportPhaseDetected))
+	postReport(t, n.Handler(), slackTestReport("abc123", v1alpha1.DriftReportPhaseResolved))
+	assert.Len(t, slack.Messages(), 3, "other IDs and phases are posted")
+
+	now = now.Add(time.Minute)
+	postReport(t, n.Handler(), detected)
+	assert.Len(t, slack.Messages(), 4, "duplicates after the window are posted again")
+}
+
+func TestSlackNotifier_DebounceDisabled(t *testing.T) {
+	slack := &fakeSlack{}
+	server := httptest.NewServer(slack)
+	defer server.Close()
+
+	n, err := NewSlackNotifier(SlackConfig{WebhookURL: server.URL, Debounce: -1})
+	require.NoError(t, err)
+
+	report := slackTestReport("abc123", v1alpha1.DriftReportPhaseDetected)
+	postReport(t, n.Handler(), report)
+	postReport(t, n.Handler(), report)
+	assert.Len(t, slack.Messages(), 2)
+}
+
+func TestNewSlackNotifier_RequiresWebhookURL(t *testing.T) {
+	_, err := NewSlackNotifier(SlackConfig{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,290 @@
+package backend
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kausality-io/kausality/pkg/callback/v1alpha1"
+)
+
+const (
+	// DefaultStatsWindow is the default rolling window for drift statistics.
+	DefaultStatsWindow = 7 * 24 * time.Hour
+	// DefaultStatsMaxParents is the default number of parents tracked at once.
+	DefaultStatsMaxParents = 1000
+	// maxEventsPerParent bounds memory for a single chronically drifting parent.
+	maxEventsPerParent = 1000
+	// maxFieldDepth limits how deep changed fields are reported below spec.
+	maxFieldDepth = 3
+	// topFieldsPerParent is the number of changed fields reported per parent.
+	topFieldsPerParent = 5
+)
+
+// TopDrifter summarizes drift events for one parent within the stats window.
+type TopDrifter struct {
+	Parent    v1alpha1.ObjectReference          `json:"parent"`
+	Count     int                               `json:"count"`
+	ByPhase   map[v1alpha1.DriftReportPhase]int `json:"byPhase"`
+	TopFields []FieldCount                      `json:"topFields,omitempty"`
+	LastSeen  time.Time                         `json:"lastSeen"`
+}
+
+// FieldCount counts how often a spec field changed.
+type FieldCount struct {
+	Field string `json:"field"`
+	Count int    `json:"count"`
+}
+
+// Stats aggregates drift reports per parent over a rolling window.
+type Stats struct {
+	mu         sync.Mutex
+	window     time.Duration
+	maxParents int
+	nowFunc    func() time.Time
+	parents    map[string]*parentStats
+}
+
+type parentStats struct {
+	parent v1alpha1.ObjectReference
+	events []statEvent
+}
+
+type statEvent struct {
+	at     time.Time
+	phase  v1alpha1.DriftReportPhase
+	fields []string
+}
+
+// StatsOption configures Stats.
+type StatsOption func(*Stats)
+
+// WithStatsWindow sets the rolling window.
+func WithStatsWindow(window time.Duration) StatsOption {
+	return func(s *Stats) {
+		s.window = window
+	}
+}
+
+// WithStatsMaxParents sets the maximum number of tracked parents.
+func WithStatsMaxParents(n int) StatsOption {
+	return func(s *Stats) {
+		s.maxParents = n
+	}
+}
+
+// WithStatsNowFunc sets the time function (for testing).
+func WithStatsNowFunc(fn func() time.Time) StatsOption {
+	return func(s *Stats) {
+		s.nowFunc = fn
+	}
+}
+
+// NewStats creates a new drift statistics aggregator.
+func NewStats(opts ...StatsOption) *Stats {
+	s := &Stats{
+		window:     DefaultStatsWindow,
+		maxParents: DefaultStatsMaxParents,
+		nowFunc:    time.Now,
+		parents:    make(map[string]*parentStats),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Record adds a drift report to the statistics.
+func (s *Stats) Record(report *v1alpha1.DriftReport) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.nowFunc()
+	key := parentKey(report.Spec.Parent)
+	ps, ok := s.parents[key]
+	if !ok {
+		ps = &parentStats{parent: report.Spec.Parent}
+		s.parents[key] = ps
+	}
+	ps.parent.Generation = report.Spec.Parent.Generation
+	ps.parent.ObservedGeneration = report.Spec.Parent.ObservedGeneration
+	ps.events = append(ps.events, statEvent{
+		at:     now,
+		phase:  report.Spec.Phase,
+		fields: changedFields(&report.Spec),
+	})
+	if len(ps.events) > maxEventsPerParent {
+		ps.events = ps.events[len(ps.events)-maxEventsPerParent:]
+	}
+
+	s.pruneLocked(now)
+}
+
+// Top returns the n parents with the most drift events in the window,
+// ordered by count descending. n <= 0 returns all parents.
+func (s *Stats) Top(n int) []TopDrifter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pruneLocked(s.nowFunc())
+
+	result := make([]TopDrifter, 0, len(s.parents))
+	for _, ps := range s.parents {
+		result = append(result, ps.summary())
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].LastSeen.After(result[j].LastSeen)
+	})
+	if n > 0 && len(result) > n {
+		result = result[:n]
+	}
+	return result
+}
+
+// pruneLocked drops events outside the window and evicts the least recently
+// seen parents beyond maxParents. Caller must hold s.mu.
+func (s *Stats) pruneLocked(now time.Time) {
+	cutoff := now.Add(-s.window)
+	for key, ps := range s.parents {
+		i := 0
+		for i < len(ps.events) && ps.events[i].at.Before(cutoff) {
+			i++
+		}
+		ps.events = ps.events[i:]
+		if len(ps.events) == 0 {
+			delete(s.parents, key)
+		}
+	}
+
+	if s.maxParents <= 0 || len(s.parents) <= s.maxParents {
+		return
+	}
+	keys := make([]string, 0, len(s.parents))
+	for key := range s.parents {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return s.parents[keys[i]].lastSeen().Before(s.parents[keys[j]].lastSeen())
+	})
+	for _, key := range keys[:len(keys)-s.maxParents] {
+		delete(s.parents, key)
+	}
+}
+
+func (ps *parentStats) lastSeen() time.Time {
+	return ps.events[len(ps.events)-1].at
+}
+
+func (ps *parentStats) summary() TopDrifter {
+	td := TopDrifter{
+		Parent:   ps.parent,
+		Count:    len(ps.events),
+		ByPhase:  make(map[v1alpha1.DriftReportPhase]int),
+		LastSeen: ps.lastSeen(),
+	}
+	fieldCounts := make(map[string]int)
+	for _, e := range ps.events {
+		td.ByPhase[e.phase]++
+		for _, f := range e.fields {
+			fieldCounts[f]++
+		}
+	}
+	for field, count := range fieldCounts {
+		td.TopFields = append(td.TopFields, FieldCount{Field: field, Count: count})
+	}
+	sort.Slice(td.TopFields, func(i, j int) bool {
+		if td.TopFields[i].Count != td.TopFields[j].Count {
+			return td.TopFields[i].Count > td.TopFields[j].Count
+		}
+		return td.TopFields[i].Field < td.TopFields[j].Field
+	})
+	if len(td.TopFields) > topFieldsPerParent {
+		td.TopFields = td.TopFields[:topFieldsPerParent]
+	}
+	return td
+}
+
+func parentKey(ref v1alpha1.ObjectReference) string {
+	return ref.APIVersion + "/" + ref.Kind + "/" + ref.Namespace + "/" + ref.Name
+}
+
+// changedFields returns the changed spec field paths of a report, cut to
+// maxFieldDepth. They are taken from the report's specChanges, and only diffed from
+// the old and new objects if the report has none.
+func changedFields(spec *v1alpha1.DriftReportSpec) []string {
+	if len(spec.SpecChanges) == 0 {
+		return diffObjects(spec.OldObject, spec.NewObject)
+	}
+	var fields []string
+	seen := make(map[string]struct{}, len(spec.SpecChanges))
+	for _, change := range spec.SpecChanges {
+		field := truncateField(change.Path)
+		if _, ok := seen[field]; ok {
+			continue
+		}
+		seen[field] = struct{}{}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// truncateField cuts a field path like "spec.template.spec.containers[0].image" to
+// maxFieldDepth segments. Like diffObjects, it stops at lists.
+func truncateField(path string) string {
+	if i := strings.IndexByte(path, '['); i >= 0 {
+		path = path[:i]
+	}
+	segments := strings.SplitN(path, ".", maxFieldDepth+1)
+	if len(segments) > maxFieldDepth {
+		segments = segments[:maxFieldDepth]
+	}
+	return strings.Join(segments, ".")
+}
+
+// diffObjects returns the spec field paths that differ between old and new objects.
+// Returns nil for creates or undecodable objects.
+func diffObjects(oldObj *runtime.RawExtension, newObj runtime.RawExtension) []string {
+	if oldObj == nil || len(oldObj.Raw) == 0 || len(newObj.Raw) == 0 {
+		return nil
+	}
+	var oldMap, newMap map[string]interface{}
+	if err := json.Unmarshal(oldObj.Raw, &oldMap); err != nil {
+		return nil
+	}
+	if err := json.Unmarshal(newObj.Raw, &newMap); err != nil {
+		return nil
+	}
+	var fields []string
+	diffFields("spec", oldMap["spec"], newMap["spec"], 1, &fields)
+	return fields
+}
+
+func diffFields(path string, a, b interface{}, depth int, fields *[]string) {
+	if reflect.DeepEqual(a, b) {
+		return
+	}
+	am, aok := a.(map[string]interface{})
+	bm, bok := b.(map[string]interface{})
+	if !aok || !bok || depth >= maxFieldDepth {
+		*fields = append(*fields, path)
+		return
+	}
+	keys := make(map[string]struct{}, len(am)+len(bm))
+	for k := range am {
+		keys[k] = struct{}{}
+	}
+	for k := range bm {
+		keys[k] = struct{}{}
+	}
+	for k := range keys {
+		diffFields(path+"."+k, am[k], bm[k], depth+1, fields)
+	}
+}
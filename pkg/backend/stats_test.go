@@ -0,0 +1,161 @@
+package backend
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kausality-io/kausality/pkg/callback/v1alpha1"
+)
+
+func statsReport(t *testing.T, parent string, phase v1alpha1.DriftReportPhase, oldSpec, newSpec map[string]interface{}) *v1alpha1.DriftReport {
+	t.Helper()
+	report := &v1alpha1.DriftReport{Spec: v1alpha1.DriftReportSpec{
+		ID:     parent + "-" + string(phase),
+		Phase:  phase,
+		Parent: v1alpha1.ObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: parent},
+		Child:  v1alpha1.ObjectReference{APIVersion: "apps/v1", Kind: "ReplicaSet", Namespace: "default", Name: parent + "-rs"},
+	}}
+	newRaw, err := json.Marshal(map[string]interface{}{"spec": newSpec})
+	require.NoError(t, err)
+	report.Spec.NewObject = runtime.RawExtension{Raw: newRaw}
+	if oldSpec != nil {
+		oldRaw, err := json.Marshal(map[string]interface{}{"spec": oldSpec})
+		require.NoError(t, err)
+		report.Spec.OldObject = &runtime.RawExtension{Raw: oldRaw}
+	}
+	return report
+}
+
+func TestStats_Top(t *testing.T) {
+	now := time.Date(2026, 1, 25, 10, 0, 0, 0, time.UTC)
+	stats := NewStats(WithStatsNowFunc(func() time.Time { return now }))
+
+	for i := 0; i < 3; i++ {
+		stats.Record(statsReport(t, "noisy", v1alpha1.DriftReportPhaseDetected,
+			map[string]interface{}{"replicas": 1, "paused": false},
+			map[string]interface{}{"replicas": 2, "paused": false}))
+	}
+	stats.Record(statsReport(t, "noisy", v1alpha1.DriftReportPhaseResolved, nil, map[string]interface{}{}))
+	stats.Record(statsReport(t, "quiet", v1alpha1.DriftReportPhaseDetected,
+		map[string]interface{}{"template": map[string]interface{}{"spec": map[string]interface{}{"image": "a"}}},
+		map[string]interface{}{"template": map[string]interface{}{"spec": map[string]interface{}{"image": "b"}}}))
+
+	top := stats.Top(10)
+	require.Len(t, top, 2)
+
+	assert.Equal(t, "noisy", top[0].Parent.Name)
+	assert.Equal(t, 4, top[0].Count)
+	assert.Equal(t, map[v1alpha1.DriftReportPhase]int{
+		v1alpha1.DriftReportPhaseDetected: 3,
+		v1alpha1.DriftReportPhaseResolved: 1,
+	}, top[0].ByPhase)
+	assert.Equal(t, []FieldCount{{Field: "spec.replicas", Count: 3}}, top[0].TopFields)
+
+	assert.Equal(t, "quiet", top[1].Parent.Name)
+	assert.Equal(t, []FieldCount{{Field: "spec.template.spec", Count: 1}}, top[1].TopFields)
+
+	assert.Len(t, stats.Top(1), 1)
+}
+
+func TestStats_SpecChanges(t *testing.T) {
+	stats := NewStats()
+
+	// specChanges take precedence over diffing the objects
+	report := statsReport(t, "parent", v1alpha1.DriftReportPhaseDetected,
+		map[string]interface{}{"replicas": 1}, map[string]interface{}{"replicas": 2})
+	report.Spec.SpecChanges = []v1alpha1.FieldChange{
+		{Path: "spec.template.spec.containers[0].image"},
+		{Path: "spec.template.spec.containers[1].image"},
+		{Path: "spec.paused"},
+	}
+	stats.Record(report)
+
+	top := stats.Top(1)
+	require.Len(t, top, 1)
+	assert.Equal(t, []FieldCount{{Field: "spec.paused", Count: 1}, {Field: "spec.template.spec", Count: 1}}, top[0].TopFields)
+}
+
+func TestStats_Window(t *testing.T) {
+	now := time.Date(2026, 1, 25, 10, 0, 0, 0, time.UTC)
+	stats := NewStats(
+		WithStatsWindow(time.Hour),
+		WithStatsNowFunc(func() time.Time { return now }),
+	)
+
+	stats.Record(statsReport(t, "old", v1alpha1.DriftReportPhaseDetected, nil, map[string]interface{}{}))
+	now = now.Add(30 * time.Minute)
+	stats.Record(statsReport(t, "recent", v1alpha1.DriftReportPhaseDetected, nil, map[string]interface{}{}))
+	stats.Record(statsReport(t, "old", v1alpha1.DriftReportPhaseDetected, nil, map[string]interface{}{}))
+
+	top := stats.Top(0)
+	require.Len(t, top, 2)
+	assert.Equal(t, "old", top[0].Parent.Name)
+	assert.Equal(t, 2, top[0].Count)
+
+	// First "old" event falls out of the window
+	now = now.Add(45 * time.Minute)
+	top = stats.Top(0)
+	require.Len(t, top, 2)
+	for _, d := range top {
+		assert.Equal(t, 1, d.Count, d.Parent.Name)
+	}
+
+	// Everything expires
+	now = now.Add(time.Hour)
+	assert.Empty(t, stats.Top(0))
+}
+
+func TestStats_MaxParents(t *testing.T) {
+	now := time.Date(2026, 1, 25, 10, 0, 0, 0, time.UTC)
+	stats := NewStats(
+		WithStatsMaxParents(3),
+		WithStatsNowFunc(func() time.Time { return now }),
+	)
+
+	for i := 0; i < 5; i++ {
+		now = now.Add(time.Second)
+		stats.Record(statsReport(t, fmt.Sprintf("parent-%d", i), v1alpha1.DriftReportPhaseDetected, nil, map[string]interface{}{}))
+	}
+
+	top := stats.Top(0)
+	require.Len(t, top, 3)
+	names := []string{top[0].Parent.Name, top[1].Parent.Name, top[2].Parent.Name}
+	assert.ElementsMatch(t, []string{"parent-2", "parent-3", "parent-4"}, names)
+}
+
+func TestServer_TopDrifters(t *testing.T) {
+	server := NewServer()
+	handler := server.Handler()
+
+	for _, name := range []string{"a", "b", "b"} {
+		server.Stats().Record(statsReport(t, name, v1alpha1.DriftReportPhaseDetected, nil, map[string]interface{}{}))
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/stats/top-drifters?n=1", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var response struct {
+		Items []TopDrifter `json:"items"`
+		Count int          `json:"count"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	require.Equal(t, 1, response.Count)
+	assert.Equal(t, "b", response.Items[0].Parent.Name)
+	assert.Equal(t, 2, response.Items[0].Count)
+
+	req = httptest.NewRequest(http.MethodGet, "/stats/top-drifters?n=zero", nil)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
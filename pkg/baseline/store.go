@@ -0,0 +1,143 @@
+// Package baseline resolves declared controller baselines. A Baseline lists the
+// changes controllers of a parent kind make to children as part of normal operation;
+// such changes are not drift even while the parent is stable.
+package baseline
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/go-logr/logr"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kausalityv1alpha1 "github.com/kausality-io/kausality/api/v1alpha1"
+)
+
+// Matcher decides whether a change to a child is within a baseline.
+type Matcher interface {
+	// Match returns the name of the baseline covering a change of fields in a child of
+	// kind child, made by fieldManager to a parent of kind parent.
+	Match(parent, child schema.GroupKind, fieldManager string, fields []string) (string, bool)
+}
+
+// Store caches Baseline resources and matches changes against them.
+type Store struct {
+	client    client.Client
+	log       logr.Logger
+	mu        sync.RWMutex
+	baselines []kausalityv1alpha1.Baseline
+}
+
+var _ Matcher = &Store{}
+
+// NewStore creates a new baseline store.
+func NewStore(c client.Client, log logr.Logger) *Store {
+	return &Store{
+		client: c,
+		log:    log.WithName("baseline-store"),
+	}
+}
+
+// Refresh reloads all baselines from the API server.
+func (s *Store) Refresh(ctx context.Context) error {
+	var list kausalityv1alpha1.BaselineList
+	if err := s.client.List(ctx, &list); err != nil {
+		return err
+	}
+	s.Update(list.Items)
+	return nil
+}
+
+// Update replaces the cached baselines. Deleting baselines are dropped.
+func (s *Store) Update(baselines []kausalityv1alpha1.Baseline) {
+	active := make([]kausalityv1alpha1.Baseline, 0, len(baselines))
+	for _, b := range baselines {
+		if b.DeletionTimestamp.IsZero() {
+			active = append(active, b)
+		}
+	}
+	// Sort by name for determinism
+	sort.Slice(active, func(i, j int) bool { return active[i].Name < active[j].Name })
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.baselines = active
+	s.log.V(1).Info("baselines updated", "count", len(active))
+}
+
+// List returns the cached baselines applying to a parent kind.
+func (s *Store) List(parent schema.GroupKind) []kausalityv1alpha1.Baseline {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var result []kausalityv1alpha1.Baseline
+	for _, b := range s.baselines {
+		if kindMatches(b.Spec.Parent, parent) {
+			result = append(result, b)
+		}
+	}
+	return result
+}
+
+// Match returns the name of the first baseline of the parent kind in which every
+// changed field is covered by an expected change for the child kind and field manager.
+// An empty change is never covered.
+func (s *Store) Match(parent, child schema.GroupKind, fieldManager string, fields []string) (string, bool) {
+	if len(fields) == 0 {
+		return "", false
+	}
+	for _, b := range s.List(parent) {
+		if Covers(b.Spec, child, fieldManager, fields) {
+			return b.Name, true
+		}
+	}
+	return "", false
+}
+
+// Covers returns true if every field is covered by an expected change of spec
+// for the child kind and field manager.
+func Covers(spec kausalityv1alpha1.BaselineSpec, child schema.GroupKind, fieldManager string, fields []string) bool {
+	for _, field := range fields {
+		covered := false
+		for _, e := range spec.Expected {
+			if kindMatches(e.Child, child) && managerMatches(e.FieldManagers, fieldManager) && fieldCovered(e.Fields, field) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
+	return true
+}
+
+func kindMatches(k kausalityv1alpha1.BaselineKind, gk schema.GroupKind) bool {
+	return k.APIGroup == gk.Group && k.Kind == gk.Kind
+}
+
+func managerMatches(managers []string, fieldManager string) bool {
+	if len(managers) == 0 {
+		return true
+	}
+	for _, m := range managers {
+		if m == fieldManager {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldCovered returns true if field equals one of the expected fields or is below one.
+func fieldCovered(expected []string, field string) bool {
+	for _, e := range expected {
+		if field == e || strings.HasPrefix(field, e+".") {
+			return true
+		}
+	}
+	return false
+}
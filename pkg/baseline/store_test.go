@@ -0,0 +1,105 @@
+package baseline
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kausalityv1alpha1 "github.com/kausality-io/kausality/api/v1alpha1"
+)
+
+var (
+	deployment = schema.GroupKind{Group: "apps", Kind: "Deployment"}
+	replicaSet = schema.GroupKind{Group: "apps", Kind: "ReplicaSet"}
+)
+
+func deploymentBaseline(name string, expected ...kausalityv1alpha1.ExpectedChange) *kausalityv1alpha1.Baseline {
+	return &kausalityv1alpha1.Baseline{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: kausalityv1alpha1.BaselineSpec{
+			Parent:   kausalityv1alpha1.BaselineKind{APIGroup: "apps", Kind: "Deployment"},
+			Expected: expected,
+		},
+	}
+}
+
+func TestStore_Match(t *testing.T) {
+	s := NewStore(nil, logr.Discard())
+	s.Update([]kausalityv1alpha1.Baseline{*deploymentBaseline("deployments",
+		kausalityv1alpha1.ExpectedChange{
+			Child:         kausalityv1alpha1.BaselineKind{APIGroup: "apps", Kind: "ReplicaSet"},
+			FieldManagers: []string{"kube-controller-manager"},
+			Fields:        []string{"spec.replicas"},
+		},
+		kausalityv1alpha1.ExpectedChange{
+			Child:  kausalityv1alpha1.BaselineKind{APIGroup: "apps", Kind: "ReplicaSet"},
+			Fields: []string{"spec.template.metadata"},
+		},
+	)})
+
+	tests := []struct {
+		name    string
+		parent  schema.GroupKind
+		child   schema.GroupKind
+		manager string
+		fields  []string
+		want    bool
+	}{
+		{name: "expected field and manager", parent: deployment, child: replicaSet, manager: "kube-controller-manager", fields: []string{"spec.replicas"}, want: true},
+		{name: "subfield of expected field, any manager", parent: deployment, child: replicaSet, manager: "other", fields: []string{"spec.template.metadata.labels"}, want: true},
+		{name: "fields covered by different entries", parent: deployment, child: replicaSet, manager: "kube-controller-manager", fields: []string{"spec.replicas", "spec.template.metadata"}, want: true},
+		{name: "unexpected field", parent: deployment, child: replicaSet, manager: "kube-controller-manager", fields: []string{"spec.replicas", "spec.selector"}},
+		{name: "unexpected manager", parent: deployment, child: replicaSet, manager: "kubectl", fields: []string{"spec.replicas"}},
+		{name: "field prefix is not a parent field", parent: deployment, child: replicaSet, manager: "kube-controller-manager", fields: []string{"spec.replicasMax"}},
+		{name: "other child kind", parent: deployment, child: schema.GroupKind{Kind: "ConfigMap"}, manager: "kube-controller-manager", fields: []string{"spec.replicas"}},
+		{name: "other parent kind", parent: schema.GroupKind{Group: "apps", Kind: "StatefulSet"}, child: replicaSet, manager: "kube-controller-manager", fields: []string{"spec.replicas"}},
+		{name: "no fields", parent: deployment, child: replicaSet, manager: "kube-controller-manager"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, ok := s.Match(tt.parent, tt.child, tt.manager, tt.fields)
+			assert.Equal(t, tt.want, ok)
+			if tt.want {
+				assert.Equal(t, "deployments", name)
+			}
+		})
+	}
+}
+
+func TestStore_Refresh(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, kausalityv1alpha1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	s := NewStore(c, logr.Discard())
+	replicas := kausalityv1alpha1.ExpectedChange{
+		Child:  kausalityv1alpha1.BaselineKind{APIGroup: "apps", Kind: "ReplicaSet"},
+		Fields: []string{"spec.replicas"},
+	}
+	match := func() bool {
+		require.NoError(t, s.Refresh(t.Context()))
+		_, ok := s.Match(deployment, replicaSet, "", []string{"spec.replicas"})
+		return ok
+	}
+
+	assert.False(t, match(), "no baselines")
+
+	b := deploymentBaseline("deployments", replicas)
+	require.NoError(t, c.Create(t.Context(), b))
+	assert.True(t, match(), "created")
+	assert.Len(t, s.List(deployment), 1)
+
+	b.Spec.Expected[0].Fields = []string{"spec.paused"}
+	require.NoError(t, c.Update(t.Context(), b))
+	assert.False(t, match(), "updated")
+
+	require.NoError(t, c.Delete(t.Context(), b))
+	assert.False(t, match(), "deleted")
+	assert.Empty(t, s.List(deployment))
+}
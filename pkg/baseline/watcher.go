@@ -0,0 +1,49 @@
+package baseline
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kausalityv1alpha1 "github.com/kausality-io/kausality/api/v1alpha1"
+)
+
+// Watcher watches Baseline resources and keeps the Store updated.
+type Watcher struct {
+	client client.Client
+	store  *Store
+	log    logr.Logger
+}
+
+// NewWatcher creates a new baseline watcher.
+func NewWatcher(c client.Client, store *Store, log logr.Logger) *Watcher {
+	return &Watcher{
+		client: c,
+		store:  store,
+		log:    log.WithName("baseline-watcher"),
+	}
+}
+
+// Reconcile is called when any Baseline changes. It refreshes the entire store.
+func (w *Watcher) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	w.log.V(1).Info("baseline changed, refreshing store", "name", req.Name)
+
+	if err := w.store.Refresh(ctx); err != nil {
+		w.log.Error(err, "failed to refresh baseline store")
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// SetupWatcher registers a baseline watcher for store with the controller manager.
+func SetupWatcher(mgr ctrl.Manager, store *Store, log logr.Logger) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("baseline-watcher").
+		For(&kausalityv1alpha1.Baseline{}).
+		Complete(NewWatcher(mgr.GetClient(), store, log))
+}
@@ -0,0 +1,139 @@
+package breakglass
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestVerifier_Verify(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	_, otherPriv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	now := time.Date(2026, 1, 25, 10, 0, 0, 0, time.UTC)
+	target := Target{Group: "apps", Kind: "Deployment", Namespace: "default", Name: "web"}
+	signClaims := func(key ed25519.PrivateKey, claims Claims) string {
+		token, err := Sign(claims, key)
+		require.NoError(t, err)
+		return token
+	}
+	sign := func(key ed25519.PrivateKey, issuedAt time.Time) string {
+		return signClaims(key, Claims{ID: "token-1", User: "oncall@example.com", Reason: "INC-42", IssuedAt: metav1.NewTime(issuedAt), Target: target})
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{
+			name:  "valid token",
+			token: sign(priv, now.Add(-5*time.Minute)),
+		},
+		{
+			name:    "minted for another object",
+			token:   signClaims(priv, Claims{ID: "token-1", User: "oncall@example.com", Reason: "INC-42", IssuedAt: metav1.NewTime(now), Target: Target{Group: "apps", Kind: "Deployment", Namespace: "default", Name: "db"}}),
+			wantErr: ErrTargetMismatch,
+		},
+		{
+			name:    "missing jti",
+			token:   signClaims(priv, Claims{User: "oncall@example.com", Reason: "INC-42", IssuedAt: metav1.NewTime(now), Target: target}),
+			wantErr: ErrMalformed,
+		},
+		{
+			name:    "missing target",
+			token:   signClaims(priv, Claims{ID: "token-1", User: "oncall@example.com", Reason: "INC-42", IssuedAt: metav1.NewTime(now)}),
+			wantErr: ErrMalformed,
+		},
+		{
+			name:    "expired token",
+			token:   sign(priv, now.Add(-20*time.Minute)),
+			wantErr: ErrExpired,
+		},
+		{
+			name:    "issued in the future",
+			token:   sign(priv, now.Add(time.Hour)),
+			wantErr: ErrExpired,
+		},
+		{
+			name:    "signed with other key",
+			token:   sign(otherPriv, now),
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "no separator",
+			token:   "garbage",
+			wantErr: ErrMalformed,
+		},
+		{
+			name:    "bad signature encoding",
+			token:   "abc.!!!",
+			wantErr: ErrMalformed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := NewVerifier(pub, 15*time.Minute, WithNowFunc(func() time.Time { return now }))
+			claims, err := v.Verify(tt.token, target)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, claims)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "token-1", claims.ID)
+			assert.Equal(t, "oncall@example.com", claims.User)
+			assert.Equal(t, "INC-42", claims.Reason)
+		})
+	}
+}
+
+func TestVerifier_TamperedPayload(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	target := Target{Kind: "ConfigMap", Namespace: "default", Name: "cm"}
+	token, err := Sign(Claims{ID: "a", User: "a", Reason: "b", IssuedAt: metav1.Now(), Target: target}, priv)
+	require.NoError(t, err)
+	other, err := Sign(Claims{ID: "b", User: "mallory", Reason: "b", IssuedAt: metav1.Now(), Target: target}, priv)
+	require.NoError(t, err)
+
+	// Combine the payload of one token with the signature of another.
+	payload, _, _ := strings.Cut(other, ".")
+	_, sig, _ := strings.Cut(token, ".")
+	_, err = NewVerifier(pub, 0).Verify(payload+"."+sig, target)
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+}
+
+func TestLoadPublicKey(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600))
+
+	got, err := LoadPublicKey(path)
+	require.NoError(t, err)
+	assert.Equal(t, pub, got)
+
+	badPath := filepath.Join(t.TempDir(), "bad.pem")
+	require.NoError(t, os.WriteFile(badPath, []byte("not pem"), 0o600))
+	_, err = LoadPublicKey(badPath)
+	assert.Error(t, err)
+}
@@ -0,0 +1,258 @@
+package callback
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/sasl"
+	"github.com/twmb/franz-go/pkg/sasl/plain"
+	"github.com/twmb/franz-go/pkg/sasl/scram"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kausality-io/kausality/pkg/callback/v1alpha1"
+	"github.com/kausality-io/kausality/pkg/metrics"
+)
+
+// SASL mechanisms supported by the KafkaSender.
+const (
+	SASLMechanismPlain       = "PLAIN"
+	SASLMechanismSCRAMSHA256 = "SCRAM-SHA-256"
+	SASLMechanismSCRAMSHA512 = "SCRAM-SHA-512"
+)
+
+// KafkaProducer is the subset of the Kafka client used by the KafkaSender.
+// It is implemented by *kgo.Client.
+type KafkaProducer interface {
+	// TryProduce buffers a record, failing it with kgo.ErrMaxBuffered
+	// instead of blocking when the buffer is full.
+	TryProduce(ctx context.Context, r *kgo.Record, promise func(*kgo.Record, error))
+	// Flush waits until all buffered records are produced.
+	Flush(ctx context.Context) error
+	// Close closes the client.
+	Close()
+}
+
+// KafkaSenderConfig configures the KafkaSender.
+type KafkaSenderConfig struct {
+	// Brokers are the seed brokers, e.g. "kafka-0.kafka:9092".
+	Brokers []string
+	// Topic is the topic reports are produced to.
+	Topic string
+	// TLS enables TLS to the brokers.
+	TLS bool
+	// CAFile is the path to the CA certificate file for TLS verification.
+	// If empty, system CA pool is used. Implies TLS.
+	CAFile string
+	// SASLMechanism is one of PLAIN, SCRAM-SHA-256 or SCRAM-SHA-512.
+	// If empty, SASL is not used.
+	SASLMechanism string
+	// Username is the SASL username.
+	Username string
+	// PasswordFile is the path to a file containing the SASL password.
+	PasswordFile string
+	// MaxBufferedRecords is the number of reports buffered while brokers are slow
+	// or unreachable. Reports beyond that are dropped. Default is 1000.
+	MaxBufferedRecords int
+	// DedupWindow is how long duplicate Detected reports of the same drift ID are
+	// suppressed. Resolved reports are always sent. Default is 10 minutes.
+	DedupWindow time.Duration
+	// DedupMaxSize bounds the drift IDs remembered for deduplication. Default is 10000.
+	DedupMaxSize int
+	// Log is the logger. If nil, a noop logger is used.
+	Log logr.Logger
+
+	// Producer replaces the Kafka client built from the fields above, for tests.
+	Producer KafkaProducer
+}
+
+// KafkaSender produces DriftReports to a Kafka topic. Each report is keyed by
+// its correlation ID, derived from the parent and child references, so that
+// the Detected and Resolved reports of a drift land on the same partition and
+// are consumed in order.
+type KafkaSender struct {
+	config   KafkaSenderConfig
+	producer KafkaProducer
+	tracker  *Tracker
+	log      logr.Logger
+}
+
+// NewKafkaSender creates a new KafkaSender with the given configuration.
+func NewKafkaSender(cfg KafkaSenderConfig) (*KafkaSender, error) {
+	if cfg.Topic == "" {
+		return nil, fmt.Errorf("kafka topic must not be empty")
+	}
+	if cfg.MaxBufferedRecords == 0 {
+		cfg.MaxBufferedRecords = 1000
+	}
+
+	log := cfg.Log
+	if log.GetSink() == nil {
+		log = logr.Discard()
+	}
+
+	producer := cfg.Producer
+	if producer == nil {
+		if len(cfg.Brokers) == 0 {
+			return nil, fmt.Errorf("kafka brokers must not be empty")
+		}
+		opts, err := kafkaClientOpts(cfg)
+		if err != nil {
+			return nil, err
+		}
+		client, err := kgo.NewClient(opts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create kafka client: %w", err)
+		}
+		producer = client
+	}
+
+	return &KafkaSender{
+		config:   cfg,
+		producer: producer,
+		tracker:  NewTracker(WithTTL(cfg.DedupWindow), WithMaxSize(cfg.DedupMaxSize)),
+		log:      log.WithName("drift-callback-kafka"),
+	}, nil
+}
+
+// kafkaClientOpts translates the config into Kafka client options.
+func kafkaClientOpts(cfg KafkaSenderConfig) ([]kgo.Opt, error) {
+	opts := []kgo.Opt{
+		kgo.SeedBrokers(cfg.Brokers...),
+		kgo.DefaultProduceTopic(cfg.Topic),
+		kgo.MaxBufferedRecords(cfg.MaxBufferedRecords),
+	}
+
+	if cfg.TLS || cfg.CAFile != "" {
+		tlsConfig := &tls.Config{
+			MinVersion: tls.VersionTLS12,
+		}
+		if cfg.CAFile != "" {
+			caCert, err := os.ReadFile(cfg.CAFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read CA file: %w", err)
+			}
+			caCertPool := x509.NewCertPool()
+			if !caCertPool.AppendCertsFromPEM(caCert) {
+				return nil, fmt.Errorf("failed to parse CA certificate")
+			}
+			tlsConfig.RootCAs = caCertPool
+		}
+		opts = append(opts, kgo.DialTLSConfig(tlsConfig))
+	}
+
+	if cfg.SASLMechanism != "" {
+		password, err := os.ReadFile(cfg.PasswordFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read SASL password file: %w", err)
+		}
+		pass := strings.TrimSpace(string(password))
+
+		var mechanism sasl.Mechanism
+		switch cfg.SASLMechanism {
+		case SASLMechanismPlain:
+			mechanism = plain.Auth{User: cfg.Username, Pass: pass}.AsMechanism()
+		case SASLMechanismSCRAMSHA256:
+			mechanism = scram.Auth{User: cfg.Username, Pass: pass}.AsSha256Mechanism()
+		case SASLMechanismSCRAMSHA512:
+			mechanism = scram.Auth{User: cfg.Username, Pass: pass}.AsSha512Mechanism()
+		default:
+			return nil, fmt.Errorf("unsupported SASL mechanism %q", cfg.SASLMechanism)
+		}
+		opts = append(opts, kgo.SASL(mechanism))
+	}
+
+	return opts, nil
+}
+
+// SendAsync buffers a DriftReport for producing. It never blocks: if the producer
+// buffer is full, the report is dropped and logged.
+func (s *KafkaSender) SendAsync(ctx context.Context, report *v1alpha1.DriftReport) {
+	// Make a copy to avoid concurrent modification when multiple senders run in parallel
+	reportCopy := *report
+	reportCopy.TypeMeta = metav1.TypeMeta{
+		APIVersion: v1alpha1.GroupName + "/" + v1alpha1.Version,
+		Kind:       "DriftReport",
+	}
+
+	// Check for deduplication (only for Detected and Rejected phases)
+	id := reportCopy.Spec.ID
+	if phase := reportCopy.Spec.Phase; phase == v1alpha1.DriftReportPhaseDetected || phase == v1alpha1.DriftReportPhaseRejected {
+		if !s.tracker.Track(id) {
+			s.log.V(1).Info("skipping duplicate drift report", "id", id)
+			return
+		}
+	}
+
+	body, err := json.Marshal(&reportCopy)
+	if err != nil {
+		s.log.Error(err, "failed to marshal drift report", "id", id)
+		return
+	}
+
+	record := &kgo.Record{
+		Key:   []byte(CorrelationID(&reportCopy)),
+		Value: body,
+		Topic: s.config.Topic,
+	}
+	// Use background context since the admission request context will be canceled
+	// after the response is sent, but we still want the record to be produced.
+	backend, phase := "kafka/"+s.config.Topic, string(reportCopy.Spec.Phase)
+	start := time.Now()
+	s.producer.TryProduce(context.Background(), record, func(_ *kgo.Record, err error) {
+		switch {
+		case errors.Is(err, kgo.ErrMaxBuffered):
+			s.log.Info("kafka producer buffer full, dropping drift report", "id", id)
+		case err != nil:
+			metrics.CallbackDuration.WithLabelValues(backend).Observe(time.Since(start).Seconds())
+			metrics.CallbackFailed.WithLabelValues(backend, phase).Inc()
+			s.log.Error(err, "failed to produce drift report", "id", id)
+		default:
+			metrics.CallbackDuration.WithLabelValues(backend).Observe(time.Since(start).Seconds())
+			metrics.CallbackSent.WithLabelValues(backend, phase).Inc()
+			s.log.V(1).Info("drift report produced", "id", id)
+		}
+	})
+}
+
+// Close flushes buffered reports and closes the producer. Reports still buffered
+// when ctx is done are lost.
+func (s *KafkaSender) Close(ctx context.Context) error {
+	defer s.producer.Close()
+	if err := s.producer.Flush(ctx); err != nil {
+		return fmt.Errorf("failed to flush drift reports: %w", err)
+	}
+	return nil
+}
+
+// MarkResolved marks a drift as resolved and removes it from the tracker.
+// This allows the same drift to be tracked again if it recurs.
+func (s *KafkaSender) MarkResolved(id string) {
+	s.tracker.Remove(id)
+}
+
+// StartCleanup starts a background cleanup loop for the tracker.
+// Returns a stop function to cancel the loop.
+func (s *KafkaSender) StartCleanup(interval time.Duration) func() {
+	return s.tracker.StartCleanupLoop(interval)
+}
+
+// IsEnabled returns true if the sender is configured with a topic.
+func (s *KafkaSender) IsEnabled() bool {
+	return s.config.Topic != ""
+}
+
+// CorrelationID returns the ID shared by all reports about the same parent and
+// child, independent of the phase and the diff.
+func CorrelationID(report *v1alpha1.DriftReport) string {
+	return GenerateResolutionID(report.Spec.Parent, report.Spec.Child)
+}
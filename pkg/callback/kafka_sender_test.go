@@ -0,0 +1,182 @@
+package callback
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kgo"
+
+	"github.com/kausality-io/kausality/pkg/callback/v1alpha1"
+)
+
+// mockProducer records produced records and completes them immediately,
+// failing with kgo.ErrMaxBuffered once maxBuffered records are pending.
+type mockProducer struct {
+	mu          sync.Mutex
+	records     []*kgo.Record
+	pending     int
+	maxBuffered int
+	flushed     bool
+	closed      bool
+}
+
+func (p *mockProducer) TryProduce(_ context.Context, r *kgo.Record, promise func(*kgo.Record, error)) {
+	p.mu.Lock()
+	if p.maxBuffered > 0 && p.pending >= p.maxBuffered {
+		p.mu.Unlock()
+		promise(r, kgo.ErrMaxBuffered)
+		return
+	}
+	p.records = append(p.records, r)
+	p.pending++
+	p.mu.Unlock()
+	if p.maxBuffered == 0 {
+		promise(r, nil)
+	}
+}
+
+func (p *mockProducer) Flush(context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.pending = 0
+	p.flushed = true
+	return nil
+}
+
+func (p *mockProducer) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.closed = true
+}
+
+func newKafkaTestReport(id string, phase v1alpha1.DriftReportPhase, childName string) *v1alpha1.DriftReport {
+	return &v1alpha1.DriftReport{
+		Spec: v1alpha1.DriftReportSpec{
+			ID:    id,
+			Phase: phase,
+			Parent: v1alpha1.ObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Namespace:  "default",
+				Name:       "app",
+			},
+			Child: v1alpha1.ObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "ReplicaSet",
+				Namespace:  "default",
+				Name:       childName,
+			},
+		},
+	}
+}
+
+func TestKafkaSender_SendAsync(t *testing.T) {
+	producer := &mockProducer{}
+	sender, err := NewKafkaSender(KafkaSenderConfig{
+		Topic:    "drift",
+		Log:      logr.Discard(),
+		Producer: producer,
+	})
+	require.NoError(t, err)
+
+	detected := newKafkaTestReport("drift-id", v1alpha1.DriftReportPhaseDetected, "app-abc")
+	resolved := newKafkaTestReport("resolution-id", v1alpha1.DriftReportPhaseResolved, "app-abc")
+	other := newKafkaTestReport("other-id", v1alpha1.DriftReportPhaseDetected, "app-def")
+	sender.SendAsync(t.Context(), detected)
+	sender.SendAsync(t.Context(), resolved)
+	sender.SendAsync(t.Context(), other)
+
+	require.Len(t, producer.records, 3)
+
+	// Detected and Resolved of the same drift share the key, i.e. the partition
+	key := GenerateResolutionID(detected.Spec.Parent, detected.Spec.Child)
+	assert.Equal(t, key, string(producer.records[0].Key))
+	assert.Equal(t, key, string(producer.records[1].Key))
+	assert.NotEqual(t, key, string(producer.records[2].Key))
+
+	for _, r := range producer.records {
+		assert.Equal(t, "drift", r.Topic)
+	}
+
+	var got v1alpha1.DriftReport
+	require.NoError(t, json.Unmarshal(producer.records[0].Value, &got))
+	assert.Equal(t, v1alpha1.GroupName+"/"+v1alpha1.Version, got.APIVersion)
+	assert.Equal(t, "DriftReport", got.Kind)
+	assert.Equal(t, "drift-id", got.Spec.ID)
+	assert.Equal(t, v1alpha1.DriftReportPhaseDetected, got.Spec.Phase)
+	assert.Equal(t, "app-abc", got.Spec.Child.Name)
+
+	require.NoError(t, json.Unmarshal(producer.records[1].Value, &got))
+	assert.Equal(t, "resolution-id", got.Spec.ID)
+	assert.Equal(t, v1alpha1.DriftReportPhaseResolved, got.Spec.Phase)
+
+	// The caller's report is not modified
+	assert.Empty(t, detected.Kind)
+}
+
+func TestKafkaSender_Deduplication(t *testing.T) {
+	producer := &mockProducer{}
+	sender, err := NewKafkaSender(KafkaSenderConfig{Topic: "drift", Producer: producer})
+	require.NoError(t, err)
+
+	report := newKafkaTestReport("drift-id", v1alpha1.DriftReportPhaseDetected, "app-abc")
+	sender.SendAsync(t.Context(), report)
+	sender.SendAsync(t.Context(), report)
+	assert.Len(t, producer.records, 1)
+
+	sender.MarkResolved("drift-id")
+	sender.SendAsync(t.Context(), report)
+	assert.Len(t, producer.records, 2)
+}
+
+func TestKafkaSender_BufferFullDropsReports(t *testing.T) {
+	producer := &mockProducer{maxBuffered: 2}
+	sender, err := NewKafkaSender(KafkaSenderConfig{Topic: "drift", Producer: producer})
+	require.NoError(t, err)
+
+	for _, name := range []string{"a", "b", "c"} {
+		sender.SendAsync(t.Context(), newKafkaTestReport(name, v1alpha1.DriftReportPhaseDetected, name))
+	}
+	assert.Len(t, producer.records, 2, "third report should be dropped instead of blocking")
+
+	require.NoError(t, sender.Close(t.Context()))
+	assert.True(t, producer.flushed)
+	assert.True(t, producer.closed)
+}
+
+func TestNewKafkaSender_Validation(t *testing.T) {
+	_, err := NewKafkaSender(KafkaSenderConfig{Brokers: []string{"localhost:9092"}})
+	assert.ErrorContains(t, err, "topic")
+
+	_, err = NewKafkaSender(KafkaSenderConfig{Topic: "drift"})
+	assert.ErrorContains(t, err, "brokers")
+
+	_, err = NewKafkaSender(KafkaSenderConfig{
+		Brokers:       []string{"localhost:9092"},
+		Topic:         "drift",
+		SASLMechanism: "GSSAPI",
+		PasswordFile:  "/dev/null",
+	})
+	assert.ErrorContains(t, err, "unsupported SASL mechanism")
+}
+
+func TestMultiSender_WithKafka(t *testing.T) {
+	producer := &mockProducer{}
+	ms, err := NewMultiSender([]SenderConfig{
+		{Kafka: &KafkaSenderConfig{Topic: "drift", Producer: producer}},
+	}, logr.Discard())
+	require.NoError(t, err)
+	require.NotNil(t, ms)
+	assert.Equal(t, 1, ms.Len())
+
+	ms.SendAsync(t.Context(), newKafkaTestReport("drift-id", v1alpha1.DriftReportPhaseDetected, "app-abc"))
+	assert.Len(t, producer.records, 1)
+
+	require.NoError(t, ms.Close(t.Context()))
+	assert.True(t, producer.flushed)
+}
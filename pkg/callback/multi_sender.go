@@ -2,29 +2,59 @@ package callback
 
 import (
 	"context"
+	"errors"
+	"slices"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-logr/logr"
 
 	"github.com/kausality-io/kausality/pkg/callback/v1alpha1"
+	"github.com/kausality-io/kausality/pkg/metrics"
 )
 
-// MultiSender wraps multiple Sender instances and fans out reports to all of them.
+// MultiSender wraps multiple senders and fans out reports to all of them.
 // Each sender has independent deduplication tracking.
 type MultiSender struct {
-	senders []*Sender
-	log     logr.Logger
+	senders []ReportSender
+	// phases holds the phases each sender receives, by index into senders. Empty means all.
+	phases [][]v1alpha1.DriftReportPhase
+	log    logr.Logger
+	// paused suppresses all reports, e.g. during planned maintenance.
+	paused atomic.Bool
 }
 
 // NewMultiSender creates a new MultiSender from a list of SenderConfig.
+// Configs with Kafka set create a KafkaSender, the others an HTTP Sender.
 // Returns nil if configs is empty.
 func NewMultiSender(configs []SenderConfig, log logr.Logger) (*MultiSender, error) {
 	if len(configs) == 0 {
 		return nil, nil
 	}
 
-	senders := make([]*Sender, 0, len(configs))
+	senders := make([]ReportSender, 0, len(configs))
+	phases := make([][]v1alpha1.DriftReportPhase, 0, len(configs))
 	for _, cfg := range configs {
+		if cfg.Kafka != nil {
+			kafkaCfg := *cfg.Kafka
+			if kafkaCfg.Log.GetSink() == nil {
+				kafkaCfg.Log = log
+			}
+			if kafkaCfg.DedupWindow == 0 {
+				kafkaCfg.DedupWindow = cfg.DedupWindow
+			}
+			if kafkaCfg.DedupMaxSize == 0 {
+				kafkaCfg.DedupMaxSize = cfg.DedupMaxSize
+			}
+			sender, err := NewKafkaSender(kafkaCfg)
+			if err != nil {
+				return nil, err
+			}
+			senders = append(senders, sender)
+			phases = append(phases, cfg.Phases)
+			continue
+		}
+
 		// Skip empty URLs
 		if cfg.URL == "" {
 			continue
@@ -40,6 +70,7 @@ func NewMultiSender(configs []SenderConfig, log logr.Logger) (*MultiSender, erro
 			return nil, err
 		}
 		senders = append(senders, sender)
+		phases = append(phases, cfg.Phases)
 	}
 
 	if len(senders) == 0 {
@@ -48,18 +79,46 @@ func NewMultiSender(configs []SenderConfig, log logr.Logger) (*MultiSender, erro
 
 	return &MultiSender{
 		senders: senders,
+		phases:  phases,
 		log:     log.WithName("multi-sender"),
 	}, nil
 }
 
-// SendAsync sends a DriftReport to all configured backends in parallel.
+// SendAsync sends a DriftReport to all configured backends in parallel, skipping
+// backends whose Phases don't include the report's phase.
 // Each backend has independent deduplication tracking.
+// While paused, reports are dropped and counted instead.
 func (m *MultiSender) SendAsync(ctx context.Context, report *v1alpha1.DriftReport) {
-	for _, sender := range m.senders {
+	if m.paused.Load() {
+		metrics.CallbacksSuppressed.WithLabelValues(string(report.Spec.Phase)).Inc()
+		m.log.V(1).Info("drift callback suppressed, callbacks are paused", "id", report.Spec.ID, "phase", report.Spec.Phase)
+		return
+	}
+	for i, sender := range m.senders {
+		if len(m.phases[i]) > 0 && !slices.Contains(m.phases[i], report.Spec.Phase) {
+			continue
+		}
 		sender.SendAsync(ctx, report)
 	}
 }
 
+// SetPaused pauses or resumes all callbacks. Detection and enforcement are not affected.
+func (m *MultiSender) SetPaused(paused bool) {
+	if m.paused.Swap(paused) == paused {
+		return
+	}
+	if paused {
+		m.log.Info("DRIFT CALLBACKS PAUSED - reports are suppressed until resumed")
+	} else {
+		m.log.Info("DRIFT CALLBACKS RESUMED")
+	}
+}
+
+// Paused returns true if callbacks are paused.
+func (m *MultiSender) Paused() bool {
+	return m.paused.Load()
+}
+
 // IsEnabled returns true if at least one sender is configured.
 func (m *MultiSender) IsEnabled() bool {
 	return len(m.senders) > 0
@@ -86,13 +145,26 @@ func (m *MultiSender) StartCleanup(interval time.Duration) func() {
 	}
 }
 
-// Len returns the number of configured senders.
+// Close flushes and closes all senders that buffer reports, e.g. Kafka senders and
+// observe senders.
+func (m *MultiSender) Close(ctx context.Context) error {
+	var errs []error
+	for _, sender := range m.senders {
+		if c, ok := sender.(interface{ Close(context.Context) error }); ok {
+			errs = append(errs, c.Close(ctx))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Len returns the number of configured senders, regardless of their phase filters.
 func (m *MultiSender) Len() int {
 	return len(m.senders)
 }
 
-// Ensure Sender and MultiSender implement ReportSender.
+// Ensure Sender, KafkaSender and MultiSender implement ReportSender.
 var (
 	_ ReportSender = (*Sender)(nil)
+	_ ReportSender = (*KafkaSender)(nil)
 	_ ReportSender = (*MultiSender)(nil)
 )
@@ -109,6 +109,48 @@ func TestMultiSender_SendAsync_FansOut(t *testing.T) {
 	}, ktesting.Timeout, ktesting.PollInterval, "all backends should receive 1 report")
 }
 
+func TestMultiSender_SendAsync_PhaseFilter(t *testing.T) {
+	var counts [3]atomic.Int32
+
+	servers := make([]*httptest.Server, 3)
+	for i := 0; i < 3; i++ {
+		idx := i
+		servers[i] = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			counts[idx].Add(1)
+			response := v1alpha1.DriftReportResponse{Acknowledged: true}
+			_ = json.NewEncoder(w).Encode(response)
+		}))
+		defer servers[i].Close()
+	}
+
+	ms, err := NewMultiSender([]SenderConfig{
+		{URL: servers[0].URL, Phases: []v1alpha1.DriftReportPhase{v1alpha1.DriftReportPhaseDetected}, Log: logr.Discard()},
+		{URL: servers[1].URL, Phases: []v1alpha1.DriftReportPhase{v1alpha1.DriftReportPhaseResolved}, Log: logr.Discard()},
+		{URL: servers[2].URL, Log: logr.Discard()},
+	}, logr.Discard())
+	require.NoError(t, err)
+	require.NotNil(t, ms)
+	assert.Equal(t, 3, ms.Len(), "filtered senders are still counted")
+
+	ms.SendAsync(context.Background(), &v1alpha1.DriftReport{Spec: v1alpha1.DriftReportSpec{ID: "phase-detected", Phase: v1alpha1.DriftReportPhaseDetected}})
+	ms.SendAsync(context.Background(), &v1alpha1.DriftReport{Spec: v1alpha1.DriftReportSpec{ID: "phase-breakglass", Phase: v1alpha1.DriftReportPhaseBreakGlass}})
+
+	want := [3]int32{1, 0, 2}
+	ktesting.Eventually(t, func() (bool, string) {
+		for i := range want {
+			if counts[i].Load() != want[i] {
+				return false, fmt.Sprintf("count[%d]=%d, want %d", i, counts[i].Load(), want[i])
+			}
+		}
+		return true, "backends received their phases"
+	}, ktesting.Timeout, ktesting.PollInterval, "backends should only receive reports in their phases")
+
+	// Nothing more arrives at the filtered backends.
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, int32(1), counts[0].Load())
+	assert.Equal(t, int32(0), counts[1].Load())
+}
+
 func TestMultiSender_IsEnabled(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		response := v1alpha1.DriftReportResponse{Acknowledged: true}
@@ -0,0 +1,95 @@
+package callback
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DefaultPauseConfigMapKey is the ConfigMap data key read by ConfigMapPause if none is configured.
+const DefaultPauseConfigMapKey = "paused"
+
+// DefaultPauseCheckInterval is how often the pause ConfigMap is re-read by default.
+const DefaultPauseCheckInterval = 10 * time.Second
+
+// ConfigMapPause reads the global callback pause from a boolean ConfigMap entry.
+// A missing ConfigMap or key means not paused.
+type ConfigMapPause struct {
+	reader client.Reader
+	key    types.NamespacedName
+	data   string
+}
+
+// NewConfigMapPause creates a pause flag reading data[key] of the ConfigMap namespace/name.
+// An empty key defaults to DefaultPauseConfigMapKey.
+func NewConfigMapPause(reader client.Reader, namespace, name, key string) *ConfigMapPause {
+	if key == "" {
+		key = DefaultPauseConfigMapKey
+	}
+	return &ConfigMapPause{
+		reader: reader,
+		key:    types.NamespacedName{Namespace: namespace, Name: name},
+		data:   key,
+	}
+}
+
+// Paused returns true if the ConfigMap entry is set to true.
+func (p *ConfigMapPause) Paused(ctx context.Context) (bool, error) {
+	var cm corev1.ConfigMap
+	if err := p.reader.Get(ctx, p.key, &cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get callback pause ConfigMap %s: %w", p.key, err)
+	}
+	value, ok := cm.Data[p.data]
+	if !ok {
+		return false, nil
+	}
+	paused, err := strconv.ParseBool(strings.TrimSpace(value))
+	if err != nil {
+		return false, fmt.Errorf("invalid value %q for key %q in callback pause ConfigMap %s: %w", value, p.data, p.key, err)
+	}
+	return paused, nil
+}
+
+// WatchPause re-reads the pause ConfigMap every interval until ctx is done and pauses or
+// resumes callbacks when its value changes. Changes made with SetPaused in between, e.g.
+// by a signal, stick until the ConfigMap value changes. Read errors keep the current state.
+func (m *MultiSender) WatchPause(ctx context.Context, pause *ConfigMapPause, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultPauseCheckInterval
+	}
+	// An unread ConfigMap counts as not paused, so a pause set at startup sticks
+	last := false
+	check := func() {
+		paused, err := pause.Paused(ctx)
+		if err != nil {
+			m.log.Error(err, "failed to read callback pause, keeping current state", "paused", m.Paused())
+			return
+		}
+		if paused != last {
+			m.SetPaused(paused)
+			last = paused
+		}
+	}
+
+	check()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			check()
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package callback
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kausality-io/kausality/pkg/callback/v1alpha1"
+	"github.com/kausality-io/kausality/pkg/metrics"
+	ktesting "github.com/kausality-io/kausality/pkg/testing"
+)
+
+func TestMultiSender_PausedSuppressesReports(t *testing.T) {
+	var received atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received.Add(1)
+		_ = json.NewEncoder(w).Encode(v1alpha1.DriftReportResponse{Acknowledged: true})
+	}))
+	defer server.Close()
+
+	ms, err := NewMultiSender([]SenderConfig{{URL: server.URL}}, logr.Discard())
+	require.NoError(t, err)
+
+	suppressed := metrics.CallbacksSuppressed.WithLabelValues(string(v1alpha1.DriftReportPhaseDetected))
+	before := testutil.ToFloat64(suppressed)
+
+	ms.SetPaused(true)
+	assert.True(t, ms.Paused())
+	ms.SendAsync(t.Context(), &v1alpha1.DriftReport{Spec: v1alpha1.DriftReportSpec{ID: "paused", Phase: v1alpha1.DriftReportPhaseDetected}})
+	assert.Equal(t, before+1, testutil.ToFloat64(suppressed))
+
+	// Resuming sends again; the suppressed report was dropped, not queued
+	ms.SetPaused(false)
+	ms.SendAsync(t.Context(), &v1alpha1.DriftReport{Spec: v1alpha1.DriftReportSpec{ID: "resumed", Phase: v1alpha1.DriftReportPhaseDetected}})
+	ktesting.Eventually(t, func() (bool, string) {
+		return received.Load() == 1, "waiting for the report sent after resume"
+	}, ktesting.Timeout, ktesting.PollInterval)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int32(1), received.Load())
+}
+
+func TestConfigMapPause(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       map[string]string
+		missing    bool
+		wantPaused bool
+		wantErr    bool
+	}{
+		{name: "missing ConfigMap is not paused", missing: true},
+		{name: "missing key is not paused", data: map[string]string{"other": "true"}},
+		{name: "true", data: map[string]string{"paused": "true"}, wantPaused: true},
+		{name: "false", data: map[string]string{"paused": "false"}},
+		{name: "invalid value is not paused", data: map[string]string{"paused": "soon"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := fake.NewClientBuilder()
+			if !tt.missing {
+				builder = builder.WithObjects(&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "kausality-system", Name: "maintenance"},
+					Data:       tt.data,
+				})
+			}
+			paused, err := NewConfigMapPause(builder.Build(), "kausality-system", "maintenance", "").Paused(t.Context())
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantPaused, paused)
+		})
+	}
+}
+
+func TestMultiSender_WatchPause(t *testing.T) {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kausality-system", Name: "maintenance"},
+		Data:       map[string]string{"paused": "true"},
+	}
+	c := fake.NewClientBuilder().WithObjects(cm).Build()
+	ms, err := NewMultiSender([]SenderConfig{{URL: "http://localhost"}}, logr.Discard())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	go ms.WatchPause(ctx, NewConfigMapPause(c, "kausality-system", "maintenance", ""), 10*time.Millisecond)
+
+	ktesting.Eventually(t, func() (bool, string) {
+		return ms.Paused(), "waiting for pause"
+	}, ktesting.Timeout, ktesting.PollInterval)
+
+	cm.Data["paused"] = "false"
+	require.NoError(t, c.Update(t.Context(), cm))
+	ktesting.Eventually(t, func() (bool, string) {
+		return !ms.Paused(), "waiting for resume"
+	}, ktesting.Timeout, ktesting.PollInterval)
+}
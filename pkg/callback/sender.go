@@ -8,8 +8,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math/rand/v2"
 	"net/http"
+	"net/url"
 	"os"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -17,6 +21,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/kausality-io/kausality/pkg/callback/v1alpha1"
+	"github.com/kausality-io/kausality/pkg/config"
+	"github.com/kausality-io/kausality/pkg/metrics"
 )
 
 // ReportSender sends drift reports to backend endpoints.
@@ -38,18 +44,56 @@ type SenderConfig struct {
 	Timeout time.Duration
 	// RetryCount is the number of retries on failure. Default is 3.
 	RetryCount int
-	// RetryInterval is the interval between retries. Default is 1 second.
+	// RetryInterval is the base interval of the exponential backoff between retries.
+	// Each wait is random between zero and the doubled interval (full jitter), so that
+	// concurrent sends do not retry in lockstep. Default is 1 second.
 	RetryInterval time.Duration
+	// MaxRetryInterval caps the backoff between retries. Default is 30 seconds.
+	MaxRetryInterval time.Duration
+	// MaxInFlight bounds the reports SendAsync sends concurrently. Reports beyond that
+	// are dropped and counted in Dropped. Default is 100.
+	MaxInFlight int
+	// DedupWindow is how long duplicate Detected reports of the same drift ID are
+	// suppressed. Resolved reports are always sent. Default is 10 minutes.
+	DedupWindow time.Duration
+	// DedupMaxSize bounds the drift IDs remembered for deduplication. Default is 10000.
+	DedupMaxSize int
 	// Log is the logger. If nil, a noop logger is used.
 	Log logr.Logger
+	// Kafka produces reports to a Kafka topic instead of POSTing them to URL.
+	Kafka *KafkaSenderConfig
+	// Phases restricts the reports MultiSender sends to this sender to these phases.
+	// Empty means all phases.
+	Phases []v1alpha1.DriftReportPhase
+	// Mode is empty for a regular backend, or config.BackendModeObserve for a backend that
+	// only observes drift. An observe sender guarantees that it never delays or
+	// influences admission: SendAsync only enqueues a copy of the report without
+	// blocking, MaxInFlight workers send the queue, reports beyond QueueSize are
+	// dropped and counted in Dropped, and responses are not inspected beyond their
+	// status code.
+	Mode string
+	// QueueSize bounds the reports waiting for an observe backend. Default is 1000.
+	QueueSize int
 }
 
 // Sender sends DriftReports to webhook endpoints.
 type Sender struct {
-	config  SenderConfig
-	client  *http.Client
-	tracker *Tracker
-	log     logr.Logger
+	config   SenderConfig
+	backend  string
+	client   *http.Client
+	tracker  *Tracker
+	inFlight chan struct{}
+	dropped  atomic.Int64
+	log      logr.Logger
+
+	// queue, workers and cancel are only set in observe mode. queueMu guards closing
+	// the queue against concurrent sends.
+	queue   chan v1alpha1.DriftReport
+	queueMu sync.RWMutex
+	closed  bool
+	workers sync.WaitGroup
+	ctx     context.Context
+	cancel  context.CancelFunc
 }
 
 // NewSender creates a new Sender with the given configuration.
@@ -64,6 +108,18 @@ func NewSender(cfg SenderConfig) (*Sender, error) {
 	if cfg.RetryInterval == 0 {
 		cfg.RetryInterval = 1 * time.Second
 	}
+	if cfg.MaxRetryInterval <= 0 {
+		cfg.MaxRetryInterval = 30 * time.Second
+	}
+	if cfg.MaxInFlight <= 0 {
+		cfg.MaxInFlight = 100
+	}
+	if cfg.Mode != "" && cfg.Mode != config.BackendModeObserve {
+		return nil, fmt.Errorf("unknown sender mode %q", cfg.Mode)
+	}
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = 1000
+	}
 
 	// Create TLS config
 	tlsConfig := &tls.Config{
@@ -94,12 +150,23 @@ func NewSender(cfg SenderConfig) (*Sender, error) {
 		log = logr.Discard()
 	}
 
-	return &Sender{
-		config:  cfg,
-		client:  client,
-		tracker: NewTracker(),
-		log:     log.WithName("drift-callback"),
-	}, nil
+	s := &Sender{
+		config:   cfg,
+		backend:  backendLabel(cfg.URL),
+		client:   client,
+		tracker:  NewTracker(WithTTL(cfg.DedupWindow), WithMaxSize(cfg.DedupMaxSize)),
+		inFlight: make(chan struct{}, cfg.MaxInFlight),
+		log:      log.WithName("drift-callback"),
+	}
+	if cfg.Mode == config.BackendModeObserve {
+		s.queue = make(chan v1alpha1.DriftReport, cfg.QueueSize)
+		s.ctx, s.cancel = context.WithCancel(context.Background())
+		s.workers.Add(cfg.MaxInFlight)
+		for range cfg.MaxInFlight {
+			go s.work()
+		}
+	}
+	return s, nil
 }
 
 // Send sends a DriftReport to the configured webhook endpoint.
@@ -111,8 +178,8 @@ func (s *Sender) Send(ctx context.Context, report *v1alpha1.DriftReport) error {
 		Kind:       "DriftReport",
 	}
 
-	// Check for deduplication (only for Detected phase)
-	if report.Spec.Phase == v1alpha1.DriftReportPhaseDetected {
+	// Check for deduplication (only for Detected and Rejected phases)
+	if phase := report.Spec.Phase; phase == v1alpha1.DriftReportPhaseDetected || phase == v1alpha1.DriftReportPhaseRejected {
 		if !s.tracker.Track(report.Spec.ID) {
 			s.log.V(1).Info("skipping duplicate drift report", "id", report.Spec.ID)
 			return nil
@@ -129,22 +196,28 @@ func (s *Sender) Send(ctx context.Context, report *v1alpha1.DriftReport) error {
 	var lastErr error
 	for attempt := 0; attempt <= s.config.RetryCount; attempt++ {
 		if attempt > 0 {
+			backoff := s.retryBackoff(attempt)
 			s.log.V(1).Info("retrying drift report",
 				"attempt", attempt,
 				"id", report.Spec.ID,
+				"backoff", backoff,
 				"lastError", lastErr,
 			)
 			select {
 			case <-ctx.Done():
 				return ctx.Err()
-			case <-time.After(s.config.RetryInterval):
+			case <-time.After(backoff):
 			}
 		}
 
+		start := time.Now()
 		lastErr = s.doSend(ctx, body, report.Spec.ID)
+		metrics.CallbackDuration.WithLabelValues(s.backend).Observe(time.Since(start).Seconds())
 		if lastErr == nil {
+			metrics.CallbackSent.WithLabelValues(s.backend, string(report.Spec.Phase)).Inc()
 			return nil
 		}
+		metrics.CallbackFailed.WithLabelValues(s.backend, string(report.Spec.Phase)).Inc()
 	}
 
 	s.log.Error(lastErr, "failed to send drift report after retries",
@@ -154,6 +227,30 @@ func (s *Sender) Send(ctx context.Context, report *v1alpha1.DriftReport) error {
 	return lastErr
 }
 
+// backendLabel returns the host of a webhook URL as metrics label, or the URL itself
+// if it has none.
+func backendLabel(rawURL string) string {
+	if u, err := url.Parse(rawURL); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return rawURL
+}
+
+// retryBackoff returns the wait before the given retry attempt (starting at 1): random
+// between zero and RetryInterval doubled per previous retry, capped at MaxRetryInterval.
+func (s *Sender) retryBackoff(attempt int) time.Duration {
+	ceiling := s.config.MaxRetryInterval
+	if shift := attempt - 1; shift < 32 {
+		if d := s.config.RetryInterval << shift; d > 0 && d < ceiling {
+			ceiling = d
+		}
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+	return rand.N(ceiling + 1)
+}
+
 // doSend performs a single send attempt.
 func (s *Sender) doSend(ctx context.Context, body []byte, id string) error {
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.config.URL, bytes.NewReader(body))
@@ -180,6 +277,12 @@ func (s *Sender) doSend(ctx context.Context, body []byte, id string) error {
 		return fmt.Errorf("webhook returned status %d: %s", resp.StatusCode, string(respBody))
 	}
 
+	// An observe backend cannot influence anything, so its response is not inspected
+	if s.config.Mode == config.BackendModeObserve {
+		s.log.V(1).Info("drift report observed", "id", id)
+		return nil
+	}
+
 	// Parse response
 	var response v1alpha1.DriftReportResponse
 	if err := json.Unmarshal(respBody, &response); err != nil {
@@ -202,10 +305,32 @@ func (s *Sender) doSend(ctx context.Context, body []byte, id string) error {
 // SendAsync sends a DriftReport asynchronously.
 // The report is sent in a goroutine and any errors are logged but not returned.
 // Uses a background context since the original request context may be canceled.
+// If MaxInFlight reports are being sent already, e.g. to a slow backend, the report
+// is dropped and counted in Dropped.
 func (s *Sender) SendAsync(_ context.Context, report *v1alpha1.DriftReport) {
+	if s.queue != nil {
+		s.enqueue(report)
+		return
+	}
+
+	select {
+	case s.inFlight <- struct{}{}:
+	default:
+		dropped := s.dropped.Add(1)
+		s.log.Info("too many drift reports in flight, dropping", "id", report.Spec.ID, "maxInFlight", s.config.MaxInFlight, "dropped", dropped)
+		return
+	}
+
+	inFlight := metrics.CallbackInFlight.WithLabelValues(s.backend)
+	inFlight.Inc()
+
 	// Make a copy to avoid concurrent modification when multiple senders run in parallel
 	reportCopy := *report
 	go func() {
+		defer func() {
+			inFlight.Dec()
+			<-s.inFlight
+		}()
 		// Use background context since the admission request context will be canceled
 		// after the response is sent, but we still want to complete the HTTP request.
 		if err := s.Send(context.Background(), &reportCopy); err != nil {
@@ -214,6 +339,69 @@ func (s *Sender) SendAsync(_ context.Context, report *v1alpha1.DriftReport) {
 	}()
 }
 
+// enqueue queues a copy of the report for the observe workers without ever blocking.
+// If the queue is full, e.g. because the backend hangs, the report is dropped.
+func (s *Sender) enqueue(report *v1alpha1.DriftReport) {
+	s.queueMu.RLock()
+	defer s.queueMu.RUnlock()
+	if s.closed {
+		return
+	}
+	select {
+	case s.queue <- *report:
+	default:
+		dropped := s.dropped.Add(1)
+		s.log.Info("observe queue full, dropping drift report", "id", report.Spec.ID, "queueSize", s.config.QueueSize, "dropped", dropped)
+	}
+}
+
+// work sends queued reports to an observe backend until the queue is closed.
+func (s *Sender) work() {
+	defer s.workers.Done()
+	inFlight := metrics.CallbackInFlight.WithLabelValues(s.backend)
+	for report := range s.queue {
+		inFlight.Inc()
+		if err := s.Send(s.ctx, &report); err != nil {
+			s.log.Error(err, "observe drift report send failed", "id", report.Spec.ID)
+		}
+		inFlight.Dec()
+	}
+}
+
+// Close sends the reports queued for an observe backend. Reports still queued or
+// being sent when ctx is done are lost. Other senders have nothing to flush.
+func (s *Sender) Close(ctx context.Context) error {
+	if s.queue == nil {
+		return nil
+	}
+	s.queueMu.Lock()
+	if !s.closed {
+		s.closed = true
+		close(s.queue)
+	}
+	s.queueMu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.workers.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		s.cancel()
+		return nil
+	case <-ctx.Done():
+		s.cancel()
+		return ctx.Err()
+	}
+}
+
+// Dropped returns the number of reports SendAsync dropped because MaxInFlight reports
+// were being sent already, or for an observe backend, because its queue was full.
+func (s *Sender) Dropped() int64 {
+	return s.dropped.Load()
+}
+
 // MarkResolved marks a drift as resolved and removes it from the tracker.
 // This allows the same drift to be tracked again if it recurs.
 func (s *Sender) MarkResolved(id string) {
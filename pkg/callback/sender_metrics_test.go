@@ -0,0 +1,106 @@
+package callback
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kausality-io/kausality/pkg/callback/v1alpha1"
+	"github.com/kausality-io/kausality/pkg/metrics"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func fakeResponse(status int, body string) *http.Response {
+	return &http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader(body)), Header: http.Header{}}
+}
+
+func attempts(t *testing.T, backend string) uint64 {
+	t.Helper()
+	var m dto.Metric
+	require.NoError(t, metrics.CallbackDuration.WithLabelValues(backend).(prometheus.Histogram).Write(&m))
+	return m.GetHistogram().GetSampleCount()
+}
+
+func TestSender_Metrics(t *testing.T) {
+	const backend = "metrics.example.com:8443"
+	phase := string(v1alpha1.DriftReportPhaseDetected)
+	sent := metrics.CallbackSent.WithLabelValues(backend, phase)
+	failed := metrics.CallbackFailed.WithLabelValues(backend, phase)
+
+	sender, err := NewSender(SenderConfig{
+		URL:           "https://" + backend + "/drift",
+		RetryCount:    3,
+		RetryInterval: time.Millisecond,
+		Log:           logr.Discard(),
+	})
+	require.NoError(t, err)
+
+	var calls atomic.Int32
+	sender.client.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		if calls.Add(1) < 3 {
+			return fakeResponse(http.StatusServiceUnavailable, "backend down"), nil
+		}
+		return fakeResponse(http.StatusOK, `{"acknowledged":true}`), nil
+	})
+
+	sentBefore, failedBefore, attemptsBefore := testutil.ToFloat64(sent), testutil.ToFloat64(failed), attempts(t, backend)
+	require.NoError(t, sender.Send(context.Background(), &v1alpha1.DriftReport{
+		Spec: v1alpha1.DriftReportSpec{ID: "metrics-retry", Phase: v1alpha1.DriftReportPhaseDetected},
+	}))
+	assert.Equal(t, sentBefore+1, testutil.ToFloat64(sent), "one successful attempt")
+	assert.Equal(t, failedBefore+2, testutil.ToFloat64(failed), "retried attempts count as failures")
+	assert.Equal(t, attemptsBefore+3, attempts(t, backend), "every attempt is timed")
+
+	sender.client.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return nil, io.ErrUnexpectedEOF
+	})
+	sentBefore, failedBefore = testutil.ToFloat64(sent), testutil.ToFloat64(failed)
+	require.Error(t, sender.Send(context.Background(), &v1alpha1.DriftReport{
+		Spec: v1alpha1.DriftReportSpec{ID: "metrics-exhausted", Phase: v1alpha1.DriftReportPhaseDetected},
+	}))
+	assert.Equal(t, sentBefore, testutil.ToFloat64(sent))
+	assert.Equal(t, failedBefore+4, testutil.ToFloat64(failed), "initial attempt and 3 retries")
+}
+
+func TestSender_InFlightMetric(t *testing.T) {
+	const backend = "in-flight.example.com"
+	inFlight := metrics.CallbackInFlight.WithLabelValues(backend)
+
+	sender, err := NewSender(SenderConfig{URL: "http://" + backend, Log: logr.Discard()})
+	require.NoError(t, err)
+
+	release := make(chan struct{})
+	sender.client.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		<-release
+		return fakeResponse(http.StatusOK, `{"acknowledged":true}`), nil
+	})
+
+	sender.SendAsync(context.Background(), &v1alpha1.DriftReport{
+		Spec: v1alpha1.DriftReportSpec{ID: "in-flight", Phase: v1alpha1.DriftReportPhaseDetected},
+	})
+	assert.Equal(t, float64(1), testutil.ToFloat64(inFlight))
+
+	close(release)
+	assert.Eventually(t, func() bool { return testutil.ToFloat64(inFlight) == 0 }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestBackendLabel(t *testing.T) {
+	assert.Equal(t, "drift.example.com:8443", backendLabel("https://drift.example.com:8443/api/v1/drifts"))
+	assert.Equal(t, "drift-backend", backendLabel("drift-backend"))
+}
@@ -3,6 +3,7 @@ package callback
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -17,6 +18,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/kausality-io/kausality/pkg/callback/v1alpha1"
+	"github.com/kausality-io/kausality/pkg/config"
 )
 
 func TestSender_Send(t *testing.T) {
@@ -129,6 +131,53 @@ func TestSender_Deduplication(t *testing.T) {
 	assert.Equal(t, int32(2), callCount.Load())
 }
 
+func TestSender_DedupWindow(t *testing.T) {
+	var detected, resolved atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var report v1alpha1.DriftReport
+		_ = json.NewDecoder(r.Body).Decode(&report)
+		if report.Spec.Phase == v1alpha1.DriftReportPhaseResolved {
+			resolved.Add(1)
+		} else {
+			detected.Add(1)
+		}
+		_ = json.NewEncoder(w).Encode(v1alpha1.DriftReportResponse{Acknowledged: true})
+	}))
+	defer server.Close()
+
+	sender, err := NewSender(SenderConfig{
+		URL:          server.URL,
+		DedupWindow:  200 * time.Millisecond,
+		DedupMaxSize: 10,
+		Log:          logr.Discard(),
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	send := func(phase v1alpha1.DriftReportPhase) {
+		require.NoError(t, sender.Send(ctx, &v1alpha1.DriftReport{
+			Spec: v1alpha1.DriftReportSpec{ID: "retried-id", Phase: phase},
+		}))
+	}
+
+	// A controller retrying a blocked drift fires the same ID rapidly
+	for range 20 {
+		send(v1alpha1.DriftReportPhaseDetected)
+	}
+	assert.Equal(t, int32(1), detected.Load())
+
+	// Resolved reports are never suppressed
+	send(v1alpha1.DriftReportPhaseResolved)
+	send(v1alpha1.DriftReportPhaseResolved)
+	assert.Equal(t, int32(2), resolved.Load())
+
+	// After the window, the drift is reported again
+	time.Sleep(250 * time.Millisecond)
+	send(v1alpha1.DriftReportPhaseDetected)
+	assert.Equal(t, int32(2), detected.Load())
+}
+
 func TestSender_NoDeduplicationForResolved(t *testing.T) {
 	var callCount atomic.Int32
 
@@ -374,6 +423,73 @@ func TestSender_SendAsync(t *testing.T) {
 	}
 }
 
+func TestSender_RetryBackoff(t *testing.T) {
+	sender, err := NewSender(SenderConfig{
+		URL:              "https://webhook.example.com",
+		RetryInterval:    100 * time.Millisecond,
+		MaxRetryInterval: time.Second,
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		attempt int
+		ceiling time.Duration
+	}{
+		{attempt: 1, ceiling: 100 * time.Millisecond},
+		{attempt: 2, ceiling: 200 * time.Millisecond},
+		{attempt: 4, ceiling: 800 * time.Millisecond},
+		{attempt: 5, ceiling: time.Second},
+		{attempt: 100, ceiling: time.Second},
+	}
+	for _, tt := range tests {
+		var maxSeen time.Duration
+		for range 200 {
+			backoff := sender.retryBackoff(tt.attempt)
+			require.GreaterOrEqual(t, backoff, time.Duration(0))
+			require.LessOrEqual(t, backoff, tt.ceiling, "attempt %d", tt.attempt)
+			maxSeen = max(maxSeen, backoff)
+		}
+		assert.Greater(t, maxSeen, tt.ceiling/2, "attempt %d: waits are spread up to the ceiling", tt.attempt)
+	}
+}
+
+func TestSender_SendAsync_MaxInFlight(t *testing.T) {
+	release := make(chan struct{})
+	var callCount atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		callCount.Add(1)
+		<-release
+		_ = json.NewEncoder(w).Encode(v1alpha1.DriftReportResponse{Acknowledged: true})
+	}))
+	defer server.Close()
+	defer close(release)
+
+	sender, err := NewSender(SenderConfig{
+		URL:         server.URL,
+		MaxInFlight: 2,
+		Log:         logr.Discard(),
+	})
+	require.NoError(t, err)
+
+	for _, id := range []string{"a", "b", "c", "d"} {
+		sender.SendAsync(context.Background(), &v1alpha1.DriftReport{
+			Spec: v1alpha1.DriftReportSpec{ID: id, Phase: v1alpha1.DriftReportPhaseDetected},
+		})
+	}
+	assert.Equal(t, int64(2), sender.Dropped(), "reports beyond MaxInFlight are dropped")
+	assert.Eventually(t, func() bool { return callCount.Load() == 2 }, 2*time.Second, 10*time.Millisecond)
+
+	// Slots are freed once sends complete
+	release <- struct{}{}
+	release <- struct{}{}
+	assert.Eventually(t, func() bool { return len(sender.inFlight) == 0 }, 2*time.Second, 10*time.Millisecond)
+	sender.SendAsync(context.Background(), &v1alpha1.DriftReport{
+		Spec: v1alpha1.DriftReportSpec{ID: "e", Phase: v1alpha1.DriftReportPhaseDetected},
+	})
+	assert.Eventually(t, func() bool { return callCount.Load() == 3 }, 2*time.Second, 10*time.Millisecond)
+	assert.Equal(t, int64(2), sender.Dropped())
+}
+
 func TestSender_IsEnabled(t *testing.T) {
 	sender, err := NewSender(SenderConfig{
 		URL: "https://webhook.example.com",
@@ -396,3 +512,55 @@ func TestNewSender_InvalidCAFile(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to read CA file")
 }
+
+func TestSender_Observe(t *testing.T) {
+	var received atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received.Add(1)
+		// Not a DriftReportResponse: observe backends are not inspected beyond the status
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	sender, err := NewSender(SenderConfig{URL: server.URL, Mode: config.BackendModeObserve, Log: logr.Discard()})
+	require.NoError(t, err)
+
+	report := &v1alpha1.DriftReport{Spec: v1alpha1.DriftReportSpec{ID: "observe", Phase: v1alpha1.DriftReportPhaseDetected}}
+	require.NoError(t, sender.Send(context.Background(), report))
+	assert.Equal(t, int32(1), received.Load())
+
+	sender.SendAsync(context.Background(), &v1alpha1.DriftReport{Spec: v1alpha1.DriftReportSpec{ID: "observe-async", Phase: v1alpha1.DriftReportPhaseDetected}})
+	require.NoError(t, sender.Close(t.Context()))
+	assert.Equal(t, int32(2), received.Load(), "Close sends the queue")
+
+	sender.SendAsync(context.Background(), &v1alpha1.DriftReport{Spec: v1alpha1.DriftReportSpec{ID: "observe-closed", Phase: v1alpha1.DriftReportPhaseDetected}})
+	assert.Zero(t, sender.Dropped(), "reports after Close are ignored")
+}
+
+func TestSender_ObserveQueueFull(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+
+	sender, err := NewSender(SenderConfig{URL: server.URL, Mode: config.BackendModeObserve, MaxInFlight: 1, QueueSize: 2, Log: logr.Discard()})
+	require.NoError(t, err)
+	defer func() {
+		// Unblock the hung backend first, so that Close can drain the queue
+		close(release)
+		require.NoError(t, sender.Close(t.Context()))
+	}()
+
+	for i := range 10 {
+		sender.SendAsync(context.Background(), &v1alpha1.DriftReport{Spec: v1alpha1.DriftReportSpec{ID: fmt.Sprintf("hang-%d", i), Phase: v1alpha1.DriftReportPhaseDetected}})
+	}
+	// One report is being sent, two are queued, and between one and two more may have
+	// been queued after the worker took the first
+	assert.GreaterOrEqual(t, sender.Dropped(), int64(6))
+}
+
+func TestNewSender_InvalidMode(t *testing.T) {
+	_, err := NewSender(SenderConfig{URL: "http://backend", Mode: "blocking"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,77 @@
+package callback
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"sigs.k8s.io/yaml"
+)
+
+// DefaultSpecDiffMaxBytes bounds rendered spec diffs by default.
+const DefaultSpecDiffMaxBytes = 16 * 1024
+
+// RenderSpecDiff renders the change from oldSpec to newSpec as a unified diff of their
+// YAML, with a/spec and b/spec as file names. A nil spec renders as empty, e.g. for
+// CREATE. Diffs longer than maxBytes are cut at a line boundary and end in a
+// truncation marker; maxBytes <= 0 uses DefaultSpecDiffMaxBytes. It returns "" if the
+// specs render identically.
+func RenderSpecDiff(oldSpec, newSpec interface{}, maxBytes int) (string, error) {
+	oldYAML, err := specYAML(oldSpec)
+	if err != nil {
+		return "", fmt.Errorf("failed to render old spec: %w", err)
+	}
+	newYAML, err := specYAML(newSpec)
+	if err != nil {
+		return "", fmt.Errorf("failed to render new spec: %w", err)
+	}
+	if oldYAML == newYAML {
+		return "", nil
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(oldYAML),
+		B:        splitLines(newYAML),
+		FromFile: "a/spec",
+		ToFile:   "b/spec",
+		Context:  3,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to diff specs: %w", err)
+	}
+	return truncateDiff(diff, maxBytes), nil
+}
+
+// specYAML renders a spec as YAML with sorted keys, "" for nil.
+func specYAML(spec interface{}) (string, error) {
+	if spec == nil {
+		return "", nil
+	}
+	out, err := yaml.Marshal(spec)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// splitLines splits YAML into lines keeping their newlines. Unlike difflib.SplitLines,
+// it adds no empty line after the final newline.
+func splitLines(s string) []string {
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// truncateDiff cuts diff at the last line boundary within maxBytes.
+func truncateDiff(diff string, maxBytes int) string {
+	if maxBytes <= 0 {
+		maxBytes = DefaultSpecDiffMaxBytes
+	}
+	if len(diff) <= maxBytes {
+		return diff
+	}
+	cut := strings.LastIndexByte(diff[:maxBytes], '\n') + 1
+	return fmt.Sprintf("%s... diff truncated, %d of %d bytes shown\n", diff[:cut], cut, len(diff))
+}
@@ -0,0 +1,85 @@
+package callback
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderSpecDiff(t *testing.T) {
+	t.Run("replicas change", func(t *testing.T) {
+		diff, err := RenderSpecDiff(
+			map[string]interface{}{"replicas": int64(3), "paused": false},
+			map[string]interface{}{"replicas": int64(5), "paused": false},
+			0,
+		)
+		require.NoError(t, err)
+		assert.Equal(t, `--- a/spec
++++ b/spec
+@@ -1,2 +1,2 @@
+ paused: false
+-replicas: 3
++replicas: 5
+`, diff)
+	})
+
+	t.Run("nested structures", func(t *testing.T) {
+		container := func(image string) map[string]interface{} {
+			return map[string]interface{}{
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"containers": []interface{}{
+							map[string]interface{}{"name": "app", "image": image},
+						},
+					},
+				},
+			}
+		}
+		diff, err := RenderSpecDiff(container("nginx:1.25"), container("nginx:1.26"), 0)
+		require.NoError(t, err)
+		assert.Equal(t, `--- a/spec
++++ b/spec
+@@ -1,5 +1,5 @@
+ template:
+   spec:
+     containers:
+-    - image: nginx:1.25
++    - image: nginx:1.26
+       name: app
+`, diff)
+	})
+
+	t.Run("creation", func(t *testing.T) {
+		diff, err := RenderSpecDiff(nil, map[string]interface{}{"replicas": int64(1)}, 0)
+		require.NoError(t, err)
+		assert.Equal(t, "--- a/spec\n+++ b/spec\n@@ -0,0 +1 @@\n+replicas: 1\n", diff)
+	})
+
+	t.Run("identical specs", func(t *testing.T) {
+		diff, err := RenderSpecDiff(map[string]interface{}{"replicas": int64(1)}, map[string]interface{}{"replicas": int64(1)}, 0)
+		require.NoError(t, err)
+		assert.Empty(t, diff)
+	})
+
+	t.Run("truncation", func(t *testing.T) {
+		oldSpec, newSpec := map[string]interface{}{}, map[string]interface{}{}
+		for _, k := range []string{"a", "b", "c", "d", "e", "f"} {
+			oldSpec[k] = strings.Repeat("x", 20)
+			newSpec[k] = strings.Repeat("y", 20)
+		}
+		full, err := RenderSpecDiff(oldSpec, newSpec, 1<<20)
+		require.NoError(t, err)
+		require.Greater(t, len(full), 100)
+
+		diff, err := RenderSpecDiff(oldSpec, newSpec, 100)
+		require.NoError(t, err)
+		shown, marker, found := strings.Cut(diff, "... diff truncated")
+		require.True(t, found, diff)
+		assert.LessOrEqual(t, len(shown), 100)
+		assert.True(t, strings.HasPrefix(full, shown))
+		assert.True(t, strings.HasSuffix(shown, "\n"), "cut at a line boundary")
+		assert.Contains(t, marker, " bytes shown\n")
+	})
+}
@@ -1,6 +1,7 @@
 package callback
 
 import (
+	"container/list"
 	"sync"
 	"time"
 )
@@ -8,15 +9,28 @@ import (
 // DefaultTTL is the default time-to-live for tracked IDs.
 const DefaultTTL = 10 * time.Minute
 
+// DefaultMaxSize is the default number of IDs a Tracker holds.
+const DefaultMaxSize = 10000
+
 // Tracker tracks drift IDs for deduplication.
-// It maintains an in-memory map of recently sent IDs with TTL-based expiration.
+// It maintains an in-memory map of recently sent IDs with TTL-based expiration,
+// bounded to a maximum number of IDs. When full, the least recently tracked ID is
+// evicted first.
 type Tracker struct {
 	mu      sync.RWMutex
-	ids     map[string]time.Time // ID -> expiration time
+	ids     map[string]*list.Element // ID -> element in order
+	order   *list.List               // trackedID, least recently tracked first
 	ttl     time.Duration
+	maxSize int
 	nowFunc func() time.Time // for testing
 }
 
+// trackedID is an ID with its expiration time.
+type trackedID struct {
+	id     string
+	expiry time.Time
+}
+
 // TrackerOption configures the Tracker.
 type TrackerOption func(*Tracker)
 
@@ -27,6 +41,13 @@ func WithTTL(ttl time.Duration) TrackerOption {
 	}
 }
 
+// WithMaxSize bounds the number of tracked IDs. Values below 1 mean DefaultMaxSize.
+func WithMaxSize(n int) TrackerOption {
+	return func(t *Tracker) {
+		t.maxSize = n
+	}
+}
+
 // WithNowFunc sets the function to get the current time (for testing).
 func WithNowFunc(fn func() time.Time) TrackerOption {
 	return func(t *Tracker) {
@@ -37,13 +58,21 @@ func WithNowFunc(fn func() time.Time) TrackerOption {
 // NewTracker creates a new Tracker with optional configuration.
 func NewTracker(opts ...TrackerOption) *Tracker {
 	t := &Tracker{
-		ids:     make(map[string]time.Time),
+		ids:     make(map[string]*list.Element),
+		order:   list.New(),
 		ttl:     DefaultTTL,
+		maxSize: DefaultMaxSize,
 		nowFunc: time.Now,
 	}
 	for _, opt := range opts {
 		opt(t)
 	}
+	if t.ttl <= 0 {
+		t.ttl = DefaultTTL
+	}
+	if t.maxSize < 1 {
+		t.maxSize = DefaultMaxSize
+	}
 	return t
 }
 
@@ -56,26 +85,38 @@ func (t *Tracker) Track(id string) bool {
 	now := t.nowFunc()
 
 	// Check if already tracked and not expired
-	if expiry, exists := t.ids[id]; exists {
-		if now.Before(expiry) {
+	if elem, exists := t.ids[id]; exists {
+		if now.Before(elem.Value.(*trackedID).expiry) {
 			// Already tracked and not expired
 			return false
 		}
+		t.remove(elem)
+	}
+
+	// Evict the least recently tracked IDs when full
+	for t.order.Len() >= t.maxSize {
+		t.remove(t.order.Front())
 	}
 
-	// Add/update with new expiration
-	t.ids[id] = now.Add(t.ttl)
+	// Add with new expiration
+	t.ids[id] = t.order.PushBack(&trackedID{id: id, expiry: now.Add(t.ttl)})
 	return true
 }
 
+// remove removes an element from the tracker. The caller must hold t.mu.
+func (t *Tracker) remove(elem *list.Element) {
+	t.order.Remove(elem)
+	delete(t.ids, elem.Value.(*trackedID).id)
+}
+
 // IsTracked returns true if the ID is currently tracked and not expired.
 func (t *Tracker) IsTracked(id string) bool {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
 
 	now := t.nowFunc()
-	if expiry, exists := t.ids[id]; exists {
-		return now.Before(expiry)
+	if elem, exists := t.ids[id]; exists {
+		return now.Before(elem.Value.(*trackedID).expiry)
 	}
 	return false
 }
@@ -84,7 +125,9 @@ func (t *Tracker) IsTracked(id string) bool {
 func (t *Tracker) Remove(id string) {
 	t.mu.Lock()
 	defer t.mu.Unlock()
-	delete(t.ids, id)
+	if elem, exists := t.ids[id]; exists {
+		t.remove(elem)
+	}
 }
 
 // Cleanup removes expired entries from the tracker.
@@ -93,13 +136,15 @@ func (t *Tracker) Cleanup() int {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
+	// IDs share one TTL, so they expire in the order they were tracked
 	now := t.nowFunc()
 	count := 0
-	for id, expiry := range t.ids {
-		if now.After(expiry) || now.Equal(expiry) {
-			delete(t.ids, id)
-			count++
+	for elem := t.order.Front(); elem != nil; elem = t.order.Front() {
+		if now.Before(elem.Value.(*trackedID).expiry) {
+			break
 		}
+		t.remove(elem)
+		count++
 	}
 	return count
 }
@@ -77,6 +77,26 @@ func TestTracker_Expiration(t *testing.T) {
 	assert.True(t, tracker.Track("id1")) // Can track again
 }
 
+func TestTracker_MaxSize(t *testing.T) {
+	tracker := NewTracker(WithMaxSize(2))
+
+	assert.True(t, tracker.Track("id1"))
+	assert.True(t, tracker.Track("id2"))
+	assert.False(t, tracker.Track("id1")) // Duplicate, doesn't make id1 more recent
+
+	// Full: id1 is the least recently tracked and evicted
+	assert.True(t, tracker.Track("id3"))
+	assert.Equal(t, 2, tracker.Size())
+	assert.False(t, tracker.IsTracked("id1"))
+	assert.True(t, tracker.IsTracked("id2"))
+	assert.True(t, tracker.IsTracked("id3"))
+
+	// Evicted IDs are sent again
+	assert.True(t, tracker.Track("id1"))
+	assert.False(t, tracker.IsTracked("id2"))
+	assert.Equal(t, 2, tracker.Size())
+}
+
 func TestTracker_Cleanup(t *testing.T) {
 	now := time.Now()
 	currentTime := now
@@ -22,6 +22,47 @@ const (
 	DriftReportPhaseDetected DriftReportPhase = "Detected"
 	// DriftReportPhaseResolved indicates drift was resolved.
 	DriftReportPhaseResolved DriftReportPhase = "Resolved"
+	// DriftReportPhaseBreakGlass indicates a mutation was admitted with a break-glass token.
+	DriftReportPhaseBreakGlass DriftReportPhase = "BreakGlass"
+	// DriftReportPhasePostureChange indicates an UPDATE weakened kausality enforcement
+	// on an object. Parent and child both reference that object.
+	DriftReportPhasePostureChange DriftReportPhase = "PostureChange"
+	// DriftReportPhaseFreezeApplied indicates an UPDATE froze an object.
+	// Parent and child both reference that object.
+	DriftReportPhaseFreezeApplied DriftReportPhase = "FreezeApplied"
+	// DriftReportPhaseSnoozeApplied indicates an UPDATE snoozed drift callbacks of an
+	// object. Parent and child both reference that object.
+	DriftReportPhaseSnoozeApplied DriftReportPhase = "SnoozeApplied"
+	// DriftReportPhaseStuck indicates a controller kept retrying a drift that enforce
+	// mode blocked, i.e. the controller is wedged.
+	DriftReportPhaseStuck DriftReportPhase = "Stuck"
+	// DriftReportPhaseRejected indicates drift matched a rejection on the parent,
+	// whether enforce mode denied it or it was only warned about.
+	DriftReportPhaseRejected DriftReportPhase = "Rejected"
+)
+
+// DriftReportPhases lists all drift report phases.
+var DriftReportPhases = []DriftReportPhase{
+	DriftReportPhaseDetected,
+	DriftReportPhaseResolved,
+	DriftReportPhaseBreakGlass,
+	DriftReportPhasePostureChange,
+	DriftReportPhaseFreezeApplied,
+	DriftReportPhaseSnoozeApplied,
+	DriftReportPhaseStuck,
+	DriftReportPhaseRejected,
+}
+
+// DriftReportSeverity indicates how urgently a report needs attention.
+type DriftReportSeverity string
+
+const (
+	// DriftReportSeverityInfo is informational.
+	DriftReportSeverityInfo DriftReportSeverity = "Info"
+	// DriftReportSeverityWarning needs attention.
+	DriftReportSeverityWarning DriftReportSeverity = "Warning"
+	// DriftReportSeverityCritical needs immediate attention.
+	DriftReportSeverityCritical DriftReportSeverity = "Critical"
 )
 
 // DriftReport is sent to webhook endpoints when drift is detected.
@@ -45,6 +86,21 @@ type DriftReportSpec struct {
 	// +required
 	Phase DriftReportPhase `json:"phase"`
 
+	// severity indicates how urgently the report needs attention.
+	// Empty means the receiver decides based on phase.
+	// +optional
+	Severity DriftReportSeverity `json:"severity,omitempty"`
+
+	// firstSeen is when the drift with this ID was first detected.
+	// It is preserved across webhook restarts. For Resolved reports it refers
+	// to the drift being resolved, if known.
+	// +optional
+	FirstSeen *metav1.Time `json:"firstSeen,omitempty"`
+
+	// synthetic marks a report for an injected test drift, not a real one.
+	// +optional
+	Synthetic bool `json:"synthetic,omitempty"`
+
 	// parent is the parent object reference.
 	// +required
 	Parent ObjectReference `json:"parent"`
@@ -53,17 +109,129 @@ type DriftReportSpec struct {
 	// +required
 	Child ObjectReference `json:"child"`
 
-	// oldObject is the previous state. Only set for UPDATE operations.
+	// oldObject is the previous state. Only set for UPDATE operations, and
+	// omitted if the webhook is configured not to include full objects.
 	// +optional
 	OldObject *runtime.RawExtension `json:"oldObject,omitempty"`
 
-	// newObject is the current/new state of the object.
-	// +required
+	// newObject is the current/new state of the object. Null if the webhook is
+	// configured not to include full objects.
+	// +optional
 	NewObject runtime.RawExtension `json:"newObject"`
 
 	// request contains admission request context.
 	// +required
 	Request RequestContext `json:"request"`
+
+	// ownershipConflicts lists spec fields whose server-side apply ownership the
+	// request took from other field managers.
+	// +optional
+	OwnershipConflicts []OwnershipConflict `json:"ownershipConflicts,omitempty"`
+
+	// clearedFields lists spec fields set by someone else that the controller
+	// removed or reset to their zero value, e.g. "spec.replicas".
+	// +optional
+	ClearedFields []string `json:"clearedFields,omitempty"`
+
+	// specDiff is a unified diff of the spec change (old vs new YAML), possibly
+	// truncated. Only set if enabled in the webhook configuration.
+	// +optional
+	SpecDiff string `json:"specDiff,omitempty"`
+
+	// specChanges lists the changed spec leaf fields with their old and new values,
+	// sorted by path. Only set for Detected, Resolved and BreakGlass reports.
+	// +optional
+	SpecChanges []FieldChange `json:"specChanges,omitempty"`
+
+	// recreated is true if the controller deleted the child and created it again,
+	// as done for immutable resources.
+	// +optional
+	Recreated bool `json:"recreated,omitempty"`
+
+	// postureChanges describes how a PostureChange report weakened enforcement,
+	// e.g. "mode downgraded from enforce to log".
+	// +optional
+	PostureChanges []string `json:"postureChanges,omitempty"`
+
+	// lockdown describes the freeze or snooze of a FreezeApplied or SnoozeApplied report.
+	// +optional
+	Lockdown *Lockdown `json:"lockdown,omitempty"`
+
+	// approvalNote is the note of the approval that resolved the drift,
+	// e.g. "approved per CHG-1234". Only set for Resolved reports.
+	// +optional
+	ApprovalNote string `json:"approvalNote,omitempty"`
+
+	// blockedAttempts is how often the drift was blocked in a row. Only set for
+	// Stuck reports.
+	// +optional
+	BlockedAttempts int `json:"blockedAttempts,omitempty"`
+
+	// rejection is the parent's rejection of the drift. Only set for Rejected
+	// reports.
+	// +optional
+	Rejection *Rejection `json:"rejection,omitempty"`
+}
+
+// Rejection describes the rejection of a drift.
+type Rejection struct {
+	// reason explains why the drift is rejected.
+	// +required
+	Reason string `json:"reason"`
+
+	// severity is info, warn or critical.
+	// +optional
+	Severity string `json:"severity,omitempty"`
+
+	// remediation tells the writer how to proceed.
+	// +optional
+	Remediation string `json:"remediation,omitempty"`
+}
+
+// Lockdown describes a freeze or snooze applied to an object. The report's request
+// user is the actor; its parent is the scope, i.e. the object whose children are
+// frozen or whose drift callbacks are snoozed.
+type Lockdown struct {
+	// user is the user recorded in the annotation, e.g. by the CLI. It may differ
+	// from the actor if the annotation was written on someone's behalf.
+	// +optional
+	User string `json:"user,omitempty"`
+
+	// message is the reason given in the annotation.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// expiry is when a snooze ends.
+	// +optional
+	Expiry *metav1.Time `json:"expiry,omitempty"`
+}
+
+// FieldChange describes a spec field changed by a request.
+type FieldChange struct {
+	// path is the dotted field path, e.g. "spec.replicas" or "spec.containers[0].image".
+	// Lists of equal length are compared element-wise, other lists as a whole.
+	// +required
+	Path string `json:"path"`
+
+	// oldValue is the JSON value before the request. Unset if the field was added.
+	// +optional
+	OldValue *runtime.RawExtension `json:"oldValue,omitempty"`
+
+	// newValue is the JSON value after the request. Unset if the field was removed.
+	// +optional
+	NewValue *runtime.RawExtension `json:"newValue,omitempty"`
+}
+
+// OwnershipConflict describes a spec field whose server-side apply ownership moved
+// to the requesting field manager.
+type OwnershipConflict struct {
+	// field is the field path, e.g. "spec.replicas".
+	// +required
+	Field string `json:"field"`
+
+	// previousManagers are the field managers that owned the field before the request.
+	// +required
+	PreviousManagers []string `json:"previousManagers"`
 }
 
 // ObjectReference identifies a Kubernetes object.
@@ -121,6 +289,11 @@ type RequestContext struct {
 	// +optional
 	FieldManager string `json:"fieldManager,omitempty"`
 
+	// controllerVersion is the version or image of the controller that sent the request.
+	// Best-effort; omitted when unknown.
+	// +optional
+	ControllerVersion string `json:"controllerVersion,omitempty"`
+
 	// operation is the type of operation (CREATE, UPDATE, DELETE).
 	// +required
 	Operation string `json:"operation"`
@@ -70,6 +70,27 @@ func TestDriftReport_JSONRoundTrip(t *testing.T) {
 	assert.Equal(t, report.Spec.Request, decoded.Spec.Request)
 }
 
+func TestFieldChange_JSONRoundTrip(t *testing.T) {
+	changes := []FieldChange{
+		{Path: "spec.replicas", OldValue: &runtime.RawExtension{Raw: []byte(`3`)}, NewValue: &runtime.RawExtension{Raw: []byte(`5`)}},
+		{Path: "spec.template.containers[0].image", OldValue: &runtime.RawExtension{Raw: []byte(`"app:v1"`)}, NewValue: &runtime.RawExtension{Raw: []byte(`"app:v2"`)}},
+		{Path: "spec.selector", NewValue: &runtime.RawExtension{Raw: []byte(`{"matchLabels":{"app":"web"}}`)}},
+		{Path: "spec.ports", OldValue: &runtime.RawExtension{Raw: []byte(`[{"port":80},{"port":443}]`)}},
+	}
+
+	data, err := json.Marshal(DriftReportSpec{ID: "a1b2c3d4e5f67890", SpecChanges: changes})
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"specChanges":[`+
+		`{"path":"spec.replicas","oldValue":3,"newValue":5},`+
+		`{"path":"spec.template.containers[0].image","oldValue":"app:v1","newValue":"app:v2"},`+
+		`{"path":"spec.selector","newValue":{"matchLabels":{"app":"web"}}},`+
+		`{"path":"spec.ports","oldValue":[{"port":80},{"port":443}]}]`)
+
+	var decoded DriftReportSpec
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, changes, decoded.SpecChanges)
+}
+
 func TestDriftReportResponse_JSONRoundTrip(t *testing.T) {
 	tests := []struct {
 		name     string
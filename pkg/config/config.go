@@ -2,9 +2,15 @@
 package config
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"path"
+	"slices"
 	"strings"
+	"text/template"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -12,20 +18,235 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	kausalityv1alpha1 "github.com/kausality-io/kausality/api/v1alpha1"
+	"github.com/kausality-io/kausality/pkg/callback/v1alpha1"
 )
 
 // Config is the root configuration structure.
 type Config struct {
 	DriftDetection DriftDetectionConfig `yaml:"driftDetection"`
-	// Backends configures drift report webhook endpoints.
+	// Backends configures drift report webhook endpoints and Kafka topics.
 	// Reports are sent to all configured backends in parallel.
 	Backends []BackendConfig `yaml:"backends,omitempty"`
+	// CallbackPause silences all drift callbacks cluster-wide, e.g. during planned
+	// maintenance, without affecting detection and enforcement. If nil, callbacks are
+	// only paused with --pause-callbacks or a signal.
+	CallbackPause *CallbackPauseConfig `yaml:"callbackPause,omitempty"`
+	// BreakGlass configures emergency break-glass tokens.
+	// If nil, break-glass tokens are ignored.
+	BreakGlass *BreakGlassConfig `yaml:"breakGlass,omitempty"`
+	// ControllerVersions maps fieldManagers to the source of the controller version
+	// reported in DriftReports (request.controllerVersion).
+	ControllerVersions []ControllerVersionSource `yaml:"controllerVersions,omitempty"`
+	// DiscoverControllerVersions looks up the version of service account users
+	// without a mapping from the image of the Deployment running as that service account.
+	DiscoverControllerVersions bool `yaml:"discoverControllerVersions,omitempty"`
+	// OpenLineage exports traced mutations as OpenLineage run events.
+	// Independent of drift callbacks. If nil, nothing is exported.
+	OpenLineage *OpenLineageConfig `yaml:"openLineage,omitempty"`
+	// TraceMaxAge prunes hops older than this from traces when they are extended.
+	// The origin and the most recent hop are always kept. Zero keeps all hops.
+	TraceMaxAge time.Duration `yaml:"traceMaxAge,omitempty"`
+	// MaxTraceHops caps the number of hops of a trace. Beyond it, the oldest hops after
+	// the origin are collapsed into one "(N hops omitted)" marker hop. Zero defaults to
+	// 32 hops; the minimum is 3.
+	MaxTraceHops int `yaml:"maxTraceHops,omitempty"`
+	// SpecDiff adds a unified diff of the spec change to drift reports (spec.specDiff),
+	// for review like a code change. If nil, reports carry no diff.
+	SpecDiff *SpecDiffConfig `yaml:"specDiff,omitempty"`
+	// IncludeFullObjects includes the full old and new objects in drift reports
+	// (spec.oldObject, spec.newObject). Defaults to true; set it to false to keep
+	// reports small and rely on spec.specChanges.
+	IncludeFullObjects *bool `yaml:"includeFullObjects,omitempty"`
+	// ApprovalSweep periodically prunes stale and expired approvals from parents,
+	// also those whose children never drift again. If nil, approvals are only
+	// pruned when one is consumed on admission.
+	ApprovalSweep *ApprovalSweepConfig `yaml:"approvalSweep,omitempty"`
+	// AnnotationPrefix replaces "kausality.io/" in all annotation keys the webhook reads
+	// and writes, e.g. "staging.kausality.io/", so that two instances can run side by side
+	// on the same objects. It must be a DNS subdomain followed by "/". Empty means
+	// "kausality.io/".
+	AnnotationPrefix string `yaml:"annotationPrefix,omitempty"`
+	// IdentityHash configures the hashes of user identifiers in the controllers and
+	// updaters annotations. If nil, they are 5-character base36 hashes.
+	IdentityHash *IdentityHashConfig `yaml:"identityHash,omitempty"`
+	// DenyMessageTemplate renders the message of drift denials, and of the warnings of
+	// drift that would be denied in enforce mode, e.g. to link a runbook. It is a Go
+	// text/template over DenyMessageData, e.g.
+	// "{{.Reason}} ({{.ParentKind}} {{.ParentName}}), see https://wiki.example.com/drift".
+	// Empty means the default message, which is .Reason.
+	DenyMessageTemplate string `yaml:"denyMessageTemplate,omitempty"`
 }
 
-// BackendConfig configures a drift report webhook endpoint.
-type BackendConfig struct {
-	// URL is the webhook endpoint URL.
+// DenyMessageData is the data DenyMessageTemplate is rendered with.
+type DenyMessageData struct {
+	// ParentKind and ParentName identify the parent the drift was detected against.
+	ParentKind string
+	ParentName string
+	// ChildKind, ChildName and Namespace identify the mutated child.
+	ChildKind string
+	ChildName string
+	Namespace string
+	// Actor is the username of the request.
+	Actor string
+	// Reason is the default message, e.g. "drift detected: no approval found for this
+	// mutation".
+	Reason string
+}
+
+// RenderDenyMessage renders DenyMessageTemplate with data. Without a template, or if it
+// fails to render, it returns data.Reason.
+func (c *Config) RenderDenyMessage(data DenyMessageData) string {
+	if c == nil || c.DenyMessageTemplate == "" {
+		return data.Reason
+	}
+	msg, err := renderDenyMessage(c.DenyMessageTemplate, data)
+	if err != nil {
+		return data.Reason
+	}
+	return msg
+}
+
+// renderDenyMessage parses and executes the deny message template text with data.
+func renderDenyMessage(text string, data DenyMessageData) (string, error) {
+	tmpl, err := template.New("denyMessage").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// AnnotationKeys returns the annotation keys under AnnotationPrefix.
+func (c *Config) AnnotationKeys() kausalityv1alpha1.AnnotationKeys {
+	return kausalityv1alpha1.NewAnnotationKeys(c.AnnotationPrefix)
+}
+
+// Identity hash algorithms for IdentityHashConfig.Algorithm.
+const (
+	// HashAlgorithmBase36 is the default 5-character base36 hash.
+	HashAlgorithmBase36 = "base36"
+	// HashAlgorithmHex is a hex-encoded SHA-256 prefix of configurable length.
+	HashAlgorithmHex = "hex"
+)
+
+// Identity hash lengths.
+const (
+	// Base36HashLength is the length of base36 hashes.
+	Base36HashLength = 5
+	// DefaultHexHashLength is the default length of hex hashes, i.e. 64 bits.
+	DefaultHexHashLength = 16
+	// MinHexHashLength is the minimum length of hex hashes, i.e. 32 bits.
+	MinHexHashLength = 8
+)
+
+// IdentityHashConfig configures the hashes of user identifiers. Changing it makes
+// existing controllers and updaters annotations unrecognizable until controllers are
+// recorded again.
+type IdentityHashConfig struct {
+	// Algorithm is "base36" (default) or "hex".
+	Algorithm string `yaml:"algorithm,omitempty"`
+	// Length is the number of characters. base36 hashes have 5; hex hashes 8 to 64,
+	// default 16.
+	Length int `yaml:"length,omitempty"`
+}
+
+// ApprovalSweepConfig configures the periodic approval sweep.
+type ApprovalSweepConfig struct {
+	// Interval between sweeps. Zero disables the sweep.
+	Interval time.Duration `yaml:"interval,omitempty"`
+	// Parents are the parent kinds whose approvals are swept.
+	Parents []SweepParent `yaml:"parents"`
+}
+
+// SweepParent is a parent kind swept for stale approvals.
+type SweepParent struct {
+	// APIVersion of the parent, e.g. "apps/v1".
+	APIVersion string `yaml:"apiVersion"`
+	// Kind of the parent, e.g. "Deployment".
+	Kind string `yaml:"kind"`
+}
+
+// SpecDiffConfig configures the unified spec diff in drift reports.
+type SpecDiffConfig struct {
+	// MaxBytes truncates longer diffs. Defaults to 16KiB.
+	MaxBytes int `yaml:"maxBytes,omitempty"`
+}
+
+// ControllerVersionSource configures where the version of a controller comes from.
+// Exactly one of Version or Deployment must be set.
+type ControllerVersionSource struct {
+	// FieldManager of the controller's requests.
+	FieldManager string `yaml:"fieldManager"`
+	// Version is a static version string.
+	Version string `yaml:"version,omitempty"`
+	// Deployment reports the image of a container in the referenced Deployment.
+	Deployment *DeploymentRef `yaml:"deployment,omitempty"`
+}
+
+// DeploymentRef references a container in a Deployment.
+type DeploymentRef struct {
+	// Namespace of the Deployment.
+	Namespace string `yaml:"namespace"`
+	// Name of the Deployment.
+	Name string `yaml:"name"`
+	// Container name. If empty, the first container is used.
+	Container string `yaml:"container,omitempty"`
+}
+
+// CallbackPauseConfig configures the global callback pause flag.
+type CallbackPauseConfig struct {
+	// ConfigMap references a ConfigMap entry holding "true" (paused) or "false".
+	// A missing ConfigMap or key means not paused.
+	ConfigMap *ConfigMapKeyRef `yaml:"configMap"`
+	// CheckInterval is how often the flag is re-read. Default is 10 seconds.
+	CheckInterval time.Duration `yaml:"checkInterval,omitempty"`
+}
+
+// ConfigMapKeyRef references an entry of a ConfigMap.
+type ConfigMapKeyRef struct {
+	// Namespace of the ConfigMap.
+	Namespace string `yaml:"namespace"`
+	// Name of the ConfigMap.
+	Name string `yaml:"name"`
+	// Key in the ConfigMap data. Defaults to "paused".
+	Key string `yaml:"key,omitempty"`
+}
+
+// BreakGlassConfig configures verification of break-glass tokens.
+type BreakGlassConfig struct {
+	// PublicKeyFile is the path to the PEM-encoded ed25519 public key that verifies tokens.
+	PublicKeyFile string `yaml:"publicKeyFile"`
+	// MaxTokenAge is the maximum age of a token. Default is 15 minutes.
+	MaxTokenAge time.Duration `yaml:"maxTokenAge,omitempty"`
+}
+
+// OpenLineageConfig configures export of OpenLineage run events.
+// Events are emitted for trace origins and for object creation and deletion.
+type OpenLineageConfig struct {
+	// URL is the OpenLineage HTTP endpoint, e.g. "http://marquez:5000/api/v1/lineage".
 	URL string `yaml:"url"`
+	// APIKeyFile is the path to a file with a bearer token sent to the endpoint.
+	APIKeyFile string `yaml:"apiKeyFile,omitempty"`
+	// Timeout is the request timeout. Default is 10 seconds.
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+	// JobNamespace is the OpenLineage namespace of jobs (the users producing changes).
+	// Default is "kausality".
+	JobNamespace string `yaml:"jobNamespace,omitempty"`
+	// DatasetNamespace is the OpenLineage namespace of datasets (the Kubernetes objects).
+	// Default is "kubernetes"; set it to the cluster name to tell clusters apart.
+	DatasetNamespace string `yaml:"datasetNamespace,omitempty"`
+}
+
+// BackendConfig configures a drift report webhook endpoint, or a Kafka topic.
+type BackendConfig struct {
+	// URL is the webhook endpoint URL. Must be empty if Kafka is set.
+	URL string `yaml:"url,omitempty"`
 	// CAFile is the path to the CA certificate file for TLS verification.
 	// If empty, system CA pool is used.
 	CAFile string `yaml:"caFile,omitempty"`
@@ -33,17 +254,425 @@ type BackendConfig struct {
 	Timeout time.Duration `yaml:"timeout,omitempty"`
 	// RetryCount is the number of retries on failure. Default is 3.
 	RetryCount int `yaml:"retryCount,omitempty"`
-	// RetryInterval is the interval between retries. Default is 1 second.
+	// RetryInterval is the base interval of the exponential backoff between retries,
+	// with full jitter. Default is 1 second.
 	RetryInterval time.Duration `yaml:"retryInterval,omitempty"`
+	// MaxRetryInterval caps the backoff between retries. Default is 30 seconds.
+	MaxRetryInterval time.Duration `yaml:"maxRetryInterval,omitempty"`
+	// MaxInFlight bounds the reports sent to this backend concurrently. Reports beyond
+	// that are dropped. Default is 100.
+	MaxInFlight int `yaml:"maxInFlight,omitempty"`
+	// DedupWindow is how long duplicate Detected reports of the same drift are
+	// suppressed, e.g. while a controller keeps retrying a blocked mutation. Resolved
+	// reports are always sent. Default is 10 minutes.
+	DedupWindow time.Duration `yaml:"dedupWindow,omitempty"`
+	// DedupMaxSize bounds the drifts remembered for deduplication. When full, the
+	// least recently reported drift is forgotten first. Default is 10000.
+	DedupMaxSize int `yaml:"dedupMaxSize,omitempty"`
+	// Kafka produces reports to a Kafka topic instead of POSTing them to URL.
+	// Timeout and retries do not apply; the Kafka client retries on its own.
+	Kafka *KafkaConfig `yaml:"kafka,omitempty"`
+	// Phases restricts the reports sent to this backend to these phases, e.g.
+	// ["Detected"]. Empty means all phases.
+	Phases []v1alpha1.DriftReportPhase `yaml:"phases,omitempty"`
+	// Mode "observe" marks a URL backend that only observes drift, e.g. for compliance.
+	// It is guaranteed never to delay or influence admission: reports are queued
+	// without blocking, dropped when the queue is full, and responses are not
+	// inspected beyond their status code. Empty means a regular backend.
+	Mode string `yaml:"mode,omitempty"`
+	// QueueSize bounds the reports waiting for an observe backend. Default is 1000.
+	QueueSize int `yaml:"queueSize,omitempty"`
+}
+
+// BackendModeObserve is the BackendConfig.Mode of a backend that only observes drift.
+const BackendModeObserve = "observe"
+
+// KafkaConfig configures a Kafka drift report backend. Reports are keyed by a
+// correlation ID derived from parent and child, so that Detected and Resolved
+// reports of the same drift land on the same partition.
+type KafkaConfig struct {
+	// Brokers are the seed brokers, e.g. "kafka-0.kafka:9092".
+	Brokers []string `yaml:"brokers"`
+	// Topic is the topic reports are produced to.
+	Topic string `yaml:"topic"`
+	// TLS enables TLS to the brokers. If nil, plaintext is used.
+	TLS *KafkaTLSConfig `yaml:"tls,omitempty"`
+	// SASL enables SASL authentication. If nil, no authentication is used.
+	SASL *KafkaSASLConfig `yaml:"sasl,omitempty"`
+	// MaxBufferedRecords is the number of reports buffered while brokers are slow
+	// or unreachable. Reports beyond that are dropped. Default is 1000.
+	MaxBufferedRecords int `yaml:"maxBufferedRecords,omitempty"`
+}
+
+// KafkaTLSConfig configures TLS to Kafka brokers.
+type KafkaTLSConfig struct {
+	// CAFile is the path to the CA certificate file for TLS verification.
+	// If empty, system CA pool is used.
+	CAFile string `yaml:"caFile,omitempty"`
+}
+
+// KafkaSASLConfig configures SASL authentication to Kafka brokers.
+type KafkaSASLConfig struct {
+	// Mechanism is one of PLAIN, SCRAM-SHA-256 or SCRAM-SHA-512.
+	Mechanism string `yaml:"mechanism"`
+	// Username is the SASL username.
+	Username string `yaml:"username"`
+	// PasswordFile is the path to a file containing the SASL password.
+	PasswordFile string `yaml:"passwordFile"`
 }
 
 // DriftDetectionConfig configures drift detection behavior.
 type DriftDetectionConfig struct {
-	// DefaultMode is the default drift detection mode ("log" or "enforce").
+	// DefaultMode is the default drift detection mode ("log", "enforce", "warn" or "dryrun").
 	DefaultMode string `yaml:"defaultMode"`
 
 	// Overrides allows per-resource drift detection configuration.
 	Overrides []DriftDetectionOverride `yaml:"overrides,omitempty"`
+
+	// ArrayMergeKeys declares spec arrays that are compared as sets keyed by an
+	// identity field, so reordering them is not considered a spec change.
+	ArrayMergeKeys []ArrayMergeKey `yaml:"arrayMergeKeys,omitempty"`
+
+	// IgnoredSpecPaths are JSON Pointers of spec fields that are removed from both the
+	// old and the new spec before they are compared, e.g.
+	// "/spec/template/metadata/annotations/kubectl.kubernetes.io~1restartedAt" for
+	// rollout restarts. Numeric reference tokens index into arrays. A change of only
+	// these fields is no spec change.
+	IgnoredSpecPaths []string `yaml:"ignoredSpecPaths,omitempty"`
+
+	// FreezeLifecycleAware lets the parent's controller keep mutating children
+	// of a frozen parent while the parent is reconciling (generation != observedGeneration),
+	// so a user change made before the freeze can converge. All other mutations stay blocked.
+	// Default (false) is strict: freeze blocks everything except during deletion.
+	FreezeLifecycleAware bool `yaml:"freezeLifecycleAware,omitempty"`
+
+	// StripOnCreate lists annotations removed from objects on CREATE, in addition
+	// to kausality.io/*, so annotations copied from an owner don't leak into children.
+	// Entries ending in "/" or "*" are prefixes; all others match exact keys.
+	StripOnCreate []string `yaml:"stripOnCreate,omitempty"`
+
+	// ApprovalProposals enables the learning loop: after a controller makes the same
+	// unapproved correction to a child Threshold times, a DriftApprovalProposal is
+	// written for operator review. Proposals are never applied automatically.
+	ApprovalProposals *ApprovalProposalsConfig `yaml:"approvalProposals,omitempty"`
+
+	// ApprovalHistory records consumed mode=once approvals in the parent's
+	// kausality.io/approval-history annotation, for audits. If nil, consumed
+	// approvals are removed without a record.
+	ApprovalHistory *ApprovalHistoryConfig `yaml:"approvalHistory,omitempty"`
+
+	// StuckDetection reports controllers wedged on blocked drift: once enforce mode blocked
+	// the same drift Threshold times, a Stuck DriftReport is sent. If nil, nothing is reported.
+	StuckDetection *StuckDetectionConfig `yaml:"stuckDetection,omitempty"`
+
+	// ControllerSelection decides which status writers are recorded as the parent's controller.
+	// "statusWriters" (default) records every status writer. "observedGenerationOwner" records
+	// only the writer owning status.observedGeneration, so secondary status writers don't flip
+	// the drift-vs-actor decision. Resources without observedGeneration record every writer.
+	ControllerSelection string `yaml:"controllerSelection,omitempty"`
+
+	// OwnerSelection decides which ownerReferences of a child are evaluated as its parent.
+	// "controllerOnly" (default) uses the controller ownerReference. "firstOwner" falls back to
+	// the first ownerReference if none is marked as controller. "allOwners" evaluates every
+	// owner and reports drift if any of them is stable.
+	OwnerSelection string `yaml:"ownerSelection,omitempty"`
+
+	// OnGVKMismatch decides what happens to an UPDATE whose old and new objects have
+	// different apiVersion or kind. This indicates a tampered request or an apiserver bug.
+	// "deny" (default) rejects the request. "allowWithWarning" admits it unprocessed with a warning.
+	OnGVKMismatch string `yaml:"onGVKMismatch,omitempty"`
+	// OnNamespaceMetadataUnavailable decides what happens when the namespace of an object
+	// cannot be read, e.g. because the webhook lacks RBAC to get namespaces.
+	// "ignoreSelectors" (default) evaluates drift with namespace selectors not matching and
+	// without namespace annotations. "allowWithWarning" admits the request without drift
+	// evaluation. "deny" rejects the request.
+	OnNamespaceMetadataUnavailable string `yaml:"onNamespaceMetadataUnavailable,omitempty"`
+	// OnMissingUserInfo decides how requests without username and UID are attributed, as
+	// sent on some internal apiserver paths. "anonymousUser" (default) treats them as a
+	// user change, never drift. "anonymousController" treats them as the child's controller,
+	// i.e. as drift while the parent is stable. "deny" rejects them. Either way they are
+	// never recorded as updater or controller.
+	OnMissingUserInfo string `yaml:"onMissingUserInfo,omitempty"`
+
+	// OnClearedUserFields decides what happens when a controller removes or empties spec
+	// fields someone else set while the parent is stable. "drift" (default) handles it like
+	// any other drift, naming the cleared fields. "deny" rejects it even in log mode,
+	// unless it is approved.
+	OnClearedUserFields string `yaml:"onClearedUserFields,omitempty"`
+
+	// HealthSignal downgrades enforce to log while the cluster reports itself unhealthy,
+	// so strict enforcement doesn't make an incident worse. If nil, enforcement is never downgraded.
+	HealthSignal *HealthSignalConfig `yaml:"healthSignal,omitempty"`
+
+	// OrphanApprovalTTL flags approvals whose referenced child still doesn't exist this long
+	// after the approval was first observed by the approval sweep, catching typos while
+	// leaving room for approvals pre-staged for children about to be created. Wildcard
+	// approvals are never orphans. Requires ApprovalSweep. Zero (default) disables the check.
+	OrphanApprovalTTL time.Duration `yaml:"orphanApprovalTTL,omitempty"`
+	// OrphanApprovalAction is what happens to an orphaned approval: "warn" (default)
+	// logs and warns, "remove" also removes it from the parent.
+	OrphanApprovalAction string `yaml:"orphanApprovalAction,omitempty"`
+
+	// GovernPostureChanges makes weakening enforcement a governed action: UPDATEs that
+	// downgrade kausality.io/mode, add wildcard approvals or remove a freeze are denied
+	// unless the user may "weaken" kausality.io "postures", and allowed ones are reported
+	// with critical severity.
+	GovernPostureChanges bool `yaml:"governPostureChanges,omitempty"`
+
+	// AnnotationWriters lists the field managers allowed to change kausality.io/*
+	// annotations, e.g. approval tooling. If set, UPDATEs by other field managers that
+	// change these annotations are warned about, and denied when the object's mode is
+	// enforce. Kausality's own field manager is always allowed. Empty allows everyone.
+	AnnotationWriters []string `yaml:"annotationWriters,omitempty"`
+
+	// TrustedUserFieldManagers lists field managers, e.g. of GitOps tools like Argo CD
+	// or Flux, whose writes are always user changes: never drift, and always a new trace
+	// origin. Entries are path.Match patterns, e.g. "argocd-*". Freezes still apply.
+	TrustedUserFieldManagers []string `yaml:"trustedUserFieldManagers,omitempty"`
+
+	// PropagateModeToChildren sets kausality.io/mode: enforce on children admitted under a
+	// parent whose effective mode is enforce, so enforcement is inherited down the ownership
+	// tree. A mode annotation already on the child is kept.
+	PropagateModeToChildren bool `yaml:"propagateModeToChildren,omitempty"`
+
+	// DetectChainMutations warns when another mutator in the admission chain, e.g. a
+	// mutating webhook, changed spec fields of the object, as seen in managedFields
+	// entries of other field managers gained during the request. Diagnostic only.
+	DetectChainMutations bool `yaml:"detectChainMutations,omitempty"`
+
+	// ParentFetchTimeouts bound the parent resolution for children of slow parent kinds,
+	// e.g. cluster-scoped aggregated APIs. When it times out, the request fails open: it
+	// is admitted without drift evaluation. Parent kinds without an entry are not bounded.
+	ParentFetchTimeouts []ParentFetchTimeout `yaml:"parentFetchTimeouts,omitempty"`
+
+	// ParentReferences declare, per child kind, how children without a controller
+	// ownerReference name their parent: by a label or a spec field holding the parent's
+	// name. The parent lives in the child's namespace. Controller ownerReferences and the
+	// kausality.io/parent annotation take precedence; child kinds without an entry only
+	// use those.
+	ParentReferences []ParentReference `yaml:"parentReferences,omitempty"`
+
+	// RecreateAfterDelete classifies, per child kind, a controller re-creating a child that
+	// a user deleted within RecreateWindow while the parent is stable: "drift" (like any
+	// other controller change on a stable parent) or "expected" (self-healing, allowed).
+	// Child kinds without an entry are not tracked and keep the drift classification.
+	RecreateAfterDelete []RecreateAfterDelete `yaml:"recreateAfterDelete,omitempty"`
+	// DetectRecreates pairs a controller's DELETE of a child with its CREATE of the same
+	// child (same parent, kind, namespace and name) within RecreateWindow and reports the
+	// CREATE as a recreate. Controllers of immutable resources delete and re-create
+	// instead of updating; on a stable parent the recreate is drift like an UPDATE.
+	DetectRecreates bool `yaml:"detectRecreates,omitempty"`
+	// RecreateWindow is how long deletes are remembered for RecreateAfterDelete and
+	// DetectRecreates. Defaults to DefaultRecreateWindow.
+	RecreateWindow time.Duration `yaml:"recreateWindow,omitempty"`
+
+	// StabilizationGracePeriod keeps treating a parent as reconciling for this long after
+	// its status was last written, even though observedGeneration caught up, so a final
+	// spec write the controller issues right after its status update is not drift. The
+	// time is taken from the parent's managedFields entries for the status subresource.
+	// Zero (default) disables the grace period.
+	StabilizationGracePeriod time.Duration `yaml:"stabilizationGracePeriod,omitempty"`
+
+	// IncludeResources restricts processing to resources matching an entry. Requests for
+	// other resources, e.g. high-churn Events, Leases or EndpointSlices, are admitted
+	// before anything is decoded, including status updates, so controllers of excluded
+	// parents are not recorded. Empty includes every resource.
+	IncludeResources []ResourceFilter `yaml:"includeResources,omitempty"`
+	// ExcludeResources skips resources matching an entry like IncludeResources does for
+	// the others. Exclusion wins over inclusion.
+	ExcludeResources []ResourceFilter `yaml:"excludeResources,omitempty"`
+
+	// MinObjectAge exempts children younger than this from drift, by their
+	// creationTimestamp: new children often get several controller writes that look like
+	// drift while the parent's observedGeneration catches up. Tracing is unaffected.
+	// Zero (default) disables the exemption.
+	MinObjectAge time.Duration `yaml:"minObjectAge,omitempty"`
+}
+
+// ResourceFilter matches resources by group, version and kind.
+type ResourceFilter struct {
+	// APIGroup of the resource. Empty string "" matches the core group, "*" every group.
+	APIGroup string `yaml:"apiGroup"`
+	// Version of the resource. Empty matches every version.
+	Version string `yaml:"version,omitempty"`
+	// Kind of the resource. "*" matches every kind.
+	Kind string `yaml:"kind"`
+}
+
+// Matches returns true if gvk matches the filter.
+func (f ResourceFilter) Matches(gvk schema.GroupVersionKind) bool {
+	return (f.APIGroup == "*" || f.APIGroup == gvk.Group) &&
+		(f.Version == "" || f.Version == gvk.Version) &&
+		(f.Kind == "*" || f.Kind == gvk.Kind)
+}
+
+// ParentFetchTimeout bounds the parent resolution for parents of one kind.
+type ParentFetchTimeout struct {
+	// APIGroup of the parent. Empty string "" matches the core group.
+	APIGroup string `yaml:"apiGroup"`
+	// Kind of the parent.
+	Kind string `yaml:"kind"`
+	// Timeout for resolving the parent.
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// ParentReference declares how children of one kind reference their parent without a
+// controller ownerReference. Exactly one of Label and FieldPath must be set.
+type ParentReference struct {
+	// APIGroup of the child. Empty string "" matches the core group.
+	APIGroup string `yaml:"apiGroup"`
+	// Kind of the child.
+	Kind string `yaml:"kind"`
+	// ParentAPIVersion is the apiVersion of the parent, e.g. "example.com/v1".
+	ParentAPIVersion string `yaml:"parentAPIVersion"`
+	// ParentKind is the kind of the parent.
+	ParentKind string `yaml:"parentKind"`
+	// Label is the child label holding the parent's name.
+	Label string `yaml:"label,omitempty"`
+	// FieldPath is the dot-separated path of the child field holding the parent's name,
+	// e.g. "spec.clusterRef.name".
+	FieldPath string `yaml:"fieldPath,omitempty"`
+}
+
+// RecreateAfterDelete classifies the re-creation of user-deleted children of one kind.
+type RecreateAfterDelete struct {
+	// APIGroup of the child. Empty string "" matches the core group.
+	APIGroup string `yaml:"apiGroup"`
+	// Kind of the child.
+	Kind string `yaml:"kind"`
+	// Classification is "expected" or "drift".
+	Classification string `yaml:"classification"`
+}
+
+// Namespace metadata decisions for DriftDetectionConfig.OnNamespaceMetadataUnavailable.
+const (
+	NamespaceMetadataIgnoreSelectors  = "ignoreSelectors"
+	NamespaceMetadataAllowWithWarning = "allowWithWarning"
+	NamespaceMetadataDeny             = "deny"
+)
+
+// Missing user info decisions for DriftDetectionConfig.OnMissingUserInfo.
+const (
+	MissingUserInfoAnonymousUser       = "anonymousUser"
+	MissingUserInfoAnonymousController = "anonymousController"
+	MissingUserInfoDeny                = "deny"
+)
+
+// Cleared field decisions for DriftDetectionConfig.OnClearedUserFields.
+const (
+	ClearedFieldsDrift = "drift"
+	ClearedFieldsDeny  = "deny"
+)
+
+// Re-creation classifications for RecreateAfterDelete.Classification.
+const (
+	RecreateExpected = "expected"
+	RecreateDrift    = "drift"
+)
+
+// DefaultRecreateWindow is how long deletes are remembered by default.
+const DefaultRecreateWindow = 5 * time.Minute
+
+// Orphan approval actions for DriftDetectionConfig.OrphanApprovalAction.
+const (
+	OrphanApprovalWarn   = "warn"
+	OrphanApprovalRemove = "remove"
+)
+
+// DefaultHealthCheckInterval is how often the health signal is re-read by default.
+const DefaultHealthCheckInterval = 10 * time.Second
+
+// HealthSignalConfig configures the cluster health signal.
+type HealthSignalConfig struct {
+	// ConfigMap reads health from a boolean ConfigMap entry.
+	ConfigMap *ConfigMapHealthSignal `yaml:"configMap,omitempty"`
+	// CheckInterval is how often the signal is re-read. Defaults to DefaultHealthCheckInterval.
+	CheckInterval time.Duration `yaml:"checkInterval,omitempty"`
+}
+
+// ConfigMapHealthSignal references a ConfigMap entry holding "true" (healthy) or "false".
+// A missing ConfigMap or key counts as healthy.
+type ConfigMapHealthSignal struct {
+	// Namespace of the ConfigMap.
+	Namespace string `yaml:"namespace"`
+	// Name of the ConfigMap.
+	Name string `yaml:"name"`
+	// Key in the ConfigMap data. Defaults to "healthy".
+	Key string `yaml:"key,omitempty"`
+}
+
+// Controller selection values for DriftDetectionConfig.ControllerSelection.
+const (
+	ControllerSelectionStatusWriters           = "statusWriters"
+	ControllerSelectionObservedGenerationOwner = "observedGenerationOwner"
+)
+
+// Owner selection values for DriftDetectionConfig.OwnerSelection.
+const (
+	OwnerSelectionControllerOnly = "controllerOnly"
+	OwnerSelectionFirstOwner     = "firstOwner"
+	OwnerSelectionAllOwners      = "allOwners"
+)
+
+// GVK mismatch decisions for DriftDetectionConfig.OnGVKMismatch.
+const (
+	GVKMismatchDeny             = "deny"
+	GVKMismatchAllowWithWarning = "allowWithWarning"
+)
+
+// DefaultProposalThreshold is the default number of identical corrections before proposing an approval.
+const DefaultProposalThreshold = 3
+
+// ApprovalProposalsConfig configures approval proposals.
+type ApprovalProposalsConfig struct {
+	// Threshold is the number of identical corrections before a proposal is written.
+	// Defaults to DefaultProposalThreshold.
+	Threshold int `yaml:"threshold,omitempty"`
+}
+
+// DefaultApprovalHistoryMaxEntries is the default number of consumed approvals kept per parent.
+const DefaultApprovalHistoryMaxEntries = 10
+
+// ApprovalHistoryConfig configures the approval history.
+type ApprovalHistoryConfig struct {
+	// MaxEntries bounds the entries kept per parent; older entries are dropped.
+	// Defaults to DefaultApprovalHistoryMaxEntries.
+	MaxEntries int `yaml:"maxEntries,omitempty"`
+}
+
+// Defaults for StuckDetectionConfig.
+const (
+	DefaultStuckThreshold = 10
+	DefaultStuckWindow    = 10 * time.Minute
+)
+
+// StuckDetectionConfig configures the detection of controllers retrying blocked drift.
+type StuckDetectionConfig struct {
+	// Threshold is the number of blocked attempts of the same drift before a Stuck report
+	// is sent. Further reports follow at twice, four times, ... the threshold.
+	// Defaults to DefaultStuckThreshold.
+	Threshold int `yaml:"threshold,omitempty"`
+	// Window is how long blocked attempts are remembered after the last one. A drift not
+	// retried within the window starts counting from zero. Defaults to DefaultStuckWindow.
+	Window time.Duration `yaml:"window,omitempty"`
+}
+
+// ArrayMergeKey declares an array in a resource's spec whose elements are
+// identified by a key field, like Kubernetes strategic merge keys.
+type ArrayMergeKey struct {
+	// APIGroup of the resource. Empty string "" matches the core group.
+	APIGroup string `yaml:"apiGroup"`
+
+	// Kind of the resource.
+	Kind string `yaml:"kind"`
+
+	// Path is the dotted path to the array, starting at "spec".
+	// A "[]" suffix on a segment descends into every element of that array,
+	// e.g. "spec.template.spec.containers[].env".
+	Path string `yaml:"path"`
+
+	// Key is the field that identifies array elements, e.g. "name".
+	Key string `yaml:"key"`
 }
 
 // DriftDetectionOverride configures drift detection for specific resources.
@@ -68,7 +697,7 @@ type DriftDetectionOverride struct {
 	// Empty selector matches all objects.
 	ObjectSelector *metav1.LabelSelector `yaml:"objectSelector,omitempty"`
 
-	// Mode is the drift detection mode for matching resources ("log" or "enforce").
+	// Mode is the drift detection mode for matching resources ("log", "enforce", "warn" or "dryrun").
 	Mode string `yaml:"mode"`
 }
 
@@ -88,8 +717,16 @@ type ResourceContext struct {
 const (
 	ModeLog     = "log"
 	ModeEnforce = "enforce"
+	// ModeWarn evaluates drift as in enforce mode, but admits what enforce mode would
+	// deny, with a warning.
+	ModeWarn = "warn"
+	// ModeDryRun behaves like log mode, with warnings tagged "[dry-run]".
+	ModeDryRun = "dryrun"
 )
 
+// Modes lists the valid modes.
+var Modes = []string{ModeLog, ModeEnforce, ModeWarn, ModeDryRun}
+
 // ModeAnnotation is the annotation key for runtime mode configuration.
 const ModeAnnotation = "kausality.io/mode"
 
@@ -99,9 +736,17 @@ func Load(path string) (*Config, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
+	return Parse(data)
+}
 
+// Parse parses YAML configuration, applies defaults and validates it.
+// Unknown fields are rejected, so that typos fail instead of silently
+// falling back to defaults.
+func Parse(data []byte) (*Config, error) {
 	var cfg Config
-	if err := yaml.Unmarshal(data, &cfg); err != nil {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
 
@@ -118,25 +763,354 @@ func Load(path string) (*Config, error) {
 	return &cfg, nil
 }
 
-// Validate checks that the configuration is valid.
+// Validate checks that the configuration is valid. It reports every problem
+// found, joined into a single error.
 func (c *Config) Validate() error {
+	var errs []error
+
 	if !isValidMode(c.DriftDetection.DefaultMode) {
-		return fmt.Errorf("invalid defaultMode %q: must be %q or %q", c.DriftDetection.DefaultMode, ModeLog, ModeEnforce)
+		errs = append(errs, fmt.Errorf("invalid defaultMode %q: must be one of %s", c.DriftDetection.DefaultMode, strings.Join(Modes, ", ")))
 	}
 
 	for i, override := range c.DriftDetection.Overrides {
 		if len(override.APIGroups) == 0 {
-			return fmt.Errorf("override[%d]: apiGroups must not be empty", i)
+			errs = append(errs, fmt.Errorf("override[%d]: apiGroups must not be empty", i))
 		}
 		if len(override.Resources) == 0 {
-			return fmt.Errorf("override[%d]: resources must not be empty", i)
+			errs = append(errs, fmt.Errorf("override[%d]: resources must not be empty", i))
 		}
 		if !isValidMode(override.Mode) {
-			return fmt.Errorf("override[%d]: invalid mode %q: must be %q or %q", i, override.Mode, ModeLog, ModeEnforce)
+			errs = append(errs, fmt.Errorf("override[%d]: invalid mode %q: must be one of %s", i, override.Mode, strings.Join(Modes, ", ")))
+		}
+	}
+
+	for i, mk := range c.DriftDetection.ArrayMergeKeys {
+		if mk.Kind == "" {
+			errs = append(errs, fmt.Errorf("arrayMergeKeys[%d]: kind must not be empty", i))
+		}
+		if mk.Key == "" {
+			errs = append(errs, fmt.Errorf("arrayMergeKeys[%d]: key must not be empty", i))
+		}
+		if !strings.HasPrefix(mk.Path, "spec.") {
+			errs = append(errs, fmt.Errorf("arrayMergeKeys[%d]: path %q must start with \"spec.\"", i, mk.Path))
+		}
+	}
+
+	for i, p := range c.DriftDetection.IgnoredSpecPaths {
+		if !strings.HasPrefix(p, "/spec/") {
+			errs = append(errs, fmt.Errorf("ignoredSpecPaths[%d]: path %q must start with \"/spec/\"", i, p))
 		}
 	}
 
-	return nil
+	if c.SpecDiff != nil && c.SpecDiff.MaxBytes < 0 {
+		errs = append(errs, fmt.Errorf("specDiff: maxBytes must not be negative"))
+	}
+
+	if c.AnnotationPrefix != "" {
+		domain, ok := strings.CutSuffix(c.AnnotationPrefix, "/")
+		if !ok || len(validation.IsDNS1123Subdomain(domain)) > 0 {
+			errs = append(errs, fmt.Errorf("invalid annotationPrefix %q: must be a DNS subdomain followed by \"/\"", c.AnnotationPrefix))
+		}
+	}
+
+	if ih := c.IdentityHash; ih != nil {
+		switch ih.Algorithm {
+		case "", HashAlgorithmBase36:
+			if ih.Length != 0 && ih.Length != Base36HashLength {
+				errs = append(errs, fmt.Errorf("invalid identityHash.length %d: base36 hashes have %d characters", ih.Length, Base36HashLength))
+			}
+		case HashAlgorithmHex:
+			if ih.Length != 0 && (ih.Length < MinHexHashLength || ih.Length > 64) {
+				errs = append(errs, fmt.Errorf("invalid identityHash.length %d: hex hashes have %d to 64 characters", ih.Length, MinHexHashLength))
+			}
+		default:
+			errs = append(errs, fmt.Errorf("invalid identityHash.algorithm %q: must be %q or %q", ih.Algorithm, HashAlgorithmBase36, HashAlgorithmHex))
+		}
+	}
+
+	for i, pt := range c.DriftDetection.ParentFetchTimeouts {
+		if pt.Kind == "" {
+			errs = append(errs, fmt.Errorf("parentFetchTimeouts[%d]: kind must not be empty", i))
+		}
+		if pt.Timeout <= 0 {
+			errs = append(errs, fmt.Errorf("parentFetchTimeouts[%d]: timeout must be positive", i))
+		}
+	}
+
+	for i, f := range c.DriftDetection.IncludeResources {
+		if f.Kind == "" {
+			errs = append(errs, fmt.Errorf("includeResources[%d]: kind must not be empty", i))
+		}
+	}
+	for i, f := range c.DriftDetection.ExcludeResources {
+		if f.Kind == "" {
+			errs = append(errs, fmt.Errorf("excludeResources[%d]: kind must not be empty", i))
+		}
+	}
+
+	for i, pr := range c.DriftDetection.ParentReferences {
+		if pr.Kind == "" || pr.ParentKind == "" {
+			errs = append(errs, fmt.Errorf("parentReferences[%d]: kind and parentKind must not be empty", i))
+		}
+		if _, err := schema.ParseGroupVersion(pr.ParentAPIVersion); err != nil || pr.ParentAPIVersion == "" {
+			errs = append(errs, fmt.Errorf("parentReferences[%d]: invalid parentAPIVersion %q", i, pr.ParentAPIVersion))
+		}
+		if (pr.Label == "") == (pr.FieldPath == "") {
+			errs = append(errs, fmt.Errorf("parentReferences[%d]: exactly one of label and fieldPath must be set", i))
+		}
+	}
+
+	switch c.DriftDetection.ControllerSelection {
+	case "", ControllerSelectionStatusWriters, ControllerSelectionObservedGenerationOwner:
+	default:
+		errs = append(errs, fmt.Errorf("invalid controllerSelection %q: must be %q or %q", c.DriftDetection.ControllerSelection,
+			ControllerSelectionStatusWriters, ControllerSelectionObservedGenerationOwner))
+	}
+
+	switch c.DriftDetection.OwnerSelection {
+	case "", OwnerSelectionControllerOnly, OwnerSelectionFirstOwner, OwnerSelectionAllOwners:
+	default:
+		errs = append(errs, fmt.Errorf("invalid ownerSelection %q: must be %q, %q or %q", c.DriftDetection.OwnerSelection,
+			OwnerSelectionControllerOnly, OwnerSelectionFirstOwner, OwnerSelectionAllOwners))
+	}
+
+	for i, w := range c.DriftDetection.AnnotationWriters {
+		if w == "" {
+			errs = append(errs, fmt.Errorf("annotationWriters[%d]: must not be empty", i))
+		}
+	}
+
+	for i, m := range c.DriftDetection.TrustedUserFieldManagers {
+		if m == "" {
+			errs = append(errs, fmt.Errorf("trustedUserFieldManagers[%d]: must not be empty", i))
+		}
+		if _, err := path.Match(m, ""); err != nil {
+			errs = append(errs, fmt.Errorf("trustedUserFieldManagers[%d]: invalid pattern %q: %w", i, m, err))
+		}
+	}
+
+	for i, r := range c.DriftDetection.RecreateAfterDelete {
+		if r.Kind == "" {
+			errs = append(errs, fmt.Errorf("recreateAfterDelete[%d]: kind must not be empty", i))
+		}
+		if r.Classification != RecreateExpected && r.Classification != RecreateDrift {
+			errs = append(errs, fmt.Errorf("recreateAfterDelete[%d]: invalid classification %q: must be %q or %q", i, r.Classification,
+				RecreateExpected, RecreateDrift))
+		}
+	}
+	if c.DriftDetection.RecreateWindow < 0 {
+		errs = append(errs, fmt.Errorf("recreateWindow must not be negative"))
+	}
+	if c.DriftDetection.StabilizationGracePeriod < 0 {
+		errs = append(errs, fmt.Errorf("stabilizationGracePeriod must not be negative"))
+	}
+	if c.DriftDetection.MinObjectAge < 0 {
+		errs = append(errs, fmt.Errorf("minObjectAge must not be negative"))
+	}
+
+	switch c.DriftDetection.OnGVKMismatch {
+	case "", GVKMismatchDeny, GVKMismatchAllowWithWarning:
+	default:
+		errs = append(errs, fmt.Errorf("invalid onGVKMismatch %q: must be %q or %q", c.DriftDetection.OnGVKMismatch,
+			GVKMismatchDeny, GVKMismatchAllowWithWarning))
+	}
+
+	switch c.DriftDetection.OnMissingUserInfo {
+	case "", MissingUserInfoAnonymousUser, MissingUserInfoAnonymousController, MissingUserInfoDeny:
+	default:
+		errs = append(errs, fmt.Errorf("invalid onMissingUserInfo %q: must be %q, %q or %q", c.DriftDetection.OnMissingUserInfo,
+			MissingUserInfoAnonymousUser, MissingUserInfoAnonymousController, MissingUserInfoDeny))
+	}
+
+	switch c.DriftDetection.OnNamespaceMetadataUnavailable {
+	case "", NamespaceMetadataIgnoreSelectors, NamespaceMetadataAllowWithWarning, NamespaceMetadataDeny:
+	default:
+		errs = append(errs, fmt.Errorf("invalid onNamespaceMetadataUnavailable %q: must be %q, %q or %q", c.DriftDetection.OnNamespaceMetadataUnavailable,
+			NamespaceMetadataIgnoreSelectors, NamespaceMetadataAllowWithWarning, NamespaceMetadataDeny))
+	}
+
+	switch c.DriftDetection.OnClearedUserFields {
+	case "", ClearedFieldsDrift, ClearedFieldsDeny:
+	default:
+		errs = append(errs, fmt.Errorf("invalid onClearedUserFields %q: must be %q or %q", c.DriftDetection.OnClearedUserFields,
+			ClearedFieldsDrift, ClearedFieldsDeny))
+	}
+
+	if hs := c.DriftDetection.HealthSignal; hs != nil {
+		if hs.ConfigMap == nil {
+			errs = append(errs, fmt.Errorf("healthSignal: configMap must be set"))
+		} else if hs.ConfigMap.Namespace == "" || hs.ConfigMap.Name == "" {
+			errs = append(errs, fmt.Errorf("healthSignal: configMap namespace and name must not be empty"))
+		}
+		if hs.CheckInterval < 0 {
+			errs = append(errs, fmt.Errorf("healthSignal: checkInterval must not be negative"))
+		}
+	}
+
+	if c.DriftDetection.OrphanApprovalTTL < 0 {
+		errs = append(errs, fmt.Errorf("orphanApprovalTTL must not be negative"))
+	}
+	if c.DriftDetection.OrphanApprovalTTL > 0 && (c.ApprovalSweep == nil || c.ApprovalSweep.Interval <= 0) {
+		errs = append(errs, fmt.Errorf("orphanApprovalTTL requires approvalSweep"))
+	}
+	switch c.DriftDetection.OrphanApprovalAction {
+	case "", OrphanApprovalWarn, OrphanApprovalRemove:
+	default:
+		errs = append(errs, fmt.Errorf("invalid orphanApprovalAction %q: must be %q or %q", c.DriftDetection.OrphanApprovalAction,
+			OrphanApprovalWarn, OrphanApprovalRemove))
+	}
+
+	if ap := c.DriftDetection.ApprovalProposals; ap != nil && ap.Threshold < 0 {
+		errs = append(errs, fmt.Errorf("approvalProposals: threshold must not be negative"))
+	}
+	if ah := c.DriftDetection.ApprovalHistory; ah != nil && ah.MaxEntries < 0 {
+		errs = append(errs, fmt.Errorf("approvalHistory: maxEntries must not be negative"))
+	}
+	if sd := c.DriftDetection.StuckDetection; sd != nil {
+		if sd.Threshold < 0 {
+			errs = append(errs, fmt.Errorf("stuckDetection: threshold must not be negative"))
+		}
+		if sd.Window < 0 {
+			errs = append(errs, fmt.Errorf("stuckDetection: window must not be negative"))
+		}
+	}
+
+	for i, src := range c.ControllerVersions {
+		if src.FieldManager == "" {
+			errs = append(errs, fmt.Errorf("controllerVersions[%d]: fieldManager must not be empty", i))
+		}
+		if (src.Version == "") == (src.Deployment == nil) {
+			errs = append(errs, fmt.Errorf("controllerVersions[%d]: exactly one of version or deployment must be set", i))
+		}
+		if src.Deployment != nil && (src.Deployment.Namespace == "" || src.Deployment.Name == "") {
+			errs = append(errs, fmt.Errorf("controllerVersions[%d]: deployment namespace and name must not be empty", i))
+		}
+	}
+
+	for i, b := range c.Backends {
+		if b.Timeout < 0 {
+			errs = append(errs, fmt.Errorf("backends[%d]: timeout must not be negative", i))
+		}
+		if b.RetryCount < 0 {
+			errs = append(errs, fmt.Errorf("backends[%d]: retryCount must not be negative", i))
+		}
+		if b.RetryInterval < 0 {
+			errs = append(errs, fmt.Errorf("backends[%d]: retryInterval must not be negative", i))
+		}
+		if b.MaxRetryInterval < 0 {
+			errs = append(errs, fmt.Errorf("backends[%d]: maxRetryInterval must not be negative", i))
+		}
+		if b.MaxInFlight < 0 {
+			errs = append(errs, fmt.Errorf("backends[%d]: maxInFlight must not be negative", i))
+		}
+		if b.DedupWindow < 0 {
+			errs = append(errs, fmt.Errorf("backends[%d]: dedupWindow must not be negative", i))
+		}
+		if b.DedupMaxSize < 0 {
+			errs = append(errs, fmt.Errorf("backends[%d]: dedupMaxSize must not be negative", i))
+		}
+		for _, phase := range b.Phases {
+			if !slices.Contains(v1alpha1.DriftReportPhases, phase) {
+				errs = append(errs, fmt.Errorf("backends[%d]: unknown phase %q", i, phase))
+			}
+		}
+		if b.Mode != "" && b.Mode != BackendModeObserve {
+			errs = append(errs, fmt.Errorf("backends[%d]: invalid mode %q: must be empty or %q", i, b.Mode, BackendModeObserve))
+		}
+		if b.QueueSize < 0 {
+			errs = append(errs, fmt.Errorf("backends[%d]: queueSize must not be negative", i))
+		}
+		if b.Kafka == nil {
+			continue
+		}
+		if b.Mode != "" {
+			errs = append(errs, fmt.Errorf("backends[%d]: mode does not apply to kafka backends, which never block admission", i))
+		}
+		if b.URL != "" {
+			errs = append(errs, fmt.Errorf("backends[%d]: url and kafka are mutually exclusive", i))
+		}
+		if len(b.Kafka.Brokers) == 0 {
+			errs = append(errs, fmt.Errorf("backends[%d]: kafka brokers must not be empty", i))
+		}
+		if b.Kafka.Topic == "" {
+			errs = append(errs, fmt.Errorf("backends[%d]: kafka topic must not be empty", i))
+		}
+		if b.Kafka.MaxBufferedRecords < 0 {
+			errs = append(errs, fmt.Errorf("backends[%d]: kafka maxBufferedRecords must not be negative", i))
+		}
+		if sasl := b.Kafka.SASL; sasl != nil {
+			switch sasl.Mechanism {
+			case "PLAIN", "SCRAM-SHA-256", "SCRAM-SHA-512":
+			default:
+				errs = append(errs, fmt.Errorf("backends[%d]: kafka sasl mechanism must be PLAIN, SCRAM-SHA-256 or SCRAM-SHA-512, got %q", i, sasl.Mechanism))
+			}
+			if sasl.Username == "" || sasl.PasswordFile == "" {
+				errs = append(errs, fmt.Errorf("backends[%d]: kafka sasl username and passwordFile must not be empty", i))
+			}
+		}
+	}
+
+	if c.OpenLineage != nil {
+		if c.OpenLineage.URL == "" {
+			errs = append(errs, fmt.Errorf("openLineage: url must not be empty"))
+		}
+		if c.OpenLineage.Timeout < 0 {
+			errs = append(errs, fmt.Errorf("openLineage: timeout must not be negative"))
+		}
+	}
+
+	if as := c.ApprovalSweep; as != nil {
+		if as.Interval < 0 {
+			errs = append(errs, fmt.Errorf("approvalSweep: interval must not be negative"))
+		}
+		if as.Interval > 0 && len(as.Parents) == 0 {
+			errs = append(errs, fmt.Errorf("approvalSweep: parents must not be empty"))
+		}
+		for i, p := range as.Parents {
+			if _, err := schema.ParseGroupVersion(p.APIVersion); err != nil || p.APIVersion == "" {
+				errs = append(errs, fmt.Errorf("approvalSweep: parents[%d]: invalid apiVersion %q", i, p.APIVersion))
+			}
+			if p.Kind == "" {
+				errs = append(errs, fmt.Errorf("approvalSweep: parents[%d]: kind must not be empty", i))
+			}
+		}
+	}
+
+	if cp := c.CallbackPause; cp != nil {
+		if cp.ConfigMap == nil {
+			errs = append(errs, fmt.Errorf("callbackPause: configMap must be set"))
+		} else if cp.ConfigMap.Namespace == "" || cp.ConfigMap.Name == "" {
+			errs = append(errs, fmt.Errorf("callbackPause: configMap namespace and name must not be empty"))
+		}
+		if cp.CheckInterval < 0 {
+			errs = append(errs, fmt.Errorf("callbackPause: checkInterval must not be negative"))
+		}
+	}
+
+	if c.BreakGlass != nil {
+		if c.BreakGlass.PublicKeyFile == "" {
+			errs = append(errs, fmt.Errorf("breakGlass: publicKeyFile must not be empty"))
+		}
+		if c.BreakGlass.MaxTokenAge < 0 {
+			errs = append(errs, fmt.Errorf("breakGlass: maxTokenAge must not be negative"))
+		}
+	}
+
+	if c.TraceMaxAge < 0 {
+		errs = append(errs, fmt.Errorf("traceMaxAge must not be negative"))
+	}
+	// Executing catches unknown fields, which parsing doesn't
+	if c.DenyMessageTemplate != "" {
+		if _, err := renderDenyMessage(c.DenyMessageTemplate, DenyMessageData{}); err != nil {
+			errs = append(errs, fmt.Errorf("invalid denyMessageTemplate: %w", err))
+		}
+	}
+
+	if c.MaxTraceHops < 0 || (c.MaxTraceHops > 0 && c.MaxTraceHops < 3) {
+		errs = append(errs, fmt.Errorf("maxTraceHops must be zero (default) or at least 3"))
+	}
+
+	return errors.Join(errs...)
 }
 
 // GetModeForResource returns the drift detection mode for a specific resource.
@@ -174,13 +1148,15 @@ func (c *Config) IsEnforceModeContext(ctx ResourceContext) bool {
 // 2. Namespace annotation kausality.io/mode
 // 3. Config-based mode (overrides + default)
 func (c *Config) ResolveModeWithAnnotations(objectAnnotations, namespaceAnnotations map[string]string, ctx ResourceContext) string {
+	modeAnnotation := c.AnnotationKeys().Mode
+
 	// Check object annotation first
-	if mode := objectAnnotations[ModeAnnotation]; isValidMode(mode) {
+	if mode := objectAnnotations[modeAnnotation]; isValidMode(mode) {
 		return mode
 	}
 
 	// Check namespace annotation second
-	if mode := namespaceAnnotations[ModeAnnotation]; isValidMode(mode) {
+	if mode := namespaceAnnotations[modeAnnotation]; isValidMode(mode) {
 		return mode
 	}
 
@@ -194,6 +1170,114 @@ func (c *Config) IsEnforceModeWithAnnotations(objectAnnotations, namespaceAnnota
 	return c.ResolveModeWithAnnotations(objectAnnotations, namespaceAnnotations, ctx) == ModeEnforce
 }
 
+// ArrayMergeKeysFor returns the array merge keys that apply to the given GVK.
+func (c *Config) ArrayMergeKeysFor(gvk schema.GroupVersionKind) []ArrayMergeKey {
+	var result []ArrayMergeKey
+	for _, mk := range c.DriftDetection.ArrayMergeKeys {
+		if mk.APIGroup == gvk.Group && mk.Kind == gvk.Kind {
+			result = append(result, mk)
+		}
+	}
+	return result
+}
+
+// ParentFetchTimeoutFor returns the parent resolution timeout for parents of the given
+// kind, or zero if it is not bounded.
+func (c *Config) ParentFetchTimeoutFor(gk schema.GroupKind) time.Duration {
+	for _, pt := range c.DriftDetection.ParentFetchTimeouts {
+		if pt.APIGroup == gk.Group && pt.Kind == gk.Kind {
+			return pt.Timeout
+		}
+	}
+	return 0
+}
+
+// InScope returns true if resources of gvk are processed under IncludeResources and
+// ExcludeResources.
+func (c *Config) InScope(gvk schema.GroupVersionKind) bool {
+	for _, f := range c.DriftDetection.ExcludeResources {
+		if f.Matches(gvk) {
+			return false
+		}
+	}
+	if len(c.DriftDetection.IncludeResources) == 0 {
+		return true
+	}
+	for _, f := range c.DriftDetection.IncludeResources {
+		if f.Matches(gvk) {
+			return true
+		}
+	}
+	return false
+}
+
+// RecreateClassificationFor returns the re-creation classification for children of the
+// given kind, or "" if they are not tracked.
+func (c *Config) RecreateClassificationFor(gk schema.GroupKind) string {
+	for _, r := range c.DriftDetection.RecreateAfterDelete {
+		if r.APIGroup == gk.Group && r.Kind == gk.Kind {
+			return r.Classification
+		}
+	}
+	return ""
+}
+
+// DeniesClearedFields returns true if controllers clearing user-set fields are denied
+// regardless of the mode.
+func (c *Config) DeniesClearedFields() bool {
+	return c.DriftDetection.OnClearedUserFields == ClearedFieldsDeny
+}
+
+// IsTrustedUserFieldManager returns true if the field manager matches a
+// TrustedUserFieldManagers pattern.
+func (c *Config) IsTrustedUserFieldManager(manager string) bool {
+	if manager == "" {
+		return false
+	}
+	for _, pattern := range c.DriftDetection.TrustedUserFieldManagers {
+		if ok, _ := path.Match(pattern, manager); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// FullObjectsIncluded returns whether drift reports include the full old and new objects.
+func (c *Config) FullObjectsIncluded() bool {
+	return c.IncludeFullObjects == nil || *c.IncludeFullObjects
+}
+
+// ApprovalHistoryMaxEntries returns the number of consumed approvals kept per parent,
+// or 0 if the approval history is disabled.
+func (c *Config) ApprovalHistoryMaxEntries() int {
+	if c.DriftDetection.ApprovalHistory == nil {
+		return 0
+	}
+	if c.DriftDetection.ApprovalHistory.MaxEntries == 0 {
+		return DefaultApprovalHistoryMaxEntries
+	}
+	return c.DriftDetection.ApprovalHistory.MaxEntries
+}
+
+// ShouldStripOnCreate returns true if the annotation key matches a StripOnCreate entry.
+func (c *Config) ShouldStripOnCreate(key string) bool {
+	for _, entry := range c.DriftDetection.StripOnCreate {
+		switch {
+		case strings.HasSuffix(entry, "*"):
+			if strings.HasPrefix(key, strings.TrimSuffix(entry, "*")) {
+				return true
+			}
+		case strings.HasSuffix(entry, "/"):
+			if strings.HasPrefix(key, entry) {
+				return true
+			}
+		case key == entry:
+			return true
+		}
+	}
+	return false
+}
+
 // Matches returns true if this override applies to the given GVK.
 // Deprecated: Use MatchesContext for full selector support.
 func (o *DriftDetectionOverride) Matches(gvk schema.GroupVersionKind) bool {
@@ -282,7 +1366,7 @@ func (o *DriftDetectionOverride) matchesObjectSelector(objLabels map[string]stri
 }
 
 func isValidMode(mode string) bool {
-	return mode == ModeLog || mode == ModeEnforce
+	return slices.Contains(Modes, mode)
 }
 
 // Default returns a default configuration with log mode.
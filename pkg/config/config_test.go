@@ -11,6 +11,8 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kausality-io/kausality/pkg/callback/v1alpha1"
 )
 
 func TestDefault(t *testing.T) {
@@ -25,77 +27,868 @@ func TestValidate(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name: "valid log mode",
+			name: "valid log mode",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{
+					DefaultMode: ModeLog,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid enforce mode",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{
+					DefaultMode: ModeEnforce,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid warn mode",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{
+					DefaultMode: ModeWarn,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid dryrun mode",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{
+					DefaultMode: ModeDryRun,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid default mode",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{
+					DefaultMode: "invalid",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid with overrides",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{
+					DefaultMode: ModeLog,
+					Overrides: []DriftDetectionOverride{
+						{
+							APIGroups: []string{"apps"},
+							Resources: []string{"deployments"},
+							Mode:      ModeEnforce,
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid override - empty apiGroups",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{
+					DefaultMode: ModeLog,
+					Overrides: []DriftDetectionOverride{
+						{
+							APIGroups: []string{},
+							Resources: []string{"deployments"},
+							Mode:      ModeEnforce,
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid override - empty resources",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{
+					DefaultMode: ModeLog,
+					Overrides: []DriftDetectionOverride{
+						{
+							APIGroups: []string{"apps"},
+							Resources: []string{},
+							Mode:      ModeEnforce,
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid array merge key",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{
+					DefaultMode: ModeLog,
+					ArrayMergeKeys: []ArrayMergeKey{
+						{APIGroup: "apps", Kind: "Deployment", Path: "spec.template.spec.containers", Key: "name"},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid array merge key - path outside spec",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{
+					DefaultMode: ModeLog,
+					ArrayMergeKeys: []ArrayMergeKey{
+						{APIGroup: "apps", Kind: "Deployment", Path: "metadata.finalizers", Key: "name"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid array merge key - empty key",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{
+					DefaultMode: ModeLog,
+					ArrayMergeKeys: []ArrayMergeKey{
+						{APIGroup: "apps", Kind: "Deployment", Path: "spec.template.spec.containers"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid ignored spec path",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{
+					DefaultMode:      ModeLog,
+					IgnoredSpecPaths: []string{"/spec/template/metadata/annotations/kubectl.kubernetes.io~1restartedAt"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid ignored spec path - outside spec",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{
+					DefaultMode:      ModeLog,
+					IgnoredSpecPaths: []string{"spec.replicas"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid parent fetch timeout",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{
+					DefaultMode: ModeLog,
+					ParentFetchTimeouts: []ParentFetchTimeout{
+						{APIGroup: "metrics.k8s.io", Kind: "NodeMetrics", Timeout: 200 * time.Millisecond},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid parent fetch timeout - zero timeout",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{
+					DefaultMode: ModeLog,
+					ParentFetchTimeouts: []ParentFetchTimeout{
+						{APIGroup: "metrics.k8s.io", Kind: "NodeMetrics"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid parent fetch timeout - empty kind",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{
+					DefaultMode: ModeLog,
+					ParentFetchTimeouts: []ParentFetchTimeout{
+						{APIGroup: "metrics.k8s.io", Timeout: time.Second},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid recreate after delete",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{
+					DefaultMode: ModeLog,
+					RecreateAfterDelete: []RecreateAfterDelete{
+						{Kind: "Pod", Classification: RecreateExpected},
+						{APIGroup: "apps", Kind: "ReplicaSet", Classification: RecreateDrift},
+					},
+					DetectRecreates: true,
+					RecreateWindow:  10 * time.Minute,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid recreate after delete - unknown classification",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{
+					DefaultMode: ModeLog,
+					RecreateAfterDelete: []RecreateAfterDelete{
+						{Kind: "Pod", Classification: "allowed"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid recreate after delete - empty kind",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{
+					DefaultMode: ModeLog,
+					RecreateAfterDelete: []RecreateAfterDelete{
+						{Classification: RecreateExpected},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid parent references",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{
+					DefaultMode: ModeLog,
+					ParentReferences: []ParentReference{
+						{Kind: "ConfigMap", ParentAPIVersion: "apps/v1", ParentKind: "Deployment", Label: "app.kubernetes.io/instance"},
+						{APIGroup: "example.com", Kind: "NodePool", ParentAPIVersion: "example.com/v1", ParentKind: "Cluster", FieldPath: "spec.clusterRef.name"},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid parent reference - label and field path",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{
+					DefaultMode: ModeLog,
+					ParentReferences: []ParentReference{
+						{Kind: "ConfigMap", ParentAPIVersion: "apps/v1", ParentKind: "Deployment", Label: "a", FieldPath: "spec.a"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid parent reference - missing parent apiVersion",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{
+					DefaultMode: ModeLog,
+					ParentReferences: []ParentReference{
+						{Kind: "ConfigMap", ParentKind: "Deployment", Label: "a"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid annotation writers",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{
+					DefaultMode:       ModeLog,
+					AnnotationWriters: []string{"approval-tool"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid annotation writers - empty field manager",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{
+					DefaultMode:       ModeLog,
+					AnnotationWriters: []string{""},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid trusted user field managers",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{
+					DefaultMode:              ModeLog,
+					TrustedUserFieldManagers: []string{"argocd-*", "kustomize-controller"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid trusted user field manager pattern",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{
+					DefaultMode:              ModeLog,
+					TrustedUserFieldManagers: []string{"argocd-["},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative min object age",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{
+					DefaultMode:  ModeLog,
+					MinObjectAge: -time.Second,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid annotation prefix",
+			config: Config{
+				DriftDetection:   DriftDetectionConfig{DefaultMode: ModeLog},
+				AnnotationPrefix: "staging.kausality.io/",
+			},
+			wantErr: false,
+		},
+		{
+			name: "annotation prefix without slash",
+			config: Config{
+				DriftDetection:   DriftDetectionConfig{DefaultMode: ModeLog},
+				AnnotationPrefix: "staging.kausality.io",
+			},
+			wantErr: true,
+		},
+		{
+			name: "annotation prefix not a DNS subdomain",
+			config: Config{
+				DriftDetection:   DriftDetectionConfig{DefaultMode: ModeLog},
+				AnnotationPrefix: "Staging_Kausality/",
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid hex identity hash",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{DefaultMode: ModeLog},
+				IdentityHash:   &IdentityHashConfig{Algorithm: HashAlgorithmHex, Length: 12},
+			},
+			wantErr: false,
+		},
+		{
+			name: "hex identity hash too short",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{DefaultMode: ModeLog},
+				IdentityHash:   &IdentityHashConfig{Algorithm: HashAlgorithmHex, Length: 4},
+			},
+			wantErr: true,
+		},
+		{
+			name: "base36 identity hash with other length",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{DefaultMode: ModeLog},
+				IdentityHash:   &IdentityHashConfig{Length: 8},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown identity hash algorithm",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{DefaultMode: ModeLog},
+				IdentityHash:   &IdentityHashConfig{Algorithm: "md5"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid controller selection",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{
+					DefaultMode:         ModeLog,
+					ControllerSelection: ControllerSelectionObservedGenerationOwner,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid controller selection",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{
+					DefaultMode:         ModeLog,
+					ControllerSelection: "firstWriter",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid owner selection",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{
+					DefaultMode:    ModeLog,
+					OwnerSelection: OwnerSelectionAllOwners,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid owner selection",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{
+					DefaultMode:    ModeLog,
+					OwnerSelection: "lastOwner",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid gvk mismatch decision",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{
+					DefaultMode:   ModeLog,
+					OnGVKMismatch: GVKMismatchAllowWithWarning,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid gvk mismatch decision",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{
+					DefaultMode:   ModeLog,
+					OnGVKMismatch: "ignore",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid missing user info decision",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{
+					DefaultMode:       ModeLog,
+					OnMissingUserInfo: MissingUserInfoAnonymousController,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid missing user info decision",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{
+					DefaultMode:       ModeLog,
+					OnMissingUserInfo: "ignore",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid namespace metadata decision",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{
+					DefaultMode:                    ModeEnforce,
+					OnNamespaceMetadataUnavailable: NamespaceMetadataDeny,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid namespace metadata decision",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{
+					DefaultMode:                    ModeEnforce,
+					OnNamespaceMetadataUnavailable: "failOpen",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid cleared user fields decision",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{
+					DefaultMode:         ModeLog,
+					OnClearedUserFields: ClearedFieldsDeny,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid cleared user fields decision",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{
+					DefaultMode:         ModeLog,
+					OnClearedUserFields: "block",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid health signal",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{
+					DefaultMode:  ModeEnforce,
+					HealthSignal: &HealthSignalConfig{ConfigMap: &ConfigMapHealthSignal{Namespace: "kausality-system", Name: "cluster-health"}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid health signal - no source",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{
+					DefaultMode:  ModeEnforce,
+					HealthSignal: &HealthSignalConfig{CheckInterval: time.Second},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid health signal - configMap without name",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{
+					DefaultMode:  ModeEnforce,
+					HealthSignal: &HealthSignalConfig{ConfigMap: &ConfigMapHealthSignal{Namespace: "kausality-system"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid callback pause",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{DefaultMode: ModeLog},
+				CallbackPause:  &CallbackPauseConfig{ConfigMap: &ConfigMapKeyRef{Namespace: "kausality-system", Name: "maintenance"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid callback pause - no configMap",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{DefaultMode: ModeLog},
+				CallbackPause:  &CallbackPauseConfig{CheckInterval: time.Minute},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid callback pause - configMap without name",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{DefaultMode: ModeLog},
+				CallbackPause:  &CallbackPauseConfig{ConfigMap: &ConfigMapKeyRef{Namespace: "kausality-system"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid orphan approvals",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{
+					DefaultMode:          ModeLog,
+					OrphanApprovalTTL:    24 * time.Hour,
+					OrphanApprovalAction: OrphanApprovalRemove,
+				},
+				ApprovalSweep: &ApprovalSweepConfig{Interval: time.Hour, Parents: []SweepParent{{APIVersion: "apps/v1", Kind: "Deployment"}}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid orphan approvals - no approval sweep",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{
+					DefaultMode:       ModeLog,
+					OrphanApprovalTTL: 24 * time.Hour,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid orphan approval action",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{
+					DefaultMode:          ModeLog,
+					OrphanApprovalTTL:    time.Hour,
+					OrphanApprovalAction: "delete",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid approval proposals - negative threshold",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{
+					DefaultMode:       ModeLog,
+					ApprovalProposals: &ApprovalProposalsConfig{Threshold: -1},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid openlineage",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{DefaultMode: ModeLog},
+				OpenLineage:    &OpenLineageConfig{URL: "http://marquez:5000/api/v1/lineage"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid openlineage - empty url",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{DefaultMode: ModeLog},
+				OpenLineage:    &OpenLineageConfig{JobNamespace: "prod"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid kafka backend",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{DefaultMode: ModeLog},
+				Backends: []BackendConfig{{Kafka: &KafkaConfig{
+					Brokers: []string{"kafka:9092"},
+					Topic:   "drift",
+					TLS:     &KafkaTLSConfig{},
+					SASL:    &KafkaSASLConfig{Mechanism: "SCRAM-SHA-512", Username: "kausality", PasswordFile: "/etc/kafka/password"},
+				}}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid kafka backend - url and kafka",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{DefaultMode: ModeLog},
+				Backends:       []BackendConfig{{URL: "https://backend", Kafka: &KafkaConfig{Brokers: []string{"kafka:9092"}, Topic: "drift"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid kafka backend - no topic",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{DefaultMode: ModeLog},
+				Backends:       []BackendConfig{{Kafka: &KafkaConfig{Brokers: []string{"kafka:9092"}}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid backend - negative maxRetryInterval",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{DefaultMode: ModeLog},
+				Backends:       []BackendConfig{{URL: "https://backend.example.com", MaxRetryInterval: -time.Second}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid backend - negative maxInFlight",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{DefaultMode: ModeLog},
+				Backends:       []BackendConfig{{URL: "https://backend.example.com", MaxInFlight: -1}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid includeResources - empty kind",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{DefaultMode: ModeLog, IncludeResources: []ResourceFilter{{APIGroup: "apps"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid excludeResources - empty kind",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{DefaultMode: ModeLog, ExcludeResources: []ResourceFilter{{APIGroup: "*"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid backend - negative dedupWindow",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{DefaultMode: ModeLog},
+				Backends:       []BackendConfig{{URL: "https://backend.example.com", DedupWindow: -time.Second}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid backend - negative dedupMaxSize",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{DefaultMode: ModeLog},
+				Backends:       []BackendConfig{{URL: "https://backend.example.com", DedupMaxSize: -1}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid kafka backend - unknown sasl mechanism",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{DefaultMode: ModeLog},
+				Backends: []BackendConfig{{Kafka: &KafkaConfig{
+					Brokers: []string{"kafka:9092"},
+					Topic:   "drift",
+					SASL:    &KafkaSASLConfig{Mechanism: "GSSAPI", Username: "kausality", PasswordFile: "/etc/kafka/password"},
+				}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid backend phases",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{DefaultMode: ModeLog},
+				Backends:       []BackendConfig{{URL: "https://backend", Phases: []v1alpha1.DriftReportPhase{v1alpha1.DriftReportPhaseDetected, v1alpha1.DriftReportPhaseBreakGlass}}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid backend phase",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{DefaultMode: ModeLog},
+				Backends:       []BackendConfig{{URL: "https://backend", Phases: []v1alpha1.DriftReportPhase{"detected"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "observe backend",
 			config: Config{
-				DriftDetection: DriftDetectionConfig{
-					DefaultMode: ModeLog,
-				},
+				DriftDetection: DriftDetectionConfig{DefaultMode: ModeLog},
+				Backends:       []BackendConfig{{URL: "https://compliance", Mode: BackendModeObserve, QueueSize: 500}},
 			},
 			wantErr: false,
 		},
 		{
-			name: "valid enforce mode",
+			name: "invalid backend mode",
 			config: Config{
-				DriftDetection: DriftDetectionConfig{
-					DefaultMode: ModeEnforce,
-				},
+				DriftDetection: DriftDetectionConfig{DefaultMode: ModeLog},
+				Backends:       []BackendConfig{{URL: "https://backend", Mode: "enforce"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "observe kafka backend",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{DefaultMode: ModeLog},
+				Backends:       []BackendConfig{{Mode: BackendModeObserve, Kafka: &KafkaConfig{Brokers: []string{"kafka:9092"}, Topic: "drift"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid approval sweep",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{DefaultMode: ModeLog},
+				ApprovalSweep:  &ApprovalSweepConfig{Interval: time.Hour, Parents: []SweepParent{{APIVersion: "apps/v1", Kind: "Deployment"}}},
 			},
 			wantErr: false,
 		},
 		{
-			name: "invalid default mode",
+			name: "disabled approval sweep without parents",
 			config: Config{
-				DriftDetection: DriftDetectionConfig{
-					DefaultMode: "invalid",
-				},
+				DriftDetection: DriftDetectionConfig{DefaultMode: ModeLog},
+				ApprovalSweep:  &ApprovalSweepConfig{},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid approval sweep - negative interval",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{DefaultMode: ModeLog},
+				ApprovalSweep:  &ApprovalSweepConfig{Interval: -time.Hour, Parents: []SweepParent{{APIVersion: "apps/v1", Kind: "Deployment"}}},
 			},
 			wantErr: true,
 		},
 		{
-			name: "valid with overrides",
+			name: "invalid approval sweep - no parents",
 			config: Config{
-				DriftDetection: DriftDetectionConfig{
-					DefaultMode: ModeLog,
-					Overrides: []DriftDetectionOverride{
-						{
-							APIGroups: []string{"apps"},
-							Resources: []string{"deployments"},
-							Mode:      ModeEnforce,
-						},
-					},
+				DriftDetection: DriftDetectionConfig{DefaultMode: ModeLog},
+				ApprovalSweep:  &ApprovalSweepConfig{Interval: time.Hour},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid approval sweep - no kind",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{DefaultMode: ModeLog},
+				ApprovalSweep:  &ApprovalSweepConfig{Interval: time.Hour, Parents: []SweepParent{{APIVersion: "apps/v1"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid trace max age",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{DefaultMode: ModeLog},
+				TraceMaxAge:    90 * 24 * time.Hour,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid trace max age - negative",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{DefaultMode: ModeLog},
+				TraceMaxAge:    -time.Hour,
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid max trace hops",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{DefaultMode: ModeLog},
+				MaxTraceHops:   3,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid max trace hops - too small",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{DefaultMode: ModeLog},
+				MaxTraceHops:   2,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid max trace hops - negative",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{DefaultMode: ModeLog},
+				MaxTraceHops:   -1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid stabilization grace period",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{DefaultMode: ModeLog, StabilizationGracePeriod: 2 * time.Second},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid stabilization grace period - negative",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{DefaultMode: ModeLog, StabilizationGracePeriod: -time.Second},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid spec diff",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{DefaultMode: ModeLog},
+				SpecDiff:       &SpecDiffConfig{MaxBytes: 4096},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid spec diff - negative max bytes",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{DefaultMode: ModeLog},
+				SpecDiff:       &SpecDiffConfig{MaxBytes: -1},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid controller versions",
+			config: Config{
+				DriftDetection: DriftDetectionConfig{DefaultMode: ModeLog},
+				ControllerVersions: []ControllerVersionSource{
+					{FieldManager: "a", Version: "v1"},
+					{FieldManager: "b", Deployment: &DeploymentRef{Namespace: "ns", Name: "ctrl"}},
 				},
 			},
 			wantErr: false,
 		},
 		{
-			name: "invalid override - empty apiGroups",
+			name: "invalid controller version - both sources",
 			config: Config{
-				DriftDetection: DriftDetectionConfig{
-					DefaultMode: ModeLog,
-					Overrides: []DriftDetectionOverride{
-						{
-							APIGroups: []string{},
-							Resources: []string{"deployments"},
-							Mode:      ModeEnforce,
-						},
-					},
+				DriftDetection: DriftDetectionConfig{DefaultMode: ModeLog},
+				ControllerVersions: []ControllerVersionSource{
+					{FieldManager: "a", Version: "v1", Deployment: &DeploymentRef{Namespace: "ns", Name: "ctrl"}},
 				},
 			},
 			wantErr: true,
 		},
 		{
-			name: "invalid override - empty resources",
+			name: "invalid controller version - no source",
 			config: Config{
-				DriftDetection: DriftDetectionConfig{
-					DefaultMode: ModeLog,
-					Overrides: []DriftDetectionOverride{
-						{
-							APIGroups: []string{"apps"},
-							Resources: []string{},
-							Mode:      ModeEnforce,
-						},
-					},
-				},
+				DriftDetection:     DriftDetectionConfig{DefaultMode: ModeLog},
+				ControllerVersions: []ControllerVersionSource{{FieldManager: "a"}},
 			},
 			wantErr: true,
 		},
@@ -280,6 +1073,34 @@ driftDetection: {}
 			content: `
 driftDetection:
   defaultMode: invalid
+`,
+			wantErr: true,
+		},
+		{
+			name: "deny message template",
+			content: `
+driftDetection:
+  defaultMode: enforce
+denyMessageTemplate: "{{.Reason}}, see https://wiki.example.com/drift"
+`,
+			wantErr:  false,
+			wantMode: ModeEnforce,
+		},
+		{
+			name: "invalid deny message template",
+			content: `
+driftDetection:
+  defaultMode: enforce
+denyMessageTemplate: "{{.Reason"
+`,
+			wantErr: true,
+		},
+		{
+			name: "deny message template with unknown field",
+			content: `
+driftDetection:
+  defaultMode: enforce
+denyMessageTemplate: "{{.Runbook}}"
 `,
 			wantErr: true,
 		},
@@ -312,6 +1133,84 @@ driftDetection:
 	assert.Error(t, err)
 }
 
+func TestParse_Malformed(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		wantErrs []string
+	}{
+		{
+			name: "unknown field",
+			content: `
+driftDetection:
+  defaultMod: enforce
+`,
+			wantErrs: []string{"field defaultMod not found"},
+		},
+		{
+			name: "unknown top-level field",
+			content: `
+backend:
+  - url: http://example.com
+`,
+			wantErrs: []string{"field backend not found"},
+		},
+		{
+			name: "invalid duration",
+			content: `
+backends:
+  - url: http://example.com
+    timeout: 10 seconds
+`,
+			wantErrs: []string{"cannot unmarshal"},
+		},
+		{
+			name: "all problems reported",
+			content: `
+driftDetection:
+  defaultMode: strict
+  overrides:
+    - apiGroups: ["apps"]
+      mode: enforce
+    - apiGroups: ["apps"]
+      resources: ["deployments"]
+      mode: block
+backends:
+  - url: http://example.com
+    timeout: -1s
+`,
+			wantErrs: []string{
+				`invalid defaultMode "strict": must be one of log, enforce, warn, dryrun`,
+				"override[0]: resources must not be empty",
+				`override[1]: invalid mode "block": must be one of log, enforce, warn, dryrun`,
+				"backends[0]: timeout must not be negative",
+			},
+		},
+		{
+			name: "missing configMap does not hide other problems",
+			content: `
+driftDetection:
+  healthSignal: {}
+  minObjectAge: -5m
+`,
+			wantErrs: []string{
+				"healthSignal: configMap must be set",
+				"minObjectAge must not be negative",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.content))
+			require.Error(t, err)
+			for _, want := range tt.wantErrs {
+				assert.Contains(t, err.Error(), want)
+			}
+		})
+	}
+}
+
 func TestOverrideMatches(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -675,6 +1574,26 @@ func TestResolveModeWithAnnotations(t *testing.T) {
 			},
 			wantMode: ModeLog,
 		},
+		{
+			name:          "object annotation warn",
+			objectAnns:    map[string]string{ModeAnnotation: ModeWarn},
+			namespaceAnns: map[string]string{ModeAnnotation: ModeEnforce},
+			ctx: ResourceContext{
+				GVK:       schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+				Namespace: "default",
+			},
+			wantMode: ModeWarn,
+		},
+		{
+			name:          "namespace annotation dryrun",
+			objectAnns:    map[string]string{},
+			namespaceAnns: map[string]string{ModeAnnotation: ModeDryRun},
+			ctx: ResourceContext{
+				GVK:       schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+				Namespace: "enforce-ns",
+			},
+			wantMode: ModeDryRun,
+		},
 		{
 			name:          "invalid object annotation ignored",
 			objectAnns:    map[string]string{ModeAnnotation: "invalid"},
@@ -870,6 +1789,10 @@ backends:
     timeout: 10s
     retryCount: 3
     retryInterval: 1s
+    maxRetryInterval: 1m
+    maxInFlight: 50
+    dedupWindow: 30s
+    dedupMaxSize: 500
 `,
 			wantBackends: 1,
 			checkBackend: func(t *testing.T, cfg *Config) {
@@ -878,6 +1801,10 @@ backends:
 				assert.Equal(t, 10*time.Second, b.Timeout)
 				assert.Equal(t, 3, b.RetryCount)
 				assert.Equal(t, 1*time.Second, b.RetryInterval)
+				assert.Equal(t, time.Minute, b.MaxRetryInterval)
+				assert.Equal(t, 50, b.MaxInFlight)
+				assert.Equal(t, 30*time.Second, b.DedupWindow)
+				assert.Equal(t, 500, b.DedupMaxSize)
 			},
 		},
 		{
@@ -940,6 +1867,20 @@ backends:
 				assert.Equal(t, 2*time.Second, b.RetryInterval)
 			},
 		},
+		{
+			name: "backend with phases",
+			content: `
+driftDetection:
+  defaultMode: log
+backends:
+  - url: https://pager.example.com/webhook
+    phases: [Detected, BreakGlass]
+`,
+			wantBackends: 1,
+			checkBackend: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, []v1alpha1.DriftReportPhase{v1alpha1.DriftReportPhaseDetected, v1alpha1.DriftReportPhaseBreakGlass}, cfg.Backends[0].Phases)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -962,3 +1903,134 @@ backends:
 		})
 	}
 }
+
+func TestShouldStripOnCreate(t *testing.T) {
+	cfg := &Config{DriftDetection: DriftDetectionConfig{
+		StripOnCreate: []string{"argocd.argoproj.io/", "fluxcd.io/*", "example.com/owner"},
+	}}
+
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{key: "argocd.argoproj.io/tracking-id", want: true},
+		{key: "argocd.argoproj.io/sync-wave", want: true},
+		{key: "fluxcd.io/sync-checksum", want: true},
+		{key: "example.com/owner", want: true},
+		{key: "example.com/owner-team", want: false},
+		{key: "argocd.argoproj.io", want: false},
+		{key: "app.kubernetes.io/name", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			assert.Equal(t, tt.want, cfg.ShouldStripOnCreate(tt.key))
+		})
+	}
+
+	assert.False(t, Default().ShouldStripOnCreate("argocd.argoproj.io/tracking-id"))
+}
+
+func TestIsTrustedUserFieldManager(t *testing.T) {
+	cfg := &Config{DriftDetection: DriftDetectionConfig{
+		TrustedUserFieldManagers: []string{"argocd-*", "kustomize-controller"},
+	}}
+
+	tests := []struct {
+		manager string
+		want    bool
+	}{
+		{manager: "argocd-controller", want: true},
+		{manager: "argocd-application-controller", want: true},
+		{manager: "kustomize-controller", want: true},
+		{manager: "kustomize-controller-v2", want: false},
+		{manager: "argocd", want: false},
+		{manager: "kube-controller-manager", want: false},
+		{manager: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.manager, func(t *testing.T) {
+			assert.Equal(t, tt.want, cfg.IsTrustedUserFieldManager(tt.manager))
+		})
+	}
+
+	assert.False(t, Default().IsTrustedUserFieldManager("argocd-controller"))
+}
+
+func TestInScope(t *testing.T) {
+	event := schema.GroupVersionKind{Version: "v1", Kind: "Event"}
+	eventsV1 := schema.GroupVersionKind{Group: "events.k8s.io", Version: "v1", Kind: "Event"}
+	lease := schema.GroupVersionKind{Group: "coordination.k8s.io", Version: "v1", Kind: "Lease"}
+	deployment := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+	widgetV1 := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}
+	widgetV2 := schema.GroupVersionKind{Group: "example.com", Version: "v2", Kind: "Widget"}
+
+	tests := []struct {
+		name    string
+		include []ResourceFilter
+		exclude []ResourceFilter
+		gvk     schema.GroupVersionKind
+		want    bool
+	}{
+		{name: "no filters", gvk: event, want: true},
+		{name: "excluded kind", exclude: []ResourceFilter{{APIGroup: "", Kind: "Event"}}, gvk: event, want: false},
+		{name: "exclude matches group", exclude: []ResourceFilter{{APIGroup: "", Kind: "Event"}}, gvk: eventsV1, want: true},
+		{name: "exclude any group", exclude: []ResourceFilter{{APIGroup: "*", Kind: "Event"}}, gvk: eventsV1, want: false},
+		{name: "exclude whole group", exclude: []ResourceFilter{{APIGroup: "coordination.k8s.io", Kind: "*"}}, gvk: lease, want: false},
+		{name: "exclude other kind", exclude: []ResourceFilter{{APIGroup: "", Kind: "Event"}}, gvk: deployment, want: true},
+		{name: "included kind", include: []ResourceFilter{{APIGroup: "apps", Kind: "*"}}, gvk: deployment, want: true},
+		{name: "not included", include: []ResourceFilter{{APIGroup: "apps", Kind: "*"}}, gvk: lease, want: false},
+		{name: "included version", include: []ResourceFilter{{APIGroup: "example.com", Version: "v1", Kind: "Widget"}}, gvk: widgetV1, want: true},
+		{name: "other version not included", include: []ResourceFilter{{APIGroup: "example.com", Version: "v1", Kind: "Widget"}}, gvk: widgetV2, want: false},
+		{
+			name:    "exclude wins over include",
+			include: []ResourceFilter{{APIGroup: "example.com", Kind: "*"}},
+			exclude: []ResourceFilter{{APIGroup: "example.com", Version: "v2", Kind: "Widget"}},
+			gvk:     widgetV2,
+			want:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{DriftDetection: DriftDetectionConfig{IncludeResources: tt.include, ExcludeResources: tt.exclude}}
+			assert.Equal(t, tt.want, cfg.InScope(tt.gvk))
+		})
+	}
+}
+
+func TestRenderDenyMessage(t *testing.T) {
+	data := DenyMessageData{
+		ParentKind: "Deployment",
+		ParentName: "web",
+		ChildKind:  "ReplicaSet",
+		ChildName:  "web-abc",
+		Namespace:  "default",
+		Actor:      "system:serviceaccount:kube-system:deployment-controller",
+		Reason:     "drift detected: no approval found for this mutation",
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{name: "no template", want: data.Reason},
+		{
+			name:     "template",
+			template: "{{.ParentKind}} {{.ParentName}} owns {{.ChildKind}} {{.Namespace}}/{{.ChildName}}: {{.Reason}} by {{.Actor}}; see https://wiki.example.com/drift",
+			want:     "Deployment web owns ReplicaSet default/web-abc: drift detected: no approval found for this mutation by system:serviceaccount:kube-system:deployment-controller; see https://wiki.example.com/drift",
+		},
+		{name: "unknown field falls back", template: "{{.Runbook}}", want: data.Reason},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{DenyMessageTemplate: tt.template}
+			assert.Equal(t, tt.want, cfg.RenderDenyMessage(data))
+		})
+	}
+
+	assert.Equal(t, data.Reason, (*Config)(nil).RenderDenyMessage(data))
+}
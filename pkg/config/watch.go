@@ -0,0 +1,123 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ConfigMapKey is the ConfigMap data key WatchConfigMap reads the configuration from,
+// in the format of the file read by Load.
+const ConfigMapKey = "config.yaml"
+
+// rewatchDelay is the pause before a closed or failed watch is re-established.
+const rewatchDelay = time.Second
+
+// Watcher holds the configuration of a ConfigMap and swaps it whenever the ConfigMap
+// changes.
+type Watcher struct {
+	client  client.WithWatch
+	key     types.NamespacedName
+	current atomic.Pointer[Config]
+	// resourceVersion is the ConfigMap version current was read from.
+	resourceVersion string
+}
+
+// WatchConfigMap reads the configuration from the ConfigMapKey entry of the ConfigMap
+// namespace/name and reloads it on every change until ctx is done. The ConfigMap must
+// exist and hold a valid configuration. Later invalid configurations, and deletion of the
+// ConfigMap, keep the last valid one. Reloads and their errors are logged to the logger
+// of ctx.
+func WatchConfigMap(ctx context.Context, c client.WithWatch, namespace, name string) (*Watcher, error) {
+	w := &Watcher{client: c, key: types.NamespacedName{Namespace: namespace, Name: name}}
+	var cm corev1.ConfigMap
+	if err := c.Get(ctx, w.key, &cm); err != nil {
+		return nil, fmt.Errorf("failed to get config ConfigMap %s: %w", w.key, err)
+	}
+	if err := w.load(&cm); err != nil {
+		return nil, err
+	}
+	go w.run(ctx)
+	return w, nil
+}
+
+// Get returns the current configuration. It never returns nil.
+func (w *Watcher) Get() *Config {
+	return w.current.Load()
+}
+
+// run watches the ConfigMap until ctx is done, re-establishing closed watches.
+func (w *Watcher) run(ctx context.Context) {
+	log := logr.FromContextOrDiscard(ctx).WithValues("configMap", w.key.String())
+	for {
+		if err := w.watch(ctx, log); err != nil {
+			log.Error(err, "config ConfigMap watch failed")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(rewatchDelay):
+		}
+	}
+}
+
+// watch applies the changes of the ConfigMap until the watch closes or ctx is done.
+func (w *Watcher) watch(ctx context.Context, log logr.Logger) error {
+	watcher, err := w.client.Watch(ctx, &corev1.ConfigMapList{}, client.InNamespace(w.key.Namespace),
+		client.MatchingFields{"metadata.name": w.key.Name})
+	if err != nil {
+		return err
+	}
+	defer watcher.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return nil
+			}
+			cm, ok := event.Object.(*corev1.ConfigMap)
+			if !ok || cm.Name != w.key.Name {
+				continue
+			}
+			switch event.Type {
+			case watch.Added, watch.Modified:
+				if cm.ResourceVersion == w.resourceVersion {
+					continue
+				}
+				if err := w.load(cm); err != nil {
+					log.Error(err, "keeping previous configuration")
+					continue
+				}
+				log.Info("reloaded configuration", "resourceVersion", cm.ResourceVersion)
+			case watch.Deleted:
+				log.Info("config ConfigMap deleted, keeping previous configuration")
+			}
+		}
+	}
+}
+
+// load parses the configuration of cm and makes it current.
+func (w *Watcher) load(cm *corev1.ConfigMap) error {
+	data, ok := cm.Data[ConfigMapKey]
+	if !ok {
+		return fmt.Errorf("config ConfigMap %s has no %q entry", w.key, ConfigMapKey)
+	}
+	cfg, err := Parse([]byte(data))
+	if err != nil {
+		return fmt.Errorf("config ConfigMap %s: %w", w.key, err)
+	}
+	w.current.Store(cfg)
+	w.resourceVersion = cm.ResourceVersion
+	return nil
+}
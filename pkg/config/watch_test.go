@@ -0,0 +1,73 @@
+package config
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// setConfigMap writes data as the configuration of the kausality-system/kausality-config
+// ConfigMap.
+func setConfigMap(t *testing.T, c client.Client, data string) {
+	t.Helper()
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: "kausality-system", Name: "kausality-config"}, cm))
+	cm.Data = map[string]string{ConfigMapKey: data}
+	require.NoError(t, c.Update(context.Background(), cm))
+}
+
+func TestWatchConfigMap(t *testing.T) {
+	c := fake.NewClientBuilder().WithObjects(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kausality-system", Name: "kausality-config"},
+		Data:       map[string]string{ConfigMapKey: "driftDetection:\n  defaultMode: log\n"},
+	}).Build()
+
+	w, err := WatchConfigMap(t.Context(), c, "kausality-system", "kausality-config")
+	require.NoError(t, err)
+	assert.Equal(t, ModeLog, w.Get().DriftDetection.DefaultMode)
+
+	// The watch may start after the first update, so updates are repeated until seen
+	require.Eventually(t, func() bool {
+		setConfigMap(t, c, "driftDetection:\n  defaultMode: enforce\n")
+		return w.Get().DriftDetection.DefaultMode == ModeEnforce
+	}, 5*time.Second, 50*time.Millisecond, "update not reloaded")
+
+	// Invalid configurations and deletion keep the last valid one
+	setConfigMap(t, c, "driftDetection:\n  defaultMode: invalid\n")
+	setConfigMap(t, c, "driftDetection: [")
+	require.NoError(t, c.Delete(t.Context(), &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "kausality-system", Name: "kausality-config"}}))
+	assert.Never(t, func() bool {
+		return w.Get().DriftDetection.DefaultMode != ModeEnforce
+	}, 200*time.Millisecond, 20*time.Millisecond)
+}
+
+func TestWatchConfigMap_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		cm   *corev1.ConfigMap
+	}{
+		{name: "missing ConfigMap"},
+		{name: "missing key", cm: &corev1.ConfigMap{Data: map[string]string{"other.yaml": ""}}},
+		{name: "invalid config", cm: &corev1.ConfigMap{Data: map[string]string{ConfigMapKey: "driftDetection:\n  defaultMode: invalid\n"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := fake.NewClientBuilder()
+			if tt.cm != nil {
+				tt.cm.Namespace, tt.cm.Name = "kausality-system", "kausality-config"
+				builder = builder.WithObjects(tt.cm)
+			}
+			_, err := WatchConfigMap(t.Context(), builder.Build(), "kausality-system", "kausality-config")
+			require.Error(t, err)
+		})
+	}
+}
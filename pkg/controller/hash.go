@@ -0,0 +1,50 @@
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"strconv"
+
+	"github.com/kausality-io/kausality/pkg/config"
+)
+
+// HashFunc hashes a user identifier for the controllers and updaters annotations.
+// All components must agree on it, as hashes are compared across objects.
+type HashFunc func(username string) string
+
+// NewHashFunc returns the hash function configured by cfg, which config.Validate
+// has checked. Nil means HashUsername.
+func NewHashFunc(cfg *config.IdentityHashConfig) HashFunc {
+	if cfg == nil || cfg.Algorithm != config.HashAlgorithmHex {
+		return HashUsername
+	}
+	length := cfg.Length
+	if length == 0 {
+		length = config.DefaultHexHashLength
+	}
+	return func(username string) string {
+		h := sha256.Sum256([]byte(username))
+		return hex.EncodeToString(h[:])[:length]
+	}
+}
+
+// HashUsername is the default HashFunc. It hashes a username (or UID) to 5 base36
+// characters.
+func HashUsername(username string) string {
+	return hashBase36(username)
+}
+
+// hashBase36 creates a 5-character base36 hash of a username. The leading base36
+// digit of a uint32 is skewed, so it has only about 7 million effective values.
+func hashBase36(username string) string {
+	h := sha256.Sum256([]byte(username))
+	// Use first 4 bytes as uint32, convert to base36
+	n := binary.BigEndian.Uint32(h[:4])
+	s := strconv.FormatUint(uint64(n), 36)
+	// Pad to 5 chars if needed
+	for len(s) < 5 {
+		s = "0" + s
+	}
+	return s[:5]
+}
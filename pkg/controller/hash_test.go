@@ -0,0 +1,66 @@
+package controller
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kausality-io/kausality/pkg/config"
+)
+
+func TestNewHashFunc(t *testing.T) {
+	username := "system:serviceaccount:kube-system:deployment-controller"
+
+	tests := []struct {
+		name    string
+		cfg     *config.IdentityHashConfig
+		wantLen int
+	}{
+		{name: "nil is base36", cfg: nil, wantLen: 5},
+		{name: "base36", cfg: &config.IdentityHashConfig{Algorithm: config.HashAlgorithmBase36}, wantLen: 5},
+		{name: "hex default length", cfg: &config.IdentityHashConfig{Algorithm: config.HashAlgorithmHex}, wantLen: 16},
+		{name: "hex 8", cfg: &config.IdentityHashConfig{Algorithm: config.HashAlgorithmHex, Length: 8}, wantLen: 8},
+		{name: "hex 64", cfg: &config.IdentityHashConfig{Algorithm: config.HashAlgorithmHex, Length: 64}, wantLen: 64},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewHashFunc(tt.cfg)
+			assert.Len(t, f(username), tt.wantLen)
+			assert.Equal(t, f(username), f(username))
+		})
+	}
+	assert.Equal(t, HashUsername(username), NewHashFunc(nil)(username), "default is HashUsername")
+}
+
+func TestNewHashFunc_HexExtendsPrefix(t *testing.T) {
+	short := NewHashFunc(&config.IdentityHashConfig{Algorithm: config.HashAlgorithmHex, Length: 8})
+	long := NewHashFunc(&config.IdentityHashConfig{Algorithm: config.HashAlgorithmHex, Length: 16})
+	assert.Equal(t, short("user@example.com"), long("user@example.com")[:8])
+}
+
+// TestHashUsername_Collisions documents how many distinct actors the hashes tell
+// apart. The default base36 hash has about 7 million effective values, so among
+// 10000 actors a few already collide; 16 hex characters, i.e. 64 bits, do not.
+func TestHashUsername_Collisions(t *testing.T) {
+	collisions := func(f HashFunc, n int) int {
+		seen := make(map[string]struct{}, n)
+		var count int
+		for i := range n {
+			h := f(fmt.Sprintf("system:serviceaccount:ns-%d:controller", i))
+			if _, ok := seen[h]; ok {
+				count++
+			}
+			seen[h] = struct{}{}
+		}
+		return count
+	}
+
+	base36 := NewHashFunc(nil)
+	hex := NewHashFunc(&config.IdentityHashConfig{Algorithm: config.HashAlgorithmHex})
+
+	assert.Zero(t, collisions(base36, 1000), "1000 actors")
+	assert.Equal(t, 7, collisions(base36, 10000), "10000 actors")
+	assert.Zero(t, collisions(hex, 100000), "100000 actors")
+}
@@ -3,10 +3,7 @@ package controller
 
 import (
 	"context"
-	"crypto/sha256"
-	"encoding/binary"
 	"reflect"
-	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -21,9 +18,10 @@ import (
 
 // Annotation keys - re-exported from api/v1alpha1.
 const (
-	ControllersAnnotation = v1alpha1.ControllersAnnotation
-	UpdatersAnnotation    = v1alpha1.UpdatersAnnotation
-	MaxHashes             = v1alpha1.MaxHashes
+	ControllersAnnotation               = v1alpha1.ControllersAnnotation
+	ControllerServiceAccountsAnnotation = v1alpha1.ControllerServiceAccountsAnnotation
+	UpdatersAnnotation                  = v1alpha1.UpdatersAnnotation
+	MaxHashes                           = v1alpha1.MaxHashes
 )
 
 const (
@@ -36,10 +34,12 @@ const (
 // Tracker tracks controller identity via user hash annotations.
 type Tracker struct {
 	client client.Client
+	keys   v1alpha1.AnnotationKeys
+	hash   HashFunc
 	log    logr.Logger
 
 	// pending tracks async updates to batch
-	pending   map[string]string // objectKey -> hash to add
+	pending   map[string]string // objectKey -> username to add
 	pendingMu sync.Mutex
 }
 
@@ -47,11 +47,24 @@ type Tracker struct {
 func NewTracker(c client.Client, log logr.Logger) *Tracker {
 	return &Tracker{
 		client:  c,
+		keys:    v1alpha1.DefaultAnnotationKeys,
+		hash:    HashUsername,
 		log:     log.WithName("controller-tracker"),
 		pending: make(map[string]string),
 	}
 }
 
+// SetAnnotationKeys records controllers and phases in the annotations of keys rather
+// than the kausality.io/ ones.
+func (t *Tracker) SetAnnotationKeys(keys v1alpha1.AnnotationKeys) {
+	t.keys = keys
+}
+
+// SetHashFunc hashes user identifiers with f rather than HashUsername.
+func (t *Tracker) SetHashFunc(f HashFunc) {
+	t.hash = f
+}
+
 // UserIdentifier returns the user identifier to use for hashing.
 // Uses username if non-empty, otherwise falls back to UID.
 func UserIdentifier(username, uid string) string {
@@ -61,19 +74,6 @@ func UserIdentifier(username, uid string) string {
 	return uid
 }
 
-// HashUsername creates a 5-character base36 hash of a username (or UID).
-func HashUsername(username string) string {
-	h := sha256.Sum256([]byte(username))
-	// Use first 4 bytes as uint32, convert to base36
-	n := binary.BigEndian.Uint32(h[:4])
-	s := strconv.FormatUint(uint64(n), 36)
-	// Pad to 5 chars if needed
-	for len(s) < 5 {
-		s = "0" + s
-	}
-	return s[:5]
-}
-
 // RecordUpdater adds a user hash to the child's updaters annotation.
 // This is called synchronously and returns the patch data.
 func RecordUpdater(obj client.Object, username string) map[string]string {
@@ -100,24 +100,51 @@ func RecordUpdater(obj client.Object, username string) map[string]string {
 	return annotations
 }
 
+// serviceAccountUsernamePrefix is the prefix of service account usernames.
+const serviceAccountUsernamePrefix = "system:serviceaccount:"
+
+// ServiceAccount returns the service account of a username of the form
+// "system:serviceaccount:<namespace>:<name>" as "<namespace>/<name>".
+func ServiceAccount(username string) (string, bool) {
+	rest, ok := strings.CutPrefix(username, serviceAccountUsernamePrefix)
+	if !ok {
+		return "", false
+	}
+	namespace, name, ok := strings.Cut(rest, ":")
+	if !ok || namespace == "" || name == "" || strings.Contains(name, ":") {
+		return "", false
+	}
+	return namespace + "/" + name, true
+}
+
+// AddEntry adds an entry to a comma-separated list if not already present,
+// keeping the MaxHashes most recent entries.
+func AddEntry(existing, entry string) string {
+	entries := ParseHashes(existing)
+	if ContainsHash(entries, entry) {
+		return existing
+	}
+	entries = append(entries, entry)
+	if len(entries) > MaxHashes {
+		entries = entries[len(entries)-MaxHashes:]
+	}
+	return strings.Join(entries, ",")
+}
+
 // RecordControllerAsync schedules an async update to add the user hash
-// to the parent's controllers annotation.
+// to the parent's controllers annotation, and the user's service account, if
+// any, to the controllers-sa annotation.
 func (t *Tracker) RecordControllerAsync(ctx context.Context, obj client.Object, username string) {
-	hash := HashUsername(username)
 	key := objectKey(obj)
 
-	// Check if hash is already in annotation
-	annotations := obj.GetAnnotations()
-	if annotations != nil {
-		existing := annotations[ControllersAnnotation]
-		if ContainsHash(ParseHashes(existing), hash) {
-			return // Already recorded
-		}
+	// Check if already in annotations
+	if t.recorded(obj.GetAnnotations(), username) {
+		return
 	}
 
 	t.pendingMu.Lock()
 	_, alreadyPending := t.pending[key]
-	t.pending[key] = hash
+	t.pending[key] = username
 	t.pendingMu.Unlock()
 
 	if !alreadyPending {
@@ -139,7 +166,7 @@ func (t *Tracker) flushAfterDelay(ctx context.Context, obj client.Object, delay
 
 	key := objectKey(obj)
 	t.pendingMu.Lock()
-	hash, ok := t.pending[key]
+	username, ok := t.pending[key]
 	delete(t.pending, key)
 	t.pendingMu.Unlock()
 
@@ -147,6 +174,8 @@ func (t *Tracker) flushAfterDelay(ctx context.Context, obj client.Object, delay
 		return
 	}
 
+	hash := t.hash(username)
+	sa, isSA := ServiceAccount(username)
 	log := t.log.WithValues(
 		"kind", objectTypeName(obj),
 		"namespace", obj.GetNamespace(),
@@ -162,26 +191,20 @@ func (t *Tracker) flushAfterDelay(ctx context.Context, obj client.Object, delay
 			return err
 		}
 
-		// Get existing hashes
-		annotations := current.GetAnnotations()
-		hashes := ParseHashes(annotations[ControllersAnnotation])
-
 		// Check if already present
-		if ContainsHash(hashes, hash) {
+		annotations := current.GetAnnotations()
+		if t.recorded(annotations, username) {
 			return nil
 		}
 
-		// Add new hash
-		hashes = append(hashes, hash)
-		if len(hashes) > MaxHashes {
-			hashes = hashes[len(hashes)-MaxHashes:]
-		}
-
 		// Initialize map only before writing
 		if annotations == nil {
 			annotations = make(map[string]string)
 		}
-		annotations[ControllersAnnotation] = strings.Join(hashes, ",")
+		annotations[t.keys.Controllers] = AddEntry(annotations[t.keys.Controllers], hash)
+		if isSA {
+			annotations[t.keys.ControllerServiceAccounts] = AddEntry(annotations[t.keys.ControllerServiceAccounts], sa)
+		}
 		current.SetAnnotations(annotations)
 
 		return t.client.Update(ctx, current)
@@ -194,6 +217,16 @@ func (t *Tracker) flushAfterDelay(ctx context.Context, obj client.Object, delay
 	}
 }
 
+// recorded returns true if annotations already record username as controller, by hash
+// and, for service accounts, by service account.
+func (t *Tracker) recorded(annotations map[string]string, username string) bool {
+	if !ContainsHash(ParseHashes(annotations[t.keys.Controllers]), t.hash(username)) {
+		return false
+	}
+	sa, ok := ServiceAccount(username)
+	return !ok || ContainsHash(ParseHashes(annotations[t.keys.ControllerServiceAccounts]), sa)
+}
+
 // ParseHashes splits a comma-separated hash string.
 func ParseHashes(s string) []string {
 	if s == "" {
@@ -252,12 +285,12 @@ func (t *Tracker) RecordPhaseAsync(ctx context.Context, obj client.Object, phase
 
 	// Skip if already initialized (don't downgrade)
 	annotations := obj.GetAnnotations()
-	if annotations != nil && annotations[PhaseAnnotation] == PhaseValueInitialized {
+	if annotations != nil && annotations[t.keys.Phase] == PhaseValueInitialized {
 		return
 	}
 
 	// Skip if setting to same value
-	if annotations != nil && annotations[PhaseAnnotation] == phase {
+	if annotations != nil && annotations[t.keys.Phase] == phase {
 		return
 	}
 
@@ -304,12 +337,12 @@ func (t *Tracker) flushPhaseAfterDelay(ctx context.Context, obj client.Object, d
 
 		// Check if already initialized (don't downgrade)
 		annotations := current.GetAnnotations()
-		if annotations != nil && annotations[PhaseAnnotation] == PhaseValueInitialized {
+		if annotations != nil && annotations[t.keys.Phase] == PhaseValueInitialized {
 			return nil
 		}
 
 		// Check if already set to this value
-		if annotations != nil && annotations[PhaseAnnotation] == phase {
+		if annotations != nil && annotations[t.keys.Phase] == phase {
 			return nil
 		}
 
@@ -317,7 +350,7 @@ func (t *Tracker) flushPhaseAfterDelay(ctx context.Context, obj client.Object, d
 		if annotations == nil {
 			annotations = make(map[string]string)
 		}
-		annotations[PhaseAnnotation] = phase
+		annotations[t.keys.Phase] = phase
 		current.SetAnnotations(annotations)
 
 		return t.client.Update(ctx, current)
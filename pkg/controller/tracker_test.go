@@ -165,6 +165,38 @@ func TestParseHashes(t *testing.T) {
 	}
 }
 
+func TestServiceAccount(t *testing.T) {
+	tests := []struct {
+		username string
+		want     string
+		wantOK   bool
+	}{
+		{"system:serviceaccount:kube-system:deployment-controller", "kube-system/deployment-controller", true},
+		{"system:serviceaccount:infra:widget-controller", "infra/widget-controller", true},
+		{"system:serviceaccount:infra", "", false},
+		{"system:serviceaccount::widget-controller", "", false},
+		{"system:serviceaccount:infra:a:b", "", false},
+		{"system:kube-controller-manager", "", false},
+		{"alice@example.com", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.username, func(t *testing.T) {
+			got, ok := ServiceAccount(tt.username)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAddEntry(t *testing.T) {
+	assert.Equal(t, "infra/a", AddEntry("", "infra/a"))
+	assert.Equal(t, "infra/a,infra/b", AddEntry("infra/a", "infra/b"))
+	assert.Equal(t, "infra/a,infra/b", AddEntry("infra/a,infra/b", "infra/a"))
+	assert.Equal(t, "b,c,d,e,f", AddEntry("a,b,c,d,e", "f"))
+}
+
 func TestContainsHash(t *testing.T) {
 	hashes := []string{"abc12", "def34", "ghi56"}
 
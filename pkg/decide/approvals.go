@@ -0,0 +1,133 @@
+package decide
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kausalityv1alpha1 "github.com/kausality-io/kausality/api/v1alpha1"
+	"github.com/kausality-io/kausality/pkg/approval"
+	"github.com/kausality-io/kausality/pkg/config"
+	"github.com/kausality-io/kausality/pkg/drift"
+	"github.com/kausality-io/kausality/pkg/policy"
+)
+
+// maxApprovalAncestors bounds the ancestors above the parent searched for recursive approvals.
+const maxApprovalAncestors = 10
+
+// ApprovalInput is the input of CheckApprovals.
+type ApprovalInput struct {
+	// Checker matches approvals and rejections.
+	Checker *approval.Checker
+	// Policies resolves auto-approvals. Optional.
+	Policies policy.Resolver
+	// Parent is the parent of Child, carrying its approvals and rejections.
+	Parent client.Object
+	// Child is the object being written.
+	Child client.Object
+	// ChangedFields are the JSON pointers of the changed spec fields, nil if the whole
+	// child changes, e.g. on creation.
+	ChangedFields []string
+	// ParentReferences and Keys find the owners of ancestors.
+	ParentReferences []config.ParentReference
+	Keys             kausalityv1alpha1.AnnotationKeys
+	// NamespaceLabels returns the labels of a namespace for matching auto-approvals.
+	NamespaceLabels func(ctx context.Context, namespace string) (map[string]string, error)
+	// FetchParent fetches an ancestor for recursive approvals.
+	FetchParent func(ctx context.Context, ref drift.ParentRef, childNamespace string) (client.Object, error)
+	Log         logr.Logger
+}
+
+// CheckApprovals checks the parent's approvals and rejections for the child, falling
+// back to the auto-approvals of the policies matching the parent, and then to the
+// recursive approvals of the parent's ancestors.
+func CheckApprovals(ctx context.Context, in ApprovalInput) approval.CheckResult {
+	result := in.Checker.CheckChanges(in.Parent, ApprovalChildRef(in.Child), in.Parent.GetGeneration(), in.ChangedFields)
+	if !result.Approved && !result.Rejected {
+		result = checkAutoApprovals(ctx, in, result)
+	}
+	if !result.Approved && !result.Rejected {
+		result = checkRecursiveApprovals(ctx, in, result)
+	}
+	return result
+}
+
+// checkAutoApprovals falls back to the auto-approvals of the policies matching the parent
+// if none of the parent's approvals matched. Auto-approvals are always or generation mode,
+// so they are never consumed.
+func checkAutoApprovals(ctx context.Context, in ApprovalInput, result approval.CheckResult) approval.CheckResult {
+	resolver, ok := in.Policies.(policy.ApprovalResolver)
+	if !ok {
+		return result
+	}
+
+	gvk := in.Parent.GetObjectKind().GroupVersionKind()
+	parentCtx := policy.ResourceContext{
+		GVR:          gvk.GroupVersion().WithResource(KindToResource(gvk.Kind)),
+		Kind:         gvk.Kind,
+		Namespace:    in.Parent.GetNamespace(),
+		ObjectLabels: in.Parent.GetLabels(),
+	}
+	if parentCtx.Namespace != "" && in.NamespaceLabels != nil {
+		nsLabels, err := in.NamespaceLabels(ctx, parentCtx.Namespace)
+		if err != nil {
+			in.Log.V(1).Info("failed to fetch namespace for auto-approvals", "namespace", parentCtx.Namespace, "error", err.Error())
+		}
+		parentCtx.NamespaceLabels = nsLabels
+	}
+
+	approvals := resolver.ResolveApprovals(parentCtx)
+	if len(approvals) == 0 {
+		return result
+	}
+	auto := in.Checker.CheckApprovals(approvals, ApprovalChildRef(in.Child), in.Parent.GetGeneration(), in.ChangedFields)
+	if !auto.Approved {
+		return result
+	}
+	auto.Reason = "auto-approved by policy: " + auto.Reason
+	return auto
+}
+
+// checkRecursiveApprovals falls back to the recursive approvals of the parent's ancestors,
+// walking up the ownership chain. An ancestor's recursive approval matching its child on
+// the path approves drift anywhere below that child.
+func checkRecursiveApprovals(ctx context.Context, in ApprovalInput, result approval.CheckResult) approval.CheckResult {
+	if in.FetchParent == nil {
+		return result
+	}
+
+	child := in.Parent
+	for range maxApprovalAncestors {
+		ownerRef := drift.ParentOwnerRef(child, in.ParentReferences, in.Keys)
+		if ownerRef == nil {
+			return result
+		}
+		ref := drift.ParentRefFromOwnerRef(*ownerRef, child.GetNamespace())
+		ancestor, err := in.FetchParent(ctx, ref, child.GetNamespace())
+		if err != nil {
+			in.Log.V(1).Info("failed to fetch ancestor for recursive approvals", "ancestor", ref.String(), "error", err.Error())
+			return result
+		}
+		recursive := in.Checker.CheckRecursive(ancestor, ApprovalChildRef(child), in.ChangedFields)
+		if recursive.Approved {
+			recursive.Reason = fmt.Sprintf("approved by recursive approval on %s %s: %s", ref.Kind, ref.Name, recursive.Reason)
+			return recursive
+		}
+		child = ancestor
+	}
+	return result
+}
+
+// ApprovalChildRef returns the reference approvals are matched against.
+func ApprovalChildRef(obj client.Object) approval.ChildRef {
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	return approval.ChildRef{
+		APIVersion: gvk.GroupVersion().String(),
+		Kind:       gvk.Kind,
+		Name:       obj.GetName(),
+		Labels:     obj.GetLabels(),
+	}
+}
@@ -0,0 +1,231 @@
+// Package decide decides child mutations with the drift detection, approval, trace and
+// mode logic of the admission webhook, for embedding outside of admission, e.g. in a
+// controller's reconcile loop before it writes a child.
+package decide
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	"github.com/kausality-io/kausality/pkg/approval"
+	"github.com/kausality-io/kausality/pkg/config"
+	"github.com/kausality-io/kausality/pkg/controller"
+	"github.com/kausality-io/kausality/pkg/drift"
+	"github.com/kausality-io/kausality/pkg/policy"
+	"github.com/kausality-io/kausality/pkg/trace"
+)
+
+// Options configures DecideDrift.
+type Options struct {
+	// Username identifies the actor writing the child, e.g. the controller's service
+	// account "system:serviceaccount:infra:widget-controller".
+	Username string
+	// OldObject is the child as currently stored, nil if it is being created. Its
+	// updaters annotation tells whether Username is the child's controller, and its
+	// spec which fields the write changes.
+	OldObject client.Object
+	// Config provides drift detection settings and, without Policies, modes.
+	// Defaults to config.Default().
+	Config *config.Config
+	// Policies resolves modes and auto-approvals from Kausality policies. Optional.
+	Policies policy.Resolver
+	// Classifiers classify the lifecycle phase of parents of their kind, see
+	// drift.Classifier. Optional.
+	Classifiers map[schema.GroupKind]drift.Classifier
+}
+
+// Decision is the decision on a write of a child.
+type Decision struct {
+	// Allowed is false if the webhook would deny the write.
+	Allowed bool
+	// DriftDetected is true if the write is drift, approved or not.
+	DriftDetected bool
+	// Phase is the lifecycle phase of the child's parent. Empty without a parent.
+	Phase drift.LifecyclePhase
+	// Trace is the causal trace the child carries after the write. Nil if the write
+	// does not change the spec.
+	Trace trace.Trace
+	// Reason explains the decision.
+	Reason string
+	// Mode is the resolved drift detection mode. Empty if there is no drift.
+	Mode string
+}
+
+// DecideDrift decides a write of obj by opts.Username like the admission webhook
+// would: it detects drift against the parent, checks the parent's approvals and
+// rejections, the policies' auto-approvals and the ancestors' recursive approvals,
+// resolves the mode and propagates the trace. Nothing is written; approvals are not consumed.
+//
+// Freezes, break-glass tokens, baselines, field ownership and the other refinements
+// of admission requests are not applied.
+func DecideDrift(ctx context.Context, c client.Client, obj client.Object, opts Options) (*Decision, error) {
+	cfg := opts.Config
+	if cfg == nil {
+		cfg = config.Default()
+	}
+	keys := cfg.AnnotationKeys()
+	hash := controller.NewHashFunc(cfg.IdentityHash)
+
+	newObj, err := toUnstructured(c, obj)
+	if err != nil {
+		return nil, err
+	}
+	var oldObj *unstructured.Unstructured
+	if opts.OldObject != nil {
+		if oldObj, err = toUnstructured(c, opts.OldObject); err != nil {
+			return nil, err
+		}
+	}
+
+	// Writes that don't change the spec are never drift
+	gvk := newObj.GroupVersionKind()
+	mergeKeys := cfg.ArrayMergeKeysFor(gvk)
+	var oldSpec, newSpec interface{}
+	if oldObj != nil {
+		oldSpec = drift.RemoveSpecPaths(drift.ExtractSpec(oldObj, mergeKeys), cfg.DriftDetection.IgnoredSpecPaths)
+		newSpec = drift.RemoveSpecPaths(drift.ExtractSpec(newObj, mergeKeys), cfg.DriftDetection.IgnoredSpecPaths)
+		if drift.EqualSpec(oldSpec, newSpec) {
+			return &Decision{Allowed: true, Reason: "no spec change"}, nil
+		}
+	}
+
+	var childUpdaters []string
+	if oldObj != nil {
+		childUpdaters = drift.ParseUpdaterHashesWithKeys(oldObj, keys)
+	}
+
+	detectorOpts := []drift.DetectorOption{
+		drift.WithParentReferences(cfg.DriftDetection.ParentReferences),
+		drift.WithArrayMergeKeys(cfg.DriftDetection.ArrayMergeKeys),
+		drift.WithLifecycleDetector(&drift.LifecycleDetector{
+			DetectionOrder:           drift.DefaultDetectionOrder,
+			StabilizationGracePeriod: cfg.DriftDetection.StabilizationGracePeriod,
+		}),
+		drift.WithAnnotationKeys(keys),
+		drift.WithHashFunc(hash),
+		drift.WithOwnerSelection(cfg.DriftDetection.OwnerSelection),
+	}
+	for gk, classifier := range opts.Classifiers {
+		detectorOpts = append(detectorOpts, drift.WithClassifier(gk, classifier))
+	}
+	result, err := drift.NewDetectorWithOptions(c, detectorOpts...).Detect(ctx, newObj, opts.Username, childUpdaters)
+	if err != nil {
+		return nil, fmt.Errorf("drift detection failed: %w", err)
+	}
+
+	propagator := trace.NewPropagatorWithOptions(c,
+		trace.WithMaxAge(cfg.TraceMaxAge),
+		trace.WithMaxTraceHops(cfg.MaxTraceHops),
+		trace.WithParentReferences(cfg.DriftDetection.ParentReferences),
+		trace.WithAnnotationKeys(keys),
+		trace.WithHashFunc(hash),
+	)
+	traceResult, err := propagator.PropagateWithParent(ctx, newObj, result.ParentState, opts.Username, childUpdaters, "")
+	if err != nil {
+		return nil, fmt.Errorf("trace propagation failed: %w", err)
+	}
+
+	decision := &Decision{
+		Allowed:       true,
+		DriftDetected: result.DriftDetected,
+		Phase:         result.LifecyclePhase,
+		Trace:         traceResult.Trace,
+		Reason:        result.Reason,
+	}
+	if !result.DriftDetected || result.ParentState == nil || result.ParentState.Object == nil {
+		return decision, nil
+	}
+
+	// Approvals scoped to fields need the changed fields; creations change the whole child
+	var changedFields []string
+	if oldObj != nil {
+		changedFields = []string{}
+		for _, diff := range drift.DiffSpec(oldSpec, newSpec) {
+			changedFields = append(changedFields, diff.Pointer)
+		}
+	}
+	nsLabels, nsAnnotations := namespaceMetadata(ctx, c, newObj.GetNamespace())
+	check := CheckApprovals(ctx, ApprovalInput{
+		Checker:          approval.NewChecker(approval.WithAnnotationKeys(keys)),
+		Policies:         opts.Policies,
+		Parent:           result.ParentState.Object,
+		Child:            newObj,
+		ChangedFields:    changedFields,
+		ParentReferences: cfg.DriftDetection.ParentReferences,
+		Keys:             keys,
+		NamespaceLabels: func(ctx context.Context, namespace string) (map[string]string, error) {
+			if namespace == newObj.GetNamespace() {
+				return nsLabels, nil
+			}
+			labels, _ := namespaceMetadata(ctx, c, namespace)
+			return labels, nil
+		},
+		FetchParent: func(ctx context.Context, ref drift.ParentRef, childNamespace string) (client.Object, error) {
+			return drift.GetParent(ctx, c, ref, childNamespace)
+		},
+	})
+
+	objAnnotations := newObj.GetAnnotations()
+	if objAnnotations == nil {
+		objAnnotations = map[string]string{}
+	}
+	decision.Mode = ModeResolver{Policies: opts.Policies, Config: cfg}.Resolve(gvk, newObj.GetNamespace(), nsLabels, newObj.GetLabels(), objAnnotations, nsAnnotations)
+
+	verdict := Drift(DriftInput{
+		Result:            result,
+		Approval:          check,
+		Mode:              decision.Mode,
+		DenyClearedFields: cfg.DeniesClearedFields(),
+		Render:            DenyMessageRenderer(cfg, result, newObj, opts.Username),
+	})
+	decision.Allowed = verdict.Allowed
+	decision.Reason = verdict.Message
+	if check.Approved {
+		decision.Reason = check.Reason
+	}
+	return decision, nil
+}
+
+// namespaceMetadata returns the labels and annotations of a namespace. Namespaces that
+// cannot be read have none.
+func namespaceMetadata(ctx context.Context, c client.Client, namespace string) (labels, annotations map[string]string) {
+	if namespace == "" {
+		return nil, map[string]string{}
+	}
+	ns := &unstructured.Unstructured{}
+	ns.SetAPIVersion("v1")
+	ns.SetKind("Namespace")
+	if err := c.Get(ctx, client.ObjectKey{Name: namespace}, ns); err != nil {
+		return nil, map[string]string{}
+	}
+	annotations = ns.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	return ns.GetLabels(), annotations
+}
+
+// toUnstructured converts obj to unstructured, with its kind looked up in the client's
+// scheme if obj is typed.
+func toUnstructured(c client.Client, obj client.Object) (*unstructured.Unstructured, error) {
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		return u, nil
+	}
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine kind of %T: %w", obj, err)
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert %s: %w", gvk.Kind, err)
+	}
+	u := &unstructured.Unstructured{Object: content}
+	u.SetGroupVersionKind(gvk)
+	return u, nil
+}
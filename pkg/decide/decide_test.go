@@ -0,0 +1,193 @@
+package decide
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kausalityv1alpha1 "github.com/kausality-io/kausality/api/v1alpha1"
+	"github.com/kausality-io/kausality/pkg/config"
+	"github.com/kausality-io/kausality/pkg/controller"
+	"github.com/kausality-io/kausality/pkg/drift"
+)
+
+const (
+	deploymentController = "system:serviceaccount:kube-system:deployment-controller"
+	operator             = "alice@example.com"
+)
+
+// stableDeployment returns an initialized Deployment whose controller has caught up.
+func stableDeployment(annotations map[string]string) *appsv1.Deployment {
+	anns := map[string]string{kausalityv1alpha1.PhaseAnnotation: controller.PhaseValueInitialized}
+	for k, v := range annotations {
+		anns[k] = v
+	}
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "web",
+			Namespace:   "default",
+			UID:         "web-uid",
+			Generation:  1,
+			Annotations: anns,
+		},
+		Status: appsv1.DeploymentStatus{ObservedGeneration: 1},
+	}
+}
+
+// replicaSet returns a ReplicaSet controlled by the web Deployment and last written by
+// the deployment controller.
+func replicaSet(replicas int32) *appsv1.ReplicaSet {
+	return &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "web-abc",
+			Namespace:   "default",
+			Annotations: map[string]string{kausalityv1alpha1.UpdatersAnnotation: controller.HashUsername(deploymentController)},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "apps/v1", Kind: "Deployment", Name: "web", UID: "web-uid", Controller: ptr.To(true),
+			}},
+		},
+		Spec: appsv1.ReplicaSetSpec{Replicas: ptr.To(replicas)},
+	}
+}
+
+func TestDecideDrift(t *testing.T) {
+	enforce := &config.Config{DriftDetection: config.DriftDetectionConfig{DefaultMode: config.ModeEnforce}}
+	approved := map[string]string{
+		kausalityv1alpha1.ApprovalsAnnotation: `[{"apiVersion":"apps/v1","kind":"ReplicaSet","name":"web-abc","mode":"always"}]`,
+	}
+	rejected := map[string]string{
+		kausalityv1alpha1.RejectionsAnnotation: `[{"apiVersion":"apps/v1","kind":"ReplicaSet","name":"web-abc","reason":"hands off"}]`,
+	}
+
+	tests := []struct {
+		name       string
+		parentAnns map[string]string
+		reconcile  bool
+		config     *config.Config
+		user       string
+		oldObj     client.Object
+		newObj     client.Object
+
+		wantAllowed   bool
+		wantDrift     bool
+		wantMode      string
+		wantReason    string
+		wantTraceLen  int
+		wantTraceUser string
+	}{
+		{
+			name:          "controller drift is allowed in log mode",
+			user:          deploymentController,
+			oldObj:        replicaSet(1),
+			newObj:        replicaSet(2),
+			wantAllowed:   true,
+			wantDrift:     true,
+			wantMode:      config.ModeLog,
+			wantReason:    "drift detected: no approval found for this mutation",
+			wantTraceLen:  1,
+			wantTraceUser: deploymentController,
+		},
+		{
+			name:         "controller drift is denied in enforce mode",
+			config:       enforce,
+			user:         deploymentController,
+			oldObj:       replicaSet(1),
+			newObj:       replicaSet(2),
+			wantDrift:    true,
+			wantMode:     config.ModeEnforce,
+			wantReason:   "drift detected: no approval found for this mutation",
+			wantTraceLen: 1,
+		},
+		{
+			name:         "approved drift is allowed in enforce mode",
+			parentAnns:   approved,
+			config:       enforce,
+			user:         deploymentController,
+			oldObj:       replicaSet(1),
+			newObj:       replicaSet(2),
+			wantAllowed:  true,
+			wantDrift:    true,
+			wantMode:     config.ModeEnforce,
+			wantReason:   "approved via always approval",
+			wantTraceLen: 1,
+		},
+		{
+			name:         "rejected drift is denied in enforce mode",
+			parentAnns:   rejected,
+			config:       enforce,
+			user:         deploymentController,
+			oldObj:       replicaSet(1),
+			newObj:       replicaSet(2),
+			wantDrift:    true,
+			wantMode:     config.ModeEnforce,
+			wantReason:   "drift rejected: hands off",
+			wantTraceLen: 1,
+		},
+		{
+			name:          "controller of a reconciling parent extends its trace",
+			reconcile:     true,
+			config:        enforce,
+			user:          deploymentController,
+			oldObj:        replicaSet(1),
+			newObj:        replicaSet(2),
+			wantAllowed:   true,
+			wantTraceLen:  2,
+			wantTraceUser: deploymentController,
+		},
+		{
+			name:          "a different actor starts a new trace",
+			config:        enforce,
+			user:          operator,
+			oldObj:        replicaSet(1),
+			newObj:        replicaSet(2),
+			wantAllowed:   true,
+			wantTraceLen:  1,
+			wantTraceUser: operator,
+		},
+		{
+			name:        "no spec change",
+			config:      enforce,
+			user:        deploymentController,
+			oldObj:      replicaSet(1),
+			newObj:      replicaSet(1),
+			wantAllowed: true,
+			wantReason:  "no spec change",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parent := stableDeployment(tt.parentAnns)
+			if tt.reconcile {
+				parent.Generation = 2
+			}
+			c := fake.NewClientBuilder().WithObjects(parent).Build()
+
+			decision, err := DecideDrift(t.Context(), c, tt.newObj, Options{
+				Username:  tt.user,
+				OldObject: tt.oldObj,
+				Config:    tt.config,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAllowed, decision.Allowed, decision.Reason)
+			assert.Equal(t, tt.wantDrift, decision.DriftDetected)
+			assert.Equal(t, tt.wantMode, decision.Mode)
+			if tt.wantReason != "" {
+				assert.Contains(t, decision.Reason, tt.wantReason)
+			}
+			require.Len(t, decision.Trace, tt.wantTraceLen)
+			if tt.wantTraceUser != "" {
+				assert.Equal(t, tt.wantTraceUser, decision.Trace[len(decision.Trace)-1].User)
+			}
+			if tt.wantDrift {
+				assert.Equal(t, drift.PhaseInitialized, decision.Phase)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package decide
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kausality-io/kausality/pkg/config"
+	"github.com/kausality-io/kausality/pkg/policy"
+)
+
+// ModeResolver resolves the drift detection mode of a resource.
+type ModeResolver struct {
+	// Policies resolves modes from Kausality policies. If set, it takes precedence over
+	// Config.
+	Policies policy.Resolver
+	// Config resolves modes without Policies and defines the mode annotation key.
+	// Defaults to config.Default().
+	Config *config.Config
+}
+
+// Resolve determines the drift detection mode of a resource.
+// Precedence: object annotation > namespace annotation > CRD policy > legacy config.
+func (r ModeResolver) Resolve(gvk schema.GroupVersionKind, namespace string, nsLabels, objLabels, objAnnotations, nsAnnotations map[string]string) string {
+	cfg := r.Config
+	if cfg == nil {
+		cfg = config.Default()
+	}
+
+	// If policy resolver is available, use it
+	if r.Policies != nil {
+		policyCtx := policy.ResourceContext{
+			GVR: schema.GroupVersionResource{
+				Group:    gvk.Group,
+				Version:  gvk.Version,
+				Resource: KindToResource(gvk.Kind),
+			},
+			Kind:            gvk.Kind,
+			Namespace:       namespace,
+			NamespaceLabels: nsLabels,
+			ObjectLabels:    objLabels,
+		}
+		modeKey := cfg.AnnotationKeys().Mode
+		mode := r.Policies.ResolveMode(policyCtx, policyModeAnnotations(modeKey, objAnnotations), policyModeAnnotations(modeKey, nsAnnotations))
+		return string(mode)
+	}
+
+	// Fallback to legacy config
+	resourceCtx := config.ResourceContext{
+		GVK:             gvk,
+		Namespace:       namespace,
+		NamespaceLabels: nsLabels,
+		ObjectLabels:    objLabels,
+	}
+	return cfg.ResolveModeWithAnnotations(objAnnotations, nsAnnotations, resourceCtx)
+}
+
+// policyModeAnnotations returns the mode annotation of annotations, under modeKey, as
+// the key policy resolvers read, policy.ModeAnnotation.
+func policyModeAnnotations(modeKey string, annotations map[string]string) map[string]string {
+	if modeKey == policy.ModeAnnotation {
+		return annotations
+	}
+	mode, ok := annotations[modeKey]
+	if !ok {
+		return nil
+	}
+	return map[string]string{policy.ModeAnnotation: mode}
+}
+
+// KindToResource converts a Kind to the conventional resource name.
+func KindToResource(kind string) string {
+	// Simple lowercase + 's' suffix (works for most resources)
+	// Note: This doesn't handle irregular plurals (e.g., "Ingress" -> "ingresses")
+	// but works for common cases like Deployment -> deployments
+	lower := strings.ToLower(kind)
+	if strings.HasSuffix(lower, "s") || strings.HasSuffix(lower, "x") || strings.HasSuffix(lower, "ch") || strings.HasSuffix(lower, "sh") {
+		return lower + "es"
+	}
+	return lower + "s"
+}
@@ -0,0 +1,138 @@
+package decide
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kausalityv1alpha1 "github.com/kausality-io/kausality/api/v1alpha1"
+	"github.com/kausality-io/kausality/pkg/approval"
+	"github.com/kausality-io/kausality/pkg/config"
+	"github.com/kausality-io/kausality/pkg/drift"
+)
+
+// Verdict is the decision on a drifting mutation that is neither frozen nor covered by
+// a break-glass token.
+type Verdict struct {
+	// Allowed is true if the drift is admitted.
+	Allowed bool
+	// Message explains why the drift is denied, or would be in enforce mode. Empty if
+	// approved.
+	Message string
+	// Warning is the response warning of an allowed drift with a message.
+	Warning string
+}
+
+// DriftInput is what a drifting mutation is decided on.
+type DriftInput struct {
+	// Result is the drift classification of the mutation.
+	Result *drift.DriftResult
+	// Approval is the approval check of the mutation.
+	Approval approval.CheckResult
+	// Mode is the resolved drift detection mode.
+	Mode string
+	// DenyClearedFields denies drift clearing user-set fields in every mode but warn,
+	// see config.Config.DeniesClearedFields.
+	DenyClearedFields bool
+	// Synthetic marks an injected synthetic drift in the message.
+	Synthetic bool
+	// Notes are appended to the message of an unapproved drift, e.g. describing spec
+	// changes by other mutators in the admission chain.
+	Notes []string
+	// Render rewrites the message of a rejected or unapproved drift, see
+	// DenyMessageRenderer. Nil keeps it.
+	Render func(msg string) string
+}
+
+// DenyMessageRenderer returns a DriftInput.Render rendering cfg's DenyMessageTemplate
+// for a drift of child by actor, detected as result.
+func DenyMessageRenderer(cfg *config.Config, result *drift.DriftResult, child client.Object, actor string) func(string) string {
+	return func(msg string) string {
+		data := config.DenyMessageData{
+			ChildKind: child.GetObjectKind().GroupVersionKind().Kind,
+			ChildName: child.GetName(),
+			Namespace: child.GetNamespace(),
+			Actor:     actor,
+			Reason:    msg,
+		}
+		if result != nil && result.ParentRef != nil {
+			data.ParentKind = result.ParentRef.Kind
+			data.ParentName = result.ParentRef.Name
+		}
+		return cfg.RenderDenyMessage(data)
+	}
+}
+
+// Drift decides a drifting mutation from its approval check and the resolved mode.
+// Rejections deny in enforce mode, approvals always allow, and unapproved drift is
+// denied in enforce mode and otherwise admitted with a warning.
+func Drift(in DriftInput) Verdict {
+	enforce := in.Mode == string(kausalityv1alpha1.ModeEnforce)
+	switch {
+	case in.Approval.Rejected:
+		return newVerdict(!enforce, in.Mode, in.render(RejectionMessage(in.Approval)))
+	case in.Approval.Approved:
+		return Verdict{Allowed: true}
+	}
+
+	msg := "drift detected: no approval found for this mutation"
+	if in.Synthetic {
+		msg = "synthetic " + msg + " (dry-run, nothing persisted)"
+	}
+	if len(in.Result.OwnershipConflicts) > 0 {
+		msg += "; field ownership taken over: " + drift.DescribeOwnershipConflicts(in.Result.OwnershipConflicts)
+	}
+	if len(in.Result.ClearedFields) > 0 {
+		msg += "; " + drift.DescribeClearedFields(in.Result.ClearedFields)
+	}
+	if in.Result.Recreated {
+		msg += "; controller deleted and recreated the child"
+	}
+	for _, note := range in.Notes {
+		msg += "; " + note
+	}
+	// Warn mode admits everything enforce mode would deny, including cleared fields
+	deny := enforce || (len(in.Result.ClearedFields) > 0 && in.DenyClearedFields && in.Mode != string(kausalityv1alpha1.ModeWarn))
+	return newVerdict(!deny, in.Mode, in.render(msg))
+}
+
+// render applies Render to msg, if set.
+func (in DriftInput) render(msg string) string {
+	if in.Render == nil {
+		return msg
+	}
+	return in.Render(msg)
+}
+
+// RejectionMessage describes a rejected drift, with the severity and remediation of the
+// matched rejection, e.g. "drift rejected (critical): frozen for audit; remediation: ask
+// #platform for an approval".
+func RejectionMessage(result approval.CheckResult) string {
+	msg := "drift rejected"
+	r := result.MatchedRejection
+	if r != nil && r.Severity != "" {
+		msg += " (" + r.Severity + ")"
+	}
+	msg += ": " + result.Reason
+	if r != nil && r.Remediation != "" {
+		msg += "; remediation: " + r.Remediation
+	}
+	return msg
+}
+
+// newVerdict returns the verdict with the warning an allowed drift gets in mode.
+func newVerdict(allowed bool, mode, msg string) Verdict {
+	verdict := Verdict{Allowed: allowed, Message: msg}
+	if !allowed {
+		return verdict
+	}
+	switch kausalityv1alpha1.Mode(mode) {
+	case kausalityv1alpha1.ModeWarn:
+		verdict.Warning = fmt.Sprintf("[kausality] %s (not blocked in warn mode)", msg)
+	case kausalityv1alpha1.ModeDryRun:
+		verdict.Warning = fmt.Sprintf("[kausality] [dry-run] %s (would be blocked in enforce mode)", msg)
+	default:
+		verdict.Warning = fmt.Sprintf("[kausality] %s (would be blocked in enforce mode)", msg)
+	}
+	return verdict
+}
@@ -0,0 +1,269 @@
+package drift
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kausalityv1alpha1 "github.com/kausality-io/kausality/api/v1alpha1"
+	"github.com/kausality-io/kausality/pkg/approval"
+	"github.com/kausality-io/kausality/pkg/config"
+	"github.com/kausality-io/kausality/pkg/controller"
+)
+
+// DefaultAuditPageSize is the number of objects fetched per list call during an audit.
+const DefaultAuditPageSize int64 = 500
+
+// AuditConfig configures a namespace audit.
+type AuditConfig struct {
+	// Kinds are the child kinds to list.
+	Kinds []schema.GroupVersionKind
+	// DriftConfig resolves the effective mode of each child. If nil, all children are
+	// reported in log mode.
+	DriftConfig *config.Config
+	// PageSize is the number of objects per list call. Defaults to DefaultAuditPageSize.
+	PageSize int64
+	// LifecycleDetector detects the parent lifecycle phase. Defaults to NewLifecycleDetector().
+	LifecycleDetector *LifecycleDetector
+}
+
+// AuditEntry is the assessment of a single child.
+type AuditEntry struct {
+	// APIVersion of the child.
+	APIVersion string `json:"apiVersion"`
+	// Kind of the child.
+	Kind string `json:"kind"`
+	// Namespace of the child.
+	Namespace string `json:"namespace"`
+	// Name of the child.
+	Name string `json:"name"`
+	// Parent is the controller parent, nil if the child has none.
+	Parent *ParentRef `json:"parent,omitempty"`
+	// LifecyclePhase is the parent's lifecycle phase.
+	LifecyclePhase LifecyclePhase `json:"lifecyclePhase,omitempty"`
+	// Mode is the effective drift detection mode of the child.
+	Mode string `json:"mode,omitempty"`
+	// DriftProne indicates that a controller update of the child would be drift right now:
+	// the parent is initialized and stable, and the child's controller is known.
+	DriftProne bool `json:"driftProne"`
+	// Approved indicates that an approval on the parent currently covers the child.
+	Approved bool `json:"approved,omitempty"`
+	// Rejected indicates that a rejection on the parent currently covers the child.
+	Rejected bool `json:"rejected,omitempty"`
+	// Reason explains the assessment.
+	Reason string `json:"reason"`
+}
+
+// Governed returns true if the child has a controller parent, i.e. is subject to drift detection.
+func (e AuditEntry) Governed() bool {
+	return e.Parent != nil
+}
+
+// AuditSummary is the result of a namespace audit.
+type AuditSummary struct {
+	// Namespace is the audited namespace.
+	Namespace string `json:"namespace"`
+	// Governed is the number of children with a controller parent.
+	Governed int `json:"governed"`
+	// DriftProne is the number of children in a drift-prone state.
+	DriftProne int `json:"driftProne"`
+	// Approved is the number of children covered by an active approval.
+	Approved int `json:"approved"`
+	// Entries holds one entry per listed object.
+	Entries []AuditEntry `json:"entries"`
+}
+
+// AuditNamespace lists the configured kinds in a namespace, resolves the parent of each
+// object and reports which objects are currently in a drift-prone state. It is read-only
+// and meant as a risk assessment before adopting kausality. Parents are fetched once per run.
+func AuditNamespace(ctx context.Context, c client.Client, namespace string, cfg AuditConfig) (*AuditSummary, error) {
+	a := &auditor{
+		client:  c,
+		cfg:     cfg,
+		parents: map[string]*unstructured.Unstructured{},
+	}
+	if a.cfg.PageSize <= 0 {
+		a.cfg.PageSize = DefaultAuditPageSize
+	}
+	if a.cfg.LifecycleDetector == nil {
+		a.cfg.LifecycleDetector = NewLifecycleDetector()
+	}
+
+	// Namespace metadata only refines mode resolution, so audit without it if unreadable
+	ns := &corev1.Namespace{}
+	if err := c.Get(ctx, client.ObjectKey{Name: namespace}, ns); err == nil {
+		a.nsLabels, a.nsAnnotations = ns.Labels, ns.Annotations
+	}
+
+	summary := &AuditSummary{Namespace: namespace}
+	for _, gvk := range cfg.Kinds {
+		err := a.list(ctx, gvk, namespace, func(obj *unstructured.Unstructured) error {
+			entry, err := a.assess(ctx, obj)
+			if err != nil {
+				return err
+			}
+			if entry.Governed() {
+				summary.Governed++
+			}
+			if entry.DriftProne {
+				summary.DriftProne++
+			}
+			if entry.Approved {
+				summary.Approved++
+			}
+			summary.Entries = append(summary.Entries, entry)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return summary, nil
+}
+
+// auditor holds the state of a single audit run.
+type auditor struct {
+	client        client.Client
+	cfg           AuditConfig
+	nsLabels      map[string]string
+	nsAnnotations map[string]string
+	// parents caches fetched parents by GVK and name; nil marks a parent that was not found.
+	parents map[string]*unstructured.Unstructured
+}
+
+// list pages through all objects of the given kind in the namespace.
+func (a *auditor) list(ctx context.Context, gvk schema.GroupVersionKind, namespace string, fn func(*unstructured.Unstructured) error) error {
+	cont := ""
+	for {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		opts := []client.ListOption{client.InNamespace(namespace), client.Limit(a.cfg.PageSize)}
+		if cont != "" {
+			opts = append(opts, client.Continue(cont))
+		}
+		if err := a.client.List(ctx, list, opts...); err != nil {
+			return fmt.Errorf("failed to list %s in namespace %s: %w", gvk.Kind, namespace, err)
+		}
+		for i := range list.Items {
+			obj := &list.Items[i]
+			obj.SetGroupVersionKind(gvk)
+			if err := fn(obj); err != nil {
+				return err
+			}
+		}
+		cont = list.GetContinue()
+		if cont == "" {
+			return nil
+		}
+	}
+}
+
+// assess evaluates a single child.
+func (a *auditor) assess(ctx context.Context, obj *unstructured.Unstructured) (AuditEntry, error) {
+	gvk := obj.GroupVersionKind()
+	entry := AuditEntry{
+		APIVersion: gvk.GroupVersion().String(),
+		Kind:       gvk.Kind,
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+	}
+
+	var refs []config.ParentReference
+	keys := kausalityv1alpha1.DefaultAnnotationKeys
+	if a.cfg.DriftConfig != nil {
+		refs = a.cfg.DriftConfig.DriftDetection.ParentReferences
+		keys = a.cfg.DriftConfig.AnnotationKeys()
+	}
+	ownerRef := ParentOwnerRef(obj, refs, keys)
+	if ownerRef == nil {
+		entry.Reason = "no controller owner reference"
+		return entry, nil
+	}
+	ref := ParentRefFromOwnerRef(*ownerRef, obj.GetNamespace())
+	entry.Parent = &ref
+
+	parent, err := a.parent(ctx, ref)
+	if err != nil {
+		return entry, err
+	}
+	if parent == nil {
+		entry.Reason = "parent not found"
+		return entry, nil
+	}
+	state := extractParentStateWithKeys(parent, *ownerRef, keys)
+
+	entry.LifecyclePhase = a.cfg.LifecycleDetector.DetectPhase(state)
+	entry.Mode = config.ModeLog
+	if a.cfg.DriftConfig != nil {
+		entry.Mode = a.cfg.DriftConfig.ResolveModeWithAnnotations(obj.GetAnnotations(), a.nsAnnotations, config.ResourceContext{
+			GVK:             gvk,
+			Namespace:       obj.GetNamespace(),
+			ObjectLabels:    obj.GetLabels(),
+			NamespaceLabels: a.nsLabels,
+		})
+	}
+
+	check := approval.CheckFromAnnotations(
+		parent.GetAnnotations()[keys.Approvals],
+		parent.GetAnnotations()[keys.Rejections],
+		approval.ChildRef{APIVersion: entry.APIVersion, Kind: entry.Kind, Name: entry.Name, Labels: obj.GetLabels()},
+		state.Generation,
+	)
+	entry.Approved, entry.Rejected = check.Approved, check.Rejected
+
+	switch {
+	case entry.LifecyclePhase != PhaseInitialized:
+		entry.Reason = fmt.Sprintf("parent is %s", entry.LifecyclePhase)
+	case state.Generation != state.ObservedGeneration:
+		entry.Reason = fmt.Sprintf("parent is reconciling: generation (%d) != observedGeneration (%d)",
+			state.Generation, state.ObservedGeneration)
+	case !controllerKnown(state, ParseUpdaterHashesWithKeys(obj, keys)):
+		entry.Reason = "controller identity unknown"
+	default:
+		entry.DriftProne = true
+		entry.Reason = fmt.Sprintf("parent is stable: generation (%d) == observedGeneration (%d)",
+			state.Generation, state.ObservedGeneration)
+	}
+	return entry, nil
+}
+
+// parent fetches the parent, returning nil if it does not exist.
+func (a *auditor) parent(ctx context.Context, ref ParentRef) (*unstructured.Unstructured, error) {
+	key := ref.String()
+	if p, ok := a.parents[key]; ok {
+		return p, nil
+	}
+
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid API version %q: %w", ref.APIVersion, err)
+	}
+	p := &unstructured.Unstructured{}
+	p.SetGroupVersionKind(gv.WithKind(ref.Kind))
+	if err := a.client.Get(ctx, client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, p); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get parent %s: %w", ref.String(), err)
+		}
+		p = nil
+	}
+	a.parents[key] = p
+	return p, nil
+}
+
+// controllerKnown returns true if the child's controller can be identified from its
+// updaters, mirroring IsControllerByHash.
+func controllerKnown(state *ParentState, childUpdaters []string) bool {
+	switch {
+	case len(childUpdaters) == 1:
+		return true
+	case len(childUpdaters) > 1:
+		return len(controller.Intersect(childUpdaters, state.Controllers)) > 0
+	default:
+		return false
+	}
+}
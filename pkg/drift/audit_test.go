@@ -0,0 +1,149 @@
+package drift
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/kausality-io/kausality/api/v1alpha1"
+	"github.com/kausality-io/kausality/pkg/config"
+	"github.com/kausality-io/kausality/pkg/controller"
+)
+
+func auditParent(name string, generation, observedGeneration int64, annotations map[string]string) *appsv1.Deployment {
+	anns := map[string]string{controller.PhaseAnnotation: controller.PhaseValueInitialized}
+	for k, v := range annotations {
+		anns[k] = v
+	}
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Generation: generation, UID: types.UID("uid-" + name), Annotations: anns},
+		Status:     appsv1.DeploymentStatus{ObservedGeneration: observedGeneration},
+	}
+}
+
+func auditChild(name, parent string, updaters ...string) *corev1.ConfigMap {
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+	if parent != "" {
+		cm.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion: "apps/v1", Kind: "Deployment", Name: parent, UID: types.UID("uid-" + parent), Controller: ptr.To(true),
+		}}
+	}
+	if len(updaters) > 0 {
+		hashes := ""
+		for i, u := range updaters {
+			if i > 0 {
+				hashes += ","
+			}
+			hashes += controller.HashUsername(u)
+		}
+		cm.Annotations = map[string]string{controller.UpdatersAnnotation: hashes}
+	}
+	return cm
+}
+
+func TestAuditNamespace(t *testing.T) {
+	approvals := `[{"apiVersion":"v1","kind":"ConfigMap","name":"approved","mode":"always"}]`
+	c := fake.NewClientBuilder().WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default", Annotations: map[string]string{config.ModeAnnotation: config.ModeEnforce}}},
+		auditParent("stable", 1, 1, map[string]string{v1alpha1.ApprovalsAnnotation: approvals}),
+		auditParent("reconciling", 2, 1, nil),
+		auditChild("drift-prone", "stable", "controller"),
+		auditChild("approved", "stable", "controller"),
+		auditChild("unknown-controller", "stable", "controller", "user"),
+		auditChild("no-updaters", "stable"),
+		auditChild("reconciling", "reconciling", "controller"),
+		auditChild("orphan", ""),
+		auditChild("missing-parent", "gone", "controller"),
+	).Build()
+
+	summary, err := AuditNamespace(t.Context(), c, "default", AuditConfig{
+		Kinds:       []schema.GroupVersionKind{{Version: "v1", Kind: "ConfigMap"}},
+		DriftConfig: &config.Config{DriftDetection: config.DriftDetectionConfig{DefaultMode: config.ModeLog}},
+	})
+	require.NoError(t, err)
+
+	entries := map[string]AuditEntry{}
+	for _, e := range summary.Entries {
+		entries[e.Name] = e
+	}
+	require.Len(t, entries, 7)
+
+	assert.True(t, entries["drift-prone"].DriftProne)
+	assert.Equal(t, config.ModeEnforce, entries["drift-prone"].Mode, "namespace annotation should apply")
+	assert.Equal(t, "apps/v1/Deployment:default/stable", entries["drift-prone"].Parent.String())
+
+	assert.True(t, entries["approved"].DriftProne)
+	assert.True(t, entries["approved"].Approved)
+	assert.False(t, entries["drift-prone"].Approved)
+
+	assert.False(t, entries["unknown-controller"].DriftProne)
+	assert.Equal(t, "controller identity unknown", entries["unknown-controller"].Reason)
+	assert.False(t, entries["no-updaters"].DriftProne)
+	assert.False(t, entries["reconciling"].DriftProne)
+	assert.Contains(t, entries["reconciling"].Reason, "parent is reconciling")
+
+	assert.False(t, entries["orphan"].Governed())
+	assert.Equal(t, "no controller owner reference", entries["orphan"].Reason)
+	assert.True(t, entries["missing-parent"].Governed())
+	assert.Equal(t, "parent not found", entries["missing-parent"].Reason)
+
+	assert.Equal(t, 6, summary.Governed)
+	assert.Equal(t, 2, summary.DriftProne)
+	assert.Equal(t, 1, summary.Approved)
+}
+
+func TestAuditNamespace_Paginates(t *testing.T) {
+	var objs []client.Object
+	objs = append(objs, auditParent("stable", 1, 1, nil))
+	for i := range 5 {
+		objs = append(objs, auditChild("child-"+strconv.Itoa(i), "stable", "controller"))
+	}
+
+	// Page the fake client's results by hand to observe the list calls
+	var limits []int64
+	c := fake.NewClientBuilder().WithObjects(objs...).WithInterceptorFuncs(interceptor.Funcs{
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			lo := (&client.ListOptions{}).ApplyOptions(opts)
+			if err := c.List(ctx, list, client.InNamespace(lo.Namespace)); err != nil {
+				return err
+			}
+			limits = append(limits, lo.Limit)
+			start := 0
+			if lo.Continue != "" {
+				start, _ = strconv.Atoi(lo.Continue)
+			}
+			ul := list.(*unstructured.UnstructuredList)
+			total := len(ul.Items)
+			end := min(start+int(lo.Limit), total)
+			ul.Items = ul.Items[start:end]
+			if end < total {
+				ul.SetContinue(strconv.Itoa(end))
+			}
+			return nil
+		},
+	}).Build()
+
+	summary, err := AuditNamespace(t.Context(), c, "default", AuditConfig{
+		Kinds:    []schema.GroupVersionKind{{Version: "v1", Kind: "ConfigMap"}},
+		PageSize: 2,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{2, 2, 2}, limits)
+	assert.Len(t, summary.Entries, 5)
+	assert.Equal(t, 5, summary.DriftProne)
+	assert.Equal(t, config.ModeLog, summary.Entries[0].Mode)
+}
@@ -0,0 +1,69 @@
+package drift
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ChainMutation describes spec fields that a field manager other than the request's
+// gained during the request, i.e. a mutator in the admission chain changed them.
+type ChainMutation struct {
+	// Manager is the field manager of the mutator, e.g. a mutating webhook.
+	Manager string
+	// Fields are the spec fields the manager gained.
+	Fields []string
+}
+
+// String returns e.g. "sidecar-injector (spec.containers[name=proxy])".
+func (m ChainMutation) String() string {
+	return fmt.Sprintf("%s (%s)", m.Manager, strings.Join(m.Fields, ", "))
+}
+
+// ChainMutations compares the managedFields of the old and new object (old is nil for
+// CREATE) and returns the managers other than manager that own spec fields after the
+// request they did not own before. The request itself only changes its own manager's
+// entry, so such entries were written by another mutator in the chain.
+// Returns nil if manager is unknown, as the request's own entry cannot be told apart.
+func ChainMutations(oldFields, newFields []metav1.ManagedFieldsEntry, manager string) []ChainMutation {
+	if manager == "" {
+		return nil
+	}
+	before := specOwners(oldFields)
+	after := specOwners(newFields)
+
+	gained := map[string][]string{}
+	for field, owners := range after {
+		for owner := range owners {
+			if owner == manager || owner == "" {
+				continue
+			}
+			if _, ok := before[field][owner]; ok {
+				continue
+			}
+			gained[owner] = append(gained[owner], field)
+		}
+	}
+
+	mutations := make([]ChainMutation, 0, len(gained))
+	for owner, fields := range gained {
+		sort.Strings(fields)
+		mutations = append(mutations, ChainMutation{Manager: owner, Fields: fields})
+	}
+	sort.Slice(mutations, func(i, j int) bool { return mutations[i].Manager < mutations[j].Manager })
+	if len(mutations) == 0 {
+		return nil
+	}
+	return mutations
+}
+
+// DescribeChainMutations renders mutations as "m1 (spec.a), m2 (spec.b, spec.c)".
+func DescribeChainMutations(mutations []ChainMutation) string {
+	parts := make([]string, 0, len(mutations))
+	for _, m := range mutations {
+		parts = append(parts, m.String())
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,67 @@
+package drift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestChainMutations(t *testing.T) {
+	const (
+		replicas = `{"f:spec":{"f:replicas":{}}}`
+		proxy    = `{"f:spec":{"f:containers":{"k:{\"name\":\"proxy\"}":{".":{},"f:image":{}}}}}`
+	)
+	updateEntry := func(manager, fields string) metav1.ManagedFieldsEntry {
+		e := applyEntry(manager, fields)
+		e.Operation = metav1.ManagedFieldsOperationUpdate
+		return e
+	}
+
+	tests := []struct {
+		name     string
+		old, new []metav1.ManagedFieldsEntry
+		manager  string
+		want     []ChainMutation
+	}{
+		{
+			name:    "webhook adds fields on update",
+			old:     []metav1.ManagedFieldsEntry{updateEntry("operator", replicas)},
+			new:     []metav1.ManagedFieldsEntry{updateEntry("operator", replicas), updateEntry("sidecar-injector", proxy)},
+			manager: "operator",
+			want: []ChainMutation{{Manager: "sidecar-injector", Fields: []string{
+				"spec.containers[name=proxy]",
+				"spec.containers[name=proxy].image",
+			}}},
+		},
+		{
+			name:    "webhook adds fields on create",
+			new:     []metav1.ManagedFieldsEntry{updateEntry("operator", replicas), updateEntry("defaulter", `{"f:spec":{"f:paused":{}}}`)},
+			manager: "operator",
+			want:    []ChainMutation{{Manager: "defaulter", Fields: []string{"spec.paused"}}},
+		},
+		{
+			name:    "unchanged entries of other managers",
+			old:     []metav1.ManagedFieldsEntry{updateEntry("operator", replicas), updateEntry("sidecar-injector", proxy)},
+			new:     []metav1.ManagedFieldsEntry{updateEntry("operator", replicas), updateEntry("sidecar-injector", proxy)},
+			manager: "operator",
+		},
+		{
+			name:    "request manager gaining fields",
+			old:     []metav1.ManagedFieldsEntry{updateEntry("operator", replicas)},
+			new:     []metav1.ManagedFieldsEntry{updateEntry("operator", `{"f:spec":{"f:replicas":{},"f:paused":{}}}`)},
+			manager: "operator",
+		},
+		{
+			name: "unknown request manager",
+			new:  []metav1.ManagedFieldsEntry{updateEntry("sidecar-injector", proxy)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ChainMutations(tt.old, tt.new, tt.manager))
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package drift
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Classifier classifies the lifecycle phase of parents, replacing the
+// generation/observedGeneration heuristic for the kinds it is registered for, e.g. a
+// CRD that signals reconciling by status.phase rather than by observedGeneration.
+//
+// PhaseInitializing and PhaseReconciling allow all changes of children. Any other phase
+// counts as PhaseInitialized: the parent is stable, and changes by its controller are
+// drift whatever its observedGeneration. Parents being deleted are PhaseDeleting, and
+// parents with spec.paused PhasePaused, without consulting the classifier.
+// ParentState.Status holds the parent's raw status.
+type Classifier interface {
+	Classify(state *ParentState) LifecyclePhase
+}
+
+// ClassifierFunc adapts a function to a Classifier.
+type ClassifierFunc func(state *ParentState) LifecyclePhase
+
+// Classify calls f.
+func (f ClassifierFunc) Classify(state *ParentState) LifecyclePhase {
+	return f(state)
+}
+
+// Classify implements Classifier with DetectPhase. The lifecycle detector classifies
+// parents of all kinds without a registered classifier.
+func (d *LifecycleDetector) Classify(state *ParentState) LifecyclePhase {
+	return d.DetectPhase(state)
+}
+
+// RegisterClassifier registers c for parents of gk in all versions, replacing any
+// classifier registered for gk before. It must not be called concurrently with Detect.
+func (d *Detector) RegisterClassifier(gk schema.GroupKind, c Classifier) {
+	if d.classifiers == nil {
+		d.classifiers = map[schema.GroupKind]Classifier{}
+	}
+	d.classifiers[gk] = c
+}
+
+// classifier returns the classifier registered for the parent of state, or nil.
+func (d *Detector) classifier(state *ParentState) Classifier {
+	if len(d.classifiers) == 0 {
+		return nil
+	}
+	gv, err := schema.ParseGroupVersion(state.Ref.APIVersion)
+	if err != nil {
+		return nil
+	}
+	return d.classifiers[gv.WithKind(state.Ref.Kind).GroupKind()]
+}
@@ -0,0 +1,111 @@
+package drift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kausality-io/kausality/pkg/controller"
+)
+
+func TestDetect_Classifier(t *testing.T) {
+	// rollout is a CRD that signals reconciling by status.phase and has no observedGeneration
+	rollout := func(name, phase string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{Object: map[string]interface{}{
+			"status": map[string]interface{}{"phase": phase},
+		}}
+		u.SetAPIVersion("example.com/v1")
+		u.SetKind("Rollout")
+		u.SetNamespace("default")
+		u.SetName(name)
+		u.SetUID(types.UID("uid-" + name))
+		u.SetGeneration(3)
+		return u
+	}
+	child := func(parent string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion("example.com/v1")
+		u.SetKind("Widget")
+		u.SetNamespace("default")
+		u.SetName("child-of-" + parent)
+		u.SetOwnerReferences([]metav1.OwnerReference{{
+			APIVersion: "example.com/v1", Kind: "Rollout", Name: parent, UID: types.UID("uid-" + parent), Controller: ptr.To(true),
+		}})
+		return u
+	}
+	progressing := ClassifierFunc(func(state *ParentState) LifecyclePhase {
+		if phase, _, _ := unstructured.NestedString(state.Status, "phase"); phase == "Progressing" {
+			return PhaseReconciling
+		}
+		return PhaseInitialized
+	})
+	c := fake.NewClientBuilder().WithObjects(rollout("progressing", "Progressing"), rollout("available", "Available")).Build()
+	updaters := []string{controller.HashUsername("rollout-controller")}
+
+	tests := []struct {
+		name       string
+		classify   bool
+		parent     string
+		wantPhase  LifecyclePhase
+		wantDrifts bool
+	}{
+		{
+			name:      "default classifier sees an initializing parent without observedGeneration",
+			parent:    "available",
+			wantPhase: PhaseInitializing,
+		},
+		{
+			name:      "custom classifier: progressing parent is reconciling",
+			classify:  true,
+			parent:    "progressing",
+			wantPhase: PhaseReconciling,
+		},
+		{
+			name:       "custom classifier: available parent is stable",
+			classify:   true,
+			parent:     "available",
+			wantPhase:  PhaseInitialized,
+			wantDrifts: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts []DetectorOption
+			if tt.classify {
+				opts = append(opts, WithClassifier(schema.GroupKind{Group: "example.com", Kind: "Rollout"}, progressing))
+			}
+			d := NewDetectorWithOptions(c, opts...)
+
+			result, err := d.Detect(t.Context(), child(tt.parent), "rollout-controller", updaters)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantPhase, result.LifecyclePhase)
+			assert.Equal(t, tt.wantDrifts, result.DriftDetected, result.Reason)
+		})
+	}
+}
+
+func TestDetector_RegisterClassifier(t *testing.T) {
+	d := NewDetector(nil)
+	rollouts := schema.GroupKind{Group: "example.com", Kind: "Rollout"}
+	state := &ParentState{Ref: ParentRef{APIVersion: "example.com/v1", Kind: "Rollout"}}
+	assert.Nil(t, d.classifier(state))
+
+	d.RegisterClassifier(rollouts, ClassifierFunc(func(*ParentState) LifecyclePhase { return PhaseInitializing }))
+	require.NotNil(t, d.classifier(state))
+	assert.Equal(t, PhaseInitializing, d.classifier(state).Classify(state))
+
+	// All versions share the classifier, other kinds use the default
+	assert.NotNil(t, d.classifier(&ParentState{Ref: ParentRef{APIVersion: "example.com/v2", Kind: "Rollout"}}))
+	assert.Nil(t, d.classifier(&ParentState{Ref: ParentRef{APIVersion: "apps/v1", Kind: "Deployment"}}))
+
+	// The lifecycle detector is the default classifier
+	var _ Classifier = NewLifecycleDetector()
+}
@@ -0,0 +1,113 @@
+package drift
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClearedFields returns the spec fields that are set in oldSpec and removed or reset to
+// their zero value ("", 0, false, null, empty list or map) in newSpec, e.g. "spec.replicas".
+// Nested maps are walked; lists are compared as a whole.
+//
+// With managedFields on the old object, only fields owned by a field manager other than
+// manager count, i.e. fields someone else explicitly set. Without managedFields, every
+// cleared field counts.
+func ClearedFields(oldSpec, newSpec map[string]interface{}, oldFields []metav1.ManagedFieldsEntry, manager string) []string {
+	var cleared []string
+	collectCleared("spec", oldSpec, newSpec, &cleared)
+	if len(cleared) == 0 {
+		return nil
+	}
+
+	owners := specOwners(oldFields)
+	if len(owners) == 0 {
+		sort.Strings(cleared)
+		return cleared
+	}
+	var userSet []string
+	for _, field := range cleared {
+		if ownedByOthers(owners, field, manager) {
+			userSet = append(userSet, field)
+		}
+	}
+	sort.Strings(userSet)
+	return userSet
+}
+
+// collectCleared appends the paths of values set in old and cleared in new.
+func collectCleared(path string, old, new map[string]interface{}, cleared *[]string) {
+	for key, oldValue := range old {
+		if isZeroValue(oldValue) {
+			continue
+		}
+		fieldPath := path + "." + key
+		newValue, ok := new[key]
+		if !ok || isZeroValue(newValue) {
+			*cleared = append(*cleared, fieldPath)
+			continue
+		}
+		oldMap, oldIsMap := oldValue.(map[string]interface{})
+		newMap, newIsMap := newValue.(map[string]interface{})
+		if oldIsMap && newIsMap {
+			collectCleared(fieldPath, oldMap, newMap, cleared)
+		}
+	}
+}
+
+// isZeroValue returns true for unset-equivalent JSON values.
+func isZeroValue(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case bool:
+		return !v
+	case int64:
+		return v == 0
+	case float64:
+		return v == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+// ownedByOthers returns true if a manager other than manager owns field or a field below it.
+func ownedByOthers(owners map[string]map[string]struct{}, field, manager string) bool {
+	for path, managers := range owners {
+		if path != field && !strings.HasPrefix(path, field+".") && !strings.HasPrefix(path, field+"[") {
+			continue
+		}
+		for m := range managers {
+			if m != manager {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ClassifyClearedFields records fields a controller cleared on a drift result and names
+// them in the reason. It does nothing unless drift was detected.
+func ClassifyClearedFields(result *DriftResult, cleared []string) {
+	if result == nil || !result.DriftDetected || len(cleared) == 0 {
+		return
+	}
+	result.ClearedFields = cleared
+	result.Reason = fmt.Sprintf("%s; %s", result.Reason, DescribeClearedFields(cleared))
+}
+
+// DescribeClearedFields renders e.g. "controller cleared user-set field spec.a, spec.b".
+func DescribeClearedFields(cleared []string) string {
+	noun := "field"
+	if len(cleared) > 1 {
+		noun = "fields"
+	}
+	return fmt.Sprintf("controller cleared user-set %s %s", noun, strings.Join(cleared, ", "))
+}
@@ -0,0 +1,83 @@
+package drift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestClearedFields(t *testing.T) {
+	oldSpec := map[string]interface{}{
+		"replicas": int64(3),
+		"paused":   true,
+		"template": map[string]interface{}{"image": "app:v1", "command": []interface{}{"run"}},
+		"color":    "",
+	}
+
+	tests := []struct {
+		name    string
+		newSpec map[string]interface{}
+		fields  []metav1.ManagedFieldsEntry
+		manager string
+		want    []string
+	}{
+		{
+			name:    "nothing cleared",
+			newSpec: map[string]interface{}{"replicas": int64(5), "paused": true, "template": map[string]interface{}{"image": "app:v2", "command": []interface{}{"run"}}},
+			want:    nil,
+		},
+		{
+			name:    "removed and zeroed fields without managedFields",
+			newSpec: map[string]interface{}{"replicas": int64(0), "template": map[string]interface{}{"image": "app:v1", "command": []interface{}{}}},
+			want:    []string{"spec.paused", "spec.replicas", "spec.template.command"},
+		},
+		{
+			name:    "whole nested map removed",
+			newSpec: map[string]interface{}{"replicas": int64(3), "paused": true},
+			want:    []string{"spec.template"},
+		},
+		{
+			name:    "only fields owned by other managers count",
+			newSpec: map[string]interface{}{"template": map[string]interface{}{"image": "", "command": []interface{}{"run"}}},
+			fields: []metav1.ManagedFieldsEntry{
+				applyEntry("kubectl", `{"f:spec":{"f:replicas":{}}}`),
+				applyEntry("operator", `{"f:spec":{"f:paused":{},"f:template":{"f:image":{},"f:command":{}}}}`),
+			},
+			manager: "operator",
+			want:    []string{"spec.replicas"},
+		},
+		{
+			name:    "list owned item-wise by another manager",
+			newSpec: map[string]interface{}{"replicas": int64(3), "paused": true, "template": map[string]interface{}{"image": "app:v1"}},
+			fields: []metav1.ManagedFieldsEntry{
+				applyEntry("operator", `{"f:spec":{"f:replicas":{},"f:paused":{},"f:template":{"f:image":{}}}}`),
+				applyEntry("kubectl", `{"f:spec":{"f:template":{"f:command":{"v:\"run\"":{}}}}}`),
+			},
+			manager: "operator",
+			want:    []string{"spec.template.command"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ClearedFields(oldSpec, tt.newSpec, tt.fields, tt.manager))
+		})
+	}
+}
+
+func TestClassifyClearedFields(t *testing.T) {
+	result := &DriftResult{DriftDetected: true, Reason: "drift detected"}
+	ClassifyClearedFields(result, []string{"spec.replicas"})
+	assert.Equal(t, []string{"spec.replicas"}, result.ClearedFields)
+	assert.Equal(t, "drift detected; controller cleared user-set field spec.replicas", result.Reason)
+
+	// Not drift: a user or a reconciling controller may clear fields
+	result = &DriftResult{Reason: "change by different actor"}
+	ClassifyClearedFields(result, []string{"spec.replicas"})
+	assert.Nil(t, result.ClearedFields)
+	assert.Equal(t, "change by different actor", result.Reason)
+
+	assert.Equal(t, "controller cleared user-set fields spec.a, spec.b", DescribeClearedFields([]string{"spec.a", "spec.b"}))
+}
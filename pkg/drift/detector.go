@@ -4,8 +4,14 @@ import (
 	"context"
 	"fmt"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	kausalityv1alpha1 "github.com/kausality-io/kausality/api/v1alpha1"
+	"github.com/kausality-io/kausality/pkg/config"
 	"github.com/kausality-io/kausality/pkg/controller"
 )
 
@@ -13,6 +19,10 @@ import (
 type Detector struct {
 	resolver          *ParentResolver
 	lifecycleDetector *LifecycleDetector
+	classifiers       map[schema.GroupKind]Classifier
+	arrayMergeKeys    []config.ArrayMergeKey
+	ownerSelection    string
+	hash              controller.HashFunc
 }
 
 // NewDetector creates a new Detector.
@@ -20,6 +30,7 @@ func NewDetector(c client.Client) *Detector {
 	return &Detector{
 		resolver:          NewParentResolver(c),
 		lifecycleDetector: NewLifecycleDetector(),
+		hash:              controller.HashUsername,
 	}
 }
 
@@ -33,6 +44,60 @@ func WithLifecycleDetector(ld *LifecycleDetector) DetectorOption {
 	}
 }
 
+// WithClassifier registers a classifier for parents of gk, see RegisterClassifier.
+func WithClassifier(gk schema.GroupKind, c Classifier) DetectorOption {
+	return func(d *Detector) {
+		d.RegisterClassifier(gk, c)
+	}
+}
+
+// WithAnnotationKeys reads parent and child annotations of keys rather than the
+// kausality.io/ ones.
+func WithAnnotationKeys(keys kausalityv1alpha1.AnnotationKeys) DetectorOption {
+	return func(d *Detector) {
+		d.resolver.SetAnnotationKeys(keys)
+	}
+}
+
+// WithHashFunc compares user identifiers hashed with f rather than controller.HashUsername.
+func WithHashFunc(f controller.HashFunc) DetectorOption {
+	return func(d *Detector) {
+		d.hash = f
+	}
+}
+
+// WithParentReferences configures parent references for children without a controller
+// ownerReference.
+func WithParentReferences(refs []config.ParentReference) DetectorOption {
+	return func(d *Detector) {
+		d.resolver.SetParentReferences(refs)
+	}
+}
+
+// WithParentFetcher fetches parents with f, see ParentResolver.SetParentFetcher.
+func WithParentFetcher(f ParentFetcher) DetectorOption {
+	return func(d *Detector) {
+		d.resolver.SetParentFetcher(f)
+	}
+}
+
+// WithOwnerSelection configures which ownerReferences of a child are evaluated as its
+// parents, one of the config.OwnerSelection* values. With "allOwners", Detect reports
+// drift if it detects drift against any parent.
+func WithOwnerSelection(selection string) DetectorOption {
+	return func(d *Detector) {
+		d.ownerSelection = selection
+		d.resolver.SetOwnerSelection(selection)
+	}
+}
+
+// WithArrayMergeKeys configures the keyed arrays DetectDryRun compares as sets.
+func WithArrayMergeKeys(keys []config.ArrayMergeKey) DetectorOption {
+	return func(d *Detector) {
+		d.arrayMergeKeys = keys
+	}
+}
+
 // NewDetectorWithOptions creates a new Detector with options.
 func NewDetectorWithOptions(c client.Client, opts ...DetectorOption) *Detector {
 	d := NewDetector(c)
@@ -46,6 +111,9 @@ func NewDetectorWithOptions(c client.Client, opts ...DetectorOption) *Detector {
 // Returns (result, done) where done=true means caller should return result immediately.
 func (d *Detector) checkLifecycle(parentState *ParentState) (*DriftResult, bool) {
 	phase := d.lifecycleDetector.DetectPhase(parentState)
+	if c := d.classifier(parentState); c != nil && phase != PhaseDeleting && phase != PhasePaused {
+		phase = c.Classify(parentState)
+	}
 
 	result := &DriftResult{
 		ParentRef:      &parentState.Ref,
@@ -62,11 +130,32 @@ func (d *Detector) checkLifecycle(parentState *ParentState) (*DriftResult, bool)
 		result.Allowed = true
 		result.Reason = "parent is initializing"
 		return result, true
+	case PhasePaused:
+		result.Allowed = true
+		result.Reason = "parent is paused"
+		return result, true
+	case PhaseReconciling:
+		result.Allowed = true
+		result.Reason = "parent is reconciling: status updated within the stabilization grace period"
+		return result, true
 	}
 
 	return result, false
 }
 
+// checkStable checks a change by the controller of an initialized parent for drift.
+// Parents with a classifier are stable once initialized; the others are checked by
+// checkGeneration.
+func (d *Detector) checkStable(result *DriftResult, parentState *ParentState) *DriftResult {
+	if d.classifier(parentState) == nil {
+		return checkGeneration(result, parentState)
+	}
+	result.Allowed = true
+	result.DriftDetected = true
+	result.Reason = fmt.Sprintf("drift detected: parent classified as %s", result.LifecyclePhase)
+	return result
+}
+
 // checkGeneration checks generation vs observedGeneration for drift.
 // Must be called when request is from the controller.
 func checkGeneration(result *DriftResult, parentState *ParentState) *DriftResult {
@@ -90,6 +179,10 @@ func checkGeneration(result *DriftResult, parentState *ParentState) *DriftResult
 // It uses user hash tracking to identify if the request comes from the controller.
 // childUpdaters contains the current updater hashes from the child's annotation (before this update).
 func (d *Detector) Detect(ctx context.Context, obj client.Object, username string, childUpdaters []string) (*DriftResult, error) {
+	if d.ownerSelection == config.OwnerSelectionAllOwners {
+		return d.detectAllOwners(ctx, obj, username, childUpdaters), nil
+	}
+
 	parentState, err := d.resolver.ResolveParent(ctx, obj)
 	if err != nil {
 		return &DriftResult{Allowed: false, Reason: fmt.Sprintf("failed to resolve parent: %v", err)}, nil
@@ -97,40 +190,140 @@ func (d *Detector) Detect(ctx context.Context, obj client.Object, username strin
 	if parentState == nil {
 		return &DriftResult{Allowed: true, Reason: "no controller owner reference"}, nil
 	}
+	return d.evaluate(parentState, username, childUpdaters), nil
+}
+
+// detectAllOwners evaluates the change against every parent. The result of the first
+// parent against which drift is detected decides, otherwise the first parent's.
+func (d *Detector) detectAllOwners(ctx context.Context, obj client.Object, username string, childUpdaters []string) *DriftResult {
+	parents, err := d.resolver.ResolveParents(ctx, obj)
+	if err != nil {
+		return &DriftResult{Allowed: false, Reason: fmt.Sprintf("failed to resolve parent: %v", err)}
+	}
+	if len(parents) == 0 {
+		return &DriftResult{Allowed: true, Reason: "no owner reference"}
+	}
+
+	var decision *DriftResult
+	evaluated := make([]ParentRef, 0, len(parents))
+	for _, parentState := range parents {
+		evaluated = append(evaluated, parentState.Ref)
+		result := d.evaluate(parentState, username, childUpdaters)
+		if decision == nil || (result.DriftDetected && !decision.DriftDetected) {
+			decision = result
+		}
+	}
+	decision.EvaluatedParents = evaluated
+	decision.DecidingParent = decision.ParentRef
+	return decision
+}
 
+// evaluate checks a change by username against a single resolved parent.
+func (d *Detector) evaluate(parentState *ParentState, username string, childUpdaters []string) *DriftResult {
 	result, done := d.checkLifecycle(parentState)
 	if done {
-		return result, nil
+		return result
 	}
 
-	isController, canDetermine := IsControllerByHash(parentState, username, childUpdaters)
+	isController, canDetermine := IsControllerByHashWithFunc(parentState, username, childUpdaters, d.hash)
 	if !canDetermine {
 		result.Allowed = true
 		result.DriftDetected = false
+		result.ControllerUnknown = true
 		result.Reason = "cannot determine controller identity (multiple updaters, no parent controllers annotation)"
-		return result, nil
+		return result
 	}
 	if !isController {
 		result.Allowed = true
 		result.DriftDetected = false
-		result.Reason = fmt.Sprintf("change by different actor (hash %s)", controller.HashUsername(username))
-		return result, nil
+		result.Reason = fmt.Sprintf("change by different actor (hash %s)", d.hash(username))
+		return result
 	}
 
-	return checkGeneration(result, parentState), nil
+	return d.checkStable(result, parentState)
+}
+
+// DetectDryRun checks whether writing obj, e.g. a rendered manifest, would be drift if
+// done by the child's controller, and returns the spec fields that differ from the live
+// object in FieldDiffs. A missing live object is diffed as a CREATE. Specs are extracted
+// like for admission, so keyed arrays configured by WithArrayMergeKeys are compared as
+// sets. obj must carry its apiVersion and kind.
+//
+// DetectDryRun only reads: it writes no trace or updater annotations and does not
+// consume approvals.
+func (d *Detector) DetectDryRun(ctx context.Context, obj client.Object) (*DriftResult, error) {
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	if gvk.Kind == "" {
+		return nil, fmt.Errorf("object %s has no kind", obj.GetName())
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert object: %w", err)
+	}
+	desired := &unstructured.Unstructured{Object: content}
+
+	var live *unstructured.Unstructured
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(gvk)
+	if err := d.resolver.client.Get(ctx, client.ObjectKeyFromObject(obj), current); err == nil {
+		live = current
+	} else if !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get live object: %w", err)
+	}
+
+	var mergeKeys []config.ArrayMergeKey
+	for _, mk := range d.arrayMergeKeys {
+		if mk.APIGroup == gvk.Group && mk.Kind == gvk.Kind {
+			mergeKeys = append(mergeKeys, mk)
+		}
+	}
+	diffs := DiffSpec(ExtractSpec(live, mergeKeys), ExtractSpec(desired, mergeKeys))
+
+	parentState, err := d.resolver.ResolveParent(ctx, obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve parent: %w", err)
+	}
+	if parentState == nil {
+		return &DriftResult{Allowed: true, Reason: "no controller owner reference", FieldDiffs: diffs}, nil
+	}
+
+	result, done := d.checkLifecycle(parentState)
+	result.FieldDiffs = diffs
+	if done {
+		return result, nil
+	}
+	if live != nil && len(diffs) == 0 {
+		result.Allowed = true
+		result.Reason = "no spec change"
+		return result, nil
+	}
+	return d.checkStable(result, parentState), nil
 }
 
 // IsControllerByHash checks if the request comes from the controller using user hash tracking.
 // Returns (isController, canDetermine).
 func IsControllerByHash(parentState *ParentState, username string, childUpdaters []string) (bool, bool) {
-	userHash := controller.HashUsername(username)
+	return IsControllerByHashWithFunc(parentState, username, childUpdaters, controller.HashUsername)
+}
+
+// IsControllerByHashWithFunc is IsControllerByHash for user identifiers hashed with hash.
+func IsControllerByHashWithFunc(parentState *ParentState, username string, childUpdaters []string, hash controller.HashFunc) (bool, bool) {
+	userHash := hash(username)
 
 	// Case 1: Single updater on child - that's the controller
 	if len(childUpdaters) == 1 {
 		return userHash == childUpdaters[0], true
 	}
 
-	// Case 2: Multiple updaters + parent has controllers - use intersection
+	// Case 2: Multiple updaters + user's service account writes parent status - controller,
+	// also if its hash was evicted from the bounded controllers annotation
+	if len(childUpdaters) > 1 {
+		if sa, ok := controller.ServiceAccount(username); ok && controller.ContainsHash(parentState.ControllerServiceAccounts, sa) {
+			return true, true
+		}
+	}
+
+	// Case 3: Multiple updaters + parent has controllers - use intersection
 	if len(childUpdaters) > 1 && len(parentState.Controllers) > 0 {
 		intersection := controller.Intersect(childUpdaters, parentState.Controllers)
 		if len(intersection) > 0 {
@@ -138,21 +331,26 @@ func IsControllerByHash(parentState *ParentState, username string, childUpdaters
 		}
 	}
 
-	// Case 3: No updaters yet (CREATE) - current user is the first/only updater
+	// Case 4: No updaters yet (CREATE) - current user is the first/only updater
 	if len(childUpdaters) == 0 {
 		// This is a CREATE - the current user will be the only updater
 		return true, true
 	}
 
-	// Case 4: Can't determine (multiple updaters, no parent controllers)
+	// Case 5: Can't determine (multiple updaters, no parent controllers)
 	return false, false
 }
 
 // ParseUpdaterHashes extracts updater hashes from the child object's annotation.
 func ParseUpdaterHashes(obj client.Object) []string {
+	return ParseUpdaterHashesWithKeys(obj, kausalityv1alpha1.DefaultAnnotationKeys)
+}
+
+// ParseUpdaterHashesWithKeys is ParseUpdaterHashes reading the updaters annotation of keys.
+func ParseUpdaterHashesWithKeys(obj client.Object, keys kausalityv1alpha1.AnnotationKeys) []string {
 	annotations := obj.GetAnnotations()
 	if annotations == nil {
 		return nil
 	}
-	return controller.ParseHashes(annotations[controller.UpdatersAnnotation])
+	return controller.ParseHashes(annotations[keys.Updaters])
 }
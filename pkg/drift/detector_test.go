@@ -1,13 +1,21 @@
 package drift
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
+	"github.com/kausality-io/kausality/pkg/config"
 	"github.com/kausality-io/kausality/pkg/controller"
 )
 
@@ -40,6 +48,23 @@ func TestLifecycleDetector_DetectPhase(t *testing.T) {
 			},
 			expect: PhaseDeleting,
 		},
+		{
+			name: "spec.paused - paused",
+			state: &ParentState{
+				Paused:                true,
+				HasObservedGeneration: true,
+				IsInitialized:         true,
+			},
+			expect: PhasePaused,
+		},
+		{
+			name: "deletion takes precedence over paused",
+			state: &ParentState{
+				DeletionTimestamp: &metav1.Time{Time: time.Now()},
+				Paused:            true,
+			},
+			expect: PhaseDeleting,
+		},
 		{
 			name: "annotation initialized - ready",
 			state: &ParentState{
@@ -156,6 +181,47 @@ func TestLifecycleDetector_DetectPhase(t *testing.T) {
 	}
 }
 
+func TestLifecycleDetector_StabilizationGracePeriod(t *testing.T) {
+	detector := &LifecycleDetector{StabilizationGracePeriod: time.Minute}
+	stable := func(statusUpdatedAt *metav1.Time) *ParentState {
+		return &ParentState{
+			Generation:            2,
+			ObservedGeneration:    2,
+			HasObservedGeneration: true,
+			IsInitialized:         true,
+			StatusUpdatedAt:       statusUpdatedAt,
+		}
+	}
+
+	assert.Equal(t, PhaseReconciling, detector.DetectPhase(stable(&metav1.Time{Time: time.Now().Add(-time.Second)})))
+	assert.Equal(t, PhaseInitialized, detector.DetectPhase(stable(&metav1.Time{Time: time.Now().Add(-2 * time.Minute)})))
+	assert.Equal(t, PhaseInitialized, detector.DetectPhase(stable(nil)), "without a status update time the parent is stable")
+
+	reconciling := stable(&metav1.Time{Time: time.Now()})
+	reconciling.Generation = 3
+	assert.Equal(t, PhaseInitialized, detector.DetectPhase(reconciling), "generation ahead is already an expected change")
+
+	initializing := stable(&metav1.Time{Time: time.Now()})
+	initializing.IsInitialized = false
+	assert.Equal(t, PhaseInitializing, detector.DetectPhase(initializing))
+
+	assert.Equal(t, PhaseInitialized, NewLifecycleDetector().DetectPhase(stable(&metav1.Time{Time: time.Now()})), "zero disables the grace period")
+}
+
+func TestStatusUpdatedAt(t *testing.T) {
+	earlier := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+	later := metav1.NewTime(time.Now().Truncate(time.Second))
+
+	assert.Nil(t, StatusUpdatedAt(nil))
+	assert.Nil(t, StatusUpdatedAt([]metav1.ManagedFieldsEntry{{Manager: "ctrl", Subresource: "status"}}), "entries without time are ignored")
+	assert.Nil(t, StatusUpdatedAt([]metav1.ManagedFieldsEntry{{Manager: "kubectl", Time: &later}}), "spec entries are ignored")
+	assert.Equal(t, &later, StatusUpdatedAt([]metav1.ManagedFieldsEntry{
+		{Manager: "ctrl", Subresource: "status", Time: &earlier},
+		{Manager: "kubectl", Time: &later},
+		{Manager: "other", Subresource: "status", Time: &later},
+	}))
+}
+
 func TestIsControllerByHash(t *testing.T) {
 	// Generate some user hashes
 	user1 := "system:serviceaccount:kube-system:deployment-controller"
@@ -240,6 +306,41 @@ func TestIsControllerByHash(t *testing.T) {
 			wantController:   false,
 			wantCanDetermine: false,
 		},
+		{
+			name: "multiple updaters - service account of parent controller",
+			parentState: &ParentState{
+				Ref:                       ParentRef{Kind: "Deployment", Name: "test"},
+				Controllers:               nil, // hash evicted or annotation not written yet
+				ControllerServiceAccounts: []string{"kube-system/deployment-controller"},
+			},
+			username:         user1,
+			childUpdaters:    []string{hash2, hash3},
+			wantController:   true,
+			wantCanDetermine: true,
+		},
+		{
+			name: "multiple updaters - other service account, hash only in intersection",
+			parentState: &ParentState{
+				Ref:                       ParentRef{Kind: "Deployment", Name: "test"},
+				Controllers:               []string{hash2},
+				ControllerServiceAccounts: []string{"infra/widget-controller"},
+			},
+			username:         user1,
+			childUpdaters:    []string{hash1, hash2},
+			wantController:   false,
+			wantCanDetermine: true,
+		},
+		{
+			name: "single updater - service account does not override it",
+			parentState: &ParentState{
+				Ref:                       ParentRef{Kind: "Deployment", Name: "test"},
+				ControllerServiceAccounts: []string{"kube-system/deployment-controller"},
+			},
+			username:         user1,
+			childUpdaters:    []string{hash2},
+			wantController:   false,
+			wantCanDetermine: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -251,6 +352,41 @@ func TestIsControllerByHash(t *testing.T) {
 	}
 }
 
+func TestDetect_PausedParent(t *testing.T) {
+	tests := []struct {
+		name      string
+		paused    bool
+		wantPhase LifecyclePhase
+		wantDrift bool
+	}{
+		{name: "unpaused stable parent", wantPhase: PhaseInitialized, wantDrift: true},
+		{name: "paused stable parent", paused: true, wantPhase: PhasePaused},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parent := initializedOwner("web", 2, 2).(*unstructured.Unstructured)
+			require.NoError(t, unstructured.SetNestedField(parent.Object, tt.paused, "spec", "paused"))
+			d := NewDetector(fake.NewClientBuilder().WithObjects(parent).Build())
+
+			child := &unstructured.Unstructured{}
+			child.SetAPIVersion("apps/v1")
+			child.SetKind("ReplicaSet")
+			child.SetNamespace("default")
+			child.SetName("web-abc")
+			child.SetOwnerReferences([]metav1.OwnerReference{ownerRef("web", true)})
+
+			username := "system:serviceaccount:kube-system:deployment-controller"
+			result, err := d.Detect(t.Context(), child, username, []string{controller.HashUsername(username)})
+			require.NoError(t, err)
+			assert.True(t, result.Allowed)
+			assert.Equal(t, tt.wantPhase, result.LifecyclePhase)
+			assert.Equal(t, tt.wantDrift, result.DriftDetected, result.Reason)
+			assert.Equal(t, tt.paused, result.ParentState.Paused)
+		})
+	}
+}
+
 func TestCheckGeneration(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -299,6 +435,83 @@ func TestCheckGeneration(t *testing.T) {
 	}
 }
 
+func TestDetectDryRun(t *testing.T) {
+	parent := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name": "web", "namespace": "default", "uid": "parent-uid", "generation": int64(1),
+			"annotations": map[string]interface{}{controller.PhaseAnnotation: controller.PhaseValueInitialized},
+		},
+		"status": map[string]interface{}{"observedGeneration": int64(1)},
+	}}
+	replicaSet := func(replicas int64, containers ...interface{}) *unstructured.Unstructured {
+		rs := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "ReplicaSet",
+			"metadata":   map[string]interface{}{"name": "web-abc", "namespace": "default"},
+			"spec": map[string]interface{}{
+				"replicas": replicas,
+				"template": map[string]interface{}{"spec": map[string]interface{}{"containers": containers}},
+			},
+		}}
+		rs.SetOwnerReferences([]metav1.OwnerReference{{
+			APIVersion: "apps/v1", Kind: "Deployment", Name: "web", UID: "parent-uid", Controller: ptr.To(true),
+		}})
+		return rs
+	}
+	container := func(name, image string) interface{} {
+		return map[string]interface{}{"name": name, "image": image}
+	}
+
+	var writes int
+	c := fake.NewClientBuilder().WithObjects(parent, replicaSet(1, container("app", "nginx:1.25"), container("sidecar", "envoy"))).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				writes++
+				return c.Update(ctx, obj, opts...)
+			},
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				writes++
+				return c.Patch(ctx, obj, patch, opts...)
+			},
+		}).Build()
+	d := NewDetectorWithOptions(c, WithArrayMergeKeys([]config.ArrayMergeKey{
+		{APIGroup: "apps", Kind: "ReplicaSet", Path: "spec.template.spec.containers", Key: "name"},
+	}))
+
+	t.Run("changed fields on a stable parent are drift", func(t *testing.T) {
+		result, err := d.DetectDryRun(t.Context(), replicaSet(3, container("app", "nginx:1.26"), container("sidecar", "envoy")))
+		require.NoError(t, err)
+		assert.True(t, result.DriftDetected)
+		assert.Equal(t, []FieldDiff{
+			{Path: "spec.replicas", Pointer: "/spec/replicas", Old: int64(1), New: int64(3)},
+			{Path: "spec.template.spec.containers[0].image", Pointer: "/spec/template/spec/containers/0/image", Old: "nginx:1.25", New: "nginx:1.26"},
+		}, result.FieldDiffs)
+	})
+
+	t.Run("reordered keyed arrays are no change", func(t *testing.T) {
+		result, err := d.DetectDryRun(t.Context(), replicaSet(1, container("sidecar", "envoy"), container("app", "nginx:1.25")))
+		require.NoError(t, err)
+		assert.False(t, result.DriftDetected)
+		assert.Empty(t, result.FieldDiffs)
+	})
+
+	t.Run("missing live object is a create", func(t *testing.T) {
+		rs := replicaSet(2, container("app", "nginx:1.26"))
+		rs.SetName("web-new")
+		result, err := d.DetectDryRun(t.Context(), rs)
+		require.NoError(t, err)
+		assert.True(t, result.DriftDetected)
+		assert.Equal(t, []FieldDiff{
+			{Path: "spec.replicas", Pointer: "/spec/replicas", New: int64(2)},
+			{Path: "spec.template.spec.containers", Pointer: "/spec/template/spec/containers", New: []interface{}{container("app", "nginx:1.26")}},
+		}, result.FieldDiffs)
+	})
+
+	assert.Zero(t, writes, "dry-run must not write")
+}
+
 func TestParentRef_String(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -1,6 +1,8 @@
 package drift
 
 import (
+	"time"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -9,6 +11,10 @@ type LifecycleDetector struct {
 	// DetectionOrder specifies the priority order for initialization detection.
 	// Defaults to DefaultDetectionOrder if nil.
 	DetectionOrder []InitializationDetector
+	// StabilizationGracePeriod reports an initialized parent as PhaseReconciling until
+	// this long after its last status update, even if observedGeneration caught up.
+	// Zero disables it.
+	StabilizationGracePeriod time.Duration
 }
 
 // NewLifecycleDetector creates a new LifecycleDetector with default settings.
@@ -29,9 +35,14 @@ func (d *LifecycleDetector) DetectPhase(state *ParentState) LifecyclePhase {
 		return PhaseDeleting
 	}
 
+	// A paused parent is not reconciled, whatever its observedGeneration
+	if state.Paused {
+		return PhasePaused
+	}
+
 	// Check if already marked as initialized via annotation
 	if state.IsInitialized {
-		return PhaseInitialized
+		return d.stabilizedPhase(state)
 	}
 
 	// Check initialization using configured detection order
@@ -42,13 +53,27 @@ func (d *LifecycleDetector) DetectPhase(state *ParentState) LifecyclePhase {
 
 	for _, detector := range detectionOrder {
 		if d.checkInitialized(state, detector) {
-			return PhaseInitialized
+			return d.stabilizedPhase(state)
 		}
 	}
 
 	return PhaseInitializing
 }
 
+// stabilizedPhase returns PhaseReconciling for an initialized parent whose
+// observedGeneration caught up within the stabilization grace period, PhaseInitialized
+// otherwise. Without a status update time, the parent counts as stable.
+func (d *LifecycleDetector) stabilizedPhase(state *ParentState) LifecyclePhase {
+	if d.StabilizationGracePeriod <= 0 || state.StatusUpdatedAt == nil || !state.HasObservedGeneration ||
+		state.Generation != state.ObservedGeneration {
+		return PhaseInitialized
+	}
+	if time.Since(state.StatusUpdatedAt.Time) < d.StabilizationGracePeriod {
+		return PhaseReconciling
+	}
+	return PhaseInitialized
+}
+
 // checkInitialized checks if the parent is initialized using the specified detector.
 func (d *LifecycleDetector) checkInitialized(state *ParentState, detector InitializationDetector) bool {
 	switch detector {
@@ -58,7 +83,7 @@ func (d *LifecycleDetector) checkInitialized(state *ParentState, detector Initia
 		return hasConditionTrue(state.Conditions, ConditionTypeReady)
 	case DetectByObservedGeneration:
 		// For observedGeneration to indicate "initialized", we need:
-		// 1. HasObservedGeneration (gen == obsGen from condition)
+		// 1. HasObservedGeneration, caught up with the generation (top-level or from conditions)
 		// 2. A "ready-like" condition is True (Ready, Available, or Initialized)
 		//
 		// We specifically do NOT use Synced=True alone because:
@@ -66,7 +91,7 @@ func (d *LifecycleDetector) checkInitialized(state *ParentState, detector Initia
 		// - But Ready=False until children are actually ready
 		// - During child creation, the controller needs to update children
 		// - Those updates should be allowed until fully ready
-		if !state.HasObservedGeneration {
+		if !state.HasObservedGeneration || state.ObservedGeneration < state.Generation {
 			return false
 		}
 		return hasConditionTrue(state.Conditions, ConditionTypeReady) ||
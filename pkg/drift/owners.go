@@ -0,0 +1,85 @@
+package drift
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kausalityv1alpha1 "github.com/kausality-io/kausality/api/v1alpha1"
+	"github.com/kausality-io/kausality/pkg/config"
+)
+
+// OwnerRefs returns the ownerReferences of obj evaluated as its parents under selection,
+// one of the config.OwnerSelection* values:
+//   - "controllerOnly" (default): the parent returned by ParentOwnerRef.
+//   - "firstOwner": like controllerOnly, but without a controller ownerReference the first
+//     ownerReference is the parent.
+//   - "allOwners": every ownerReference, the controller one first. Without ownerReferences,
+//     the parent returned by ParentOwnerRef.
+//
+// It returns nil if obj has no parent.
+func OwnerRefs(obj client.Object, selection string, refs []config.ParentReference, keys kausalityv1alpha1.AnnotationKeys) []metav1.OwnerReference {
+	owners := obj.GetOwnerReferences()
+	switch selection {
+	case config.OwnerSelectionFirstOwner:
+		if findControllerOwnerRef(owners) == nil && len(owners) > 0 {
+			return []metav1.OwnerReference{owners[0]}
+		}
+	case config.OwnerSelectionAllOwners:
+		if len(owners) > 0 {
+			selected := make([]metav1.OwnerReference, 0, len(owners))
+			if ref := findControllerOwnerRef(owners); ref != nil {
+				selected = append(selected, *ref)
+			}
+			for _, ref := range owners {
+				if ref.Controller == nil || !*ref.Controller {
+					selected = append(selected, ref)
+				}
+			}
+			return selected
+		}
+	}
+	if ref := ParentOwnerRef(obj, refs, keys); ref != nil {
+		return []metav1.OwnerReference{*ref}
+	}
+	return nil
+}
+
+// SetOwnerSelection configures which ownerReferences are parents, see OwnerRefs.
+// ResolveParent resolves the first of them.
+func (r *ParentResolver) SetOwnerSelection(selection string) {
+	r.ownerSelection = selection
+}
+
+// ResolveParents fetches all parents of obj under the configured owner selection, in
+// the order of OwnerRefs. Parents named by a parent reference that do not exist are
+// skipped.
+func (r *ParentResolver) ResolveParents(ctx context.Context, obj client.Object) ([]*ParentState, error) {
+	var parents []*ParentState
+	for _, ownerRef := range OwnerRefs(obj, r.ownerSelection, r.references, r.keys) {
+		parent, err := r.resolve(ctx, obj, ownerRef)
+		if err != nil {
+			return nil, err
+		}
+		if parent != nil {
+			parents = append(parents, parent)
+		}
+	}
+	return parents, nil
+}
+
+// resolve fetches the parent of obj named by ownerRef. It returns nil if ownerRef has no
+// UID, i.e. comes from a parent reference, and the parent does not exist.
+func (r *ParentResolver) resolve(ctx context.Context, obj client.Object, ownerRef metav1.OwnerReference) (*ParentState, error) {
+	parent, err := r.getParent(ctx, ParentRefFromOwnerRef(ownerRef, obj.GetNamespace()), obj.GetNamespace())
+	if err != nil {
+		if ownerRef.UID == "" && apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get parent %s/%s: %w", ownerRef.Kind, ownerRef.Name, err)
+	}
+	return extractParentStateWithKeys(parent, ownerRef, r.keys), nil
+}
@@ -0,0 +1,152 @@
+package drift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kausalityv1alpha1 "github.com/kausality-io/kausality/api/v1alpha1"
+	"github.com/kausality-io/kausality/pkg/config"
+)
+
+// initializedOwner returns an initialized Deployment owner. It is stable if its
+// controller observed its generation, and reconciling otherwise.
+func initializedOwner(name string, generation, observedGeneration int64) client.Object {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name":        name,
+			"namespace":   "default",
+			"uid":         name + "-uid",
+			"generation":  generation,
+			"annotations": map[string]interface{}{kausalityv1alpha1.PhaseAnnotation: "initialized"},
+		},
+		"status": map[string]interface{}{"observedGeneration": observedGeneration},
+	}}
+}
+
+func ownerRef(name string, isController bool) metav1.OwnerReference {
+	ref := metav1.OwnerReference{APIVersion: "apps/v1", Kind: "Deployment", Name: name, UID: types.UID(name + "-uid")}
+	if isController {
+		ref.Controller = ptr.To(true)
+	}
+	return ref
+}
+
+func TestDetect_OwnerSelection(t *testing.T) {
+	stable := ParentRef{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "stable", UID: "stable-uid"}
+	rolling := ParentRef{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "rolling", UID: "rolling-uid"}
+
+	tests := []struct {
+		name          string
+		selection     string
+		owners        []metav1.OwnerReference
+		wantParent    *ParentRef
+		wantDrift     bool
+		wantEvaluated []ParentRef
+	}{
+		{
+			name:       "controllerOnly evaluates the controller",
+			selection:  config.OwnerSelectionControllerOnly,
+			owners:     []metav1.OwnerReference{ownerRef("stable", false), ownerRef("rolling", true)},
+			wantParent: &rolling,
+		},
+		{
+			name:      "controllerOnly without controller has no parent",
+			selection: config.OwnerSelectionControllerOnly,
+			owners:    []metav1.OwnerReference{ownerRef("stable", false), ownerRef("rolling", false)},
+		},
+		{
+			name:       "firstOwner prefers the controller",
+			selection:  config.OwnerSelectionFirstOwner,
+			owners:     []metav1.OwnerReference{ownerRef("stable", false), ownerRef("rolling", true)},
+			wantParent: &rolling,
+		},
+		{
+			name:       "firstOwner without controller evaluates the first owner",
+			selection:  config.OwnerSelectionFirstOwner,
+			owners:     []metav1.OwnerReference{ownerRef("stable", false), ownerRef("rolling", false)},
+			wantParent: &stable,
+			wantDrift:  true,
+		},
+		{
+			name:          "allOwners detects drift against any stable owner",
+			selection:     config.OwnerSelectionAllOwners,
+			owners:        []metav1.OwnerReference{ownerRef("stable", false), ownerRef("rolling", true)},
+			wantParent:    &stable,
+			wantDrift:     true,
+			wantEvaluated: []ParentRef{rolling, stable},
+		},
+		{
+			name:          "allOwners without controller evaluates all owners",
+			selection:     config.OwnerSelectionAllOwners,
+			owners:        []metav1.OwnerReference{ownerRef("rolling", false), ownerRef("stable", false)},
+			wantParent:    &stable,
+			wantDrift:     true,
+			wantEvaluated: []ParentRef{rolling, stable},
+		},
+		{
+			name:          "allOwners without stable owner is decided by the first",
+			selection:     config.OwnerSelectionAllOwners,
+			owners:        []metav1.OwnerReference{ownerRef("rolling", true)},
+			wantParent:    &rolling,
+			wantEvaluated: []ParentRef{rolling},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithObjects(initializedOwner("stable", 2, 2), initializedOwner("rolling", 3, 2)).Build()
+			d := NewDetectorWithOptions(c, WithOwnerSelection(tt.selection))
+
+			child := &unstructured.Unstructured{}
+			child.SetAPIVersion("apps/v1")
+			child.SetKind("ReplicaSet")
+			child.SetNamespace("default")
+			child.SetName("web-abc")
+			child.SetOwnerReferences(tt.owners)
+
+			result, err := d.Detect(t.Context(), child, "system:serviceaccount:kube-system:deployment-controller", nil)
+			require.NoError(t, err)
+			assert.True(t, result.Allowed)
+			assert.Equal(t, tt.wantDrift, result.DriftDetected, result.Reason)
+			assert.Equal(t, tt.wantParent, result.ParentRef)
+			assert.Equal(t, tt.wantEvaluated, result.EvaluatedParents)
+			if tt.wantEvaluated != nil {
+				assert.Equal(t, tt.wantParent, result.DecidingParent)
+			} else {
+				assert.Nil(t, result.DecidingParent)
+			}
+		})
+	}
+}
+
+func TestResolveParents_SkipsMissingParentReference(t *testing.T) {
+	resolver := NewParentResolver(fake.NewClientBuilder().WithObjects(initializedOwner("stable", 2, 2)).Build())
+	resolver.SetOwnerSelection(config.OwnerSelectionAllOwners)
+
+	child := &unstructured.Unstructured{}
+	child.SetAPIVersion("v1")
+	child.SetKind("ConfigMap")
+	child.SetNamespace("default")
+	child.SetName("web-config")
+	child.SetAnnotations(map[string]string{kausalityv1alpha1.ParentAnnotation: "apps/v1/Deployment/default/missing"})
+
+	parents, err := resolver.ResolveParents(t.Context(), child)
+	require.NoError(t, err)
+	assert.Empty(t, parents)
+
+	// A missing owner is an error
+	child.SetOwnerReferences([]metav1.OwnerReference{ownerRef("stable", false), ownerRef("missing", false)})
+	_, err = resolver.ResolveParents(t.Context(), child)
+	require.Error(t, err)
+}
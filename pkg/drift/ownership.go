@@ -0,0 +1,181 @@
+package drift
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OwnershipConflict describes a spec field whose server-side apply ownership moved to the
+// requesting field manager from other managers.
+type OwnershipConflict struct {
+	// Field is the field path, e.g. "spec.replicas" or "spec.containers[name=app].image".
+	Field string
+	// PreviousManagers owned the field before the request.
+	PreviousManagers []string
+}
+
+// String returns e.g. "spec.replicas (from helm)".
+func (c OwnershipConflict) String() string {
+	return fmt.Sprintf("%s (from %s)", c.Field, strings.Join(c.PreviousManagers, ", "))
+}
+
+// OwnershipConflicts compares the managedFields of the old and new object and returns the
+// spec fields that manager owns after the request but that other managers owned, without
+// manager, before it and lost with it. This is what a forced apply (--force-conflicts) or a plain update
+// of a field applied by someone else looks like. Subresource entries are ignored.
+func OwnershipConflicts(oldFields, newFields []metav1.ManagedFieldsEntry, manager string) []OwnershipConflict {
+	if manager == "" {
+		return nil
+	}
+	before := specOwners(oldFields)
+	after := specOwners(newFields)
+
+	var conflicts []OwnershipConflict
+	for field, owners := range after {
+		if _, ok := owners[manager]; !ok {
+			continue
+		}
+		previous := before[field]
+		if _, ok := previous[manager]; ok || len(previous) == 0 {
+			continue
+		}
+		// Shared ownership (a non-forced apply of the same value) is no conflict
+		conflict := OwnershipConflict{Field: field}
+		for owner := range previous {
+			if _, ok := owners[owner]; !ok {
+				conflict.PreviousManagers = append(conflict.PreviousManagers, owner)
+			}
+		}
+		if len(conflict.PreviousManagers) == 0 {
+			continue
+		}
+		sort.Strings(conflict.PreviousManagers)
+		conflicts = append(conflicts, conflict)
+	}
+	sort.Slice(conflicts, func(i, j int) bool { return conflicts[i].Field < conflicts[j].Field })
+	return conflicts
+}
+
+// ManagesSpec returns true if manager owns any spec field in managedFields.
+func ManagesSpec(fields []metav1.ManagedFieldsEntry, manager string) bool {
+	for _, owners := range specOwners(fields) {
+		if _, ok := owners[manager]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// specOwners maps each owned spec field path to the managers owning it.
+func specOwners(entries []metav1.ManagedFieldsEntry) map[string]map[string]struct{} {
+	owners := map[string]map[string]struct{}{}
+	for _, entry := range entries {
+		if entry.Subresource != "" || entry.FieldsV1 == nil {
+			continue
+		}
+		var root map[string]interface{}
+		if err := json.Unmarshal(entry.FieldsV1.Raw, &root); err != nil {
+			continue
+		}
+		spec, ok := root["f:spec"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		collectFields("spec", spec, func(path string) {
+			if owners[path] == nil {
+				owners[path] = map[string]struct{}{}
+			}
+			owners[path][entry.Manager] = struct{}{}
+		})
+	}
+	return owners
+}
+
+// collectFields calls owned for every owned field below a FieldsV1 node. A node is owned
+// itself if it is a leaf or carries the "." marker (e.g. a list item owned as a whole).
+func collectFields(path string, node map[string]interface{}, owned func(string)) {
+	if len(node) == 0 {
+		owned(path)
+		return
+	}
+	for key, value := range node {
+		if key == "." {
+			owned(path)
+			continue
+		}
+		child, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		collectFields(path+fieldPathElement(key), child, owned)
+	}
+}
+
+// fieldPathElement renders a FieldsV1 key as a path element.
+func fieldPathElement(key string) string {
+	prefix, value, ok := strings.Cut(key, ":")
+	if !ok {
+		return "." + key
+	}
+	switch prefix {
+	case "f":
+		return "." + value
+	case "i":
+		return "[" + value + "]"
+	case "v":
+		return "[=" + value + "]"
+	case "k":
+		var keys map[string]interface{}
+		if err := json.Unmarshal([]byte(value), &keys); err != nil {
+			return "[" + value + "]"
+		}
+		names := make([]string, 0, len(keys))
+		for name := range keys {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		parts := make([]string, 0, len(names))
+		for _, name := range names {
+			v, _ := json.Marshal(keys[name])
+			parts = append(parts, name+"="+strings.Trim(string(v), `"`))
+		}
+		return "[" + strings.Join(parts, ",") + "]"
+	}
+	return "." + key
+}
+
+// ClassifyOwnershipConflicts records conflicts on result and classifies them.
+// A controller taking fields from other managers while the parent is stable is drift like
+// any other controller change; the reason names the fields. If the controller identity
+// cannot be determined from updater hashes, an established manager of the child (one that
+// owned spec fields before the request) taking fields from others is classified as drift
+// too: it is contending with another writer instead of reconciling a parent change.
+func ClassifyOwnershipConflicts(result *DriftResult, conflicts []OwnershipConflict, establishedManager bool) {
+	if result == nil || len(conflicts) == 0 || result.ParentState == nil || result.LifecyclePhase != PhaseInitialized {
+		return
+	}
+	result.OwnershipConflicts = conflicts
+
+	switch {
+	case result.DriftDetected:
+		result.Reason = fmt.Sprintf("%s; took ownership of %s", result.Reason, DescribeOwnershipConflicts(conflicts))
+	case result.ControllerUnknown && establishedManager &&
+		result.ParentState.Generation == result.ParentState.ObservedGeneration:
+		result.DriftDetected = true
+		result.Reason = fmt.Sprintf("drift detected: field manager took ownership of %s while parent generation (%d) == observedGeneration (%d)",
+			DescribeOwnershipConflicts(conflicts), result.ParentState.Generation, result.ParentState.ObservedGeneration)
+	}
+}
+
+// DescribeOwnershipConflicts renders conflicts as "spec.a (from m1), spec.b (from m2, m3)".
+func DescribeOwnershipConflicts(conflicts []OwnershipConflict) string {
+	parts := make([]string, 0, len(conflicts))
+	for _, c := range conflicts {
+		parts = append(parts, c.String())
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,153 @@
+package drift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func applyEntry(manager, fields string) metav1.ManagedFieldsEntry {
+	return metav1.ManagedFieldsEntry{
+		Manager:    manager,
+		Operation:  metav1.ManagedFieldsOperationApply,
+		FieldsType: "FieldsV1",
+		FieldsV1:   &metav1.FieldsV1{Raw: []byte(fields)},
+	}
+}
+
+func TestOwnershipConflicts(t *testing.T) {
+	const (
+		replicas = `{"f:spec":{"f:replicas":{}}}`
+		image    = `{"f:spec":{"f:template":{"f:spec":{"f:containers":{"k:{\"name\":\"app\"}":{".":{},"f:image":{}}}}}}}`
+		paused   = `{"f:spec":{"f:paused":{}}}`
+	)
+
+	tests := []struct {
+		name      string
+		old, new  []metav1.ManagedFieldsEntry
+		manager   string
+		want      []OwnershipConflict
+		wantSpecs bool
+	}{
+		{
+			name:      "forced apply takes a field from another manager",
+			old:       []metav1.ManagedFieldsEntry{applyEntry("helm", replicas), applyEntry("operator", paused)},
+			new:       []metav1.ManagedFieldsEntry{applyEntry("operator", `{"f:spec":{"f:replicas":{},"f:paused":{}}}`)},
+			manager:   "operator",
+			want:      []OwnershipConflict{{Field: "spec.replicas", PreviousManagers: []string{"helm"}}},
+			wantSpecs: true,
+		},
+		{
+			name:    "list item keys are rendered",
+			old:     []metav1.ManagedFieldsEntry{applyEntry("kubectl", image)},
+			new:     []metav1.ManagedFieldsEntry{applyEntry("operator", image)},
+			manager: "operator",
+			want: []OwnershipConflict{
+				{Field: "spec.template.spec.containers[name=app]", PreviousManagers: []string{"kubectl"}},
+				{Field: "spec.template.spec.containers[name=app].image", PreviousManagers: []string{"kubectl"}},
+			},
+		},
+		{
+			name:    "shared ownership after a non-forced apply is no conflict",
+			old:     []metav1.ManagedFieldsEntry{applyEntry("helm", replicas)},
+			new:     []metav1.ManagedFieldsEntry{applyEntry("helm", replicas), applyEntry("operator", replicas)},
+			manager: "operator",
+		},
+		{
+			name:      "field already owned by the manager",
+			old:       []metav1.ManagedFieldsEntry{applyEntry("operator", replicas), applyEntry("helm", replicas)},
+			new:       []metav1.ManagedFieldsEntry{applyEntry("operator", replicas)},
+			manager:   "operator",
+			wantSpecs: true,
+		},
+		{
+			name:    "newly set field has no previous owner",
+			old:     []metav1.ManagedFieldsEntry{applyEntry("helm", paused)},
+			new:     []metav1.ManagedFieldsEntry{applyEntry("helm", paused), applyEntry("operator", replicas)},
+			manager: "operator",
+		},
+		{
+			name: "subresource entries are ignored",
+			old: []metav1.ManagedFieldsEntry{func() metav1.ManagedFieldsEntry {
+				e := applyEntry("helm", replicas)
+				e.Subresource = "scale"
+				return e
+			}()},
+			new:     []metav1.ManagedFieldsEntry{applyEntry("operator", replicas)},
+			manager: "operator",
+		},
+		{
+			name: "unknown manager",
+			old:  []metav1.ManagedFieldsEntry{applyEntry("helm", replicas)},
+			new:  []metav1.ManagedFieldsEntry{applyEntry("operator", replicas)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, OwnershipConflicts(tt.old, tt.new, tt.manager))
+			assert.Equal(t, tt.wantSpecs, ManagesSpec(tt.old, tt.manager))
+		})
+	}
+}
+
+func TestClassifyOwnershipConflicts(t *testing.T) {
+	conflicts := []OwnershipConflict{{Field: "spec.replicas", PreviousManagers: []string{"helm"}}}
+	stable := func() *DriftResult {
+		return &DriftResult{
+			Allowed:        true,
+			ParentState:    &ParentState{Generation: 3, ObservedGeneration: 3},
+			LifecyclePhase: PhaseInitialized,
+		}
+	}
+
+	t.Run("controller drift names the fields", func(t *testing.T) {
+		result := stable()
+		result.DriftDetected = true
+		result.Reason = "drift detected: parent generation (3) == observedGeneration (3)"
+		ClassifyOwnershipConflicts(result, conflicts, false)
+		assert.True(t, result.DriftDetected)
+		assert.Equal(t, "drift detected: parent generation (3) == observedGeneration (3); took ownership of spec.replicas (from helm)", result.Reason)
+		assert.Equal(t, conflicts, result.OwnershipConflicts)
+	})
+
+	t.Run("established manager with unknown controller is drift", func(t *testing.T) {
+		result := stable()
+		result.ControllerUnknown = true
+		ClassifyOwnershipConflicts(result, conflicts, true)
+		assert.True(t, result.DriftDetected)
+		assert.Equal(t, "drift detected: field manager took ownership of spec.replicas (from helm) while parent generation (3) == observedGeneration (3)", result.Reason)
+	})
+
+	t.Run("new manager with unknown controller is not drift", func(t *testing.T) {
+		result := stable()
+		result.ControllerUnknown = true
+		ClassifyOwnershipConflicts(result, conflicts, false)
+		assert.False(t, result.DriftDetected)
+	})
+
+	t.Run("different actor is not drift", func(t *testing.T) {
+		result := stable()
+		ClassifyOwnershipConflicts(result, conflicts, true)
+		assert.False(t, result.DriftDetected)
+	})
+
+	t.Run("reconciling parent is not drift", func(t *testing.T) {
+		result := stable()
+		result.ControllerUnknown = true
+		result.ParentState.Generation = 4
+		ClassifyOwnershipConflicts(result, conflicts, true)
+		assert.False(t, result.DriftDetected)
+	})
+
+	t.Run("initializing parent is ignored", func(t *testing.T) {
+		result := stable()
+		result.ControllerUnknown = true
+		result.LifecyclePhase = PhaseInitializing
+		ClassifyOwnershipConflicts(result, conflicts, true)
+		assert.False(t, result.DriftDetected)
+		assert.Empty(t, result.OwnershipConflicts)
+	})
+}
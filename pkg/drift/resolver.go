@@ -3,55 +3,197 @@ package drift
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	kausalityv1alpha1 "github.com/kausality-io/kausality/api/v1alpha1"
+	"github.com/kausality-io/kausality/pkg/config"
 	"github.com/kausality-io/kausality/pkg/controller"
 )
 
 // ParentResolver resolves the controller parent of a Kubernetes object.
 type ParentResolver struct {
-	client client.Client
+	client         client.Client
+	fetch          ParentFetcher
+	references     []config.ParentReference
+	keys           kausalityv1alpha1.AnnotationKeys
+	ownerSelection string
 }
 
+// ParentFetcher fetches the parent identified by ref of a child in childNamespace,
+// see GetParent.
+type ParentFetcher func(ctx context.Context, ref ParentRef, childNamespace string) (*unstructured.Unstructured, error)
+
 // NewParentResolver creates a new ParentResolver.
 func NewParentResolver(c client.Client) *ParentResolver {
-	return &ParentResolver{client: c}
+	return &ParentResolver{client: c, keys: kausalityv1alpha1.DefaultAnnotationKeys}
+}
+
+// SetAnnotationKeys reads the phase and controllers of parents from the annotations of
+// keys rather than the kausality.io/ ones.
+func (r *ParentResolver) SetAnnotationKeys(keys kausalityv1alpha1.AnnotationKeys) {
+	r.keys = keys
+}
+
+// SetParentFetcher fetches parents with f rather than with GetParent on the client,
+// e.g. to serve them from a cache.
+func (r *ParentResolver) SetParentFetcher(f ParentFetcher) {
+	r.fetch = f
+}
+
+// SetParentReferences configures parent references for children without a controller
+// ownerReference, see ParentOwnerRef.
+func (r *ParentResolver) SetParentReferences(refs []config.ParentReference) {
+	r.references = refs
 }
 
-// ResolveParent finds and fetches the controller parent of the given object.
-// It returns nil if no controller owner reference is found.
+// ResolveParent finds and fetches the controller parent of the given object, or the
+// first parent under the configured owner selection, see OwnerRefs.
+// It returns nil if no controller owner reference is found. A parent named by a
+// parent reference that does not exist is no parent either.
 func (r *ParentResolver) ResolveParent(ctx context.Context, obj client.Object) (*ParentState, error) {
-	// Find controller owner reference
-	ownerRef := findControllerOwnerRef(obj.GetOwnerReferences())
-	if ownerRef == nil {
+	// Find controller owner reference, falling back to a declared parent reference
+	ownerRefs := OwnerRefs(obj, r.ownerSelection, r.references, r.keys)
+	if len(ownerRefs) == 0 {
 		return nil, nil
 	}
+	return r.resolve(ctx, obj, ownerRefs[0])
+}
+
+// getParent fetches a parent with the configured ParentFetcher, defaulting to GetParent.
+func (r *ParentResolver) getParent(ctx context.Context, ref ParentRef, childNamespace string) (*unstructured.Unstructured, error) {
+	if r.fetch != nil {
+		return r.fetch(ctx, ref, childNamespace)
+	}
+	return GetParent(ctx, r.client, ref, childNamespace)
+}
 
-	// Parse API version to get group/version
-	gv, err := schema.ParseGroupVersion(ownerRef.APIVersion)
+// GetParent fetches the parent identified by ref of a child in childNamespace.
+// A cluster-scoped child can only have cluster-scoped parents, so its parent is looked
+// up without namespace, whatever ref says. The parent of a namespaced child is looked
+// up in ref's namespace, defaulting to the child's. If it is not found there, the
+// ownerReference may legitimately point to a cluster-scoped parent, which is only
+// accepted if its UID matches ref's.
+func GetParent(ctx context.Context, c client.Reader, ref ParentRef, childNamespace string) (*unstructured.Unstructured, error) {
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
 	if err != nil {
-		return nil, fmt.Errorf("invalid API version %q: %w", ownerRef.APIVersion, err)
+		return nil, fmt.Errorf("invalid API version %q: %w", ref.APIVersion, err)
 	}
 
-	// Fetch the parent object
 	parent := &unstructured.Unstructured{}
-	parent.SetGroupVersionKind(gv.WithKind(ownerRef.Kind))
+	parent.SetGroupVersionKind(gv.WithKind(ref.Kind))
 
-	// Use the same namespace as the child for namespaced resources
-	parentKey := client.ObjectKey{
-		Namespace: obj.GetNamespace(),
-		Name:      ownerRef.Name,
+	key := client.ObjectKey{Name: ref.Name}
+	if childNamespace != "" {
+		key.Namespace = ref.Namespace
+		if key.Namespace == "" {
+			key.Namespace = childNamespace
+		}
+	}
+	err = c.Get(ctx, key, parent)
+	if err == nil {
+		return parent, nil
+	}
+	if key.Namespace == "" || ref.UID == "" || !apierrors.IsNotFound(err) {
+		return nil, err
 	}
 
-	if err := r.client.Get(ctx, parentKey, parent); err != nil {
-		return nil, fmt.Errorf("failed to get parent %s/%s: %w", ownerRef.Kind, ownerRef.Name, err)
+	// Namespaced child, cluster-scoped parent
+	clusterParent := &unstructured.Unstructured{}
+	clusterParent.SetGroupVersionKind(parent.GroupVersionKind())
+	if clusterErr := c.Get(ctx, client.ObjectKey{Name: ref.Name}, clusterParent); clusterErr != nil || clusterParent.GetUID() != ref.UID {
+		return nil, err
 	}
+	return clusterParent, nil
+}
 
-	return extractParentState(parent, *ownerRef), nil
+// ParentOwnerRef returns the controller ownerReference of obj. Without one, it falls back
+// to the parent annotation of keys on obj, and then to the parent reference declared in
+// refs for the kind of obj, returned as a controller ownerReference without UID. It
+// returns nil if obj has no parent. A malformed parent annotation, or one naming a parent
+// in another namespace, means no parent.
+func ParentOwnerRef(obj client.Object, refs []config.ParentReference, keys kausalityv1alpha1.AnnotationKeys) *metav1.OwnerReference {
+	if ownerRef := findControllerOwnerRef(obj.GetOwnerReferences()); ownerRef != nil {
+		return ownerRef
+	}
+	if value, ok := obj.GetAnnotations()[keys.Parent]; ok {
+		ref, err := ParseParentAnnotation(value)
+		if err != nil || (ref.Namespace != "" && ref.Namespace != obj.GetNamespace()) {
+			return nil
+		}
+		return &metav1.OwnerReference{
+			APIVersion: ref.APIVersion,
+			Kind:       ref.Kind,
+			Name:       ref.Name,
+			Controller: ptr.To(true),
+		}
+	}
+	gk := obj.GetObjectKind().GroupVersionKind().GroupKind()
+	for _, ref := range refs {
+		if ref.APIGroup != gk.Group || ref.Kind != gk.Kind {
+			continue
+		}
+		name := referencedParentName(obj, ref)
+		if name == "" {
+			return nil
+		}
+		return &metav1.OwnerReference{
+			APIVersion: ref.ParentAPIVersion,
+			Kind:       ref.ParentKind,
+			Name:       name,
+			Controller: ptr.To(true),
+		}
+	}
+	return nil
+}
+
+// ParseParentAnnotation parses a kausality.io/parent annotation value of the form
+// "<apiVersion>/<kind>/<namespace>/<name>", e.g. "apps/v1/Deployment/default/web" or
+// "v1/Namespace//team-a". The namespace is empty for cluster-scoped parents.
+func ParseParentAnnotation(value string) (ParentRef, error) {
+	parts := strings.Split(value, "/")
+	if len(parts) != 4 && len(parts) != 5 {
+		return ParentRef{}, fmt.Errorf("invalid parent annotation %q: must be <apiVersion>/<kind>/<namespace>/<name>", value)
+	}
+	n := len(parts)
+	ref := ParentRef{
+		APIVersion: strings.Join(parts[:n-3], "/"),
+		Kind:       parts[n-3],
+		Namespace:  parts[n-2],
+		Name:       parts[n-1],
+	}
+	if gv, err := schema.ParseGroupVersion(ref.APIVersion); err != nil || gv.Version == "" {
+		return ParentRef{}, fmt.Errorf("invalid parent annotation %q: invalid apiVersion %q", value, ref.APIVersion)
+	}
+	if ref.Kind == "" || ref.Name == "" {
+		return ParentRef{}, fmt.Errorf("invalid parent annotation %q: kind and name must not be empty", value)
+	}
+	return ref, nil
+}
+
+// referencedParentName returns the parent name held by the label or field of ref.
+func referencedParentName(obj client.Object, ref config.ParentReference) string {
+	if ref.Label != "" {
+		return obj.GetLabels()[ref.Label]
+	}
+	var content map[string]interface{}
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		content = u.Object
+	} else {
+		var err error
+		if content, err = runtime.DefaultUnstructuredConverter.ToUnstructured(obj); err != nil {
+			return ""
+		}
+	}
+	name, _, _ := unstructured.NestedString(content, strings.Split(ref.FieldPath, ".")...)
+	return name
 }
 
 // findControllerOwnerRef finds the owner reference with controller: true.
@@ -66,14 +208,21 @@ func findControllerOwnerRef(refs []metav1.OwnerReference) *metav1.OwnerReference
 
 // extractParentState extracts drift-relevant state from an unstructured parent object.
 func extractParentState(parent *unstructured.Unstructured, ownerRef metav1.OwnerReference) *ParentState {
+	return extractParentStateWithKeys(parent, ownerRef, kausalityv1alpha1.DefaultAnnotationKeys)
+}
+
+// extractParentStateWithKeys is extractParentState reading the annotations of keys.
+func extractParentStateWithKeys(parent *unstructured.Unstructured, ownerRef metav1.OwnerReference, keys kausalityv1alpha1.AnnotationKeys) *ParentState {
 	state := &ParentState{
 		Ref: ParentRef{
 			APIVersion: ownerRef.APIVersion,
 			Kind:       ownerRef.Kind,
 			Namespace:  parent.GetNamespace(),
 			Name:       ownerRef.Name,
+			UID:        ownerRef.UID,
 		},
 		Generation: parent.GetGeneration(),
+		Object:     parent,
 	}
 
 	// Extract status.observedGeneration, falling back to condition observedGeneration
@@ -83,71 +232,92 @@ func extractParentState(parent *unstructured.Unstructured, ownerRef metav1.Owner
 			state.HasObservedGeneration = true
 		}
 
+		state.Status = status
+
 		// Extract conditions for lifecycle detection
 		state.Conditions = ExtractConditions(status)
 
-		// Fallback: if no status.observedGeneration, check Synced/Ready conditions
+		// Fallback: if no status.observedGeneration, use the highest condition observedGeneration
 		// This supports Crossplane which stores observedGeneration in conditions
 		if !state.HasObservedGeneration {
 			state.ObservedGeneration, state.HasObservedGeneration = ExtractConditionObservedGeneration(status)
 		}
 	}
 
+	state.StatusUpdatedAt = StatusUpdatedAt(parent.GetManagedFields())
+
 	// Check for deletion timestamp
 	if parent.GetDeletionTimestamp() != nil {
 		state.DeletionTimestamp = parent.GetDeletionTimestamp()
 	}
 
+	state.Paused, _, _ = unstructured.NestedBool(parent.Object, "spec", "paused")
+
 	// Check annotations
 	if annotations := parent.GetAnnotations(); annotations != nil {
 		// Read phase annotation
-		state.PhaseFromAnnotation = annotations[controller.PhaseAnnotation]
+		state.PhaseFromAnnotation = annotations[keys.Phase]
 		if state.PhaseFromAnnotation == controller.PhaseValueInitialized {
 			state.IsInitialized = true
 		}
 
 		// Extract controller hashes from kausality.io/controllers annotation
-		if controllers := annotations[controller.ControllersAnnotation]; controllers != "" {
+		if controllers := annotations[keys.Controllers]; controllers != "" {
 			state.Controllers = controller.ParseHashes(controllers)
 		}
+		state.ControllerServiceAccounts = controller.ParseHashes(annotations[keys.ControllerServiceAccounts])
 	}
 
 	return state
 }
 
-// ExtractConditionObservedGeneration extracts observedGeneration from Synced or Ready conditions.
-// Returns the observedGeneration and whether it was found.
-// Prefers Synced condition, falls back to Ready.
+// StatusUpdatedAt returns the latest time of the managedFields entries for the status
+// subresource, or nil if there are none or none carries a time.
+func StatusUpdatedAt(fields []metav1.ManagedFieldsEntry) *metav1.Time {
+	var latest *metav1.Time
+	for i := range fields {
+		if fields[i].Subresource != "status" || fields[i].Time == nil {
+			continue
+		}
+		if latest == nil || latest.Before(fields[i].Time) {
+			latest = fields[i].Time
+		}
+	}
+	return latest
+}
+
+// ExtractConditionObservedGeneration extracts the highest observedGeneration of the
+// Synced and Ready status conditions, for CRDs like Crossplane composites that only
+// record it per condition. Other condition types are ignored. Returns the
+// observedGeneration and whether Synced or Ready carries one.
 func ExtractConditionObservedGeneration(status map[string]interface{}) (int64, bool) {
 	conditionsRaw, ok, _ := unstructured.NestedSlice(status, "conditions")
 	if !ok {
 		return 0, false
 	}
 
-	// Single pass: find Synced or Ready, preferring Synced
-	var readyObsGen int64
-	var foundReady bool
-
+	var highest int64
+	var found bool
 	for _, c := range conditionsRaw {
 		condMap, ok := c.(map[string]interface{})
 		if !ok {
 			continue
 		}
 		condType, _, _ := unstructured.NestedString(condMap, "type")
+		if condType != ConditionTypeSynced && condType != ConditionTypeReady {
+			continue
+		}
 		obsGen, hasObsGen, _ := unstructured.NestedInt64(condMap, "observedGeneration")
 		if !hasObsGen {
 			continue
 		}
-		if condType == ConditionTypeSynced {
-			return obsGen, true // Synced takes priority, return immediately
-		}
-		if condType == ConditionTypeReady {
-			readyObsGen = obsGen
-			foundReady = true
+		if !found || obsGen > highest {
+			highest = obsGen
+			found = true
 		}
 	}
 
-	return readyObsGen, foundReady
+	return highest, found
 }
 
 // ExtractConditions extracts metav1.Condition list from status map.
@@ -191,5 +361,6 @@ func ParentRefFromOwnerRef(ref metav1.OwnerReference, namespace string) ParentRe
 		Kind:       ref.Kind,
 		Namespace:  namespace,
 		Name:       ref.Name,
+		UID:        ref.UID,
 	}
 }
@@ -1,6 +1,7 @@
 package drift
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -8,9 +9,14 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	kausalityv1alpha1 "github.com/kausality-io/kausality/api/v1alpha1"
+	"github.com/kausality-io/kausality/pkg/config"
 	"github.com/kausality-io/kausality/pkg/controller"
 )
 
@@ -396,7 +402,7 @@ func TestExtractConditionObservedGeneration(t *testing.T) {
 			wantFound: true,
 		},
 		{
-			name: "both Synced and Ready - prefers Synced",
+			name: "both Synced and Ready",
 			status: map[string]interface{}{
 				"conditions": []interface{}{
 					map[string]interface{}{
@@ -432,6 +438,25 @@ func TestExtractConditionObservedGeneration(t *testing.T) {
 			wantObsG:  9,
 			wantFound: true,
 		},
+		{
+			name: "highest observedGeneration wins",
+			status: map[string]interface{}{
+				"conditions": []interface{}{
+					map[string]interface{}{
+						"type":               "Synced",
+						"status":             "True",
+						"observedGeneration": int64(5),
+					},
+					map[string]interface{}{
+						"type":               "Ready",
+						"status":             "False",
+						"observedGeneration": int64(6),
+					},
+				},
+			},
+			wantObsG:  6,
+			wantFound: true,
+		},
 		{
 			name: "other condition types are ignored",
 			status: map[string]interface{}{
@@ -586,3 +611,377 @@ func TestExtractParentState_CrossplaneConditions(t *testing.T) {
 		})
 	}
 }
+
+func TestParentOwnerRef(t *testing.T) {
+	refs := []config.ParentReference{
+		{APIGroup: "example.com", Kind: "Widget", ParentAPIVersion: "example.com/v1", ParentKind: "Cluster", Label: "example.com/cluster"},
+		{APIGroup: "example.com", Kind: "Gadget", ParentAPIVersion: "example.com/v1", ParentKind: "Cluster", FieldPath: "spec.clusterRef.name"},
+	}
+	child := func(kind string, labels map[string]string, spec map[string]interface{}, owners ...metav1.OwnerReference) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{Object: map[string]interface{}{}}
+		u.SetAPIVersion("example.com/v1")
+		u.SetKind(kind)
+		u.SetName("child")
+		u.SetLabels(labels)
+		u.SetOwnerReferences(owners)
+		if spec != nil {
+			u.Object["spec"] = spec
+		}
+		return u
+	}
+	owner := metav1.OwnerReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "owner", UID: "owner-uid", Controller: ptr.To(true)}
+	annotated := func(u *unstructured.Unstructured, parent string) *unstructured.Unstructured {
+		u.SetNamespace("default")
+		u.SetAnnotations(map[string]string{kausalityv1alpha1.ParentAnnotation: parent})
+		return u
+	}
+
+	tests := []struct {
+		name string
+		obj  *unstructured.Unstructured
+		want *metav1.OwnerReference
+	}{
+		{
+			name: "label reference",
+			obj:  child("Widget", map[string]string{"example.com/cluster": "prod"}, nil),
+			want: &metav1.OwnerReference{APIVersion: "example.com/v1", Kind: "Cluster", Name: "prod", Controller: ptr.To(true)},
+		},
+		{
+			name: "field reference",
+			obj:  child("Gadget", nil, map[string]interface{}{"clusterRef": map[string]interface{}{"name": "staging"}}),
+			want: &metav1.OwnerReference{APIVersion: "example.com/v1", Kind: "Cluster", Name: "staging", Controller: ptr.To(true)},
+		},
+		{
+			name: "ownerReference takes precedence",
+			obj:  child("Widget", map[string]string{"example.com/cluster": "prod"}, nil, owner),
+			want: &owner,
+		},
+		{
+			name: "parent annotation",
+			obj:  annotated(child("Gizmo", nil, nil), "apps/v1/Deployment/default/web"),
+			want: &metav1.OwnerReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "web", Controller: ptr.To(true)},
+		},
+		{
+			name: "parent annotation of a cluster-scoped parent",
+			obj:  annotated(child("Gizmo", nil, nil), "example.com/v1/Cluster//prod"),
+			want: &metav1.OwnerReference{APIVersion: "example.com/v1", Kind: "Cluster", Name: "prod", Controller: ptr.To(true)},
+		},
+		{
+			name: "parent annotation with a non-controller ownerReference",
+			obj: annotated(child("Gizmo", nil, nil, metav1.OwnerReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "owner", UID: "owner-uid"}),
+				"apps/v1/Deployment/default/web"),
+			want: &metav1.OwnerReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "web", Controller: ptr.To(true)},
+		},
+		{
+			name: "ownerReference takes precedence over parent annotation",
+			obj:  annotated(child("Gizmo", nil, nil, owner), "apps/v1/Deployment/default/web"),
+			want: &owner,
+		},
+		{
+			name: "parent annotation takes precedence over declared reference",
+			obj:  annotated(child("Widget", map[string]string{"example.com/cluster": "prod"}, nil), "apps/v1/Deployment/default/web"),
+			want: &metav1.OwnerReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "web", Controller: ptr.To(true)},
+		},
+		{
+			name: "malformed parent annotation",
+			obj:  annotated(child("Widget", map[string]string{"example.com/cluster": "prod"}, nil), "Deployment/web"),
+		},
+		{
+			name: "parent annotation in another namespace",
+			obj:  annotated(child("Gizmo", nil, nil), "apps/v1/Deployment/other/web"),
+		},
+		{
+			name: "reference not set",
+			obj:  child("Widget", map[string]string{"app": "x"}, nil),
+		},
+		{
+			name: "kind without reference",
+			obj:  child("Gizmo", map[string]string{"example.com/cluster": "prod"}, nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ParentOwnerRef(tt.obj, refs, kausalityv1alpha1.DefaultAnnotationKeys))
+		})
+	}
+}
+
+func TestParseParentAnnotation(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    ParentRef
+		wantErr bool
+	}{
+		{value: "apps/v1/Deployment/default/web", want: ParentRef{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "web"}},
+		{value: "v1/ConfigMap/default/web", want: ParentRef{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "web"}},
+		{value: "v1/Namespace//team-a", want: ParentRef{APIVersion: "v1", Kind: "Namespace", Name: "team-a"}},
+		{value: "", wantErr: true},
+		{value: "Deployment/default/web", wantErr: true},
+		{value: "a/b/c/Deployment/default/web", wantErr: true},
+		{value: "apps/v1/Deployment/default/", wantErr: true},
+		{value: "apps/v1//default/web", wantErr: true},
+		{value: "apps//Deployment/default/web", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseParentAnnotation(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestResolveParent_ParentAnnotation(t *testing.T) {
+	parent := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]interface{}{"name": "web", "namespace": "default", "generation": int64(2)},
+		"status":     map[string]interface{}{"observedGeneration": int64(2)},
+	}}
+	resolver := NewParentResolver(fake.NewClientBuilder().WithObjects(parent).Build())
+
+	child := &unstructured.Unstructured{}
+	child.SetAPIVersion("v1")
+	child.SetKind("ConfigMap")
+	child.SetNamespace("default")
+	child.SetName("web-config")
+
+	// Without ownerReference or annotation, there is no parent
+	state, err := resolver.ResolveParent(t.Context(), child)
+	require.NoError(t, err)
+	assert.Nil(t, state)
+
+	child.SetAnnotations(map[string]string{kausalityv1alpha1.ParentAnnotation: "apps/v1/Deployment/default/web"})
+	state, err = resolver.ResolveParent(t.Context(), child)
+	require.NoError(t, err)
+	require.NotNil(t, state)
+	assert.Equal(t, ParentRef{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "web"}, state.Ref)
+	assert.Equal(t, int64(2), state.Generation)
+
+	// A controller ownerReference wins over the annotation
+	child.SetOwnerReferences([]metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "Deployment", Name: "other", UID: "other-uid", Controller: ptr.To(true)}})
+	_, err = resolver.ResolveParent(t.Context(), child)
+	require.True(t, apierrors.IsNotFound(err), "expected the ownerReference's parent to be looked up, got %v", err)
+}
+
+func TestResolveParent_ParentReferences(t *testing.T) {
+	parent := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]interface{}{"name": "web", "namespace": "default", "generation": int64(2)},
+		"status":     map[string]interface{}{"observedGeneration": int64(1)},
+	}}
+	resolver := NewParentResolver(fake.NewClientBuilder().WithObjects(parent).Build())
+	resolver.SetParentReferences([]config.ParentReference{
+		{Kind: "ConfigMap", ParentAPIVersion: "apps/v1", ParentKind: "Deployment", Label: "app.kubernetes.io/instance"},
+	})
+
+	child := &unstructured.Unstructured{}
+	child.SetAPIVersion("v1")
+	child.SetKind("ConfigMap")
+	child.SetNamespace("default")
+	child.SetName("web-config")
+
+	child.SetLabels(map[string]string{"app.kubernetes.io/instance": "web"})
+	state, err := resolver.ResolveParent(t.Context(), child)
+	require.NoError(t, err)
+	require.NotNil(t, state)
+	assert.Equal(t, ParentRef{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "web"}, state.Ref)
+	assert.Equal(t, int64(2), state.Generation)
+	assert.Equal(t, int64(1), state.ObservedGeneration)
+
+	// A dangling reference is no parent
+	child.SetLabels(map[string]string{"app.kubernetes.io/instance": "gone"})
+	state, err = resolver.ResolveParent(t.Context(), child)
+	require.NoError(t, err)
+	assert.Nil(t, state)
+}
+
+func TestResolveParent_ParentFetcher(t *testing.T) {
+	parent := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]interface{}{"name": "web", "namespace": "default", "uid": "web-uid", "generation": int64(3)},
+	}}
+	// The client has no parent: it must come from the fetcher
+	resolver := NewParentResolver(fake.NewClientBuilder().Build())
+	var fetched []ParentRef
+	resolver.SetParentFetcher(func(_ context.Context, ref ParentRef, childNamespace string) (*unstructured.Unstructured, error) {
+		fetched = append(fetched, ref)
+		assert.Equal(t, "default", childNamespace)
+		return parent.DeepCopy(), nil
+	})
+
+	child := &unstructured.Unstructured{}
+	child.SetAPIVersion("apps/v1")
+	child.SetKind("ReplicaSet")
+	child.SetNamespace("default")
+	child.SetName("web-abc")
+	child.SetOwnerReferences([]metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "Deployment", Name: "web", UID: "web-uid", Controller: ptr.To(true)}})
+
+	state, err := resolver.ResolveParent(t.Context(), child)
+	require.NoError(t, err)
+	require.NotNil(t, state)
+	assert.Equal(t, int64(3), state.Generation)
+	assert.Equal(t, []ParentRef{{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "web", UID: "web-uid"}}, fetched)
+}
+
+// xservice returns an unstructured Crossplane composite shaped like the e2e XService,
+// which records observedGeneration only in its Synced and Ready conditions.
+func xservice(generation, syncedObsGen, readyObsGen int64, ready string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "test.kausality.io/v1alpha1",
+		"kind":       "XService",
+		"metadata":   map[string]interface{}{"name": "svc", "namespace": "default", "generation": generation},
+		"spec":       map[string]interface{}{"replicas": int64(2)},
+		"status": map[string]interface{}{
+			"conditions": []interface{}{
+				map[string]interface{}{
+					"type":               "Synced",
+					"status":             "True",
+					"reason":             "ReconcileSuccess",
+					"lastTransitionTime": "2026-01-01T00:00:00Z",
+					"observedGeneration": syncedObsGen,
+				},
+				map[string]interface{}{
+					"type":               "Ready",
+					"status":             ready,
+					"reason":             "Available",
+					"lastTransitionTime": "2026-01-01T00:00:00Z",
+					"observedGeneration": readyObsGen,
+				},
+			},
+		},
+	}}
+}
+
+func TestResolveParent_ConditionObservedGeneration(t *testing.T) {
+	tests := []struct {
+		name      string
+		parent    *unstructured.Unstructured
+		wantObsG  int64
+		wantPhase LifecyclePhase
+	}{
+		{
+			name:      "reconciled composite",
+			parent:    xservice(3, 3, 3, "True"),
+			wantObsG:  3,
+			wantPhase: PhaseInitialized,
+		},
+		{
+			name:      "Synced caught up, Ready lagging",
+			parent:    xservice(4, 4, 3, "True"),
+			wantObsG:  4,
+			wantPhase: PhaseInitialized,
+		},
+		{
+			name:      "spec change not yet observed",
+			parent:    xservice(5, 4, 4, "True"),
+			wantObsG:  4,
+			wantPhase: PhaseInitializing,
+		},
+		{
+			name:      "not ready yet",
+			parent:    xservice(2, 2, 2, "False"),
+			wantObsG:  2,
+			wantPhase: PhaseInitializing,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			child := &unstructured.Unstructured{}
+			child.SetAPIVersion("v1")
+			child.SetKind("ConfigMap")
+			child.SetNamespace("default")
+			child.SetName("svc-config")
+			child.SetOwnerReferences([]metav1.OwnerReference{{
+				APIVersion: "test.kausality.io/v1alpha1",
+				Kind:       "XService",
+				Name:       "svc",
+				Controller: ptr.To(true),
+			}})
+
+			resolver := NewParentResolver(fake.NewClientBuilder().WithObjects(tt.parent).Build())
+			state, err := resolver.ResolveParent(t.Context(), child)
+			require.NoError(t, err)
+			require.NotNil(t, state)
+			assert.True(t, state.HasObservedGeneration)
+			assert.Equal(t, tt.wantObsG, state.ObservedGeneration)
+
+			// Without a Ready condition, only the condition observedGeneration tells initialization
+			detector := &LifecycleDetector{DetectionOrder: []InitializationDetector{DetectByObservedGeneration}}
+			assert.Equal(t, tt.wantPhase, detector.DetectPhase(state))
+		})
+	}
+}
+
+func TestGetParent_Scopes(t *testing.T) {
+	composite := &unstructured.Unstructured{}
+	composite.SetAPIVersion("example.com/v1")
+	composite.SetKind("Composite")
+	composite.SetName("xr")
+	composite.SetUID("xr-uid")
+	deployment := &unstructured.Unstructured{}
+	deployment.SetAPIVersion("apps/v1")
+	deployment.SetKind("Deployment")
+	deployment.SetNamespace("default")
+	deployment.SetName("web")
+	c := fake.NewClientBuilder().WithObjects(composite, deployment).Build()
+
+	tests := []struct {
+		name           string
+		ref            ParentRef
+		childNamespace string
+		wantNamespace  string
+		wantNotFound   bool
+	}{
+		{
+			name: "cluster-scoped child, cluster-scoped parent",
+			ref:  ParentRef{APIVersion: "example.com/v1", Kind: "Composite", Name: "xr", UID: "xr-uid"},
+		},
+		{
+			name: "cluster-scoped child ignores the ref namespace",
+			ref:  ParentRef{APIVersion: "example.com/v1", Kind: "Composite", Namespace: "default", Name: "xr", UID: "xr-uid"},
+		},
+		{
+			name:           "namespaced child, parent in the child's namespace",
+			ref:            ParentRef{APIVersion: "apps/v1", Kind: "Deployment", Name: "web"},
+			childNamespace: "default",
+			wantNamespace:  "default",
+		},
+		{
+			name:           "namespaced child, cluster-scoped parent confirmed by UID",
+			ref:            ParentRef{APIVersion: "example.com/v1", Kind: "Composite", Namespace: "default", Name: "xr", UID: "xr-uid"},
+			childNamespace: "default",
+		},
+		{
+			name:           "namespaced child, cluster-scoped parent with other UID",
+			ref:            ParentRef{APIVersion: "example.com/v1", Kind: "Composite", Namespace: "default", Name: "xr", UID: "other-uid"},
+			childNamespace: "default",
+			wantNotFound:   true,
+		},
+		{
+			name:           "namespaced child, cluster-scoped parent without UID",
+			ref:            ParentRef{APIVersion: "example.com/v1", Kind: "Composite", Name: "xr"},
+			childNamespace: "default",
+			wantNotFound:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parent, err := GetParent(t.Context(), c, tt.ref, tt.childNamespace)
+			if tt.wantNotFound {
+				assert.True(t, apierrors.IsNotFound(err), "expected NotFound, got %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.ref.Name, parent.GetName())
+			assert.Equal(t, tt.wantNamespace, parent.GetNamespace())
+		})
+	}
+}
@@ -0,0 +1,234 @@
+package drift
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kausality-io/kausality/pkg/config"
+)
+
+// FieldDiff is a spec field that differs between two objects. Old is nil for added
+// fields, New for removed ones.
+type FieldDiff struct {
+	// Path is the dotted field path, e.g. "spec.replicas" or "spec.containers[0].image".
+	Path string
+	// Pointer is the JSON Pointer of the field, e.g. "/spec/containers/0/image".
+	Pointer string
+	Old     interface{}
+	New     interface{}
+}
+
+// ExtractSpec returns a copy of the object's spec, with the keyed arrays declared by
+// mergeKeys normalized. It returns nil for a nil object or an object without spec.
+// This is the spec that spec change detection compares.
+func ExtractSpec(obj *unstructured.Unstructured, mergeKeys []config.ArrayMergeKey) interface{} {
+	if obj == nil {
+		return nil
+	}
+	spec, _, _ := unstructured.NestedFieldCopy(obj.Object, "spec")
+	NormalizeSpec(spec, mergeKeys)
+	return spec
+}
+
+// EqualSpec compares two spec values for equality.
+func EqualSpec(a, b interface{}) bool {
+	if a == nil && b == nil {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+
+	// Use JSON encoding for deep comparison
+	aJSON, err := runtime.Encode(unstructured.UnstructuredJSONScheme, &unstructured.Unstructured{Object: map[string]interface{}{"spec": a}})
+	if err != nil {
+		return false
+	}
+	bJSON, err := runtime.Encode(unstructured.UnstructuredJSONScheme, &unstructured.Unstructured{Object: map[string]interface{}{"spec": b}})
+	if err != nil {
+		return false
+	}
+
+	return string(aJSON) == string(bJSON)
+}
+
+// DiffSpec returns the leaf fields that differ between two extracted specs, sorted by
+// path. Maps are walked, lists of equal length are compared element-wise, and other
+// lists as a whole.
+func DiffSpec(oldSpec, newSpec interface{}) []FieldDiff {
+	var diffs []FieldDiff
+	collectDiffs("spec", "/spec", oldSpec, newSpec, &diffs)
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Path < diffs[j].Path })
+	return diffs
+}
+
+// collectDiffs appends the differences between old and new below path. Maps added or
+// removed as a whole are walked like an empty map.
+func collectDiffs(path, pointer string, old, new interface{}, diffs *[]FieldDiff) {
+	if _, ok := new.(map[string]interface{}); ok && old == nil {
+		old = map[string]interface{}{}
+	}
+	if _, ok := old.(map[string]interface{}); ok && new == nil {
+		new = map[string]interface{}{}
+	}
+	switch oldValue := old.(type) {
+	case map[string]interface{}:
+		if newValue, ok := new.(map[string]interface{}); ok {
+			for key, v := range oldValue {
+				collectDiffs(path+"."+key, pointer+"/"+escapePointer(key), v, newValue[key], diffs)
+			}
+			for key, v := range newValue {
+				if _, ok := oldValue[key]; !ok {
+					collectDiffs(path+"."+key, pointer+"/"+escapePointer(key), nil, v, diffs)
+				}
+			}
+			return
+		}
+	case []interface{}:
+		if newValue, ok := new.([]interface{}); ok && len(oldValue) == len(newValue) {
+			for i := range oldValue {
+				collectDiffs(fmt.Sprintf("%s[%d]", path, i), fmt.Sprintf("%s/%d", pointer, i), oldValue[i], newValue[i], diffs)
+			}
+			return
+		}
+	}
+	if !reflect.DeepEqual(old, new) {
+		*diffs = append(*diffs, FieldDiff{Path: path, Pointer: pointer, Old: old, New: new})
+	}
+}
+
+// escapePointer escapes a key as a JSON Pointer reference token (RFC 6901).
+func escapePointer(key string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+}
+
+// RemoveSpecPaths removes the fields at the given JSON Pointers, e.g.
+// "/spec/template/metadata/annotations/kubectl.kubernetes.io~1restartedAt", from an
+// extracted spec in place and returns it. Pointers must start with "/spec/"; numeric
+// reference tokens index into arrays. Missing paths are skipped. Maps left empty by a
+// removal are removed as well, so adding a map that only holds an ignored field is no
+// spec change.
+func RemoveSpecPaths(spec interface{}, pointers []string) interface{} {
+	for _, pointer := range pointers {
+		rest, ok := strings.CutPrefix(pointer, "/spec/")
+		if !ok {
+			continue
+		}
+		tokens := strings.Split(rest, "/")
+		for i, token := range tokens {
+			tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		}
+		spec, _ = removePath(spec, tokens)
+	}
+	return spec
+}
+
+// removePath removes the field at tokens below node and returns the resulting node and
+// whether a field was removed.
+func removePath(node interface{}, tokens []string) (interface{}, bool) {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		child, ok := v[tokens[0]]
+		if !ok {
+			return node, false
+		}
+		if len(tokens) == 1 {
+			delete(v, tokens[0])
+			return v, true
+		}
+		child, removed := removePath(child, tokens[1:])
+		if m, ok := child.(map[string]interface{}); ok && removed && len(m) == 0 {
+			delete(v, tokens[0])
+		} else {
+			v[tokens[0]] = child
+		}
+		return v, removed
+	case []interface{}:
+		i, err := strconv.Atoi(tokens[0])
+		if err != nil || i < 0 || i >= len(v) {
+			return node, false
+		}
+		if len(tokens) == 1 {
+			return append(v[:i:i], v[i+1:]...), true
+		}
+		var removed bool
+		v[i], removed = removePath(v[i], tokens[1:])
+		return v, removed
+	}
+	return node, false
+}
+
+// NormalizeSpec sorts the keyed arrays declared by mergeKeys in place, so that
+// specs differing only in the order of those arrays compare equal.
+// Arrays with elements lacking the key are left untouched.
+func NormalizeSpec(spec interface{}, mergeKeys []config.ArrayMergeKey) {
+	for _, mk := range mergeKeys {
+		segments := strings.Split(mk.Path, ".")
+		if len(segments) < 2 || segments[0] != "spec" {
+			continue
+		}
+		normalizeAtPath(spec, segments[1:], mk.Key)
+	}
+}
+
+// normalizeAtPath walks segments from node and sorts the array at the end of the path.
+func normalizeAtPath(node interface{}, segments []string, key string) {
+	m, ok := node.(map[string]interface{})
+	if !ok {
+		return
+	}
+	name, each := strings.CutSuffix(segments[0], "[]")
+	if len(segments) == 1 {
+		if arr, ok := m[name].([]interface{}); ok {
+			sortByKey(arr, key)
+		}
+		return
+	}
+	if !each {
+		normalizeAtPath(m[name], segments[1:], key)
+		return
+	}
+	arr, ok := m[name].([]interface{})
+	if !ok {
+		return
+	}
+	for _, elem := range arr {
+		normalizeAtPath(elem, segments[1:], key)
+	}
+}
+
+// sortByKey sorts array elements by the value of their key field.
+func sortByKey(arr []interface{}, key string) {
+	keys := make([]string, len(arr))
+	for i, elem := range arr {
+		m, ok := elem.(map[string]interface{})
+		if !ok {
+			return
+		}
+		v, ok := m[key]
+		if !ok {
+			return
+		}
+		keys[i] = fmt.Sprint(v)
+	}
+	sort.Stable(keyedArray{arr: arr, keys: keys})
+}
+
+// keyedArray sorts an array together with its precomputed keys.
+type keyedArray struct {
+	arr  []interface{}
+	keys []string
+}
+
+func (k keyedArray) Len() int           { return len(k.arr) }
+func (k keyedArray) Less(i, j int) bool { return k.keys[i] < k.keys[j] }
+func (k keyedArray) Swap(i, j int) {
+	k.arr[i], k.arr[j] = k.arr[j], k.arr[i]
+	k.keys[i], k.keys[j] = k.keys[j], k.keys[i]
+}
@@ -0,0 +1,80 @@
+package drift
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffSpec(t *testing.T) {
+	oldSpec := map[string]interface{}{
+		"replicas": int64(1),
+		"color":    "blue",
+		"ports":    []interface{}{int64(80)},
+		"selector": map[string]interface{}{"app": "web"},
+	}
+	newSpec := map[string]interface{}{
+		"replicas": int64(1),
+		"ports":    []interface{}{int64(80), int64(443)},
+		"selector": map[string]interface{}{"app": "web", "tier": "frontend"},
+	}
+	assert.Equal(t, []FieldDiff{
+		{Path: "spec.color", Pointer: "/spec/color", Old: "blue"},
+		{Path: "spec.ports", Pointer: "/spec/ports", Old: []interface{}{int64(80)}, New: []interface{}{int64(80), int64(443)}},
+		{Path: "spec.selector.tier", Pointer: "/spec/selector/tier", New: "frontend"},
+	}, DiffSpec(oldSpec, newSpec))
+
+	assert.Empty(t, DiffSpec(oldSpec, oldSpec))
+	assert.Equal(t, "/spec/selector/app.kubernetes.io~1name",
+		DiffSpec(nil, map[string]interface{}{"selector": map[string]interface{}{"app.kubernetes.io/name": "web"}})[0].Pointer)
+	assert.Equal(t, []FieldDiff{{Path: "spec.replicas", Pointer: "/spec/replicas", Old: int64(1)}}, DiffSpec(map[string]interface{}{"replicas": int64(1)}, nil))
+}
+
+func TestRemoveSpecPaths(t *testing.T) {
+	const spec = `{
+		"replicas": 1,
+		"template": {
+			"metadata": {"annotations": {"kubectl.kubernetes.io/restartedAt": "now"}},
+			"containers": [{"name": "a", "image": "a:1"}, {"name": "b", "image": "b:1"}]
+		}
+	}`
+
+	tests := []struct {
+		name     string
+		pointers []string
+		want     string
+	}{
+		{
+			name:     "escaped key, emptied maps are removed",
+			pointers: []string{"/spec/template/metadata/annotations/kubectl.kubernetes.io~1restartedAt"},
+			want:     `{"replicas": 1, "template": {"containers": [{"name": "a", "image": "a:1"}, {"name": "b", "image": "b:1"}]}}`,
+		},
+		{
+			name:     "field of an array element",
+			pointers: []string{"/spec/template/containers/1/image"},
+			want:     `{"replicas": 1, "template": {"metadata": {"annotations": {"kubectl.kubernetes.io/restartedAt": "now"}}, "containers": [{"name": "a", "image": "a:1"}, {"name": "b"}]}}`,
+		},
+		{
+			name:     "array element",
+			pointers: []string{"/spec/template/containers/0"},
+			want:     `{"replicas": 1, "template": {"metadata": {"annotations": {"kubectl.kubernetes.io/restartedAt": "now"}}, "containers": [{"name": "b", "image": "b:1"}]}}`,
+		},
+		{
+			name:     "missing paths are skipped",
+			pointers: []string{"/spec/paused", "/spec/template/containers/5/image", "/spec/template/containers/x", "/spec/replicas/value", "/metadata/labels"},
+			want:     spec,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got, want interface{}
+			require.NoError(t, json.Unmarshal([]byte(spec), &got))
+			require.NoError(t, json.Unmarshal([]byte(tt.want), &want))
+			assert.Equal(t, want, RemoveSpecPaths(got, tt.pointers))
+		})
+	}
+
+	assert.Nil(t, RemoveSpecPaths(nil, []string{"/spec/replicas"}))
+}
@@ -3,6 +3,8 @@ package drift
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 // DriftResult represents the outcome of drift detection.
@@ -19,6 +21,27 @@ type DriftResult struct {
 	ParentState *ParentState
 	// LifecyclePhase indicates the parent's lifecycle phase.
 	LifecyclePhase LifecyclePhase
+	// ControllerUnknown indicates that the controller identity could not be determined
+	// from the updater hashes.
+	ControllerUnknown bool
+	// OwnershipConflicts lists spec fields whose server-side apply ownership the request
+	// took from other field managers.
+	OwnershipConflicts []OwnershipConflict
+	// ClearedFields lists spec fields set by someone else that the controller removed or
+	// reset to their zero value.
+	ClearedFields []string
+	// Recreated is true for a CREATE of a child the same controller just deleted.
+	Recreated bool
+	// FieldDiffs lists the spec fields that differ from the live object. Only set by
+	// Detector.DetectDryRun.
+	FieldDiffs []FieldDiff
+	// EvaluatedParents lists the parents the change was evaluated against, in the order
+	// of the child's ownerReferences with the controller first. Only set with the
+	// "allOwners" owner selection.
+	EvaluatedParents []ParentRef
+	// DecidingParent is the parent of EvaluatedParents whose evaluation is the result.
+	// Only set with the "allOwners" owner selection.
+	DecidingParent *ParentRef
 }
 
 // ParentRef identifies the parent object.
@@ -31,6 +54,9 @@ type ParentRef struct {
 	Namespace string
 	// Name of the parent object.
 	Name string
+	// UID of the parent object, from the ownerReference. Empty for parents named by
+	// a parent reference.
+	UID types.UID
 }
 
 // String returns a human-readable representation of the parent reference.
@@ -47,22 +73,40 @@ type ParentState struct {
 	Ref ParentRef
 	// Generation is the parent's metadata.generation.
 	Generation int64
-	// ObservedGeneration is the parent's status.observedGeneration.
+	// ObservedGeneration is the parent's status.observedGeneration, or the highest
+	// observedGeneration of its Synced and Ready conditions if the top-level field is absent.
 	ObservedGeneration int64
-	// HasObservedGeneration indicates whether status.observedGeneration exists.
+	// HasObservedGeneration indicates whether status.observedGeneration or a Synced or Ready
+	// condition observedGeneration exists.
 	HasObservedGeneration bool
 	// Controllers contains user hashes from kausality.io/controllers annotation.
 	// These are users who have updated the parent's status.
 	Controllers []string
+	// ControllerServiceAccounts contains the "<namespace>/<name>" service accounts from
+	// the kausality.io/controllers-sa annotation, of the users in Controllers that
+	// authenticated as service accounts.
+	ControllerServiceAccounts []string
 	// DeletionTimestamp is set if the parent is being deleted.
 	DeletionTimestamp *metav1.Time
+	// Paused is true if the parent's spec.paused is true, e.g. a paused Deployment.
+	Paused bool
 	// Conditions are the parent's status conditions for lifecycle detection.
 	Conditions []metav1.Condition
+	// Status is the parent's raw status, for classifiers to inspect. Nil if
+	// the parent has no status.
+	Status map[string]interface{}
 	// IsInitialized indicates whether the parent has completed initialization.
 	IsInitialized bool
+	// StatusUpdatedAt is the latest time of the parent's managedFields entries for the
+	// status subresource. Nil if there are none or they carry no time.
+	StatusUpdatedAt *metav1.Time
 	// PhaseFromAnnotation is the value of kausality.io/phase annotation.
 	// Used to determine if phase needs to be recorded (lazy fetch optimization).
 	PhaseFromAnnotation string
+	// Object is the parent object the state was extracted from. Callers reuse it
+	// rather than fetching the parent again, so that all decisions about a request
+	// see the same parent generation. Nil if the state was not read from the API.
+	Object *unstructured.Unstructured
 }
 
 // LifecyclePhase represents the lifecycle phase of a parent object.
@@ -75,6 +119,12 @@ const (
 	PhaseInitialized LifecyclePhase = "Initialized"
 	// PhaseDeleting indicates the parent is being deleted.
 	PhaseDeleting LifecyclePhase = "Deleting"
+	// PhasePaused indicates the parent's spec.paused is true. Its controller does not
+	// reconcile it, so every change of a child is a user change.
+	PhasePaused LifecyclePhase = "Paused"
+	// PhaseReconciling indicates an initialized parent whose observedGeneration caught up
+	// within the stabilization grace period, so its controller may still be finishing.
+	PhaseReconciling LifecyclePhase = "Reconciling"
 )
 
 // Condition types used for initialization and observedGeneration detection.
@@ -93,7 +143,8 @@ const (
 	DetectByInitializedCondition InitializationDetector = iota
 	// DetectByReadyCondition checks for Ready=True condition.
 	DetectByReadyCondition
-	// DetectByObservedGeneration checks for status.observedGeneration existence.
+	// DetectByObservedGeneration checks that status.observedGeneration (or a condition
+	// observedGeneration) caught up with the generation and a ready-like condition is True.
 	DetectByObservedGeneration
 )
 
@@ -0,0 +1,65 @@
+// Package health provides cluster health signals used to relax enforcement during incidents.
+package health
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DefaultConfigMapKey is the ConfigMap data key read by ConfigMapSignal if none is configured.
+const DefaultConfigMapKey = "healthy"
+
+// Signal reports whether the cluster is healthy.
+type Signal interface {
+	// Healthy returns false if the cluster is unhealthy and enforcement should be relaxed.
+	Healthy(ctx context.Context) (bool, error)
+}
+
+// ConfigMapSignal reads cluster health from a boolean ConfigMap entry.
+// A missing ConfigMap or key means healthy, so the signal must be set explicitly to
+// downgrade enforcement.
+type ConfigMapSignal struct {
+	reader client.Reader
+	key    types.NamespacedName
+	data   string
+}
+
+// NewConfigMapSignal creates a signal reading data[key] of the ConfigMap namespace/name.
+// An empty key defaults to DefaultConfigMapKey.
+func NewConfigMapSignal(reader client.Reader, namespace, name, key string) *ConfigMapSignal {
+	if key == "" {
+		key = DefaultConfigMapKey
+	}
+	return &ConfigMapSignal{
+		reader: reader,
+		key:    types.NamespacedName{Namespace: namespace, Name: name},
+		data:   key,
+	}
+}
+
+// Healthy implements Signal.
+func (s *ConfigMapSignal) Healthy(ctx context.Context) (bool, error) {
+	var cm corev1.ConfigMap
+	if err := s.reader.Get(ctx, s.key, &cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return true, fmt.Errorf("failed to get health ConfigMap %s: %w", s.key, err)
+	}
+	value, ok := cm.Data[s.data]
+	if !ok {
+		return true, nil
+	}
+	healthy, err := strconv.ParseBool(strings.TrimSpace(value))
+	if err != nil {
+		return true, fmt.Errorf("invalid value %q for key %q in health ConfigMap %s: %w", value, s.data, s.key, err)
+	}
+	return healthy, nil
+}
@@ -0,0 +1,51 @@
+package health
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestConfigMapSignal(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        map[string]string
+		missing     bool
+		key         string
+		wantHealthy bool
+		wantErr     bool
+	}{
+		{name: "missing ConfigMap is healthy", missing: true, wantHealthy: true},
+		{name: "missing key is healthy", data: map[string]string{"other": "false"}, wantHealthy: true},
+		{name: "true", data: map[string]string{"healthy": "true"}, wantHealthy: true},
+		{name: "false", data: map[string]string{"healthy": "false"}, wantHealthy: false},
+		{name: "custom key", key: "ok", data: map[string]string{"ok": " false\n"}, wantHealthy: false},
+		{name: "invalid value keeps healthy", data: map[string]string{"healthy": "maybe"}, wantHealthy: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := fake.NewClientBuilder()
+			if !tt.missing {
+				builder = builder.WithObjects(&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "kausality-system", Name: "cluster-health"},
+					Data:       tt.data,
+				})
+			}
+			signal := NewConfigMapSignal(builder.Build(), "kausality-system", "cluster-health", tt.key)
+
+			healthy, err := signal.Healthy(t.Context())
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantHealthy, healthy)
+		})
+	}
+}
@@ -0,0 +1,200 @@
+// Package lineage exports kausality traces as OpenLineage run events.
+//
+// A traced mutation maps onto OpenLineage as follows:
+//
+//	RunEvent field                   kausality source
+//	-------------------------------  ------------------------------------------------------
+//	eventType                        always COMPLETE (admission is a single step)
+//	eventTime                        timestamp of the last trace hop
+//	producer                         Producer
+//	schemaURL                        RunEventSchemaURL
+//	run.runId                        admission request UID of the last hop
+//	run.facets.parent                origin hop: runId = origin request UID, job = origin user
+//	run.facets.kausality_trace       the full trace (custom facet)
+//	job.namespace                    configured job namespace (default "kausality")
+//	job.name                         user of the last hop (human/CI at origin, controller otherwise)
+//	inputs[0]                        object of the previous hop (the parent), omitted at origin
+//	outputs[0]                       the mutated object
+//	outputs[0].facets.lifecycle...   CREATE, ALTER or DROP for CREATE, UPDATE or DELETE
+//
+// Dataset names are "<apiVersion>/<kind>/<namespace>/<name>" (namespace omitted for
+// cluster-scoped objects) in the configured dataset namespace (default "kubernetes").
+package lineage
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/kausality-io/kausality/api/v1alpha1"
+)
+
+const (
+	// Producer identifies kausality as the producer of events and facets.
+	Producer = "https://github.com/kausality-io/kausality"
+	// RunEventSchemaURL is the OpenLineage RunEvent schema events conform to.
+	RunEventSchemaURL = "https://openlineage.io/spec/2-0-2/OpenLineage.json#/$defs/RunEvent"
+	// TraceFacetSchemaURL identifies the schema of the custom kausality_trace run facet.
+	TraceFacetSchemaURL = Producer + "/blob/main/doc/design/TRACING.md#openlineage"
+
+	parentRunFacetSchemaURL = "https://openlineage.io/spec/facets/1-0-1/ParentRunFacet.json#/$defs/ParentRunFacet"
+	lifecycleFacetSchemaURL = "https://openlineage.io/spec/facets/1-0-1/LifecycleStateChangeDatasetFacet.json#/$defs/LifecycleStateChangeDatasetFacet"
+)
+
+// Lifecycle state changes reported for the mutated object.
+const (
+	LifecycleCreate = "CREATE"
+	LifecycleAlter  = "ALTER"
+	LifecycleDrop   = "DROP"
+)
+
+// RunEvent is an OpenLineage run event.
+type RunEvent struct {
+	EventType string    `json:"eventType"`
+	EventTime time.Time `json:"eventTime"`
+	Producer  string    `json:"producer"`
+	SchemaURL string    `json:"schemaURL"`
+	Run       Run       `json:"run"`
+	Job       Job       `json:"job"`
+	Inputs    []Dataset `json:"inputs"`
+	Outputs   []Dataset `json:"outputs"`
+}
+
+// Run identifies the run and carries run facets.
+type Run struct {
+	RunID  string    `json:"runId"`
+	Facets RunFacets `json:"facets,omitempty"`
+}
+
+// RunFacets are the run facets kausality emits.
+type RunFacets struct {
+	Parent         *ParentRunFacet `json:"parent,omitempty"`
+	KausalityTrace *TraceFacet     `json:"kausality_trace,omitempty"`
+}
+
+// Facet holds the fields common to all facets.
+type Facet struct {
+	Producer  string `json:"_producer"`
+	SchemaURL string `json:"_schemaURL"`
+}
+
+// ParentRunFacet links the run to the origin of its causal chain.
+type ParentRunFacet struct {
+	Facet
+	Run ParentRun `json:"run"`
+	Job Job       `json:"job"`
+}
+
+// ParentRun identifies the parent run.
+type ParentRun struct {
+	RunID string `json:"runId"`
+}
+
+// TraceFacet carries the kausality trace of the mutation.
+type TraceFacet struct {
+	Facet
+	Trace v1alpha1.Trace `json:"trace"`
+}
+
+// Job identifies the producer of a change.
+type Job struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// Dataset identifies a Kubernetes object.
+type Dataset struct {
+	Namespace string         `json:"namespace"`
+	Name      string         `json:"name"`
+	Facets    *DatasetFacets `json:"facets,omitempty"`
+}
+
+// DatasetFacets are the dataset facets kausality emits.
+type DatasetFacets struct {
+	LifecycleStateChange *LifecycleStateChangeFacet `json:"lifecycleStateChange,omitempty"`
+}
+
+// LifecycleStateChangeFacet describes how the object changed.
+type LifecycleStateChangeFacet struct {
+	Facet
+	LifecycleStateChange string `json:"lifecycleStateChange"`
+}
+
+// Object identifies the mutated Kubernetes object.
+type Object struct {
+	APIVersion string
+	Kind       string
+	Namespace  string
+	Name       string
+}
+
+// datasetName returns the dataset name of an object.
+func (o Object) datasetName() string {
+	if o.Namespace == "" {
+		return fmt.Sprintf("%s/%s/%s", o.APIVersion, o.Kind, o.Name)
+	}
+	return fmt.Sprintf("%s/%s/%s/%s", o.APIVersion, o.Kind, o.Namespace, o.Name)
+}
+
+// NewRunEvent maps a traced mutation of obj onto a RunEvent. The trace must not be empty;
+// its last hop is the mutation itself. Parent objects are assumed to live in obj's namespace,
+// as namespaced owners must.
+func NewRunEvent(jobNamespace, datasetNamespace string, obj Object, lifecycle string, t v1alpha1.Trace) *RunEvent {
+	hop := t[len(t)-1]
+	event := &RunEvent{
+		EventType: "COMPLETE",
+		EventTime: hop.Timestamp.UTC(),
+		Producer:  Producer,
+		SchemaURL: RunEventSchemaURL,
+		Run: Run{
+			RunID: runID(hop),
+			Facets: RunFacets{
+				KausalityTrace: &TraceFacet{Facet: Facet{Producer: Producer, SchemaURL: TraceFacetSchemaURL}, Trace: t},
+			},
+		},
+		Job:    Job{Namespace: jobNamespace, Name: hop.User},
+		Inputs: []Dataset{},
+		Outputs: []Dataset{{
+			Namespace: datasetNamespace,
+			Name:      obj.datasetName(),
+			Facets: &DatasetFacets{LifecycleStateChange: &LifecycleStateChangeFacet{
+				Facet:                Facet{Producer: Producer, SchemaURL: lifecycleFacetSchemaURL},
+				LifecycleStateChange: lifecycle,
+			}},
+		}},
+	}
+
+	if len(t) > 1 {
+		origin := t[0]
+		event.Run.Facets.Parent = &ParentRunFacet{
+			Facet: Facet{Producer: Producer, SchemaURL: parentRunFacetSchemaURL},
+			Run:   ParentRun{RunID: runID(origin)},
+			Job:   Job{Namespace: jobNamespace, Name: origin.User},
+		}
+		prev := t[len(t)-2]
+		event.Inputs = append(event.Inputs, Dataset{
+			Namespace: datasetNamespace,
+			Name:      Object{APIVersion: prev.APIVersion, Kind: prev.Kind, Namespace: obj.Namespace, Name: prev.Name}.datasetName(),
+		})
+	}
+	return event
+}
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// runID returns the hop's request UID if it is a UUID, as OpenLineage requires.
+// Otherwise a stable UUID is derived from the request UID, or from the hop if it has none.
+func runID(hop v1alpha1.Hop) string {
+	if uuidPattern.MatchString(hop.RequestUID) {
+		return hop.RequestUID
+	}
+	seed := hop.RequestUID
+	if seed == "" {
+		seed = fmt.Sprintf("%s/%s/%s/%d/%s/%s", hop.APIVersion, hop.Kind, hop.Name, hop.Generation, hop.User, hop.Timestamp.UTC().Format(time.RFC3339Nano))
+	}
+	sum := sha256.Sum256([]byte(seed))
+	sum[6] = (sum[6] & 0x0f) | 0x50 // version 5 layout
+	sum[8] = (sum[8] & 0x3f) | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16])
+}
@@ -0,0 +1,110 @@
+package lineage
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kausality-io/kausality/api/v1alpha1"
+)
+
+func TestNewRunEvent(t *testing.T) {
+	at := metav1.NewTime(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+	origin := v1alpha1.Hop{APIVersion: "apps/v1", Kind: "Deployment", Name: "web", User: "alice@example.com",
+		RequestUID: "0b8e6f3a-7c1d-4e2f-9a5b-1c2d3e4f5a6b", Timestamp: at}
+	hop := v1alpha1.Hop{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "web-abc", User: "system:serviceaccount:kube-system:deployment-controller",
+		RequestUID: "req-2", Timestamp: metav1.NewTime(at.Add(time.Second))}
+	obj := Object{APIVersion: "apps/v1", Kind: "ReplicaSet", Namespace: "prod", Name: "web-abc"}
+
+	t.Run("origin", func(t *testing.T) {
+		event := NewRunEvent("kausality", "kubernetes", Object{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "prod", Name: "web"},
+			LifecycleAlter, v1alpha1.Trace{origin})
+
+		assert.Equal(t, "COMPLETE", event.EventType)
+		assert.Equal(t, RunEventSchemaURL, event.SchemaURL)
+		assert.Equal(t, origin.RequestUID, event.Run.RunID)
+		assert.Nil(t, event.Run.Facets.Parent)
+		assert.Equal(t, Job{Namespace: "kausality", Name: "alice@example.com"}, event.Job)
+		assert.Empty(t, event.Inputs)
+		require.Len(t, event.Outputs, 1)
+		assert.Equal(t, "apps/v1/Deployment/prod/web", event.Outputs[0].Name)
+		assert.Equal(t, LifecycleAlter, event.Outputs[0].Facets.LifecycleStateChange.LifecycleStateChange)
+	})
+
+	t.Run("controller hop", func(t *testing.T) {
+		event := NewRunEvent("kausality", "kubernetes", obj, LifecycleCreate, v1alpha1.Trace{origin, hop})
+
+		assert.True(t, at.Add(time.Second).Equal(event.EventTime))
+		assert.Regexp(t, uuidPattern, event.Run.RunID, "non-UUID request UIDs are mapped to UUIDs")
+		require.NotNil(t, event.Run.Facets.Parent)
+		assert.Equal(t, origin.RequestUID, event.Run.Facets.Parent.Run.RunID)
+		assert.Equal(t, Job{Namespace: "kausality", Name: "alice@example.com"}, event.Run.Facets.Parent.Job)
+		assert.Equal(t, v1alpha1.Trace{origin, hop}, event.Run.Facets.KausalityTrace.Trace)
+		assert.Equal(t, hop.User, event.Job.Name)
+		assert.Equal(t, []Dataset{{Namespace: "kubernetes", Name: "apps/v1/Deployment/prod/web"}}, event.Inputs)
+		assert.Equal(t, "apps/v1/ReplicaSet/prod/web-abc", event.Outputs[0].Name)
+	})
+
+	t.Run("cluster-scoped object", func(t *testing.T) {
+		event := NewRunEvent("kausality", "kubernetes", Object{APIVersion: "v1", Kind: "Namespace", Name: "prod"},
+			LifecycleDrop, v1alpha1.Trace{origin})
+		assert.Equal(t, "v1/Namespace/prod", event.Outputs[0].Name)
+	})
+}
+
+func TestRunID_Stable(t *testing.T) {
+	hop := v1alpha1.Hop{APIVersion: "v1", Kind: "ConfigMap", Name: "cm", User: "bob", RequestUID: "not-a-uuid"}
+	assert.Equal(t, runID(hop), runID(hop))
+	assert.Regexp(t, uuidPattern, runID(hop))
+
+	other := hop
+	other.RequestUID = "also-not-a-uuid"
+	assert.NotEqual(t, runID(hop), runID(other))
+}
+
+func TestExporter_Emit(t *testing.T) {
+	var got RunEvent
+	var auth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &got)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	exporter, err := NewExporter(ExporterConfig{URL: srv.URL, APIKey: "secret"})
+	require.NoError(t, err)
+
+	hop := v1alpha1.Hop{APIVersion: "v1", Kind: "ConfigMap", Name: "cm", User: "bob", RequestUID: "req-1"}
+	event := exporter.Event(Object{APIVersion: "v1", Kind: "ConfigMap", Namespace: "ns", Name: "cm"}, LifecycleCreate, v1alpha1.Trace{hop})
+	require.NoError(t, exporter.Emit(t.Context(), event))
+
+	assert.Equal(t, "Bearer secret", auth)
+	assert.Equal(t, event.Run.RunID, got.Run.RunID)
+	assert.Equal(t, Job{Namespace: "kausality", Name: "bob"}, got.Job)
+	assert.Equal(t, "v1/ConfigMap/ns/cm", got.Outputs[0].Name)
+	assert.Equal(t, "kubernetes", got.Outputs[0].Namespace)
+}
+
+func TestExporter_EmitError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "bad event", http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	exporter, err := NewExporter(ExporterConfig{URL: srv.URL})
+	require.NoError(t, err)
+
+	hop := v1alpha1.Hop{APIVersion: "v1", Kind: "ConfigMap", Name: "cm", User: "bob"}
+	err = exporter.Emit(t.Context(), exporter.Event(Object{APIVersion: "v1", Kind: "ConfigMap", Name: "cm"}, LifecycleCreate, v1alpha1.Trace{hop}))
+	assert.ErrorContains(t, err, "status 400")
+}
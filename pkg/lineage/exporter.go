@@ -0,0 +1,113 @@
+package lineage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/kausality-io/kausality/api/v1alpha1"
+)
+
+// ExporterConfig configures the Exporter.
+type ExporterConfig struct {
+	// URL is the OpenLineage endpoint, e.g. "http://marquez:5000/api/v1/lineage".
+	URL string
+	// APIKey is sent as bearer token if set.
+	APIKey string
+	// Timeout is the request timeout. Default is 10 seconds.
+	Timeout time.Duration
+	// JobNamespace is the OpenLineage namespace of jobs. Default is "kausality".
+	JobNamespace string
+	// DatasetNamespace is the OpenLineage namespace of datasets. Default is "kubernetes".
+	DatasetNamespace string
+	// Log is the logger. If nil, a noop logger is used.
+	Log logr.Logger
+}
+
+// Exporter posts OpenLineage run events for traced mutations.
+// A nil Exporter is valid and exports nothing.
+type Exporter struct {
+	config ExporterConfig
+	client *http.Client
+	log    logr.Logger
+}
+
+// NewExporter creates a new Exporter with the given configuration.
+func NewExporter(cfg ExporterConfig) (*Exporter, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("openlineage url must not be empty")
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	if cfg.JobNamespace == "" {
+		cfg.JobNamespace = "kausality"
+	}
+	if cfg.DatasetNamespace == "" {
+		cfg.DatasetNamespace = "kubernetes"
+	}
+
+	log := cfg.Log
+	if log.GetSink() == nil {
+		log = logr.Discard()
+	}
+
+	return &Exporter{
+		config: cfg,
+		client: &http.Client{Timeout: cfg.Timeout},
+		log:    log.WithName("openlineage"),
+	}, nil
+}
+
+// Event builds the run event for a traced mutation of obj. See NewRunEvent.
+func (e *Exporter) Event(obj Object, lifecycle string, t v1alpha1.Trace) *RunEvent {
+	return NewRunEvent(e.config.JobNamespace, e.config.DatasetNamespace, obj, lifecycle, t)
+}
+
+// Emit posts a run event to the OpenLineage endpoint.
+func (e *Exporter) Emit(ctx context.Context, event *RunEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal run event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if e.config.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+e.config.APIKey)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("openlineage endpoint returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+	return nil
+}
+
+// EmitAsync posts a run event in a goroutine; errors are logged, not returned.
+// Uses a background context since the admission request context may be canceled.
+func (e *Exporter) EmitAsync(event *RunEvent) {
+	if e == nil {
+		return
+	}
+	go func() {
+		if err := e.Emit(context.Background(), event); err != nil {
+			e.log.Error(err, "failed to export lineage event", "runId", event.Run.RunID, "job", event.Job.Name)
+		}
+	}()
+}
@@ -0,0 +1,118 @@
+// Package metrics defines the Prometheus metrics exported by kausality. They are
+// registered with the controller-runtime registry, which the webhook serves on its
+// metrics endpoint (--metrics-bind-address).
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const namespace = "kausality"
+
+// ParentFetchTimeouts counts admission requests that failed open because resolving
+// the parent timed out, by parent API group and kind.
+var ParentFetchTimeouts = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "parent_fetch_timeouts_total",
+	Help:      "Admission requests admitted without drift evaluation because resolving the parent timed out.",
+}, []string{"group", "kind"})
+
+// NamespaceReadFailures counts failed reads of namespace metadata during admission, by
+// reason: "forbidden" (missing RBAC), "notFound" or "error".
+var NamespaceReadFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "namespace_read_failures_total",
+	Help:      "Failed reads of namespace metadata for namespace selectors and annotations.",
+}, []string{"reason"})
+
+// CallbacksSuppressed counts drift reports not sent because callbacks are paused, by
+// report phase.
+var CallbacksSuppressed = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "callbacks_suppressed_total",
+	Help:      "Drift reports not sent because callbacks are paused.",
+}, []string{"phase"})
+
+// callbackLabels are the labels of drift report delivery metrics: the backend, i.e. the
+// host of a webhook URL or "kafka/<topic>", and the report phase.
+var callbackLabels = []string{"backend", "phase"}
+
+// CallbackSent counts successful drift report delivery attempts.
+var CallbackSent = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "callback_sent_total",
+	Help:      "Drift report delivery attempts that succeeded.",
+}, callbackLabels)
+
+// CallbackFailed counts failed drift report delivery attempts. Every failed attempt
+// counts, also those retried successfully later.
+var CallbackFailed = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "callback_failed_total",
+	Help:      "Drift report delivery attempts that failed, including those that were retried.",
+}, callbackLabels)
+
+// CallbackDuration observes the latency of drift report delivery attempts, by backend.
+var CallbackDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: namespace,
+	Name:      "callback_duration_seconds",
+	Help:      "Latency of drift report delivery attempts.",
+	Buckets:   prometheus.ExponentialBuckets(0.005, 2, 12),
+}, []string{"backend"})
+
+// CallbackInFlight is the number of drift reports being sent asynchronously to a
+// webhook backend, including their retries.
+var CallbackInFlight = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: namespace,
+	Name:      "callback_in_flight",
+	Help:      "Drift reports being sent asynchronously to a webhook backend.",
+}, []string{"backend"})
+
+// driftLabels are the labels of drift decision metrics: the child's API group and kind,
+// the effective mode ("log", "enforce", "warn" or "dryrun") and the parent's lifecycle phase.
+var driftLabels = []string{"group", "kind", "mode", "lifecycle_phase"}
+
+// DriftDetected counts admission requests classified as drift.
+var DriftDetected = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "drift_detected_total",
+	Help:      "Admission requests classified as drift.",
+}, driftLabels)
+
+// DriftAllowed counts drift admitted, e.g. approved, in log mode or by break-glass.
+var DriftAllowed = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "drift_allowed_total",
+	Help:      "Drift admitted, because it was approved, in log mode or by break-glass.",
+}, driftLabels)
+
+// DriftDenied counts drift denied, because it was rejected or unapproved in enforce mode.
+var DriftDenied = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "drift_denied_total",
+	Help:      "Drift denied, because it was rejected or unapproved in enforce mode.",
+}, driftLabels)
+
+// FreezeBlocked counts mutations denied because the parent is frozen. The mode label
+// is empty, as a freeze blocks in any mode.
+var FreezeBlocked = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "freeze_blocked_total",
+	Help:      "Mutations denied because the parent is frozen.",
+}, driftLabels)
+
+// AdmissionDuration observes the latency of admission requests, by operation.
+var AdmissionDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: namespace,
+	Name:      "admission_duration_seconds",
+	Help:      "Latency of admission requests handled by the webhook.",
+	Buckets:   prometheus.ExponentialBuckets(0.001, 2, 12),
+}, []string{"operation"})
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(ParentFetchTimeouts, NamespaceReadFailures, CallbacksSuppressed,
+		CallbackSent, CallbackFailed, CallbackDuration, CallbackInFlight,
+		DriftDetected, DriftAllowed, DriftDenied, FreezeBlocked, AdmissionDuration)
+}
@@ -1,6 +1,8 @@
 package policy
 
 import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
 	kausalityv1alpha1 "github.com/kausality-io/kausality/api/v1alpha1"
 )
 
@@ -17,10 +19,32 @@ type Resolver interface {
 	IsTracked(ctx ResourceContext) bool
 }
 
-// StaticResolver provides a fixed mode for all resources.
+// ApprovalResolver resolves the auto-approvals policies declare for the children of
+// a parent. Resolvers implement it optionally.
+type ApprovalResolver interface {
+	// ResolveApprovals returns the auto-approvals of all policies matching the parent.
+	ResolveApprovals(parent ResourceContext) []kausalityv1alpha1.Approval
+}
+
+// StaticResolver provides a fixed mode for all resources, optionally overridden per
+// resource type and namespace by rules.
 // Useful for embedded apiservers that don't need dynamic policy configuration.
 type StaticResolver struct {
 	Mode kausalityv1alpha1.Mode
+	// Rules override Mode for matching resources. The first matching rule wins.
+	Rules []StaticRule
+}
+
+// StaticRule sets the mode for resources of a GVK in a namespace.
+type StaticRule struct {
+	// GVK of the resources. The zero value matches all resources; an empty Version
+	// matches all versions of Group and Kind.
+	GVK schema.GroupVersionKind
+	// Namespace of the resources. Empty matches all namespaces, including
+	// cluster-scoped resources.
+	Namespace string
+	// Mode for matching resources.
+	Mode kausalityv1alpha1.Mode
 }
 
 // NewStaticResolver creates a resolver that always returns the specified mode.
@@ -28,7 +52,26 @@ func NewStaticResolver(mode kausalityv1alpha1.Mode) *StaticResolver {
 	return &StaticResolver{Mode: mode}
 }
 
-// ResolveMode returns the configured static mode, unless overridden by annotations.
+// NewStaticResolverWithOverrides creates a resolver that returns the mode of the first
+// rule matching a resource, and mode for all others.
+func NewStaticResolverWithOverrides(mode kausalityv1alpha1.Mode, rules []StaticRule) *StaticResolver {
+	return &StaticResolver{Mode: mode, Rules: rules}
+}
+
+// Matches returns true if the rule applies to the given resource context.
+func (r StaticRule) Matches(ctx ResourceContext) bool {
+	if r.Namespace != "" && r.Namespace != ctx.Namespace {
+		return false
+	}
+	if r.GVK.Empty() {
+		return true
+	}
+	return r.GVK.Group == ctx.GVR.Group && r.GVK.Kind == ctx.Kind &&
+		(r.GVK.Version == "" || r.GVK.Version == ctx.GVR.Version)
+}
+
+// ResolveMode returns the mode of the first matching rule or the configured static mode,
+// unless overridden by annotations.
 func (r *StaticResolver) ResolveMode(ctx ResourceContext, objectAnnotations, namespaceAnnotations map[string]string) kausalityv1alpha1.Mode {
 	// Check object annotation
 	if mode := objectAnnotations[ModeAnnotation]; isValidMode(mode) {
@@ -40,6 +83,11 @@ func (r *StaticResolver) ResolveMode(ctx ResourceContext, objectAnnotations, nam
 		return kausalityv1alpha1.Mode(mode)
 	}
 
+	for _, rule := range r.Rules {
+		if rule.Matches(ctx) {
+			return rule.Mode
+		}
+	}
 	return r.Mode
 }
 
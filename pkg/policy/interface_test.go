@@ -0,0 +1,50 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	kausalityv1alpha1 "github.com/kausality-io/kausality/api/v1alpha1"
+)
+
+func TestStaticResolverWithOverrides(t *testing.T) {
+	r := NewStaticResolverWithOverrides(kausalityv1alpha1.ModeLog, []StaticRule{
+		{GVK: schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, Namespace: "staging", Mode: kausalityv1alpha1.ModeLog},
+		{GVK: schema.GroupVersionKind{Group: "apps", Kind: "Deployment"}, Mode: kausalityv1alpha1.ModeEnforce},
+		{Namespace: "production", Mode: kausalityv1alpha1.ModeEnforce},
+	})
+	deployments := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	configMaps := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+
+	tests := []struct {
+		name string
+		ctx  ResourceContext
+		want kausalityv1alpha1.Mode
+	}{
+		{name: "GVK match", ctx: ResourceContext{GVR: deployments, Kind: "Deployment", Namespace: "dev"}, want: kausalityv1alpha1.ModeEnforce},
+		{name: "GVK match in any version", ctx: ResourceContext{GVR: schema.GroupVersionResource{Group: "apps", Version: "v2", Resource: "deployments"}, Kind: "Deployment", Namespace: "dev"}, want: kausalityv1alpha1.ModeEnforce},
+		{name: "namespace match", ctx: ResourceContext{GVR: configMaps, Kind: "ConfigMap", Namespace: "production"}, want: kausalityv1alpha1.ModeEnforce},
+		{name: "combined match wins as first rule", ctx: ResourceContext{GVR: deployments, Kind: "Deployment", Namespace: "staging"}, want: kausalityv1alpha1.ModeLog},
+		{name: "combined rule needs the version", ctx: ResourceContext{GVR: schema.GroupVersionResource{Group: "apps", Version: "v2", Resource: "deployments"}, Kind: "Deployment", Namespace: "staging"}, want: kausalityv1alpha1.ModeEnforce},
+		{name: "no match falls through to default", ctx: ResourceContext{GVR: configMaps, Kind: "ConfigMap", Namespace: "dev"}, want: kausalityv1alpha1.ModeLog},
+		{name: "cluster-scoped falls through to default", ctx: ResourceContext{GVR: schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}, Kind: "Namespace"}, want: kausalityv1alpha1.ModeLog},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, r.ResolveMode(tt.ctx, nil, nil))
+		})
+	}
+
+	t.Run("annotations override rules", func(t *testing.T) {
+		ctx := ResourceContext{GVR: deployments, Kind: "Deployment", Namespace: "dev"}
+		assert.Equal(t, kausalityv1alpha1.ModeLog, r.ResolveMode(ctx, map[string]string{ModeAnnotation: "log"}, nil))
+		assert.Equal(t, kausalityv1alpha1.ModeLog, r.ResolveMode(ctx, nil, map[string]string{ModeAnnotation: "log"}))
+	})
+
+	t.Run("without rules", func(t *testing.T) {
+		assert.Equal(t, kausalityv1alpha1.ModeEnforce, NewStaticResolver(kausalityv1alpha1.ModeEnforce).ResolveMode(ResourceContext{GVR: configMaps, Kind: "ConfigMap"}, nil, nil))
+	})
+}
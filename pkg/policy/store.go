@@ -4,6 +4,7 @@ import (
 	"context"
 	"sort"
 	"sync"
+	"sync/atomic"
 
 	"github.com/go-logr/logr"
 
@@ -21,6 +22,7 @@ type Store struct {
 	log      logr.Logger
 	mu       sync.RWMutex
 	policies []kausalityv1alpha1.Kausality
+	ready    atomic.Bool
 }
 
 // NewStore creates a new policy store.
@@ -52,15 +54,37 @@ func (s *Store) Refresh(ctx context.Context) error {
 		return s.policies[i].Name < s.policies[j].Name
 	})
 
+	s.ready.Store(true)
 	s.log.V(1).Info("refreshed policies", "count", len(s.policies))
 	return nil
 }
 
+// IsReady reports whether the store has loaded the policies at least once. Until then,
+// modes are resolved as if there were no policies.
+func (s *Store) IsReady() bool {
+	return s.ready.Load()
+}
+
+// Snapshot returns a copy of the cached policies, in name order.
+func (s *Store) Snapshot() []kausalityv1alpha1.Kausality {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	policies := make([]kausalityv1alpha1.Kausality, len(s.policies))
+	for i := range s.policies {
+		s.policies[i].DeepCopyInto(&policies[i])
+	}
+	return policies
+}
+
 // ResourceContext provides context for mode resolution.
 type ResourceContext struct {
 	// GVR identifies the resource type.
 	GVR schema.GroupVersionResource
 
+	// Kind is the object's kind. Only StaticRules match on it.
+	Kind string
+
 	// Namespace is the object's namespace (empty for cluster-scoped).
 	Namespace string
 
@@ -117,6 +141,32 @@ func (s *Store) ResolveMode(ctx ResourceContext, objectAnnotations, namespaceAnn
 	return mode
 }
 
+// ResolveApprovals returns the auto-approvals of all policies matching the parent, in
+// policy name order. Auto-approvals with a mode other than always, generation or range
+// are skipped, as nothing would consume them.
+func (s *Store) ResolveApprovals(parent ResourceContext) []kausalityv1alpha1.Approval {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var approvals []kausalityv1alpha1.Approval
+	for i := range s.policies {
+		policy := &s.policies[i]
+		if len(policy.Spec.AutoApprovals) == 0 || !s.policyMatches(policy, parent) {
+			continue
+		}
+		for _, spec := range policy.Spec.AutoApprovals {
+			switch spec.Mode {
+			case kausalityv1alpha1.ApprovalModeAlways, kausalityv1alpha1.ApprovalModeGeneration, kausalityv1alpha1.ApprovalModeRange:
+			default:
+				s.log.V(1).Info("skipping auto-approval with unsupported mode", "policy", policy.Name, "mode", spec.Mode)
+				continue
+			}
+			approvals = append(approvals, spec.DeepCopy().Approval())
+		}
+	}
+	return approvals
+}
+
 // IsTracked returns true if the resource is tracked by any Kausality policy.
 func (s *Store) IsTracked(ctx ResourceContext) bool {
 	s.mu.RLock()
@@ -343,5 +393,9 @@ func (s *Store) overrideMatches(override kausalityv1alpha1.ModeOverride, ctx Res
 
 // isValidMode checks if a mode string is valid.
 func isValidMode(mode string) bool {
-	return mode == string(kausalityv1alpha1.ModeLog) || mode == string(kausalityv1alpha1.ModeEnforce)
+	switch kausalityv1alpha1.Mode(mode) {
+	case kausalityv1alpha1.ModeLog, kausalityv1alpha1.ModeEnforce, kausalityv1alpha1.ModeWarn, kausalityv1alpha1.ModeDryRun:
+		return true
+	}
+	return false
 }
@@ -379,7 +379,49 @@ func TestResolveMode_AnnotationPrecedence(t *testing.T) {
 	mode = s.ResolveMode(ctx, map[string]string{ModeAnnotation: "log"}, map[string]string{ModeAnnotation: "enforce"})
 	assert.Equal(t, kausalityv1alpha1.ModeLog, mode)
 
+	// warn and dryrun are valid annotation modes
+	mode = s.ResolveMode(ctx, map[string]string{ModeAnnotation: "warn"}, map[string]string{ModeAnnotation: "enforce"})
+	assert.Equal(t, kausalityv1alpha1.ModeWarn, mode)
+	mode = s.ResolveMode(ctx, nil, map[string]string{ModeAnnotation: "dryrun"})
+	assert.Equal(t, kausalityv1alpha1.ModeDryRun, mode)
+
 	// No annotations, no policies = default log
 	mode = s.ResolveMode(ctx, nil, nil)
 	assert.Equal(t, kausalityv1alpha1.ModeLog, mode)
 }
+
+func TestResolveApprovals(t *testing.T) {
+	autoApproval := func(name string, mode string) kausalityv1alpha1.ApprovalSpec {
+		return kausalityv1alpha1.ApprovalSpec{APIVersion: "v1", Kind: "ConfigMap", Name: name, Mode: mode}
+	}
+	policy := func(name string, group string, approvals ...kausalityv1alpha1.ApprovalSpec) kausalityv1alpha1.Kausality {
+		return kausalityv1alpha1.Kausality{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: kausalityv1alpha1.KausalitySpec{
+				Resources:     []kausalityv1alpha1.ResourceRule{{APIGroups: []string{group}, Resources: []string{"*"}}},
+				AutoApprovals: approvals,
+			},
+		}
+	}
+	s := &Store{policies: []kausalityv1alpha1.Kausality{
+		policy("a-apps", "apps", autoApproval("scaling", kausalityv1alpha1.ApprovalModeAlways), autoApproval("once", kausalityv1alpha1.ApprovalModeOnce)),
+		policy("b-apps", "apps", autoApproval("config", kausalityv1alpha1.ApprovalModeGeneration), autoApproval("rollout", kausalityv1alpha1.ApprovalModeRange)),
+		policy("c-batch", "batch", autoApproval("jobs", kausalityv1alpha1.ApprovalModeAlways)),
+		policy("d-apps-none", "apps"),
+	}}
+
+	approvals := s.ResolveApprovals(ResourceContext{
+		GVR:       schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"},
+		Namespace: "default",
+	})
+	var names []string
+	for _, a := range approvals {
+		names = append(names, a.Name)
+	}
+	// Union of matching policies in name order, without the once approval
+	assert.Equal(t, []string{"scaling", "config", "rollout"}, names)
+
+	assert.Empty(t, s.ResolveApprovals(ResourceContext{
+		GVR: schema.GroupVersionResource{Group: "", Version: "v1", Resource: "configmaps"},
+	}))
+}
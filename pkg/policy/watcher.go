@@ -2,6 +2,7 @@ package policy
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-logr/logr"
 
@@ -60,15 +61,66 @@ func (r *WatcherReconciler) Reconcile(ctx context.Context, req reconcile.Request
 	return r.Watcher.Reconcile(ctx, req)
 }
 
-// SetupWithManager registers the watcher with the controller manager.
+// SetupWatcher registers the watcher with the controller manager. The store becomes
+// ready once the manager's cache has synced and the policies are loaded from it, even
+// if there are no policies to reconcile.
 func SetupWatcher(mgr ctrl.Manager, store *Store, log logr.Logger) error {
 	w := &WatcherReconciler{
 		Watcher: NewWatcher(mgr.GetClient(), store, log),
 	}
-	return ctrl.NewControllerManagedBy(mgr).
+	if err := ctrl.NewControllerManagedBy(mgr).
 		Named("policy-watcher").
 		For(&kausalityv1alpha1.Kausality{}).
-		Complete(w)
+		Complete(w); err != nil {
+		return err
+	}
+	return mgr.Add(newInitialSync(mgr.GetCache(), store, log))
+}
+
+// cacheSyncer waits for an informer cache to sync.
+type cacheSyncer interface {
+	WaitForCacheSync(ctx context.Context) bool
+}
+
+// initialSyncRetryInterval is the interval between attempts of the initial refresh.
+const initialSyncRetryInterval = time.Second
+
+// initialSync loads the policies into the store once the cache has synced.
+type initialSync struct {
+	cache cacheSyncer
+	store *Store
+	log   logr.Logger
+}
+
+func newInitialSync(cache cacheSyncer, store *Store, log logr.Logger) *initialSync {
+	return &initialSync{cache: cache, store: store, log: log.WithName("policy-watcher")}
+}
+
+// Start refreshes the store once the cache has synced, retrying until it succeeds or
+// ctx is done. It implements manager.Runnable.
+func (i *initialSync) Start(ctx context.Context) error {
+	if !i.cache.WaitForCacheSync(ctx) {
+		return nil
+	}
+	for {
+		err := i.store.Refresh(ctx)
+		if err == nil {
+			i.log.Info("policy store ready")
+			return nil
+		}
+		i.log.Error(err, "failed to load policies, retrying")
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(initialSyncRetryInterval):
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. Every replica serves
+// admission requests and needs its own store.
+func (i *initialSync) NeedLeaderElection() bool {
+	return false
 }
 
 // InMemoryStore provides a way to manually trigger updates for testing.
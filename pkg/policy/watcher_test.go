@@ -0,0 +1,58 @@
+package policy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kausalityv1alpha1 "github.com/kausality-io/kausality/api/v1alpha1"
+)
+
+// fakeCache reports a sync once synced is closed.
+type fakeCache struct {
+	synced chan struct{}
+}
+
+func (c *fakeCache) WaitForCacheSync(ctx context.Context) bool {
+	select {
+	case <-c.synced:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func TestInitialSync_ReadyAfterCacheSync(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, kausalityv1alpha1.AddToScheme(scheme))
+	store := NewStore(fake.NewClientBuilder().WithScheme(scheme).Build(), logr.Discard())
+	cache := &fakeCache{synced: make(chan struct{})}
+
+	done := make(chan error, 1)
+	go func() { done <- newInitialSync(cache, store, logr.Discard()).Start(t.Context()) }()
+
+	// Not ready while the cache syncs
+	time.Sleep(50 * time.Millisecond)
+	assert.False(t, store.IsReady())
+
+	// Ready once synced, without any policy to reconcile
+	close(cache.synced)
+	require.NoError(t, <-done)
+	assert.True(t, store.IsReady())
+}
+
+func TestInitialSync_CacheNeverSyncs(t *testing.T) {
+	store := NewStore(fake.NewClientBuilder().Build(), logr.Discard())
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	require.NoError(t, newInitialSync(&fakeCache{synced: make(chan struct{})}, store, logr.Discard()).Start(ctx))
+	assert.False(t, store.IsReady())
+}
@@ -0,0 +1,97 @@
+package testing
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/kausality-io/kausality/pkg/callback/v1alpha1"
+)
+
+// FakeSender is an in-memory callback.ReportSender that records drift reports
+// instead of sending them, so tests can assert on reports without an HTTP server.
+// The zero value is enabled and ready to use. Disabled and Err must be set before use.
+type FakeSender struct {
+	// Disabled makes IsEnabled return false.
+	Disabled bool
+	// Err, if set, fails every send: Send returns it and reports are recorded
+	// as failed instead of sent.
+	Err error
+
+	mu       sync.Mutex
+	sent     []*v1alpha1.DriftReport
+	failed   []*v1alpha1.DriftReport
+	resolved []string
+}
+
+// Send records a copy of the report synchronously, returning Err if set. Like a
+// real sender serializing the report, later changes by the caller are not recorded.
+func (s *FakeSender) Send(_ context.Context, report *v1alpha1.DriftReport) error {
+	report = copyReport(report)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		s.failed = append(s.failed, report)
+		return s.Err
+	}
+	s.sent = append(s.sent, report)
+	return nil
+}
+
+// SendAsync records the report. Like the real senders, failures are not returned.
+func (s *FakeSender) SendAsync(ctx context.Context, report *v1alpha1.DriftReport) {
+	_ = s.Send(ctx, report)
+}
+
+// IsEnabled returns whether the sender is enabled.
+func (s *FakeSender) IsEnabled() bool {
+	return !s.Disabled
+}
+
+// MarkResolved records the resolved drift ID.
+func (s *FakeSender) MarkResolved(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.resolved = append(s.resolved, id)
+}
+
+// StartCleanup does nothing and returns a no-op stop function.
+func (s *FakeSender) StartCleanup(time.Duration) func() {
+	return func() {}
+}
+
+// copyReport deep-copies report through its wire encoding. A report that cannot be
+// encoded is recorded as is.
+func copyReport(report *v1alpha1.DriftReport) *v1alpha1.DriftReport {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return report
+	}
+	copied := &v1alpha1.DriftReport{}
+	if err := json.Unmarshal(data, copied); err != nil {
+		return report
+	}
+	return copied
+}
+
+// Sent returns the reports sent so far, in order.
+func (s *FakeSender) Sent() []*v1alpha1.DriftReport {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*v1alpha1.DriftReport(nil), s.sent...)
+}
+
+// Failed returns the reports that failed with Err so far, in order.
+func (s *FakeSender) Failed() []*v1alpha1.DriftReport {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*v1alpha1.DriftReport(nil), s.failed...)
+}
+
+// Resolved returns the drift IDs marked resolved so far, in order.
+func (s *FakeSender) Resolved() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.resolved...)
+}
@@ -0,0 +1,46 @@
+package testing_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kausality-io/kausality/pkg/callback"
+	"github.com/kausality-io/kausality/pkg/callback/v1alpha1"
+	ktesting "github.com/kausality-io/kausality/pkg/testing"
+)
+
+var _ callback.ReportSender = &ktesting.FakeSender{}
+
+func TestFakeSender(t *testing.T) {
+	s := &ktesting.FakeSender{}
+	assert.True(t, s.IsEnabled())
+
+	first := &v1alpha1.DriftReport{Spec: v1alpha1.DriftReportSpec{ID: "a"}}
+	second := &v1alpha1.DriftReport{Spec: v1alpha1.DriftReportSpec{ID: "b"}}
+	s.SendAsync(t.Context(), first)
+	assert.NoError(t, s.Send(t.Context(), second))
+	s.MarkResolved("a")
+
+	assert.Equal(t, []*v1alpha1.DriftReport{first, second}, s.Sent())
+	assert.Empty(t, s.Failed())
+	assert.Equal(t, []string{"a"}, s.Resolved())
+
+	// Reports are recorded as sent, not as changed afterwards
+	first.Spec.ID = "changed"
+	assert.Equal(t, "a", s.Sent()[0].Spec.ID)
+}
+
+func TestFakeSender_Err(t *testing.T) {
+	errUnavailable := errors.New("backend unavailable")
+	s := &ktesting.FakeSender{Err: errUnavailable, Disabled: true}
+	assert.False(t, s.IsEnabled())
+
+	report := &v1alpha1.DriftReport{Spec: v1alpha1.DriftReportSpec{ID: "a"}}
+	assert.ErrorIs(t, s.Send(t.Context(), report), errUnavailable)
+	s.SendAsync(t.Context(), report)
+
+	assert.Empty(t, s.Sent())
+	assert.Len(t, s.Failed(), 2)
+}
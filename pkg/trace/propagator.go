@@ -3,19 +3,35 @@ package trace
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/kausality-io/kausality/api/v1alpha1"
+	"github.com/kausality-io/kausality/pkg/config"
+	"github.com/kausality-io/kausality/pkg/controller"
 	"github.com/kausality-io/kausality/pkg/drift"
 )
 
+// DefaultMaxTraceHops is the default maximum number of hops of a trace.
+const DefaultMaxTraceHops = 32
+
+// minTraceHops is the smallest hop cap that fits the origin, the omission marker and
+// the most recent hop.
+const minTraceHops = 3
+
 // Propagator handles trace creation and propagation.
 type Propagator struct {
 	client   client.Client
 	resolver *drift.ParentResolver
+	maxAge   time.Duration
+	maxHops  int
+	keys     v1alpha1.AnnotationKeys
+	hash     controller.HashFunc
+	nowFunc  func() time.Time
 }
 
 // NewPropagator creates a new Propagator.
@@ -23,9 +39,68 @@ func NewPropagator(c client.Client) *Propagator {
 	return &Propagator{
 		client:   c,
 		resolver: drift.NewParentResolver(c),
+		maxHops:  DefaultMaxTraceHops,
+		keys:     v1alpha1.DefaultAnnotationKeys,
+		hash:     controller.HashUsername,
+		nowFunc:  time.Now,
+	}
+}
+
+// PropagatorOption configures a Propagator.
+type PropagatorOption func(*Propagator)
+
+// WithMaxAge prunes hops older than maxAge from extended traces.
+// The origin and the most recent hop are always kept. Zero disables pruning.
+func WithMaxAge(maxAge time.Duration) PropagatorOption {
+	return func(p *Propagator) {
+		p.maxAge = maxAge
 	}
 }
 
+// WithMaxTraceHops caps extended traces at maxHops hops. Beyond it, the oldest hops
+// after the origin are collapsed into one omission marker, keeping the origin and the
+// most recent hops. Zero keeps DefaultMaxTraceHops; caps below 3 are raised to 3.
+func WithMaxTraceHops(maxHops int) PropagatorOption {
+	return func(p *Propagator) {
+		if maxHops > 0 {
+			p.maxHops = max(maxHops, minTraceHops)
+		}
+	}
+}
+
+// WithParentReferences configures parent references for children without a controller
+// ownerReference.
+func WithParentReferences(refs []config.ParentReference) PropagatorOption {
+	return func(p *Propagator) {
+		p.resolver.SetParentReferences(refs)
+	}
+}
+
+// WithAnnotationKeys reads and writes traces in the annotations of keys rather than
+// the kausality.io/ ones.
+func WithAnnotationKeys(keys v1alpha1.AnnotationKeys) PropagatorOption {
+	return func(p *Propagator) {
+		p.keys = keys
+		p.resolver.SetAnnotationKeys(keys)
+	}
+}
+
+// WithHashFunc compares user identifiers hashed with f rather than controller.HashUsername.
+func WithHashFunc(f controller.HashFunc) PropagatorOption {
+	return func(p *Propagator) {
+		p.hash = f
+	}
+}
+
+// NewPropagatorWithOptions creates a new Propagator with options.
+func NewPropagatorWithOptions(c client.Client, opts ...PropagatorOption) *Propagator {
+	p := NewPropagator(c)
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
 // PropagationResult contains the result of trace propagation.
 type PropagationResult struct {
 	// Trace is the trace to set on the object.
@@ -45,57 +120,98 @@ func (p *Propagator) Propagate(ctx context.Context, obj client.Object, user stri
 	if err != nil {
 		return nil, fmt.Errorf("failed to resolve parent: %w", err)
 	}
+	return p.PropagateWithParent(ctx, obj, parentState, user, childUpdaters, requestUID)
+}
 
+// PropagateWithParent is Propagate for a parent already resolved for obj, e.g. by drift
+// detection of the same request. A nil parentState means obj has no parent.
+func (p *Propagator) PropagateWithParent(ctx context.Context, obj client.Object, parentState *drift.ParentState, user string, childUpdaters []string, requestUID string) (*PropagationResult, error) {
 	// Determine if this is an origin or a hop
-	isOrigin := p.isOrigin(parentState, user, childUpdaters)
+	if p.isOrigin(parentState, user, childUpdaters) {
+		return p.Origin(obj, user, requestUID), nil
+	}
+
+	// Get parent's trace
+	parentTrace, err := p.getParentTrace(ctx, parentState)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get parent trace: %w", err)
+	}
+
+	// If parent has no trace, synthesize one from parentState
+	if len(parentTrace) == 0 && parentState != nil {
+		parentHop := NewHop(
+			parentState.Ref.APIVersion,
+			parentState.Ref.Kind,
+			parentState.Ref.Name,
+			parentState.Generation,
+			"", // user unknown
+			"", // requestUID unknown
+		)
+		parentTrace = Trace{parentHop}
+	}
 
-	// Get GVK info
+	// Extend trace with new hop (each hop has its own labels, no inheritance)
+	return &PropagationResult{
+		Trace:       p.truncate(p.prune(parentTrace.Append(p.objectHop(obj, user, requestUID)))),
+		ParentTrace: parentTrace,
+	}, nil
+}
+
+// Origin starts a new trace at the mutated object, regardless of its parent, e.g. for
+// writes that are always user changes.
+func (p *Propagator) Origin(obj client.Object, user string, requestUID string) *PropagationResult {
+	return &PropagationResult{
+		Trace:    Trace{p.objectHop(obj, user, requestUID)},
+		IsOrigin: true,
+	}
+}
+
+// objectHop returns the hop of a mutation of obj, with the trace labels of its annotations.
+func (p *Propagator) objectHop(obj client.Object, user string, requestUID string) Hop {
 	gvk := obj.GetObjectKind().GroupVersionKind()
 	apiVersion := gvk.GroupVersion().String()
 	if apiVersion == "/" {
 		// Fallback for core types
 		apiVersion = "v1"
 	}
+	return NewHopWithLabels(apiVersion, gvk.Kind, obj.GetName(), obj.GetGeneration(), user, requestUID, v1alpha1.ExtractTraceLabelsWithPrefix(obj.GetAnnotations(), p.keys.TraceMetadataPrefix))
+}
 
-	result := &PropagationResult{
-		IsOrigin: isOrigin,
+// prune drops hops older than maxAge, keeping the origin and the most recent hop.
+// Hops without a timestamp are kept.
+func (p *Propagator) prune(t Trace) Trace {
+	if p.maxAge <= 0 || len(t) <= 2 {
+		return t
 	}
-
-	// Extract trace labels from this object's annotations
-	labels := ExtractTraceLabels(obj.GetAnnotations())
-
-	if isOrigin {
-		// Create new trace starting with this object
-		result.Trace = Trace{
-			NewHopWithLabels(apiVersion, gvk.Kind, obj.GetName(), obj.GetGeneration(), user, requestUID, labels),
-		}
-	} else {
-		// Get parent's trace
-		parentTrace, err := p.getParentTrace(ctx, parentState)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get parent trace: %w", err)
+	cutoff := p.nowFunc().Add(-p.maxAge)
+	pruned := Trace{t[0]}
+	for _, hop := range t[1 : len(t)-1] {
+		if hop.Timestamp.IsZero() || !hop.Timestamp.Time.Before(cutoff) {
+			pruned = append(pruned, hop)
 		}
+	}
+	return append(pruned, t[len(t)-1])
+}
 
-		// If parent has no trace, synthesize one from parentState
-		if len(parentTrace) == 0 && parentState != nil {
-			parentHop := NewHop(
-				parentState.Ref.APIVersion,
-				parentState.Ref.Kind,
-				parentState.Ref.Name,
-				parentState.Generation,
-				"", // user unknown
-				"", // requestUID unknown
-			)
-			parentTrace = Trace{parentHop}
+// truncate collapses the oldest hops after the origin into one omission marker if the
+// trace is longer than maxHops, keeping the origin and the most recent hops. Markers
+// among the collapsed hops are merged, so the count covers all hops ever omitted.
+func (p *Propagator) truncate(t Trace) Trace {
+	if p.maxHops <= 0 || len(t) <= p.maxHops {
+		return t
+	}
+	keep := p.maxHops - 2
+	omitted := 0
+	for _, hop := range t[1 : len(t)-keep] {
+		if hop.IsOmitted() {
+			omitted += hop.Omitted
+		} else {
+			omitted++
 		}
-		result.ParentTrace = parentTrace
-
-		// Extend trace with new hop (each hop has its own labels, no inheritance)
-		hop := NewHopWithLabels(apiVersion, gvk.Kind, obj.GetName(), obj.GetGeneration(), user, requestUID, labels)
-		result.Trace = parentTrace.Append(hop)
 	}
-
-	return result, nil
+	truncated := make(Trace, 0, p.maxHops)
+	truncated = append(truncated, t[0], NewOmittedHop(omitted))
+	return append(truncated, t[len(t)-keep:]...)
 }
 
 // isOrigin determines if this mutation starts a new trace.
@@ -115,7 +231,7 @@ func (p *Propagator) isOrigin(parentState *drift.ParentState, username string, c
 	}
 
 	// Check if request is from the controller using user hash tracking
-	isController, canDetermine := drift.IsControllerByHash(parentState, username, childUpdaters)
+	isController, canDetermine := drift.IsControllerByHashWithFunc(parentState, username, childUpdaters, p.hash)
 	if canDetermine && !isController {
 		// Different actor = origin (even if parent is reconciling)
 		return true
@@ -130,6 +246,9 @@ func (p *Propagator) getParentTrace(ctx context.Context, parentState *drift.Pare
 	if parentState == nil {
 		return nil, nil
 	}
+	if parentState.Object != nil {
+		return getTraceFromObject(parentState.Object, p.keys.Trace)
+	}
 
 	// Fetch the parent object
 	gv, err := schema.ParseGroupVersion(parentState.Ref.APIVersion)
@@ -149,17 +268,22 @@ func (p *Propagator) getParentTrace(ctx context.Context, parentState *drift.Pare
 		return nil, fmt.Errorf("failed to get parent: %w", err)
 	}
 
-	return GetTraceFromObject(parent)
+	return getTraceFromObject(parent, p.keys.Trace)
 }
 
 // GetTraceFromObject extracts the trace from an object's annotations.
 func GetTraceFromObject(obj client.Object) (Trace, error) {
+	return getTraceFromObject(obj, TraceAnnotation)
+}
+
+// getTraceFromObject extracts the trace from the annotation key of obj.
+func getTraceFromObject(obj client.Object, key string) (Trace, error) {
 	annotations := obj.GetAnnotations()
 	if annotations == nil {
 		return nil, nil
 	}
 
-	traceStr, ok := annotations[TraceAnnotation]
+	traceStr, ok := annotations[key]
 	if !ok || traceStr == "" {
 		return nil, nil
 	}
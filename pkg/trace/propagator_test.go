@@ -1,9 +1,18 @@
 package trace
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/kausality-io/kausality/pkg/controller"
 	"github.com/kausality-io/kausality/pkg/drift"
@@ -15,7 +24,7 @@ func TestPropagator_isOrigin(t *testing.T) {
 	otherUser := "admin@example.com"
 	controllerHash := controller.HashUsername(controllerUser)
 
-	p := &Propagator{} // client not needed for isOrigin
+	p := &Propagator{hash: controller.HashUsername} // client not needed for isOrigin
 
 	tests := []struct {
 		name          string
@@ -80,3 +89,205 @@ func TestPropagator_isOrigin(t *testing.T) {
 		})
 	}
 }
+
+func TestPropagator_prune(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	hop := func(name string, age time.Duration) Hop {
+		return Hop{APIVersion: "v1", Kind: "ConfigMap", Name: name, Timestamp: metav1.NewTime(now.Add(-age))}
+	}
+	names := func(t Trace) []string {
+		var result []string
+		for _, h := range t {
+			result = append(result, h.Name)
+		}
+		return result
+	}
+	const day = 24 * time.Hour
+
+	tests := []struct {
+		name   string
+		maxAge time.Duration
+		trace  Trace
+		want   []string
+	}{
+		{
+			name:  "disabled keeps all hops",
+			trace: Trace{hop("origin", 400*day), hop("a", 200*day), hop("b", 0)},
+			want:  []string{"origin", "a", "b"},
+		},
+		{
+			name:   "aged middle hops are dropped",
+			maxAge: 90 * day,
+			trace:  Trace{hop("origin", 400*day), hop("a", 200*day), hop("b", 30*day), hop("c", 100*day), hop("d", 0)},
+			want:   []string{"origin", "b", "d"},
+		},
+		{
+			name:   "origin and most recent hop are always kept",
+			maxAge: day,
+			trace:  Trace{hop("origin", 400*day), hop("a", 200*day), hop("last", 100*day)},
+			want:   []string{"origin", "last"},
+		},
+		{
+			name:   "hops without timestamp are kept",
+			maxAge: day,
+			trace:  Trace{hop("origin", 400*day), {Name: "untimed"}, hop("last", 0)},
+			want:   []string{"origin", "untimed", "last"},
+		},
+		{
+			name:   "two hops are never pruned",
+			maxAge: day,
+			trace:  Trace{hop("origin", 400*day), hop("last", 100*day)},
+			want:   []string{"origin", "last"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Propagator{maxAge: tt.maxAge, nowFunc: func() time.Time { return now }}
+			assert.Equal(t, tt.want, names(p.prune(tt.trace)))
+		})
+	}
+}
+
+func TestPropagator_truncate(t *testing.T) {
+	chain := func(n int) Trace {
+		var t Trace
+		for i := range n {
+			t = append(t, Hop{APIVersion: "v1", Kind: "ConfigMap", Name: fmt.Sprintf("h%d", i)})
+		}
+		return t
+	}
+	names := func(t Trace) []string {
+		var result []string
+		for _, h := range t {
+			result = append(result, h.Name)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name        string
+		maxHops     int
+		trace       Trace
+		want        []string
+		wantOmitted int
+	}{
+		{
+			name:    "below cap is kept",
+			maxHops: 5,
+			trace:   chain(4),
+			want:    []string{"h0", "h1", "h2", "h3"},
+		},
+		{
+			name:    "exactly at cap is kept",
+			maxHops: 5,
+			trace:   chain(5),
+			want:    []string{"h0", "h1", "h2", "h3", "h4"},
+		},
+		{
+			name:        "one over cap collapses two hops",
+			maxHops:     5,
+			trace:       chain(6),
+			want:        []string{"h0", "(2 hops omitted)", "h3", "h4", "h5"},
+			wantOmitted: 2,
+		},
+		{
+			name:        "well over cap keeps origin and most recent hops",
+			maxHops:     5,
+			trace:       chain(100),
+			want:        []string{"h0", "(96 hops omitted)", "h97", "h98", "h99"},
+			wantOmitted: 96,
+		},
+		{
+			name:        "existing marker is merged",
+			maxHops:     4,
+			trace:       Trace{{Name: "h0"}, NewOmittedHop(10), {Name: "h11"}, {Name: "h12"}, {Name: "h13"}},
+			want:        []string{"h0", "(11 hops omitted)", "h12", "h13"},
+			wantOmitted: 11,
+		},
+		{
+			name:        "smallest cap keeps origin and last hop",
+			maxHops:     3,
+			trace:       chain(10),
+			want:        []string{"h0", "(8 hops omitted)", "h9"},
+			wantOmitted: 8,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Propagator{maxHops: tt.maxHops}
+			got := p.truncate(tt.trace)
+			assert.Equal(t, tt.want, names(got))
+			assert.LessOrEqual(t, len(got), tt.maxHops)
+			if tt.wantOmitted > 0 {
+				assert.Equal(t, tt.wantOmitted, got[1].Omitted)
+				assert.Equal(t, OmittedHopKind, got[1].Kind)
+			}
+
+			// The truncated trace round-trips through its annotation value
+			parsed, err := Parse(got.String())
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, names(parsed))
+		})
+	}
+}
+
+func TestWithMaxTraceHops(t *testing.T) {
+	assert.Equal(t, DefaultMaxTraceHops, NewPropagatorWithOptions(nil, WithMaxTraceHops(0)).maxHops)
+	assert.Equal(t, 3, NewPropagatorWithOptions(nil, WithMaxTraceHops(1)).maxHops)
+	assert.Equal(t, 10, NewPropagatorWithOptions(nil, WithMaxTraceHops(10)).maxHops)
+}
+
+func TestPropagator_PropagateWithMaxAge(t *testing.T) {
+	const controllerUser = "system:serviceaccount:kube-system:deployment-controller"
+	aged := metav1.NewTime(time.Now().Add(-48 * time.Hour))
+	parentTrace := Trace{
+		{APIVersion: "example.com/v1", Kind: "App", Name: "app", User: "alice", Timestamp: aged},
+		{APIVersion: "apps/v1", Kind: "Deployment", Name: "old-hop", Timestamp: aged},
+		{APIVersion: "apps/v1", Kind: "Deployment", Name: "parent", Timestamp: aged},
+	}
+
+	// The parent is reconciling and carries an aged trace
+	parent := &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "parent",
+			Namespace:   "default",
+			UID:         "parent-uid",
+			Generation:  2,
+			Annotations: map[string]string{TraceAnnotation: parentTrace.String()},
+		},
+		Status: appsv1.DeploymentStatus{ObservedGeneration: 1},
+	}
+	scheme := runtime.NewScheme()
+	require.NoError(t, appsv1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(parent).Build()
+
+	child := &appsv1.ReplicaSet{
+		TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "ReplicaSet"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "child",
+			Namespace: "default",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       "parent",
+				UID:        "parent-uid",
+				Controller: ptr.To(true),
+			}},
+		},
+	}
+
+	p := NewPropagatorWithOptions(c, WithMaxAge(24*time.Hour))
+	result, err := p.Propagate(t.Context(), child, controllerUser, nil, "req-1")
+	require.NoError(t, err)
+	require.False(t, result.IsOrigin)
+	assert.Len(t, result.ParentTrace, 3, "the parent trace is reported unpruned")
+
+	var got []string
+	for _, hop := range result.Trace {
+		got = append(got, hop.Name)
+	}
+	assert.Equal(t, []string{"app", "child"}, got)
+}
@@ -7,8 +7,10 @@ import (
 
 // Annotation keys - re-exported from api/v1alpha1.
 const (
-	TraceAnnotation     = v1alpha1.TraceAnnotation
-	TraceMetadataPrefix = v1alpha1.TraceMetadataPrefix
+	TraceAnnotation       = v1alpha1.TraceAnnotation
+	TraceMetadataPrefix   = v1alpha1.TraceMetadataPrefix
+	TraceParentAnnotation = v1alpha1.TraceParentAnnotation
+	OmittedHopKind        = v1alpha1.OmittedHopKind
 )
 
 // Types - re-exported from api/v1alpha1.
@@ -24,6 +26,9 @@ var Parse = v1alpha1.ParseTrace
 // NewHop creates a new Hop with the current timestamp.
 var NewHop = v1alpha1.NewHop
 
+// NewOmittedHop creates the marker hop standing in for omitted hops.
+var NewOmittedHop = v1alpha1.NewOmittedHop
+
 // NewHopWithLabels creates a new Hop with the current timestamp and custom labels.
 var NewHopWithLabels = v1alpha1.NewHopWithLabels
 
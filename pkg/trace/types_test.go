@@ -2,6 +2,7 @@ package trace
 
 import (
 	"encoding/json"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
@@ -86,6 +87,25 @@ func TestTrace_String(t *testing.T) {
 	assert.Equal(t, "Deployment", parsed[0].Kind)
 }
 
+func TestTrace_String_OmittedHop(t *testing.T) {
+	ts := metav1.Time{Time: time.Date(2026, 1, 24, 10, 30, 0, 0, time.UTC)}
+	trace := Trace{
+		{APIVersion: "example.com/v1", Kind: "XPlatform", Name: "platform", User: "hans@example.com", Timestamp: ts},
+		NewOmittedHop(7),
+		{APIVersion: "example.com/v1", Kind: "NopResource", Name: "nop", User: "controller", Timestamp: ts},
+	}
+
+	parsed, err := Parse(trace.String())
+	require.NoError(t, err)
+	assert.Empty(t, cmp.Diff(trace, parsed))
+	require.True(t, parsed[1].IsOmitted())
+	assert.Equal(t, 7, parsed[1].Omitted)
+	assert.Equal(t, OmittedHopKind, parsed[1].Kind)
+	assert.Equal(t, "(7 hops omitted)", parsed[1].Name)
+	assert.False(t, parsed[0].IsOmitted())
+	assert.NotContains(t, trace[:1].String(), "omitted", "regular hops carry no omitted field")
+}
+
 func TestTrace_Origin(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -287,3 +307,61 @@ func TestHopWithoutLabels_JSON(t *testing.T) {
 	// Verify labels field is omitted (omitempty)
 	assert.False(t, strings.Contains(string(data), "labels"), "JSON should not contain 'labels' field when empty")
 }
+
+func TestHopWithoutRequestInfo_JSON(t *testing.T) {
+	hop := Hop{
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Name:       "test",
+		Generation: 5,
+		User:       "user",
+	}
+
+	data, err := json.Marshal(hop)
+	require.NoError(t, err)
+
+	// Verify requestUID and timestamp are omitted when unset
+	assert.False(t, strings.Contains(string(data), "requestUID"), "JSON should not contain 'requestUID' field when empty")
+	assert.False(t, strings.Contains(string(data), "timestamp"), "JSON should not contain 'timestamp' field when zero")
+}
+
+func TestTrace_ToTraceParent(t *testing.T) {
+	traceParent := regexp.MustCompile(`^00-([0-9a-f]{32})-([0-9a-f]{16})-01$`)
+	origin := Hop{APIVersion: "apps/v1", Kind: "Deployment", Name: "prod", Generation: 5, User: "hans@example.com", RequestUID: "9D2B1A2E-6F3C-4C1E-9B7A-1F2E3D4C5B6A"}
+	child := Hop{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "prod-abc", Generation: 1, User: "controller", RequestUID: "0f6c2a1b-3d4e-4f50-8a9b-c0d1e2f3a4b5"}
+
+	assert.Empty(t, Trace(nil).ToTraceParent())
+
+	t.Run("trace-id is the origin request UID", func(t *testing.T) {
+		tp := Trace{origin, child}.ToTraceParent()
+		m := traceParent.FindStringSubmatch(tp)
+		require.NotNil(t, m, "invalid traceparent %q", tp)
+		assert.Equal(t, "9d2b1a2e6f3c4c1e9b7a1f2e3d4c5b6a", m[1])
+		assert.NotEqual(t, "0000000000000000", m[2])
+	})
+
+	t.Run("deterministic, shared trace-id, per-hop span-id", func(t *testing.T) {
+		originTP := Trace{origin}.ToTraceParent()
+		childTP := Trace{origin, child}.ToTraceParent()
+		assert.Equal(t, childTP, Trace{origin, child}.ToTraceParent())
+		assert.Equal(t, originTP[:35], childTP[:35], "same trace-id")
+		assert.NotEqual(t, originTP[36:52], childTP[36:52], "different span-id")
+
+		grandchild := child
+		grandchild.Kind, grandchild.Name = "Pod", "prod-abc-xyz"
+		withOmitted := Trace{origin, NewOmittedHop(3), grandchild}.ToTraceParent()
+		assert.Equal(t, originTP[:35], withOmitted[:35], "omitted hops keep the origin")
+	})
+
+	t.Run("hops without UUID request UIDs", func(t *testing.T) {
+		for _, uid := range []string{"", "req-1", "00000000-0000-0000-0000-000000000000", "zzzzzzzz-zzzz-zzzz-zzzz-zzzzzzzzzzzz"} {
+			o := origin
+			o.RequestUID = uid
+			tp := Trace{o}.ToTraceParent()
+			m := traceParent.FindStringSubmatch(tp)
+			require.NotNil(t, m, "invalid traceparent %q for %q", tp, uid)
+			assert.NotEqual(t, strings.Repeat("0", 32), m[1])
+			assert.Equal(t, tp, Trace{o}.ToTraceParent())
+		}
+	})
+}